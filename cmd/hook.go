@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	pull_model "gitea.dev/models/pull"
 	"gitea.dev/modules/git"
 	"gitea.dev/modules/git/gitcmd"
 	"gitea.dev/modules/log"
@@ -244,7 +245,8 @@ Gitea or set your environment appropriately.`, "")
 		// If the ref is a branch or tag, check if it's protected
 		// if supportProcReceive all ref should be checked because
 		// permission check was delayed
-		if supportProcReceive || refFullName.IsBranch() || refFullName.IsTag() {
+		// the refs of the merge queues are only written by Gitea, the pushes to them are rejected
+		if supportProcReceive || refFullName.IsBranch() || refFullName.IsTag() || strings.HasPrefix(refFullName.String(), pull_model.MergeQueueRefPrefix) {
 			oldCommitIDs[count] = oldCommitID
 			newCommitIDs[count] = newCommitID
 			refFullNames[count] = refFullName
@@ -29,20 +29,20 @@ func newMigrateStorageCommand() *cli.Command {
 	return &cli.Command{
 		Name:        "migrate-storage",
 		Usage:       "Migrate the storage",
-		Description: "Copies stored files from storage configured in app.ini to parameter-configured storage",
+		Description: "Copies stored files from storage configured in app.ini to parameter-configured storage, or re-encrypts them in place with --reencrypt",
 		Action:      runMigrateStorage,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "type",
 				Aliases: []string{"t"},
 				Value:   "",
-				Usage:   "Type of stored files to copy.  Allowed types: 'attachments', 'lfs', 'avatars', 'repo-avatars', 'repo-archivers', 'packages', 'actions-log', 'actions-artifacts'",
+				Usage:   "Type of stored files to copy.  Allowed types: 'attachments', 'lfs', 'avatars', 'repo-avatars', 'repo-archivers', 'packages', 'actions-log', 'actions-artifacts', 'actions-cache'",
 			},
 			&cli.StringFlag{
 				Name:    "storage",
 				Aliases: []string{"s"},
 				Value:   "",
-				Usage:   "New storage type: local (default), minio or azureblob",
+				Usage:   "New storage type: local (default), minio, azureblob or gcs",
 			},
 			&cli.StringFlag{
 				Name:    "path",
@@ -50,6 +50,10 @@ func newMigrateStorageCommand() *cli.Command {
 				Value:   "",
 				Usage:   "New storage placement if store is local (leave blank for default)",
 			},
+			&cli.BoolFlag{
+				Name:  "reencrypt",
+				Usage: "Encrypt the stored files in place with the current master key instead of copying them, the storage of the type must have the encryption enabled",
+			},
 			// Minio Storage special configurations
 			&cli.StringFlag{
 				Name:  "minio-endpoint",
@@ -125,6 +129,41 @@ func newMigrateStorageCommand() *cli.Command {
 				Value: "",
 				Usage: "Azure Blob storage base path",
 			},
+			// Google Cloud Storage special configurations
+			&cli.StringFlag{
+				Name:  "gcs-endpoint",
+				Value: "https://storage.googleapis.com",
+				Usage: "Google Cloud Storage endpoint",
+			},
+			&cli.StringFlag{
+				Name:  "gcs-bucket",
+				Value: "",
+				Usage: "Google Cloud Storage bucket",
+			},
+			&cli.StringFlag{
+				Name:  "gcs-base-path",
+				Value: "",
+				Usage: "Google Cloud Storage base path on the bucket",
+			},
+			&cli.StringFlag{
+				Name:  "gcs-project-id",
+				Value: "",
+				Usage: "Google Cloud Storage project to create the bucket",
+			},
+			&cli.StringFlag{
+				Name:  "gcs-location",
+				Value: "US",
+				Usage: "Google Cloud Storage location to create the bucket",
+			},
+			&cli.StringFlag{
+				Name:  "gcs-credentials-file",
+				Value: "",
+				Usage: "Google Cloud Storage credentials file (leave blank for the application default credentials)",
+			},
+			&cli.BoolFlag{
+				Name:  "gcs-anonymous",
+				Usage: "Send unauthenticated requests to the Google Cloud Storage endpoint",
+			},
 		},
 	}
 }
@@ -214,6 +253,55 @@ func migrateActionsArtifacts(ctx context.Context, dstStorage storage.ObjectStora
 	})
 }
 
+func migrateActionsCache(ctx context.Context, dstStorage storage.ObjectStorage) error {
+	return db.Iterate(ctx, nil, func(ctx context.Context, c *actions_model.ActionCache) error {
+		if c.Status != actions_model.CacheStatusComplete {
+			// entries being uploaded only have chunks, which are dropped with the entry if the upload is abandoned
+			return nil
+		}
+
+		_, err := storage.Copy(dstStorage, c.StoragePath, storage.ActionsCache, c.StoragePath)
+		if err != nil {
+			// ignore files that do not exist
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		return nil
+	})
+}
+
+func reencryptStorage(ctx context.Context, tp string) error {
+	storages := map[string]storage.ObjectStorage{
+		"attachments":       storage.Attachments,
+		"lfs":               storage.LFS,
+		"avatars":           storage.Avatars,
+		"repo-avatars":      storage.RepoAvatars,
+		"repo-archivers":    storage.RepoArchives,
+		"packages":          storage.Packages,
+		"actions-log":       storage.Actions,
+		"actions-artifacts": storage.ActionsArtifacts,
+		"actions-cache":     storage.ActionsCache,
+	}
+	objStorage, ok := storages[tp]
+	if !ok {
+		return fmt.Errorf("unsupported storage: %s", tp)
+	}
+	encryptedStorage, ok := objStorage.(*storage.EncryptedStorage)
+	if !ok {
+		return fmt.Errorf("the encryption isn't enabled for the %s storage", tp)
+	}
+
+	count, err := encryptedStorage.ReencryptObjects(ctx)
+	if err != nil {
+		return err
+	}
+	log.Info("%d %s files have successfully been re-encrypted.", count, tp)
+	return nil
+}
+
 func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 	if err := initDB(ctx); err != nil {
 		return err
@@ -234,6 +322,10 @@ func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
+	if cmd.Bool("reencrypt") {
+		return reencryptStorage(ctx, strings.ToLower(cmd.String("type")))
+	}
+
 	var dstStorage storage.ObjectStorage
 	var err error
 	switch strings.ToLower(cmd.String("storage")) {
@@ -279,6 +371,20 @@ func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 					BasePath:    cmd.String("azureblob-base-path"),
 				},
 			})
+	case string(setting.GCSStorageType):
+		dstStorage, err = storage.NewGCSStorage(
+			ctx,
+			&setting.Storage{
+				GCSConfig: setting.GCSStorageConfig{
+					Endpoint:        cmd.String("gcs-endpoint"),
+					Bucket:          cmd.String("gcs-bucket"),
+					BasePath:        cmd.String("gcs-base-path"),
+					ProjectID:       cmd.String("gcs-project-id"),
+					Location:        cmd.String("gcs-location"),
+					CredentialsFile: cmd.String("gcs-credentials-file"),
+					Anonymous:       cmd.Bool("gcs-anonymous"),
+				},
+			})
 	default:
 		return fmt.Errorf("unsupported storage type: %s", cmd.String("storage"))
 	}
@@ -295,6 +401,7 @@ func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 		"packages":          migratePackages,
 		"actions-log":       migrateActionsLog,
 		"actions-artifacts": migrateActionsArtifacts,
+		"actions-cache":     migrateActionsCache,
 	}
 
 	tp := strings.ToLower(cmd.String("type"))
@@ -422,6 +422,21 @@ func prepareMigrationTasks() []*migration {
 		newMigration(346, "Add license_path column to repo_license and backfill", v28.AddLicensePathToRepoLicense),
 		newMigration(347, "Add watch options", v28.AddWatchOptions),
 		newMigration(348, "Recreate email_hash table for SHA256 avatar hashes", v28.RecreateEmailHashTable),
+		newMigration(349, "Add merge queue", v28.AddMergeQueue),
+		newMigration(350, "Add audit_event table", v28.AddAuditEventTable),
+		newMigration(351, "Add action_cache table", v28.AddActionCacheTable),
+		newMigration(352, "Add project_automation table", v28.AddProjectAutomationTable),
+		newMigration(353, "Add project_field and project_issue_field_value tables", v28.AddProjectFieldTables),
+		newMigration(354, "Add project_view table", v28.AddProjectViewTable),
+		newMigration(355, "Add webhook retry and dead letter columns", v28.AddWebhookRetryColumns),
+		newMigration(356, "Add issue form data table", v28.AddIssueFormDataTable),
+		newMigration(357, "Add package upstream table", v28.AddPackageUpstreamTable),
+		newMigration(358, "Add quota tables", v28.AddQuotaTables),
+		newMigration(359, "Add push rule table", v28.AddPushRuleTable),
+		newMigration(360, "Add secret scanning alert table", v28.AddSecretScanningAlertTable),
+		newMigration(361, "Add code navigation tables", v28.AddCodeNavigationTables),
+		newMigration(362, "Add delivery_uuid column to hook_task table", v28.AddHookTaskDeliveryUUID),
+		newMigration(363, "Allow ID tokens in the customized actions maximum token permissions", v28.AllowIDTokenInMaxTokenPermissions),
 	}
 	return preparedMigrations
 }
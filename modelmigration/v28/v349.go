@@ -0,0 +1,47 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+// AddMergeQueue adds the merge queue settings to branch protections and the pull_merge_queue table
+func AddMergeQueue(_ context.Context, x base.EngineMigration) error {
+	type ProtectedBranch struct {
+		EnableMergeQueue       bool  `xorm:"NOT NULL DEFAULT false"`
+		MergeQueueMaxGroupSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreConstrains:  true,
+		IgnoreDropIndices: true,
+	}, new(ProtectedBranch)); err != nil {
+		return err
+	}
+
+	type PullMergeQueue struct {
+		ID                     int64  `xorm:"pk autoincr"`
+		RepoID                 int64  `xorm:"INDEX(repo_branch) NOT NULL"`
+		BaseBranch             string `xorm:"INDEX(repo_branch) NOT NULL"`
+		PullID                 int64  `xorm:"UNIQUE NOT NULL"`
+		DoerID                 int64  `xorm:"INDEX NOT NULL"`
+		MergeStyle             string `xorm:"varchar(30)"`
+		Message                string `xorm:"LONGTEXT"`
+		DeleteBranchAfterMerge bool
+		Status                 int                `xorm:"NOT NULL DEFAULT 0"`
+		BaseCommitID           string             `xorm:"VARCHAR(64)"`
+		MergeCommitID          string             `xorm:"VARCHAR(64)"`
+		FailReason             string             `xorm:"TEXT"`
+		CreatedUnix            timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix            timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(PullMergeQueue))
+}
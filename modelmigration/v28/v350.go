@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+// AddAuditEventTable adds the audit_event table holding the audit log
+func AddAuditEventTable(_ context.Context, x base.EngineMigration) error {
+	type AuditEvent struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Action      string             `xorm:"VARCHAR(64) INDEX NOT NULL"`
+		ActorID     int64              `xorm:"INDEX NOT NULL"`
+		ActorName   string             `xorm:"NOT NULL DEFAULT ''"`
+		IPAddress   string             `xorm:"VARCHAR(64)"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		TargetType  string             `xorm:"VARCHAR(32) NOT NULL"`
+		TargetID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		TargetName  string             `xorm:"NOT NULL DEFAULT ''"`
+		Before      string             `xorm:"LONGTEXT"`
+		After       string             `xorm:"LONGTEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+	}
+
+	return x.Sync(new(AuditEvent))
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+// AddActionCacheTable adds the action_cache table holding the entries of the actions cache server
+func AddActionCacheTable(_ context.Context, x base.EngineMigration) error {
+	type ActionCache struct {
+		ID           int64  `xorm:"pk autoincr"`
+		RepoID       int64  `xorm:"index(repo_scope) UNIQUE(repo_entry)"`
+		Scope        string `xorm:"VARCHAR(255) index(repo_scope)"`
+		CacheKey     string `xorm:"VARCHAR(512)"`
+		Version      string `xorm:"VARCHAR(255)"`
+		EntryHash    string `xorm:"VARCHAR(64) UNIQUE(repo_entry)"`
+		Size         int64
+		StoragePath  string
+		Status       int `xorm:"index"`
+		TaskID       int64
+		LastUsedUnix timeutil.TimeStamp `xorm:"index"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(ActionCache))
+}
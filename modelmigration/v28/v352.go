@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+// AddProjectAutomationTable adds the project_automation table holding the workflow rules of the project boards
+func AddProjectAutomationTable(_ context.Context, x base.EngineMigration) error {
+	type ProjectAutomation struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ProjectID   int64              `xorm:"INDEX NOT NULL"`
+		Event       uint8              `xorm:"NOT NULL"`
+		LabelID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		ColumnID    int64              `xorm:"INDEX NOT NULL"`
+		CreatorID   int64              `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(ProjectAutomation))
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+// AddProjectFieldTables adds the tables of the custom fields of the projects and of their values per issue
+func AddProjectFieldTables(_ context.Context, x base.EngineMigration) error {
+	type ProjectFieldOption struct {
+		ID        int64  `json:"id"`
+		Name      string `json:"name"`
+		StartDate string `json:"start_date,omitempty"`
+		EndDate   string `json:"end_date,omitempty"`
+	}
+
+	type ProjectField struct {
+		ID          int64                 `xorm:"pk autoincr"`
+		ProjectID   int64                 `xorm:"INDEX NOT NULL"`
+		Name        string                `xorm:"NOT NULL"`
+		Type        uint8                 `xorm:"NOT NULL"`
+		Options     []*ProjectFieldOption `xorm:"JSON TEXT"`
+		CreatorID   int64                 `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp    `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp    `xorm:"updated"`
+	}
+
+	type ProjectIssueFieldValue struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ProjectID   int64              `xorm:"INDEX NOT NULL"`
+		FieldID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+		IssueID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Value       string             `xorm:"VARCHAR(255) NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(ProjectField), new(ProjectIssueFieldValue))
+}
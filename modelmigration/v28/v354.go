@@ -0,0 +1,31 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+// AddProjectViewTable adds the table of the saved views of the projects
+func AddProjectViewTable(_ context.Context, x base.EngineMigration) error {
+	type ProjectView struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ProjectID   int64              `xorm:"INDEX NOT NULL"`
+		Name        string             `xorm:"NOT NULL"`
+		Layout      uint8              `xorm:"NOT NULL DEFAULT 1"`
+		Filter      string             `xorm:"TEXT"`
+		GroupBy     string             `xorm:"VARCHAR(50)"`
+		SortBy      string             `xorm:"VARCHAR(50)"`
+		SortDesc    bool               `xorm:"NOT NULL DEFAULT false"`
+		DateFieldID int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatorID   int64              `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(ProjectView))
+}
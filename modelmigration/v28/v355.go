@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+// AddWebhookRetryColumns adds the columns retrying the failed webhook deliveries and keeping the dead letters
+func AddWebhookRetryColumns(_ context.Context, x base.EngineMigration) error {
+	type HookTask struct {
+		Attempt      int                `xorm:"NOT NULL DEFAULT 1"`
+		RetryAfter   timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		IsDeadLetter bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	}
+	type Webhook struct {
+		FailureCount int `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(HookTask), new(Webhook))
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+// AddIssueFormDataTable adds the table storing the values submitted through issue forms
+func AddIssueFormDataTable(_ context.Context, x base.EngineMigration) error {
+	type IssueFormData struct {
+		ID           int64 `xorm:"pk autoincr"`
+		IssueID      int64 `xorm:"UNIQUE NOT NULL"`
+		TemplateFile string
+		Values       map[string]any     `xorm:"JSON TEXT"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(IssueFormData))
+}
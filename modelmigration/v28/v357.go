@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+// AddPackageUpstreamTable adds the table storing the upstream registries packages are pulled through from
+func AddPackageUpstreamTable(_ context.Context, x base.EngineMigration) error {
+	type PackageUpstream struct {
+		ID                int64              `xorm:"pk autoincr"`
+		Enabled           bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		OwnerID           int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		Type              string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		URL               string             `xorm:"TEXT NOT NULL"`
+		Username          string             `xorm:"NOT NULL DEFAULT ''"`
+		PasswordEncrypted string             `xorm:"TEXT"`
+		CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix       timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageUpstream))
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type quotaGroupV358 struct {
+	ID               int64              `xorm:"pk autoincr"`
+	Name             string             `xorm:"UNIQUE NOT NULL"`
+	LimitTotal       int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitGit         int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitLFS         int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitAttachments int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitArtifacts   int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitPackages    int64              `xorm:"NOT NULL DEFAULT -1"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix      timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+func (quotaGroupV358) TableName() string {
+	return "quota_group"
+}
+
+type quotaGroupOwnerV358 struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	OwnerID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func (quotaGroupOwnerV358) TableName() string {
+	return "quota_group_owner"
+}
+
+// AddQuotaTables adds the tables of the quota groups and their assignments to users and organizations
+func AddQuotaTables(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(quotaGroupV358), new(quotaGroupOwnerV358))
+}
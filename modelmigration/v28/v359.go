@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type pushRuleV359 struct {
+	ID                         int64              `xorm:"pk autoincr"`
+	OwnerID                    int64              `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	RepoID                     int64              `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	CommitMessagePattern       string             `xorm:"TEXT"`
+	RequireSignedOffBy         bool               `xorm:"NOT NULL DEFAULT false"`
+	RequireVerifiedAuthorEmail bool               `xorm:"NOT NULL DEFAULT false"`
+	MaxBlobSize                int64              `xorm:"NOT NULL DEFAULT 0"`
+	ForbiddenFilePatterns      string             `xorm:"TEXT"`
+	ForbiddenFileExtensions    string             `xorm:"TEXT"`
+	SignedCommitBranchPatterns string             `xorm:"TEXT"`
+	CreatedUnix                timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix                timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (pushRuleV359) TableName() string {
+	return "push_rule"
+}
+
+// AddPushRuleTable adds the table of the push rules of repositories and organizations
+func AddPushRuleTable(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(pushRuleV359))
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type secretScanningAlertV360 struct {
+	ID           int64  `xorm:"pk autoincr"`
+	RepoID       int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Fingerprint  string `xorm:"VARCHAR(64) UNIQUE(s) NOT NULL"`
+	RuleID       string `xorm:"VARCHAR(255) NOT NULL"`
+	Description  string
+	Redacted     string             `xorm:"NOT NULL DEFAULT ''"`
+	CommitID     string             `xorm:"VARCHAR(64) NOT NULL"`
+	Path         string             `xorm:"TEXT"`
+	Line         int                `xorm:"NOT NULL DEFAULT 0"`
+	Status       int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ResolverID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	ResolvedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+}
+
+func (secretScanningAlertV360) TableName() string {
+	return "secret_scanning_alert"
+}
+
+// AddSecretScanningAlertTable adds the table of the secrets found in repositories
+func AddSecretScanningAlertTable(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(secretScanningAlertV360))
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/timeutil"
+)
+
+type codeNavIndexV361 struct {
+	ID           int64              `xorm:"pk autoincr"`
+	RepoID       int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CommitID     string             `xorm:"VARCHAR(64) UNIQUE(s) NOT NULL"`
+	Root         string             `xorm:"VARCHAR(500) NOT NULL DEFAULT ''"`
+	ToolName     string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	ToolVersion  string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	IsReady      bool               `xorm:"NOT NULL DEFAULT false"`
+	NumDocuments int                `xorm:"NOT NULL DEFAULT 0"`
+	UploaderID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+}
+
+func (codeNavIndexV361) TableName() string {
+	return "code_nav_index"
+}
+
+type codeNavDocumentV361 struct {
+	ID               int64  `xorm:"pk autoincr"`
+	IndexID          int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Path             string `xorm:"VARCHAR(500) UNIQUE(s) NOT NULL"`
+	Language         string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	PositionEncoding int    `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func (codeNavDocumentV361) TableName() string {
+	return "code_nav_document"
+}
+
+type codeNavOccurrenceV361 struct {
+	ID             int64  `xorm:"pk autoincr"`
+	IndexID        int64  `xorm:"INDEX(symbol) NOT NULL"`
+	SymbolHash     string `xorm:"VARCHAR(64) INDEX(symbol) NOT NULL"`
+	DocumentID     int64  `xorm:"INDEX(position) NOT NULL"`
+	StartLine      int32  `xorm:"INDEX(position) NOT NULL"`
+	StartCharacter int32  `xorm:"NOT NULL"`
+	EndLine        int32  `xorm:"NOT NULL"`
+	EndCharacter   int32  `xorm:"NOT NULL"`
+	IsDefinition   bool   `xorm:"NOT NULL DEFAULT false"`
+}
+
+func (codeNavOccurrenceV361) TableName() string {
+	return "code_nav_occurrence"
+}
+
+type codeNavSymbolV361 struct {
+	ID            int64    `xorm:"pk autoincr"`
+	IndexID       int64    `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Hash          string   `xorm:"VARCHAR(64) UNIQUE(s) NOT NULL"`
+	Symbol        string   `xorm:"TEXT"`
+	DisplayName   string   `xorm:"TEXT"`
+	Documentation []string `xorm:"JSON TEXT"`
+}
+
+func (codeNavSymbolV361) TableName() string {
+	return "code_nav_symbol"
+}
+
+// AddCodeNavigationTables adds the tables of the SCIP indexes uploaded for code navigation
+func AddCodeNavigationTables(_ context.Context, x base.EngineMigration) error {
+	return x.Sync(new(codeNavIndexV361), new(codeNavDocumentV361), new(codeNavOccurrenceV361), new(codeNavSymbolV361))
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+)
+
+// AddHookTaskDeliveryUUID adds the delivery UUID kept by the retries of a webhook delivery
+func AddHookTaskDeliveryUUID(_ context.Context, x base.EngineMigration) error {
+	type HookTask struct {
+		DeliveryUUID string `xorm:"INDEX"`
+	}
+	if err := x.Sync(new(HookTask)); err != nil {
+		return err
+	}
+	_, err := x.Exec("UPDATE `hook_task` SET `delivery_uuid` = `uuid` WHERE `delivery_uuid` = '' OR `delivery_uuid` IS NULL")
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"context"
+
+	"gitea.dev/modelmigration/base"
+	"gitea.dev/modules/json"
+)
+
+// allowIDTokenInMaxTokenPermissions adds "id_token: true" to the customized maximum permissions of an actions config,
+// it reports whether the config has been changed
+func allowIDTokenInMaxTokenPermissions(config string) (string, bool, error) {
+	var cfg map[string]json.Value
+	if err := json.UnmarshalHandleDoubleEncode([]byte(config), &cfg); err != nil {
+		return "", false, err
+	}
+	var maxPerms map[string]json.Value
+	if raw, ok := cfg["max_token_permissions"]; !ok || string(raw) == "null" {
+		return "", false, nil
+	} else if err := json.Unmarshal(raw, &maxPerms); err != nil {
+		return "", false, err
+	}
+	if _, ok := maxPerms["id_token"]; ok {
+		return "", false, nil
+	}
+
+	maxPerms["id_token"] = json.Value("true")
+	raw, err := json.Marshal(maxPerms)
+	if err != nil {
+		return "", false, err
+	}
+	cfg["max_token_permissions"] = raw
+	bs, err := json.Marshal(cfg)
+	if err != nil {
+		return "", false, err
+	}
+	return string(bs), true, nil
+}
+
+// AllowIDTokenInMaxTokenPermissions keeps the ID tokens allowed by the actions maximum permissions customized before
+// the maximum permissions applied to them
+func AllowIDTokenInMaxTokenPermissions(_ context.Context, x base.EngineMigration) error {
+	sess := x.NewSession()
+	defer sess.Close()
+
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	type RepoUnit struct {
+		ID     int64 `xorm:"pk autoincr"`
+		Config string
+	}
+	var units []*RepoUnit
+	if err := sess.Table("repo_unit").Select("id, config").Where("`type` = ?", 10 /* unit.TypeActions */).Find(&units); err != nil {
+		return err
+	}
+	for _, u := range units {
+		config, changed, err := allowIDTokenInMaxTokenPermissions(u.Config)
+		if err != nil || !changed {
+			continue // an invalid config is ignored when loaded too
+		}
+		if _, err := sess.Table("repo_unit").ID(u.ID).Cols("config").Update(map[string]any{"config": config}); err != nil {
+			return err
+		}
+	}
+
+	type UserSetting struct {
+		ID           int64 `xorm:"pk autoincr"`
+		SettingValue string
+	}
+	var settings []*UserSetting
+	if err := sess.Table("user_setting").Select("id, setting_value").Where("setting_key = ?", "actions.config").Find(&settings); err != nil {
+		return err
+	}
+	for _, s := range settings {
+		value, changed, err := allowIDTokenInMaxTokenPermissions(s.SettingValue)
+		if err != nil || !changed {
+			continue
+		}
+		if _, err := sess.Table("user_setting").ID(s.ID).Cols("setting_value").Update(map[string]any{"setting_value": value}); err != nil {
+			return err
+		}
+	}
+
+	return sess.Commit()
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v28
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_allowIDTokenInMaxTokenPermissions(t *testing.T) {
+	config, changed, err := allowIDTokenInMaxTokenPermissions(`{"token_permission_mode":"restricted","max_token_permissions":{"unit_access_modes":{"1":1}}}`)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.JSONEq(t, `{"token_permission_mode":"restricted","max_token_permissions":{"unit_access_modes":{"1":1},"id_token":true}}`, config)
+
+	for _, unchanged := range []string{
+		`{"token_permission_mode":"restricted"}`,
+		`{"max_token_permissions":null}`,
+		`{"max_token_permissions":{"unit_access_modes":{"1":1},"id_token":true}}`,
+	} {
+		_, changed, err = allowIDTokenInMaxTokenPermissions(unchanged)
+		require.NoError(t, err)
+		assert.False(t, changed, unchanged)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// CacheStatus is the status of an entry of the cache server
+type CacheStatus int
+
+const (
+	CacheStatusReserved CacheStatus = iota + 1 // 1, the entry has been reserved and its content is being uploaded
+	CacheStatusComplete                        // 2, the content of the entry has been uploaded and it can be restored
+)
+
+// ActionCache is an entry of the cache server used by the "actions/cache" action.
+// Entries are immutable once complete, they are isolated by repository and by the git ref they have been saved from.
+type ActionCache struct {
+	ID       int64  `xorm:"pk autoincr"`
+	RepoID   int64  `xorm:"index(repo_scope) UNIQUE(repo_entry)"`
+	Scope    string `xorm:"VARCHAR(255) index(repo_scope)"` // the git ref the entry has been saved from
+	CacheKey string `xorm:"VARCHAR(512)"`
+	Version  string `xorm:"VARCHAR(255)"` // computed by the client from the cached paths and the compression method
+	// EntryHash is the digest of the scope, key and version, the columns themselves are too long to be indexed together
+	EntryHash string `xorm:"VARCHAR(64) UNIQUE(repo_entry)"`
+	// Size is the size announced when reserving the entry, then the actual size once complete
+	Size         int64
+	StoragePath  string
+	Status       CacheStatus        `xorm:"index"`
+	TaskID       int64              // the task which has saved the entry
+	LastUsedUnix timeutil.TimeStamp `xorm:"index"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionCache))
+}
+
+// MaxCacheKeyLength is the maximum length of the key of an entry
+const MaxCacheKeyLength = 512
+
+// ErrCacheAlreadyExists is returned when reserving an entry whose key and version are already in use in the scope
+var ErrCacheAlreadyExists = util.NewAlreadyExistErrorf("cache entry already exists")
+
+// cacheEntryHash returns the digest identifying an entry in its repository
+func cacheEntryHash(scope, key, version string) string {
+	h := sha256.New()
+	for _, s := range []string{scope, key, version} {
+		_, _ = h.Write([]byte(s))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ReserveCache creates a reserved entry, unless an entry with the same key and version already exists in the scope
+func ReserveCache(ctx context.Context, c *ActionCache) error {
+	if len(c.CacheKey) > MaxCacheKeyLength {
+		return util.ErrorWrap(util.ErrInvalidArgument, "cache key is longer than %d characters", MaxCacheKeyLength)
+	}
+
+	c.EntryHash = cacheEntryHash(c.Scope, c.CacheKey, c.Version)
+	c.Status = CacheStatusReserved
+	c.LastUsedUnix = timeutil.TimeStampNow()
+	if err := db.Insert(ctx, c); err != nil {
+		// the unique index rejects the entries which are reserved concurrently with the same key and version
+		if has, _ := db.GetEngine(ctx).Where(builder.Eq{"repo_id": c.RepoID, "entry_hash": c.EntryHash}).Exist(new(ActionCache)); has {
+			return ErrCacheAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetCacheByID returns an entry whatever its status
+func GetCacheByID(ctx context.Context, id int64) (*ActionCache, error) {
+	c, exist, err := db.GetByID[ActionCache](ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, fmt.Errorf("cache entry with id %d: %w", id, util.ErrNotExist)
+	}
+	return c, nil
+}
+
+// GetCacheByRepoAndID returns an entry of the repository whatever its status
+func GetCacheByRepoAndID(ctx context.Context, repoID, id int64) (*ActionCache, error) {
+	var c ActionCache
+	has, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Get(&c)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("cache entry with id %d: %w", id, util.ErrNotExist)
+	}
+	return &c, nil
+}
+
+// UpdateCache updates the given columns of an entry
+func UpdateCache(ctx context.Context, c *ActionCache, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols(cols...).Update(c)
+	return err
+}
+
+// DeleteCacheByID deletes an entry, its content has to be deleted from the storage by the caller
+func DeleteCacheByID(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[ActionCache](ctx, id)
+	return err
+}
+
+// FindCacheToRestore looks for the complete entry to restore in the given scopes, in order.
+// In each scope the first key is looked up as an exact match, then every key is looked up as a prefix,
+// the most recent entry winning among the ones matching the same prefix, like GitHub does.
+func FindCacheToRestore(ctx context.Context, repoID int64, scopes []string, version string, keys []string) (*ActionCache, error) {
+	if len(keys) == 0 {
+		return nil, util.ErrNotExist
+	}
+	for _, scope := range scopes {
+		cond := builder.Eq{
+			"repo_id": repoID,
+			"scope":   scope,
+			"version": version,
+			"status":  CacheStatusComplete,
+		}
+
+		var exact ActionCache
+		has, err := db.GetEngine(ctx).Where(cond).And(builder.Eq{"cache_key": keys[0]}).Desc("id").Get(&exact)
+		if err != nil {
+			return nil, err
+		} else if has {
+			return &exact, nil
+		}
+
+		for _, prefix := range keys {
+			var candidates []*ActionCache
+			// LIKE also treats "_" as a wildcard and keys may contain "%", so the prefix is checked again below
+			if err := db.GetEngine(ctx).Where(cond).And(builder.Like{"cache_key", prefix + "%"}).Desc("id").Find(&candidates); err != nil {
+				return nil, err
+			}
+			for _, c := range candidates {
+				if strings.HasPrefix(c.CacheKey, prefix) {
+					return c, nil
+				}
+			}
+		}
+	}
+	return nil, util.ErrNotExist
+}
+
+// FindCachesOptions represents the options to find entries of the cache server
+type FindCachesOptions struct {
+	db.ListOptions
+	RepoID         int64
+	Status         CacheStatus
+	CreatedBefore  timeutil.TimeStamp
+	LastUsedBefore timeutil.TimeStamp
+}
+
+func (opts FindCachesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Status > 0 {
+		cond = cond.And(builder.Eq{"status": opts.Status})
+	}
+	if opts.CreatedBefore > 0 {
+		cond = cond.And(builder.Lt{"created_unix": opts.CreatedBefore})
+	}
+	if opts.LastUsedBefore > 0 {
+		cond = cond.And(builder.Lt{"last_used_unix": opts.LastUsedBefore})
+	}
+	return cond
+}
+
+// ToOrders returns the least recently used entries first, which are the first to be evicted
+func (opts FindCachesOptions) ToOrders() string {
+	return "last_used_unix ASC, id ASC"
+}
+
+// CacheRepoSize is the total size of the entries of a repository
+type CacheRepoSize struct {
+	RepoID int64
+	Size   int64
+}
+
+// FindCacheReposOverSize returns the repositories whose entries take more than maxSize in total,
+// reserved entries are counted too since their content is being uploaded.
+func FindCacheReposOverSize(ctx context.Context, maxSize int64) ([]*CacheRepoSize, error) {
+	sizes := make([]*CacheRepoSize, 0, 10)
+	if err := db.GetEngine(ctx).Table("action_cache").
+		Select("repo_id, SUM(size) AS size").
+		GroupBy("repo_id").
+		Having(fmt.Sprintf("SUM(size) > %d", maxSize)).
+		Find(&sizes); err != nil {
+		return nil, err
+	}
+	return sizes, nil
+}
+
+// GetCacheRepoSize returns the total size of the entries of a repository
+func GetCacheRepoSize(ctx context.Context, repoID int64) (int64, error) {
+	return db.GetEngine(ctx).Where("repo_id=?", repoID).SumInt(new(ActionCache), "size")
+}
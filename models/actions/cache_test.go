@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"strings"
+	"testing"
+
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCacheToRestore(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := t.Context()
+
+	const repoID = 1
+	addCache := func(scope, key, version string, complete bool) *ActionCache {
+		c := &ActionCache{RepoID: repoID, Scope: scope, CacheKey: key, Version: version, Size: 10}
+		require.NoError(t, ReserveCache(ctx, c))
+		if complete {
+			c.Status = CacheStatusComplete
+			require.NoError(t, UpdateCache(ctx, c, "status"))
+		}
+		return c
+	}
+
+	mainOld := addCache("refs/heads/main", "deps-linux-aaa", "v1", true)
+	mainNew := addCache("refs/heads/main", "deps-linux-bbb", "v1", true)
+	addCache("refs/heads/main", "deps-linux-ccc", "v2", true)
+	addCache("refs/heads/main", "deps-linux-ddd", "v1", false)
+	feature := addCache("refs/heads/feature", "deps-linux-aaa", "v1", true)
+
+	assert.ErrorIs(t, ReserveCache(ctx, &ActionCache{RepoID: repoID, Scope: "refs/heads/main", CacheKey: "deps-linux-aaa", Version: "v1"}), util.ErrAlreadyExist)
+	assert.ErrorIs(t, ReserveCache(ctx, &ActionCache{RepoID: repoID, Scope: "refs/heads/main", CacheKey: strings.Repeat("a", MaxCacheKeyLength+1), Version: "v1"}), util.ErrInvalidArgument)
+
+	find := func(scopes []string, version string, keys ...string) int64 {
+		c, err := FindCacheToRestore(ctx, repoID, scopes, version, keys)
+		if err != nil {
+			assert.ErrorIs(t, err, util.ErrNotExist)
+			return 0
+		}
+		return c.ID
+	}
+
+	t.Run("ExactMatch", func(t *testing.T) {
+		assert.Equal(t, mainOld.ID, find([]string{"refs/heads/main"}, "v1", "deps-linux-aaa", "deps-"))
+	})
+	t.Run("PrefixMatchesMostRecent", func(t *testing.T) {
+		assert.Equal(t, mainNew.ID, find([]string{"refs/heads/main"}, "v1", "deps-linux-zzz", "deps-linux-"))
+	})
+	t.Run("VersionMustMatch", func(t *testing.T) {
+		assert.Zero(t, find([]string{"refs/heads/main"}, "v3", "deps-linux-aaa", "deps-"))
+	})
+	t.Run("ReservedIsIgnored", func(t *testing.T) {
+		assert.Equal(t, mainNew.ID, find([]string{"refs/heads/main"}, "v1", "deps-linux-ddd", "deps-linux-"))
+	})
+	t.Run("UnderscoreIsNotAWildcard", func(t *testing.T) {
+		assert.Zero(t, find([]string{"refs/heads/main"}, "v1", "deps_linux"))
+	})
+	t.Run("ScopesInOrder", func(t *testing.T) {
+		assert.Equal(t, feature.ID, find([]string{"refs/heads/feature", "refs/heads/main"}, "v1", "deps-linux-zzz", "deps-linux-"))
+		assert.Equal(t, mainNew.ID, find([]string{"refs/heads/other", "refs/heads/main"}, "v1", "deps-linux-zzz", "deps-linux-"))
+		assert.Zero(t, find([]string{"refs/heads/other"}, "v1", "deps-linux-aaa"))
+	})
+
+	size, err := GetCacheRepoSize(ctx, repoID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 50, size)
+
+	repos, err := FindCacheReposOverSize(ctx, 40)
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.EqualValues(t, repoID, repos[0].RepoID)
+	assert.EqualValues(t, 50, repos[0].Size)
+}
@@ -63,8 +63,7 @@ func (cfg *OwnerActionsConfig) GetMaxTokenPermissions() repo_model.ActionsTokenP
 	if cfg.MaxTokenPermissions != nil {
 		return *cfg.MaxTokenPermissions
 	}
-	// Default max is write for everything
-	return repo_model.MakeActionsTokenPermissions(perm.AccessModeWrite)
+	return repo_model.MakeDefaultMaxTokenPermissions()
 }
 
 // ClampPermissions ensures that the given permissions don't exceed the maximum
@@ -14,6 +14,25 @@ import (
 // It uses the job's stored permissions (if any), then applies org/repo clamps and fork/cross-repo restrictions.
 // Note: target repository access policy checks are enforced in GetActionsUserRepoPermission; this function only computes the job token's effective permission ceiling.
 func ComputeTaskTokenPermissions(ctx context.Context, task *ActionTask, targetRepo *repo_model.Repository) (ret repo_model.ActionsTokenPermissions, err error) {
+	effectivePerms, err := computeJobTokenPermissions(ctx, task)
+	if err != nil {
+		return ret, err
+	}
+
+	// Cross-repository access and fork pull requests are strictly read-only for security.
+	// This ensures a "task repo" cannot gain write access to other repositories via CrossRepoAccess settings.
+	isSameRepo := task.Job.RepoID == targetRepo.ID
+	restrictCrossRepoAccess := task.IsForkPullRequest || !isSameRepo
+	if restrictCrossRepoAccess {
+		effectivePerms = repo_model.ClampActionsTokenPermissions(effectivePerms, repo_model.MakeRestrictedPermissions())
+	}
+
+	return effectivePerms, nil
+}
+
+// computeJobTokenPermissions returns the permissions declared by the job of the task, or the default ones,
+// clamped by the maximum permissions of its repository or of the owner
+func computeJobTokenPermissions(ctx context.Context, task *ActionTask) (ret repo_model.ActionsTokenPermissions, err error) {
 	if err := task.LoadJob(ctx); err != nil {
 		return ret, err
 	}
@@ -47,14 +66,18 @@ func ComputeTaskTokenPermissions(ctx context.Context, task *ActionTask, targetRe
 	} else {
 		effectivePerms = ownerActionsCfg.ClampPermissions(jobDeclaredPerms)
 	}
+	return effectivePerms, nil
+}
 
-	// Cross-repository access and fork pull requests are strictly read-only for security.
-	// This ensures a "task repo" cannot gain write access to other repositories via CrossRepoAccess settings.
-	isSameRepo := task.Job.RepoID == targetRepo.ID
-	restrictCrossRepoAccess := task.IsForkPullRequest || !isSameRepo
-	if restrictCrossRepoAccess {
-		effectivePerms = repo_model.ClampActionsTokenPermissions(effectivePerms, repo_model.MakeRestrictedPermissions())
+// CanTaskRequestIDToken reports whether the job of the task has been granted "id-token: write" and the maximum permissions allow it.
+// Fork pull requests can never request an ID token, since their workflows are controlled by the author of the fork.
+func CanTaskRequestIDToken(ctx context.Context, task *ActionTask) (bool, error) {
+	if task.IsForkPullRequest {
+		return false, nil
 	}
-
-	return effectivePerms, nil
+	perms, err := computeJobTokenPermissions(ctx, task)
+	if err != nil {
+		return false, err
+	}
+	return perms.IDToken, nil
 }
@@ -0,0 +1,164 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// Action is the kind of a security relevant change recorded in the audit log
+type Action string
+
+const (
+	ActionProtectedBranchCreate Action = "protected_branch.create"
+	ActionProtectedBranchUpdate Action = "protected_branch.update"
+	ActionProtectedBranchDelete Action = "protected_branch.delete"
+
+	ActionCollaboratorAdd    Action = "collaborator.add"
+	ActionCollaboratorUpdate Action = "collaborator.update"
+	ActionCollaboratorRemove Action = "collaborator.remove"
+
+	ActionTeamCreate Action = "team.create"
+	ActionTeamUpdate Action = "team.update"
+	ActionTeamDelete Action = "team.delete"
+
+	ActionTeamMemberAdd    Action = "team.member.add"
+	ActionTeamMemberRemove Action = "team.member.remove"
+
+	ActionAccessTokenCreate Action = "access_token.create"
+	ActionAccessTokenDelete Action = "access_token.delete"
+
+	ActionUserImpersonate  Action = "user.impersonate"
+	ActionTwoFactorDisable Action = "user.two_factor.disable"
+
+	ActionSecretCreate Action = "secret.create"
+	ActionSecretUpdate Action = "secret.update"
+	ActionSecretDelete Action = "secret.delete"
+
+	ActionRepositoryTransfer Action = "repository.transfer"
+	ActionRepositoryDelete   Action = "repository.delete"
+
+	ActionPushRuleUpdate Action = "push_rule.update"
+	ActionPushRuleDelete Action = "push_rule.delete"
+)
+
+// TargetType is the kind of object an audit event has been recorded for
+type TargetType string
+
+const (
+	TargetTypeRepository      TargetType = "repository"
+	TargetTypeProtectedBranch TargetType = "protected_branch"
+	TargetTypeUser            TargetType = "user"
+	TargetTypeTeam            TargetType = "team"
+	TargetTypeAccessToken     TargetType = "access_token"
+	TargetTypeSecret          TargetType = "secret"
+	TargetTypePushRule        TargetType = "push_rule"
+)
+
+// Event represents a single entry of the audit log.
+// Events are append-only: they are never updated and they are kept when their actor or target is deleted,
+// so the names of the actor and the target are stored along with their IDs.
+type Event struct {
+	ID         int64      `xorm:"pk autoincr"`
+	Action     Action     `xorm:"VARCHAR(64) INDEX NOT NULL"`
+	ActorID    int64      `xorm:"INDEX NOT NULL"`
+	ActorName  string     `xorm:"NOT NULL DEFAULT ''"`
+	IPAddress  string     `xorm:"VARCHAR(64)"`
+	OwnerID    int64      `xorm:"INDEX NOT NULL DEFAULT 0"` // the user or organization owning the target, 0 for instance level events
+	RepoID     int64      `xorm:"INDEX NOT NULL DEFAULT 0"`
+	TargetType TargetType `xorm:"VARCHAR(32) NOT NULL"`
+	TargetID   int64      `xorm:"NOT NULL DEFAULT 0"`
+	TargetName string     `xorm:"NOT NULL DEFAULT ''"`
+	// Before and After hold the JSON encoded state of the target before and after the change, if any
+	Before      string             `xorm:"LONGTEXT"`
+	After       string             `xorm:"LONGTEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX"`
+}
+
+// TableName represents the real table name of Event
+func (Event) TableName() string {
+	return "audit_event"
+}
+
+func init() {
+	db.RegisterModel(new(Event))
+}
+
+// InsertEvent appends an event to the audit log
+func InsertEvent(ctx context.Context, e *Event) error {
+	return db.Insert(ctx, e)
+}
+
+// FindEventsOptions represents the options to query the audit log
+type FindEventsOptions struct {
+	db.ListOptions
+	OwnerID    int64
+	RepoID     int64
+	ActorID    int64
+	Action     Action
+	TargetType TargetType
+	Since      int64
+	Before     int64
+}
+
+func (opts FindEventsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.ActorID > 0 {
+		cond = cond.And(builder.Eq{"actor_id": opts.ActorID})
+	}
+	if opts.Action != "" {
+		cond = cond.And(builder.Eq{"action": opts.Action})
+	}
+	if opts.TargetType != "" {
+		cond = cond.And(builder.Eq{"target_type": opts.TargetType})
+	}
+	if opts.Since > 0 {
+		cond = cond.And(builder.Gte{"created_unix": opts.Since})
+	}
+	if opts.Before > 0 {
+		cond = cond.And(builder.Lt{"created_unix": opts.Before})
+	}
+	return cond
+}
+
+func (opts FindEventsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// IterateEvents calls f for every event matching opts in chronological order.
+// Pagination options are ignored so that the whole log can be exported.
+func IterateEvents(ctx context.Context, opts FindEventsOptions, f func(e *Event) error) error {
+	const batchSize = 100
+	var lastID int64
+	for {
+		events := make([]*Event, 0, batchSize)
+		if err := db.GetEngine(ctx).
+			Where(opts.ToConds().And(builder.Gt{"id": lastID})).
+			OrderBy("id ASC").
+			Limit(batchSize).
+			Find(&events); err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := f(e); err != nil {
+				return err
+			}
+			lastID = e.ID
+		}
+		if len(events) < batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit_test
+
+import (
+	"testing"
+
+	audit_model "gitea.dev/models/audit"
+	"gitea.dev/models/db"
+	"gitea.dev/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	for _, e := range []*audit_model.Event{
+		{Action: audit_model.ActionProtectedBranchCreate, ActorID: 2, ActorName: "user2", OwnerID: 2, RepoID: 1, TargetType: audit_model.TargetTypeProtectedBranch, TargetID: 1, TargetName: "main"},
+		{Action: audit_model.ActionTeamUpdate, ActorID: 2, ActorName: "user2", OwnerID: 3, TargetType: audit_model.TargetTypeTeam, TargetID: 1, TargetName: "Owners", Before: `{"name":"Owners"}`},
+		{Action: audit_model.ActionUserImpersonate, ActorID: 1, ActorName: "user1", OwnerID: 2, TargetType: audit_model.TargetTypeUser, TargetID: 2, TargetName: "user2"},
+	} {
+		require.NoError(t, audit_model.InsertEvent(t.Context(), e))
+	}
+
+	events, total, err := db.FindAndCount[audit_model.Event](t.Context(), audit_model.FindEventsOptions{OwnerID: 2})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+	require.Len(t, events, 2)
+	assert.Equal(t, audit_model.ActionUserImpersonate, events[0].Action, "most recent event should be listed first")
+	assert.Equal(t, audit_model.ActionProtectedBranchCreate, events[1].Action)
+
+	events, err = db.Find[audit_model.Event](t.Context(), audit_model.FindEventsOptions{ActorID: 2, TargetType: audit_model.TargetTypeTeam})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.JSONEq(t, `{"name":"Owners"}`, events[0].Before)
+
+	var actions []audit_model.Action
+	require.NoError(t, audit_model.IterateEvents(t.Context(), audit_model.FindEventsOptions{ListOptions: db.ListOptions{PageSize: 1}}, func(e *audit_model.Event) error {
+		actions = append(actions, e.Action)
+		return nil
+	}))
+	assert.Equal(t, []audit_model.Action{audit_model.ActionProtectedBranchCreate, audit_model.ActionTeamUpdate, audit_model.ActionUserImpersonate}, actions)
+}
@@ -0,0 +1,253 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrIndexNotExist = util.NewNotExistErrorf("code navigation index does not exist")
+
+// MaxPathLength is the length of the longest document path which can be stored,
+// documents with longer paths are left out of an index
+const MaxPathLength = 500
+
+// Index is a SCIP index uploaded for a commit of a repository.
+// Its documents, occurrences and symbols are inserted in batches while the upload is decoded,
+// the index is only used once all of them have been stored.
+type Index struct {
+	ID           int64              `xorm:"pk autoincr"`
+	RepoID       int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CommitID     string             `xorm:"VARCHAR(64) UNIQUE(s) NOT NULL"`
+	Root         string             `xorm:"VARCHAR(500) NOT NULL DEFAULT ''"` // the directory of the repository the document paths are relative to
+	ToolName     string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	ToolVersion  string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	IsReady      bool               `xorm:"NOT NULL DEFAULT false"`
+	NumDocuments int                `xorm:"NOT NULL DEFAULT 0"`
+	UploaderID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName represents the real table name of Index
+func (Index) TableName() string {
+	return "code_nav_index"
+}
+
+// Document is a source file covered by an index
+type Document struct {
+	ID               int64  `xorm:"pk autoincr"`
+	IndexID          int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Path             string `xorm:"VARCHAR(500) UNIQUE(s) NOT NULL"` // relative to the repository root
+	Language         string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	PositionEncoding int    `xorm:"NOT NULL DEFAULT 0"` // scip.PositionEncoding
+}
+
+// TableName represents the real table name of Document
+func (Document) TableName() string {
+	return "code_nav_document"
+}
+
+// Occurrence is a reference to or the definition of a symbol.
+// Lines and characters are zero-based and the end of the range is exclusive.
+type Occurrence struct {
+	ID             int64  `xorm:"pk autoincr"`
+	IndexID        int64  `xorm:"INDEX(symbol) NOT NULL"`
+	SymbolHash     string `xorm:"VARCHAR(64) INDEX(symbol) NOT NULL"`
+	DocumentID     int64  `xorm:"INDEX(position) NOT NULL"`
+	StartLine      int32  `xorm:"INDEX(position) NOT NULL"`
+	StartCharacter int32  `xorm:"NOT NULL"`
+	EndLine        int32  `xorm:"NOT NULL"`
+	EndCharacter   int32  `xorm:"NOT NULL"`
+	IsDefinition   bool   `xorm:"NOT NULL DEFAULT false"`
+}
+
+// TableName represents the real table name of Occurrence
+func (Occurrence) TableName() string {
+	return "code_nav_occurrence"
+}
+
+// Contains reports whether the position is inside the range of the occurrence
+func (o *Occurrence) Contains(line, character int32) bool {
+	if line < o.StartLine || line > o.EndLine {
+		return false
+	}
+	if line == o.StartLine && character < o.StartCharacter {
+		return false
+	}
+	return line != o.EndLine || character < o.EndCharacter
+}
+
+// Symbol holds the documentation of a symbol
+type Symbol struct {
+	ID            int64    `xorm:"pk autoincr"`
+	IndexID       int64    `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Hash          string   `xorm:"VARCHAR(64) UNIQUE(s) NOT NULL"`
+	Symbol        string   `xorm:"TEXT"`
+	DisplayName   string   `xorm:"TEXT"`
+	Documentation []string `xorm:"JSON TEXT"` // markdown
+}
+
+// TableName represents the real table name of Symbol
+func (Symbol) TableName() string {
+	return "code_nav_symbol"
+}
+
+func init() {
+	db.RegisterModel(new(Index))
+	db.RegisterModel(new(Document))
+	db.RegisterModel(new(Occurrence))
+	db.RegisterModel(new(Symbol))
+}
+
+// HashSymbol returns the key of a symbol in an index. Local symbols are only unique
+// within their document, so the path of the document is part of their key.
+func HashSymbol(path, symbol string, isLocal bool) string {
+	h := sha256.New()
+	if isLocal {
+		_, _ = h.Write([]byte(path))
+		_, _ = h.Write([]byte{0})
+	}
+	_, _ = h.Write([]byte(symbol))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FindIndexesOptions represents the options to query the indexes of a repository
+type FindIndexesOptions struct {
+	db.ListOptions
+	RepoID  int64
+	IsReady bool
+}
+
+func (opts FindIndexesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.IsReady {
+		cond = cond.And(builder.Eq{"is_ready": true})
+	}
+	return cond
+}
+
+func (opts FindIndexesOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// GetIndexByCommit returns the index uploaded for the commit of the repository, ready or not
+func GetIndexByCommit(ctx context.Context, repoID int64, commitID string) (*Index, error) {
+	idx := &Index{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND commit_id = ?", repoID, commitID).Get(idx)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrIndexNotExist
+	}
+	return idx, nil
+}
+
+// MarkIndexReady makes a completely stored index available for navigation
+func MarkIndexReady(ctx context.Context, idx *Index) error {
+	idx.IsReady = true
+	_, err := db.GetEngine(ctx).ID(idx.ID).Cols("is_ready", "num_documents").Update(idx)
+	return err
+}
+
+// DeleteIndex deletes an index with all its data
+func DeleteIndex(ctx context.Context, idx *Index) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		e := db.GetEngine(ctx)
+		if _, err := e.Where("index_id = ?", idx.ID).Delete(&Occurrence{}); err != nil {
+			return err
+		}
+		if _, err := e.Where("index_id = ?", idx.ID).Delete(&Symbol{}); err != nil {
+			return err
+		}
+		if _, err := e.Where("index_id = ?", idx.ID).Delete(&Document{}); err != nil {
+			return err
+		}
+		_, err := e.ID(idx.ID).Delete(&Index{})
+		return err
+	})
+}
+
+// DeleteIndexesByRepoID deletes all the indexes of a repository
+func DeleteIndexesByRepoID(ctx context.Context, repoID int64) error {
+	indexes, err := db.Find[Index](ctx, FindIndexesOptions{RepoID: repoID})
+	if err != nil {
+		return err
+	}
+	for _, idx := range indexes {
+		if err := DeleteIndex(ctx, idx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PruneIndexes deletes the ready indexes of a repository except the keep most recent ones
+func PruneIndexes(ctx context.Context, repoID int64, keep int) error {
+	indexes, err := db.Find[Index](ctx, FindIndexesOptions{RepoID: repoID, IsReady: true})
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(indexes); i++ {
+		if err := DeleteIndex(ctx, indexes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDocumentByPath returns the document of the index with the given path, or nil if the index doesn't cover the file
+func GetDocumentByPath(ctx context.Context, indexID int64, path string) (*Document, error) {
+	doc := &Document{}
+	has, err := db.GetEngine(ctx).Where("index_id = ? AND path = ?", indexID, path).Get(doc)
+	if err != nil || !has {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// GetDocumentsByIDs returns the documents with the given IDs, mapped by their IDs
+func GetDocumentsByIDs(ctx context.Context, ids []int64) (map[int64]*Document, error) {
+	docs := make(map[int64]*Document, len(ids))
+	return docs, db.GetEngine(ctx).In("id", ids).Find(&docs)
+}
+
+// GetOccurrencesAtLine returns the occurrences of the document which start on or span the line
+func GetOccurrencesAtLine(ctx context.Context, documentID int64, line int32) ([]*Occurrence, error) {
+	occurrences := make([]*Occurrence, 0, 10)
+	return occurrences, db.GetEngine(ctx).
+		Where("document_id = ? AND start_line <= ? AND end_line >= ?", documentID, line, line).
+		Find(&occurrences)
+}
+
+// FindSymbolOccurrences returns up to limit occurrences of a symbol, definitions first
+func FindSymbolOccurrences(ctx context.Context, indexID int64, symbolHash string, limit int) ([]*Occurrence, error) {
+	occurrences := make([]*Occurrence, 0, 10)
+	return occurrences, db.GetEngine(ctx).
+		Where("index_id = ? AND symbol_hash = ?", indexID, symbolHash).
+		OrderBy("is_definition DESC, document_id, start_line, start_character").
+		Limit(limit).
+		Find(&occurrences)
+}
+
+// GetSymbol returns the documentation of a symbol, or nil if the index has none
+func GetSymbol(ctx context.Context, indexID int64, symbolHash string) (*Symbol, error) {
+	s := &Symbol{}
+	has, err := db.GetEngine(ctx).Where("index_id = ? AND hash = ?", indexID, symbolHash).Get(s)
+	if err != nil || !has {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav_test
+
+import (
+	"testing"
+
+	codenav_model "gitea.dev/models/codenav"
+	"gitea.dev/models/db"
+	"gitea.dev/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestIndex(t *testing.T, repoID int64, commitID string) *codenav_model.Index {
+	idx := &codenav_model.Index{RepoID: repoID, CommitID: commitID, ToolName: "scip-go"}
+	require.NoError(t, db.Insert(t.Context(), idx))
+
+	doc := &codenav_model.Document{IndexID: idx.ID, Path: "main.go", Language: "go"}
+	require.NoError(t, db.Insert(t.Context(), doc))
+
+	hello := codenav_model.HashSymbol(doc.Path, "scip-go gomod example v1 `example`/Hello().", false)
+	require.NoError(t, db.Insert(t.Context(), []*codenav_model.Occurrence{
+		{IndexID: idx.ID, SymbolHash: hello, DocumentID: doc.ID, StartLine: 2, StartCharacter: 5, EndLine: 2, EndCharacter: 10, IsDefinition: true},
+		{IndexID: idx.ID, SymbolHash: hello, DocumentID: doc.ID, StartLine: 8, StartCharacter: 1, EndLine: 8, EndCharacter: 6},
+	}))
+	require.NoError(t, db.Insert(t.Context(), &codenav_model.Symbol{IndexID: idx.ID, Hash: hello, Symbol: "scip-go gomod example v1 `example`/Hello().", DisplayName: "Hello", Documentation: []string{"Hello says hello."}}))
+
+	idx.NumDocuments = 1
+	require.NoError(t, codenav_model.MarkIndexReady(t.Context(), idx))
+	return idx
+}
+
+func TestIndexes(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	idx := createTestIndex(t, 1, "65f1bf27bc3bf70f64657658635e66094edbcb4d")
+
+	loaded, err := codenav_model.GetIndexByCommit(t.Context(), 1, "65f1bf27bc3bf70f64657658635e66094edbcb4d")
+	require.NoError(t, err)
+	assert.True(t, loaded.IsReady)
+	assert.Equal(t, 1, loaded.NumDocuments)
+	_, err = codenav_model.GetIndexByCommit(t.Context(), 2, "65f1bf27bc3bf70f64657658635e66094edbcb4d")
+	assert.ErrorIs(t, err, codenav_model.ErrIndexNotExist)
+
+	missing, err := codenav_model.GetDocumentByPath(t.Context(), idx.ID, "missing.go")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	doc, err := codenav_model.GetDocumentByPath(t.Context(), idx.ID, "main.go")
+	require.NoError(t, err)
+	require.NotNil(t, doc)
+
+	occurrences, err := codenav_model.GetOccurrencesAtLine(t.Context(), doc.ID, 8)
+	require.NoError(t, err)
+	require.Len(t, occurrences, 1)
+	assert.True(t, occurrences[0].Contains(8, 3))
+	assert.False(t, occurrences[0].Contains(8, 6))
+
+	occurrences, err = codenav_model.FindSymbolOccurrences(t.Context(), idx.ID, occurrences[0].SymbolHash, 10)
+	require.NoError(t, err)
+	require.Len(t, occurrences, 2)
+	assert.True(t, occurrences[0].IsDefinition)
+
+	symbol, err := codenav_model.GetSymbol(t.Context(), idx.ID, occurrences[0].SymbolHash)
+	require.NoError(t, err)
+	require.NotNil(t, symbol)
+	assert.Equal(t, []string{"Hello says hello."}, symbol.Documentation)
+
+	// only the most recent indexes are kept
+	createTestIndex(t, 1, "2a47ca4b614a9f5a43abbd5ad851a54a616ffee6")
+	require.NoError(t, codenav_model.PruneIndexes(t.Context(), 1, 1))
+	_, err = codenav_model.GetIndexByCommit(t.Context(), 1, "65f1bf27bc3bf70f64657658635e66094edbcb4d")
+	assert.ErrorIs(t, err, codenav_model.ErrIndexNotExist)
+	unittest.AssertNotExistsBean(t, &codenav_model.Occurrence{IndexID: idx.ID})
+	unittest.AssertNotExistsBean(t, &codenav_model.Document{IndexID: idx.ID})
+	unittest.AssertNotExistsBean(t, &codenav_model.Symbol{IndexID: idx.ID})
+
+	require.NoError(t, codenav_model.DeleteIndexesByRepoID(t.Context(), 1))
+	unittest.AssertNotExistsBean(t, &codenav_model.Index{RepoID: 1})
+}
+
+func TestHashSymbol(t *testing.T) {
+	global := "scip-go gomod fmt v1 `fmt`/Println()."
+	assert.Equal(t, codenav_model.HashSymbol("a.go", global, false), codenav_model.HashSymbol("b.go", global, false))
+	assert.NotEqual(t, codenav_model.HashSymbol("a.go", "local 1", true), codenav_model.HashSymbol("b.go", "local 1", true))
+	assert.Len(t, codenav_model.HashSymbol("a.go", global, false), 64)
+}
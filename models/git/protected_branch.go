@@ -67,6 +67,8 @@ type ProtectedBranch struct {
 	ProtectedFilePatterns         string   `xorm:"TEXT"`
 	UnprotectedFilePatterns       string   `xorm:"TEXT"`
 	BlockAdminMergeOverride       bool     `xorm:"NOT NULL DEFAULT false"`
+	EnableMergeQueue              bool     `xorm:"NOT NULL DEFAULT false"`
+	MergeQueueMaxGroupSize        int64    `xorm:"NOT NULL DEFAULT 0"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
@@ -294,6 +296,14 @@ func (protectBranch *ProtectedBranch) MergeBlockedByProtectedFiles(changedProtec
 	return len(changedProtectedFiles) > 0
 }
 
+// GetMergeQueueMaxGroupSize returns how many queued pull requests may be speculatively merged and checked at the same time
+func (protectBranch *ProtectedBranch) GetMergeQueueMaxGroupSize() int {
+	if protectBranch.MergeQueueMaxGroupSize <= 0 {
+		return 1
+	}
+	return int(protectBranch.MergeQueueMaxGroupSize)
+}
+
 // IsProtectedFile return if path is protected
 func (protectBranch *ProtectedBranch) IsProtectedFile(patterns []glob.Glob, path string) bool {
 	if len(patterns) == 0 {
@@ -0,0 +1,220 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/glob"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+// PushRule restricts the commits which can be pushed to a repository or to all repositories of an organization.
+// The rule of an organization has a RepoID of 0, the rule of a repository has an OwnerID of 0.
+type PushRule struct {
+	ID      int64 `xorm:"pk autoincr"`
+	OwnerID int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+	RepoID  int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+
+	// CommitMessagePattern is a regular expression the message of every commit must match
+	CommitMessagePattern string `xorm:"TEXT"`
+	// RequireSignedOffBy requires a "Signed-off-by:" trailer in every commit message
+	RequireSignedOffBy bool `xorm:"NOT NULL DEFAULT false"`
+	// RequireVerifiedAuthorEmail requires the author email of every commit to be a verified email address of a user
+	RequireVerifiedAuthorEmail bool `xorm:"NOT NULL DEFAULT false"`
+	// MaxBlobSize is the maximum size in bytes of a file added or changed by a commit, 0 means no limit
+	MaxBlobSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	// ForbiddenFilePatterns is a semicolon separated list of glob patterns of paths which must not be added or changed
+	ForbiddenFilePatterns string `xorm:"TEXT"`
+	// ForbiddenFileExtensions is a semicolon separated list of file extensions which must not be added or changed
+	ForbiddenFileExtensions string `xorm:"TEXT"`
+	// SignedCommitBranchPatterns is a semicolon separated list of glob patterns of branches which only accept signed commits
+	SignedCommitBranchPatterns string `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	commitMessageRegexp     *regexp.Regexp
+	forbiddenFileGlobs      []glob.Glob
+	forbiddenExtensions     []string
+	signedCommitBranchGlobs []glob.Glob
+	loaded                  bool
+}
+
+func init() {
+	db.RegisterModel(new(PushRule))
+}
+
+var signedOffByRegexp = regexp.MustCompile(`(?m)^Signed-off-by: .+ <.+>\s*$`)
+
+// Validate checks the regular expression and the glob patterns of the rule
+func (rule *PushRule) Validate() error {
+	if rule.CommitMessagePattern != "" {
+		if _, err := regexp.Compile(rule.CommitMessagePattern); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid commit message pattern: %v", err)
+		}
+	}
+	for expr := range strings.SplitSeq(rule.ForbiddenFilePatterns, ";") {
+		if expr = strings.TrimSpace(expr); expr != "" {
+			if _, err := glob.Compile(expr, '.', '/'); err != nil {
+				return util.NewInvalidArgumentErrorf("invalid forbidden file pattern %q: %v", expr, err)
+			}
+		}
+	}
+	for expr := range strings.SplitSeq(rule.SignedCommitBranchPatterns, ";") {
+		if expr = strings.TrimSpace(expr); expr != "" {
+			if _, err := glob.Compile(expr, '/'); err != nil {
+				return util.NewInvalidArgumentErrorf("invalid signed commit branch pattern %q: %v", expr, err)
+			}
+		}
+	}
+	if rule.MaxBlobSize < 0 {
+		return util.NewInvalidArgumentErrorf("max blob size must not be negative")
+	}
+	return nil
+}
+
+func (rule *PushRule) load() {
+	if rule.loaded {
+		return
+	}
+	rule.loaded = true
+
+	if rule.CommitMessagePattern != "" {
+		var err error
+		if rule.commitMessageRegexp, err = regexp.Compile(rule.CommitMessagePattern); err != nil {
+			log.Warn("Invalid commit message pattern of PushRule[%d] (skipped): %v", rule.ID, err)
+		}
+	}
+	rule.forbiddenFileGlobs = getFilePatterns(rule.ForbiddenFilePatterns)
+	for ext := range strings.SplitSeq(strings.ToLower(rule.ForbiddenFileExtensions), ";") {
+		if ext = strings.TrimSpace(ext); ext != "" {
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			rule.forbiddenExtensions = append(rule.forbiddenExtensions, ext)
+		}
+	}
+	for expr := range strings.SplitSeq(rule.SignedCommitBranchPatterns, ";") {
+		if expr = strings.TrimSpace(expr); expr != "" {
+			if g, err := glob.Compile(expr, '/'); err != nil {
+				log.Warn("Invalid signed commit branch pattern of PushRule[%d] (skipped): %v", rule.ID, err)
+			} else {
+				rule.signedCommitBranchGlobs = append(rule.signedCommitBranchGlobs, g)
+			}
+		}
+	}
+}
+
+// CheckCommitMessage returns the reason why the commit message violates the rule, or an empty string
+func (rule *PushRule) CheckCommitMessage(message string) string {
+	rule.load()
+	if rule.commitMessageRegexp != nil && !rule.commitMessageRegexp.MatchString(message) {
+		return fmt.Sprintf("commit message does not match %q", rule.CommitMessagePattern)
+	}
+	if rule.RequireSignedOffBy && !signedOffByRegexp.MatchString(message) {
+		return "commit message has no Signed-off-by trailer"
+	}
+	return ""
+}
+
+// CheckFile returns the reason why adding or changing the file violates the rule, or an empty string
+func (rule *PushRule) CheckFile(filePath string, size int64) string {
+	rule.load()
+	lpath := strings.ToLower(filePath)
+	for _, g := range rule.forbiddenFileGlobs {
+		if g.Match(lpath) {
+			return "file " + filePath + " is forbidden"
+		}
+	}
+	ext := path.Ext(lpath)
+	for _, forbidden := range rule.forbiddenExtensions {
+		if ext == forbidden {
+			return fmt.Sprintf("file %s has the forbidden extension %s", filePath, forbidden)
+		}
+	}
+	if rule.MaxBlobSize > 0 && size > rule.MaxBlobSize {
+		return fmt.Sprintf("file %s is larger than %d bytes", filePath, rule.MaxBlobSize)
+	}
+	return ""
+}
+
+// ChecksFiles returns true if the rule restricts the files of a commit
+func (rule *PushRule) ChecksFiles() bool {
+	rule.load()
+	return len(rule.forbiddenFileGlobs) > 0 || len(rule.forbiddenExtensions) > 0 || rule.MaxBlobSize > 0
+}
+
+// RequireSignedCommits returns true if the branch only accepts signed commits
+func (rule *PushRule) RequireSignedCommits(branchName string) bool {
+	rule.load()
+	for _, g := range rule.signedCommitBranchGlobs {
+		if g.Match(branchName) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPushRuleByRepoID returns the push rule of the repository, or nil if there is none
+func GetPushRuleByRepoID(ctx context.Context, repoID int64) (*PushRule, error) {
+	return getPushRule(ctx, 0, repoID)
+}
+
+// GetPushRuleByOwnerID returns the push rule of the organization, or nil if there is none
+func GetPushRuleByOwnerID(ctx context.Context, ownerID int64) (*PushRule, error) {
+	return getPushRule(ctx, ownerID, 0)
+}
+
+func getPushRule(ctx context.Context, ownerID, repoID int64) (*PushRule, error) {
+	rule := &PushRule{}
+	has, err := db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ?", ownerID, repoID).Get(rule)
+	if err != nil || !has {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// GetPushRulesForRepo returns the push rules which apply to the repository: the rule of its owner and its own rule
+func GetPushRulesForRepo(ctx context.Context, repo *repo_model.Repository) ([]*PushRule, error) {
+	rules := make([]*PushRule, 0, 2)
+	return rules, db.GetEngine(ctx).
+		Where("(owner_id = ? AND repo_id = 0) OR (owner_id = 0 AND repo_id = ?)", repo.OwnerID, repo.ID).
+		OrderBy("repo_id ASC").
+		Find(&rules)
+}
+
+// SavePushRule creates or replaces the push rule of the owner or the repository of the rule
+func SavePushRule(ctx context.Context, rule *PushRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := getPushRule(ctx, rule.OwnerID, rule.RepoID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return db.Insert(ctx, rule)
+		}
+		rule.ID = existing.ID
+		rule.CreatedUnix = existing.CreatedUnix
+		_, err = db.GetEngine(ctx).ID(rule.ID).AllCols().Update(rule)
+		return err
+	})
+}
+
+// DeletePushRule deletes the push rule of the owner or the repository
+func DeletePushRule(ctx context.Context, ownerID, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ?", ownerID, repoID).Delete(&PushRule{})
+	return err
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git_test
+
+import (
+	"testing"
+
+	git_model "gitea.dev/models/git"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushRuleChecks(t *testing.T) {
+	rule := &git_model.PushRule{
+		CommitMessagePattern:       `^(feat|fix): `,
+		RequireSignedOffBy:         true,
+		MaxBlobSize:                100,
+		ForbiddenFilePatterns:      "secrets/**; *.pem",
+		ForbiddenFileExtensions:    "EXE;.zip",
+		SignedCommitBranchPatterns: "main;release/*",
+	}
+	require.NoError(t, rule.Validate())
+
+	assert.Empty(t, rule.CheckCommitMessage("fix: typo\n\nSigned-off-by: User Two <user2@example.com>\n"))
+	assert.NotEmpty(t, rule.CheckCommitMessage("typo\n\nSigned-off-by: User Two <user2@example.com>\n"))
+	assert.NotEmpty(t, rule.CheckCommitMessage("fix: typo\n"))
+
+	assert.True(t, rule.ChecksFiles())
+	assert.Empty(t, rule.CheckFile("README.md", 10))
+	assert.NotEmpty(t, rule.CheckFile("README.md", 101))
+	assert.NotEmpty(t, rule.CheckFile("secrets/prod/key", 10))
+	assert.NotEmpty(t, rule.CheckFile("cert.PEM", 10))
+	assert.NotEmpty(t, rule.CheckFile("bin/tool.exe", 10))
+	assert.NotEmpty(t, rule.CheckFile("dist.zip", 10))
+	assert.Empty(t, rule.CheckFile("zip", 10))
+
+	assert.True(t, rule.RequireSignedCommits("main"))
+	assert.True(t, rule.RequireSignedCommits("release/1.0"))
+	assert.False(t, rule.RequireSignedCommits("release/1.0/fix"))
+	assert.False(t, rule.RequireSignedCommits("feature"))
+
+	assert.Error(t, (&git_model.PushRule{CommitMessagePattern: "("}).Validate())
+	assert.Error(t, (&git_model.PushRule{ForbiddenFilePatterns: "[a"}).Validate())
+	assert.Error(t, (&git_model.PushRule{MaxBlobSize: -1}).Validate())
+	assert.False(t, (&git_model.PushRule{}).ChecksFiles())
+}
+
+func TestSavePushRule(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3}) // owned by org3
+
+	rule, err := git_model.GetPushRuleByRepoID(t.Context(), repo.ID)
+	require.NoError(t, err)
+	assert.Nil(t, rule)
+
+	require.NoError(t, git_model.SavePushRule(t.Context(), &git_model.PushRule{OwnerID: repo.OwnerID, RequireSignedOffBy: true}))
+	require.NoError(t, git_model.SavePushRule(t.Context(), &git_model.PushRule{RepoID: repo.ID, MaxBlobSize: 10}))
+	require.NoError(t, git_model.SavePushRule(t.Context(), &git_model.PushRule{RepoID: repo.ID, MaxBlobSize: 20}))
+	assert.Error(t, git_model.SavePushRule(t.Context(), &git_model.PushRule{RepoID: repo.ID, CommitMessagePattern: "("}))
+
+	rules, err := git_model.GetPushRulesForRepo(t.Context(), repo)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, repo.OwnerID, rules[0].OwnerID)
+	assert.True(t, rules[0].RequireSignedOffBy)
+	assert.Equal(t, repo.ID, rules[1].RepoID)
+	assert.EqualValues(t, 20, rules[1].MaxBlobSize)
+
+	require.NoError(t, git_model.DeletePushRule(t.Context(), 0, repo.ID))
+	rules, err = git_model.GetPushRulesForRepo(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Len(t, rules, 1)
+}
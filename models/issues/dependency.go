@@ -153,6 +153,14 @@ func CreateIssueDependency(ctx context.Context, user *user_model.User, issue, de
 	})
 }
 
+// InsertIssueDependencies inserts dependencies without any comment, it is used when migrating a repository
+func InsertIssueDependencies(ctx context.Context, deps ...*IssueDependency) error {
+	if len(deps) == 0 {
+		return nil
+	}
+	return db.Insert(ctx, deps)
+}
+
 // RemoveIssueDependency removes a dependency from an issue
 func RemoveIssueDependency(ctx context.Context, user *user_model.User, issue, dep *Issue, depType DependencyType) (err error) {
 	return db.WithTx(ctx, func(ctx context.Context) error {
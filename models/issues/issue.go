@@ -108,6 +108,9 @@ type Issue struct {
 
 	// Time estimate
 	TimeEstimate int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	// FormData is stored with a new issue created through an issue form
+	FormData *IssueFormData `xorm:"-"`
 }
 
 var (
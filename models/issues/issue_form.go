@@ -0,0 +1,53 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+// IssueFormData holds the values submitted through the issue form an issue has been created with
+type IssueFormData struct {
+	ID      int64 `xorm:"pk autoincr"`
+	IssueID int64 `xorm:"UNIQUE NOT NULL"`
+	// TemplateFile is the path of the issue form template in the default branch
+	TemplateFile string
+	// Values are the typed values of the fields keyed by field ID, see template.ParseValues
+	Values      map[string]any     `xorm:"JSON TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueFormData))
+}
+
+// ErrIssueFormDataNotExist represents a "IssueFormDataNotExist" kind of error.
+type ErrIssueFormDataNotExist struct {
+	IssueID int64
+}
+
+func (err ErrIssueFormDataNotExist) Error() string {
+	return fmt.Sprintf("issue form data does not exist [issue_id: %d]", err.IssueID)
+}
+
+func (err ErrIssueFormDataNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// GetIssueFormData returns the values submitted through the issue form the issue has been created with
+func GetIssueFormData(ctx context.Context, issueID int64) (*IssueFormData, error) {
+	data := &IssueFormData{}
+	has, err := db.GetEngine(ctx).Where("issue_id=?", issueID).Get(data)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueFormDataNotExist{IssueID: issueID}
+	}
+	return data, nil
+}
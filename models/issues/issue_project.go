@@ -86,6 +86,9 @@ func IssueAssignOrRemoveProject(ctx context.Context, issue *Issue, doer *user_mo
 			if _, err := db.GetEngine(ctx).Where("issue_id=?", issue.ID).In("project_id", projectsToRemove).Delete(&project_model.ProjectIssue{}); err != nil {
 				return err
 			}
+			if err := project_model.DeleteIssueFieldValues(ctx, []int64{issue.ID}, projectsToRemove); err != nil {
+				return err
+			}
 			for _, projectID := range projectsToRemove {
 				if _, err := CreateComment(ctx, &CreateCommentOptions{
 					Type:         CommentTypeProject,
@@ -11,6 +11,7 @@ import (
 
 	"gitea.dev/models/db"
 	"gitea.dev/models/organization"
+	project_model "gitea.dev/models/project"
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/models/unit"
 	user_model "gitea.dev/models/user"
@@ -37,6 +38,7 @@ type IssuesOptions struct { //nolint:revive // export stutter
 	SubscriberID       int64
 	MilestoneIDs       []int64
 	ProjectIDs         []int64
+	ProjectFieldValues map[int64]string // project field ID to the stored value the issues must have
 	IsClosed           optional.Option[bool]
 	IsPull             optional.Option[bool]
 	LabelIDs           []int64
@@ -208,6 +210,10 @@ func applyProjectCondition(sess db.Session, opts *IssuesOptions) {
 	}
 	// empty projectIDs means all projects,
 	// do not need to apply any condition
+
+	for fieldID, value := range opts.ProjectFieldValues {
+		sess.And(project_model.FieldValueCond(fieldID, value))
+	}
 }
 
 func applyRepoConditions(sess db.Session, opts *IssuesOptions) {
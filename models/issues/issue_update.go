@@ -442,6 +442,13 @@ func NewIssueWithIndex(ctx context.Context, doer *user_model.User, opts NewIssue
 		return err
 	}
 
+	if opts.Issue.FormData != nil {
+		opts.Issue.FormData.IssueID = opts.Issue.ID
+		if err := db.Insert(ctx, opts.Issue.FormData); err != nil {
+			return err
+		}
+	}
+
 	if err = opts.Issue.LoadAttributes(ctx); err != nil {
 		return err
 	}
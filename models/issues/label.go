@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"gitea.dev/models/db"
+	project_model "gitea.dev/models/project"
 	"gitea.dev/modules/container"
 	"gitea.dev/modules/label"
 	"gitea.dev/modules/optional"
@@ -256,6 +257,9 @@ func DeleteLabel(ctx context.Context, id, labelID int64) error {
 			Delete(new(IssueLabel)); err != nil {
 			return err
 		}
+		if err := project_model.DeleteAutomationsByLabelID(ctx, labelID); err != nil {
+			return err
+		}
 
 		// delete comments about now deleted label_id
 		_, err = db.GetEngine(ctx).Where("label_id = ?", labelID).Cols("label_id").Delete(&Comment{})
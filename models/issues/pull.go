@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"time"
 
@@ -169,6 +170,12 @@ func DeletePullsByBaseRepoID(ctx context.Context, repoID int64) error {
 		return err
 	}
 
+	// Delete merge queue entries
+	if _, err := db.GetEngine(ctx).In("pull_id", deleteCond).
+		Delete(&pull_model.MergeQueueEntry{}); err != nil {
+		return err
+	}
+
 	// Delete review states
 	if _, err := db.GetEngine(ctx).In("pull_id", deleteCond).
 		Delete(&pull_model.ReviewState{}); err != nil {
@@ -830,27 +837,52 @@ func GetCodeOwnersFromContent(ctx context.Context, data string) ([]*CodeOwnerRul
 	if len(data) == 0 {
 		return nil, nil
 	}
+	rules, problems := ParseCodeOwners(ctx, data)
+	warnings := make([]string, 0, len(problems))
+	for _, p := range problems {
+		warnings = append(warnings, p.String())
+	}
+	return rules, warnings
+}
+
+// CodeOwnerWarning is a problem found on a line of a CODEOWNERS file
+type CodeOwnerWarning struct {
+	Line    int
+	Message string
+}
+
+func (w *CodeOwnerWarning) String() string {
+	return fmt.Sprintf("Line: %d: %s", w.Line, w.Message)
+}
+
+// ParseCodeOwners parses the content of a CODEOWNERS file like GetCodeOwnersFromContent
+// but keeps the line of every warning so that they can be reported to the users
+func ParseCodeOwners(ctx context.Context, data string) ([]*CodeOwnerRule, []*CodeOwnerWarning) {
+	if len(data) == 0 {
+		return nil, nil
+	}
 
 	rules := make([]*CodeOwnerRule, 0)
 	lines := strings.Split(data, "\n")
-	warnings := make([]string, 0)
+	warnings := make([]*CodeOwnerWarning, 0)
 
 	for i, line := range lines {
 		tokens := TokenizeCodeOwnersLine(line)
 		if len(tokens) == 0 {
 			continue
 		} else if len(tokens) < 2 {
-			warnings = append(warnings, fmt.Sprintf("Line: %d: incorrect format", i+1))
+			warnings = append(warnings, &CodeOwnerWarning{Line: i + 1, Message: "incorrect format"})
 			continue
 		}
 		rule, wr := ParseCodeOwnersLine(ctx, tokens)
 		for _, w := range wr {
-			warnings = append(warnings, fmt.Sprintf("Line: %d: %s", i+1, w))
+			warnings = append(warnings, &CodeOwnerWarning{Line: i + 1, Message: w})
 		}
 		if rule == nil {
 			continue
 		}
 
+		rule.Line = i + 1
 		rules = append(rules, rule)
 	}
 
@@ -863,10 +895,19 @@ func GetCodeOwnersFromContent(ctx context.Context, data string) ([]*CodeOwnerRul
 const codeOwnerMatchTimeout = 150 * time.Millisecond
 
 type CodeOwnerRule struct {
+	Line     int             // the line of the rule in the CODEOWNERS file, starting from 1
+	Pattern  string          // the pattern as written in the CODEOWNERS file
 	Rule     *regexp2.Regexp // it supports negative lookahead, does better for end users
 	Negative bool
 	Users    []*user_model.User
 	Teams    []*org_model.Team
+	Owners   []string // the names of the users and teams which could be resolved, as written in the file
+}
+
+// MatchFile returns whether the rule applies to the file, a match timeout is considered as not matched
+func (rule *CodeOwnerRule) MatchFile(file string) bool {
+	matched, _ := rule.Rule.MatchString(file)
+	return matched != rule.Negative
 }
 
 func ParseCodeOwnersLine(ctx context.Context, tokens []string) (*CodeOwnerRule, []string) {
@@ -874,6 +915,7 @@ func ParseCodeOwnersLine(ctx context.Context, tokens []string) (*CodeOwnerRule,
 	rule := &CodeOwnerRule{
 		Users:    make([]*user_model.User, 0),
 		Teams:    make([]*org_model.Team, 0),
+		Pattern:  tokens[0],
 		Negative: strings.HasPrefix(tokens[0], "!"),
 	}
 
@@ -917,11 +959,15 @@ func ParseCodeOwnersLine(ctx context.Context, tokens []string) (*CodeOwnerRule,
 				continue
 			}
 
-			for _, team := range teams {
-				if team.Name == teamName {
-					rule.Teams = append(rule.Teams, team)
-				}
+			idx := slices.IndexFunc(teams, func(team *org_model.Team) bool {
+				return team.Name == teamName
+			})
+			if idx < 0 {
+				warnings = append(warnings, "incorrect codeowner team: "+user)
+				continue
 			}
+			rule.Teams = append(rule.Teams, teams[idx])
+			rule.Owners = append(rule.Owners, "@"+user)
 		} else {
 			u, err := user_model.GetUserByName(ctx, user)
 			if err != nil {
@@ -929,6 +975,7 @@ func ParseCodeOwnersLine(ctx context.Context, tokens []string) (*CodeOwnerRule,
 				continue
 			}
 			rule.Users = append(rule.Users, u)
+			rule.Owners = append(rule.Owners, "@"+user)
 		}
 	}
 
@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/container"
+)
+
+// maxPullRequestStackDepth limits how far a stack is followed, so that branches
+// targeting each other in a loop can't make the walk run forever.
+const maxPullRequestStackDepth = 32
+
+// A pull request is stacked on another one when its base branch is the head branch
+// of an open pull request living in the same repository. The relationship is derived
+// from the branches, so retargeting or merging a pull request updates the stack
+// without any bookkeeping. The default branch never acts as a stack parent, otherwise
+// every pull request would be stacked on e.g. a "main -> release" pull request.
+
+// GetStackParentPullRequest returns the open pull request that pr is stacked on,
+// or nil if pr targets a branch that isn't the head of another open pull request.
+func GetStackParentPullRequest(ctx context.Context, pr *PullRequest) (*PullRequest, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	if pr.BaseBranch == pr.BaseRepo.DefaultBranch {
+		return nil, nil
+	}
+
+	parent := new(PullRequest)
+	has, err := db.GetEngine(ctx).
+		Join("INNER", "issue", "issue.id=pull_request.issue_id").
+		Where("pull_request.head_repo_id=? AND pull_request.base_repo_id=? AND pull_request.head_branch=? AND pull_request.has_merged=? AND issue.is_closed=? AND pull_request.flow=? AND pull_request.id<>?",
+			pr.BaseRepoID, pr.BaseRepoID, pr.BaseBranch, false, false, PullRequestFlowGithub, pr.ID).
+		OrderBy("pull_request.`index` ASC").
+		Get(parent)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return parent, nil
+}
+
+// GetStackChildPullRequests returns the open pull requests which are stacked on pr,
+// i.e. which target its head branch.
+func GetStackChildPullRequests(ctx context.Context, pr *PullRequest) (PullRequestList, error) {
+	if pr.Flow != PullRequestFlowGithub || pr.HeadRepoID != pr.BaseRepoID {
+		return nil, nil
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	if pr.HeadBranch == pr.BaseRepo.DefaultBranch {
+		return nil, nil
+	}
+
+	prs := make([]*PullRequest, 0, 2)
+	return prs, db.GetEngine(ctx).
+		Join("INNER", "issue", "issue.id=pull_request.issue_id").
+		Where("pull_request.base_repo_id=? AND pull_request.base_branch=? AND pull_request.has_merged=? AND issue.is_closed=? AND pull_request.id<>?",
+			pr.BaseRepoID, pr.HeadBranch, false, false, pr.ID).
+		OrderBy("pull_request.`index` ASC").
+		Find(&prs)
+}
+
+// GetPullRequestStack returns the whole stack pr belongs to, ordered from the bottom
+// (the pull request targeting a regular branch) to the top. Pull requests sharing a
+// parent are listed depth-first in index order. A pull request which isn't stacked
+// returns a stack containing only itself.
+func GetPullRequestStack(ctx context.Context, pr *PullRequest) (PullRequestList, error) {
+	seen := container.SetOf(pr.ID)
+
+	ancestors := make([]*PullRequest, 0, 2)
+	for cur := pr; len(ancestors) < maxPullRequestStackDepth; {
+		parent, err := GetStackParentPullRequest(ctx, cur)
+		if err != nil {
+			return nil, err
+		} else if parent == nil || !seen.Add(parent.ID) {
+			break
+		}
+		ancestors = append(ancestors, parent)
+		cur = parent
+	}
+
+	stack := make(PullRequestList, 0, len(ancestors)+1)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		stack = append(stack, ancestors[i])
+	}
+	stack = append(stack, pr)
+
+	var appendChildren func(cur *PullRequest, depth int) error
+	appendChildren = func(cur *PullRequest, depth int) error {
+		if depth >= maxPullRequestStackDepth {
+			return nil
+		}
+		children, err := GetStackChildPullRequests(ctx, cur)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if !seen.Add(child.ID) {
+				continue
+			}
+			stack = append(stack, child)
+			if err := appendChildren(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := appendChildren(pr, 0); err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	"gitea.dev/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stackPullIDs(prs issues_model.PullRequestList) []int64 {
+	ids := make([]int64, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.ID)
+	}
+	return ids
+}
+
+func TestPullRequestStack(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// pull 5 (pr-to-update -> branch2) is stacked on pull 2 (branch2 -> master)
+	bottom := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	top := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
+
+	parent, err := issues_model.GetStackParentPullRequest(t.Context(), top)
+	require.NoError(t, err)
+	require.NotNil(t, parent)
+	assert.EqualValues(t, 2, parent.ID)
+
+	parent, err = issues_model.GetStackParentPullRequest(t.Context(), bottom)
+	require.NoError(t, err)
+	assert.Nil(t, parent, "the default branch is never a stack parent")
+
+	children, err := issues_model.GetStackChildPullRequests(t.Context(), bottom)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5}, stackPullIDs(children))
+
+	for _, pr := range []*issues_model.PullRequest{bottom, top} {
+		stack, err := issues_model.GetPullRequestStack(t.Context(), pr)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2, 5}, stackPullIDs(stack))
+	}
+
+	// a pull request on a fork isn't stacked on a branch of the base repository
+	forked := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 3})
+	stack, err := issues_model.GetPullRequestStack(t.Context(), forked)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, stackPullIDs(stack))
+
+	// once the parent has been merged the stack falls apart
+	bottom.HasMerged = true
+	_, err = db.GetEngine(t.Context()).ID(bottom.ID).Cols("has_merged").Update(bottom)
+	require.NoError(t, err)
+
+	parent, err = issues_model.GetStackParentPullRequest(t.Context(), top)
+	require.NoError(t, err)
+	assert.Nil(t, parent)
+}
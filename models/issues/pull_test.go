@@ -42,6 +42,7 @@ func TestPullRequest(t *testing.T) {
 	t.Run("ParseCodeOwnersLine", testParseCodeOwnersLine)
 	t.Run("CodeOwnerAbsolutePathPatterns", testCodeOwnerAbsolutePathPatterns)
 	t.Run("CodeOwnerPatternMatchTimeout", testCodeOwnerPatternMatchTimeout)
+	t.Run("ParseCodeOwners", testParseCodeOwners)
 	t.Run("GetApprovers", testGetApprovers)
 	t.Run("GetPullRequestByMergedCommit", testGetPullRequestByMergedCommit)
 	t.Run("Migrate_InsertPullRequests", testMigrateInsertPullRequests)
@@ -395,6 +396,36 @@ func testCodeOwnerPatternMatchTimeout(t *testing.T) {
 	assert.Less(t, elapsed, time.Second, "match timeout did not bound regex evaluation; took %s", elapsed)
 }
 
+func testParseCodeOwners(t *testing.T) {
+	content := "# comment\n" +
+		"docs/.* @user5 @org3/team1\n" +
+		"\n" +
+		"README.md @nonexistent @org3/nonexistent\n" +
+		"(broken @user5\n" +
+		"!.*\\.go @user2 @user5\n" +
+		"orphan\n"
+	rules, warnings := issues_model.ParseCodeOwners(t.Context(), content)
+
+	require.Len(t, rules, 2)
+	assert.Equal(t, 2, rules[0].Line)
+	assert.Equal(t, "docs/.*", rules[0].Pattern)
+	assert.Equal(t, []string{"@user5", "@org3/team1"}, rules[0].Owners)
+	assert.True(t, rules[0].MatchFile("docs/index.md"))
+	assert.False(t, rules[0].MatchFile("README.md"))
+	assert.Equal(t, 6, rules[1].Line)
+	assert.True(t, rules[1].Negative)
+	assert.True(t, rules[1].MatchFile("README.md"))
+	assert.False(t, rules[1].MatchFile("main.go"))
+
+	lines := make([]int, 0, len(warnings))
+	for _, w := range warnings {
+		lines = append(lines, w.Line)
+	}
+	assert.Equal(t, []int{4, 4, 4, 5, 7}, lines)
+	assert.Equal(t, "incorrect codeowner team: org3/nonexistent", warnings[1].Message)
+	assert.Equal(t, "Line: 7: incorrect format", warnings[4].String())
+}
+
 func testGetApprovers(t *testing.T) {
 	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
 	// Official reviews are already deduplicated. Allow unofficial reviews
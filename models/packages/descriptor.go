@@ -15,6 +15,7 @@ import (
 	"gitea.dev/modules/cache"
 	"gitea.dev/modules/json"
 	"gitea.dev/modules/packages/alpine"
+	"gitea.dev/modules/packages/ansible"
 	"gitea.dev/modules/packages/arch"
 	"gitea.dev/modules/packages/cargo"
 	"gitea.dev/modules/packages/chef"
@@ -25,7 +26,9 @@ import (
 	"gitea.dev/modules/packages/cran"
 	"gitea.dev/modules/packages/debian"
 	"gitea.dev/modules/packages/helm"
+	"gitea.dev/modules/packages/hex"
 	"gitea.dev/modules/packages/maven"
+	"gitea.dev/modules/packages/nix"
 	"gitea.dev/modules/packages/npm"
 	"gitea.dev/modules/packages/nuget"
 	"gitea.dev/modules/packages/pub"
@@ -172,6 +175,8 @@ func GetPackageDescriptorWithCache(ctx context.Context, pv *PackageVersion, c *c
 	switch p.Type {
 	case TypeAlpine:
 		metadata = &alpine.VersionMetadata{}
+	case TypeAnsible:
+		metadata = &ansible.Metadata{}
 	case TypeArch:
 		metadata = &arch.VersionMetadata{}
 	case TypeCargo:
@@ -196,12 +201,16 @@ func GetPackageDescriptorWithCache(ctx context.Context, pv *PackageVersion, c *c
 		// go packages have no metadata
 	case TypeHelm:
 		metadata = &helm.Metadata{}
+	case TypeHex:
+		metadata = &hex.Metadata{}
 	case TypeNuGet:
 		metadata = &nuget.Metadata{}
 	case TypeNpm:
 		metadata = &npm.Metadata{}
 	case TypeMaven:
 		metadata = &maven.Metadata{}
+	case TypeNix:
+		metadata = &nix.Metadata{}
 	case TypePub:
 		metadata = &pub.Metadata{}
 	case TypePyPI:
@@ -31,6 +31,7 @@ type Type string
 // List of supported packages
 const (
 	TypeAlpine         Type = "alpine"
+	TypeAnsible        Type = "ansible"
 	TypeArch           Type = "arch"
 	TypeCargo          Type = "cargo"
 	TypeChef           Type = "chef"
@@ -43,7 +44,9 @@ const (
 	TypeGeneric        Type = "generic"
 	TypeGo             Type = "go"
 	TypeHelm           Type = "helm"
+	TypeHex            Type = "hex"
 	TypeMaven          Type = "maven"
+	TypeNix            Type = "nix"
 	TypeNpm            Type = "npm"
 	TypeNuGet          Type = "nuget"
 	TypePub            Type = "pub"
@@ -57,6 +60,7 @@ const (
 
 var TypeList = []Type{
 	TypeAlpine,
+	TypeAnsible,
 	TypeArch,
 	TypeCargo,
 	TypeChef,
@@ -69,7 +73,9 @@ var TypeList = []Type{
 	TypeGeneric,
 	TypeGo,
 	TypeHelm,
+	TypeHex,
 	TypeMaven,
+	TypeNix,
 	TypeNpm,
 	TypeNuGet,
 	TypePub,
@@ -86,6 +92,8 @@ func (pt Type) Name() string {
 	switch pt {
 	case TypeAlpine:
 		return "Alpine"
+	case TypeAnsible:
+		return "Ansible"
 	case TypeArch:
 		return "Arch"
 	case TypeCargo:
@@ -110,8 +118,12 @@ func (pt Type) Name() string {
 		return "Go"
 	case TypeHelm:
 		return "Helm"
+	case TypeHex:
+		return "Hex"
 	case TypeMaven:
 		return "Maven"
+	case TypeNix:
+		return "Nix"
 	case TypeNpm:
 		return "npm"
 	case TypeNuGet:
@@ -139,6 +151,8 @@ func (pt Type) SVGName() string {
 	switch pt {
 	case TypeAlpine:
 		return "gitea-alpine"
+	case TypeAnsible:
+		return "gitea-ansible"
 	case TypeArch:
 		return "gitea-arch"
 	case TypeCargo:
@@ -163,8 +177,12 @@ func (pt Type) SVGName() string {
 		return "gitea-go"
 	case TypeHelm:
 		return "gitea-helm"
+	case TypeHex:
+		return "gitea-hex"
 	case TypeMaven:
 		return "gitea-maven"
+	case TypeNix:
+		return "gitea-nix"
 	case TypeNpm:
 		return "gitea-npm"
 	case TypeNuGet:
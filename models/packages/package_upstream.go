@@ -0,0 +1,126 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"slices"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/secret"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+var ErrPackageUpstreamNotExist = util.NewNotExistErrorf("package upstream does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageUpstream))
+}
+
+// UpstreamTypeList are the package types which can pull packages through from an upstream registry
+var UpstreamTypeList = []Type{
+	TypeContainer,
+	TypeGo,
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// SupportsUpstream returns whether packages of this type can be pulled through from an upstream registry
+func (pt Type) SupportsUpstream() bool {
+	return slices.Contains(UpstreamTypeList, pt)
+}
+
+// PackageUpstream represents a remote registry which is queried for packages that don't exist in the registry of the owner.
+// Packages fetched from the upstream registry are stored as cached versions and served locally afterwards.
+type PackageUpstream struct {
+	ID       int64  `xorm:"pk autoincr"`
+	Enabled  bool   `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID  int64  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type     Type   `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	URL      string `xorm:"TEXT NOT NULL"`
+	Username string `xorm:"NOT NULL DEFAULT ''"`
+	// PasswordEncrypted should be accessed using Password() and SetPassword()
+	PasswordEncrypted string             `xorm:"TEXT"`
+	CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix       timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// Password returns the decrypted password of the upstream registry
+func (pu *PackageUpstream) Password() (string, error) {
+	if pu.PasswordEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, pu.PasswordEncrypted)
+}
+
+// SetPassword encrypts and sets the password of the upstream registry
+func (pu *PackageUpstream) SetPassword(cleartext string) error {
+	if cleartext == "" {
+		pu.PasswordEncrypted = ""
+		return nil
+	}
+	ciphertext, err := secret.EncryptSecret(setting.SecretKey, cleartext)
+	if err != nil {
+		return err
+	}
+	pu.PasswordEncrypted = ciphertext
+	return nil
+}
+
+func InsertUpstream(ctx context.Context, pu *PackageUpstream) (*PackageUpstream, error) {
+	return pu, db.Insert(ctx, pu)
+}
+
+func GetUpstreamByID(ctx context.Context, id int64) (*PackageUpstream, error) {
+	pu := &PackageUpstream{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(pu)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageUpstreamNotExist
+	}
+	return pu, nil
+}
+
+// GetEnabledUpstream gets the enabled upstream registry of the owner for the package type
+func GetEnabledUpstream(ctx context.Context, ownerID int64, packageType Type) (*PackageUpstream, error) {
+	pu := &PackageUpstream{}
+
+	has, err := db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ? AND enabled = ?", ownerID, packageType, true).
+		Get(pu)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageUpstreamNotExist
+	}
+	return pu, nil
+}
+
+func UpdateUpstream(ctx context.Context, pu *PackageUpstream) error {
+	_, err := db.GetEngine(ctx).ID(pu.ID).AllCols().Update(pu)
+	return err
+}
+
+func GetUpstreamsByOwner(ctx context.Context, ownerID int64) ([]*PackageUpstream, error) {
+	pus := make([]*PackageUpstream, 0, 5)
+	return pus, db.GetEngine(ctx).Where("owner_id = ?", ownerID).Find(&pus)
+}
+
+func DeleteUpstreamByID(ctx context.Context, upstreamID int64) error {
+	_, err := db.GetEngine(ctx).ID(upstreamID).Delete(&PackageUpstream{})
+	return err
+}
+
+func HasOwnerUpstreamForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Exist(&PackageUpstream{})
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// AutomationEvent is the issue event which triggers an automation
+type AutomationEvent uint8
+
+const (
+	// AutomationEventIssueOpened is triggered when an issue or a pull request is created
+	AutomationEventIssueOpened AutomationEvent = iota + 1
+	// AutomationEventIssueLabeled is triggered when a label is added to an issue or a pull request
+	AutomationEventIssueLabeled
+	// AutomationEventIssueClosed is triggered when an issue or a pull request is closed
+	AutomationEventIssueClosed
+	// AutomationEventIssueReopened is triggered when an issue or a pull request is reopened
+	AutomationEventIssueReopened
+	// AutomationEventPullRequestMerged is triggered when a pull request is merged,
+	// it moves the pull request and the issues closed by it
+	AutomationEventPullRequestMerged
+)
+
+var automationEventNames = map[AutomationEvent]string{
+	AutomationEventIssueOpened:       "issue_opened",
+	AutomationEventIssueLabeled:      "issue_labeled",
+	AutomationEventIssueClosed:       "issue_closed",
+	AutomationEventIssueReopened:     "issue_reopened",
+	AutomationEventPullRequestMerged: "pull_request_merged",
+}
+
+// AutomationEvents returns all the events in the order they are shown to the users
+func AutomationEvents() []AutomationEvent {
+	return []AutomationEvent{
+		AutomationEventIssueOpened,
+		AutomationEventIssueLabeled,
+		AutomationEventIssueClosed,
+		AutomationEventIssueReopened,
+		AutomationEventPullRequestMerged,
+	}
+}
+
+// ParseAutomationEvent returns the event of the name, or 0 if the name is unknown
+func ParseAutomationEvent(name string) AutomationEvent {
+	for event, eventName := range automationEventNames {
+		if eventName == name {
+			return event
+		}
+	}
+	return 0
+}
+
+func (e AutomationEvent) String() string {
+	return automationEventNames[e]
+}
+
+// IsValid returns whether the event is a known one
+func (e AutomationEvent) IsValid() bool {
+	_, ok := automationEventNames[e]
+	return ok
+}
+
+// CanAddIssue returns whether the event adds a matching issue to the project when it isn't in the project yet.
+// The other events only move the issues which are already in the project.
+func (e AutomationEvent) CanAddIssue() bool {
+	return e == AutomationEventIssueOpened || e == AutomationEventIssueLabeled
+}
+
+// Automation moves the issues of a project to a column when an issue event happens
+type Automation struct {
+	ID        int64           `xorm:"pk autoincr"`
+	ProjectID int64           `xorm:"INDEX NOT NULL"`
+	Event     AutomationEvent `xorm:"NOT NULL"`
+	// LabelID limits the automation to the issues with the label, 0 matches all issues.
+	// For AutomationEventIssueLabeled it is the label which has to be added.
+	LabelID   int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ColumnID  int64 `xorm:"INDEX NOT NULL"`
+	CreatorID int64 `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (Automation) TableName() string {
+	return "project_automation"
+}
+
+func init() {
+	db.RegisterModel(new(Automation))
+}
+
+// ErrProjectAutomationNotExist represents a "ProjectAutomationNotExist" kind of error.
+type ErrProjectAutomationNotExist struct {
+	ID int64
+}
+
+// IsErrProjectAutomationNotExist checks if an error is a ErrProjectAutomationNotExist
+func IsErrProjectAutomationNotExist(err error) bool {
+	_, ok := err.(ErrProjectAutomationNotExist)
+	return ok
+}
+
+func (err ErrProjectAutomationNotExist) Error() string {
+	return fmt.Sprintf("project automation does not exist [id: %d]", err.ID)
+}
+
+func (err ErrProjectAutomationNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+func validateAutomation(ctx context.Context, a *Automation) error {
+	if !a.Event.IsValid() {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "invalid automation event %d", a.Event)
+	}
+	if a.Event == AutomationEventIssueLabeled && a.LabelID == 0 {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "the %s automation needs a label", a.Event)
+	}
+	if _, err := GetColumnByIDAndProjectID(ctx, a.ColumnID, a.ProjectID); err != nil {
+		if IsErrProjectColumnNotExist(err) {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "column %d is not in project %d", a.ColumnID, a.ProjectID)
+		}
+		return err
+	}
+	return nil
+}
+
+// NewAutomation adds an automation to a project, the column has to be in the project
+func NewAutomation(ctx context.Context, a *Automation) error {
+	if err := validateAutomation(ctx, a); err != nil {
+		return err
+	}
+	return db.Insert(ctx, a)
+}
+
+// UpdateAutomation writes the event, label and column of the automation
+func UpdateAutomation(ctx context.Context, a *Automation) error {
+	if err := validateAutomation(ctx, a); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols("event", "label_id", "column_id").Update(a)
+	return err
+}
+
+// GetAutomationByIDAndProjectID returns the automation of the project
+func GetAutomationByIDAndProjectID(ctx context.Context, id, projectID int64) (*Automation, error) {
+	a := new(Automation)
+	has, err := db.GetEngine(ctx).ID(id).And("project_id=?", projectID).Get(a)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectAutomationNotExist{ID: id}
+	}
+	return a, nil
+}
+
+// GetAutomations returns all the automations of a project
+func GetAutomations(ctx context.Context, projectID int64) ([]*Automation, error) {
+	automations := make([]*Automation, 0, 5)
+	return automations, db.GetEngine(ctx).Where("project_id=?", projectID).OrderBy("id").Find(&automations)
+}
+
+// DeleteAutomationByID deletes an automation
+func DeleteAutomationByID(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(new(Automation))
+	return err
+}
+
+// DeleteAutomationsByLabelID deletes the automations which depend on the label
+func DeleteAutomationsByLabelID(ctx context.Context, labelID int64) error {
+	_, err := db.GetEngine(ctx).Where("label_id=?", labelID).Delete(new(Automation))
+	return err
+}
+
+func deleteAutomationsByColumnID(ctx context.Context, columnID int64) error {
+	_, err := db.GetEngine(ctx).Where("column_id=?", columnID).Delete(new(Automation))
+	return err
+}
+
+func deleteAutomationsByProjectID(ctx context.Context, projectID int64) error {
+	_, err := db.GetEngine(ctx).Where("project_id=?", projectID).Delete(new(Automation))
+	return err
+}
+
+// FindAutomationsForRepo returns the automations of the event of the open projects which may contain
+// the issues of the repository: the projects of the repository and the projects of its owner.
+func FindAutomationsForRepo(ctx context.Context, event AutomationEvent, repoID, ownerID int64) ([]*Automation, error) {
+	automations := make([]*Automation, 0, 5)
+	return automations, db.GetEngine(ctx).
+		Join("INNER", "project", "project.id = project_automation.project_id").
+		Where(builder.Eq{"project_automation.event": event, "project.is_closed": false}).
+		And(builder.Or(
+			builder.Eq{"project.repo_id": repoID},
+			builder.Eq{"project.repo_id": 0, "project.owner_id": ownerID},
+		)).
+		OrderBy("project_automation.id").
+		Find(&automations)
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationEvent(t *testing.T) {
+	for _, event := range AutomationEvents() {
+		assert.Equal(t, event, ParseAutomationEvent(event.String()))
+	}
+	assert.Zero(t, ParseAutomationEvent("unknown"))
+	assert.True(t, AutomationEventIssueLabeled.CanAddIssue())
+	assert.False(t, AutomationEventIssueClosed.CanAddIssue())
+}
+
+func TestNewAutomation(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// column 4 belongs to project 4
+	err := NewAutomation(t.Context(), &Automation{ProjectID: 1, Event: AutomationEventIssueClosed, ColumnID: 4, CreatorID: 2})
+	assert.ErrorIs(t, err, util.ErrUnprocessableContent)
+
+	err = NewAutomation(t.Context(), &Automation{ProjectID: 1, Event: AutomationEventIssueLabeled, ColumnID: 3, CreatorID: 2})
+	assert.ErrorIs(t, err, util.ErrUnprocessableContent)
+
+	err = NewAutomation(t.Context(), &Automation{ProjectID: 1, Event: 100, ColumnID: 3, CreatorID: 2})
+	assert.ErrorIs(t, err, util.ErrUnprocessableContent)
+
+	a := &Automation{ProjectID: 1, Event: AutomationEventIssueClosed, ColumnID: 3, CreatorID: 2}
+	require.NoError(t, NewAutomation(t.Context(), a))
+	unittest.AssertExistsAndLoadBean(t, &Automation{ID: a.ID, ProjectID: 1, ColumnID: 3})
+
+	a.ColumnID = 4
+	assert.ErrorIs(t, UpdateAutomation(t.Context(), a), util.ErrUnprocessableContent)
+	a.ColumnID = 2
+	require.NoError(t, UpdateAutomation(t.Context(), a))
+	unittest.AssertExistsAndLoadBean(t, &Automation{ID: a.ID, ColumnID: 2})
+}
+
+func TestFindAutomationsForRepo(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repoAutomation := &Automation{ProjectID: 1, Event: AutomationEventIssueClosed, ColumnID: 3, CreatorID: 2}
+	ownerAutomation := &Automation{ProjectID: 4, Event: AutomationEventIssueClosed, ColumnID: 4, CreatorID: 2}
+	otherRepoAutomation := &Automation{ProjectID: 2, Event: AutomationEventIssueClosed, ColumnID: 5, CreatorID: 2}
+	otherEventAutomation := &Automation{ProjectID: 1, Event: AutomationEventIssueReopened, ColumnID: 1, CreatorID: 2}
+	for _, a := range []*Automation{repoAutomation, ownerAutomation, otherRepoAutomation, otherEventAutomation} {
+		require.NoError(t, NewAutomation(t.Context(), a))
+	}
+
+	// repo1 is owned by user2, who owns project 4
+	automations, err := FindAutomationsForRepo(t.Context(), AutomationEventIssueClosed, 1, 2)
+	require.NoError(t, err)
+	if assert.Len(t, automations, 2) {
+		assert.Equal(t, repoAutomation.ID, automations[0].ID)
+		assert.Equal(t, ownerAutomation.ID, automations[1].ID)
+	}
+
+	// the automations of closed projects are ignored
+	require.NoError(t, ChangeProjectStatusByRepoIDAndID(t.Context(), 1, 1, true))
+	automations, err = FindAutomationsForRepo(t.Context(), AutomationEventIssueClosed, 1, 2)
+	require.NoError(t, err)
+	if assert.Len(t, automations, 1) {
+		assert.Equal(t, ownerAutomation.ID, automations[0].ID)
+	}
+}
+
+func TestDeleteColumnDeletesAutomations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	a := &Automation{ProjectID: 1, Event: AutomationEventIssueClosed, ColumnID: 3, CreatorID: 2}
+	require.NoError(t, NewAutomation(t.Context(), a))
+	require.NoError(t, DeleteColumnByID(t.Context(), 3))
+	unittest.AssertNotExistsBean(t, &Automation{ID: a.ID})
+
+	a = &Automation{ProjectID: 1, Event: AutomationEventIssueClosed, ColumnID: 2, CreatorID: 2}
+	require.NoError(t, NewAutomation(t.Context(), a))
+	require.NoError(t, DeleteProjectByID(t.Context(), 1))
+	unittest.AssertNotExistsBean(t, &Automation{ID: a.ID})
+}
@@ -0,0 +1,495 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// FieldType is the type of the values of a project field
+type FieldType uint8
+
+const (
+	// FieldTypeText holds a single line of text
+	FieldTypeText FieldType = iota + 1
+	// FieldTypeNumber holds a number, for example an estimate
+	FieldTypeNumber
+	// FieldTypeDate holds a date without time
+	FieldTypeDate
+	// FieldTypeSingleSelect holds one of the options of the field
+	FieldTypeSingleSelect
+	// FieldTypeIteration holds one of the iterations of the field, every iteration has a date range
+	FieldTypeIteration
+)
+
+var fieldTypeNames = map[FieldType]string{
+	FieldTypeText:         "text",
+	FieldTypeNumber:       "number",
+	FieldTypeDate:         "date",
+	FieldTypeSingleSelect: "single_select",
+	FieldTypeIteration:    "iteration",
+}
+
+// ParseFieldType returns the field type of the name, or 0 if the name is unknown
+func ParseFieldType(name string) FieldType {
+	for fieldType, typeName := range fieldTypeNames {
+		if typeName == name {
+			return fieldType
+		}
+	}
+	return 0
+}
+
+func (t FieldType) String() string {
+	return fieldTypeNames[t]
+}
+
+// IsValid returns whether the field type is a known one
+func (t FieldType) IsValid() bool {
+	_, ok := fieldTypeNames[t]
+	return ok
+}
+
+// HasOptions returns whether the values of the field are chosen from its options
+func (t FieldType) HasOptions() bool {
+	return t == FieldTypeSingleSelect || t == FieldTypeIteration
+}
+
+// HasDates returns whether the values of the field can place the issues on a roadmap
+func (t FieldType) HasDates() bool {
+	return t == FieldTypeDate || t == FieldTypeIteration
+}
+
+const (
+	// FieldDateFormat is the format of the date values and of the iteration dates
+	FieldDateFormat = time.DateOnly
+
+	maxFieldNameLength  = 255
+	maxFieldValueLength = 255
+)
+
+// FieldOption is an option of a single select field or an iteration of an iteration field
+type FieldOption struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// StartDate and EndDate are only used by iterations, both are inclusive
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+// Field is a typed custom field of a project, its values are stored per issue of the project
+type Field struct {
+	ID        int64          `xorm:"pk autoincr"`
+	ProjectID int64          `xorm:"INDEX NOT NULL"`
+	Name      string         `xorm:"NOT NULL"`
+	Type      FieldType      `xorm:"NOT NULL"`
+	Options   []*FieldOption `xorm:"JSON TEXT"`
+	CreatorID int64          `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (Field) TableName() string {
+	return "project_field"
+}
+
+// FieldValue is the value of a project field for an issue of the project.
+// The value is normalized: numbers are formatted without exponent, dates use FieldDateFormat
+// and the fields with options store the ID of the chosen option.
+type FieldValue struct {
+	ID        int64  `xorm:"pk autoincr"`
+	ProjectID int64  `xorm:"INDEX NOT NULL"`
+	FieldID   int64  `xorm:"UNIQUE(s) NOT NULL"`
+	IssueID   int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value     string `xorm:"VARCHAR(255) NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (FieldValue) TableName() string {
+	return "project_issue_field_value"
+}
+
+func init() {
+	db.RegisterModel(new(Field))
+	db.RegisterModel(new(FieldValue))
+}
+
+// ErrProjectFieldNotExist represents a "ProjectFieldNotExist" kind of error.
+type ErrProjectFieldNotExist struct {
+	ID int64
+}
+
+// IsErrProjectFieldNotExist checks if an error is a ErrProjectFieldNotExist
+func IsErrProjectFieldNotExist(err error) bool {
+	_, ok := err.(ErrProjectFieldNotExist)
+	return ok
+}
+
+func (err ErrProjectFieldNotExist) Error() string {
+	return fmt.Sprintf("project field does not exist [id: %d]", err.ID)
+}
+
+func (err ErrProjectFieldNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// OptionByID returns the option of the field, or nil if there is no such option
+func (f *Field) OptionByID(id int64) *FieldOption {
+	for _, option := range f.Options {
+		if option.ID == id {
+			return option
+		}
+	}
+	return nil
+}
+
+// NormalizeValue validates a value of the field and returns the form it is stored in
+func (f *Field) NormalizeValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch f.Type {
+	case FieldTypeText:
+		if utf8.RuneCountInString(value) > maxFieldValueLength {
+			return "", util.ErrorWrap(util.ErrUnprocessableContent, "the value of field %q is longer than %d characters", f.Name, maxFieldValueLength)
+		}
+		return value, nil
+	case FieldTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return "", util.ErrorWrap(util.ErrUnprocessableContent, "the value of field %q is not a number", f.Name)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case FieldTypeDate:
+		date, err := time.Parse(FieldDateFormat, value)
+		if err != nil {
+			return "", util.ErrorWrap(util.ErrUnprocessableContent, "the value of field %q is not a date like 2006-01-02", f.Name)
+		}
+		return date.Format(FieldDateFormat), nil
+	case FieldTypeSingleSelect, FieldTypeIteration:
+		optionID, _ := strconv.ParseInt(value, 10, 64)
+		if f.OptionByID(optionID) == nil {
+			return "", util.ErrorWrap(util.ErrUnprocessableContent, "field %q has no option %q", f.Name, value)
+		}
+		return strconv.FormatInt(optionID, 10), nil
+	}
+	return "", util.ErrorWrap(util.ErrUnprocessableContent, "invalid field type %d", f.Type)
+}
+
+// DisplayValue returns the stored value as it is shown to the users
+func (f *Field) DisplayValue(value string) string {
+	if f.Type.HasOptions() {
+		optionID, _ := strconv.ParseInt(value, 10, 64)
+		if option := f.OptionByID(optionID); option != nil {
+			return option.Name
+		}
+	}
+	return value
+}
+
+func validateFieldOptions(f *Field) error {
+	if !f.Type.HasOptions() {
+		if len(f.Options) > 0 {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "%s fields have no options", f.Type)
+		}
+		return nil
+	}
+	names := make(container.Set[string], len(f.Options))
+	for _, option := range f.Options {
+		option.Name = strings.TrimSpace(option.Name)
+		if option.Name == "" {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "the options need a name")
+		}
+		if !names.Add(option.Name) {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "duplicate option %q", option.Name)
+		}
+		if f.Type != FieldTypeIteration {
+			option.StartDate, option.EndDate = "", ""
+			continue
+		}
+		start, err := time.Parse(FieldDateFormat, option.StartDate)
+		if err != nil {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "iteration %q has an invalid start date", option.Name)
+		}
+		end, err := time.Parse(FieldDateFormat, option.EndDate)
+		if err != nil {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "iteration %q has an invalid end date", option.Name)
+		}
+		if end.Before(start) {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "iteration %q ends before it starts", option.Name)
+		}
+	}
+	return nil
+}
+
+func validateField(ctx context.Context, f *Field) error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" || utf8.RuneCountInString(f.Name) > maxFieldNameLength {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "the field name must have 1 to %d characters", maxFieldNameLength)
+	}
+	if !f.Type.IsValid() {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "invalid field type %d", f.Type)
+	}
+	if err := validateFieldOptions(f); err != nil {
+		return err
+	}
+	exist, err := db.GetEngine(ctx).Where("project_id=? AND name=? AND id<>?", f.ProjectID, f.Name, f.ID).Exist(new(Field))
+	if err != nil {
+		return err
+	} else if exist {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "project %d already has a field %q", f.ProjectID, f.Name)
+	}
+	return nil
+}
+
+// assignOptionIDs keeps the IDs of the existing options and numbers the new ones after the highest ID
+func assignOptionIDs(oldOptions, newOptions []*FieldOption) error {
+	existing := make(container.Set[int64], len(oldOptions))
+	var maxID int64
+	for _, option := range oldOptions {
+		existing.Add(option.ID)
+		maxID = max(maxID, option.ID)
+	}
+	used := make(container.Set[int64], len(newOptions))
+	for _, option := range newOptions {
+		if option.ID == 0 {
+			continue
+		}
+		if !existing.Contains(option.ID) || !used.Add(option.ID) {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "invalid option id %d", option.ID)
+		}
+	}
+	for _, option := range newOptions {
+		if option.ID == 0 {
+			maxID++
+			option.ID = maxID
+		}
+	}
+	return nil
+}
+
+// NewField adds a field to a project
+func NewField(ctx context.Context, f *Field) error {
+	if err := validateField(ctx, f); err != nil {
+		return err
+	}
+	if err := assignOptionIDs(nil, f.Options); err != nil {
+		return err
+	}
+	return db.Insert(ctx, f)
+}
+
+// UpdateField writes the name and the options of the field, the type can't be changed.
+// The values of the removed options are deleted.
+func UpdateField(ctx context.Context, f *Field) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		old, err := GetFieldByIDAndProjectID(ctx, f.ID, f.ProjectID)
+		if err != nil {
+			return err
+		}
+		f.Type = old.Type
+		if err := validateField(ctx, f); err != nil {
+			return err
+		}
+		if err := assignOptionIDs(old.Options, f.Options); err != nil {
+			return err
+		}
+
+		var removed []string
+		for _, option := range old.Options {
+			if f.OptionByID(option.ID) == nil {
+				removed = append(removed, strconv.FormatInt(option.ID, 10))
+			}
+		}
+		if len(removed) > 0 {
+			if _, err := db.GetEngine(ctx).Where("field_id=?", f.ID).In("value", removed).Delete(new(FieldValue)); err != nil {
+				return err
+			}
+		}
+
+		_, err = db.GetEngine(ctx).ID(f.ID).Cols("name", "options").Update(f)
+		return err
+	})
+}
+
+// GetFieldByIDAndProjectID returns the field of the project
+func GetFieldByIDAndProjectID(ctx context.Context, id, projectID int64) (*Field, error) {
+	f := new(Field)
+	has, err := db.GetEngine(ctx).ID(id).And("project_id=?", projectID).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectFieldNotExist{ID: id}
+	}
+	return f, nil
+}
+
+// GetFields returns all the fields of a project
+func GetFields(ctx context.Context, projectID int64) ([]*Field, error) {
+	fields := make([]*Field, 0, 5)
+	return fields, db.GetEngine(ctx).Where("project_id=?", projectID).OrderBy("id").Find(&fields)
+}
+
+// DeleteFieldByID deletes a field and its values, the views using the field fall back to their defaults
+func DeleteFieldByID(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		f := new(Field)
+		if has, err := db.GetEngine(ctx).ID(id).Get(f); err != nil || !has {
+			return err
+		}
+		if err := resetViewsOfField(ctx, f.ProjectID, id); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("field_id=?", id).Delete(new(FieldValue)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(new(Field))
+		return err
+	})
+}
+
+func deleteFieldsByProjectID(ctx context.Context, projectID int64) error {
+	if _, err := db.GetEngine(ctx).Where("project_id=?", projectID).Delete(new(FieldValue)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("project_id=?", projectID).Delete(new(Field))
+	return err
+}
+
+// GetFieldIssueIDs returns the IDs of the issues which have a value for the field
+func GetFieldIssueIDs(ctx context.Context, fieldID int64) ([]int64, error) {
+	issueIDs := make([]int64, 0, 10)
+	return issueIDs, db.GetEngine(ctx).Table("project_issue_field_value").Where("field_id=?", fieldID).Cols("issue_id").Find(&issueIDs)
+}
+
+// SetIssueFieldValue sets the value of the field for an issue of the field's project, an empty value clears it
+func SetIssueFieldValue(ctx context.Context, f *Field, issueID int64, value string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		inProject, err := IsIssueInProject(ctx, issueID, f.ProjectID)
+		if err != nil {
+			return err
+		} else if !inProject {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "issue %d is not in project %d", issueID, f.ProjectID)
+		}
+
+		if strings.TrimSpace(value) == "" {
+			_, err := db.GetEngine(ctx).Where("field_id=? AND issue_id=?", f.ID, issueID).Delete(new(FieldValue))
+			return err
+		}
+		value, err = f.NormalizeValue(value)
+		if err != nil {
+			return err
+		}
+
+		fv := new(FieldValue)
+		has, err := db.GetEngine(ctx).Where("field_id=? AND issue_id=?", f.ID, issueID).Get(fv)
+		if err != nil {
+			return err
+		} else if has {
+			fv.Value = value
+			_, err = db.GetEngine(ctx).ID(fv.ID).Cols("value").Update(fv)
+			return err
+		}
+		return db.Insert(ctx, &FieldValue{ProjectID: f.ProjectID, FieldID: f.ID, IssueID: issueID, Value: value})
+	})
+}
+
+// GetIssueFieldValues returns the field values of an issue in all its projects
+func GetIssueFieldValues(ctx context.Context, issueID int64) ([]*FieldValue, error) {
+	values := make([]*FieldValue, 0, 5)
+	return values, db.GetEngine(ctx).Where("issue_id=?", issueID).OrderBy("field_id").Find(&values)
+}
+
+// GetProjectIssueFieldValues returns the field values of an issue in a project
+func GetProjectIssueFieldValues(ctx context.Context, projectID, issueID int64) ([]*FieldValue, error) {
+	values := make([]*FieldValue, 0, 5)
+	return values, db.GetEngine(ctx).Where("project_id=? AND issue_id=?", projectID, issueID).OrderBy("field_id").Find(&values)
+}
+
+// GetFieldValuesMap returns the field values of the issues of a project, mapped by issue ID and field ID
+func GetFieldValuesMap(ctx context.Context, projectID int64, issueIDs []int64) (map[int64]map[int64]string, error) {
+	result := make(map[int64]map[int64]string, len(issueIDs))
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	values := make([]*FieldValue, 0, len(issueIDs))
+	if err := db.GetEngine(ctx).Where("project_id=?", projectID).In("issue_id", issueIDs).Find(&values); err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		if result[v.IssueID] == nil {
+			result[v.IssueID] = make(map[int64]string)
+		}
+		result[v.IssueID][v.FieldID] = v.Value
+	}
+	return result, nil
+}
+
+// FieldValueCond returns the condition of the issues which have the value for the field
+func FieldValueCond(fieldID int64, value string) builder.Cond {
+	return builder.In("issue.id", builder.Select("issue_id").From("project_issue_field_value").Where(builder.Eq{"field_id": fieldID, "value": value}))
+}
+
+// DeleteIssueFieldValues deletes the field values of the issues in the projects, it is used when
+// the issues are removed from the projects
+func DeleteIssueFieldValues(ctx context.Context, issueIDs, projectIDs []int64) error {
+	_, err := db.GetEngine(ctx).In("project_id", projectIDs).In("issue_id", issueIDs).Delete(new(FieldValue))
+	return err
+}
+
+// CompareValues orders two stored values of the field: numbers numerically, options in the order
+// of the field's options and the other values alphabetically
+func (f *Field) CompareValues(a, b string) int {
+	switch f.Type {
+	case FieldTypeNumber:
+		x, _ := strconv.ParseFloat(a, 64)
+		y, _ := strconv.ParseFloat(b, 64)
+		return cmp.Compare(x, y)
+	case FieldTypeSingleSelect, FieldTypeIteration:
+		return cmp.Compare(f.optionIndex(a), f.optionIndex(b))
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func (f *Field) optionIndex(value string) int {
+	optionID, _ := strconv.ParseInt(value, 10, 64)
+	for i, option := range f.Options {
+		if option.ID == optionID {
+			return i
+		}
+	}
+	return len(f.Options)
+}
+
+// DateRange returns the inclusive dates of a stored value of a date or iteration field,
+// formatted with FieldDateFormat. A date value starts and ends on the same day.
+func (f *Field) DateRange(value string) (start, end string, ok bool) {
+	switch f.Type {
+	case FieldTypeDate:
+		return value, value, value != ""
+	case FieldTypeIteration:
+		optionID, _ := strconv.ParseInt(value, 10, 64)
+		if option := f.OptionByID(optionID); option != nil {
+			return option.StartDate, option.EndDate, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldNormalizeValue(t *testing.T) {
+	selectField := &Field{Type: FieldTypeSingleSelect, Options: []*FieldOption{{ID: 1, Name: "High"}, {ID: 2, Name: "Low"}}}
+
+	cases := []struct {
+		field    *Field
+		value    string
+		expected string
+	}{
+		{&Field{Type: FieldTypeText}, " story ", "story"},
+		{&Field{Type: FieldTypeNumber}, "3", "3"},
+		{&Field{Type: FieldTypeNumber}, "0.50", "0.5"},
+		{&Field{Type: FieldTypeNumber}, "1e3", "1000"},
+		{&Field{Type: FieldTypeDate}, "2026-03-01", "2026-03-01"},
+		{selectField, "02", "2"},
+	}
+	for _, c := range cases {
+		value, err := c.field.NormalizeValue(c.value)
+		require.NoError(t, err, c.value)
+		assert.Equal(t, c.expected, value)
+	}
+
+	for _, c := range []struct {
+		field *Field
+		value string
+	}{
+		{&Field{Type: FieldTypeNumber}, "three"},
+		{&Field{Type: FieldTypeNumber}, "NaN"},
+		{&Field{Type: FieldTypeDate}, "01/03/2026"},
+		{selectField, "3"},
+		{selectField, "High"},
+	} {
+		_, err := c.field.NormalizeValue(c.value)
+		assert.ErrorIs(t, err, util.ErrUnprocessableContent, c.value)
+	}
+
+	assert.Equal(t, "Low", selectField.DisplayValue("2"))
+}
+
+func TestFieldCompareValues(t *testing.T) {
+	assert.Negative(t, (&Field{Type: FieldTypeNumber}).CompareValues("9", "10"))
+	assert.Positive(t, (&Field{Type: FieldTypeText}).CompareValues("b", "A"))
+	assert.Negative(t, (&Field{Type: FieldTypeDate}).CompareValues("2026-01-31", "2026-02-01"))
+
+	// the options are ordered as the field lists them, not by ID
+	selectField := &Field{Type: FieldTypeSingleSelect, Options: []*FieldOption{{ID: 2, Name: "High"}, {ID: 1, Name: "Low"}}}
+	assert.Negative(t, selectField.CompareValues("2", "1"))
+}
+
+func TestFieldDateRange(t *testing.T) {
+	start, end, ok := (&Field{Type: FieldTypeDate}).DateRange("2026-03-01")
+	assert.True(t, ok)
+	assert.Equal(t, "2026-03-01", start)
+	assert.Equal(t, "2026-03-01", end)
+
+	sprint := &Field{Type: FieldTypeIteration, Options: []*FieldOption{{ID: 1, Name: "Sprint 1", StartDate: "2026-03-01", EndDate: "2026-03-14"}}}
+	start, end, ok = sprint.DateRange("1")
+	assert.True(t, ok)
+	assert.Equal(t, "2026-03-01", start)
+	assert.Equal(t, "2026-03-14", end)
+
+	_, _, ok = sprint.DateRange("2")
+	assert.False(t, ok)
+	_, _, ok = (&Field{Type: FieldTypeNumber}).DateRange("3")
+	assert.False(t, ok)
+}
+
+func TestNewField(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	for _, f := range []*Field{
+		{ProjectID: 1, Name: " ", Type: FieldTypeText},
+		{ProjectID: 1, Name: "Estimate", Type: 100},
+		{ProjectID: 1, Name: "Estimate", Type: FieldTypeNumber, Options: []*FieldOption{{Name: "1"}}},
+		{ProjectID: 1, Name: "Priority", Type: FieldTypeSingleSelect, Options: []*FieldOption{{Name: "High"}, {Name: "High"}}},
+		{ProjectID: 1, Name: "Sprint", Type: FieldTypeIteration, Options: []*FieldOption{{Name: "Sprint 1", StartDate: "2026-03-14", EndDate: "2026-03-01"}}},
+	} {
+		assert.ErrorIs(t, NewField(t.Context(), f), util.ErrUnprocessableContent, f.Name)
+	}
+
+	sprint := &Field{ProjectID: 1, Name: "Sprint", Type: FieldTypeIteration, Options: []*FieldOption{
+		{Name: "Sprint 1", StartDate: "2026-03-01", EndDate: "2026-03-14"},
+		{Name: "Sprint 2", StartDate: "2026-03-15", EndDate: "2026-03-28"},
+	}}
+	require.NoError(t, NewField(t.Context(), sprint))
+	assert.EqualValues(t, 1, sprint.Options[0].ID)
+	assert.EqualValues(t, 2, sprint.Options[1].ID)
+
+	loaded, err := GetFieldByIDAndProjectID(t.Context(), sprint.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, sprint.Options, loaded.Options)
+
+	// the names are unique in a project only
+	assert.ErrorIs(t, NewField(t.Context(), &Field{ProjectID: 1, Name: "Sprint", Type: FieldTypeText}), util.ErrUnprocessableContent)
+	require.NoError(t, NewField(t.Context(), &Field{ProjectID: 2, Name: "Sprint", Type: FieldTypeText}))
+
+	_, err = GetFieldByIDAndProjectID(t.Context(), sprint.ID, 2)
+	assert.True(t, IsErrProjectFieldNotExist(err))
+}
+
+func TestIssueFieldValues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	priority := &Field{ProjectID: 1, Name: "Priority", Type: FieldTypeSingleSelect, Options: []*FieldOption{{Name: "High"}, {Name: "Low"}}}
+	require.NoError(t, NewField(t.Context(), priority))
+	estimate := &Field{ProjectID: 1, Name: "Estimate", Type: FieldTypeNumber}
+	require.NoError(t, NewField(t.Context(), estimate))
+
+	// issue 4 isn't in project 1
+	assert.ErrorIs(t, SetIssueFieldValue(t.Context(), estimate, 4, "3"), util.ErrUnprocessableContent)
+	assert.ErrorIs(t, SetIssueFieldValue(t.Context(), estimate, 1, "three"), util.ErrUnprocessableContent)
+
+	require.NoError(t, SetIssueFieldValue(t.Context(), estimate, 1, "3"))
+	require.NoError(t, SetIssueFieldValue(t.Context(), estimate, 1, "5.0"))
+	require.NoError(t, SetIssueFieldValue(t.Context(), priority, 1, "2"))
+	require.NoError(t, SetIssueFieldValue(t.Context(), priority, 2, "1"))
+
+	valuesMap, err := GetFieldValuesMap(t.Context(), 1, []int64{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]map[int64]string{
+		1: {estimate.ID: "5", priority.ID: "2"},
+		2: {priority.ID: "1"},
+	}, valuesMap)
+
+	// clearing a value
+	require.NoError(t, SetIssueFieldValue(t.Context(), estimate, 1, ""))
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: estimate.ID, IssueID: 1})
+
+	// removing an option clears its values, the kept option keeps its ID and the ID of the removed one isn't reused
+	priority.Options = []*FieldOption{{ID: 1, Name: "Urgent"}, {Name: "Someday"}}
+	require.NoError(t, UpdateField(t.Context(), priority))
+	assert.EqualValues(t, 3, priority.Options[1].ID)
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: priority.ID, IssueID: 1})
+	unittest.AssertExistsAndLoadBean(t, &FieldValue{FieldID: priority.ID, IssueID: 2, Value: "1"})
+
+	priority.Options = []*FieldOption{{ID: 5, Name: "Unknown"}}
+	assert.ErrorIs(t, UpdateField(t.Context(), priority), util.ErrUnprocessableContent)
+
+	// removing the issue from the project or deleting the project deletes the values
+	require.NoError(t, SetIssueFieldValue(t.Context(), estimate, 3, "8"))
+	require.NoError(t, DeleteAllProjectIssueByIssueIDsAndProjectIDs(t.Context(), []int64{3}, []int64{1}))
+	unittest.AssertNotExistsBean(t, &FieldValue{IssueID: 3})
+
+	require.NoError(t, DeleteProjectByID(t.Context(), 1))
+	unittest.AssertCount(t, &FieldValue{ProjectID: 1}, 0)
+	unittest.AssertCount(t, &Field{ProjectID: 1}, 0)
+}
+
+func TestDeleteFieldByID(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	f := &Field{ProjectID: 1, Name: "Notes", Type: FieldTypeText}
+	require.NoError(t, NewField(t.Context(), f))
+	require.NoError(t, SetIssueFieldValue(t.Context(), f, 1, "blocked by the API"))
+
+	issueIDs, err := GetFieldIssueIDs(t.Context(), f.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, issueIDs)
+
+	require.NoError(t, DeleteFieldByID(t.Context(), f.ID))
+	unittest.AssertNotExistsBean(t, &Field{ID: f.ID})
+	unittest.AssertNotExistsBean(t, &FieldValue{FieldID: f.ID})
+
+	has, err := db.GetEngine(t.Context()).Exist(&FieldValue{})
+	require.NoError(t, err)
+	assert.False(t, has)
+}
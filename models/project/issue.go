@@ -43,6 +43,11 @@ func columnIssueIDs(column *Column) []int64 {
 	return []int64{column.ID}
 }
 
+// IsIssueInProject reports whether the issue has been added to the project.
+func IsIssueInProject(ctx context.Context, issueID, projectID int64) (bool, error) {
+	return db.GetEngine(ctx).Where("issue_id=? AND project_id=?", issueID, projectID).Exist(new(ProjectIssue))
+}
+
 // IsIssueInColumn reports whether the issue is placed in the column.
 func IsIssueInColumn(ctx context.Context, issueID int64, column *Column) (bool, error) {
 	return db.GetEngine(ctx).
@@ -112,6 +117,8 @@ func moveIssuesToAnotherColumn(ctx context.Context, oldColumn, newColumn *Column
 
 // DeleteAllProjectIssueByIssueIDsAndProjectIDs delete all project's issues by issue's and project's ids
 func DeleteAllProjectIssueByIssueIDsAndProjectIDs(ctx context.Context, issueIDs, projectIDs []int64) error {
-	_, err := db.GetEngine(ctx).In("project_id", projectIDs).In("issue_id", issueIDs).Delete(&ProjectIssue{})
-	return err
+	if _, err := db.GetEngine(ctx).In("project_id", projectIDs).In("issue_id", issueIDs).Delete(&ProjectIssue{}); err != nil {
+		return err
+	}
+	return DeleteIssueFieldValues(ctx, issueIDs, projectIDs)
 }
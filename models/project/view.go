@@ -0,0 +1,283 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+// ViewLayout is the way a saved view shows the issues of a project
+type ViewLayout uint8
+
+const (
+	// ViewLayoutBoard shows the issues as cards in the columns of the project
+	ViewLayoutBoard ViewLayout = iota + 1
+	// ViewLayoutTable shows the issues as sortable and groupable rows
+	ViewLayoutTable
+	// ViewLayoutRoadmap shows the issues on a timeline
+	ViewLayoutRoadmap
+)
+
+var viewLayoutNames = map[ViewLayout]string{
+	ViewLayoutBoard:   "board",
+	ViewLayoutTable:   "table",
+	ViewLayoutRoadmap: "roadmap",
+}
+
+// ViewLayouts returns all the view layouts in display order
+func ViewLayouts() []ViewLayout {
+	return []ViewLayout{ViewLayoutBoard, ViewLayoutTable, ViewLayoutRoadmap}
+}
+
+// ParseViewLayout returns the view layout of the name, or 0 if the name is unknown
+func ParseViewLayout(name string) ViewLayout {
+	for layout, layoutName := range viewLayoutNames {
+		if layoutName == name {
+			return layout
+		}
+	}
+	return 0
+}
+
+func (l ViewLayout) String() string {
+	return viewLayoutNames[l]
+}
+
+// IsValid returns whether the view layout is a known one
+func (l ViewLayout) IsValid() bool {
+	_, ok := viewLayoutNames[l]
+	return ok
+}
+
+// The keys a table view can group and sort its issues by, the custom fields are referred to by ViewFieldKey
+const (
+	ViewKeyTitle      = "title"
+	ViewKeyColumn     = "column"
+	ViewKeyRepository = "repository"
+	ViewKeyAssignee   = "assignee"
+	ViewKeyMilestone  = "milestone"
+	ViewKeyLabel      = "label"
+	ViewKeyCreated    = "created"
+	ViewKeyUpdated    = "updated"
+)
+
+// ViewGroupKeys returns the built-in keys the table views can be grouped by
+func ViewGroupKeys() []string {
+	return []string{ViewKeyColumn, ViewKeyRepository, ViewKeyAssignee, ViewKeyMilestone, ViewKeyLabel}
+}
+
+// ViewSortKeys returns the built-in keys the table views can be sorted by
+func ViewSortKeys() []string {
+	return []string{ViewKeyTitle, ViewKeyAssignee, ViewKeyMilestone, ViewKeyCreated, ViewKeyUpdated}
+}
+
+const viewFieldKeyPrefix = "field_"
+
+// ViewFieldKey returns the key grouping or sorting a view by a custom field
+func ViewFieldKey(fieldID int64) string {
+	return viewFieldKeyPrefix + strconv.FormatInt(fieldID, 10)
+}
+
+// ParseViewFieldKey returns the ID of the custom field of the key, or 0 if it isn't a field key
+func ParseViewFieldKey(key string) int64 {
+	if !strings.HasPrefix(key, viewFieldKeyPrefix) {
+		return 0
+	}
+	fieldID, _ := strconv.ParseInt(key[len(viewFieldKeyPrefix):], 10, 64)
+	return max(fieldID, 0)
+}
+
+const (
+	maxViewNameLength   = 255
+	maxViewFilterLength = 4096
+)
+
+// View is a named way to show the issues of a project, saved with its filters
+type View struct {
+	ID        int64      `xorm:"pk autoincr"`
+	ProjectID int64      `xorm:"INDEX NOT NULL"`
+	Name      string     `xorm:"NOT NULL"`
+	Layout    ViewLayout `xorm:"NOT NULL DEFAULT 1"`
+	// Filter is the query string of the board filters, for example "labels=1&field_3=2"
+	Filter   string `xorm:"TEXT"`
+	GroupBy  string `xorm:"VARCHAR(50)"`
+	SortBy   string `xorm:"VARCHAR(50)"`
+	SortDesc bool   `xorm:"NOT NULL DEFAULT false"`
+	// DateFieldID is the date or iteration field placing the issues on a roadmap,
+	// 0 places them by the deadline of their milestone
+	DateFieldID int64 `xorm:"NOT NULL DEFAULT 0"`
+	CreatorID   int64 `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (View) TableName() string {
+	return "project_view"
+}
+
+func init() {
+	db.RegisterModel(new(View))
+}
+
+// ErrProjectViewNotExist represents a "ProjectViewNotExist" kind of error.
+type ErrProjectViewNotExist struct {
+	ID int64
+}
+
+// IsErrProjectViewNotExist checks if an error is a ErrProjectViewNotExist
+func IsErrProjectViewNotExist(err error) bool {
+	_, ok := err.(ErrProjectViewNotExist)
+	return ok
+}
+
+func (err ErrProjectViewNotExist) Error() string {
+	return fmt.Sprintf("project view does not exist [id: %d]", err.ID)
+}
+
+func (err ErrProjectViewNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// FilterQuery returns the saved filters of the view, they are applied like the query of the board
+func (v *View) FilterQuery() url.Values {
+	query, _ := url.ParseQuery(v.Filter)
+	return query
+}
+
+// Query returns the query string of the project page showing the view with its saved filters
+func (v *View) Query() string {
+	query := v.FilterQuery()
+	query.Set("view", strconv.FormatInt(v.ID, 10))
+	return query.Encode()
+}
+
+// validViewKey checks a group or sort key of a view, the field keys must refer to a field of the project
+func validViewKey(key string, builtin []string, fields []*Field) bool {
+	if key == "" {
+		return true
+	}
+	for _, k := range builtin {
+		if k == key {
+			return true
+		}
+	}
+	fieldID := ParseViewFieldKey(key)
+	for _, f := range fields {
+		if f.ID == fieldID {
+			return true
+		}
+	}
+	return false
+}
+
+func validateView(ctx context.Context, v *View) error {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" || utf8.RuneCountInString(v.Name) > maxViewNameLength {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "the view name must have 1 to %d characters", maxViewNameLength)
+	}
+	if !v.Layout.IsValid() {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "invalid view layout %d", v.Layout)
+	}
+	if len(v.Filter) > maxViewFilterLength {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "the view filter is longer than %d characters", maxViewFilterLength)
+	}
+	query, err := url.ParseQuery(v.Filter)
+	if err != nil {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "invalid view filter: %v", err)
+	}
+	v.Filter = query.Encode()
+
+	fields, err := GetFields(ctx, v.ProjectID)
+	if err != nil {
+		return err
+	}
+	if !validViewKey(v.GroupBy, ViewGroupKeys(), fields) {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "the view can't be grouped by %q", v.GroupBy)
+	}
+	if !validViewKey(v.SortBy, ViewSortKeys(), fields) {
+		return util.ErrorWrap(util.ErrUnprocessableContent, "the view can't be sorted by %q", v.SortBy)
+	}
+	if v.DateFieldID != 0 {
+		var dateField *Field
+		for _, f := range fields {
+			if f.ID == v.DateFieldID {
+				dateField = f
+			}
+		}
+		if dateField == nil || !dateField.Type.HasDates() {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "field %d is not a date or iteration field of the project", v.DateFieldID)
+		}
+	}
+	return nil
+}
+
+// NewView saves a view of a project
+func NewView(ctx context.Context, v *View) error {
+	if err := validateView(ctx, v); err != nil {
+		return err
+	}
+	return db.Insert(ctx, v)
+}
+
+// UpdateView writes the settings of the view
+func UpdateView(ctx context.Context, v *View) error {
+	if err := validateView(ctx, v); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(v.ID).Cols("name", "layout", "filter", "group_by", "sort_by", "sort_desc", "date_field_id").Update(v)
+	return err
+}
+
+// GetViewByIDAndProjectID returns the view of the project
+func GetViewByIDAndProjectID(ctx context.Context, id, projectID int64) (*View, error) {
+	v := new(View)
+	has, err := db.GetEngine(ctx).ID(id).And("project_id=?", projectID).Get(v)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectViewNotExist{ID: id}
+	}
+	return v, nil
+}
+
+// GetViews returns all the views of a project in the order they were created
+func GetViews(ctx context.Context, projectID int64) ([]*View, error) {
+	views := make([]*View, 0, 5)
+	return views, db.GetEngine(ctx).Where("project_id=?", projectID).OrderBy("id").Find(&views)
+}
+
+// DeleteViewByID deletes a view
+func DeleteViewByID(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(new(View))
+	return err
+}
+
+func deleteViewsByProjectID(ctx context.Context, projectID int64) error {
+	_, err := db.GetEngine(ctx).Where("project_id=?", projectID).Delete(new(View))
+	return err
+}
+
+// resetViewsOfField stops the views of the project from grouping, sorting or placing the issues by a deleted field
+func resetViewsOfField(ctx context.Context, projectID, fieldID int64) error {
+	key := ViewFieldKey(fieldID)
+	if _, err := db.GetEngine(ctx).Where("project_id=? AND group_by=?", projectID, key).Cols("group_by").Update(&View{}); err != nil {
+		return err
+	}
+	if _, err := db.GetEngine(ctx).Where("project_id=? AND sort_by=?", projectID, key).Cols("sort_by", "sort_desc").Update(&View{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("project_id=? AND date_field_id=?", projectID, fieldID).Cols("date_field_id").Update(&View{})
+	return err
+}
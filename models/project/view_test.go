@@ -0,0 +1,95 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"fmt"
+	"testing"
+
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewLayout(t *testing.T) {
+	for _, layout := range ViewLayouts() {
+		assert.Equal(t, layout, ParseViewLayout(layout.String()))
+	}
+	assert.Zero(t, ParseViewLayout("kanban"))
+	assert.EqualValues(t, 3, ParseViewFieldKey(ViewFieldKey(3)))
+	assert.Zero(t, ParseViewFieldKey("field_x"))
+	assert.Zero(t, ParseViewFieldKey("title"))
+}
+
+func TestNewView(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	estimate := &Field{ProjectID: 1, Name: "Estimate", Type: FieldTypeNumber}
+	require.NoError(t, NewField(t.Context(), estimate))
+	due := &Field{ProjectID: 1, Name: "Due", Type: FieldTypeDate}
+	require.NoError(t, NewField(t.Context(), due))
+	otherField := &Field{ProjectID: 2, Name: "Estimate", Type: FieldTypeNumber}
+	require.NoError(t, NewField(t.Context(), otherField))
+
+	for _, v := range []*View{
+		{ProjectID: 1, Name: " ", Layout: ViewLayoutTable},
+		{ProjectID: 1, Name: "Sprint", Layout: 10},
+		{ProjectID: 1, Name: "Sprint", Layout: ViewLayoutTable, GroupBy: "poster"},
+		{ProjectID: 1, Name: "Sprint", Layout: ViewLayoutTable, SortBy: ViewFieldKey(otherField.ID)},
+		{ProjectID: 1, Name: "Sprint", Layout: ViewLayoutRoadmap, DateFieldID: estimate.ID},
+		{ProjectID: 1, Name: "Sprint", Layout: ViewLayoutTable, Filter: "labels=%zz"},
+	} {
+		assert.ErrorIs(t, NewView(t.Context(), v), util.ErrUnprocessableContent, v.Name)
+	}
+
+	v := &View{
+		ProjectID: 1,
+		Name:      "By estimate",
+		Layout:    ViewLayoutTable,
+		Filter:    "milestone=1&labels=1",
+		GroupBy:   ViewKeyAssignee,
+		SortBy:    ViewFieldKey(estimate.ID),
+		SortDesc:  true,
+		CreatorID: 2,
+	}
+	require.NoError(t, NewView(t.Context(), v))
+	assert.Equal(t, fmt.Sprintf("labels=1&milestone=1&view=%d", v.ID), v.Query())
+	assert.Equal(t, "1", v.FilterQuery().Get("milestone"))
+
+	v.Layout = ViewLayoutRoadmap
+	v.DateFieldID = due.ID
+	require.NoError(t, UpdateView(t.Context(), v))
+	unittest.AssertExistsAndLoadBean(t, &View{ID: v.ID, Layout: ViewLayoutRoadmap, DateFieldID: due.ID})
+
+	_, err := GetViewByIDAndProjectID(t.Context(), v.ID, 2)
+	assert.True(t, IsErrProjectViewNotExist(err))
+}
+
+func TestDeleteFieldResetsViews(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	sprint := &Field{ProjectID: 1, Name: "Sprint", Type: FieldTypeIteration, Options: []*FieldOption{
+		{Name: "Sprint 1", StartDate: "2026-03-01", EndDate: "2026-03-14"},
+	}}
+	require.NoError(t, NewField(t.Context(), sprint))
+	key := ViewFieldKey(sprint.ID)
+	v := &View{ProjectID: 1, Name: "Roadmap", Layout: ViewLayoutRoadmap, GroupBy: key, SortBy: key, SortDesc: true, DateFieldID: sprint.ID, CreatorID: 2}
+	require.NoError(t, NewView(t.Context(), v))
+	kept := &View{ProjectID: 1, Name: "Table", Layout: ViewLayoutTable, GroupBy: ViewKeyLabel, SortBy: ViewKeyTitle, CreatorID: 2}
+	require.NoError(t, NewView(t.Context(), kept))
+
+	require.NoError(t, DeleteFieldByID(t.Context(), sprint.ID))
+	v, err := GetViewByIDAndProjectID(t.Context(), v.ID, 1)
+	require.NoError(t, err)
+	assert.Empty(t, v.GroupBy)
+	assert.Empty(t, v.SortBy)
+	assert.False(t, v.SortDesc)
+	assert.Zero(t, v.DateFieldID)
+	unittest.AssertExistsAndLoadBean(t, &View{ID: kept.ID, GroupBy: ViewKeyLabel, SortBy: ViewKeyTitle})
+
+	require.NoError(t, DeleteProjectByID(t.Context(), 1))
+	unittest.AssertCount(t, &View{ProjectID: 1}, 0)
+}
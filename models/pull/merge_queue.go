@@ -0,0 +1,183 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// MergeQueueRefPrefix is the prefix of the temporary refs holding the speculative merge results of merge queues
+const MergeQueueRefPrefix = "refs/merge-queue/"
+
+// MergeQueueEntryStatus represents the state of a pull request in a merge queue
+type MergeQueueEntryStatus int
+
+const (
+	MergeQueueEntryStatusWaiting  MergeQueueEntryStatus = iota // waiting to be speculatively merged
+	MergeQueueEntryStatusChecking                              // speculatively merged, waiting for the checks of its merge group
+	MergeQueueEntryStatusFailed                                // dropped from the queue because the speculative merge or its checks failed
+)
+
+func (status MergeQueueEntryStatus) String() string {
+	switch status {
+	case MergeQueueEntryStatusWaiting:
+		return "waiting"
+	case MergeQueueEntryStatusChecking:
+		return "checking"
+	case MergeQueueEntryStatusFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of a protected branch.
+// Entries are processed in ID order; merged entries are removed from the table.
+type MergeQueueEntry struct {
+	ID                     int64                 `xorm:"pk autoincr"`
+	RepoID                 int64                 `xorm:"INDEX(repo_branch) NOT NULL"`
+	BaseBranch             string                `xorm:"INDEX(repo_branch) NOT NULL"`
+	PullID                 int64                 `xorm:"UNIQUE NOT NULL"`
+	DoerID                 int64                 `xorm:"INDEX NOT NULL"`
+	Doer                   *user_model.User      `xorm:"-"`
+	MergeStyle             repo_model.MergeStyle `xorm:"varchar(30)"`
+	Message                string                `xorm:"LONGTEXT"`
+	DeleteBranchAfterMerge bool
+	Status                 MergeQueueEntryStatus `xorm:"NOT NULL DEFAULT 0"`
+	BaseCommitID           string                `xorm:"VARCHAR(64)"` // the commit this entry was speculatively merged onto
+	MergeCommitID          string                `xorm:"VARCHAR(64)"` // the speculative merge result of this entry and all entries before it
+	FailReason             string                `xorm:"TEXT"`
+	CreatedUnix            timeutil.TimeStamp    `xorm:"created"`
+	UpdatedUnix            timeutil.TimeStamp    `xorm:"updated"`
+}
+
+// TableName return database table name for xorm
+func (MergeQueueEntry) TableName() string {
+	return "pull_merge_queue"
+}
+
+func init() {
+	db.RegisterModel(new(MergeQueueEntry))
+}
+
+// GetRefName returns the temporary ref holding the speculative merge result of this entry
+func (entry *MergeQueueEntry) GetRefName() string {
+	return fmt.Sprintf("%s%s/%d", MergeQueueRefPrefix, entry.BaseBranch, entry.ID)
+}
+
+// LoadDoer loads the user who added the pull request to the merge queue
+func (entry *MergeQueueEntry) LoadDoer(ctx context.Context) (err error) {
+	if entry.Doer != nil {
+		return nil
+	}
+	entry.DoerID, entry.Doer, err = user_model.GetPossibleUserByID(ctx, entry.DoerID)
+	return err
+}
+
+// ErrAlreadyInMergeQueue represents an error when a pull request is already waiting in a merge queue
+type ErrAlreadyInMergeQueue struct {
+	PullID int64
+}
+
+func (err ErrAlreadyInMergeQueue) Error() string {
+	return fmt.Sprintf("pull request is already in the merge queue [pull_id: %d]", err.PullID)
+}
+
+func (err ErrAlreadyInMergeQueue) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// AddToMergeQueue appends a pull request to the end of the merge queue of its base branch.
+// A previously failed entry of the same pull request is replaced.
+func AddToMergeQueue(ctx context.Context, entry *MergeQueueEntry) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetMergeQueueEntryByPullID(ctx, entry.PullID)
+		if err != nil && !db.IsErrNotExist(err) {
+			return err
+		}
+		if existing != nil {
+			if existing.Status != MergeQueueEntryStatusFailed {
+				return ErrAlreadyInMergeQueue{PullID: entry.PullID}
+			}
+			if _, err := db.GetEngine(ctx).ID(existing.ID).Delete(&MergeQueueEntry{}); err != nil {
+				return err
+			}
+		}
+
+		entry.Status = MergeQueueEntryStatusWaiting
+		return db.Insert(ctx, entry)
+	})
+}
+
+// GetMergeQueueEntryByPullID returns the merge queue entry of a pull request
+func GetMergeQueueEntryByPullID(ctx context.Context, pullID int64) (*MergeQueueEntry, error) {
+	entry := &MergeQueueEntry{}
+	has, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Get(entry)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, db.ErrNotExist{Resource: "merge_queue_entry", ID: pullID}
+	}
+	return entry, nil
+}
+
+// GetMergeQueueEntries returns the active (not failed) entries of the merge queue of a branch in queue order
+func GetMergeQueueEntries(ctx context.Context, repoID int64, baseBranch string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 10)
+	return entries, db.GetEngine(ctx).
+		Where(builder.Eq{"repo_id": repoID, "base_branch": baseBranch}).
+		And(builder.Neq{"status": MergeQueueEntryStatusFailed}).
+		OrderBy("id ASC").
+		Find(&entries)
+}
+
+// FindMergeQueueEntriesOptions represents the options to list merge queue entries
+type FindMergeQueueEntriesOptions struct {
+	db.ListOptions
+	RepoID        int64
+	BaseBranch    string
+	MergeCommitID string
+	IncludeFailed bool
+}
+
+func (opts FindMergeQueueEntriesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.BaseBranch != "" {
+		cond = cond.And(builder.Eq{"base_branch": opts.BaseBranch})
+	}
+	if opts.MergeCommitID != "" {
+		cond = cond.And(builder.Eq{"merge_commit_id": opts.MergeCommitID})
+	}
+	if !opts.IncludeFailed {
+		cond = cond.And(builder.Neq{"status": MergeQueueEntryStatusFailed})
+	}
+	return cond
+}
+
+func (opts FindMergeQueueEntriesOptions) ToOrders() string {
+	return "id ASC"
+}
+
+// UpdateMergeQueueEntryCols updates the given columns of a merge queue entry
+func UpdateMergeQueueEntryCols(ctx context.Context, entry *MergeQueueEntry, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(entry.ID).Cols(cols...).Update(entry)
+	return err
+}
+
+// RemoveFromMergeQueue deletes the merge queue entry of a pull request
+func RemoveFromMergeQueue(ctx context.Context, pullID int64) error {
+	_, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Delete(&MergeQueueEntry{})
+	return err
+}
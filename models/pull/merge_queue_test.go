@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gitea.dev/models/db"
+	pull_model "gitea.dev/models/pull"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeQueue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	newEntry := func(pullID int64) *pull_model.MergeQueueEntry {
+		return &pull_model.MergeQueueEntry{RepoID: 1, BaseBranch: "master", PullID: pullID, DoerID: 1, MergeStyle: "merge"}
+	}
+	require.NoError(t, pull_model.AddToMergeQueue(t.Context(), newEntry(2)))
+	require.NoError(t, pull_model.AddToMergeQueue(t.Context(), newEntry(1)))
+	assert.ErrorIs(t, pull_model.AddToMergeQueue(t.Context(), newEntry(1)), util.ErrAlreadyExist)
+
+	entries, err := pull_model.GetMergeQueueEntries(t.Context(), 1, "master")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.EqualValues(t, 2, entries[0].PullID)
+	assert.EqualValues(t, 1, entries[1].PullID)
+	assert.Equal(t, fmt.Sprintf("refs/merge-queue/master/%d", entries[0].ID), entries[0].GetRefName())
+
+	// a failed entry leaves the queue but can be queued again
+	entries[0].Status = pull_model.MergeQueueEntryStatusFailed
+	require.NoError(t, pull_model.UpdateMergeQueueEntryCols(t.Context(), entries[0], "status"))
+	entries, err = pull_model.GetMergeQueueEntries(t.Context(), 1, "master")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.EqualValues(t, 1, entries[0].PullID)
+
+	all, err := db.Find[pull_model.MergeQueueEntry](t.Context(), pull_model.FindMergeQueueEntriesOptions{RepoID: 1, IncludeFailed: true})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	require.NoError(t, pull_model.AddToMergeQueue(t.Context(), newEntry(2)))
+	entries, err = pull_model.GetMergeQueueEntries(t.Context(), 1, "master")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.EqualValues(t, 2, entries[1].PullID)
+
+	require.NoError(t, pull_model.RemoveFromMergeQueue(t.Context(), 1))
+	_, err = pull_model.GetMergeQueueEntryByPullID(t.Context(), 1)
+	assert.True(t, db.IsErrNotExist(err))
+}
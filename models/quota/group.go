@@ -0,0 +1,178 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+var (
+	ErrGroupNotExist     = util.NewNotExistErrorf("quota group does not exist")
+	ErrGroupAlreadyExist = util.NewAlreadyExistErrorf("quota group already exists")
+)
+
+func init() {
+	db.RegisterModel(new(Group))
+	db.RegisterModel(new(GroupOwner))
+}
+
+// Group is a named set of size limits which is assigned to users and organizations by site administrators.
+// A limit of -1 means no limit.
+type Group struct {
+	ID               int64              `xorm:"pk autoincr"`
+	Name             string             `xorm:"UNIQUE NOT NULL"`
+	LimitTotal       int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitGit         int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitLFS         int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitAttachments int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitArtifacts   int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitPackages    int64              `xorm:"NOT NULL DEFAULT -1"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix      timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+func (*Group) TableName() string {
+	return "quota_group"
+}
+
+// Limits returns the limits of the group
+func (g *Group) Limits() Limits {
+	return Limits{
+		Total:       g.LimitTotal,
+		Git:         g.LimitGit,
+		LFS:         g.LimitLFS,
+		Attachments: g.LimitAttachments,
+		Artifacts:   g.LimitArtifacts,
+		Packages:    g.LimitPackages,
+	}
+}
+
+// SetLimits sets the limits of the group
+func (g *Group) SetLimits(l Limits) {
+	g.LimitTotal = l.Total
+	g.LimitGit = l.Git
+	g.LimitLFS = l.LFS
+	g.LimitAttachments = l.Attachments
+	g.LimitArtifacts = l.Artifacts
+	g.LimitPackages = l.Packages
+}
+
+// GroupOwner assigns a quota group to a user or an organization
+type GroupOwner struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	OwnerID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func (*GroupOwner) TableName() string {
+	return "quota_group_owner"
+}
+
+func CreateGroup(ctx context.Context, g *Group) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where("name = ?", g.Name).Exist(&Group{})
+		if err != nil {
+			return err
+		}
+		if exist {
+			return ErrGroupAlreadyExist
+		}
+		return db.Insert(ctx, g)
+	})
+}
+
+func GetGroupByID(ctx context.Context, id int64) (*Group, error) {
+	g := &Group{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(g)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrGroupNotExist
+	}
+	return g, nil
+}
+
+// GetGroups returns all quota groups ordered by name
+func GetGroups(ctx context.Context) ([]*Group, error) {
+	groups := make([]*Group, 0, 10)
+	return groups, db.GetEngine(ctx).OrderBy("name ASC").Find(&groups)
+}
+
+// GetGroupsByNames returns the quota groups with the names, unknown names are ignored
+func GetGroupsByNames(ctx context.Context, names []string) ([]*Group, error) {
+	groups := make([]*Group, 0, len(names))
+	if len(names) == 0 {
+		return groups, nil
+	}
+	return groups, db.GetEngine(ctx).In("name", names).OrderBy("name ASC").Find(&groups)
+}
+
+// UpdateGroup updates the name and the limits of the group
+func UpdateGroup(ctx context.Context, g *Group) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where("name = ? AND id <> ?", g.Name, g.ID).Exist(&Group{})
+		if err != nil {
+			return err
+		}
+		if exist {
+			return ErrGroupAlreadyExist
+		}
+		_, err = db.GetEngine(ctx).ID(g.ID).AllCols().Update(g)
+		return err
+	})
+}
+
+// DeleteGroup deletes the group and its assignments
+func DeleteGroup(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("group_id = ?", id).Delete(&GroupOwner{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(&Group{})
+		return err
+	})
+}
+
+// AddGroupOwner assigns the group to the owner, assigning it again is a no-op
+func AddGroupOwner(ctx context.Context, groupID, ownerID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where("group_id = ? AND owner_id = ?", groupID, ownerID).Exist(&GroupOwner{})
+		if err != nil || exist {
+			return err
+		}
+		return db.Insert(ctx, &GroupOwner{GroupID: groupID, OwnerID: ownerID})
+	})
+}
+
+func RemoveGroupOwner(ctx context.Context, groupID, ownerID int64) error {
+	_, err := db.GetEngine(ctx).Where("group_id = ? AND owner_id = ?", groupID, ownerID).Delete(&GroupOwner{})
+	return err
+}
+
+// GetGroupOwnerIDs returns the IDs of the users and organizations the group is assigned to
+func GetGroupOwnerIDs(ctx context.Context, groupID int64) ([]int64, error) {
+	ownerIDs := make([]int64, 0, 10)
+	return ownerIDs, db.GetEngine(ctx).
+		Table("quota_group_owner").
+		Where("group_id = ?", groupID).
+		OrderBy("owner_id ASC").
+		Cols("owner_id").
+		Find(&ownerIDs)
+}
+
+// GetOwnerGroups returns the groups assigned to the owner
+func GetOwnerGroups(ctx context.Context, ownerID int64) ([]*Group, error) {
+	groups := make([]*Group, 0, 2)
+	return groups, db.GetEngine(ctx).
+		Join("INNER", "quota_group_owner", "quota_group_owner.group_id = quota_group.id").
+		Where("quota_group_owner.owner_id = ?", ownerID).
+		OrderBy("quota_group.name ASC").
+		Find(&groups)
+}
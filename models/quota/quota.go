@@ -0,0 +1,271 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/db"
+	packages_model "gitea.dev/models/packages"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+// Subject is a kind of content whose size counts towards the quota of the owner
+type Subject string
+
+const (
+	SubjectGit         Subject = "git"
+	SubjectLFS         Subject = "lfs"
+	SubjectAttachments Subject = "attachments"
+	SubjectArtifacts   Subject = "artifacts"
+	SubjectPackages    Subject = "packages"
+)
+
+// ErrQuotaExceeded is returned if storing more content would exceed a limit of the owner.
+// It implies HTTP 413 like util.ErrContentTooLarge.
+type ErrQuotaExceeded struct {
+	OwnerID int64
+	Subject Subject
+	Limit   int64
+}
+
+func (err ErrQuotaExceeded) Error() string {
+	if err.Subject == "" {
+		return fmt.Sprintf("total storage quota of %d bytes exceeded [owner_id: %d]", err.Limit, err.OwnerID)
+	}
+	return fmt.Sprintf("%s storage quota of %d bytes exceeded [owner_id: %d]", err.Subject, err.Limit, err.OwnerID)
+}
+
+func (err ErrQuotaExceeded) Unwrap() error {
+	return util.ErrContentTooLarge
+}
+
+// IsErrQuotaExceeded checks if an error is a ErrQuotaExceeded.
+func IsErrQuotaExceeded(err error) bool {
+	var errQuotaExceeded ErrQuotaExceeded
+	return errors.As(err, &errQuotaExceeded)
+}
+
+// Limits are the maximum sizes in bytes the owner can use, -1 means no limit.
+// Total limits the sum of all subjects.
+type Limits struct {
+	Total       int64
+	Git         int64
+	LFS         int64
+	Attachments int64
+	Artifacts   int64
+	Packages    int64
+}
+
+// Unlimited returns limits which don't restrict anything
+func Unlimited() Limits {
+	return Limits{Total: -1, Git: -1, LFS: -1, Attachments: -1, Artifacts: -1, Packages: -1}
+}
+
+// Of returns the limit of the subject
+func (l Limits) Of(subject Subject) int64 {
+	switch subject {
+	case SubjectGit:
+		return l.Git
+	case SubjectLFS:
+		return l.LFS
+	case SubjectAttachments:
+		return l.Attachments
+	case SubjectArtifacts:
+		return l.Artifacts
+	case SubjectPackages:
+		return l.Packages
+	}
+	return -1
+}
+
+// mostPermissive returns the larger limit, no limit wins over any limit
+func mostPermissive(a, b int64) int64 {
+	if a < 0 || b < 0 {
+		return -1
+	}
+	return max(a, b)
+}
+
+// MergeLimits combines the limits of several groups, the most permissive limit of each kind applies
+func MergeLimits(limits ...Limits) Limits {
+	if len(limits) == 0 {
+		return Unlimited()
+	}
+	merged := limits[0]
+	for _, l := range limits[1:] {
+		merged.Total = mostPermissive(merged.Total, l.Total)
+		merged.Git = mostPermissive(merged.Git, l.Git)
+		merged.LFS = mostPermissive(merged.LFS, l.LFS)
+		merged.Attachments = mostPermissive(merged.Attachments, l.Attachments)
+		merged.Artifacts = mostPermissive(merged.Artifacts, l.Artifacts)
+		merged.Packages = mostPermissive(merged.Packages, l.Packages)
+	}
+	return merged
+}
+
+// GetOwnerLimits returns the limits of the owner and the groups they result from.
+// Owners without an assigned group get the default groups.
+func GetOwnerLimits(ctx context.Context, ownerID int64) (Limits, []*Group, error) {
+	groups, err := GetOwnerGroups(ctx, ownerID)
+	if err != nil {
+		return Limits{}, nil, err
+	}
+	if len(groups) == 0 {
+		if groups, err = GetGroupsByNames(ctx, setting.Quota.DefaultGroups); err != nil {
+			return Limits{}, nil, err
+		}
+	}
+
+	limits := make([]Limits, 0, len(groups))
+	for _, g := range groups {
+		limits = append(limits, g.Limits())
+	}
+	return MergeLimits(limits...), groups, nil
+}
+
+// Usage are the sizes in bytes the owner uses
+type Usage struct {
+	Git         int64
+	LFS         int64
+	Attachments int64
+	Artifacts   int64
+	Packages    int64
+}
+
+// Total returns the sum of all subjects
+func (u *Usage) Total() int64 {
+	return u.Git + u.LFS + u.Attachments + u.Artifacts + u.Packages
+}
+
+// Of returns the usage of the subject
+func (u *Usage) Of(subject Subject) int64 {
+	switch subject {
+	case SubjectGit:
+		return u.Git
+	case SubjectLFS:
+		return u.LFS
+	case SubjectAttachments:
+		return u.Attachments
+	case SubjectArtifacts:
+		return u.Artifacts
+	case SubjectPackages:
+		return u.Packages
+	}
+	return 0
+}
+
+// GetUsage calculates the usage of the owner. Repository content counts towards the owner of the repository.
+func GetUsage(ctx context.Context, ownerID int64) (*Usage, error) {
+	u := &Usage{}
+
+	sums, err := db.GetEngine(ctx).
+		Table("repository").
+		Where("owner_id = ?", ownerID).
+		SumsInt(new(repo_model.Repository), "git_size", "lfs_size")
+	if err != nil {
+		return nil, err
+	}
+	u.Git, u.LFS = sums[0], sums[1]
+
+	u.Attachments, err = db.GetEngine(ctx).
+		Table("attachment").
+		Join("INNER", "repository", "repository.id = attachment.repo_id").
+		Where("repository.owner_id = ?", ownerID).
+		SumInt(new(repo_model.Attachment), "attachment.size")
+	if err != nil {
+		return nil, err
+	}
+
+	// expired artifacts are deleted from the storage
+	u.Artifacts, err = db.GetEngine(ctx).
+		Table("action_artifact").
+		Where(builder.Eq{"owner_id": ownerID}.And(builder.In("status", actions_model.ArtifactStatusUploadPending, actions_model.ArtifactStatusUploadConfirmed))).
+		SumInt(new(actions_model.ActionArtifact), "file_compressed_size")
+	if err != nil {
+		return nil, err
+	}
+
+	u.Packages, err = packages_model.CalculateFileSize(ctx, &packages_model.PackageFileSearchOptions{
+		OwnerID: ownerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Checker checks the sizes of a subject against the limits of an owner with its usage computed once,
+// for the requests storing several objects
+type Checker struct {
+	ownerID int64
+	subject Subject
+	limits  Limits
+	usage   *Usage // nil if the subject isn't limited
+}
+
+// NewChecker loads the limits of the owner, and its usage if they apply to the subject
+func NewChecker(ctx context.Context, ownerID int64, subject Subject) (*Checker, error) {
+	c := &Checker{ownerID: ownerID, subject: subject}
+	if !setting.Quota.Enabled {
+		return c, nil
+	}
+
+	limits, _, err := GetOwnerLimits(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if limits.Total < 0 && limits.Of(subject) < 0 {
+		return c, nil
+	}
+
+	c.limits = limits
+	c.usage, err = GetUsage(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Check returns ErrQuotaExceeded if storing size more bytes of the subject would exceed a limit of the owner.
+// A size of 0 checks whether the owner has already reached a limit.
+func (c *Checker) Check(size int64) error {
+	if c.usage == nil {
+		return nil
+	}
+	if limit := c.limits.Of(c.subject); limit >= 0 && isExceeded(c.usage.Of(c.subject), size, limit) {
+		return ErrQuotaExceeded{OwnerID: c.ownerID, Subject: c.subject, Limit: limit}
+	}
+	if c.limits.Total >= 0 && isExceeded(c.usage.Total(), size, c.limits.Total) {
+		return ErrQuotaExceeded{OwnerID: c.ownerID, Limit: c.limits.Total}
+	}
+	return nil
+}
+
+// Check returns ErrQuotaExceeded if storing size more bytes of the subject would exceed a limit of the owner.
+// A size of 0 checks whether the owner has already reached a limit.
+func Check(ctx context.Context, ownerID int64, subject Subject, size int64) error {
+	c, err := NewChecker(ctx, ownerID, subject)
+	if err != nil {
+		return err
+	}
+	return c.Check(size)
+}
+
+// isExceeded reports whether adding size to used exceeds the limit.
+// Without a known size the owner must still be below the limit.
+func isExceeded(used, size, limit int64) bool {
+	if size <= 0 {
+		return used >= limit
+	}
+	return used+size > limit
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLimits(t *testing.T) {
+	assert.Equal(t, Unlimited(), MergeLimits())
+
+	a := Limits{Total: 100, Git: 50, LFS: -1, Attachments: 10, Artifacts: 0, Packages: 20}
+	assert.Equal(t, a, MergeLimits(a))
+
+	b := Limits{Total: 200, Git: -1, LFS: 30, Attachments: 5, Artifacts: 10, Packages: 20}
+	assert.Equal(t, Limits{Total: 200, Git: -1, LFS: -1, Attachments: 10, Artifacts: 10, Packages: 20}, MergeLimits(a, b))
+	assert.Equal(t, MergeLimits(a, b), MergeLimits(b, a))
+}
+
+func TestIsExceeded(t *testing.T) {
+	cases := []struct {
+		used, size, limit int64
+		expected          bool
+	}{
+		{used: 0, size: 10, limit: 10, expected: false},
+		{used: 1, size: 10, limit: 10, expected: true},
+		{used: 9, size: 0, limit: 10, expected: false},
+		{used: 10, size: 0, limit: 10, expected: true},
+		{used: 0, size: 0, limit: 0, expected: true},
+		{used: 0, size: 1, limit: 0, expected: true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, isExceeded(c.used, c.size, c.limit), "used: %d, size: %d, limit: %d", c.used, c.size, c.limit)
+	}
+}
+
+func TestChecker(t *testing.T) {
+	limits := Unlimited()
+	limits.Total, limits.LFS = 100, 30
+	c := &Checker{ownerID: 1, subject: SubjectLFS, limits: limits, usage: &Usage{Git: 60, LFS: 20}}
+
+	// the sizes of the objects of a batch are added up against the usage computed once
+	assert.NoError(t, c.Check(10))
+	assert.Equal(t, ErrQuotaExceeded{OwnerID: 1, Subject: SubjectLFS, Limit: 30}, c.Check(10+1))
+
+	limits.LFS = -1
+	c.limits = limits
+	assert.NoError(t, c.Check(20))
+	assert.Equal(t, ErrQuotaExceeded{OwnerID: 1, Limit: 100}, c.Check(20+1))
+
+	// the subject isn't limited
+	assert.NoError(t, (&Checker{ownerID: 1, subject: SubjectLFS}).Check(1<<40))
+}
@@ -29,6 +29,9 @@ func (ActionsTokenPermissionMode) EnumValues() []ActionsTokenPermissionMode {
 // ActionsTokenPermissions defines the permissions for different repository units
 type ActionsTokenPermissions struct {
 	UnitAccessModes map[unit.Type]perm.AccessMode `json:"unit_access_modes,omitempty"`
+	// IDToken allows the job to request an OIDC ID token, it is only granted by "id-token: write" or "write-all"
+	// and only if the maximum permissions allow it.
+	IDToken bool `json:"id_token,omitempty"`
 }
 
 var ActionsTokenUnitTypes = []unit.Type{
@@ -56,6 +59,15 @@ func ClampActionsTokenPermissions(p1, p2 ActionsTokenPermissions) (ret ActionsTo
 	for _, ut := range ActionsTokenUnitTypes {
 		ret.UnitAccessModes[ut] = min(p1.UnitAccessModes[ut], p2.UnitAccessModes[ut])
 	}
+	ret.IDToken = p1.IDToken && p2.IDToken
+	return ret
+}
+
+// MakeDefaultMaxTokenPermissions returns the maximum permissions used if none have been customized,
+// write for everything and ID tokens allowed
+func MakeDefaultMaxTokenPermissions() ActionsTokenPermissions {
+	ret := MakeActionsTokenPermissions(perm.AccessModeWrite)
+	ret.IDToken = true
 	return ret
 }
 
@@ -155,8 +167,7 @@ func (cfg *ActionsConfig) GetMaxTokenPermissions() ActionsTokenPermissions {
 	if cfg.MaxTokenPermissions != nil {
 		return *cfg.MaxTokenPermissions
 	}
-	// Default max is write for everything
-	return MakeActionsTokenPermissions(perm.AccessModeWrite)
+	return MakeDefaultMaxTokenPermissions()
 }
 
 // ClampPermissions ensures that the given permissions don't exceed the maximum
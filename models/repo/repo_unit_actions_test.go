@@ -6,6 +6,9 @@ package repo
 import (
 	"testing"
 
+	"gitea.dev/models/perm"
+	"gitea.dev/models/unit"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -49,3 +52,21 @@ func TestActionsConfig_ScopedWorkflowSerialization(t *testing.T) {
 	assert.True(t, got.IsScopedWorkflowDisabled(100, "ci.yml"))
 	assert.True(t, got.IsWorkflowDisabled("repo.yml"))
 }
+
+func TestClampActionsTokenPermissions_IDToken(t *testing.T) {
+	requested := MakeActionsTokenPermissions(perm.AccessModeRead)
+	requested.IDToken = true
+
+	assert.True(t, ClampActionsTokenPermissions(requested, MakeDefaultMaxTokenPermissions()).IDToken)
+	assert.True(t, (&ActionsConfig{}).ClampPermissions(requested).IDToken)
+
+	// a customized maximum without ID tokens can't be exceeded by "id-token: write"
+	maxPerms := MakeActionsTokenPermissions(perm.AccessModeWrite)
+	cfg := &ActionsConfig{MaxTokenPermissions: &maxPerms}
+	clamped := cfg.ClampPermissions(requested)
+	assert.False(t, clamped.IDToken)
+	assert.Equal(t, perm.AccessModeRead, clamped.UnitAccessModes[unit.TypeCode])
+
+	// the maximum doesn't grant ID tokens to jobs which don't request them
+	assert.False(t, ClampActionsTokenPermissions(MakeActionsTokenPermissions(perm.AccessModeWrite), MakeDefaultMaxTokenPermissions()).IDToken)
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"context"
+
+	"gitea.dev/models/db"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrAlertNotExist = util.NewNotExistErrorf("secret scanning alert does not exist")
+
+// AlertStatus is the triage state of an alert
+type AlertStatus int
+
+const (
+	AlertStatusOpen          AlertStatus = iota // the secret has not been handled yet
+	AlertStatusRevoked                          // the secret has been revoked and is of no use anymore
+	AlertStatusFalsePositive                    // the match is not a secret, e.g. test data
+)
+
+var alertStatusNames = map[AlertStatus]string{
+	AlertStatusOpen:          "open",
+	AlertStatusRevoked:       "revoked",
+	AlertStatusFalsePositive: "false_positive",
+}
+
+func (s AlertStatus) String() string {
+	return alertStatusNames[s]
+}
+
+// ParseAlertStatus returns the status of the given name
+func ParseAlertStatus(name string) (AlertStatus, bool) {
+	for s, n := range alertStatusNames {
+		if n == name {
+			return s, true
+		}
+	}
+	return AlertStatusOpen, false
+}
+
+// Alert is a secret which has been found in a repository.
+// A secret is reported once per repository, at the first place it has been found.
+type Alert struct {
+	ID           int64  `xorm:"pk autoincr"`
+	RepoID       int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Fingerprint  string `xorm:"VARCHAR(64) UNIQUE(s) NOT NULL"` // identifies the secret without storing it, see secretscan.Finding
+	RuleID       string `xorm:"VARCHAR(255) NOT NULL"`
+	Description  string
+	Redacted     string             `xorm:"NOT NULL DEFAULT ''"`
+	CommitID     string             `xorm:"VARCHAR(64) NOT NULL"`
+	Path         string             `xorm:"TEXT"`
+	Line         int                `xorm:"NOT NULL DEFAULT 0"`
+	Status       AlertStatus        `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ResolverID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	Resolver     *user_model.User   `xorm:"-"`
+	ResolvedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName represents the real table name of Alert
+func (Alert) TableName() string {
+	return "secret_scanning_alert"
+}
+
+func init() {
+	db.RegisterModel(new(Alert))
+}
+
+// LoadResolver loads the user who triaged the alert, if any
+func (a *Alert) LoadResolver(ctx context.Context) (err error) {
+	if a.ResolverID == 0 || a.Resolver != nil {
+		return nil
+	}
+	_, a.Resolver, err = user_model.GetPossibleUserByID(ctx, a.ResolverID)
+	return err
+}
+
+// InsertAlerts stores the alerts whose secrets are not yet known for their repository and returns the new ones
+func InsertAlerts(ctx context.Context, alerts []*Alert) ([]*Alert, error) {
+	var inserted []*Alert
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		for _, a := range alerts {
+			has, err := db.Exist[Alert](ctx, builder.Eq{"repo_id": a.RepoID, "fingerprint": a.Fingerprint})
+			if err != nil {
+				return err
+			}
+			if has {
+				continue
+			}
+			if err := db.Insert(ctx, a); err != nil {
+				return err
+			}
+			inserted = append(inserted, a)
+		}
+		return nil
+	})
+	return inserted, err
+}
+
+// FindAlertsOptions represents the options to query the alerts of a repository
+type FindAlertsOptions struct {
+	db.ListOptions
+	RepoID int64
+	Status optional.Option[AlertStatus]
+}
+
+func (opts FindAlertsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Status.Has() {
+		cond = cond.And(builder.Eq{"status": opts.Status.Value()})
+	}
+	return cond
+}
+
+func (opts FindAlertsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// GetAlertByID returns the alert of the repository with the given ID
+func GetAlertByID(ctx context.Context, repoID, id int64) (*Alert, error) {
+	a := &Alert{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND id = ?", repoID, id).Get(a)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrAlertNotExist
+	}
+	return a, nil
+}
+
+// UpdateAlertStatus changes the triage state of the alert, resolverID is the user who made the change
+func UpdateAlertStatus(ctx context.Context, a *Alert, status AlertStatus, resolverID int64) error {
+	a.Status = status
+	a.Resolver = nil
+	if status == AlertStatusOpen {
+		a.ResolverID = 0
+		a.ResolvedUnix = 0
+	} else {
+		a.ResolverID = resolverID
+		a.ResolvedUnix = timeutil.TimeStampNow()
+	}
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols("status", "resolver_id", "resolved_unix").Update(a)
+	return err
+}
+
+// CountOpenAlerts returns the number of the open alerts of the repository
+func CountOpenAlerts(ctx context.Context, repoID int64) (int64, error) {
+	return db.Count[Alert](ctx, FindAlertsOptions{RepoID: repoID, Status: optional.Some(AlertStatusOpen)})
+}
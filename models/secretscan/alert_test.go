@@ -0,0 +1,72 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan_test
+
+import (
+	"testing"
+
+	"gitea.dev/models/db"
+	secretscan_model "gitea.dev/models/secretscan"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/optional"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlerts(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	newAlert := func(repoID int64, fingerprint string) *secretscan_model.Alert {
+		return &secretscan_model.Alert{RepoID: repoID, Fingerprint: fingerprint, RuleID: "aws-access-key-id", CommitID: "65f1bf27bc3bf70f64657658635e66094edbcb4d", Path: "config.ini", Line: 2}
+	}
+
+	inserted, err := secretscan_model.InsertAlerts(t.Context(), []*secretscan_model.Alert{newAlert(1, "a"), newAlert(1, "b"), newAlert(2, "a")})
+	require.NoError(t, err)
+	assert.Len(t, inserted, 3)
+
+	// secrets are only reported once per repository
+	inserted, err = secretscan_model.InsertAlerts(t.Context(), []*secretscan_model.Alert{newAlert(1, "a"), newAlert(1, "c")})
+	require.NoError(t, err)
+	require.Len(t, inserted, 1)
+	assert.Equal(t, "c", inserted[0].Fingerprint)
+
+	alerts, count, err := db.FindAndCount[secretscan_model.Alert](t.Context(), secretscan_model.FindAlertsOptions{RepoID: 1})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	assert.Equal(t, "c", alerts[0].Fingerprint)
+
+	alert, err := secretscan_model.GetAlertByID(t.Context(), 1, alerts[0].ID)
+	require.NoError(t, err)
+	_, err = secretscan_model.GetAlertByID(t.Context(), 2, alerts[0].ID)
+	assert.ErrorIs(t, err, secretscan_model.ErrAlertNotExist)
+
+	require.NoError(t, secretscan_model.UpdateAlertStatus(t.Context(), alert, secretscan_model.AlertStatusRevoked, 2))
+	alert, err = secretscan_model.GetAlertByID(t.Context(), 1, alert.ID)
+	require.NoError(t, err)
+	assert.Equal(t, secretscan_model.AlertStatusRevoked, alert.Status)
+	assert.EqualValues(t, 2, alert.ResolverID)
+	assert.NotZero(t, alert.ResolvedUnix)
+
+	count, err = secretscan_model.CountOpenAlerts(t.Context(), 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	alerts, err = db.Find[secretscan_model.Alert](t.Context(), secretscan_model.FindAlertsOptions{RepoID: 1, Status: optional.Some(secretscan_model.AlertStatusRevoked)})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, alert.ID, alerts[0].ID)
+
+	require.NoError(t, secretscan_model.UpdateAlertStatus(t.Context(), alert, secretscan_model.AlertStatusOpen, 2))
+	assert.Zero(t, alert.ResolverID)
+}
+
+func TestParseAlertStatus(t *testing.T) {
+	for _, s := range []secretscan_model.AlertStatus{secretscan_model.AlertStatusOpen, secretscan_model.AlertStatusRevoked, secretscan_model.AlertStatusFalsePositive} {
+		parsed, ok := secretscan_model.ParseAlertStatus(s.String())
+		assert.True(t, ok)
+		assert.Equal(t, s, parsed)
+	}
+	_, ok := secretscan_model.ParseAlertStatus("closed")
+	assert.False(t, ok)
+}
@@ -56,6 +56,15 @@ type HookTask struct {
 	IsDelivered bool
 	Delivered   timeutil.TimeStampNano
 
+	// DeliveryUUID identifies the delivery of the event to the receivers, it is kept by the retries
+	DeliveryUUID string `xorm:"INDEX"`
+	// Attempt is 1 for the first delivery of an event and is increased for every retry
+	Attempt int `xorm:"NOT NULL DEFAULT 1"`
+	// RetryAfter delays the delivery of a retry, it is 0 if the task can be delivered at once
+	RetryAfter timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	// IsDeadLetter is set on a delivery which failed and won't be retried, until it is replayed
+	IsDeadLetter bool `xorm:"INDEX NOT NULL DEFAULT false"`
+
 	// History info.
 	IsSucceed       bool
 	RequestContent  string        `xorm:"LONGTEXT"`
@@ -120,12 +129,18 @@ func HookTasks(ctx context.Context, hookID int64, page int) ([]*HookTask, error)
 // it handles conversion from Payload to PayloadContent.
 func CreateHookTask(ctx context.Context, t *HookTask) (*HookTask, error) {
 	t.UUID = gouuid.New().String()
+	if t.DeliveryUUID == "" {
+		t.DeliveryUUID = t.UUID
+	}
 	if t.Delivered == 0 {
 		t.Delivered = timeutil.TimeStampNanoNow()
 	}
 	if t.PayloadVersion == 0 {
 		return nil, errors.New("missing HookTask.PayloadVersion")
 	}
+	if t.Attempt == 0 {
+		t.Attempt = 1
+	}
 	return t, db.Insert(ctx, t)
 }
 
@@ -170,7 +185,21 @@ func ReplayHookTask(ctx context.Context, hookID int64, uuid string) (*HookTask,
 	})
 }
 
-// FindUndeliveredHookTaskIDs will find the next 100 undelivered hook tasks with ID greater than the provided lowerID
+// CreateRetryHookTask creates the task retrying a failed delivery after the given time
+func CreateRetryHookTask(ctx context.Context, t *HookTask, retryAfter timeutil.TimeStamp) (*HookTask, error) {
+	return CreateHookTask(ctx, &HookTask{
+		HookID:         t.HookID,
+		PayloadContent: t.PayloadContent,
+		EventType:      t.EventType,
+		PayloadVersion: t.PayloadVersion,
+		DeliveryUUID:   t.DeliveryUUID,
+		Attempt:        t.Attempt + 1,
+		RetryAfter:     retryAfter,
+	})
+}
+
+// FindUndeliveredHookTaskIDs will find the next 100 undelivered hook tasks with ID greater than the provided lowerID,
+// the retries which are not due yet are skipped
 func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, error) {
 	const batchSize = 100
 
@@ -179,12 +208,53 @@ func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, er
 		Select("id").
 		Table(new(HookTask)).
 		Where("is_delivered=?", false).
+		And("retry_after <= ?", timeutil.TimeStampNow()).
 		And("id > ?", lowerID).
 		Asc("id").
 		Limit(batchSize).
 		Find(&tasks)
 }
 
+// FindDueHookTaskRetryIDs returns the IDs of the undelivered retries which are due
+func FindDueHookTaskRetryIDs(ctx context.Context) ([]int64, error) {
+	tasks := make([]int64, 0, 10)
+	return tasks, db.GetEngine(ctx).
+		Select("id").
+		Table(new(HookTask)).
+		Where("is_delivered=?", false).
+		And("retry_after > 0 AND retry_after <= ?", timeutil.TimeStampNow()).
+		Asc("id").
+		Find(&tasks)
+}
+
+// FindDeadLetterHookTasks returns the dead letters of a webhook, the latest first
+func FindDeadLetterHookTasks(ctx context.Context, hookID int64, listOptions db.ListOptions) ([]*HookTask, int64, error) {
+	sess := db.GetEngine(ctx).Where("hook_id=? AND is_dead_letter=?", hookID, true).Desc("id")
+	if listOptions.Page > 0 {
+		db.SetSessionPagination(sess, &listOptions)
+	}
+	tasks := make([]*HookTask, 0, listOptions.PageSize)
+	count, err := sess.FindAndCount(&tasks)
+	return tasks, count, err
+}
+
+// GetDeadLetterHookTasks returns the dead letters of a webhook with the given IDs, or all of them if no ID is given
+func GetDeadLetterHookTasks(ctx context.Context, hookID int64, ids []int64) ([]*HookTask, error) {
+	var cond builder.Cond = builder.Eq{"hook_id": hookID, "is_dead_letter": true}
+	if len(ids) > 0 {
+		cond = cond.And(builder.In("id", ids))
+	}
+	tasks := make([]*HookTask, 0, len(ids))
+	return tasks, db.GetEngine(ctx).Where(cond).Asc("id").Find(&tasks)
+}
+
+// ClearDeadLetter removes a task from the dead letters of its webhook
+func ClearDeadLetter(ctx context.Context, t *HookTask) error {
+	t.IsDeadLetter = false
+	_, err := db.GetEngine(ctx).ID(t.ID).Cols("is_dead_letter").Update(t)
+	return err
+}
+
 func MarkTaskDelivered(ctx context.Context, task *HookTask) (bool, error) {
 	count, err := db.GetEngine(ctx).ID(task.ID).Where("is_delivered = ?", false).Cols("is_delivered").Update(&HookTask{
 		ID:          task.ID,
@@ -202,7 +272,7 @@ func CleanupHookTaskTable(ctx context.Context, cleanupType HookTaskCleanupType,
 	case OlderThan:
 		deleteOlderThan := time.Now().Add(-olderThan).UnixNano()
 		deletes, err := db.GetEngine(ctx).
-			Where("is_delivered = ? and delivered < ?", true, deleteOlderThan).
+			Where("is_delivered = ? and is_dead_letter = ? and delivered < ?", true, false, deleteOlderThan).
 			Delete(new(HookTask))
 		if err != nil {
 			return err
@@ -233,6 +303,18 @@ func CleanupHookTaskTable(ctx context.Context, cleanupType HookTaskCleanupType,
 	return nil
 }
 
+// CleanupDeadLetterHookTasks deletes the dead letters which failed before the given age
+func CleanupDeadLetterHookTasks(ctx context.Context, olderThan time.Duration) error {
+	deletes, err := db.GetEngine(ctx).
+		Where("is_dead_letter = ? and delivered < ?", true, time.Now().Add(-olderThan).UnixNano()).
+		Delete(new(HookTask))
+	if err != nil {
+		return err
+	}
+	log.Trace("Deleted %d dead letters from hook_task", deletes)
+	return nil
+}
+
 func deleteDeliveredHookTasksByWebhook(ctx context.Context, hookID int64, numberDeliveriesToKeep int) error {
 	log.Trace("Deleting hook_task rows for webhook %d, keeping the most recent %d deliveries", hookID, numberDeliveriesToKeep)
 	deliveryDates := make([]int64, 0, 10)
@@ -249,7 +331,7 @@ func deleteDeliveredHookTasksByWebhook(ctx context.Context, hookID int64, number
 
 	if len(deliveryDates) > 0 {
 		deletes, err := db.GetEngine(ctx).
-			Where("hook_id = ? and is_delivered = ? and delivered <= ?", hookID, true, deliveryDates[0]).
+			Where("hook_id = ? and is_delivered = ? and is_dead_letter = ? and delivered <= ?", hookID, true, false, deliveryDates[0]).
 			Delete(new(HookTask))
 		if err != nil {
 			return err
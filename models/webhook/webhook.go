@@ -136,6 +136,8 @@ type Webhook struct {
 	Type                      webhook_module.HookType   `xorm:"VARCHAR(16) 'type'"`
 	Meta                      string                    `xorm:"TEXT"` // store hook-specific attributes
 	LastStatus                webhook_module.HookStatus // Last delivery status
+	// FailureCount is the number of consecutive deliveries which failed after all their retries
+	FailureCount int `xorm:"NOT NULL DEFAULT 0"`
 
 	// HeaderAuthorizationEncrypted should be accessed using HeaderAuthorization() and SetHeaderAuthorization()
 	HeaderAuthorizationEncrypted string `xorm:"TEXT"`
@@ -306,7 +308,9 @@ func (opts ListWebhookOptions) ToConds() builder.Cond {
 }
 
 // UpdateWebhook updates information of webhook.
+// It resets the failure count since the changes may fix the deliveries.
 func UpdateWebhook(ctx context.Context, w *Webhook) error {
+	w.FailureCount = 0
 	_, err := db.GetEngine(ctx).ID(w.ID).AllCols().Update(w)
 	return err
 }
@@ -317,6 +321,32 @@ func UpdateWebhookLastStatus(ctx context.Context, w *Webhook) error {
 	return err
 }
 
+// IncreaseWebhookFailureCount counts a failed delivery of the webhook and returns the new failure count
+func IncreaseWebhookFailureCount(ctx context.Context, id int64) (int, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (int, error) {
+		if _, err := db.GetEngine(ctx).ID(id).Incr("failure_count").NoAutoTime().Update(new(Webhook)); err != nil {
+			return 0, err
+		}
+		w, err := GetWebhookByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return w.FailureCount, nil
+	})
+}
+
+// ResetWebhookFailureCount resets the failure count of the webhook after a successful delivery
+func ResetWebhookFailureCount(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Where("failure_count > 0").Cols("failure_count").NoAutoTime().Update(&Webhook{FailureCount: 0})
+	return err
+}
+
+// DeactivateWebhook deactivates the webhook, it returns false if the webhook was already inactive
+func DeactivateWebhook(ctx context.Context, id int64) (bool, error) {
+	count, err := db.GetEngine(ctx).ID(id).Where("is_active = ?", true).Cols("is_active").Update(&Webhook{IsActive: false})
+	return count > 0, err
+}
+
 // DeleteWebhookByID uses argument bean as query condition,
 // ID must be specified and do not assign unnecessary fields.
 func DeleteWebhookByID(ctx context.Context, id int64) (err error) {
@@ -389,3 +389,125 @@ func TestCleanupHookTaskTable_OlderThan_LeavesTaskEarlierThanAgeToDelete(t *test
 	assert.NoError(t, CleanupHookTaskTable(t.Context(), OlderThan, 168*time.Hour, 0))
 	unittest.AssertExistsAndLoadBean(t, hookTask)
 }
+
+func TestCleanupHookTaskTable_LeavesDeadLetters(t *testing.T) {
+	hook := &Webhook{RepoID: 3, URL: "https://www.example.com/cleanup7", ContentType: ContentTypeJSON, Events: `{"push_only":true}`}
+	require.NoError(t, db.Insert(t.Context(), hook))
+	hookTask := &HookTask{
+		HookID:         hook.ID,
+		IsDelivered:    true,
+		IsDeadLetter:   true,
+		Delivered:      timeutil.TimeStampNano(time.Now().AddDate(0, 0, -8).UnixNano()),
+		PayloadVersion: 2,
+	}
+	_, err := CreateHookTask(t.Context(), hookTask)
+	assert.NoError(t, err)
+
+	assert.NoError(t, CleanupHookTaskTable(t.Context(), OlderThan, 168*time.Hour, 0))
+	assert.NoError(t, CleanupHookTaskTable(t.Context(), PerWebhook, 168*time.Hour, 0))
+	unittest.AssertExistsAndLoadBean(t, hookTask)
+}
+
+func TestCleanupDeadLetterHookTasks(t *testing.T) {
+	hook := &Webhook{RepoID: 3, URL: "https://www.example.com/cleanup8", ContentType: ContentTypeJSON, Events: `{"push_only":true}`}
+	require.NoError(t, db.Insert(t.Context(), hook))
+	newTask := func(isDeadLetter bool, age time.Duration) *HookTask {
+		hookTask := &HookTask{
+			HookID:         hook.ID,
+			IsDelivered:    true,
+			IsDeadLetter:   isDeadLetter,
+			Delivered:      timeutil.TimeStampNano(time.Now().Add(-age).UnixNano()),
+			PayloadVersion: 2,
+		}
+		_, err := CreateHookTask(t.Context(), hookTask)
+		require.NoError(t, err)
+		return hookTask
+	}
+	oldDeadLetter := newTask(true, 31*24*time.Hour)
+	recentDeadLetter := newTask(true, 24*time.Hour)
+	oldDelivery := newTask(false, 31*24*time.Hour)
+
+	assert.NoError(t, CleanupDeadLetterHookTasks(t.Context(), 30*24*time.Hour))
+	unittest.AssertNotExistsBean(t, &HookTask{ID: oldDeadLetter.ID})
+	unittest.AssertExistsAndLoadBean(t, &HookTask{ID: recentDeadLetter.ID})
+	unittest.AssertExistsAndLoadBean(t, &HookTask{ID: oldDelivery.ID})
+}
+
+func TestHookTaskRetries(t *testing.T) {
+	hook := &Webhook{RepoID: 3, URL: "https://www.example.com/retry", ContentType: ContentTypeJSON, Events: `{"push_only":true}`}
+	require.NoError(t, db.Insert(t.Context(), hook))
+	hookTask := &HookTask{HookID: hook.ID, PayloadContent: "payload", PayloadVersion: 2, IsDelivered: true}
+	_, err := CreateHookTask(t.Context(), hookTask)
+	require.NoError(t, err)
+	assert.Equal(t, 1, hookTask.Attempt)
+
+	later, err := CreateRetryHookTask(t.Context(), hookTask, timeutil.TimeStampNow().Add(3600))
+	require.NoError(t, err)
+	due, err := CreateRetryHookTask(t.Context(), hookTask, timeutil.TimeStampNow().Add(-1))
+	require.NoError(t, err)
+	assert.Equal(t, 2, due.Attempt)
+	assert.Equal(t, "payload", due.PayloadContent)
+	assert.NotEqual(t, hookTask.UUID, due.UUID)
+	assert.Equal(t, hookTask.UUID, hookTask.DeliveryUUID)
+	assert.Equal(t, hookTask.DeliveryUUID, due.DeliveryUUID)
+
+	ids, err := FindDueHookTaskRetryIDs(t.Context())
+	require.NoError(t, err)
+	assert.Contains(t, ids, due.ID)
+	assert.NotContains(t, ids, later.ID)
+
+	ids, err = FindUndeliveredHookTaskIDs(t.Context(), hookTask.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{due.ID}, ids)
+}
+
+func TestHookTaskDeadLetters(t *testing.T) {
+	hook := &Webhook{RepoID: 3, URL: "https://www.example.com/dead-letters", ContentType: ContentTypeJSON, Events: `{"push_only":true}`}
+	require.NoError(t, db.Insert(t.Context(), hook))
+	var deadLetters []*HookTask
+	for _, isDeadLetter := range []bool{true, false, true} {
+		hookTask := &HookTask{HookID: hook.ID, PayloadVersion: 2, IsDelivered: true, IsDeadLetter: isDeadLetter}
+		_, err := CreateHookTask(t.Context(), hookTask)
+		require.NoError(t, err)
+		if isDeadLetter {
+			deadLetters = append(deadLetters, hookTask)
+		}
+	}
+
+	tasks, count, err := FindDeadLetterHookTasks(t.Context(), hook.ID, db.ListOptions{Page: 1, PageSize: 1})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, deadLetters[1].ID, tasks[0].ID)
+
+	tasks, err = GetDeadLetterHookTasks(t.Context(), hook.ID, []int64{deadLetters[0].ID, deadLetters[0].ID + 1})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, deadLetters[0].ID, tasks[0].ID)
+
+	require.NoError(t, ClearDeadLetter(t.Context(), tasks[0]))
+	tasks, err = GetDeadLetterHookTasks(t.Context(), hook.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, deadLetters[1].ID, tasks[0].ID)
+}
+
+func TestWebhookFailureCount(t *testing.T) {
+	hook := &Webhook{RepoID: 3, URL: "https://www.example.com/failures", ContentType: ContentTypeJSON, Events: `{"push_only":true}`, IsActive: true}
+	require.NoError(t, db.Insert(t.Context(), hook))
+
+	for i := 1; i <= 2; i++ {
+		failures, err := IncreaseWebhookFailureCount(t.Context(), hook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, i, failures)
+	}
+	require.NoError(t, ResetWebhookFailureCount(t.Context(), hook.ID))
+	unittest.AssertExistsAndLoadBean(t, &Webhook{ID: hook.ID, FailureCount: 0})
+
+	disabled, err := DeactivateWebhook(t.Context(), hook.ID)
+	require.NoError(t, err)
+	assert.True(t, disabled)
+	disabled, err = DeactivateWebhook(t.Context(), hook.ID)
+	require.NoError(t, err)
+	assert.False(t, disabled)
+}
@@ -171,7 +171,7 @@ func ScopedWorkflowStatusContextName(prefix, displayName, jobName, event string)
 // The two are kept in sync by hand and can drift; unify them into a single source so adding a status-producing event in one place automatically updates the other.
 func ShouldEventCreateCommitStatus(event string) bool {
 	switch event {
-	case "push", "pull_request", "pull_request_target", "pull_request_review", "pull_request_review_comment", "release":
+	case "push", "pull_request", "pull_request_target", "pull_request_review", "pull_request_review_comment", "release", "merge_group":
 		return true
 	}
 	return false
@@ -377,6 +377,14 @@ func detectWorkflowMatch(ctx context.Context, gitRepo *git.Repository, commit *g
 		}
 		return detectNotApplicable
 
+	case // merge_group
+		webhook_module.HookEventMergeGroup:
+		mergeGroupPayload := payloadAs[*api.MergeGroupPayload](payload, inputEvent)
+		if matchMergeGroupEvent(mergeGroupPayload, evt) {
+			return detectMatched
+		}
+		return detectNotApplicable
+
 	default:
 		log.Warn("unsupported event %q", inputEvent)
 		return detectNotApplicable
@@ -803,6 +811,32 @@ func matchReleaseEvent(payload *api.ReleasePayload, evt *jobparser.Event) bool {
 	return matchTimes == len(evt.Acts())
 }
 
+func matchMergeGroupEvent(payload *api.MergeGroupPayload, evt *jobparser.Event) bool {
+	// with no special filter parameters
+	if len(evt.Acts()) == 0 {
+		return true
+	}
+
+	matchTimes := 0
+	// all acts conditions should be satisfied
+	for cond, vals := range evt.Acts() {
+		switch cond {
+		case "types":
+			// See https://docs.github.com/en/actions/using-workflows/events-that-trigger-workflows#merge_group
+			// Only the "checks_requested" activity type exists
+			for _, val := range vals {
+				if glob.MustCompile(val, '/').Match(payload.Action) {
+					matchTimes++
+					break
+				}
+			}
+		default:
+			log.Warn("merge group event unsupported condition %q", cond)
+		}
+	}
+	return matchTimes == len(evt.Acts())
+}
+
 func matchPackageEvent(payload *api.PackagePayload, evt *jobparser.Event) bool {
 	// with no special filter parameters
 	if len(evt.Acts()) == 0 {
@@ -206,6 +206,27 @@ func TestDetectMatched(t *testing.T) {
 			yamlOn:       "on: schedule",
 			expected:     detectMatched,
 		},
+		{
+			desc:         "HookEventMergeGroup(merge_group) matches GithubEventMergeGroup(merge_group)",
+			triggedEvent: webhook_module.HookEventMergeGroup,
+			payload:      &api.MergeGroupPayload{Action: api.HookMergeGroupChecksRequested},
+			yamlOn:       "on: merge_group",
+			expected:     detectMatched,
+		},
+		{
+			desc:         "HookEventMergeGroup(merge_group) `checks_requested` action matches GithubEventMergeGroup(merge_group) with `checks_requested` activity type",
+			triggedEvent: webhook_module.HookEventMergeGroup,
+			payload:      &api.MergeGroupPayload{Action: api.HookMergeGroupChecksRequested},
+			yamlOn:       "on:\n  merge_group:\n    types: [checks_requested]",
+			expected:     detectMatched,
+		},
+		{
+			desc:         "HookEventPush(push) doesn't match GithubEventMergeGroup(merge_group)",
+			triggedEvent: webhook_module.HookEventPush,
+			payload:      &api.PushPayload{Ref: "refs/heads/main"},
+			yamlOn:       "on: merge_group",
+			expected:     detectNotApplicable,
+		},
 		{
 			desc:         "push to tag matches workflow with paths condition (should skip paths check)",
 			triggedEvent: webhook_module.HookEventPush,
@@ -80,6 +80,7 @@ const (
 	StderrNotTreeObject      StderrPrefix = "fatal: not a tree object"
 	StderrPathSpec           StderrPrefix = "fatal: pathspec"
 	StderrBadRevision        StderrPrefix = "fatal: bad revision"
+	StderrEmptyBundle        StderrPrefix = "fatal: refusing to create empty bundle"
 
 	StderrNoSuchRemote1 StderrPrefix = "fatal: no such remote" // git < 2.30, exit status 128
 	StderrNoSuchRemote2 StderrPrefix = "error: no such remote" // git >= 2.30. exit status 2
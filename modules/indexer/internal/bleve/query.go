@@ -38,6 +38,13 @@ func MatchAndQuery(matchPhrase, field, analyzer string, fuzziness int) *query.Ma
 	return q
 }
 
+// TermQuery generates an exact term query for the given value and keyword field
+func TermQuery(value, field string) *query.TermQuery {
+	q := bleve.NewTermQuery(value)
+	q.SetField(field)
+	return q
+}
+
 // BoolFieldQuery generates a bool field query for the given value and field
 func BoolFieldQuery(value bool, field string) *query.BoolFieldQuery {
 	q := bleve.NewBoolFieldQuery(value)
@@ -84,6 +84,13 @@ func (f FilterEq) Statement() string {
 	return string(f)
 }
 
+// NewFilterEqString creates a new FilterEq for a string value, quoting the value and escaping the characters
+// which end the quoted string.
+func NewFilterEqString(field, value string) FilterEq {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return FilterEq(fmt.Sprintf(`%s = "%s"`, field, value))
+}
+
 type FilterNot string
 
 func NewFilterNot(filter Filter) FilterNot {
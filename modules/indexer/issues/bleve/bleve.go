@@ -26,7 +26,7 @@ import (
 const (
 	issueIndexerAnalyzer      = "issueIndexer"
 	issueIndexerDocType       = "issueIndexerDocType"
-	issueIndexerLatestVersion = 7
+	issueIndexerLatestVersion = 8
 )
 
 const unicodeNormalizeName = "unicodeNormalize"
@@ -70,6 +70,10 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	numberFieldMapping.Store = false
 	numberFieldMapping.IncludeInAll = false
 
+	keywordFieldMapping := bleve.NewKeywordFieldMapping()
+	keywordFieldMapping.Store = false
+	keywordFieldMapping.IncludeInAll = false
+
 	docMapping.AddFieldMappingsAt("is_public", boolFieldMapping)
 
 	docMapping.AddFieldMappingsAt("title", textFieldMapping)
@@ -84,6 +88,7 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("milestone_id", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("project_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("no_project", boolFieldMapping)
+	docMapping.AddFieldMappingsAt("project_field_values", keywordFieldMapping)
 	docMapping.AddFieldMappingsAt("poster_id", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("assignee_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("no_assignee", boolFieldMapping)
@@ -252,6 +257,10 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		queries = append(queries, bleve.NewDisjunctionQuery(projectQueries...))
 	}
 
+	for fieldID, value := range options.ProjectFieldValues {
+		queries = append(queries, inner_bleve.TermQuery(internal.ProjectFieldValueToken(fieldID, value), "project_field_values"))
+	}
+
 	if options.PosterID != "" {
 		// "(none)" becomes 0, it means no poster
 		posterIDInt64, _ := strconv.ParseInt(options.PosterID, 10, 64)
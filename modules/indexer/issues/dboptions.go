@@ -51,6 +51,7 @@ func ToSearchOptions(keyword string, opts *issues_model.IssuesOptions) *SearchOp
 	} else {
 		searchOpt.ProjectIDs = opts.ProjectIDs
 	}
+	searchOpt.ProjectFieldValues = opts.ProjectFieldValues
 
 	searchOpt.AssigneeID = opts.AssigneeID
 
@@ -16,7 +16,7 @@ import (
 	"gitea.dev/modules/util"
 )
 
-const issueIndexerLatestVersion = 4
+const issueIndexerLatestVersion = 5
 
 var _ internal.Indexer = &Indexer{}
 
@@ -56,6 +56,7 @@ const (
 			"milestone_id": { "type": "integer", "index": true },
 			"project_ids": { "type": "integer", "index": true },
 			"no_project": { "type": "boolean", "index": true },
+			"project_field_values": { "type": "keyword", "index": true },
 			"poster_id": { "type": "integer", "index": true },
 			"assignee_ids": { "type": "integer", "index": true },
 			"no_assignee": { "type": "boolean", "index": true },
@@ -172,6 +173,10 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.Must(es.TermsQuery("project_ids", es.ToAnySlice(options.ProjectIDs)...))
 	}
 
+	for fieldID, value := range options.ProjectFieldValues {
+		query.Must(es.TermQuery("project_field_values", internal.ProjectFieldValueToken(fieldID, value)))
+	}
+
 	if options.PosterID != "" {
 		// "(none)" becomes 0, it means no poster
 		posterIDInt64, _ := strconv.ParseInt(options.PosterID, 10, 64)
@@ -33,6 +33,7 @@ type IndexerData struct {
 	ProjectIDs         []int64            `json:"project_ids"`
 	NoProject          bool               `json:"no_project"`                   // True if ProjectIDs is empty
 	ProjectColumnMap   map[int64]int64    `json:"project_column_map,omitempty"` // Maps project ID to column ID for each project the issue is in
+	ProjectFieldValues []string           `json:"project_field_values"`         // ProjectFieldValueToken of every project field value of the issue
 	PosterID           int64              `json:"poster_id"`
 	AssigneeIDs        []int64            `json:"assignee_ids"`
 	NoAssignee         bool               `json:"no_assignee"` // True if the issue has no assignees
@@ -50,6 +51,11 @@ type IndexerData struct {
 	CommentCount int64              `json:"comment_count"`
 }
 
+// ProjectFieldValueToken returns the term a project field value is indexed and searched as
+func ProjectFieldValueToken(fieldID int64, value string) string {
+	return strconv.FormatInt(fieldID, 10) + ":" + value
+}
+
 // Match represents on search result
 type Match struct {
 	ID    int64   `json:"id"`
@@ -99,6 +105,8 @@ type SearchOptions struct {
 	ProjectIDs    []int64 // project the issues belong to. FIXME: ISSUE-MULTIPLE-PROJECTS-FILTER: no multiple project filter support yet. Search logic is wrong.
 	NoProjectOnly bool    // if the issues have no project, if true, ProjectIDs will be ignored
 
+	ProjectFieldValues map[int64]string // project field ID to the stored value the issues must have
+
 	PosterID   string // poster of the issues, "(none)" or "(any)" or a user ID
 	AssigneeID string // assignee of the issues, "(none)" or "(any)" or a user ID
 
@@ -10,6 +10,7 @@ package tests
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"testing"
 	"time"
 
@@ -328,6 +329,25 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "ProjectFieldValues",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 50,
+			},
+			ProjectFieldValues: map[int64]string{1: "2", 2: `sprint "1"`},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			match := func(v *internal.IndexerData) bool {
+				return slices.Contains(v.ProjectFieldValues, "1:2") && slices.Contains(v.ProjectFieldValues, `2:sprint "1"`)
+			}
+			assert.NotEmpty(t, result.Hits)
+			for _, v := range result.Hits {
+				assert.True(t, match(data[v.ID]), "Issue %d should have the field values", v.ID)
+			}
+			assert.Equal(t, countIndexerData(data, match), result.Total)
+		},
+	},
 	{
 		Name: "no ProjectIDs (empty array)",
 		SearchOptions: &internal.SearchOptions{
@@ -686,6 +706,13 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 			for i := range projectIDs {
 				projectIDs[i] = int64(i) + 1 // projectID should not be 0
 			}
+			var projectFieldValues []string
+			if len(projectIDs) > 0 {
+				projectFieldValues = []string{
+					internal.ProjectFieldValueToken(1, strconv.FormatInt(id%3, 10)),
+					internal.ProjectFieldValueToken(2, fmt.Sprintf(`sprint "%d"`, id%2)),
+				}
+			}
 			var assigneeIDs []int64
 			if issueIndex%10 != 0 {
 				assigneeID := issueIndex % 10
@@ -713,6 +740,7 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				MilestoneID:        issueIndex % 4,
 				ProjectIDs:         projectIDs,
 				NoProject:          len(projectIDs) == 0,
+				ProjectFieldValues: projectFieldValues,
 				PosterID:           id%10 + 1, // PosterID should not be 0
 				AssigneeIDs:        assigneeIDs,
 				NoAssignee:         len(assigneeIDs) == 0,
@@ -20,7 +20,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 6
+	issueIndexerLatestVersion = 7
 
 	// TODO: make this configurable if necessary
 	maxTotalHits = 10000
@@ -73,6 +73,7 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"milestone_id",
 			"project_ids",
 			"no_project",
+			"project_field_values",
 			"poster_id",
 			"assignee_ids",
 			"no_assignee",
@@ -190,6 +191,10 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.And(inner_meilisearch.NewFilterIn("project_ids", options.ProjectIDs...))
 	}
 
+	for fieldID, value := range options.ProjectFieldValues {
+		query.And(inner_meilisearch.NewFilterEqString("project_field_values", internal.ProjectFieldValueToken(fieldID, value)))
+	}
+
 	if options.PosterID != "" {
 		// "(none)" becomes 0, it means no poster
 		posterIDInt64, _ := strconv.ParseInt(options.PosterID, 10, 64)
@@ -10,6 +10,7 @@ import (
 
 	"gitea.dev/models/db"
 	issue_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
 	"gitea.dev/modules/container"
 	"gitea.dev/modules/indexer/issues/internal"
 	"gitea.dev/modules/log"
@@ -97,6 +98,15 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		projectIDs = append(projectIDs, project.ID)
 	}
 
+	fieldValues, err := project_model.GetIssueFieldValues(ctx, issueID)
+	if err != nil {
+		return nil, false, err
+	}
+	fieldValueTokens := make([]string, 0, len(fieldValues))
+	for _, v := range fieldValues {
+		fieldValueTokens = append(fieldValueTokens, internal.ProjectFieldValueToken(v.FieldID, v.Value))
+	}
+
 	if err := issue.Repo.LoadOwner(ctx); err != nil {
 		return nil, false, fmt.Errorf("issue.Repo.LoadOwner: %w", err)
 	}
@@ -116,6 +126,7 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		MilestoneID:        issue.MilestoneID,
 		ProjectIDs:         projectIDs,
 		NoProject:          len(projectIDs) == 0,
+		ProjectFieldValues: fieldValueTokens,
 		PosterID:           issue.PosterID,
 		AssigneeIDs:        assigneeIDs,
 		NoAssignee:         len(assigneeIDs) == 0,
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"gitea.dev/modules/container"
+	"gitea.dev/modules/setting"
 	api "gitea.dev/modules/structs"
 
 	"gitea.com/go-chi/binding"
@@ -106,6 +107,36 @@ func validateYaml(template *api.IssueTemplate) error {
 			if err := validateOptions(field, idx); err != nil {
 				return err
 			}
+		case api.IssueFormFieldTypeUser, api.IssueFormFieldTypeLabel, api.IssueFormFieldTypeFile:
+			if err := validateStringItem(position, field.Attributes, false, "description"); err != nil {
+				return err
+			}
+			if err := validateBoolItem(position, field.Attributes, "multiple"); err != nil {
+				return err
+			}
+		case api.IssueFormFieldTypeDate:
+			if err := validateStringItem(position, field.Attributes, false, "description"); err != nil {
+				return err
+			}
+			if err := validateDateItem(position, field.Attributes, "value"); err != nil {
+				return err
+			}
+			if err := validateDateItem(position, field.Validations, "min", "max"); err != nil {
+				return err
+			}
+		case api.IssueFormFieldTypeNumber:
+			if err := validateStringItem(position, field.Attributes, false,
+				"description",
+				"placeholder",
+			); err != nil {
+				return err
+			}
+			if err := validateNumberItem(position, field.Attributes, "value"); err != nil {
+				return err
+			}
+			if err := validateNumberItem(position, field.Validations, "min", "max"); err != nil {
+				return err
+			}
 		default:
 			return position.Errorf("unknown type")
 		}
@@ -257,6 +288,42 @@ func validateBoolItem(position errorPosition, m map[string]any, names ...string)
 	return nil
 }
 
+func validateDateItem(position errorPosition, m map[string]any, names ...string) error {
+	for _, name := range names {
+		v, ok := m[name]
+		if !ok {
+			continue
+		}
+		if _, ok := dateItem(v); !ok {
+			return position.Errorf("'%s' should be a date formatted as YYYY-MM-DD", name)
+		}
+	}
+	if minDate, ok := dateItem(m["min"]); ok {
+		if maxDate, ok := dateItem(m["max"]); ok && maxDate.Before(minDate) {
+			return position.Errorf("'min' should not be after 'max'")
+		}
+	}
+	return nil
+}
+
+func validateNumberItem(position errorPosition, m map[string]any, names ...string) error {
+	for _, name := range names {
+		v, ok := m[name]
+		if !ok {
+			continue
+		}
+		if _, ok := numberItem(v); !ok {
+			return position.Errorf("'%s' should be a number", name)
+		}
+	}
+	if minNumber, ok := numberItem(m["min"]); ok {
+		if maxNumber, ok := numberItem(m["max"]); ok && maxNumber < minNumber {
+			return position.Errorf("'min' should not be greater than 'max'")
+		}
+	}
+	return nil
+}
+
 func validateDropdownDefault(position errorPosition, attributes map[string]any) error {
 	v, ok := attributes["default"]
 	if !ok {
@@ -374,6 +441,32 @@ func (f *valuedField) WriteTo(builder *strings.Builder) {
 		if value, ok := f.Attributes["value"].(string); ok {
 			_, _ = fmt.Fprintf(builder, "%s\n", value)
 		}
+	case api.IssueFormFieldTypeDate, api.IssueFormFieldTypeNumber:
+		if value := f.Value(); value == "" {
+			_, _ = fmt.Fprint(builder, blankPlaceholder)
+		} else {
+			_, _ = fmt.Fprintf(builder, "%s\n", value)
+		}
+	case api.IssueFormFieldTypeUser, api.IssueFormFieldTypeLabel:
+		names := f.Names()
+		if len(names) == 0 {
+			_, _ = fmt.Fprint(builder, blankPlaceholder)
+			break
+		}
+		if f.Type == api.IssueFormFieldTypeUser {
+			for i, name := range names {
+				names[i] = "@" + name
+			}
+		}
+		_, _ = fmt.Fprintf(builder, "%s\n", strings.Join(names, ", "))
+	case api.IssueFormFieldTypeFile:
+		uuids := f.UUIDs()
+		if len(uuids) == 0 {
+			_, _ = fmt.Fprint(builder, blankPlaceholder)
+		}
+		for _, uuid := range uuids {
+			_, _ = fmt.Fprintf(builder, "- %sattachments/%s\n", setting.AppURL, uuid)
+		}
 	}
 	_, _ = fmt.Fprintln(builder)
 }
@@ -406,6 +499,28 @@ func (f *valuedField) Value() string {
 	return strings.TrimSpace(f.Get("form-field-" + f.ID))
 }
 
+// Names returns the user or label names selected in the field
+func (f *valuedField) Names() []string {
+	var names []string
+	for name := range strings.SplitSeq(f.Value(), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// UUIDs returns the UUIDs of the attachments uploaded through the field
+func (f *valuedField) UUIDs() []string {
+	var uuids []string
+	for _, uuid := range f.Values["form-field-"+f.ID] {
+		if uuid = strings.TrimSpace(uuid); uuid != "" {
+			uuids = append(uuids, uuid)
+		}
+	}
+	return uuids
+}
+
 func (f *valuedField) Options() []*valuedOption {
 	if options, ok := f.Attributes["options"].([]any); ok {
 		ret := make([]*valuedOption, 0, len(options))
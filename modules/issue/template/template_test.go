@@ -244,6 +244,82 @@ body:
 `,
 			wantErr: "body[0](checkboxes): 'description' should be a string",
 		},
+		{
+			name: "user invalid multiple",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "user"
+    id: "1"
+    attributes:
+      label: "a"
+      multiple: "yes"
+`,
+			wantErr: "body[0](user): 'multiple' should be a bool",
+		},
+		{
+			name: "date invalid min",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "date"
+    id: "1"
+    attributes:
+      label: "a"
+    validations:
+      min: "next week"
+`,
+			wantErr: "body[0](date): 'min' should be a date formatted as YYYY-MM-DD",
+		},
+		{
+			name: "date min after max",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "date"
+    id: "1"
+    attributes:
+      label: "a"
+    validations:
+      min: 2026-02-01
+      max: 2026-01-01
+`,
+			wantErr: "body[0](date): 'min' should not be after 'max'",
+		},
+		{
+			name: "number invalid max",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "number"
+    id: "1"
+    attributes:
+      label: "a"
+    validations:
+      max: "ten"
+`,
+			wantErr: "body[0](number): 'max' should be a number",
+		},
+		{
+			name: "number min greater than max",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "number"
+    id: "1"
+    attributes:
+      label: "a"
+    validations:
+      min: 10
+      max: 1.5
+`,
+			wantErr: "body[0](number): 'min' should not be greater than 'max'",
+		},
 		{
 			name: "invalid type",
 			content: `
@@ -459,6 +535,83 @@ body:
 			},
 			wantErr: "",
 		},
+		{
+			name: "new field types are valid",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: user
+    id: reporter
+    attributes:
+      label: Reporter
+      multiple: true
+  - type: label
+    id: area
+    attributes:
+      label: Area
+  - type: date
+    id: seen
+    attributes:
+      label: Seen on
+      value: 2026-01-15
+    validations:
+      min: 2026-01-01
+      required: true
+  - type: number
+    id: users
+    attributes:
+      label: Affected users
+      placeholder: "0"
+    validations:
+      min: 0
+      max: 1000
+  - type: file
+    id: logs
+    attributes:
+      label: Logs
+`,
+			want: &api.IssueTemplate{
+				Name:  "test",
+				About: "this is about",
+				Fields: []*api.IssueFormField{
+					{
+						Type:       "user",
+						ID:         "reporter",
+						Attributes: map[string]any{"label": "Reporter", "multiple": true},
+						Visible:    []api.IssueFormFieldVisible{api.IssueFormFieldVisibleForm, api.IssueFormFieldVisibleContent},
+					},
+					{
+						Type:       "label",
+						ID:         "area",
+						Attributes: map[string]any{"label": "Area"},
+						Visible:    []api.IssueFormFieldVisible{api.IssueFormFieldVisibleForm, api.IssueFormFieldVisibleContent},
+					},
+					{
+						Type:        "date",
+						ID:          "seen",
+						Attributes:  map[string]any{"label": "Seen on", "value": "2026-01-15"},
+						Validations: map[string]any{"min": "2026-01-01", "required": true},
+						Visible:     []api.IssueFormFieldVisible{api.IssueFormFieldVisibleForm, api.IssueFormFieldVisibleContent},
+					},
+					{
+						Type:        "number",
+						ID:          "users",
+						Attributes:  map[string]any{"label": "Affected users", "placeholder": "0"},
+						Validations: map[string]any{"min": 0, "max": 1000},
+						Visible:     []api.IssueFormFieldVisible{api.IssueFormFieldVisibleForm, api.IssueFormFieldVisibleContent},
+					},
+					{
+						Type:       "file",
+						ID:         "logs",
+						Attributes: map[string]any{"label": "Logs"},
+						Visible:    []api.IssueFormFieldVisible{api.IssueFormFieldVisibleForm, api.IssueFormFieldVisibleContent},
+					},
+				},
+				FileName: "test.yaml",
+			},
+			wantErr: "",
+		},
 		{
 			name: "valid",
 			content: `
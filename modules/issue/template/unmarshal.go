@@ -132,6 +132,11 @@ func unmarshal(filename string, content []byte) (*api.IssueTemplate, error) {
 			if v.ID == "" {
 				v.ID = strconv.Itoa(i)
 			}
+			// dates are parsed from yaml as time.Time
+			if v.Type == api.IssueFormFieldTypeDate {
+				normalizeDates(v.Attributes, "value")
+				normalizeDates(v.Validations, "min", "max")
+			}
 			// set default visibility
 			if v.Visible == nil {
 				v.Visible = []api.IssueFormFieldVisible{api.IssueFormFieldVisibleForm}
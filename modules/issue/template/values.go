@@ -0,0 +1,297 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+)
+
+// dateItem returns the date of a template item, which is a string formatted as YYYY-MM-DD or a date parsed from yaml
+func dateItem(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.DateOnly, v)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// numberItem returns the number of a template item
+func numberItem(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// normalizeDates formats the dates parsed from yaml as YYYY-MM-DD, so they are rendered as such in the form
+func normalizeDates(m map[string]any, names ...string) {
+	for _, name := range names {
+		if t, ok := m[name].(time.Time); ok {
+			m[name] = t.Format(time.DateOnly)
+		}
+	}
+}
+
+func fieldError(f *valuedField, format string, a ...any) error {
+	return util.NewInvalidArgumentErrorf("%q: %s", f.Label(), fmt.Sprintf(format, a...))
+}
+
+// ParseValues checks the values submitted through the form of the template against the validations of its fields,
+// and returns them as structured data keyed by the field IDs:
+//   - a string for the input, textarea and date fields and a number for the number fields
+//   - the labels of the selected options for the dropdown and checkboxes fields
+//   - the user names, label names or attachment UUIDs for the user, label and file fields
+//
+// The dropdown, user and label fields which don't allow multiple values have a single string.
+// The fields without a value are left out.
+func ParseValues(template *api.IssueTemplate, values url.Values) (map[string]any, error) {
+	ret := make(map[string]any)
+	for _, field := range template.Fields {
+		if field.ID == "" || field.Type == api.IssueFormFieldTypeMarkdown {
+			continue
+		}
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		value, err := f.parse()
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			ret[f.ID] = value
+		} else if required, _ := f.Validations["required"].(bool); required {
+			return nil, fieldError(f, "a value is required")
+		}
+	}
+	return ret, nil
+}
+
+func (f *valuedField) parse() (any, error) {
+	multiple, _ := f.Attributes["multiple"].(bool)
+
+	switch f.Type {
+	case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+		value := f.Value()
+		if value == "" {
+			return nil, nil
+		}
+		if isNumber, _ := f.Validations["is_number"].(bool); isNumber {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, fieldError(f, "%q is not a number", value)
+			}
+		}
+		if pattern, _ := f.Validations["regex"].(string); pattern != "" {
+			// like the pattern attribute of the input element, the pattern has to match the whole value
+			re, err := regexp.Compile("^(?:" + pattern + ")$")
+			if err != nil {
+				return nil, fieldError(f, "invalid regex %q", pattern)
+			}
+			if !re.MatchString(value) {
+				return nil, fieldError(f, "%q does not match %q", value, pattern)
+			}
+		}
+		return value, nil
+
+	case api.IssueFormFieldTypeDropdown:
+		options := f.Options()
+		var selected []string
+		for idx := range strings.SplitSeq(f.Value(), ",") {
+			if idx = strings.TrimSpace(idx); idx == "" {
+				continue
+			}
+			i, err := strconv.Atoi(idx)
+			if err != nil || i < 0 || i >= len(options) {
+				return nil, fieldError(f, "unknown option %q", idx)
+			}
+			selected = append(selected, options[i].Label())
+		}
+		return multipleValue(f, multiple, selected)
+
+	case api.IssueFormFieldTypeCheckboxes:
+		var checked []string
+		for _, option := range f.Options() {
+			if option.IsChecked() {
+				checked = append(checked, option.Label())
+			} else if vs, ok := option.data.(map[string]any); ok {
+				if required, _ := vs["required"].(bool); required {
+					return nil, fieldError(f, "%q has to be checked", option.Label())
+				}
+			}
+		}
+		if len(checked) == 0 {
+			return nil, nil
+		}
+		return checked, nil
+
+	case api.IssueFormFieldTypeUser, api.IssueFormFieldTypeLabel:
+		return multipleValue(f, multiple, f.Names())
+
+	case api.IssueFormFieldTypeFile:
+		uuids := f.UUIDs()
+		if len(uuids) > 1 && !multiple {
+			return nil, fieldError(f, "only one file can be uploaded")
+		}
+		if len(uuids) == 0 {
+			return nil, nil
+		}
+		return uuids, nil
+
+	case api.IssueFormFieldTypeDate:
+		value := f.Value()
+		if value == "" {
+			return nil, nil
+		}
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, fieldError(f, "%q is not a date formatted as YYYY-MM-DD", value)
+		}
+		if minDate, ok := dateItem(f.Validations["min"]); ok && date.Before(minDate) {
+			return nil, fieldError(f, "%s is before %s", value, minDate.Format(time.DateOnly))
+		}
+		if maxDate, ok := dateItem(f.Validations["max"]); ok && date.After(maxDate) {
+			return nil, fieldError(f, "%s is after %s", value, maxDate.Format(time.DateOnly))
+		}
+		return value, nil
+
+	case api.IssueFormFieldTypeNumber:
+		value := f.Value()
+		if value == "" {
+			return nil, nil
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fieldError(f, "%q is not a number", value)
+		}
+		if minNumber, ok := numberItem(f.Validations["min"]); ok && number < minNumber {
+			return nil, fieldError(f, "%s is less than %v", value, minNumber)
+		}
+		if maxNumber, ok := numberItem(f.Validations["max"]); ok && number > maxNumber {
+			return nil, fieldError(f, "%s is greater than %v", value, maxNumber)
+		}
+		return number, nil
+	}
+	return nil, nil
+}
+
+func multipleValue(f *valuedField, multiple bool, values []string) (any, error) {
+	switch {
+	case len(values) == 0:
+		return nil, nil
+	case multiple:
+		return values, nil
+	case len(values) > 1:
+		return nil, fieldError(f, "only one value can be selected")
+	}
+	return values[0], nil
+}
+
+// ValuesFromMap converts the values of the fields of the template given as structured data, like ParseValues returns them,
+// into the values submitted by the form of the template. The options of the dropdown and checkboxes fields are given by their labels.
+func ValuesFromMap(template *api.IssueTemplate, m map[string]any) (url.Values, error) {
+	values := url.Values{}
+	for id := range m {
+		if !slices.ContainsFunc(template.Fields, func(field *api.IssueFormField) bool {
+			return field.ID == id && field.Type != api.IssueFormFieldTypeMarkdown
+		}) {
+			return nil, util.NewInvalidArgumentErrorf("unknown field %q", id)
+		}
+	}
+
+	for _, field := range template.Fields {
+		v, ok := m[field.ID]
+		if !ok || field.Type == api.IssueFormFieldTypeMarkdown {
+			continue
+		}
+		f := &valuedField{IssueFormField: field, Values: values}
+		key := "form-field-" + field.ID
+
+		switch field.Type {
+		case api.IssueFormFieldTypeNumber:
+			switch v := v.(type) {
+			case float64:
+				values.Set(key, strconv.FormatFloat(v, 'f', -1, 64))
+			case string:
+				values.Set(key, v)
+			default:
+				return nil, fieldError(f, "should be a number")
+			}
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea, api.IssueFormFieldTypeDate:
+			s, ok := v.(string)
+			if !ok {
+				return nil, fieldError(f, "should be a string")
+			}
+			values.Set(key, s)
+		default:
+			list, err := stringList(v)
+			if err != nil {
+				return nil, fieldError(f, "%v", err)
+			}
+			switch field.Type {
+			case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeCheckboxes:
+				options := f.Options()
+				indexes := make([]string, 0, len(list))
+				for _, label := range list {
+					i := slices.IndexFunc(options, func(option *valuedOption) bool { return option.Label() == label })
+					if i < 0 {
+						return nil, fieldError(f, "unknown option %q", label)
+					}
+					if field.Type == api.IssueFormFieldTypeCheckboxes {
+						values.Set(fmt.Sprintf("%s-%d", key, i), "on")
+					} else {
+						indexes = append(indexes, strconv.Itoa(i))
+					}
+				}
+				if field.Type == api.IssueFormFieldTypeDropdown {
+					values.Set(key, strings.Join(indexes, ","))
+				}
+			case api.IssueFormFieldTypeUser, api.IssueFormFieldTypeLabel:
+				values.Set(key, strings.Join(list, ","))
+			case api.IssueFormFieldTypeFile:
+				values[key] = list
+			}
+		}
+	}
+	return values, nil
+}
+
+func stringList(v any) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("should be a list of strings")
+			}
+			list = append(list, s)
+		}
+		return list, nil
+	}
+	return nil, errors.New("should be a string or a list of strings")
+}
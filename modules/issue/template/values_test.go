@@ -0,0 +1,187 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"net/url"
+	"testing"
+
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const valuesTestTemplate = `
+name: Bug
+about: Report a bug
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for reporting!
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+      regex: "[0-9]+\\.[0-9]+"
+  - type: dropdown
+    id: os
+    attributes:
+      label: OS
+      options: [Linux, macOS, Windows]
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: I searched the existing issues
+          required: true
+        - label: I can help fixing it
+  - type: user
+    id: owner
+    attributes:
+      label: Owner
+  - type: label
+    id: areas
+    attributes:
+      label: Areas
+      multiple: true
+  - type: date
+    id: seen
+    attributes:
+      label: Seen on
+    validations:
+      min: 2026-01-01
+  - type: number
+    id: users
+    attributes:
+      label: Affected users
+    validations:
+      min: 1
+  - type: file
+    id: logs
+    attributes:
+      label: Logs
+      multiple: true
+`
+
+func TestParseValues(t *testing.T) {
+	template, err := Unmarshal("bug.yaml", []byte(valuesTestTemplate))
+	require.NoError(t, err)
+
+	values := url.Values{
+		"form-field-version": {"1.25"},
+		"form-field-os":      {"2"},
+		"form-field-terms-0": {"on"},
+		"form-field-owner":   {"user2"},
+		"form-field-areas":   {"bug, ui"},
+		"form-field-seen":    {"2026-03-04"},
+		"form-field-users":   {"12"},
+		"form-field-logs":    {"uuid-1", "uuid-2"},
+	}
+	parsed, err := ParseValues(template, values)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"version": "1.25",
+		"os":      "Windows",
+		"terms":   []string{"I searched the existing issues"},
+		"owner":   "user2",
+		"areas":   []string{"bug", "ui"},
+		"seen":    "2026-03-04",
+		"users":   12.0,
+		"logs":    []string{"uuid-1", "uuid-2"},
+	}, parsed)
+
+	defer test.MockVariableValue(&setting.AppURL, "https://gitea.example.com/")()
+	assert.Equal(t, `### Version
+
+1.25
+
+### OS
+
+Windows
+
+### Terms
+
+- [x] I searched the existing issues
+- [ ] I can help fixing it
+
+### Owner
+
+@user2
+
+### Areas
+
+bug, ui
+
+### Seen on
+
+2026-03-04
+
+### Affected users
+
+12
+
+### Logs
+
+- https://gitea.example.com/attachments/uuid-1
+- https://gitea.example.com/attachments/uuid-2
+
+`, RenderToMarkdown(template, values))
+
+	for _, c := range []struct {
+		name   string
+		values url.Values
+	}{
+		{"missing required", url.Values{"form-field-terms-0": {"on"}}},
+		{"regex mismatch", url.Values{"form-field-version": {"latest"}, "form-field-terms-0": {"on"}}},
+		{"required checkbox", url.Values{"form-field-version": {"1.25"}}},
+		{"unknown option", url.Values{"form-field-version": {"1.25"}, "form-field-terms-0": {"on"}, "form-field-os": {"3"}}},
+		{"single user", url.Values{"form-field-version": {"1.25"}, "form-field-terms-0": {"on"}, "form-field-owner": {"user2,user3"}}},
+		{"date before min", url.Values{"form-field-version": {"1.25"}, "form-field-terms-0": {"on"}, "form-field-seen": {"2025-12-31"}}},
+		{"invalid date", url.Values{"form-field-version": {"1.25"}, "form-field-terms-0": {"on"}, "form-field-seen": {"yesterday"}}},
+		{"number below min", url.Values{"form-field-version": {"1.25"}, "form-field-terms-0": {"on"}, "form-field-users": {"0"}}},
+		{"invalid number", url.Values{"form-field-version": {"1.25"}, "form-field-terms-0": {"on"}, "form-field-users": {"many"}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := ParseValues(template, c.values)
+			assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestValuesFromMap(t *testing.T) {
+	template, err := Unmarshal("bug.yaml", []byte(valuesTestTemplate))
+	require.NoError(t, err)
+
+	values, err := ValuesFromMap(template, map[string]any{
+		"version": "1.25",
+		"os":      "macOS",
+		"terms":   []any{"I searched the existing issues", "I can help fixing it"},
+		"areas":   []any{"bug", "ui"},
+		"users":   3.0,
+		"logs":    []any{"uuid-1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"form-field-version": {"1.25"},
+		"form-field-os":      {"1"},
+		"form-field-terms-0": {"on"},
+		"form-field-terms-1": {"on"},
+		"form-field-areas":   {"bug,ui"},
+		"form-field-users":   {"3"},
+		"form-field-logs":    {"uuid-1"},
+	}, values)
+
+	_, err = ValuesFromMap(template, map[string]any{"unknown": "value"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = ValuesFromMap(template, map[string]any{"os": "BeOS"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = ValuesFromMap(template, map[string]any{"users": []any{1}})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
@@ -24,6 +24,7 @@ type Issue struct {
 	Labels       []*Label          `json:"labels"`
 	Reactions    []*Reaction       `json:"reactions"`
 	Assignees    []string          `json:"assignees"`
+	Dependencies []int64           `json:"dependencies"` // the numbers of the issues which have to be closed before this one
 	ForeignIndex int64             `json:"foreign_id"`
 	Context      DownloaderContext `yaml:"-"`
 }
@@ -0,0 +1,368 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/validation"
+
+	"github.com/hashicorp/go-version"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	PropertyKind = "ansible.kind"
+
+	KindCollection = "collection"
+	KindRole       = "role"
+
+	maxMetadataFileSize = 1024 * 1024
+	maxReadmeFileSize   = 1024 * 1024
+)
+
+var (
+	ErrMissingMetadataFile  = util.NewInvalidArgumentErrorf("metadata file is missing")
+	ErrMetadataFileTooLarge = util.NewInvalidArgumentErrorf("metadata file is too large")
+	ErrInvalidNamespace     = util.NewInvalidArgumentErrorf("namespace is invalid")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("package version is invalid")
+)
+
+var (
+	// https://docs.ansible.com/ansible/latest/dev_guide/collections_galaxy_meta.html
+	namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+	// collection versions must have all three components of semantic versioning
+	collectionVersionPattern = regexp.MustCompile(`\A\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?\z`)
+)
+
+// IsValidName returns whether the name is a valid namespace or name of a collection or role
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// PackageName returns the name of the package of a collection or role, which is qualified by its namespace
+func PackageName(namespace, name string) string {
+	return namespace + "." + name
+}
+
+// SplitPackageName returns the namespace and the name of a collection or role from the name of its package
+func SplitPackageName(packageName string) (namespace, name string) {
+	namespace, name, _ = strings.Cut(packageName, ".")
+	return namespace, name
+}
+
+// Filename returns the name of the archive of a collection or role
+func Filename(namespace, name, version string) string {
+	return strings.ToLower(fmt.Sprintf("%s-%s-%s.tar.gz", namespace, name, version))
+}
+
+// Package represents an Ansible collection or role
+type Package struct {
+	Namespace string
+	Name      string
+	Version   string
+	Metadata  *Metadata
+}
+
+// Metadata represents the metadata of an Ansible collection or role
+type Metadata struct {
+	Kind              string            `json:"kind"`
+	Description       string            `json:"description,omitempty"`
+	Readme            string            `json:"readme,omitempty"`
+	Authors           []string          `json:"authors,omitempty"`
+	Tags              []string          `json:"tags,omitempty"`
+	Licenses          []string          `json:"licenses,omitempty"`
+	ProjectURL        string            `json:"project_url,omitempty"`
+	RepositoryURL     string            `json:"repository_url,omitempty"`
+	DocumentationURL  string            `json:"documentation_url,omitempty"`
+	IssuesURL         string            `json:"issues_url,omitempty"`
+	Dependencies      map[string]string `json:"dependencies,omitempty"`
+	MinAnsibleVersion string            `json:"min_ansible_version,omitempty"`
+}
+
+// collectionInfo is the metadata of a collection in its MANIFEST.json or galaxy.yml file
+type collectionInfo struct {
+	Namespace     string            `json:"namespace" yaml:"namespace"`
+	Name          string            `json:"name" yaml:"name"`
+	Version       string            `json:"version" yaml:"version"`
+	Authors       []string          `json:"authors" yaml:"authors"`
+	Readme        string            `json:"readme" yaml:"readme"`
+	Tags          []string          `json:"tags" yaml:"tags"`
+	Description   string            `json:"description" yaml:"description"`
+	License       any               `json:"license" yaml:"license"`
+	Dependencies  map[string]string `json:"dependencies" yaml:"dependencies"`
+	Repository    string            `json:"repository" yaml:"repository"`
+	Documentation string            `json:"documentation" yaml:"documentation"`
+	Homepage      string            `json:"homepage" yaml:"homepage"`
+	Issues        string            `json:"issues" yaml:"issues"`
+}
+
+type collectionManifest struct {
+	CollectionInfo collectionInfo `json:"collection_info"`
+}
+
+// roleMeta is the metadata of a role in its meta/main.yml file
+type roleMeta struct {
+	GalaxyInfo struct {
+		Author            string `yaml:"author"`
+		Description       string `yaml:"description"`
+		License           any    `yaml:"license"`
+		MinAnsibleVersion any    `yaml:"min_ansible_version"`
+		GalaxyTags        []any  `yaml:"galaxy_tags"`
+		IssueTrackerURL   string `yaml:"issue_tracker_url"`
+	} `yaml:"galaxy_info"`
+	Dependencies []any `yaml:"dependencies"`
+}
+
+// archiveFiles reads the files of a gzipped tar archive which are selected by the callback.
+// The callback returns the maximum size of the file, or 0 if the file isn't needed.
+func archiveFiles(r io.Reader, selectFile func(name string) int64) (map[string][]byte, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid archive: %v", err)
+	}
+	defer gzr.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid archive: %v", err)
+		}
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := strings.TrimPrefix(path.Clean(hd.Name), "./")
+		limit := selectFile(name)
+		if limit == 0 {
+			continue
+		}
+		if hd.Size > limit {
+			return nil, ErrMetadataFileTooLarge
+		}
+		data, err := util.ReadWithLimit(tr, int(limit))
+		if err != nil {
+			return nil, err
+		}
+		files[name] = data
+	}
+}
+
+// ParseCollection parses the archive of a collection built by ansible-galaxy collection build.
+// The metadata is read from the MANIFEST.json file, or from the galaxy.yml file of the collection sources.
+func ParseCollection(r io.Reader) (*Package, error) {
+	files, err := archiveFiles(r, func(name string) int64 {
+		switch {
+		case name == "MANIFEST.json" || name == "galaxy.yml":
+			return maxMetadataFileSize
+		// the path of the readme file is configured in the metadata, which may come after it
+		case strings.Count(name, "/") <= 1 && strings.HasPrefix(strings.ToLower(path.Base(name)), "readme"):
+			return maxReadmeFileSize
+		}
+		return 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var info collectionInfo
+	if data, ok := files["MANIFEST.json"]; ok {
+		var manifest collectionManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid MANIFEST.json: %v", err)
+		}
+		info = manifest.CollectionInfo
+	} else if data, ok := files["galaxy.yml"]; ok {
+		if err := yaml.Unmarshal(data, &info); err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid galaxy.yml: %v", err)
+		}
+	} else {
+		return nil, ErrMissingMetadataFile
+	}
+
+	if !IsValidName(info.Namespace) {
+		return nil, ErrInvalidNamespace
+	}
+	if !IsValidName(info.Name) {
+		return nil, ErrInvalidName
+	}
+	if _, err := version.NewSemver(info.Version); err != nil || !collectionVersionPattern.MatchString(info.Version) {
+		return nil, ErrInvalidVersion
+	}
+
+	readme := info.Readme
+	if readme == "" {
+		readme = "README.md"
+	}
+
+	return &Package{
+		Namespace: info.Namespace,
+		Name:      info.Name,
+		Version:   info.Version,
+		Metadata: &Metadata{
+			Kind:             KindCollection,
+			Description:      info.Description,
+			Readme:           string(files[readme]),
+			Authors:          info.Authors,
+			Tags:             info.Tags,
+			Licenses:         stringList(info.License),
+			ProjectURL:       validURL(info.Homepage),
+			RepositoryURL:    validURL(info.Repository),
+			DocumentationURL: validURL(info.Documentation),
+			IssuesURL:        validURL(info.Issues),
+			Dependencies:     info.Dependencies,
+		},
+	}, nil
+}
+
+// ParseRole parses the archive of a role. The archive contains the role in its root or in a single directory,
+// like the archives of a git repository.
+func ParseRole(r io.Reader, namespace, name, v string) (*Package, error) {
+	if !IsValidName(namespace) {
+		return nil, ErrInvalidNamespace
+	}
+	if !IsValidName(name) {
+		return nil, ErrInvalidName
+	}
+	if _, err := version.NewSemver(v); err != nil {
+		return nil, ErrInvalidVersion
+	}
+
+	files, err := archiveFiles(r, func(name string) int64 {
+		base := path.Base(name)
+		if strings.Count(name, "/") > 2 {
+			return 0
+		}
+		switch {
+		case isRoleMetaFile(name):
+			return maxMetadataFileSize
+		case strings.HasPrefix(strings.ToLower(base), "readme"):
+			return maxReadmeFileSize
+		}
+		return 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// like ansible-galaxy, use the shortest parent directory of a meta file as the root of the role
+	var metaFile string
+	for filename := range files {
+		if isRoleMetaFile(filename) {
+			if metaFile == "" || len(filename) < len(metaFile) || len(filename) == len(metaFile) && filename < metaFile {
+				metaFile = filename
+			}
+		}
+	}
+	if metaFile == "" {
+		return nil, ErrMissingMetadataFile
+	}
+	root := path.Dir(path.Dir(metaFile))
+
+	var meta roleMeta
+	if err := yaml.Unmarshal(files[metaFile], &meta); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid %s: %v", metaFile, err)
+	}
+
+	m := &Metadata{
+		Kind:        KindRole,
+		Description: meta.GalaxyInfo.Description,
+		Licenses:    stringList(meta.GalaxyInfo.License),
+		Tags:        stringList(meta.GalaxyInfo.GalaxyTags),
+		IssuesURL:   validURL(meta.GalaxyInfo.IssueTrackerURL),
+	}
+	if meta.GalaxyInfo.Author != "" {
+		m.Authors = []string{meta.GalaxyInfo.Author}
+	}
+	if meta.GalaxyInfo.MinAnsibleVersion != nil {
+		m.MinAnsibleVersion = fmt.Sprint(meta.GalaxyInfo.MinAnsibleVersion)
+	}
+	for _, dep := range meta.Dependencies {
+		depName, depVersion := roleDependency(dep)
+		if depName != "" {
+			if m.Dependencies == nil {
+				m.Dependencies = make(map[string]string)
+			}
+			m.Dependencies[depName] = depVersion
+		}
+	}
+	for filename, data := range files {
+		if path.Dir(filename) == root && strings.EqualFold(path.Base(filename), "readme.md") {
+			m.Readme = string(data)
+		}
+	}
+
+	return &Package{
+		Namespace: namespace,
+		Name:      name,
+		Version:   v,
+		Metadata:  m,
+	}, nil
+}
+
+func isRoleMetaFile(name string) bool {
+	return name == "meta/main.yml" || name == "meta/main.yaml" ||
+		strings.HasSuffix(name, "/meta/main.yml") || strings.HasSuffix(name, "/meta/main.yaml")
+}
+
+// roleDependency returns the name and version of a role dependency, which is either the name or a map with the role name
+func roleDependency(dep any) (string, string) {
+	switch v := dep.(type) {
+	case string:
+		return v, ""
+	case map[string]any:
+		name, _ := v["role"].(string)
+		if name == "" {
+			name, _ = v["name"].(string)
+		}
+		if name == "" {
+			name, _ = v["src"].(string)
+		}
+		version, _ := v["version"].(string)
+		return name, version
+	}
+	return "", ""
+}
+
+// stringList returns a list of strings from a yaml or json value which is either a single string or a list
+func stringList(v any) []string {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" && !slices.Contains(list, s) {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func validURL(s string) string {
+	if validation.IsValidURL(s) {
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	namespace      = "gitea"
+	packageName    = "test_collection"
+	packageVersion = "1.0.1"
+	description    = "Package Description"
+	projectURL     = "https://gitea.com"
+)
+
+func createArchive(files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for filename, content := range files {
+		hdr := &tar.Header{
+			Name: filename,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		}
+		tw.WriteHeader(hdr)
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	zw.Close()
+	return buf.Bytes()
+}
+
+const manifestContent = `{
+  "collection_info": {
+    "namespace": "` + namespace + `",
+    "name": "` + packageName + `",
+    "version": "` + packageVersion + `",
+    "authors": ["Gitea Authors"],
+    "readme": "docs/README.md",
+    "tags": ["git"],
+    "description": "` + description + `",
+    "license": ["MIT"],
+    "license_file": null,
+    "dependencies": {"community.general": ">=1.0.0"},
+    "repository": "` + projectURL + `/repo",
+    "documentation": "not a link",
+    "homepage": "` + projectURL + `",
+    "issues": null
+  },
+  "file_manifest_file": {"name": "FILES.json", "ftype": "file"},
+  "format": 1
+}`
+
+func TestParseCollection(t *testing.T) {
+	t.Run("MissingMetadataFile", func(t *testing.T) {
+		p, err := ParseCollection(bytes.NewReader(createArchive(map[string]string{"README.md": ""})))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingMetadataFile)
+	})
+
+	t.Run("InvalidArchive", func(t *testing.T) {
+		p, err := ParseCollection(bytes.NewReader([]byte("invalid")))
+		assert.Nil(t, p)
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		for _, name := range []string{"", "Test", "test-collection", "1test"} {
+			p, err := ParseCollection(bytes.NewReader(createArchive(map[string]string{
+				"galaxy.yml": "namespace: gitea\nname: " + name + "\nversion: 1.0.0\n",
+			})))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidName)
+		}
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		for _, version := range []string{"", "1.0", "a.b.c"} {
+			p, err := ParseCollection(bytes.NewReader(createArchive(map[string]string{
+				"galaxy.yml": "namespace: gitea\nname: test\nversion: '" + version + "'\n",
+			})))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidVersion)
+		}
+	})
+
+	t.Run("Manifest", func(t *testing.T) {
+		p, err := ParseCollection(bytes.NewReader(createArchive(map[string]string{
+			"MANIFEST.json":  manifestContent,
+			"FILES.json":     "{}",
+			"docs/README.md": "# Collection",
+			"README.md":      "ignored",
+		})))
+		require.NoError(t, err)
+		assert.Equal(t, namespace, p.Namespace)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, KindCollection, p.Metadata.Kind)
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, "# Collection", p.Metadata.Readme)
+		assert.Equal(t, []string{"Gitea Authors"}, p.Metadata.Authors)
+		assert.Equal(t, []string{"git"}, p.Metadata.Tags)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+		assert.Equal(t, projectURL, p.Metadata.ProjectURL)
+		assert.Equal(t, projectURL+"/repo", p.Metadata.RepositoryURL)
+		assert.Empty(t, p.Metadata.DocumentationURL)
+		assert.Empty(t, p.Metadata.IssuesURL)
+		assert.Equal(t, map[string]string{"community.general": ">=1.0.0"}, p.Metadata.Dependencies)
+	})
+
+	t.Run("GalaxyYAML", func(t *testing.T) {
+		p, err := ParseCollection(bytes.NewReader(createArchive(map[string]string{
+			"galaxy.yml": `namespace: gitea
+name: test
+version: 2.0.0-rc.1
+license: GPL-3.0-or-later
+`,
+			"README.md": "# Test",
+		})))
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-rc.1", p.Version)
+		assert.Equal(t, []string{"GPL-3.0-or-later"}, p.Metadata.Licenses)
+		assert.Equal(t, "# Test", p.Metadata.Readme)
+	})
+}
+
+func TestParseRole(t *testing.T) {
+	const roleMeta = `galaxy_info:
+  role_name: test_role
+  author: Gitea Authors
+  description: ` + description + `
+  license: MIT
+  min_ansible_version: 2.9
+  galaxy_tags:
+    - git
+    - web
+dependencies:
+  - gitea.common
+  - role: gitea.database
+    version: 1.2.0
+  - src: https://example.com/role.tar.gz
+`
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		content := createArchive(map[string]string{"meta/main.yml": roleMeta})
+
+		_, err := ParseRole(bytes.NewReader(content), "Gitea", "test_role", "1.0.0")
+		assert.ErrorIs(t, err, ErrInvalidNamespace)
+		_, err = ParseRole(bytes.NewReader(content), namespace, "test-role", "1.0.0")
+		assert.ErrorIs(t, err, ErrInvalidName)
+		_, err = ParseRole(bytes.NewReader(content), namespace, "test_role", "main")
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("MissingMetadataFile", func(t *testing.T) {
+		p, err := ParseRole(bytes.NewReader(createArchive(map[string]string{"tasks/main.yml": ""})), namespace, "test_role", "1.0.0")
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingMetadataFile)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParseRole(bytes.NewReader(createArchive(map[string]string{
+			"test_role-1.0.0/meta/main.yml":                     roleMeta,
+			"test_role-1.0.0/README.md":                         "# Role",
+			"test_role-1.0.0/tasks/main.yml":                    "",
+			"test_role-1.0.0/roles/nested/meta/main.yml":        "galaxy_info:\n  description: nested\n",
+			"test_role-1.0.0/molecule/default/meta/main.yml":    "",
+			"test_role-1.0.0/molecule/default/docs/README.md":   "nested",
+			"test_role-1.0.0/molecule/default/meta/README.md":   "nested",
+			"test_role-1.0.0/molecule/default/tasks/README.md":  "nested",
+			"test_role-1.0.0/molecule/default/tests/README.md":  "nested",
+			"test_role-1.0.0/molecule/default/tests/README.txt": "nested",
+		})), namespace, "test_role", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, namespace, p.Namespace)
+		assert.Equal(t, "test_role", p.Name)
+		assert.Equal(t, "1.0.0", p.Version)
+		assert.Equal(t, KindRole, p.Metadata.Kind)
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, "# Role", p.Metadata.Readme)
+		assert.Equal(t, []string{"Gitea Authors"}, p.Metadata.Authors)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+		assert.Equal(t, []string{"git", "web"}, p.Metadata.Tags)
+		assert.Equal(t, "2.9", p.Metadata.MinAnsibleVersion)
+		assert.Equal(t, map[string]string{
+			"gitea.common":                    "",
+			"gitea.database":                  "1.2.0",
+			"https://example.com/role.tar.gz": "",
+		}, p.Metadata.Dependencies)
+	})
+}
+
+func TestPackageName(t *testing.T) {
+	assert.Equal(t, "gitea.test", PackageName("gitea", "test"))
+	ns, name := SplitPackageName("gitea.test")
+	assert.Equal(t, "gitea", ns)
+	assert.Equal(t, "test", name)
+	assert.Equal(t, "gitea-test-1.0.0-rc.1.tar.gz", Filename("gitea", "test", "1.0.0-RC.1"))
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	PropertyRetirement = "hex.retirement"
+
+	// https://github.com/hexpm/specifications/blob/main/package_tarball.md
+	tarballVersion      = "3"
+	maxMetadataFileSize = 128 * 1024
+	maxReadmeFileSize   = 1024 * 1024
+)
+
+var (
+	ErrMissingFile          = util.NewInvalidArgumentErrorf("package tarball is missing a file")
+	ErrMetadataFileTooLarge = util.NewInvalidArgumentErrorf("metadata file is too large")
+	ErrInvalidTarball       = util.NewInvalidArgumentErrorf("package tarball version is not supported")
+	ErrInvalidChecksum      = util.NewInvalidArgumentErrorf("package tarball checksum does not match")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("package version is invalid")
+)
+
+var (
+	namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+	// Hex requires the versions to have all three components of semantic versioning
+	versionPattern = regexp.MustCompile(`\A\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?\z`)
+)
+
+// IsValidName returns whether the name is a valid package name
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Package represents a Hex package
+type Package struct {
+	Name     string
+	Version  string
+	Metadata *Metadata
+}
+
+// Metadata represents the metadata of a Hex package
+type Metadata struct {
+	App               string            `json:"app,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	Licenses          []string          `json:"licenses,omitempty"`
+	Links             map[string]string `json:"links,omitempty"`
+	BuildTools        []string          `json:"build_tools,omitempty"`
+	ElixirRequirement string            `json:"elixir,omitempty"`
+	Requirements      []*Requirement    `json:"requirements,omitempty"`
+	Readme            string            `json:"readme,omitempty"`
+	InnerChecksum     string            `json:"inner_checksum"`
+}
+
+// Requirement represents a dependency of a Hex package
+type Requirement struct {
+	Name        string `json:"name"`
+	App         string `json:"app,omitempty"`
+	Requirement string `json:"requirement"`
+	Optional    bool   `json:"optional,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+}
+
+// Retirement represents the retirement status of a Hex package version
+type Retirement struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// RetirementReasons are the reasons a package version can be retired for, in the order of the registry enum
+var RetirementReasons = []string{"other", "invalid", "security", "deprecated", "renamed"}
+
+// IsValidRetirementReason returns whether the reason is a known retirement reason
+func IsValidRetirementReason(reason string) bool {
+	return slices.Contains(RetirementReasons, reason)
+}
+
+// ParsePackage parses the Hex package tarball
+func ParsePackage(r io.Reader) (*Package, error) {
+	var versionData, checksumData, metadataData, contentsData []byte
+
+	tr := tar.NewReader(r)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch hd.Name {
+		case "VERSION":
+			versionData, err = util.ReadWithLimit(tr, 16)
+		case "CHECKSUM":
+			checksumData, err = util.ReadWithLimit(tr, 128)
+		case "metadata.config":
+			if hd.Size > maxMetadataFileSize {
+				return nil, ErrMetadataFileTooLarge
+			}
+			metadataData, err = io.ReadAll(tr)
+		case "contents.tar.gz":
+			contentsData, err = io.ReadAll(tr)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if versionData == nil || checksumData == nil || metadataData == nil || contentsData == nil {
+		return nil, ErrMissingFile
+	}
+	if strings.TrimSpace(string(versionData)) != tarballVersion {
+		return nil, ErrInvalidTarball
+	}
+
+	// the inner checksum covers the files of the tarball besides the checksum itself
+	h := sha256.New()
+	h.Write(versionData)
+	h.Write(metadataData)
+	h.Write(contentsData)
+	innerChecksum := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(strings.TrimSpace(string(checksumData)), innerChecksum) {
+		return nil, ErrInvalidChecksum
+	}
+
+	p, err := ParseMetadata(metadataData)
+	if err != nil {
+		return nil, err
+	}
+	p.Metadata.InnerChecksum = innerChecksum
+
+	readme, err := readReadme(contentsData)
+	if err != nil {
+		return nil, err
+	}
+	p.Metadata.Readme = readme
+
+	return p, nil
+}
+
+// readReadme reads the readme file in the root of the package contents
+func readReadme(contents []byte) (string, error) {
+	gzr, err := gzip.NewReader(bytes.NewReader(contents))
+	if err != nil {
+		return "", util.NewInvalidArgumentErrorf("invalid package contents: %v", err)
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			return "", nil
+		}
+		if err != nil {
+			return "", util.NewInvalidArgumentErrorf("invalid package contents: %v", err)
+		}
+
+		if hd.Typeflag == tar.TypeReg && strings.EqualFold(path.Clean(hd.Name), "readme.md") {
+			data, err := util.ReadWithLimit(tr, maxReadmeFileSize)
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		}
+	}
+}
+
+// ParseMetadata parses the metadata.config file of a Hex package tarball
+func ParseMetadata(data []byte) (*Package, error) {
+	terms, err := parseTerms(string(data))
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid metadata file: %v", err)
+	}
+	values := proplist(terms)
+
+	name, _ := termString(values["name"])
+	if !IsValidName(name) {
+		return nil, ErrInvalidName
+	}
+
+	v, _ := termString(values["version"])
+	if _, err := version.NewSemver(v); err != nil || !versionPattern.MatchString(v) {
+		return nil, ErrInvalidVersion
+	}
+
+	m := &Metadata{
+		Licenses:   termStrings(values["licenses"]),
+		BuildTools: termStrings(values["build_tools"]),
+	}
+	m.App, _ = termString(values["app"])
+	m.Description, _ = termString(values["description"])
+	m.ElixirRequirement, _ = termString(values["elixir"])
+	if m.App == "" {
+		m.App = name
+	}
+
+	for key, value := range proplist(values["links"]) {
+		if link, ok := termString(value); ok && validation.IsValidURL(link) {
+			if m.Links == nil {
+				m.Links = make(map[string]string)
+			}
+			m.Links[key] = link
+		}
+	}
+
+	requirements, _ := values["requirements"].([]any)
+	for _, elem := range requirements {
+		req, err := parseRequirement(elem)
+		if err != nil {
+			return nil, err
+		}
+		m.Requirements = append(m.Requirements, req)
+	}
+	slices.SortFunc(m.Requirements, func(a, b *Requirement) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return &Package{
+		Name:     name,
+		Version:  v,
+		Metadata: m,
+	}, nil
+}
+
+// parseRequirement parses a requirement which is either a list of properties with the name of the dependency,
+// or a tuple of the name and the list of properties
+func parseRequirement(elem any) (*Requirement, error) {
+	var name string
+	var props map[string]any
+	if t, ok := elem.(Tuple); ok && len(t) == 2 {
+		name, _ = termString(t[0])
+		props = proplist(t[1])
+	} else {
+		props = proplist(elem)
+		name, _ = termString(props["name"])
+	}
+	if !IsValidName(name) {
+		return nil, util.NewInvalidArgumentErrorf("requirement name %q is invalid", name)
+	}
+
+	req := &Requirement{Name: name}
+	req.App, _ = termString(props["app"])
+	req.Requirement, _ = termString(props["requirement"])
+	req.Repository, _ = termString(props["repository"])
+	optional, _ := props["optional"].(Atom)
+	req.Optional = optional == "true"
+	if req.Requirement == "" {
+		return nil, util.NewInvalidArgumentErrorf("requirement of %q is missing", name)
+	}
+	return req, nil
+}
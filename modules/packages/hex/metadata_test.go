@@ -0,0 +1,209 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packageName    = "gitea"
+	packageVersion = "1.0.1"
+	description    = "Package Description"
+	projectURL     = "https://gitea.com"
+)
+
+const metadataContent = `{<<"app">>,<<"` + packageName + `">>}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"description">>,<<"` + description + `">>}.
+{<<"elixir">>,<<"~> 1.15">>}.
+{<<"files">>,[<<"lib">>,<<"lib/gitea.ex">>,<<"mix.exs">>,<<"README.md">>]}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"links">>,[{<<"GitHub">>,<<"` + projectURL + `">>},{<<"Invalid">>,<<"not a link">>}]}.
+{<<"name">>,<<"` + packageName + `">>}.
+{<<"requirements">>,
+ [[{<<"name">>,<<"plug">>},
+   {<<"app">>,<<"plug">>},
+   {<<"optional">>,false},
+   {<<"requirement">>,<<"~> 1.14">>},
+   {<<"repository">>,<<"hexpm">>}],
+  [{<<"name">>,<<"jason">>},
+   {<<"app">>,<<"jason">>},
+   {<<"optional">>,true},
+   {<<"requirement">>,<<"~> 1.0">>},
+   {<<"repository">>,<<"hexpm">>}]]}.
+{<<"version">>,<<"` + packageVersion + `">>}.
+`
+
+func createArchive(files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for filename, content := range files {
+		hdr := &tar.Header{
+			Name: filename,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		}
+		tw.WriteHeader(hdr)
+		tw.Write(content)
+	}
+	tw.Close()
+	return buf.Bytes()
+}
+
+func createTarball(metadata string, contents map[string][]byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(createArchive(contents))
+	zw.Close()
+
+	h := sha256.New()
+	h.Write([]byte("3"))
+	h.Write([]byte(metadata))
+	h.Write(buf.Bytes())
+
+	return createArchive(map[string][]byte{
+		"VERSION":         []byte("3"),
+		"CHECKSUM":        []byte(hex.EncodeToString(h.Sum(nil))),
+		"metadata.config": []byte(metadata),
+		"contents.tar.gz": buf.Bytes(),
+	})
+}
+
+func TestParsePackage(t *testing.T) {
+	t.Run("MissingFile", func(t *testing.T) {
+		data := createArchive(map[string][]byte{"VERSION": []byte("3")})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingFile)
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		data := createArchive(map[string][]byte{
+			"VERSION":         []byte("2"),
+			"CHECKSUM":        {},
+			"metadata.config": {},
+			"contents.tar.gz": {},
+		})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidTarball)
+	})
+
+	t.Run("InvalidChecksum", func(t *testing.T) {
+		data := createArchive(map[string][]byte{
+			"VERSION":         []byte("3"),
+			"CHECKSUM":        []byte("0000"),
+			"metadata.config": []byte(metadataContent),
+			"contents.tar.gz": {},
+		})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidChecksum)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		data := createTarball(metadataContent, map[string][]byte{
+			"README.md":    []byte("# Gitea"),
+			"lib/gitea.ex": []byte("defmodule Gitea do\nend"),
+		})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, packageName, p.Metadata.App)
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, "~> 1.15", p.Metadata.ElixirRequirement)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+		assert.Equal(t, []string{"mix"}, p.Metadata.BuildTools)
+		assert.Equal(t, map[string]string{"GitHub": projectURL}, p.Metadata.Links)
+		assert.Equal(t, "# Gitea", p.Metadata.Readme)
+		assert.Len(t, p.Metadata.InnerChecksum, 64)
+		assert.Equal(t, []*Requirement{
+			{Name: "jason", App: "jason", Requirement: "~> 1.0", Optional: true, Repository: "hexpm"},
+			{Name: "plug", App: "plug", Requirement: "~> 1.14", Repository: "hexpm"},
+		}, p.Metadata.Requirements)
+	})
+}
+
+func TestParseMetadata(t *testing.T) {
+	t.Run("InvalidName", func(t *testing.T) {
+		for _, name := range []string{"", "Gitea", "gitea-package", "1gitea"} {
+			p, err := ParseMetadata([]byte(`{<<"name">>,<<"` + name + `">>}. {<<"version">>,<<"1.0.0">>}.`))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidName)
+		}
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		for _, version := range []string{"", "1.0", "a.b.c"} {
+			p, err := ParseMetadata([]byte(`{<<"name">>,<<"gitea">>}. {<<"version">>,<<"` + version + `">>}.`))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidVersion)
+		}
+	})
+
+	t.Run("LegacyRequirements", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`{<<"name">>,<<"gitea">>}.
+{<<"version">>,<<"1.0.0-rc.1">>}.
+{<<"description">>,<<"G"/utf8,105,"tea \"quoted\"">>}.
+{<<"requirements">>,[{<<"plug">>,[{<<"app">>,<<"plug">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.14">>}]}]}.
+`))
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0-rc.1", p.Version)
+		assert.Equal(t, `Gitea "quoted"`, p.Metadata.Description)
+		assert.Equal(t, []*Requirement{{Name: "plug", App: "plug", Requirement: "~> 1.14"}}, p.Metadata.Requirements)
+	})
+
+	t.Run("InvalidTerm", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`{<<"name">>,<<"gitea">>`))
+		assert.Nil(t, p)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseTerms(t *testing.T) {
+	terms, err := parseTerms(`% comment
+{<<"binary"/utf8>>, "string", atom, 'quoted atom', -12, 1.5e3, [], #{<<"k">> => [1, 2]}}.
+<<104,105>>.
+"esc\n\x41\101".
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		Tuple{"binary", "string", Atom("atom"), Atom("quoted atom"), int64(-12), 1500.0, []any{}, []any{Tuple{"k", []any{int64(1), int64(2)}}}},
+		"hi",
+		"esc\nAA",
+	}, terms)
+
+	for _, data := range []string{`{a`, `[a b]`, `a`, `<<256>>.`, `"unterminated.`} {
+		_, err := parseTerms(data)
+		assert.Error(t, err, data)
+	}
+}
+
+func TestReadReadme(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(createArchive(map[string][]byte{"lib/readme.md": []byte("nested")}))
+	zw.Close()
+
+	readme, err := readReadme(buf.Bytes())
+	require.NoError(t, err)
+	assert.Empty(t, readme)
+
+	_, err = readReadme([]byte("invalid"))
+	assert.Error(t, err)
+}
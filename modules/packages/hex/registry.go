@@ -0,0 +1,160 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The registry resources are protobuf messages, their schemas are described at
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+
+// NamesPackage is the entry of a package in the names resource
+type NamesPackage struct {
+	Name      string
+	UpdatedAt time.Time
+}
+
+// VersionsPackage is the entry of a package in the versions resource
+type VersionsPackage struct {
+	Name     string
+	Versions []string
+	// Retired has the indexes of the retired versions
+	Retired []int32
+}
+
+// Release is a release of a package in the package resource
+type Release struct {
+	Version       string
+	InnerChecksum []byte
+	OuterChecksum []byte
+	Dependencies  []*Requirement
+	Retirement    *Retirement
+}
+
+// EncodeNames encodes the names resource listing all packages of the repository
+func EncodeNames(repository string, packages []*NamesPackage) []byte {
+	var b []byte
+	for _, p := range packages {
+		var pb []byte
+		pb = appendString(pb, 1, p.Name)
+		if !p.UpdatedAt.IsZero() {
+			var ts []byte
+			ts = protowire.AppendTag(ts, 1, protowire.VarintType)
+			ts = protowire.AppendVarint(ts, uint64(p.UpdatedAt.Unix()))
+			if nanos := p.UpdatedAt.Nanosecond(); nanos != 0 {
+				ts = protowire.AppendTag(ts, 2, protowire.VarintType)
+				ts = protowire.AppendVarint(ts, uint64(nanos))
+			}
+			pb = appendBytes(pb, 2, ts)
+		}
+		b = appendBytes(b, 1, pb)
+	}
+	return appendString(b, 2, repository)
+}
+
+// EncodeVersions encodes the versions resource listing the versions of all packages of the repository
+func EncodeVersions(repository string, packages []*VersionsPackage) []byte {
+	var b []byte
+	for _, p := range packages {
+		var pb []byte
+		pb = appendString(pb, 1, p.Name)
+		for _, v := range p.Versions {
+			pb = appendString(pb, 2, v)
+		}
+		if len(p.Retired) > 0 {
+			var packed []byte
+			for _, idx := range p.Retired {
+				packed = protowire.AppendVarint(packed, uint64(idx))
+			}
+			pb = appendBytes(pb, 3, packed)
+		}
+		b = appendBytes(b, 1, pb)
+	}
+	return appendString(b, 2, repository)
+}
+
+// EncodePackage encodes the package resource listing the releases of a package
+func EncodePackage(repository, name string, releases []*Release) []byte {
+	var b []byte
+	for _, r := range releases {
+		var rb []byte
+		rb = appendString(rb, 1, r.Version)
+		rb = appendBytes(rb, 2, r.InnerChecksum)
+		for _, dep := range r.Dependencies {
+			var db []byte
+			db = appendString(db, 1, dep.Name)
+			db = appendString(db, 2, dep.Requirement)
+			if dep.Optional {
+				db = protowire.AppendTag(db, 3, protowire.VarintType)
+				db = protowire.AppendVarint(db, 1)
+			}
+			if dep.App != "" && dep.App != dep.Name {
+				db = appendString(db, 4, dep.App)
+			}
+			if dep.Repository != "" && dep.Repository != repository {
+				db = appendString(db, 5, dep.Repository)
+			}
+			rb = appendBytes(rb, 3, db)
+		}
+		if r.Retirement != nil {
+			var sb []byte
+			sb = protowire.AppendTag(sb, 1, protowire.VarintType)
+			sb = protowire.AppendVarint(sb, uint64(max(slices.Index(RetirementReasons, r.Retirement.Reason), 0)))
+			if r.Retirement.Message != "" {
+				sb = appendString(sb, 2, r.Retirement.Message)
+			}
+			rb = appendBytes(rb, 4, sb)
+		}
+		if len(r.OuterChecksum) > 0 {
+			rb = appendBytes(rb, 5, r.OuterChecksum)
+		}
+		b = appendBytes(b, 1, rb)
+	}
+	b = appendString(b, 2, name)
+	return appendString(b, 3, repository)
+}
+
+// SignResource signs the encoded resource with the private key of the repository
+// and returns the gzipped signed message which is served to the clients
+func SignResource(payload []byte, key *rsa.PrivateKey) ([]byte, error) {
+	hash := sha512.Sum512(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var signed []byte
+	signed = appendBytes(signed, 1, payload)
+	signed = appendBytes(signed, 2, signature)
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(signed); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
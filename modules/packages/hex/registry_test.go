@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeMessage decodes the fields of a protobuf message, keeping the raw bytes of the length-delimited ones
+func decodeMessage(t *testing.T, b []byte) map[protowire.Number][]any {
+	fields := make(map[protowire.Number][]any)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			require.GreaterOrEqual(t, n, 0)
+			fields[num] = append(fields[num], v)
+			b = b[n:]
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			require.GreaterOrEqual(t, n, 0)
+			fields[num] = append(fields[num], v)
+			b = b[n:]
+		default:
+			require.FailNow(t, "unexpected wire type", typ)
+		}
+	}
+	return fields
+}
+
+func TestEncodeNames(t *testing.T) {
+	names := decodeMessage(t, EncodeNames("user", []*NamesPackage{
+		{Name: "gitea", UpdatedAt: time.Unix(1700000000, 0)},
+	}))
+
+	assert.Equal(t, []any{[]byte("user")}, names[2])
+	require.Len(t, names[1], 1)
+	pkg := decodeMessage(t, names[1][0].([]byte))
+	assert.Equal(t, []any{[]byte("gitea")}, pkg[1])
+	timestamp := decodeMessage(t, pkg[2][0].([]byte))
+	assert.Equal(t, []any{uint64(1700000000)}, timestamp[1])
+}
+
+func TestEncodeVersions(t *testing.T) {
+	versions := decodeMessage(t, EncodeVersions("user", []*VersionsPackage{
+		{Name: "gitea", Versions: []string{"1.0.0", "1.1.0", "2.0.0"}, Retired: []int32{0, 2}},
+	}))
+
+	assert.Equal(t, []any{[]byte("user")}, versions[2])
+	pkg := decodeMessage(t, versions[1][0].([]byte))
+	assert.Equal(t, []any{[]byte("gitea")}, pkg[1])
+	assert.Equal(t, []any{[]byte("1.0.0"), []byte("1.1.0"), []byte("2.0.0")}, pkg[2])
+	assert.Equal(t, []any{[]byte{0, 2}}, pkg[3])
+}
+
+func TestEncodePackage(t *testing.T) {
+	p := decodeMessage(t, EncodePackage("user", "gitea", []*Release{
+		{
+			Version:       "1.0.0",
+			InnerChecksum: []byte{1, 2},
+			OuterChecksum: []byte{3, 4},
+			Dependencies: []*Requirement{
+				{Name: "plug", App: "plug", Requirement: "~> 1.14", Repository: "hexpm"},
+				{Name: "local", App: "local_app", Requirement: "~> 1.0", Optional: true, Repository: "user"},
+			},
+			Retirement: &Retirement{Reason: "security", Message: "CVE"},
+		},
+	}))
+
+	assert.Equal(t, []any{[]byte("gitea")}, p[2])
+	assert.Equal(t, []any{[]byte("user")}, p[3])
+	release := decodeMessage(t, p[1][0].([]byte))
+	assert.Equal(t, []any{[]byte("1.0.0")}, release[1])
+	assert.Equal(t, []any{[]byte{1, 2}}, release[2])
+	assert.Equal(t, []any{[]byte{3, 4}}, release[5])
+
+	require.Len(t, release[3], 2)
+	dep := decodeMessage(t, release[3][0].([]byte))
+	assert.Equal(t, []any{[]byte("plug")}, dep[1])
+	assert.Equal(t, []any{[]byte("~> 1.14")}, dep[2])
+	assert.Nil(t, dep[3])
+	assert.Nil(t, dep[4], "the app is omitted if it matches the package name")
+	assert.Equal(t, []any{[]byte("hexpm")}, dep[5])
+	dep = decodeMessage(t, release[3][1].([]byte))
+	assert.Equal(t, []any{uint64(1)}, dep[3])
+	assert.Equal(t, []any{[]byte("local_app")}, dep[4])
+	assert.Nil(t, dep[5], "the repository is omitted if it is the same repository")
+
+	retirement := decodeMessage(t, release[4][0].([]byte))
+	assert.Equal(t, []any{uint64(2)}, retirement[1])
+	assert.Equal(t, []any{[]byte("CVE")}, retirement[2])
+}
+
+func TestSignResource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	payload := EncodeNames("user", nil)
+	data, err := SignResource(payload, key)
+	require.NoError(t, err)
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	signed, err := io.ReadAll(zr)
+	require.NoError(t, err)
+
+	fields := decodeMessage(t, signed)
+	assert.Equal(t, []any{payload}, fields[1])
+	hash := sha512.Sum512(payload)
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA512, hash[:], fields[2][0].([]byte)))
+}
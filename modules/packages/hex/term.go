@@ -0,0 +1,342 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Atom represents an Erlang atom
+type Atom string
+
+// Tuple represents an Erlang tuple
+type Tuple []any
+
+// parseTerms parses a file of Erlang terms each terminated by a dot, like file:consult/1 does.
+// Binaries and strings are returned as Go strings, lists as []any and maps as lists of key-value tuples.
+func parseTerms(data string) ([]any, error) {
+	p := &termParser{data: data}
+	var terms []any
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return terms, nil
+		}
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(".") {
+			return nil, p.errorf("expected end of term")
+		}
+		terms = append(terms, term)
+	}
+}
+
+type termParser struct {
+	data string
+	pos  int
+}
+
+func (p *termParser) errorf(format string, a ...any) error {
+	return fmt.Errorf("invalid term at offset %d: %s", p.pos, fmt.Sprintf(format, a...))
+}
+
+func (p *termParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+				p.pos++
+			}
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *termParser) consume(s string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.data[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *termParser) parseTerm() (any, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of data")
+	}
+
+	switch c := p.data[p.pos]; {
+	case strings.HasPrefix(p.data[p.pos:], "<<"):
+		p.pos += 2
+		return p.parseBinary()
+	case strings.HasPrefix(p.data[p.pos:], "#{"):
+		p.pos += 2
+		return p.parseMap()
+	case c == '[':
+		p.pos++
+		return p.parseSequence("]")
+	case c == '{':
+		p.pos++
+		elems, err := p.parseSequence("}")
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(elems), nil
+	case c == '"':
+		return p.parseQuoted('"')
+	case c == '\'':
+		s, err := p.parseQuoted('\'')
+		if err != nil {
+			return nil, err
+		}
+		return Atom(s), nil
+	case c == '-' || c >= '0' && c <= '9':
+		return p.parseNumber()
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for p.pos < len(p.data) && isAtomChar(p.data[p.pos]) {
+			p.pos++
+		}
+		return Atom(p.data[start:p.pos]), nil
+	}
+	return nil, p.errorf("unexpected character %q", p.data[p.pos])
+}
+
+func isAtomChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '@'
+}
+
+func (p *termParser) parseSequence(end string) ([]any, error) {
+	elems := []any{}
+	if p.consume(end) {
+		return elems, nil
+	}
+	for {
+		elem, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+		if p.consume(end) {
+			return elems, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected %q or \",\"", end)
+		}
+	}
+}
+
+func (p *termParser) parseMap() (any, error) {
+	pairs := []any{}
+	if p.consume("}") {
+		return pairs, nil
+	}
+	for {
+		key, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume("=>") {
+			return nil, p.errorf("expected \"=>\"")
+		}
+		value, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, Tuple{key, value})
+		if p.consume("}") {
+			return pairs, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected \"}\" or \",\"")
+		}
+	}
+}
+
+// parseBinary parses the content of a binary, either a string like <<"text"/utf8>> or a list of bytes like <<116,101>>
+func (p *termParser) parseBinary() (any, error) {
+	var sb strings.Builder
+	if p.consume(">>") {
+		return "", nil
+	}
+	for {
+		p.skipSpace()
+		if p.pos < len(p.data) && p.data[p.pos] == '"' {
+			s, err := p.parseQuoted('"')
+			if err != nil {
+				return nil, err
+			}
+			sb.WriteString(s)
+			p.consume("/utf8")
+		} else {
+			n, err := p.parseNumber()
+			if err != nil {
+				return nil, err
+			}
+			b, ok := n.(int64)
+			if !ok || b < 0 || b > 255 {
+				return nil, p.errorf("invalid byte %v", n)
+			}
+			sb.WriteByte(byte(b))
+		}
+		if p.consume(">>") {
+			return sb.String(), nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected \">>\" or \",\"")
+		}
+	}
+}
+
+func (p *termParser) parseQuoted(quote byte) (string, error) {
+	p.pos++ // opening quote
+	var sb strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case quote:
+			return sb.String(), nil
+		case '\\':
+			if p.pos >= len(p.data) {
+				return "", p.errorf("unterminated escape sequence")
+			}
+			e := p.data[p.pos]
+			p.pos++
+			switch e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case 's':
+				sb.WriteByte(' ')
+			case 'e':
+				sb.WriteByte(0x1b)
+			case 'x':
+				var digits string
+				if p.consume("{") {
+					end := strings.IndexByte(p.data[p.pos:], '}')
+					if end < 0 {
+						return "", p.errorf("unterminated escape sequence")
+					}
+					digits = p.data[p.pos : p.pos+end]
+					p.pos += end + 1
+				} else if p.pos+2 <= len(p.data) {
+					digits = p.data[p.pos : p.pos+2]
+					p.pos += 2
+				}
+				r, err := strconv.ParseUint(digits, 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid escape sequence")
+				}
+				sb.WriteRune(rune(r))
+			default:
+				if e >= '0' && e <= '7' {
+					end := p.pos
+					for end < len(p.data) && end < p.pos+2 && p.data[end] >= '0' && p.data[end] <= '7' {
+						end++
+					}
+					r, _ := strconv.ParseUint(p.data[p.pos-1:end], 8, 32)
+					p.pos = end
+					sb.WriteRune(rune(r))
+				} else {
+					sb.WriteByte(e)
+				}
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *termParser) parseNumber() (any, error) {
+	p.skipSpace()
+	start := p.pos
+	if p.pos < len(p.data) && p.data[p.pos] == '-' {
+		p.pos++
+	}
+	isFloat := false
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if c >= '0' && c <= '9' {
+			p.pos++
+		} else if (c == '.' || c == 'e' || c == 'E') && p.pos+1 < len(p.data) && (p.data[p.pos+1] >= '0' && p.data[p.pos+1] <= '9' || p.data[p.pos+1] == '-') {
+			// a dot followed by whitespace terminates the term
+			isFloat = true
+			p.pos++
+			if p.data[p.pos] == '-' {
+				p.pos++
+			}
+		} else {
+			break
+		}
+	}
+	s := p.data[start:p.pos]
+	if isFloat {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", s)
+		}
+		return f, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, p.errorf("invalid number %q", s)
+	}
+	return n, nil
+}
+
+// proplist returns the values of a list of key-value tuples keyed by their string or atom keys
+func proplist(term any) map[string]any {
+	list, ok := term.([]any)
+	if !ok {
+		return nil
+	}
+	m := make(map[string]any, len(list))
+	for _, elem := range list {
+		if t, ok := elem.(Tuple); ok && len(t) == 2 {
+			if key, ok := termString(t[0]); ok {
+				m[key] = t[1]
+			}
+		}
+	}
+	return m
+}
+
+// termString returns the string of a binary, a string or an atom
+func termString(term any) (string, bool) {
+	switch v := term.(type) {
+	case string:
+		return v, utf8.ValidString(v)
+	case Atom:
+		return string(v), true
+	}
+	return "", false
+}
+
+// termStrings returns the strings of a list
+func termStrings(term any) []string {
+	list, _ := term.([]any)
+	ret := make([]string, 0, len(list))
+	for _, elem := range list {
+		if s, ok := termString(elem); ok {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// base32Alphabet is the alphabet of the base32 encoding used by Nix, which omits e, o, u and t
+const base32Alphabet = "0123456789abcdfghijklmnpqrsvwxyz"
+
+var ErrInvalidHash = errors.New("invalid hash")
+
+// EncodeBase32 encodes the data with the base32 encoding used by Nix, which starts with the last byte
+func EncodeBase32(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	length := (len(data)*8-1)/5 + 1
+	var sb strings.Builder
+	sb.Grow(length)
+	for n := length - 1; n >= 0; n-- {
+		b := n * 5
+		i, j := b/8, b%8
+		c := data[i] >> j
+		if i+1 < len(data) {
+			c |= data[i+1] << (8 - j)
+		}
+		sb.WriteByte(base32Alphabet[c&0x1f])
+	}
+	return sb.String()
+}
+
+// DecodeBase32 decodes a string in the base32 encoding used by Nix into size bytes
+func DecodeBase32(s string, size int) ([]byte, error) {
+	if len(s) != (size*8-1)/5+1 {
+		return nil, ErrInvalidHash
+	}
+
+	data := make([]byte, size)
+	for n := range len(s) {
+		digit := strings.IndexByte(base32Alphabet, s[len(s)-n-1])
+		if digit < 0 {
+			return nil, ErrInvalidHash
+		}
+		b := n * 5
+		i, j := b/8, b%8
+		data[i] |= byte(digit << j)
+		if i+1 < size {
+			data[i+1] |= byte(digit >> (8 - j))
+		} else if digit>>(8-j) != 0 {
+			return nil, ErrInvalidHash
+		}
+	}
+	return data, nil
+}
+
+// ParseSHA256 parses a SHA256 hash in one of the formats accepted by Nix:
+// "sha256:" followed by the base16, Nix base32 or base64 encoding, or the SRI format "sha256-<base64>"
+func ParseSHA256(s string) ([]byte, error) {
+	if encoded, ok := strings.CutPrefix(s, "sha256-"); ok {
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(data) != sha256.Size {
+			return nil, ErrInvalidHash
+		}
+		return data, nil
+	}
+
+	encoded, ok := strings.CutPrefix(s, "sha256:")
+	if !ok {
+		return nil, ErrInvalidHash
+	}
+	switch len(encoded) {
+	case hex.EncodedLen(sha256.Size):
+		data, err := hex.DecodeString(encoded)
+		if err != nil {
+			return nil, ErrInvalidHash
+		}
+		return data, nil
+	case base64.StdEncoding.EncodedLen(sha256.Size):
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, ErrInvalidHash
+		}
+		return data, nil
+	}
+	return DecodeBase32(encoded, sha256.Size)
+}
+
+// FormatSHA256 formats a SHA256 hash like Nix does in narinfo files
+func FormatSHA256(data []byte) string {
+	return "sha256:" + EncodeBase32(data)
+}
@@ -0,0 +1,285 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gitea.dev/modules/util"
+)
+
+const (
+	PropertyReference = "nix.reference"
+
+	StoreDir = "/nix/store"
+
+	// NarInfoContentType is the content type of narinfo files
+	NarInfoContentType = "text/x-nix-narinfo"
+
+	maxNarInfoSize = 1024 * 1024
+)
+
+var (
+	ErrInvalidStorePath = util.NewInvalidArgumentErrorf("store path is invalid")
+	ErrInvalidNarInfo   = util.NewInvalidArgumentErrorf("narinfo is invalid")
+	ErrInvalidNarURL    = util.NewInvalidArgumentErrorf("nar url is invalid")
+	ErrMissingSignature = util.NewInvalidArgumentErrorf("narinfo is not signed by a trusted key")
+	ErrInvalidSignature = util.NewInvalidArgumentErrorf("narinfo signature is invalid")
+)
+
+var (
+	// https://nix.dev/manual/nix/latest/store/store-path
+	storePathBasePattern = regexp.MustCompile(`\A([0-9a-df-np-sv-z]{32})-([A-Za-z0-9+\-_?=][A-Za-z0-9+\-._?=]*)\z`)
+	storeHashPattern     = regexp.MustCompile(`\A[0-9a-df-np-sv-z]{32}\z`)
+	// the file name of a compressed NAR is the Nix base32 SHA256 hash of the file
+	narFilenamePattern = regexp.MustCompile(`\A([0-9a-df-np-sv-z]{52})\.nar(\.[a-z0-9]+)?\z`)
+)
+
+// CacheInfo returns the content of the nix-cache-info file
+func CacheInfo(priority int) string {
+	return fmt.Sprintf("StoreDir: %s\nWantMassQuery: 1\nPriority: %d\n", StoreDir, priority)
+}
+
+// IsValidStoreHash returns whether the hash is the hash part of a store path
+func IsValidStoreHash(hash string) bool {
+	return storeHashPattern.MatchString(hash)
+}
+
+// SplitStorePath returns the hash and the name of the base name of a store path
+func SplitStorePath(base string) (hash, name string, err error) {
+	m := storePathBasePattern.FindStringSubmatch(base)
+	if m == nil || len(m[2]) > 211 {
+		return "", "", ErrInvalidStorePath
+	}
+	return m[1], m[2], nil
+}
+
+// ParseNarFilename returns the SHA256 hash of a compressed NAR file from its file name
+func ParseNarFilename(filename string) ([]byte, error) {
+	m := narFilenamePattern.FindStringSubmatch(filename)
+	if m == nil {
+		return nil, ErrInvalidNarURL
+	}
+	hash, err := DecodeBase32(m[1], sha256.Size)
+	if err != nil {
+		return nil, ErrInvalidNarURL
+	}
+	return hash, nil
+}
+
+// Metadata represents the narinfo of a store path
+type Metadata struct {
+	StorePath   string   `json:"store_path"`
+	URL         string   `json:"url"`
+	Compression string   `json:"compression,omitempty"`
+	FileHash    string   `json:"file_hash"`
+	FileSize    int64    `json:"file_size"`
+	NarHash     string   `json:"nar_hash"`
+	NarSize     int64    `json:"nar_size"`
+	References  []string `json:"references,omitempty"`
+	Deriver     string   `json:"deriver,omitempty"`
+	System      string   `json:"system,omitempty"`
+	Signatures  []string `json:"signatures,omitempty"`
+	CA          string   `json:"ca,omitempty"`
+}
+
+// ParseNarInfo parses a narinfo file
+func ParseNarInfo(r io.Reader) (*Metadata, error) {
+	m := &Metadata{}
+
+	s := bufio.NewScanner(io.LimitReader(r, maxNarInfoSize))
+	for s.Scan() {
+		line := s.Text()
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, util.NewInvalidArgumentErrorf("invalid narinfo line %q", line)
+		}
+
+		var err error
+		switch key {
+		case "StorePath":
+			m.StorePath = value
+		case "URL":
+			m.URL = value
+		case "Compression":
+			m.Compression = value
+		case "FileHash":
+			m.FileHash, err = normalizeSHA256(value)
+		case "FileSize":
+			m.FileSize, err = strconv.ParseInt(value, 10, 64)
+		case "NarHash":
+			m.NarHash, err = normalizeSHA256(value)
+		case "NarSize":
+			m.NarSize, err = strconv.ParseInt(value, 10, 64)
+		case "References":
+			m.References = strings.Fields(value)
+		case "Deriver":
+			if value != "unknown-deriver" {
+				m.Deriver = value
+			}
+		case "System":
+			m.System = value
+		case "Sig":
+			m.Signatures = append(m.Signatures, value)
+		case "CA":
+			m.CA = value
+		}
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid narinfo value of %s: %v", key, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if dir, base := path.Split(m.StorePath); dir != StoreDir+"/" {
+		return nil, ErrInvalidStorePath
+	} else if _, _, err := SplitStorePath(base); err != nil {
+		return nil, err
+	}
+	for _, ref := range m.References {
+		if _, _, err := SplitStorePath(ref); err != nil {
+			return nil, err
+		}
+	}
+	if m.Deriver != "" {
+		if _, _, err := SplitStorePath(m.Deriver); err != nil {
+			return nil, err
+		}
+	}
+	if m.NarHash == "" || m.NarSize <= 0 || m.FileHash == "" || m.FileSize <= 0 {
+		return nil, ErrInvalidNarInfo
+	}
+	if dir, filename := path.Split(m.URL); dir != "nar/" {
+		return nil, ErrInvalidNarURL
+	} else if hash, err := ParseNarFilename(filename); err != nil {
+		return nil, err
+	} else if FormatSHA256(hash) != m.FileHash {
+		return nil, ErrInvalidNarURL
+	}
+	if m.Compression == "" {
+		m.Compression = "bzip2"
+	}
+
+	return m, nil
+}
+
+func normalizeSHA256(s string) (string, error) {
+	hash, err := ParseSHA256(s)
+	if err != nil {
+		return "", err
+	}
+	return FormatSHA256(hash), nil
+}
+
+// StoreHash returns the hash part of the store path
+func (m *Metadata) StoreHash() string {
+	hash, _, _ := SplitStorePath(path.Base(m.StorePath))
+	return hash
+}
+
+// StoreName returns the name part of the store path
+func (m *Metadata) StoreName() string {
+	_, name, _ := SplitStorePath(path.Base(m.StorePath))
+	return name
+}
+
+// NarFilename returns the file name of the compressed NAR
+func (m *Metadata) NarFilename() string {
+	return path.Base(m.URL)
+}
+
+// Fingerprint returns the data covered by the signatures of the narinfo
+func (m *Metadata) Fingerprint() string {
+	refs := make([]string, 0, len(m.References))
+	for _, ref := range m.References {
+		refs = append(refs, StoreDir+"/"+ref)
+	}
+	return fmt.Sprintf("1;%s;%s;%d;%s", m.StorePath, m.NarHash, m.NarSize, strings.Join(refs, ","))
+}
+
+// String returns the content of the narinfo file
+func (m *Metadata) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "StorePath: %s\n", m.StorePath)
+	fmt.Fprintf(&sb, "URL: %s\n", m.URL)
+	fmt.Fprintf(&sb, "Compression: %s\n", m.Compression)
+	fmt.Fprintf(&sb, "FileHash: %s\n", m.FileHash)
+	fmt.Fprintf(&sb, "FileSize: %d\n", m.FileSize)
+	fmt.Fprintf(&sb, "NarHash: %s\n", m.NarHash)
+	fmt.Fprintf(&sb, "NarSize: %d\n", m.NarSize)
+	fmt.Fprintf(&sb, "References: %s\n", strings.Join(m.References, " "))
+	if m.Deriver != "" {
+		fmt.Fprintf(&sb, "Deriver: %s\n", m.Deriver)
+	}
+	if m.System != "" {
+		fmt.Fprintf(&sb, "System: %s\n", m.System)
+	}
+	for _, sig := range m.Signatures {
+		fmt.Fprintf(&sb, "Sig: %s\n", sig)
+	}
+	if m.CA != "" {
+		fmt.Fprintf(&sb, "CA: %s\n", m.CA)
+	}
+	return sb.String()
+}
+
+// PublicKey is a named key which verifies narinfo signatures
+type PublicKey struct {
+	Name string
+	Key  ed25519.PublicKey
+}
+
+// ParsePublicKey parses a public key in the "<name>:<base64 key>" format of the trusted-public-keys Nix setting
+func ParsePublicKey(s string) (*PublicKey, error) {
+	name, encoded, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid public key %q", s)
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key %q", s)
+	}
+	return &PublicKey{Name: name, Key: key}, nil
+}
+
+// VerifySignatures checks that the narinfo is signed by one of the keys.
+// Signatures of other keys are ignored, but an invalid signature of one of the keys is an error.
+func (m *Metadata) VerifySignatures(keys []*PublicKey) error {
+	fingerprint := []byte(m.Fingerprint())
+
+	verified := false
+	for _, sig := range m.Signatures {
+		name, encoded, ok := strings.Cut(sig, ":")
+		if !ok {
+			return ErrInvalidSignature
+		}
+		for _, key := range keys {
+			if key.Name != name {
+				continue
+			}
+			signature, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil || !ed25519.Verify(key.Key, fingerprint, signature) {
+				return ErrInvalidSignature
+			}
+			verified = true
+		}
+	}
+	if !verified {
+		return ErrMissingSignature
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	storeHash = "7rjj86a15146cq1d2qy068lq1bjd2nad"
+	storeName = "hello-2.12.1"
+	// the empty file
+	fileHash = "0mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c73"
+)
+
+const narInfoContent = `StorePath: /nix/store/` + storeHash + `-` + storeName + `
+URL: nar/` + fileHash + `.nar.xz
+Compression: xz
+FileHash: sha256:` + fileHash + `
+FileSize: 50264
+NarHash: sha256:1f1ymibnxsa4wj4f3anlh0k58gmm58rs4r1g1mq3mz6wcx9vnj8a
+NarSize: 226560
+References: ` + storeHash + `-` + storeName + ` 8vsmbvj6rf3xvj0b2ahx8cpsfq8dbrsd-glibc-2.40-66
+Deriver: 5pv1w5rbnhaw2qj2h2mr2cjzgp2p7mq7-` + storeName + `.drv
+Sig: cache.nixos.org-1:invalid
+`
+
+func TestBase32(t *testing.T) {
+	empty := sha256.Sum256(nil)
+	assert.Equal(t, fileHash, EncodeBase32(empty[:]))
+
+	data, err := DecodeBase32(fileHash, sha256.Size)
+	require.NoError(t, err)
+	assert.Equal(t, empty[:], data)
+
+	for _, s := range []string{"", fileHash[1:], strings.Replace(fileHash, "0", "e", 1), "z" + fileHash[1:]} {
+		_, err := DecodeBase32(s, sha256.Size)
+		assert.ErrorIs(t, err, ErrInvalidHash, s)
+	}
+}
+
+func TestParseSHA256(t *testing.T) {
+	empty := sha256.Sum256(nil)
+	for _, s := range []string{
+		"sha256:" + fileHash,
+		"sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"sha256:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+		"sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+	} {
+		data, err := ParseSHA256(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, empty[:], data, s)
+	}
+
+	for _, s := range []string{"", "md5:d41d8cd98f00b204e9800998ecf8427e", "sha256:invalid"} {
+		_, err := ParseSHA256(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseNarInfo(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		m, err := ParseNarInfo(strings.NewReader(narInfoContent))
+		require.NoError(t, err)
+		assert.Equal(t, storeHash, m.StoreHash())
+		assert.Equal(t, storeName, m.StoreName())
+		assert.Equal(t, fileHash+".nar.xz", m.NarFilename())
+		assert.Equal(t, "xz", m.Compression)
+		assert.EqualValues(t, 50264, m.FileSize)
+		assert.EqualValues(t, 226560, m.NarSize)
+		assert.Len(t, m.References, 2)
+		assert.Equal(t, []string{"cache.nixos.org-1:invalid"}, m.Signatures)
+		assert.Equal(t, narInfoContent, m.String())
+	})
+
+	t.Run("Normalize", func(t *testing.T) {
+		content := strings.Replace(narInfoContent, "FileHash: sha256:"+fileHash, "FileHash: sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", 1)
+		content = strings.Replace(content, "Compression: xz\n", "", 1)
+
+		m, err := ParseNarInfo(strings.NewReader(content))
+		require.NoError(t, err)
+		assert.Equal(t, "sha256:"+fileHash, m.FileHash)
+		assert.Equal(t, "bzip2", m.Compression)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		otherHash := sha256.Sum256([]byte("other"))
+		cases := map[string]error{
+			strings.Replace(narInfoContent, "/nix/store/", "/gnu/store/", 1):                                                 ErrInvalidStorePath,
+			strings.Replace(narInfoContent, storeHash+"-"+storeName+"\n", storeHash+"\n", 1):                                 ErrInvalidStorePath,
+			strings.Replace(narInfoContent, "URL: nar/", "URL: ", 1):                                                         ErrInvalidNarURL,
+			strings.Replace(narInfoContent, "FileHash: sha256:"+fileHash, "FileHash: sha256:"+EncodeBase32(otherHash[:]), 1): ErrInvalidNarURL,
+			strings.Replace(narInfoContent, "NarSize: 226560\n", "", 1):                                                      ErrInvalidNarInfo,
+		}
+		for content, expected := range cases {
+			m, err := ParseNarInfo(strings.NewReader(content))
+			assert.Nil(t, m)
+			assert.ErrorIs(t, err, expected)
+		}
+
+		_, err := ParseNarInfo(strings.NewReader("invalid"))
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifySignatures(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := ParsePublicKey("gitea-1:" + base64.StdEncoding.EncodeToString(pub))
+	require.NoError(t, err)
+	assert.Equal(t, "gitea-1", key.Name)
+
+	_, err = ParsePublicKey("gitea-1:invalid")
+	assert.Error(t, err)
+
+	m, err := ParseNarInfo(strings.NewReader(narInfoContent))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, m.VerifySignatures([]*PublicKey{key}), ErrMissingSignature)
+
+	m.Signatures = append(m.Signatures, "gitea-1:"+base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(m.Fingerprint()))))
+	assert.NoError(t, m.VerifySignatures([]*PublicKey{key}))
+	assert.True(t, strings.HasPrefix(m.Fingerprint(), "1;/nix/store/"+storeHash+"-"+storeName+";sha256:"))
+	assert.Contains(t, m.Fingerprint(), ";226560;/nix/store/"+storeHash+"-"+storeName+",/nix/store/8vsmbvj6rf3xvj0b2ahx8cpsfq8dbrsd-glibc-2.40-66")
+
+	m.NarSize++
+	assert.ErrorIs(t, m.VerifySignatures([]*PublicKey{key}), ErrInvalidSignature)
+}
@@ -0,0 +1,361 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package scip decodes SCIP code intelligence indexes (https://github.com/sourcegraph/scip).
+//
+// An index is a single protobuf message whose documents are repeated top-level fields,
+// so it is decoded one document at a time: indexes of large repositories don't need
+// to fit in memory, only their largest document does.
+package scip
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// PositionEncoding tells how the character offsets of a document are counted
+type PositionEncoding int32
+
+const (
+	PositionEncodingUnspecified PositionEncoding = 0
+	PositionEncodingUTF8        PositionEncoding = 1
+	PositionEncodingUTF16       PositionEncoding = 2
+	PositionEncodingUTF32       PositionEncoding = 3
+)
+
+// SymbolRole is a bitset of the roles a symbol occurrence plays
+type SymbolRole int32
+
+const (
+	SymbolRoleDefinition  SymbolRole = 0x1
+	SymbolRoleImport      SymbolRole = 0x2
+	SymbolRoleWriteAccess SymbolRole = 0x4
+	SymbolRoleReadAccess  SymbolRole = 0x8
+)
+
+// Metadata describes the tool which produced an index
+type Metadata struct {
+	ToolName    string
+	ToolVersion string
+	ProjectRoot string
+}
+
+// Range is a zero-based, end-exclusive range in a document
+type Range struct {
+	StartLine, StartCharacter int32
+	EndLine, EndCharacter     int32
+}
+
+// Contains reports whether the position is inside the range
+func (r Range) Contains(line, character int32) bool {
+	if line < r.StartLine || line > r.EndLine {
+		return false
+	}
+	if line == r.StartLine && character < r.StartCharacter {
+		return false
+	}
+	return line != r.EndLine || character < r.EndCharacter
+}
+
+// Occurrence is a reference to or the definition of a symbol in a document
+type Occurrence struct {
+	Range  Range
+	Symbol string
+	Roles  SymbolRole
+}
+
+// IsDefinition reports whether the occurrence defines its symbol
+func (o *Occurrence) IsDefinition() bool {
+	return o.Roles&SymbolRoleDefinition != 0
+}
+
+// SymbolInformation holds the documentation of a symbol
+type SymbolInformation struct {
+	Symbol        string
+	DisplayName   string
+	Documentation []string // markdown
+}
+
+// Document holds the occurrences and symbols of a single source file
+type Document struct {
+	RelativePath     string
+	Language         string
+	PositionEncoding PositionEncoding
+	Occurrences      []*Occurrence
+	Symbols          []*SymbolInformation
+}
+
+// Handler receives the decoded parts of an index, in the order they appear in it
+type Handler interface {
+	Metadata(*Metadata) error
+	Document(*Document) error
+	ExternalSymbol(*SymbolInformation) error
+}
+
+// ErrMessageTooLarge is returned when a single message of an index is larger than the decoder accepts
+var ErrMessageTooLarge = errors.New("scip: message too large")
+
+// IsLocalSymbol reports whether a symbol is local to the document it occurs in
+func IsLocalSymbol(symbol string) bool {
+	return strings.HasPrefix(symbol, "local ")
+}
+
+// Decode reads an index from r and passes its parts to h. A single metadata, document
+// or external symbol message may not be larger than maxMessageSize bytes.
+func Decode(r io.Reader, maxMessageSize int, h Handler) error {
+	br := bufio.NewReader(r)
+	for {
+		tag, err := readVarint(br)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		num, typ := protowire.DecodeTag(tag)
+		if typ != protowire.BytesType {
+			if err := skipStreamField(br, typ); err != nil {
+				return err
+			}
+			continue
+		}
+
+		size, err := readVarint(br)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if size > uint64(maxMessageSize) {
+			return ErrMessageTooLarge
+		}
+		if num < 1 || num > 3 {
+			if _, err := br.Discard(int(size)); err != nil {
+				return unexpectedEOF(err)
+			}
+			continue
+		}
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return unexpectedEOF(err)
+		}
+
+		if err := decodeMessage(num, buf, h); err != nil {
+			return err
+		}
+	}
+}
+
+func decodeMessage(num protowire.Number, buf []byte, h Handler) error {
+	switch num {
+	case 1:
+		m, err := parseMetadata(buf)
+		if err != nil {
+			return err
+		}
+		return h.Metadata(m)
+	case 2:
+		d, err := parseDocument(buf)
+		if err != nil {
+			return err
+		}
+		return h.Document(d)
+	case 3:
+		s, err := parseSymbolInformation(buf)
+		if err != nil {
+			return err
+		}
+		return h.ExternalSymbol(s)
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func readVarint(br *bufio.Reader) (uint64, error) {
+	var v uint64
+	for i := 0; ; i++ {
+		b, err := br.ReadByte()
+		if err != nil {
+			if i > 0 {
+				return 0, unexpectedEOF(err)
+			}
+			return 0, err
+		}
+		if i == 9 && b > 1 {
+			return 0, errors.New("scip: varint overflow")
+		}
+		v |= uint64(b&0x7f) << (7 * i)
+		if b < 0x80 {
+			return v, nil
+		}
+	}
+}
+
+func skipStreamField(br *bufio.Reader, typ protowire.Type) error {
+	var err error
+	switch typ {
+	case protowire.VarintType:
+		_, err = readVarint(br)
+	case protowire.Fixed32Type:
+		_, err = br.Discard(4)
+	case protowire.Fixed64Type:
+		_, err = br.Discard(8)
+	default:
+		return fmt.Errorf("scip: unsupported top-level wire type %d", typ)
+	}
+	return unexpectedEOF(err)
+}
+
+// fields calls fn for every varint and length-delimited field of a message,
+// other wire types aren't used by the parts of SCIP this package decodes.
+func fields(b []byte, fn func(num protowire.Number, typ protowire.Type, varint uint64, bytes []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("scip: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		var varint uint64
+		var bytes []byte
+		switch typ {
+		case protowire.VarintType:
+			varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return fmt.Errorf("scip: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		if typ == protowire.VarintType || typ == protowire.BytesType {
+			if err := fn(num, typ, varint, bytes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseMetadata(b []byte) (*Metadata, error) {
+	m := &Metadata{}
+	return m, fields(b, func(num protowire.Number, typ protowire.Type, _ uint64, bytes []byte) error {
+		switch {
+		case num == 2 && typ == protowire.BytesType: // tool_info
+			return fields(bytes, func(num protowire.Number, typ protowire.Type, _ uint64, bytes []byte) error {
+				if typ == protowire.BytesType {
+					switch num {
+					case 1:
+						m.ToolName = string(bytes)
+					case 2:
+						m.ToolVersion = string(bytes)
+					}
+				}
+				return nil
+			})
+		case num == 3 && typ == protowire.BytesType:
+			m.ProjectRoot = string(bytes)
+		}
+		return nil
+	})
+}
+
+func parseDocument(b []byte) (*Document, error) {
+	d := &Document{}
+	return d, fields(b, func(num protowire.Number, typ protowire.Type, varint uint64, bytes []byte) error {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			d.RelativePath = string(bytes)
+		case num == 2 && typ == protowire.BytesType:
+			o, err := parseOccurrence(bytes)
+			if err != nil {
+				return err
+			}
+			if o != nil {
+				d.Occurrences = append(d.Occurrences, o)
+			}
+		case num == 3 && typ == protowire.BytesType:
+			s, err := parseSymbolInformation(bytes)
+			if err != nil {
+				return err
+			}
+			d.Symbols = append(d.Symbols, s)
+		case num == 4 && typ == protowire.BytesType:
+			d.Language = string(bytes)
+		case num == 6 && typ == protowire.VarintType:
+			d.PositionEncoding = PositionEncoding(varint)
+		}
+		return nil
+	})
+}
+
+// parseOccurrence returns nil for occurrences without a symbol or with a malformed range
+func parseOccurrence(b []byte) (*Occurrence, error) {
+	o := &Occurrence{}
+	var rng []int32
+	err := fields(b, func(num protowire.Number, typ protowire.Type, varint uint64, bytes []byte) error {
+		switch {
+		case num == 1 && typ == protowire.VarintType: // unpacked range element
+			rng = append(rng, int32(varint))
+		case num == 1 && typ == protowire.BytesType: // packed range
+			for len(bytes) > 0 {
+				v, n := protowire.ConsumeVarint(bytes)
+				if n < 0 {
+					return fmt.Errorf("scip: %w", protowire.ParseError(n))
+				}
+				rng = append(rng, int32(v))
+				bytes = bytes[n:]
+			}
+		case num == 2 && typ == protowire.BytesType:
+			o.Symbol = string(bytes)
+		case num == 3 && typ == protowire.VarintType:
+			o.Roles = SymbolRole(varint)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(rng) {
+	case 3: // [startLine, startCharacter, endCharacter]
+		o.Range = Range{StartLine: rng[0], StartCharacter: rng[1], EndLine: rng[0], EndCharacter: rng[2]}
+	case 4:
+		o.Range = Range{StartLine: rng[0], StartCharacter: rng[1], EndLine: rng[2], EndCharacter: rng[3]}
+	default:
+		return nil, nil
+	}
+	if o.Symbol == "" {
+		return nil, nil
+	}
+	return o, nil
+}
+
+func parseSymbolInformation(b []byte) (*SymbolInformation, error) {
+	s := &SymbolInformation{}
+	return s, fields(b, func(num protowire.Number, typ protowire.Type, _ uint64, bytes []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			s.Symbol = string(bytes)
+		case 3:
+			s.Documentation = append(s.Documentation, string(bytes))
+		case 6:
+			s.DisplayName = string(bytes)
+		}
+		return nil
+	})
+}
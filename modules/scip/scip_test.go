@@ -0,0 +1,145 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scip
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type collector struct {
+	metadata  *Metadata
+	documents []*Document
+	external  []*SymbolInformation
+}
+
+func (c *collector) Metadata(m *Metadata) error {
+	c.metadata = m
+	return nil
+}
+
+func (c *collector) Document(d *Document) error {
+	c.documents = append(c.documents, d)
+	return nil
+}
+
+func (c *collector) ExternalSymbol(s *SymbolInformation) error {
+	c.external = append(c.external, s)
+	return nil
+}
+
+func appendBytesField(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendStringField(b []byte, num protowire.Number, v string) []byte {
+	return appendBytesField(b, num, []byte(v))
+}
+
+func appendVarintField(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func encodeOccurrence(symbol string, roles SymbolRole, rng ...int32) []byte {
+	var packed []byte
+	for _, v := range rng {
+		packed = protowire.AppendVarint(packed, uint64(v))
+	}
+	b := appendBytesField(nil, 1, packed)
+	b = appendStringField(b, 2, symbol)
+	if roles != 0 {
+		b = appendVarintField(b, 3, uint64(roles))
+	}
+	return b
+}
+
+func encodeSymbol(symbol, displayName string, docs ...string) []byte {
+	b := appendStringField(nil, 1, symbol)
+	for _, doc := range docs {
+		b = appendStringField(b, 3, doc)
+	}
+	return appendStringField(b, 6, displayName)
+}
+
+func testIndex() []byte {
+	toolInfo := appendStringField(appendStringField(nil, 1, "scip-go"), 2, "v0.1.0")
+	metadata := appendBytesField(appendVarintField(nil, 1, 0), 2, toolInfo)
+	metadata = appendStringField(metadata, 3, "file:///src/project")
+
+	doc := appendStringField(nil, 1, "main.go")
+	doc = appendBytesField(doc, 2, encodeOccurrence("scip-go gomod example v1 `example`/Hello().", SymbolRoleDefinition, 2, 5, 10))
+	doc = appendBytesField(doc, 2, encodeOccurrence("local 0", 0, 4, 1, 5, 3))
+	doc = appendBytesField(doc, 2, encodeOccurrence("", 0, 6, 1, 3))     // no symbol
+	doc = appendBytesField(doc, 2, encodeOccurrence("local 1", 0, 6, 1)) // malformed range
+	doc = appendBytesField(doc, 3, encodeSymbol("scip-go gomod example v1 `example`/Hello().", "Hello", "```go\nfunc Hello()\n```", "Hello says hello."))
+	doc = appendStringField(doc, 4, "go")
+	doc = appendVarintField(doc, 6, uint64(PositionEncodingUTF8))
+	doc = appendStringField(doc, 99, "unknown fields are skipped")
+
+	var index []byte
+	index = appendBytesField(index, 1, metadata)
+	index = appendBytesField(index, 2, doc)
+	index = appendBytesField(index, 3, encodeSymbol("scip-go gomod fmt v1 `fmt`/Println().", "Println", "Println formats using the default formats."))
+	return index
+}
+
+func TestDecode(t *testing.T) {
+	c := &collector{}
+	require.NoError(t, Decode(bytes.NewReader(testIndex()), 1024, c))
+
+	assert.Equal(t, &Metadata{ToolName: "scip-go", ToolVersion: "v0.1.0", ProjectRoot: "file:///src/project"}, c.metadata)
+
+	require.Len(t, c.documents, 1)
+	doc := c.documents[0]
+	assert.Equal(t, "main.go", doc.RelativePath)
+	assert.Equal(t, "go", doc.Language)
+	assert.Equal(t, PositionEncodingUTF8, doc.PositionEncoding)
+	assert.Equal(t, []*Occurrence{
+		{Range: Range{StartLine: 2, StartCharacter: 5, EndLine: 2, EndCharacter: 10}, Symbol: "scip-go gomod example v1 `example`/Hello().", Roles: SymbolRoleDefinition},
+		{Range: Range{StartLine: 4, StartCharacter: 1, EndLine: 5, EndCharacter: 3}, Symbol: "local 0"},
+	}, doc.Occurrences)
+	assert.True(t, doc.Occurrences[0].IsDefinition())
+	assert.False(t, doc.Occurrences[1].IsDefinition())
+	require.Len(t, doc.Symbols, 1)
+	assert.Equal(t, "Hello", doc.Symbols[0].DisplayName)
+	assert.Equal(t, []string{"```go\nfunc Hello()\n```", "Hello says hello."}, doc.Symbols[0].Documentation)
+
+	require.Len(t, c.external, 1)
+	assert.Equal(t, "Println", c.external[0].DisplayName)
+
+	t.Run("MessageTooLarge", func(t *testing.T) {
+		assert.ErrorIs(t, Decode(bytes.NewReader(testIndex()), 16, &collector{}), ErrMessageTooLarge)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		index := testIndex()
+		assert.ErrorIs(t, Decode(bytes.NewReader(index[:len(index)-3]), 1024, &collector{}), io.ErrUnexpectedEOF)
+	})
+}
+
+func TestRangeContains(t *testing.T) {
+	single := Range{StartLine: 2, StartCharacter: 5, EndLine: 2, EndCharacter: 10}
+	assert.False(t, single.Contains(2, 4))
+	assert.True(t, single.Contains(2, 5))
+	assert.True(t, single.Contains(2, 9))
+	assert.False(t, single.Contains(2, 10))
+	assert.False(t, single.Contains(1, 7))
+
+	multi := Range{StartLine: 4, StartCharacter: 1, EndLine: 5, EndCharacter: 3}
+	assert.True(t, multi.Contains(4, 80))
+	assert.True(t, multi.Contains(5, 0))
+	assert.False(t, multi.Contains(5, 3))
+}
+
+func TestIsLocalSymbol(t *testing.T) {
+	assert.True(t, IsLocalSymbol("local 12"))
+	assert.False(t, IsLocalSymbol("scip-go gomod fmt v1 `fmt`/Println()."))
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+)
+
+// Rule detects a kind of secret. If the pattern has a capture group, the first group is the secret, otherwise the whole match.
+type Rule struct {
+	ID          string
+	Description string
+	Pattern     *regexp.Regexp
+}
+
+// Finding is a secret found in a content
+type Finding struct {
+	Rule   *Rule
+	Line   int // 1-based
+	Secret string
+}
+
+// Redacted returns the secret with all but its first characters masked, it is safe to show to users and to store
+func (f *Finding) Redacted() string {
+	return Redact(f.Secret)
+}
+
+// Fingerprint identifies the secret independently of where it has been found
+func (f *Finding) Fingerprint() string {
+	h := sha256.Sum256([]byte(f.Rule.ID + "\x00" + f.Secret))
+	return hex.EncodeToString(h[:])
+}
+
+// Redact masks the secret
+func Redact(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", min(len(secret)-4, 16))
+}
+
+var builtinRules = []*Rule{
+	{ID: "aws-access-key-id", Description: "AWS access key ID", Pattern: regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{ID: "aws-secret-access-key", Description: "AWS secret access key", Pattern: regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+]{40})\b`)},
+	{ID: "github-token", Description: "GitHub token", Pattern: regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,255})\b`)},
+	{ID: "github-fine-grained-token", Description: "GitHub fine-grained personal access token", Pattern: regexp.MustCompile(`\b(github_pat_[A-Za-z0-9_]{82})\b`)},
+	{ID: "gitlab-token", Description: "GitLab personal access token", Pattern: regexp.MustCompile(`\b(glpat-[A-Za-z0-9_\-]{20,})`)},
+	{ID: "slack-token", Description: "Slack token", Pattern: regexp.MustCompile(`\b(xox[abposr]-[A-Za-z0-9-]{10,})`)},
+	{ID: "slack-webhook", Description: "Slack webhook URL", Pattern: regexp.MustCompile(`https://hooks\.slack\.com/services/T[A-Za-z0-9_]+/B[A-Za-z0-9_]+/[A-Za-z0-9_]+`)},
+	{ID: "google-api-key", Description: "Google API key", Pattern: regexp.MustCompile(`\b(AIza[0-9A-Za-z_\-]{35})`)},
+	{ID: "stripe-secret-key", Description: "Stripe secret key", Pattern: regexp.MustCompile(`\b((?:sk|rk)_live_[0-9A-Za-z]{24,})\b`)},
+	{ID: "npm-token", Description: "npm access token", Pattern: regexp.MustCompile(`\b(npm_[A-Za-z0-9]{36})\b`)},
+	{ID: "pypi-token", Description: "PyPI API token", Pattern: regexp.MustCompile(`\b(pypi-AgEIcHlwaS5vcmc[A-Za-z0-9_\-]{50,})`)},
+	{ID: "sendgrid-api-key", Description: "SendGrid API key", Pattern: regexp.MustCompile(`\b(SG\.[A-Za-z0-9_\-]{22}\.[A-Za-z0-9_\-]{43})\b`)},
+	{ID: "private-key", Description: "Private key", Pattern: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`)},
+}
+
+var loadRules = sync.OnceValue(func() []*Rule {
+	rules := make([]*Rule, 0, len(builtinRules)+len(setting.SecretScanning.Rules))
+	rules = append(rules, builtinRules...)
+	for _, r := range setting.SecretScanning.Rules {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			log.Error("Invalid pattern of secret scanning rule %s (skipped): %v", r.ID, err)
+			continue
+		}
+		rules = append(rules, &Rule{ID: r.ID, Description: r.Description, Pattern: pattern})
+	}
+	return rules
+})
+
+// Rules returns the built-in rules and the rules defined by the site administrator
+func Rules() []*Rule {
+	return loadRules()
+}
+
+// IsBinary reports whether the content looks like a binary file, these are not scanned
+func IsBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), 8000)], 0) != -1
+}
+
+// Scan returns the secrets found in the content by the rules
+func Scan(content []byte, rules []*Rule) []*Finding {
+	var findings []*Finding
+	for _, rule := range rules {
+		for _, loc := range rule.Pattern.FindAllSubmatchIndex(content, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			findings = append(findings, &Finding{
+				Rule:   rule,
+				Line:   bytes.Count(content[:start], []byte{'\n'}) + 1,
+				Secret: string(content[start:end]),
+			})
+		}
+	}
+	return findings
+}
@@ -25,6 +25,9 @@ var (
 		LogCompression        logCompression    `ini:"LOG_COMPRESSION"`
 		ArtifactStorage       *Storage          // how the created artifacts should be stored
 		ArtifactRetentionDays int64             `ini:"ARTIFACT_RETENTION_DAYS"`
+		CacheStorage          *Storage          // how the entries of the cache server should be stored
+		CacheRetentionDays    int64             `ini:"CACHE_RETENTION_DAYS"`
+		CacheMaxSizePerRepo   int64             `ini:"-"`
 		DefaultActionsURL     defaultActionsURL `ini:"DEFAULT_ACTIONS_URL"`
 		ZombieTaskTimeout     time.Duration     `ini:"ZOMBIE_TASK_TIMEOUT"`
 		EndlessTaskTimeout    time.Duration     `ini:"ENDLESS_TASK_TIMEOUT"`
@@ -127,6 +130,24 @@ func loadActionsFrom(rootCfg ConfigProvider) error {
 		Actions.ArtifactRetentionDays = 90
 	}
 
+	cacheSec, _ := rootCfg.GetSection("actions.cache")
+
+	Actions.CacheStorage, err = getStorage(rootCfg, "actions_cache", "", cacheSec)
+	if err != nil {
+		return err
+	}
+
+	// default to 7 days in Github Actions, counted from the last time an entry has been restored
+	if Actions.CacheRetentionDays <= 0 {
+		Actions.CacheRetentionDays = 7
+	}
+
+	// default to 10 GiB in Github Actions
+	Actions.CacheMaxSizePerRepo = mustBytes(sec, "CACHE_MAX_SIZE_PER_REPO")
+	if Actions.CacheMaxSizePerRepo <= 0 {
+		Actions.CacheMaxSizePerRepo = 10 << 30
+	}
+
 	Actions.ZombieTaskTimeout = sec.Key("ZOMBIE_TASK_TIMEOUT").MustDuration(10 * time.Minute)
 	Actions.EndlessTaskTimeout = sec.Key("ENDLESS_TASK_TIMEOUT").MustDuration(3 * time.Hour)
 	Actions.AbandonedJobTimeout = sec.Key("ABANDONED_JOB_TIMEOUT").MustDuration(24 * time.Hour)
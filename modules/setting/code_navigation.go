@@ -0,0 +1,41 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"gitea.dev/modules/log"
+
+	"github.com/dustin/go-humanize"
+)
+
+// CodeNavigation settings
+var CodeNavigation = struct {
+	Enabled           bool
+	MaxIndexSize      int64
+	MaxDocumentSize   int64
+	MaxIndexesPerRepo int
+	MaxReferences     int
+}{
+	MaxIndexSize:      512 * 1024 * 1024,
+	MaxDocumentSize:   32 * 1024 * 1024,
+	MaxIndexesPerRepo: 10,
+	MaxReferences:     100,
+}
+
+func loadCodeNavigationFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("code_navigation")
+	CodeNavigation.Enabled = sec.Key("ENABLED").MustBool(false)
+	if size, err := humanize.ParseBytes(sec.Key("MAX_INDEX_SIZE").MustString("512 MiB")); err != nil {
+		log.Error("Invalid [code_navigation] MAX_INDEX_SIZE: %v", err)
+	} else {
+		CodeNavigation.MaxIndexSize = int64(size)
+	}
+	if size, err := humanize.ParseBytes(sec.Key("MAX_DOCUMENT_SIZE").MustString("32 MiB")); err != nil {
+		log.Error("Invalid [code_navigation] MAX_DOCUMENT_SIZE: %v", err)
+	} else {
+		CodeNavigation.MaxDocumentSize = int64(size)
+	}
+	CodeNavigation.MaxIndexesPerRepo = sec.Key("MAX_INDEXES_PER_REPO").MustInt(10)
+	CodeNavigation.MaxReferences = sec.Key("MAX_REFERENCES").MustInt(100)
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCodeNavigationFrom(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[code_navigation]
+ENABLED = true
+MAX_INDEX_SIZE = 1 GiB
+MAX_DOCUMENT_SIZE = 4 MiB
+MAX_INDEXES_PER_REPO = 3
+`)
+	require.NoError(t, err)
+	loadCodeNavigationFrom(cfg)
+
+	assert.True(t, CodeNavigation.Enabled)
+	assert.EqualValues(t, 1024*1024*1024, CodeNavigation.MaxIndexSize)
+	assert.EqualValues(t, 4*1024*1024, CodeNavigation.MaxDocumentSize)
+	assert.Equal(t, 3, CodeNavigation.MaxIndexesPerRepo)
+	assert.Equal(t, 100, CodeNavigation.MaxReferences)
+
+	cfg, err = NewConfigProviderFromData(``)
+	require.NoError(t, err)
+	loadCodeNavigationFrom(cfg)
+	assert.False(t, CodeNavigation.Enabled)
+	assert.EqualValues(t, 512*1024*1024, CodeNavigation.MaxIndexSize)
+	assert.Equal(t, 10, CodeNavigation.MaxIndexesPerRepo)
+}
@@ -6,6 +6,7 @@ package setting
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 )
@@ -19,6 +20,7 @@ var (
 		LimitTotalOwnerCount    int64
 		LimitTotalOwnerSize     int64
 		LimitSizeAlpine         int64
+		LimitSizeAnsible        int64
 		LimitSizeArch           int64
 		LimitSizeCargo          int64
 		LimitSizeChef           int64
@@ -31,7 +33,9 @@ var (
 		LimitSizeGeneric        int64
 		LimitSizeGo             int64
 		LimitSizeHelm           int64
+		LimitSizeHex            int64
 		LimitSizeMaven          int64
+		LimitSizeNix            int64
 		LimitSizeNpm            int64
 		LimitSizeNuGet          int64
 		LimitSizePub            int64
@@ -43,6 +47,10 @@ var (
 		LimitSizeVagrant        int64
 
 		DefaultRPMSignEnabled bool
+
+		NixTrustedPublicKeys []string
+
+		UpstreamAllowedHostList string
 	}{
 		Enabled:              true,
 		LimitTotalOwnerCount: -1,
@@ -50,6 +58,8 @@ var (
 )
 
 func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
+	Packages.UpstreamAllowedHostList = Security.AllowedHostList
+
 	sec, _ := rootCfg.GetSection("packages")
 	if sec == nil {
 		Packages.Storage, err = getStorage(rootCfg, "packages", "", nil)
@@ -67,6 +77,7 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 
 	Packages.LimitTotalOwnerSize = mustBytes(sec, "LIMIT_TOTAL_OWNER_SIZE")
 	Packages.LimitSizeAlpine = mustBytes(sec, "LIMIT_SIZE_ALPINE")
+	Packages.LimitSizeAnsible = mustBytes(sec, "LIMIT_SIZE_ANSIBLE")
 	Packages.LimitSizeArch = mustBytes(sec, "LIMIT_SIZE_ARCH")
 	Packages.LimitSizeCargo = mustBytes(sec, "LIMIT_SIZE_CARGO")
 	Packages.LimitSizeChef = mustBytes(sec, "LIMIT_SIZE_CHEF")
@@ -79,7 +90,9 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeGeneric = mustBytes(sec, "LIMIT_SIZE_GENERIC")
 	Packages.LimitSizeGo = mustBytes(sec, "LIMIT_SIZE_GO")
 	Packages.LimitSizeHelm = mustBytes(sec, "LIMIT_SIZE_HELM")
+	Packages.LimitSizeHex = mustBytes(sec, "LIMIT_SIZE_HEX")
 	Packages.LimitSizeMaven = mustBytes(sec, "LIMIT_SIZE_MAVEN")
+	Packages.LimitSizeNix = mustBytes(sec, "LIMIT_SIZE_NIX")
 	Packages.LimitSizeNpm = mustBytes(sec, "LIMIT_SIZE_NPM")
 	Packages.LimitSizeNuGet = mustBytes(sec, "LIMIT_SIZE_NUGET")
 	Packages.LimitSizePub = mustBytes(sec, "LIMIT_SIZE_PUB")
@@ -90,6 +103,8 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeTerraformState = mustBytes(sec, "LIMIT_SIZE_TERRAFORM_STATE")
 	Packages.LimitSizeVagrant = mustBytes(sec, "LIMIT_SIZE_VAGRANT")
 	Packages.DefaultRPMSignEnabled = sec.Key("DEFAULT_RPM_SIGN_ENABLED").MustBool(false)
+	Packages.NixTrustedPublicKeys = strings.Fields(sec.Key("NIX_TRUSTED_PUBLIC_KEYS").String())
+	Packages.UpstreamAllowedHostList = sec.Key("UPSTREAM_ALLOWED_HOST_LIST").MustString(Security.AllowedHostList)
 	return nil
 }
 
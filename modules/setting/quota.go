@@ -0,0 +1,16 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+// Quota settings
+var Quota = struct {
+	Enabled       bool
+	DefaultGroups []string
+}{}
+
+func loadQuotaFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("quota")
+	Quota.Enabled = sec.Key("ENABLED").MustBool(false)
+	Quota.DefaultGroups = sec.Key("DEFAULT_GROUPS").Strings(",")
+}
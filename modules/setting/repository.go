@@ -389,4 +389,7 @@ func loadRepositoryFrom(rootCfg ConfigProvider) {
 	if err := loadRepoArchiveFrom(rootCfg); err != nil {
 		log.Fatal("loadRepoArchiveFrom: %v", err)
 	}
+	if err := loadRepoBackupFrom(rootCfg); err != nil {
+		log.Fatal("loadRepoBackupFrom: %v", err)
+	}
 }
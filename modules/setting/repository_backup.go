@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import "fmt"
+
+// RepoBackup represents the storage of the scheduled repository backups,
+// the schedule and the retention are configured by the cron task "repo_backups"
+var RepoBackup = struct {
+	Storage *Storage
+}{}
+
+func loadRepoBackupFrom(rootCfg ConfigProvider) (err error) {
+	sec, _ := rootCfg.GetSection("repo-backup")
+	if sec == nil {
+		RepoBackup.Storage, err = getStorage(rootCfg, "repo-backup", "", nil)
+		return err
+	}
+
+	if err := sec.MapTo(&RepoBackup); err != nil {
+		return fmt.Errorf("mapto repo-backup failed: %v", err)
+	}
+
+	RepoBackup.Storage, err = getStorage(rootCfg, "repo-backup", "", sec)
+	return err
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"strings"
+
+	"gitea.dev/modules/log"
+
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	SecretScanningPushModeAlert = "alert"
+	SecretScanningPushModeBlock = "block"
+)
+
+// SecretScanningRule is a rule for secrets defined by the site administrator
+type SecretScanningRule struct {
+	ID          string
+	Description string
+	Pattern     string
+}
+
+// SecretScanning settings
+var SecretScanning = struct {
+	Enabled     bool
+	PushMode    string
+	MaxFileSize int64
+	Rules       []SecretScanningRule
+}{
+	PushMode:    SecretScanningPushModeAlert,
+	MaxFileSize: 1024 * 1024,
+}
+
+func loadSecretScanningFrom(rootCfg ConfigProvider) {
+	sec := rootCfg.Section("secret_scanning")
+	SecretScanning.Enabled = sec.Key("ENABLED").MustBool(false)
+	SecretScanning.PushMode = sec.Key("PUSH_MODE").In(SecretScanningPushModeAlert, []string{SecretScanningPushModeAlert, SecretScanningPushModeBlock})
+	if size, err := humanize.ParseBytes(sec.Key("MAX_FILE_SIZE").MustString("1 MiB")); err != nil {
+		log.Error("Invalid [secret_scanning] MAX_FILE_SIZE: %v", err)
+	} else {
+		SecretScanning.MaxFileSize = int64(size)
+	}
+
+	SecretScanning.Rules = nil
+	for _, child := range rootCfg.Section("secret_scanning.rule").ChildSections() {
+		id := strings.TrimPrefix(child.Name(), "secret_scanning.rule.")
+		pattern := child.Key("PATTERN").String()
+		if id == "" || pattern == "" {
+			log.Warn("Secret scanning rule %s has no PATTERN, ignored", child.Name())
+			continue
+		}
+		SecretScanning.Rules = append(SecretScanning.Rules, SecretScanningRule{
+			ID:          id,
+			Description: child.Key("DESCRIPTION").MustString(id),
+			Pattern:     pattern,
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSecretScanningFrom(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[secret_scanning]
+ENABLED = true
+PUSH_MODE = block
+MAX_FILE_SIZE = 10 KiB
+
+[secret_scanning.rule.internal-token]
+DESCRIPTION = Internal token
+PATTERN = itk_[a-z]{8}
+
+[secret_scanning.rule.no-description]
+PATTERN = nd_[a-z]{8}
+
+[secret_scanning.rule.no-pattern]
+DESCRIPTION = Ignored
+`)
+	require.NoError(t, err)
+	loadSecretScanningFrom(cfg)
+
+	assert.True(t, SecretScanning.Enabled)
+	assert.Equal(t, SecretScanningPushModeBlock, SecretScanning.PushMode)
+	assert.EqualValues(t, 10*1024, SecretScanning.MaxFileSize)
+	assert.Equal(t, []SecretScanningRule{
+		{ID: "internal-token", Description: "Internal token", Pattern: "itk_[a-z]{8}"},
+		{ID: "no-description", Description: "no-description", Pattern: "nd_[a-z]{8}"},
+	}, SecretScanning.Rules)
+
+	cfg, err = NewConfigProviderFromData(`
+[secret_scanning]
+PUSH_MODE = invalid
+`)
+	require.NoError(t, err)
+	loadSecretScanningFrom(cfg)
+	assert.False(t, SecretScanning.Enabled)
+	assert.Equal(t, SecretScanningPushModeAlert, SecretScanning.PushMode)
+	assert.Empty(t, SecretScanning.Rules)
+}
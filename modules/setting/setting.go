@@ -144,6 +144,9 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	if err := loadPackagesFrom(cfg); err != nil {
 		return err
 	}
+	loadQuotaFrom(cfg)
+	loadSecretScanningFrom(cfg)
+	loadCodeNavigationFrom(cfg)
 	if err := loadActionsFrom(cfg); err != nil {
 		return err
 	}
@@ -4,6 +4,7 @@
 package setting
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -21,12 +22,15 @@ const (
 	MinioStorageType StorageType = "minio"
 	// AzureBlobStorageType is the type descriptor for azure blob storage
 	AzureBlobStorageType StorageType = "azureblob"
+	// GCSStorageType is the type descriptor for google cloud storage
+	GCSStorageType StorageType = "gcs"
 )
 
 var storageTypes = []StorageType{
 	LocalStorageType,
 	MinioStorageType,
 	AzureBlobStorageType,
+	GCSStorageType,
 }
 
 // IsValidStorageType returns true if the given storage type is valid
@@ -78,25 +82,79 @@ func (cfg *AzureBlobStorageConfig) ToShadow() {
 	}
 }
 
+// GCSStorageConfig represents the configuration for a google cloud storage
+type GCSStorageConfig struct {
+	Endpoint  string `ini:"GCS_ENDPOINT" json:",omitempty"`
+	Bucket    string `ini:"GCS_BUCKET" json:",omitempty"`
+	BasePath  string `ini:"GCS_BASE_PATH" json:",omitempty"`
+	ProjectID string `ini:"GCS_PROJECT_ID" json:",omitempty"` // only needed to create the bucket
+	Location  string `ini:"GCS_LOCATION" json:",omitempty"`
+	// the credentials of a service account or of a workload identity federation,
+	// the application default credentials are used if it is empty
+	CredentialsFile string `ini:"GCS_CREDENTIALS_FILE" json:",omitempty"`
+	// the service account signing the serve direct URLs through the IAM API when the credentials have no private key
+	ServiceAccountEmail string `ini:"GCS_SERVICE_ACCOUNT_EMAIL" json:",omitempty"`
+	// send unauthenticated requests, for emulators like fake-gcs-server
+	Anonymous   bool `ini:"GCS_ANONYMOUS"`
+	ServeDirect bool `ini:"SERVE_DIRECT"`
+}
+
+// StorageEncryptionConfig represents the configuration of the encryption at rest of a storage,
+// every object is encrypted with its own data key which is wrapped by the master key
+type StorageEncryptionConfig struct {
+	Enabled     bool
+	KeyProvider string `json:",omitempty"`
+	// the master keys are base64 encoded 256-bit keys, the old ones are only used to decrypt the objects
+	// which haven't been re-encrypted yet after a rotation
+	MasterKey     string   `json:",omitempty"`
+	OldMasterKeys []string `json:",omitempty"`
+	// the key service wrapping the data keys for the "kms" key provider
+	KMSEndpoint string `json:",omitempty"`
+	KMSToken    string `json:",omitempty"`
+	// whether the objects written before the encryption was enabled can still be read
+	AllowPlaintext bool
+}
+
+func (cfg *StorageEncryptionConfig) ToShadow() {
+	if cfg.MasterKey != "" {
+		cfg.MasterKey = "******"
+	}
+	if cfg.KMSToken != "" {
+		cfg.KMSToken = "******"
+	}
+	for i := range cfg.OldMasterKeys {
+		cfg.OldMasterKeys[i] = "******"
+	}
+}
+
 // Storage represents configuration of storages
 type Storage struct {
-	Type            StorageType            // local or minio or azureblob
+	Type            StorageType            // local or minio or azureblob or gcs
 	Path            string                 `json:",omitempty"` // for local type
 	TemporaryPath   string                 `json:",omitempty"`
 	MinioConfig     MinioStorageConfig     // for minio type
 	AzureBlobConfig AzureBlobStorageConfig // for azureblob type
+	GCSConfig       GCSStorageConfig       // for gcs type
+	Encryption      StorageEncryptionConfig
 }
 
 func (storage *Storage) ToShadowCopy() Storage {
 	shadowStorage := *storage
 	shadowStorage.MinioConfig.ToShadow()
 	shadowStorage.AzureBlobConfig.ToShadow()
+	shadowStorage.Encryption.OldMasterKeys = slices.Clone(storage.Encryption.OldMasterKeys)
+	shadowStorage.Encryption.ToShadow()
 	return shadowStorage
 }
 
+// ServeDirect reports whether the files are served by the object storage, the encrypted files are always served by Gitea
 func (storage *Storage) ServeDirect() bool {
+	if storage.Encryption.Enabled {
+		return false
+	}
 	return (storage.Type == MinioStorageType && storage.MinioConfig.ServeDirect) ||
-		(storage.Type == AzureBlobStorageType && storage.AzureBlobConfig.ServeDirect)
+		(storage.Type == AzureBlobStorageType && storage.AzureBlobConfig.ServeDirect) ||
+		(storage.Type == GCSStorageType && storage.GCSConfig.ServeDirect)
 }
 
 const storageSectionName = "storage"
@@ -118,6 +176,9 @@ func getDefaultStorageSection(rootCfg ConfigProvider) ConfigSection {
 	storageSec.Key("AZURE_BLOB_ACCOUNT_NAME").MustString("")
 	storageSec.Key("AZURE_BLOB_ACCOUNT_KEY").MustString("")
 	storageSec.Key("AZURE_BLOB_CONTAINER").MustString("gitea")
+	storageSec.Key("GCS_ENDPOINT").MustString("https://storage.googleapis.com")
+	storageSec.Key("GCS_BUCKET").MustString("gitea")
+	storageSec.Key("GCS_LOCATION").MustString("US")
 	return storageSec
 }
 
@@ -135,17 +196,61 @@ func getStorage(rootCfg ConfigProvider, name, typ string, sec ConfigSection) (*S
 
 	overrideSec := getStorageOverrideSection(rootCfg, sec, tp, name)
 
+	var storage *Storage
 	targetType := targetSec.Key("STORAGE_TYPE").String()
 	switch targetType {
 	case string(LocalStorageType):
-		return getStorageForLocal(targetSec, overrideSec, tp, name)
+		storage, err = getStorageForLocal(targetSec, overrideSec, tp, name)
 	case string(MinioStorageType):
-		return getStorageForMinio(targetSec, overrideSec, tp, name)
+		storage, err = getStorageForMinio(targetSec, overrideSec, tp, name)
 	case string(AzureBlobStorageType):
-		return getStorageForAzureBlob(targetSec, overrideSec, tp, name)
+		storage, err = getStorageForAzureBlob(targetSec, overrideSec, tp, name)
+	case string(GCSStorageType):
+		storage, err = getStorageForGCS(targetSec, overrideSec, tp, name)
 	default:
 		return nil, fmt.Errorf("unsupported storage type %q", targetType)
 	}
+	if err != nil {
+		return nil, err
+	}
+	if err := loadStorageEncryption(&storage.Encryption, targetSec, overrideSec); err != nil {
+		return nil, fmt.Errorf("invalid encryption config of storage %q: %w", name, err)
+	}
+	return storage, nil
+}
+
+// loadStorageEncryption reads the encryption config of the target section, the override section could
+// enable the encryption or change the master key for a single storage
+func loadStorageEncryption(cfg *StorageEncryptionConfig, targetSec, overrideSec ConfigSection) error {
+	sec := targetSec
+	if overrideSec != nil && (overrideSec.HasKey("ENCRYPTION_ENABLED") || overrideSec.HasKey("ENCRYPTION_MASTER_KEY") || overrideSec.HasKey("ENCRYPTION_MASTER_KEY_URI")) {
+		sec = overrideSec
+	}
+	cfg.Enabled = sec.Key("ENCRYPTION_ENABLED").MustBool(false)
+	if !cfg.Enabled {
+		return nil
+	}
+	cfg.KeyProvider = sec.Key("ENCRYPTION_KEY_PROVIDER").MustString("local")
+	cfg.MasterKey = loadSecret(sec, "ENCRYPTION_MASTER_KEY_URI", "ENCRYPTION_MASTER_KEY")
+	cfg.OldMasterKeys = sec.Key("ENCRYPTION_OLD_MASTER_KEYS").Strings(",")
+	cfg.KMSEndpoint = sec.Key("ENCRYPTION_KMS_ENDPOINT").String()
+	cfg.KMSToken = loadSecret(sec, "ENCRYPTION_KMS_TOKEN_URI", "ENCRYPTION_KMS_TOKEN")
+	cfg.AllowPlaintext = sec.Key("ENCRYPTION_ALLOW_PLAINTEXT").MustBool(false)
+
+	switch cfg.KeyProvider {
+	case "local":
+		for _, key := range append([]string{cfg.MasterKey}, cfg.OldMasterKeys...) {
+			if k, err := base64.StdEncoding.DecodeString(key); err != nil || len(k) != 32 {
+				return errors.New("the master keys must be base64 encoded 32-byte keys")
+			}
+		}
+	case "kms":
+		if cfg.KMSEndpoint == "" {
+			return errors.New("ENCRYPTION_KMS_ENDPOINT is required by the kms key provider")
+		}
+	}
+	// the other key providers validate their own config
+	return nil
 }
 
 type targetSecType int
@@ -340,3 +445,32 @@ func getStorageForAzureBlob(targetSec, overrideSec ConfigSection, tp targetSecTy
 	}
 	return &storage, nil
 }
+
+func getStorageForGCS(targetSec, overrideSec ConfigSection, tp targetSecType, name string) (*Storage, error) { //nolint:dupl // duplicates minio setup
+	var storage Storage
+	storage.Type = StorageType(targetSec.Key("STORAGE_TYPE").String())
+	if err := targetSec.MapTo(&storage.GCSConfig); err != nil {
+		return nil, fmt.Errorf("map gcs config failed: %v", err)
+	}
+
+	var defaultPath string
+	if storage.GCSConfig.BasePath != "" {
+		if tp == targetSecIsStorage || tp == targetSecIsDefault {
+			defaultPath = strings.TrimSuffix(storage.GCSConfig.BasePath, "/") + "/" + name + "/"
+		} else {
+			defaultPath = storage.GCSConfig.BasePath
+		}
+	}
+	if defaultPath == "" {
+		defaultPath = name + "/"
+	}
+
+	if overrideSec != nil {
+		storage.GCSConfig.ServeDirect = ConfigSectionKeyBool(overrideSec, "SERVE_DIRECT", storage.GCSConfig.ServeDirect)
+		storage.GCSConfig.BasePath = ConfigSectionKeyString(overrideSec, "GCS_BASE_PATH", defaultPath)
+		storage.GCSConfig.Bucket = ConfigSectionKeyString(overrideSec, "GCS_BUCKET", storage.GCSConfig.Bucket)
+	} else {
+		storage.GCSConfig.BasePath = defaultPath
+	}
+	return &storage, nil
+}
@@ -135,6 +135,99 @@ STORAGE_TYPE = azureblob
 	assert.Equal(t, "repo-avatars/", RepoAvatar.Storage.AzureBlobConfig.BasePath)
 }
 
+func Test_getStorageInheritStorageTypeGCS(t *testing.T) {
+	iniStr := `
+[storage]
+STORAGE_TYPE = gcs
+GCS_BUCKET = my-bucket
+
+[storage.actions_log]
+GCS_BASE_PATH = logs/
+SERVE_DIRECT = true
+`
+	cfg, err := NewConfigProviderFromData(iniStr)
+	assert.NoError(t, err)
+
+	assert.NoError(t, loadPackagesFrom(cfg))
+	assert.EqualValues(t, "gcs", Packages.Storage.Type)
+	assert.Equal(t, "https://storage.googleapis.com", Packages.Storage.GCSConfig.Endpoint)
+	assert.Equal(t, "my-bucket", Packages.Storage.GCSConfig.Bucket)
+	assert.Equal(t, "packages/", Packages.Storage.GCSConfig.BasePath)
+	assert.False(t, Packages.Storage.ServeDirect())
+
+	assert.NoError(t, loadActionsFrom(cfg))
+	assert.EqualValues(t, "gcs", Actions.LogStorage.Type)
+	assert.Equal(t, "my-bucket", Actions.LogStorage.GCSConfig.Bucket)
+	assert.Equal(t, "logs/", Actions.LogStorage.GCSConfig.BasePath)
+	assert.True(t, Actions.LogStorage.ServeDirect())
+
+	assert.EqualValues(t, "gcs", Actions.ArtifactStorage.Type)
+	assert.Equal(t, "actions_artifacts/", Actions.ArtifactStorage.GCSConfig.BasePath)
+}
+
+func Test_getStorageEncryption(t *testing.T) {
+	iniStr := `
+[storage]
+ENCRYPTION_ENABLED = true
+ENCRYPTION_MASTER_KEY = MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=
+
+[storage.minio]
+MINIO_BUCKET = gitea
+
+[attachment]
+STORAGE_TYPE = minio
+SERVE_DIRECT = true
+
+[storage.packages]
+ENCRYPTION_ENABLED = false
+`
+	cfg, err := NewConfigProviderFromData(iniStr)
+	assert.NoError(t, err)
+
+	assert.NoError(t, loadAttachmentFrom(cfg))
+	assert.True(t, Attachment.Storage.Encryption.Enabled)
+	assert.Equal(t, "local", Attachment.Storage.Encryption.KeyProvider)
+	assert.False(t, Attachment.Storage.ServeDirect())
+	assert.Equal(t, "******", Attachment.Storage.ToShadowCopy().Encryption.MasterKey)
+	assert.Equal(t, "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=", Attachment.Storage.Encryption.MasterKey)
+	assert.False(t, Attachment.Storage.Encryption.AllowPlaintext)
+
+	assert.NoError(t, loadPackagesFrom(cfg))
+	assert.False(t, Packages.Storage.Encryption.Enabled)
+
+	cfg, err = NewConfigProviderFromData(`
+[storage]
+ENCRYPTION_ENABLED = true
+ENCRYPTION_MASTER_KEY = short
+`)
+	assert.NoError(t, err)
+	assert.Error(t, loadAttachmentFrom(cfg))
+
+	cfg, err = NewConfigProviderFromData(`
+[storage]
+ENCRYPTION_ENABLED = true
+ENCRYPTION_KEY_PROVIDER = kms
+`)
+	assert.NoError(t, err)
+	assert.Error(t, loadAttachmentFrom(cfg))
+
+	cfg, err = NewConfigProviderFromData(`
+[storage]
+ENCRYPTION_ENABLED = true
+ENCRYPTION_KEY_PROVIDER = kms
+ENCRYPTION_KMS_ENDPOINT = https://kms.example.com
+ENCRYPTION_KMS_TOKEN = token
+ENCRYPTION_ALLOW_PLAINTEXT = true
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, loadAttachmentFrom(cfg))
+	assert.Equal(t, "kms", Attachment.Storage.Encryption.KeyProvider)
+	assert.Equal(t, "https://kms.example.com", Attachment.Storage.Encryption.KMSEndpoint)
+	assert.Equal(t, "token", Attachment.Storage.Encryption.KMSToken)
+	assert.Equal(t, "******", Attachment.Storage.ToShadowCopy().Encryption.KMSToken)
+	assert.True(t, Attachment.Storage.Encryption.AllowPlaintext)
+}
+
 type testLocalStoragePathCase struct {
 	loader       func(rootCfg ConfigProvider) error
 	storagePtr   **Storage
@@ -5,6 +5,7 @@ package setting
 
 import (
 	"net/url"
+	"time"
 
 	"gitea.dev/modules/log"
 )
@@ -20,6 +21,12 @@ var Webhook = struct {
 	ProxyURL        string
 	ProxyURLFixed   *url.URL
 	ProxyHosts      []string
+	// MaxRetries is the number of times a failed delivery is retried
+	MaxRetries      int
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+	// DisableAfterFailures is the number of consecutive failed deliveries disabling a webhook, 0 never disables it
+	DisableAfterFailures int
 }{
 	QueueLength:    1000,
 	DeliverTimeout: 5,
@@ -27,6 +34,11 @@ var Webhook = struct {
 	PagingNum:      10,
 	ProxyURL:       "",
 	ProxyHosts:     []string{},
+
+	MaxRetries:           3,
+	RetryBackoff:         time.Minute,
+	RetryMaxBackoff:      time.Hour,
+	DisableAfterFailures: 10,
 }
 
 func loadWebhookFrom(rootCfg ConfigProvider) {
@@ -38,7 +50,7 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 	deprecatedSetting(rootCfg, "webhook", "ALLOWED_HOST_LIST", "security", "ALLOWED_HOST_LIST", "v28.0.0")
 	Webhook.AllowedHostList = sec.Key("ALLOWED_HOST_LIST").MustString(Security.AllowedHostList)
 
-	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist"}
+	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist", "custom"}
 	Webhook.PagingNum = sec.Key("PAGING_NUM").MustInt(10)
 	Webhook.ProxyURL = sec.Key("PROXY_URL").MustString("")
 	if Webhook.ProxyURL != "" {
@@ -50,4 +62,8 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 		}
 	}
 	Webhook.ProxyHosts = sec.Key("PROXY_HOSTS").Strings(",")
+	Webhook.MaxRetries = max(sec.Key("MAX_RETRIES").MustInt(3), 0)
+	Webhook.RetryBackoff = sec.Key("RETRY_BACKOFF").MustDuration(time.Minute)
+	Webhook.RetryMaxBackoff = max(sec.Key("RETRY_MAX_BACKOFF").MustDuration(time.Hour), Webhook.RetryBackoff)
+	Webhook.DisableAfterFailures = max(sec.Key("DISABLE_AFTER_FAILURES").MustInt(10), 0)
 }
@@ -0,0 +1,579 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+)
+
+// The encrypted objects start with a header holding the wrapped data key, followed by the content which is
+// split into chunks sealed by AES-256-GCM, so they can be decrypted while streaming and seeked.
+// The nonce of a chunk is made of a random prefix, the chunk index and a flag for the last chunk,
+// so reordered or truncated chunks fail the authentication.
+const (
+	encryptedObjectMagic     = "GITEAENC"
+	encryptedObjectVersion   = 1
+	encryptedChunkSize       = 64 * 1024
+	encryptedNoncePrefixSize = 7
+	encryptedDataKeySize     = 32
+)
+
+var (
+	// ErrObjectCorrupted is returned if an encrypted object can't be decrypted
+	ErrObjectCorrupted = errors.New("encrypted object is corrupted")
+	// ErrObjectNotEncrypted is returned for the plain objects if they are not allowed to be read
+	ErrObjectNotEncrypted = errors.New("object is not encrypted")
+)
+
+// KeyProvider wraps the data keys of the encrypted objects with a master key, it is where a KMS could be plugged in
+type KeyProvider interface {
+	// CurrentKeyID returns the ID of the master key used by WrapKey
+	CurrentKeyID() (string, error)
+	WrapKey(dataKey []byte) (keyID string, wrapped []byte, err error)
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+}
+
+// NewKeyProviderFunc is a function that creates a key provider, the context is the one of the storage
+type NewKeyProviderFunc func(ctx context.Context, cfg *setting.StorageEncryptionConfig) (KeyProvider, error)
+
+var keyProviderMap = map[string]NewKeyProviderFunc{}
+
+// RegisterKeyProvider registers a provided key provider with a function to create it
+func RegisterKeyProvider(name string, fn NewKeyProviderFunc) {
+	keyProviderMap[name] = fn
+}
+
+// masterKeyProvider wraps the data keys with the master keys from the config
+type masterKeyProvider struct {
+	currentKeyID string
+	keys         map[string]cipher.AEAD
+}
+
+func masterKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return "local:" + hex.EncodeToString(sum[:8])
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// NewMasterKeyProvider returns a key provider using the master keys from the config,
+// the old master keys can still unwrap the data keys of the objects encrypted before a rotation
+func NewMasterKeyProvider(_ context.Context, cfg *setting.StorageEncryptionConfig) (KeyProvider, error) {
+	p := &masterKeyProvider{keys: map[string]cipher.AEAD{}}
+	for i, encoded := range append([]string{cfg.MasterKey}, cfg.OldMasterKeys...) {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != encryptedDataKeySize {
+			return nil, errors.New("the master keys must be base64 encoded 32-byte keys")
+		}
+		aead, err := newGCM(key)
+		if err != nil {
+			return nil, err
+		}
+		keyID := masterKeyID(key)
+		if i == 0 {
+			p.currentKeyID = keyID
+		}
+		p.keys[keyID] = aead
+	}
+	return p, nil
+}
+
+func (p *masterKeyProvider) CurrentKeyID() (string, error) {
+	return p.currentKeyID, nil
+}
+
+func (p *masterKeyProvider) WrapKey(dataKey []byte) (string, []byte, error) {
+	aead := p.keys[p.currentKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	return p.currentKeyID, aead.Seal(nonce, nonce, dataKey, []byte(p.currentKeyID)), nil
+}
+
+func (p *masterKeyProvider) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown master key %q", keyID)
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrObjectCorrupted
+	}
+	dataKey, err := aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], []byte(keyID))
+	if err != nil {
+		return nil, ErrObjectCorrupted
+	}
+	return dataKey, nil
+}
+
+// encryptedObjectHeader is stored as JSON after the magic and the version
+type encryptedObjectHeader struct {
+	KeyID       string `json:"key_id"`
+	WrappedKey  []byte `json:"wrapped_key"`
+	NoncePrefix []byte `json:"nonce_prefix"`
+	ChunkSize   int64  `json:"chunk_size"`
+}
+
+func (h *encryptedObjectHeader) marshal() ([]byte, error) {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBufferString(encryptedObjectMagic)
+	buf.WriteByte(encryptedObjectVersion)
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	buf.Write(data)
+	return buf.Bytes(), nil
+}
+
+// readEncryptedObjectHeader reads the header from the start of the object, it returns nil if the object isn't encrypted
+func readEncryptedObjectHeader(r io.Reader) (*encryptedObjectHeader, int64, error) {
+	prefix := make([]byte, len(encryptedObjectMagic)+1+4)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	if string(prefix[:len(encryptedObjectMagic)]) != encryptedObjectMagic {
+		return nil, 0, nil
+	}
+	if prefix[len(encryptedObjectMagic)] != encryptedObjectVersion {
+		return nil, 0, fmt.Errorf("unsupported encrypted object version %d", prefix[len(encryptedObjectMagic)])
+	}
+	length := binary.BigEndian.Uint32(prefix[len(encryptedObjectMagic)+1:])
+	if length > 64*1024 {
+		return nil, 0, ErrObjectCorrupted
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, 0, ErrObjectCorrupted
+	}
+	var header encryptedObjectHeader
+	if err := json.Unmarshal(data, &header); err != nil || header.ChunkSize <= 0 || len(header.NoncePrefix) != encryptedNoncePrefixSize {
+		return nil, 0, ErrObjectCorrupted
+	}
+	return &header, int64(len(prefix)) + int64(length), nil
+}
+
+func chunkNonce(prefix []byte, index int64, last bool) []byte {
+	nonce := make([]byte, 0, encryptedNoncePrefixSize+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, uint32(index))
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// encryptedSize returns the size of the encrypted content, an empty content still has one sealed chunk
+func encryptedSize(size, chunkSize int64, overhead int) int64 {
+	chunks := max((size+chunkSize-1)/chunkSize, 1)
+	return size + chunks*int64(overhead)
+}
+
+var _ Object = &encryptedObject{}
+
+// encryptedObject decrypts the chunks of the underlying object on demand
+type encryptedObject struct {
+	raw         Object
+	aead        cipher.AEAD
+	noncePrefix []byte
+	chunkSize   int64
+	dataOffset  int64
+	chunks      int64
+	size        int64
+	offset      int64
+	chunkIndex  int64
+	chunk       []byte
+}
+
+type encryptedFileInfo struct {
+	os.FileInfo
+	size int64
+}
+
+func (fi encryptedFileInfo) Size() int64 {
+	return fi.size
+}
+
+func (o *encryptedObject) loadChunk(index int64) error {
+	if _, err := o.raw.Seek(o.dataOffset+index*(o.chunkSize+int64(o.aead.Overhead())), io.SeekStart); err != nil {
+		return err
+	}
+	length := o.chunkSize
+	last := index == o.chunks-1
+	if last {
+		length = o.size - index*o.chunkSize
+	}
+	buf := make([]byte, length+int64(o.aead.Overhead()))
+	if _, err := io.ReadFull(o.raw, buf); err != nil {
+		return ErrObjectCorrupted
+	}
+	plain, err := o.aead.Open(buf[:0], chunkNonce(o.noncePrefix, index, last), buf, nil)
+	if err != nil {
+		return ErrObjectCorrupted
+	}
+	o.chunk, o.chunkIndex = plain, index
+	return nil
+}
+
+func (o *encryptedObject) Read(p []byte) (int, error) {
+	if o.offset >= o.size {
+		return 0, io.EOF
+	}
+	index := o.offset / o.chunkSize
+	if index != o.chunkIndex {
+		if err := o.loadChunk(index); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, o.chunk[o.offset-index*o.chunkSize:])
+	o.offset += int64(n)
+	return n, nil
+}
+
+func (o *encryptedObject) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += o.offset
+	case io.SeekEnd:
+		offset = o.size + offset
+	default:
+		return 0, errors.New("Seek: invalid whence")
+	}
+	if offset > o.size || offset < 0 {
+		return 0, errors.New("Seek: invalid offset")
+	}
+	o.offset = offset
+	return o.offset, nil
+}
+
+func (o *encryptedObject) Close() error {
+	return o.raw.Close()
+}
+
+func (o *encryptedObject) Stat() (os.FileInfo, error) {
+	fi, err := o.raw.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return encryptedFileInfo{FileInfo: fi, size: o.size}, nil
+}
+
+var _ ObjectStorage = &EncryptedStorage{}
+
+// EncryptedStorage wraps an object storage and encrypts the objects with envelope encryption
+type EncryptedStorage struct {
+	storage        ObjectStorage
+	keyProvider    KeyProvider
+	allowPlaintext bool
+}
+
+// NewEncryptedStorage wraps the object storage with the encryption config
+func NewEncryptedStorage(ctx context.Context, objStorage ObjectStorage, cfg *setting.StorageEncryptionConfig) (*EncryptedStorage, error) {
+	fn, ok := keyProviderMap[cfg.KeyProvider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported encryption key provider: %s", cfg.KeyProvider)
+	}
+	keyProvider, err := fn(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedStorage{storage: objStorage, keyProvider: keyProvider, allowPlaintext: cfg.AllowPlaintext}, nil
+}
+
+// openRaw opens the underlying object, the returned header is nil if the object hasn't been encrypted
+func (s *EncryptedStorage) openRaw(path string) (Object, *encryptedObjectHeader, int64, error) {
+	raw, err := s.storage.Open(path)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	header, dataOffset, err := readEncryptedObjectHeader(raw)
+	if err != nil {
+		_ = raw.Close()
+		return nil, nil, 0, err
+	}
+	return raw, header, dataOffset, nil
+}
+
+// checkPlaintext reports whether the object written before the encryption was enabled can be read
+func (s *EncryptedStorage) checkPlaintext(path string) error {
+	if !s.allowPlaintext {
+		return fmt.Errorf("%w: %s", ErrObjectNotEncrypted, path)
+	}
+	log.Warn("The object %s of an encrypted storage is not encrypted, it can be encrypted by \"gitea migrate-storage --reencrypt\"", path)
+	return nil
+}
+
+// wrapObject returns the decrypting object, the objects written before the encryption was enabled are returned as-is
+func (s *EncryptedStorage) wrapObject(raw Object, header *encryptedObjectHeader, dataOffset int64) (Object, error) {
+	if header == nil {
+		if _, err := raw.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return raw, nil
+	}
+
+	dataKey, err := s.keyProvider.UnwrapKey(header.KeyID, header.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := raw.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := fi.Size() - dataOffset
+	sealedChunkSize := header.ChunkSize + int64(aead.Overhead())
+	chunks := (payload + sealedChunkSize - 1) / sealedChunkSize
+	if payload < int64(aead.Overhead()) || payload-(chunks-1)*sealedChunkSize < int64(aead.Overhead()) {
+		return nil, ErrObjectCorrupted
+	}
+	return &encryptedObject{
+		raw:         raw,
+		aead:        aead,
+		noncePrefix: header.NoncePrefix,
+		chunkSize:   header.ChunkSize,
+		dataOffset:  dataOffset,
+		chunks:      chunks,
+		size:        payload - chunks*int64(aead.Overhead()),
+		chunkIndex:  -1,
+	}, nil
+}
+
+// Open opens the object and decrypts it while reading
+func (s *EncryptedStorage) Open(path string) (Object, error) {
+	raw, header, dataOffset, err := s.openRaw(path)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		if err := s.checkPlaintext(path); err != nil {
+			_ = raw.Close()
+			return nil, err
+		}
+	}
+	obj, err := s.wrapObject(raw, header, dataOffset)
+	if err != nil {
+		_ = raw.Close()
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Save encrypts the content while writing it to the underlying storage, it returns the size of the plain content
+func (s *EncryptedStorage) Save(path string, r io.Reader, size int64) (int64, error) {
+	dataKey := make([]byte, encryptedDataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return 0, err
+	}
+	header := &encryptedObjectHeader{
+		NoncePrefix: make([]byte, encryptedNoncePrefixSize),
+		ChunkSize:   encryptedChunkSize,
+	}
+	if _, err := rand.Read(header.NoncePrefix); err != nil {
+		return 0, err
+	}
+	var err error
+	if header.KeyID, header.WrappedKey, err = s.keyProvider.WrapKey(dataKey); err != nil {
+		return 0, err
+	}
+	headerBytes, err := header.marshal()
+	if err != nil {
+		return 0, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return 0, err
+	}
+
+	rawSize := int64(-1)
+	if size >= 0 {
+		rawSize = int64(len(headerBytes)) + encryptedSize(size, header.ChunkSize, aead.Overhead())
+	}
+
+	pr, pw := io.Pipe()
+	var written int64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var err error
+		written, err = writeEncryptedChunks(pw, headerBytes, aead, header, r)
+		_ = pw.CloseWithError(err)
+	}()
+
+	_, err = s.storage.Save(path, pr, rawSize)
+	_ = pr.CloseWithError(err) // unblock the writer if the storage stopped reading
+	<-done
+	if err != nil {
+		return 0, err
+	}
+	if size >= 0 && written != size {
+		return 0, fmt.Errorf("unexpected content size %d, expected %d", written, size)
+	}
+	return written, nil
+}
+
+func writeEncryptedChunks(w io.Writer, headerBytes []byte, aead cipher.AEAD, header *encryptedObjectHeader, r io.Reader) (int64, error) {
+	if _, err := w.Write(headerBytes); err != nil {
+		return 0, err
+	}
+	rd := bufio.NewReaderSize(r, int(header.ChunkSize))
+	buf := make([]byte, header.ChunkSize, header.ChunkSize+int64(aead.Overhead()))
+	var written int64
+	for index := int64(0); ; index++ {
+		n, last, err := readFullChunk(rd, buf[:header.ChunkSize])
+		if err != nil {
+			return written, err
+		}
+		sealed := aead.Seal(buf[:0], chunkNonce(header.NoncePrefix, index, last), buf[:n], nil)
+		if _, err := w.Write(sealed); err != nil {
+			return written, err
+		}
+		written += int64(n)
+		if last {
+			return written, nil
+		}
+	}
+}
+
+// Stat returns the stat information of the object with the size of the plain content
+func (s *EncryptedStorage) Stat(path string) (os.FileInfo, error) {
+	obj, err := s.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	return obj.Stat()
+}
+
+// Delete deletes the object
+func (s *EncryptedStorage) Delete(path string) error {
+	return s.storage.Delete(path)
+}
+
+// ServeDirectURL isn't supported because the object storage can't decrypt the objects
+func (s *EncryptedStorage) ServeDirectURL(path, name, method string, opt *ServeDirectOptions) (*url.URL, error) {
+	return nil, ErrURLNotSupported
+}
+
+// IterateObjects iterates across the objects and decrypts them
+func (s *EncryptedStorage) IterateObjects(basePath string, iterator func(fullPath string, obj Object) error) error {
+	return s.storage.IterateObjects(basePath, func(fullPath string, raw Object) error {
+		header, dataOffset, err := readEncryptedObjectHeader(raw)
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			if err := s.checkPlaintext(fullPath); err != nil {
+				return err
+			}
+		}
+		obj, err := s.wrapObject(raw, header, dataOffset)
+		if err != nil {
+			return err
+		}
+		return iterator(fullPath, obj)
+	})
+}
+
+// Reencrypt rewrites the object if it hasn't been encrypted or if its data key isn't wrapped by the current master key,
+// it reports whether the object has been rewritten. The plain objects are always read, even if they are not allowed to be served.
+func (s *EncryptedStorage) Reencrypt(path string) (bool, error) {
+	raw, header, dataOffset, err := s.openRaw(path)
+	if err != nil {
+		return false, err
+	}
+	defer raw.Close()
+	currentKeyID, err := s.keyProvider.CurrentKeyID()
+	if err != nil {
+		return false, err
+	}
+	if header != nil && header.KeyID == currentKeyID {
+		return false, nil
+	}
+	obj, err := s.wrapObject(raw, header, dataOffset)
+	if err != nil {
+		return false, err
+	}
+
+	// the content is copied to a temporary file first, some storages can't read and overwrite the same object at the same time
+	tmp, cleanup, err := setting.AppDataTempDir("storage-reencrypt").CreateTempFileRandom("object-*")
+	if err != nil {
+		return false, err
+	}
+	defer cleanup()
+	size, err := io.Copy(tmp, obj)
+	if err != nil {
+		return false, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	if _, err := s.Save(path, tmp, size); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ReencryptObjects re-encrypts all the objects of the storage, it returns the number of the rewritten objects
+func (s *EncryptedStorage) ReencryptObjects(ctx context.Context) (int, error) {
+	// collect the paths first, rewriting the objects while listing them could confuse the iteration of some storages
+	var paths []string
+	if err := s.storage.IterateObjects("", func(fullPath string, _ Object) error {
+		paths = append(paths, fullPath)
+		return ctx.Err()
+	}); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, p := range paths {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		rewritten, err := s.Reencrypt(p)
+		if err != nil {
+			return count, fmt.Errorf("re-encrypt %s: %w", p, err)
+		}
+		if rewritten {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func init() {
+	RegisterKeyProvider("local", NewMasterKeyProvider)
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/setting"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	kmsRequestTimeout = 30 * time.Second
+	// kmsKeyCacheSize is the number of the unwrapped data keys kept in memory, so reading an object again
+	// doesn't need a request to the key service
+	kmsKeyCacheSize = 1000
+)
+
+// kmsKeyProvider wraps the data keys with a remote key service, so the master keys never leave it.
+// The key service identifies its master keys by IDs and keeps the retired keys to unwrap the old data keys,
+// a rotation only changes the current key ID. It has to serve:
+//
+//	GET  /keys/current -> {"key_id": "..."}
+//	POST /wrap   {"plaintext": "<base64>"} -> {"key_id": "...", "ciphertext": "<base64>"}
+//	POST /unwrap {"key_id": "...", "ciphertext": "<base64>"} -> {"plaintext": "<base64>"}
+type kmsKeyProvider struct {
+	ctx      context.Context
+	endpoint string
+	token    string
+	client   *http.Client
+	keyCache *lru.Cache[string, []byte]
+}
+
+// NewKMSKeyProvider returns a key provider using the key service from the config
+func NewKMSKeyProvider(ctx context.Context, cfg *setting.StorageEncryptionConfig) (KeyProvider, error) {
+	if cfg.KMSEndpoint == "" {
+		return nil, errors.New("the endpoint of the key service is required")
+	}
+	keyCache, err := lru.New[string, []byte](kmsKeyCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &kmsKeyProvider{
+		ctx:      ctx,
+		endpoint: strings.TrimSuffix(cfg.KMSEndpoint, "/"),
+		token:    cfg.KMSToken,
+		client:   &http.Client{},
+		keyCache: keyCache,
+	}, nil
+}
+
+type kmsKeyRequest struct {
+	KeyID      string `json:"key_id,omitempty"`
+	Plaintext  []byte `json:"plaintext,omitempty"`
+	Ciphertext []byte `json:"ciphertext,omitempty"`
+}
+
+func (p *kmsKeyProvider) request(method, path string, body, result any) error {
+	var rd io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		rd = bytes.NewReader(data)
+	}
+	ctx, cancel := context.WithTimeout(p.ctx, kmsRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint+path, rd)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("key service %s %s: %s %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (p *kmsKeyProvider) CurrentKeyID() (string, error) {
+	var result kmsKeyRequest
+	if err := p.request(http.MethodGet, "/keys/current", nil, &result); err != nil {
+		return "", err
+	}
+	return result.KeyID, nil
+}
+
+func (p *kmsKeyProvider) WrapKey(dataKey []byte) (string, []byte, error) {
+	var result kmsKeyRequest
+	if err := p.request(http.MethodPost, "/wrap", &kmsKeyRequest{Plaintext: dataKey}, &result); err != nil {
+		return "", nil, err
+	}
+	if result.KeyID == "" || len(result.Ciphertext) == 0 {
+		return "", nil, errors.New("key service returned no wrapped key")
+	}
+	return result.KeyID, result.Ciphertext, nil
+}
+
+func (p *kmsKeyProvider) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	sum := sha256.Sum256(wrapped)
+	cacheKey := keyID + ":" + string(sum[:])
+	if dataKey, ok := p.keyCache.Get(cacheKey); ok {
+		return dataKey, nil
+	}
+
+	var result kmsKeyRequest
+	if err := p.request(http.MethodPost, "/unwrap", &kmsKeyRequest{KeyID: keyID, Ciphertext: wrapped}, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Plaintext) != encryptedDataKeySize {
+		return nil, ErrObjectCorrupted
+	}
+	p.keyCache.Add(cacheKey, result.Plaintext)
+	return result.Plaintext, nil
+}
+
+func init() {
+	RegisterKeyProvider("kms", NewKMSKeyProvider)
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKeyService stands in for a KMS, it keeps the retired master keys until they are deleted
+type fakeKeyService struct {
+	mu        sync.Mutex
+	keys      map[string]cipher.AEAD
+	currentID string
+	token     string
+	unwraps   int
+}
+
+func newFakeKeyService(t *testing.T) (*fakeKeyService, *httptest.Server) {
+	s := &fakeKeyService{keys: map[string]cipher.AEAD{}, token: "kms-token"}
+	s.rotate(t)
+	server := httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func (s *fakeKeyService) rotate(t *testing.T) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	aead, err := newGCM(key)
+	require.NoError(t, err)
+	s.currentID = fmt.Sprintf("key-%d", len(s.keys)+1)
+	s.keys[s.currentID] = aead
+	return s.currentID
+}
+
+func (s *fakeKeyService) deleteKey(keyID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, keyID)
+}
+
+func (s *fakeKeyService) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var req kmsKeyRequest
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var resp kmsKeyRequest
+	switch r.Method + " " + r.URL.Path {
+	case "GET /keys/current":
+		resp.KeyID = s.currentID
+	case "POST /wrap":
+		aead := s.keys[s.currentID]
+		nonce := make([]byte, aead.NonceSize())
+		_, _ = rand.Read(nonce)
+		resp.KeyID = s.currentID
+		resp.Ciphertext = aead.Seal(nonce, nonce, req.Plaintext, nil)
+	case "POST /unwrap":
+		s.unwraps++
+		aead, ok := s.keys[req.KeyID]
+		if !ok {
+			http.Error(w, "unknown key", http.StatusNotFound)
+			return
+		}
+		plain, err := aead.Open(nil, req.Ciphertext[:aead.NonceSize()], req.Ciphertext[aead.NonceSize():], nil)
+		if err != nil {
+			http.Error(w, "invalid ciphertext", http.StatusBadRequest)
+			return
+		}
+		resp.Plaintext = plain
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestEncryptedStorageKMS(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppDataPath, t.TempDir())()
+
+	keyService, server := newFakeKeyService(t)
+	rawStore, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	objStore, err := NewEncryptedStorage(t.Context(), rawStore, &setting.StorageEncryptionConfig{KeyProvider: "kms", KMSEndpoint: server.URL + "/", KMSToken: "kms-token"})
+	require.NoError(t, err)
+
+	readContent := func(t *testing.T, p string) string {
+		obj, err := objStore.Open(p)
+		require.NoError(t, err)
+		defer obj.Close()
+		content, err := io.ReadAll(obj)
+		require.NoError(t, err)
+		return string(content)
+	}
+	objectKeyID := func(t *testing.T, p string) string {
+		raw, err := rawStore.Open(p)
+		require.NoError(t, err)
+		defer raw.Close()
+		header, _, err := readEncryptedObjectHeader(raw)
+		require.NoError(t, err)
+		require.NotNil(t, header)
+		return header.KeyID
+	}
+
+	_, err = objStore.Save("old.txt", strings.NewReader("old content"), -1)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", objectKeyID(t, "old.txt"))
+
+	// the key service rotates its master key, the objects wrapped by the retired key are still readable
+	assert.Equal(t, "key-2", keyService.rotate(t))
+	assert.Equal(t, "old content", readContent(t, "old.txt"))
+	_, err = objStore.Save("new.txt", strings.NewReader("new content"), -1)
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", objectKeyID(t, "new.txt"))
+	assert.Equal(t, "new content", readContent(t, "new.txt"))
+
+	// the unwrapped data keys are cached, reading an object again doesn't need the key service
+	keyService.mu.Lock()
+	unwraps := keyService.unwraps
+	keyService.mu.Unlock()
+	assert.Equal(t, "new content", readContent(t, "new.txt"))
+	keyService.mu.Lock()
+	assert.Equal(t, unwraps, keyService.unwraps)
+	keyService.mu.Unlock()
+
+	count, err := objStore.ReencryptObjects(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "key-2", objectKeyID(t, "old.txt"))
+
+	// the retired key can be deleted after the re-encryption
+	keyService.deleteKey("key-1")
+	assert.Equal(t, "old content", readContent(t, "old.txt"))
+	assert.Equal(t, "new content", readContent(t, "new.txt"))
+
+	// the objects whose data keys aren't cached can't be decrypted without access to the key service
+	_, err = objStore.Save("other.txt", strings.NewReader("other content"), -1)
+	require.NoError(t, err)
+	keyService.mu.Lock()
+	keyService.token = "other-token"
+	keyService.mu.Unlock()
+	_, err = objStore.Open("other.txt")
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestEncryptedStorageKMSContext(t *testing.T) {
+	_, server := newFakeKeyService(t)
+	rawStore, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+
+	// the requests to the key service are canceled with the context of the storage
+	ctx, cancel := context.WithCancel(t.Context())
+	objStore, err := NewEncryptedStorage(ctx, rawStore, &setting.StorageEncryptionConfig{KeyProvider: "kms", KMSEndpoint: server.URL, KMSToken: "kms-token"})
+	require.NoError(t, err)
+	cancel()
+	_, err = objStore.Save("test.txt", strings.NewReader("content"), -1)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMasterKey(t *testing.T) string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func TestEncryptedStorage(t *testing.T) {
+	rawStore, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	objStore, err := NewEncryptedStorage(t.Context(), rawStore, &setting.StorageEncryptionConfig{Enabled: true, KeyProvider: "local", MasterKey: newTestMasterKey(t)})
+	require.NoError(t, err)
+
+	t.Run("StorageIterator", func(t *testing.T) { testStorageIterator(t, objStore) })
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, size := range []int{0, 1, encryptedChunkSize - 1, encryptedChunkSize, 3*encryptedChunkSize + 7} {
+			data := make([]byte, size)
+			_, _ = rand.Read(data)
+
+			// both the known and the unknown size must produce the same object
+			for _, saveSize := range []int64{int64(size), -1} {
+				n, err := objStore.Save("test.bin", bytes.NewReader(data), saveSize)
+				require.NoError(t, err)
+				assert.EqualValues(t, size, n)
+
+				raw, err := rawStore.Open("test.bin")
+				require.NoError(t, err)
+				rawContent, err := io.ReadAll(raw)
+				require.NoError(t, err)
+				assert.NoError(t, raw.Close())
+				assert.True(t, strings.HasPrefix(string(rawContent), encryptedObjectMagic))
+				if size > 16 {
+					assert.NotContains(t, string(rawContent), string(data[:16]))
+				}
+
+				fi, err := objStore.Stat("test.bin")
+				require.NoError(t, err)
+				assert.EqualValues(t, size, fi.Size())
+
+				obj, err := objStore.Open("test.bin")
+				require.NoError(t, err)
+				content, err := io.ReadAll(obj)
+				require.NoError(t, err)
+				assert.Equal(t, data, content)
+				assert.NoError(t, obj.Close())
+			}
+		}
+		assert.NoError(t, objStore.Delete("test.bin"))
+	})
+
+	t.Run("Seek", func(t *testing.T) {
+		data := strings.Repeat("0123456789", encryptedChunkSize/5)
+		_, err := objStore.Save("seek.txt", strings.NewReader(data), -1)
+		require.NoError(t, err)
+		obj, err := objStore.Open("seek.txt")
+		require.NoError(t, err)
+		defer obj.Close()
+
+		offset, err := obj.Seek(encryptedChunkSize-3, io.SeekStart)
+		require.NoError(t, err)
+		assert.EqualValues(t, encryptedChunkSize-3, offset)
+		buf, err := io.ReadAll(io.LimitReader(obj, 6))
+		require.NoError(t, err)
+		assert.Equal(t, data[encryptedChunkSize-3:encryptedChunkSize+3], string(buf))
+
+		offset, err = obj.Seek(-5, io.SeekEnd)
+		require.NoError(t, err)
+		assert.EqualValues(t, len(data)-5, offset)
+		buf, err = io.ReadAll(obj)
+		require.NoError(t, err)
+		assert.Equal(t, "56789", string(buf))
+		assert.NoError(t, objStore.Delete("seek.txt"))
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		_, err := objStore.Save("tampered.txt", strings.NewReader("secret content"), -1)
+		require.NoError(t, err)
+		raw, err := rawStore.Open("tampered.txt")
+		require.NoError(t, err)
+		rawContent, err := io.ReadAll(raw)
+		require.NoError(t, err)
+		assert.NoError(t, raw.Close())
+
+		rawContent[len(rawContent)-1] ^= 1
+		_, err = rawStore.Save("tampered.txt", bytes.NewReader(rawContent), int64(len(rawContent)))
+		require.NoError(t, err)
+		obj, err := objStore.Open("tampered.txt")
+		require.NoError(t, err)
+		_, err = io.ReadAll(obj)
+		assert.ErrorIs(t, err, ErrObjectCorrupted)
+		assert.NoError(t, obj.Close())
+
+		// a truncated object must not be returned as a shorter content
+		_, err = rawStore.Save("tampered.txt", bytes.NewReader(rawContent[:len(rawContent)-16]), -1)
+		require.NoError(t, err)
+		_, err = objStore.Open("tampered.txt")
+		assert.ErrorIs(t, err, ErrObjectCorrupted)
+		assert.NoError(t, objStore.Delete("tampered.txt"))
+	})
+
+	t.Run("ServeDirect", func(t *testing.T) {
+		_, err := objStore.ServeDirectURL("test.txt", "test.txt", "GET", nil)
+		assert.ErrorIs(t, err, ErrURLNotSupported)
+	})
+}
+
+func TestEncryptedStorageReencrypt(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppDataPath, t.TempDir())()
+
+	rawStore, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	oldKey, newKey := newTestMasterKey(t), newTestMasterKey(t)
+
+	// the plain objects saved before the encryption was enabled are still readable
+	_, err = rawStore.Save("plain.txt", strings.NewReader("plain content"), -1)
+	require.NoError(t, err)
+	oldStore, err := NewEncryptedStorage(t.Context(), rawStore, &setting.StorageEncryptionConfig{KeyProvider: "local", MasterKey: oldKey, AllowPlaintext: true})
+	require.NoError(t, err)
+	_, err = oldStore.Save("old.txt", strings.NewReader("old content"), -1)
+	require.NoError(t, err)
+
+	readContent := func(t *testing.T, s ObjectStorage, p string) string {
+		obj, err := s.Open(p)
+		require.NoError(t, err)
+		defer obj.Close()
+		content, err := io.ReadAll(obj)
+		require.NoError(t, err)
+		return string(content)
+	}
+	assert.Equal(t, "plain content", readContent(t, oldStore, "plain.txt"))
+
+	// the master key is rotated
+	newStore, err := NewEncryptedStorage(t.Context(), rawStore, &setting.StorageEncryptionConfig{KeyProvider: "local", MasterKey: newKey, OldMasterKeys: []string{oldKey}, AllowPlaintext: true})
+	require.NoError(t, err)
+	assert.Equal(t, "old content", readContent(t, newStore, "old.txt"))
+
+	count, err := newStore.ReencryptObjects(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	count, err = newStore.ReencryptObjects(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	assert.Equal(t, "plain content", readContent(t, newStore, "plain.txt"))
+	assert.Equal(t, "old content", readContent(t, newStore, "old.txt"))
+
+	// the old master key can be dropped after the re-encryption
+	rotatedStore, err := NewEncryptedStorage(t.Context(), rawStore, &setting.StorageEncryptionConfig{KeyProvider: "local", MasterKey: newKey})
+	require.NoError(t, err)
+	assert.Equal(t, "plain content", readContent(t, rotatedStore, "plain.txt"))
+	assert.Equal(t, "old content", readContent(t, rotatedStore, "old.txt"))
+	_, err = oldStore.Open("old.txt")
+	assert.Error(t, err)
+}
+
+func TestEncryptedStoragePlaintext(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppDataPath, t.TempDir())()
+
+	rawStore, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	_, err = rawStore.Save("plain.txt", strings.NewReader("plain content"), -1)
+	require.NoError(t, err)
+
+	objStore, err := NewEncryptedStorage(t.Context(), rawStore, &setting.StorageEncryptionConfig{KeyProvider: "local", MasterKey: newTestMasterKey(t)})
+	require.NoError(t, err)
+	_, err = objStore.Open("plain.txt")
+	assert.ErrorIs(t, err, ErrObjectNotEncrypted)
+	_, err = objStore.Stat("plain.txt")
+	assert.ErrorIs(t, err, ErrObjectNotEncrypted)
+	err = objStore.IterateObjects("", func(string, Object) error { return nil })
+	assert.ErrorIs(t, err, ErrObjectNotEncrypted)
+
+	// the plain objects can still be encrypted
+	count, err := objStore.ReencryptObjects(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	obj, err := objStore.Open("plain.txt")
+	require.NoError(t, err)
+	defer obj.Close()
+	content, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "plain content", string(content))
+}
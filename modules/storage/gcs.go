@@ -0,0 +1,745 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	gcsScope             = "https://www.googleapis.com/auth/devstorage.read_write"
+	gcsIAMCredentialsAPI = "https://iamcredentials.googleapis.com"
+	gcsSignedURLExpiry   = 5 * time.Minute
+	gcsMaxChunkAttempts  = 3
+)
+
+var (
+	// the chunk size of resumable uploads must be a multiple of 256 KiB
+	gcsUploadChunkSize = 16 * 1024 * 1024
+
+	timeNow = time.Now
+)
+
+// gcsError is the error returned by the JSON API of google cloud storage
+type gcsError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *gcsError) Error() string {
+	return fmt.Sprintf("gcs: %d %s", e.StatusCode, e.Message)
+}
+
+func convertGCSErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	var gcsErr *gcsError
+	if !errors.As(err, &gcsErr) {
+		return err
+	}
+	switch gcsErr.StatusCode {
+	case http.StatusNotFound:
+		return os.ErrNotExist
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return os.ErrPermission
+	}
+	return err
+}
+
+func readGCSError(resp *http.Response) error {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	msg := strings.TrimSpace(string(data))
+	if json.Unmarshal(data, &body) == nil && body.Error.Message != "" {
+		msg = body.Error.Message
+	}
+	if msg == "" {
+		msg = resp.Status
+	}
+	return &gcsError{StatusCode: resp.StatusCode, Message: msg}
+}
+
+// gcsObjectAttrs is the object resource of the JSON API
+type gcsObjectAttrs struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size,string"`
+	Updated time.Time `json:"updated"`
+}
+
+var _ Object = &gcsObject{}
+
+type gcsObject struct {
+	storage *GCSStorage
+	name    string
+	size    int64
+	modTime time.Time
+	offset  int64
+	body    io.ReadCloser
+}
+
+func (g *gcsObject) Read(p []byte) (int, error) {
+	if g.offset >= g.size {
+		return 0, io.EOF
+	}
+	if g.body == nil {
+		body, err := g.storage.download(g.name, g.offset)
+		if err != nil {
+			return 0, convertGCSErr(err)
+		}
+		g.body = body
+	}
+	n, err := g.body.Read(p)
+	g.offset += int64(n)
+	if err == io.EOF && g.offset < g.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (g *gcsObject) closeBody() error {
+	if g.body == nil {
+		return nil
+	}
+	err := g.body.Close()
+	g.body = nil
+	return err
+}
+
+func (g *gcsObject) Close() error {
+	g.offset = 0
+	return g.closeBody()
+}
+
+func (g *gcsObject) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += g.offset
+	case io.SeekEnd:
+		offset = g.size + offset
+	default:
+		return 0, errors.New("Seek: invalid whence")
+	}
+
+	if offset > g.size || offset < 0 {
+		return 0, errors.New("Seek: invalid offset")
+	}
+	if offset != g.offset {
+		// the next read starts a new ranged download
+		_ = g.closeBody()
+		g.offset = offset
+	}
+	return g.offset, nil
+}
+
+func (g *gcsObject) Stat() (os.FileInfo, error) {
+	return &gcsFileInfo{
+		name:    g.name,
+		size:    g.size,
+		modTime: g.modTime,
+	}, nil
+}
+
+type gcsFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (g gcsFileInfo) Name() string {
+	return path.Base(g.name)
+}
+
+func (g gcsFileInfo) Size() int64 {
+	return g.size
+}
+
+func (g gcsFileInfo) ModTime() time.Time {
+	return g.modTime
+}
+
+func (g gcsFileInfo) IsDir() bool {
+	return strings.HasSuffix(g.name, "/")
+}
+
+func (g gcsFileInfo) Mode() os.FileMode {
+	return os.ModePerm
+}
+
+func (g gcsFileInfo) Sys() any {
+	return nil
+}
+
+// gcsSigner signs the V4 signed URLs, either locally with the private key of a service account
+// or remotely with the IAM credentials API for the credentials without a private key (e.g. workload identity)
+type gcsSigner struct {
+	email      string
+	privateKey *rsa.PrivateKey
+	client     *http.Client
+	iamAPI     string
+}
+
+func (s *gcsSigner) sign(ctx context.Context, data []byte) ([]byte, error) {
+	if s.privateKey != nil {
+		sum := sha256.Sum256(data)
+		return rsa.SignPKCS1v15(nil, s.privateKey, crypto.SHA256, sum[:])
+	}
+
+	reqBody, err := json.Marshal(map[string]string{"payload": base64.StdEncoding.EncodeToString(data)})
+	if err != nil {
+		return nil, err
+	}
+	u := s.iamAPI + "/v1/projects/-/serviceAccounts/" + url.PathEscape(s.email) + ":signBlob"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, readGCSError(resp)
+	}
+	var result struct {
+		SignedBlob string `json:"signedBlob"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(result.SignedBlob)
+}
+
+func parseGCSPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not a RSA key")
+	}
+	return rsaKey, nil
+}
+
+// newGCSCredentials loads the credentials file, or falls back to the application default credentials
+// which cover the service account of GCE/GKE (workload identity) and the gcloud user credentials
+func newGCSCredentials(ctx context.Context, config *setting.GCSStorageConfig) (*google.Credentials, error) {
+	if config.CredentialsFile == "" {
+		return google.FindDefaultCredentials(ctx, gcsScope)
+	}
+	data, err := os.ReadFile(config.CredentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	var f struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %w", config.CredentialsFile, err)
+	}
+	return google.CredentialsFromJSONWithType(ctx, data, google.CredentialsType(f.Type), gcsScope)
+}
+
+func newGCSSigner(config *setting.GCSStorageConfig, creds *google.Credentials, client *http.Client) (*gcsSigner, error) {
+	signer := &gcsSigner{email: config.ServiceAccountEmail, client: client, iamAPI: gcsIAMCredentialsAPI}
+	if len(creds.JSON) > 0 {
+		if jwtConfig, err := google.JWTConfigFromJSON(creds.JSON); err == nil {
+			privateKey, err := parseGCSPrivateKey(jwtConfig.PrivateKey)
+			if err != nil {
+				return nil, err
+			}
+			signer.privateKey = privateKey
+			signer.email = jwtConfig.Email
+		}
+	}
+	if signer.email == "" {
+		return nil, errors.New("GCS_SERVICE_ACCOUNT_EMAIL is required to sign the URLs of the credentials without a service account key")
+	}
+	return signer, nil
+}
+
+var _ ObjectStorage = &GCSStorage{}
+
+// GCSStorage uses the JSON API of google cloud storage
+type GCSStorage struct {
+	cfg      *setting.GCSStorageConfig
+	ctx      context.Context
+	endpoint *url.URL
+	client   *http.Client
+	signer   *gcsSigner
+}
+
+// NewGCSStorage returns a google cloud storage
+func NewGCSStorage(ctx context.Context, cfg *setting.Storage) (ObjectStorage, error) {
+	config := cfg.GCSConfig
+
+	log.Info("Creating GCS storage at %s:%s with base path %s", config.Endpoint, config.Bucket, config.BasePath)
+
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid GCS_ENDPOINT %q: %w", config.Endpoint, err)
+	}
+
+	g := &GCSStorage{
+		cfg:      &config,
+		ctx:      ctx,
+		endpoint: endpoint,
+		client:   http.DefaultClient,
+	}
+
+	if !config.Anonymous {
+		creds, err := newGCSCredentials(ctx, &config)
+		if err != nil {
+			return nil, err
+		}
+		g.client = oauth2.NewClient(ctx, creds.TokenSource)
+		if config.ServeDirect {
+			if g.signer, err = newGCSSigner(&config, creds, g.client); err != nil {
+				return nil, err
+			}
+		}
+		if config.ProjectID == "" {
+			config.ProjectID = creds.ProjectID
+		}
+	}
+
+	if err := g.ensureBucket(); err != nil {
+		return nil, convertGCSErr(err)
+	}
+	return g, nil
+}
+
+func (g *GCSStorage) apiURL(elems ...string) string {
+	return g.endpoint.String() + "/storage/v1/b/" + url.PathEscape(g.cfg.Bucket) + strings.Join(elems, "")
+}
+
+func (g *GCSStorage) objectURL(objectName string) string {
+	return g.apiURL("/o/", url.PathEscape(objectName))
+}
+
+func (g *GCSStorage) do(req *http.Request, expectedStatus ...int) (*http.Response, error) {
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range expectedStatus {
+		if resp.StatusCode == status {
+			return resp, nil
+		}
+	}
+	defer resp.Body.Close()
+	return nil, readGCSError(resp)
+}
+
+func (g *GCSStorage) doJSON(method, u string, body, result any) error {
+	var rd io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		rd = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(g.ctx, method, u, rd)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := g.do(req, http.StatusOK, http.StatusNoContent)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (g *GCSStorage) ensureBucket() error {
+	err := g.doJSON(http.MethodGet, g.apiURL(), nil, nil)
+	if err == nil {
+		return nil
+	}
+	var gcsErr *gcsError
+	if !errors.As(err, &gcsErr) {
+		return err
+	}
+	switch gcsErr.StatusCode {
+	case http.StatusForbidden:
+		// the credentials may only be allowed to access the objects
+		log.Warn("Unable to check the GCS bucket %s: %v", g.cfg.Bucket, err)
+		return nil
+	case http.StatusNotFound:
+	default:
+		return err
+	}
+
+	u := g.endpoint.String() + "/storage/v1/b?project=" + url.QueryEscape(g.cfg.ProjectID)
+	err = g.doJSON(http.MethodPost, u, map[string]string{"name": g.cfg.Bucket, "location": g.cfg.Location}, nil)
+	if err != nil && errors.As(err, &gcsErr) && gcsErr.StatusCode == http.StatusConflict {
+		return nil // created by another instance in the meantime
+	}
+	return err
+}
+
+func (g *GCSStorage) buildGCSPath(p string) string {
+	return buildObjectStorePath(g.cfg.BasePath, p)
+}
+
+func (g *GCSStorage) getAttrs(objectName string) (*gcsObjectAttrs, error) {
+	var attrs gcsObjectAttrs
+	if err := g.doJSON(http.MethodGet, g.objectURL(objectName), nil, &attrs); err != nil {
+		return nil, err
+	}
+	return &attrs, nil
+}
+
+func (g *GCSStorage) download(objectName string, offset int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(g.ctx, http.MethodGet, g.objectURL(objectName)+"?alt=media", nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := g.do(req, http.StatusOK, http.StatusPartialContent)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Open opens a file
+func (g *GCSStorage) Open(path string) (Object, error) {
+	objectName := g.buildGCSPath(path)
+	attrs, err := g.getAttrs(objectName)
+	if err != nil {
+		return nil, convertGCSErr(err)
+	}
+	return &gcsObject{
+		storage: g,
+		name:    objectName,
+		size:    attrs.Size,
+		modTime: attrs.Updated,
+	}, nil
+}
+
+// Save saves a file to google cloud storage, the content is sent within one request if it fits into one chunk
+// otherwise a resumable upload is used
+func (g *GCSStorage) Save(path string, r io.Reader, size int64) (int64, error) {
+	objectName := g.buildGCSPath(path)
+
+	bufSize := gcsUploadChunkSize
+	if size >= 0 && size < int64(gcsUploadChunkSize) {
+		bufSize = int(size) + 1 // read one byte more to detect the end of the reader
+	}
+	rd := bufio.NewReaderSize(r, bufSize)
+	buf := make([]byte, bufSize)
+
+	n, last, err := readFullChunk(rd, buf)
+	if err != nil {
+		return 0, err
+	}
+	if last {
+		u := g.endpoint.String() + "/upload/storage/v1/b/" + url.PathEscape(g.cfg.Bucket) + "/o?uploadType=media&name=" + url.QueryEscape(objectName)
+		req, err := http.NewRequestWithContext(g.ctx, http.MethodPost, u, bytes.NewReader(buf[:n]))
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Content-Type", "application/octet-stream")
+		resp, err := g.do(req, http.StatusOK)
+		if err != nil {
+			return 0, convertGCSErr(err)
+		}
+		resp.Body.Close()
+		return int64(n), nil
+	}
+
+	session, err := g.startResumableUpload(objectName)
+	if err != nil {
+		return 0, convertGCSErr(err)
+	}
+	var written int64
+	for {
+		// GCS may persist only a part of a chunk, the rest of it is sent again from the persisted size
+		for chunk, noProgress := buf[:n], 0; ; {
+			persisted, done, err := g.uploadChunk(session, chunk, written, last)
+			if err != nil {
+				return written, convertGCSErr(err)
+			}
+			if persisted < written || persisted > written+int64(len(chunk)) {
+				return written, fmt.Errorf("gcs: unexpected persisted size %d of the chunk at %d", persisted, written)
+			}
+			if persisted > written || done {
+				noProgress = 0
+			} else if noProgress++; noProgress >= gcsMaxChunkAttempts {
+				return written, fmt.Errorf("gcs: the chunk at %d isn't persisted", written)
+			}
+			chunk = chunk[persisted-written:]
+			written = persisted
+			if done || (!last && len(chunk) == 0) {
+				break
+			}
+		}
+		if last {
+			return written, nil
+		}
+		if n, last, err = readFullChunk(rd, buf); err != nil {
+			return written, err
+		}
+	}
+}
+
+func (g *GCSStorage) startResumableUpload(objectName string) (string, error) {
+	u := g.endpoint.String() + "/upload/storage/v1/b/" + url.PathEscape(g.cfg.Bucket) + "/o?uploadType=resumable&name=" + url.QueryEscape(objectName)
+	req, err := http.NewRequestWithContext(g.ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := g.do(req, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	session := resp.Header.Get("Location")
+	if session == "" {
+		return "", errors.New("gcs: no resumable upload session returned")
+	}
+	return session, nil
+}
+
+// uploadChunk sends a chunk at the offset of the upload session. It returns the size of the object persisted by GCS,
+// and whether the upload is done, which is only the case after the last chunk has been persisted completely.
+func (g *GCSStorage) uploadChunk(session string, chunk []byte, offset int64, last bool) (persisted int64, done bool, _ error) {
+	req, err := http.NewRequestWithContext(g.ctx, http.MethodPut, session, bytes.NewReader(chunk))
+	if err != nil {
+		return 0, false, err
+	}
+	total := "*"
+	if last {
+		total = strconv.FormatInt(offset+int64(len(chunk)), 10)
+	}
+	if len(chunk) == 0 {
+		req.Header.Set("Content-Range", "bytes */"+total)
+	} else {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%s", offset, offset+int64(len(chunk))-1, total))
+	}
+
+	expected := []int{http.StatusPermanentRedirect} // "308 Resume Incomplete"
+	if last {
+		expected = append(expected, http.StatusOK, http.StatusCreated)
+	}
+	resp, err := g.do(req, expected...)
+	if err != nil {
+		return 0, false, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPermanentRedirect {
+		return offset + int64(len(chunk)), true, nil
+	}
+	persisted, err = parseGCSPersistedSize(resp.Header.Get("Range"))
+	return persisted, false, err
+}
+
+// parseGCSPersistedSize parses the "bytes=0-N" range of a "308 Resume Incomplete" response,
+// there is no range if nothing has been persisted yet
+func parseGCSPersistedSize(rangeHeader string) (int64, error) {
+	if rangeHeader == "" {
+		return 0, nil
+	}
+	end, ok := strings.CutPrefix(rangeHeader, "bytes=0-")
+	if !ok {
+		return 0, fmt.Errorf("gcs: invalid range %q of the upload", rangeHeader)
+	}
+	n, err := strconv.ParseInt(end, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("gcs: invalid range %q of the upload", rangeHeader)
+	}
+	return n + 1, nil
+}
+
+// Stat returns the stat information of the object
+func (g *GCSStorage) Stat(path string) (os.FileInfo, error) {
+	attrs, err := g.getAttrs(g.buildGCSPath(path))
+	if err != nil {
+		return nil, convertGCSErr(err)
+	}
+	return &gcsFileInfo{
+		name:    attrs.Name,
+		size:    attrs.Size,
+		modTime: attrs.Updated,
+	}, nil
+}
+
+// Delete delete a file
+func (g *GCSStorage) Delete(path string) error {
+	err := g.doJSON(http.MethodDelete, g.objectURL(g.buildGCSPath(path)), nil, nil)
+	if errors.Is(convertGCSErr(err), os.ErrNotExist) {
+		return nil
+	}
+	return convertGCSErr(err)
+}
+
+// gcsEscape escapes the value as required by the canonical request of the V4 signature
+func gcsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// ServeDirectURL gets the V4 signed URL of the object, see https://cloud.google.com/storage/docs/access-control/signed-urls
+func (g *GCSStorage) ServeDirectURL(storePath, name, method string, reqParams *ServeDirectOptions) (*url.URL, error) {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut:
+	default:
+		return nil, ErrURLNotSupported
+	}
+
+	param := prepareServeDirectOptions(reqParams, name)
+
+	segments := strings.Split(g.buildGCSPath(storePath), "/")
+	for i := range segments {
+		segments[i] = gcsEscape(segments[i])
+	}
+	canonicalPath := g.endpoint.Path + "/" + gcsEscape(g.cfg.Bucket) + "/" + strings.Join(segments, "/")
+
+	query := url.Values{}
+	if method != http.MethodPut {
+		query.Set("response-content-type", param.ContentType)
+		query.Set("response-content-disposition", param.ContentDisposition)
+	}
+
+	if g.signer == nil {
+		if !g.cfg.Anonymous {
+			return nil, ErrURLNotSupported
+		}
+		// anonymous access (e.g. an emulator) doesn't need to sign the URL
+		return url.Parse(g.endpoint.Scheme + "://" + g.endpoint.Host + canonicalPath + "?" + strings.ReplaceAll(query.Encode(), "+", "%20"))
+	}
+
+	now := timeNow().UTC()
+	date := now.Format("20060102")
+	timestamp := now.Format("20060102T150405Z")
+	credentialScope := date + "/auto/storage/goog4_request"
+
+	query.Set("X-Goog-Algorithm", "GOOG4-RSA-SHA256")
+	query.Set("X-Goog-Credential", g.signer.email+"/"+credentialScope)
+	query.Set("X-Goog-Date", timestamp)
+	query.Set("X-Goog-Expires", strconv.Itoa(int(gcsSignedURLExpiry.Seconds())))
+	query.Set("X-Goog-SignedHeaders", "host")
+	canonicalQuery := strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalPath,
+		canonicalQuery,
+		"host:" + g.endpoint.Host,
+		"",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"GOOG4-RSA-SHA256",
+		timestamp,
+		credentialScope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signature, err := g.signer.sign(g.ctx, []byte(stringToSign))
+	if err != nil {
+		return nil, convertGCSErr(err)
+	}
+
+	return url.Parse(g.endpoint.Scheme + "://" + g.endpoint.Host + canonicalPath + "?" + canonicalQuery + "&X-Goog-Signature=" + hex.EncodeToString(signature))
+}
+
+// IterateObjects iterates across the objects in the google cloud storage
+func (g *GCSStorage) IterateObjects(dirName string, fn func(path string, obj Object) error) error {
+	basePrefix := buildObjectStorePathPrefix(g.cfg.BasePath, "")
+	dirPrefix := buildObjectStorePathPrefix(g.cfg.BasePath, dirName)
+
+	callback := func(object *gcsObject, objPath string) error {
+		defer object.Close()
+		return fn(objPath, object)
+	}
+
+	var pageToken string
+	for {
+		var result struct {
+			Items         []*gcsObjectAttrs `json:"items"`
+			NextPageToken string            `json:"nextPageToken"`
+		}
+		query := url.Values{}
+		query.Set("prefix", dirPrefix)
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		if err := g.doJSON(http.MethodGet, g.apiURL("/o?", query.Encode()), nil, &result); err != nil {
+			return convertGCSErr(err)
+		}
+		for _, attrs := range result.Items {
+			objPath := strings.TrimPrefix(attrs.Name, basePrefix)
+			object := &gcsObject{
+				storage: g,
+				name:    attrs.Name,
+				size:    attrs.Size,
+				modTime: attrs.Updated,
+			}
+			if err := callback(object, objPath); err != nil {
+				return convertGCSErr(err)
+			}
+		}
+		if result.NextPageToken == "" {
+			return nil
+		}
+		pageToken = result.NextPageToken
+	}
+}
+
+func init() {
+	RegisterStorageType(setting.GCSStorageType, NewGCSStorage)
+}
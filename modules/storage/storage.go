@@ -4,6 +4,7 @@
 package storage
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -141,6 +142,22 @@ func SaveFrom(objStorage ObjectStorage, path string, callback func(w io.Writer)
 	return err
 }
 
+// readFullChunk fills the buffer and reports whether the reader has been drained
+func readFullChunk(rd *bufio.Reader, buf []byte) (int, bool, error) {
+	n, err := io.ReadFull(rd, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return n, true, nil
+	} else if err != nil {
+		return n, false, err
+	}
+	if _, err := rd.Peek(1); errors.Is(err, io.EOF) {
+		return n, true, nil
+	} else if err != nil {
+		return n, false, err
+	}
+	return n, false, nil
+}
+
 func buildObjectStorePath(base, p string) string {
 	p = strings.TrimPrefix(util.PathJoinRelX(base, p), "/") // object store doesn't use slash for root path
 	if p == "." {
@@ -173,6 +190,9 @@ var (
 	// RepoArchives represents repository archives storage
 	RepoArchives ObjectStorage = uninitializedStorage
 
+	// RepoBackups represents the storage of the scheduled repository backups
+	RepoBackups ObjectStorage = uninitializedStorage
+
 	// Packages represents packages storage
 	Packages ObjectStorage = uninitializedStorage
 
@@ -180,6 +200,8 @@ var (
 	Actions ObjectStorage = uninitializedStorage
 	// ActionsArtifacts Artifacts represents actions artifacts storage
 	ActionsArtifacts ObjectStorage = uninitializedStorage
+	// ActionsCache represents the storage of the entries of the actions cache server
+	ActionsCache ObjectStorage = uninitializedStorage
 )
 
 // Init init the storage
@@ -190,6 +212,7 @@ func Init() error {
 		initRepoAvatars,
 		initLFS,
 		initRepoArchives,
+		initRepoBackups,
 		initPackages,
 		initActions,
 	} {
@@ -210,7 +233,12 @@ func NewStorage(typStr Type, cfg *setting.Storage) (ObjectStorage, error) {
 		return nil, fmt.Errorf("Unsupported storage type: %s", typStr)
 	}
 
-	return fn(context.Background(), cfg)
+	ctx := context.Background()
+	objStorage, err := fn(ctx, cfg)
+	if err != nil || !cfg.Encryption.Enabled {
+		return objStorage, err
+	}
+	return NewEncryptedStorage(ctx, objStorage, &cfg.Encryption)
 }
 
 func initAvatars() (err error) {
@@ -251,6 +279,12 @@ func initRepoArchives() (err error) {
 	return err
 }
 
+func initRepoBackups() (err error) {
+	log.Info("Initialising Repository Backup storage with type: %s", setting.RepoBackup.Storage.Type)
+	RepoBackups, err = NewStorage(setting.RepoBackup.Storage.Type, setting.RepoBackup.Storage)
+	return err
+}
+
 func initPackages() (err error) {
 	if !setting.Packages.Enabled {
 		Packages = discardStorage("Packages isn't enabled")
@@ -265,6 +299,7 @@ func initActions() (err error) {
 	if !setting.Actions.Enabled {
 		Actions = discardStorage("Actions isn't enabled")
 		ActionsArtifacts = discardStorage("ActionsArtifacts isn't enabled")
+		ActionsCache = discardStorage("ActionsCache isn't enabled")
 		return nil
 	}
 	log.Info("Initialising Actions storage with type: %s", setting.Actions.LogStorage.Type)
@@ -272,6 +307,10 @@ func initActions() (err error) {
 		return err
 	}
 	log.Info("Initialising ActionsArtifacts storage with type: %s", setting.Actions.ArtifactStorage.Type)
-	ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage)
+	if ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage); err != nil {
+		return err
+	}
+	log.Info("Initialising ActionsCache storage with type: %s", setting.Actions.CacheStorage.Type)
+	ActionsCache, err = NewStorage(setting.Actions.CacheStorage.Type, setting.Actions.CacheStorage)
 	return err
 }
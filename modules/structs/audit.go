@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// AuditEvent represents an entry of the audit log
+type AuditEvent struct {
+	// The unique identifier of the event
+	ID int64 `json:"id"`
+	// The kind of change, e.g. "protected_branch.update"
+	Action string `json:"action"`
+	// The ID of the user who made the change, 0 if it was made by the system
+	ActorID int64 `json:"actor_id"`
+	// The name of the user who made the change at the time of the change
+	ActorName string `json:"actor_name"`
+	// The IP address the change was made from, if it was made by a request
+	IPAddress string `json:"ip_address"`
+	// The ID of the user or organization owning the target, 0 for instance level events
+	OwnerID int64 `json:"owner_id"`
+	// The ID of the repository of the target, if any
+	RepoID int64 `json:"repo_id"`
+	// The kind of object that has been changed
+	TargetType string `json:"target_type"`
+	// The ID of the object that has been changed
+	TargetID int64 `json:"target_id"`
+	// The name of the object that has been changed at the time of the change
+	TargetName string `json:"target_name"`
+	// The JSON encoded state of the target before the change, if any
+	Before string `json:"before,omitempty"`
+	// The JSON encoded state of the target after the change, if any
+	After string `json:"after,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// CodeNavigationIndex represents a SCIP index uploaded for a commit of a repository
+type CodeNavigationIndex struct {
+	ID        int64  `json:"id"`
+	CommitSHA string `json:"commit_sha"`
+	// The directory of the repository the paths of the index are relative to
+	Root        string `json:"root"`
+	ToolName    string `json:"tool_name"`
+	ToolVersion string `json:"tool_version"`
+	// The number of files covered by the index
+	Documents int `json:"documents"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CodeNavigationLocation represents a line of a file
+type CodeNavigationLocation struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CodeNavigation represents the definitions and references of the symbol at a position in a file
+type CodeNavigation struct {
+	// Whether the result comes from an uploaded index or from a text search,
+	// a text search can't tell definitions from references
+	Precise     bool   `json:"precise"`
+	Symbol      string `json:"symbol"`
+	DisplayName string `json:"display_name"`
+	// Markdown documentation of the symbol
+	Documentation []string                  `json:"documentation"`
+	Definitions   []*CodeNavigationLocation `json:"definitions"`
+	References    []*CodeNavigationLocation `json:"references"`
+}
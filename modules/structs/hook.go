@@ -43,17 +43,43 @@ type Hook struct {
 	Created time.Time `json:"created_at"`
 }
 
+// HookDelivery represents a delivery of a webhook
+type HookDelivery struct {
+	// The unique identifier of the delivery
+	ID int64 `json:"id"`
+	// The UUID sent with the delivery
+	UUID string `json:"uuid"`
+	// The event type of the delivery
+	Event string `json:"event"`
+	// The number of the attempt, 1 for the first delivery of an event
+	Attempt int `json:"attempt"`
+	// Whether the delivery succeeded
+	Succeeded bool `json:"succeeded"`
+	// The HTTP status code answered by the receiver, 0 if there was no response
+	StatusCode int `json:"status_code"`
+	// swagger:strfmt date-time
+	// The date and time of the delivery
+	Delivered time.Time `json:"delivered_at"`
+}
+
+// ReplayHookDeliveriesOption options for replaying the dead letters of a webhook
+type ReplayHookDeliveriesOption struct {
+	// The IDs of the dead letters to replay, all of them are replayed if empty
+	IDs []int64 `json:"ids"`
+}
+
 // HookList represents a list of API hook.
 type HookList []*Hook
 
 // CreateHookOptionConfig has all config options in it
 // required are "content_type" and "url" Required
+// the custom webhooks also take "payload_template", "payload_content_type", "headers" and "signature_scheme"
 type CreateHookOptionConfig map[string]string
 
 // CreateHookOption options when create a hook
 type CreateHookOption struct {
 	// required: true
-	// enum: ["dingtalk","discord","gitea","gogs","msteams","slack","telegram","feishu","wechatwork","packagist"]
+	// enum: ["dingtalk","discord","gitea","gogs","msteams","slack","telegram","feishu","wechatwork","packagist","custom"]
 	// The type of the webhook to create
 	Type string `json:"type" binding:"Required"`
 	// required: true
@@ -652,6 +678,40 @@ func (p *WorkflowRunPayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
 
+// HookMergeGroupChecksRequested is the only action of a merge group event
+const HookMergeGroupChecksRequested = "checks_requested"
+
+// MergeGroup represents a speculative merge result of a merge queue
+type MergeGroup struct {
+	// The SHA of the speculative merge commit
+	HeadSHA string `json:"head_sha"`
+	// The full ref holding the speculative merge commit
+	HeadRef string `json:"head_ref"`
+	// The SHA of the commit the merge group is based on
+	BaseSHA string `json:"base_sha"`
+	// The full ref of the branch the merge group will be merged into
+	BaseRef string `json:"base_ref"`
+	// The last pull request added to the merge group
+	PullRequest *PullRequest `json:"pull_request,omitempty"`
+}
+
+// MergeGroupPayload represents a payload information of merge group event.
+type MergeGroupPayload struct {
+	// The action performed on the merge group
+	Action string `json:"action"`
+	// The merge group whose checks are requested
+	MergeGroup *MergeGroup `json:"merge_group"`
+	// The repository of the merge queue
+	Repo *Repository `json:"repository"`
+	// The user who added the pull request to the merge queue
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *MergeGroupPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
 // WorkflowJobPayload represents a payload information of workflow job event.
 type WorkflowJobPayload struct {
 	// The action performed on the workflow job
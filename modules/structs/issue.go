@@ -104,6 +104,11 @@ type CreateIssueOption struct {
 	// list of project ids
 	Projects []int64 `json:"projects"`
 	Closed   bool    `json:"closed"`
+	// path of the issue form template in the default branch the issue is created with,
+	// the body is then rendered from the form values
+	Template string `json:"template"`
+	// values of the fields of the issue form template, keyed by field id
+	FormValues map[string]any `json:"form_values"`
 }
 
 // EditIssueOption options for editing an issue
@@ -143,7 +148,8 @@ type IssueDeadline struct {
 	Deadline *time.Time `json:"due_date"`
 }
 
-// IssueFormFieldType defines issue form field type, can be "markdown", "textarea", "input", "dropdown" or "checkboxes"
+// IssueFormFieldType defines issue form field type, can be "markdown", "textarea", "input", "dropdown", "checkboxes",
+// "user", "label", "date", "number" or "file"
 //
 // swagger:enum IssueFormFieldType
 type IssueFormFieldType string
@@ -154,6 +160,11 @@ const (
 	IssueFormFieldTypeInput      IssueFormFieldType = "input"
 	IssueFormFieldTypeDropdown   IssueFormFieldType = "dropdown"
 	IssueFormFieldTypeCheckboxes IssueFormFieldType = "checkboxes"
+	IssueFormFieldTypeUser       IssueFormFieldType = "user"
+	IssueFormFieldTypeLabel      IssueFormFieldType = "label"
+	IssueFormFieldTypeDate       IssueFormFieldType = "date"
+	IssueFormFieldTypeNumber     IssueFormFieldType = "number"
+	IssueFormFieldTypeFile       IssueFormFieldType = "file"
 )
 
 // IssueFormField represents a form field
@@ -191,6 +202,17 @@ const (
 	IssueFormFieldVisibleContent IssueFormFieldVisible = "content"
 )
 
+// IssueFormValues represents the values submitted through the issue form an issue has been created with
+// swagger:model
+type IssueFormValues struct {
+	// path of the issue form template
+	Template string `json:"template"`
+	// values of the fields, keyed by field id
+	Values map[string]any `json:"values"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
 // IssueTemplate represents an issue template for a repository
 // swagger:model
 type IssueTemplate struct {
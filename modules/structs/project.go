@@ -119,3 +119,131 @@ type MoveProjectIssueOption struct {
 	// the rest, equal values are ordered newest first.
 	Sorting *int64 `json:"sorting,omitempty"`
 }
+
+// ProjectAutomationEvent is the event which triggers a project automation
+//
+// swagger:enum ProjectAutomationEvent
+type ProjectAutomationEvent string
+
+const (
+	ProjectAutomationEventIssueOpened       ProjectAutomationEvent = "issue_opened"
+	ProjectAutomationEventIssueLabeled      ProjectAutomationEvent = "issue_labeled"
+	ProjectAutomationEventIssueClosed       ProjectAutomationEvent = "issue_closed"
+	ProjectAutomationEventIssueReopened     ProjectAutomationEvent = "issue_reopened"
+	ProjectAutomationEventPullRequestMerged ProjectAutomationEvent = "pull_request_merged"
+)
+
+// ProjectAutomation represents a rule which moves the issues of a project to a column when an issue event happens
+// swagger:model
+type ProjectAutomation struct {
+	ID        int64 `json:"id"`
+	ProjectID int64 `json:"project_id"`
+	// Event which triggers the automation
+	Event ProjectAutomationEvent `json:"event"`
+	// Only issues with this label are moved, 0 matches all issues. For "issue_labeled" it is the label which has been added.
+	LabelID int64 `json:"label_id"`
+	// Column the issues are moved to
+	ColumnID int64 `json:"column_id"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateProjectAutomationOption represents options for creating a project automation
+// swagger:model
+type CreateProjectAutomationOption struct {
+	// Event which triggers the automation. "issue_opened" and "issue_labeled" also add the issues
+	// to the project, the other events only move the issues which are already in the project.
+	// required: true
+	Event ProjectAutomationEvent `json:"event" binding:"Required"`
+	// Only issues with this label are moved, required for "issue_labeled"
+	LabelID int64 `json:"label_id"`
+	// required: true
+	ColumnID int64 `json:"column_id" binding:"Required"`
+}
+
+// EditProjectAutomationOption represents options for editing a project automation
+// swagger:model
+type EditProjectAutomationOption struct {
+	Event    *ProjectAutomationEvent `json:"event,omitempty"`
+	LabelID  *int64                  `json:"label_id,omitempty"`
+	ColumnID *int64                  `json:"column_id,omitempty"`
+}
+
+// ProjectFieldOption represents an option of a single select project field or an iteration of an iteration field
+// swagger:model
+type ProjectFieldOption struct {
+	// Set it to keep an existing option when editing the field, new options get an ID
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// First day of an iteration, like 2006-01-02
+	StartDate string `json:"start_date,omitempty"`
+	// Last day of an iteration, like 2006-01-02
+	EndDate string `json:"end_date,omitempty"`
+}
+
+// ProjectFieldType is the type of the values of a project field
+//
+// swagger:enum ProjectFieldType
+type ProjectFieldType string
+
+const (
+	ProjectFieldTypeText         ProjectFieldType = "text"
+	ProjectFieldTypeNumber       ProjectFieldType = "number"
+	ProjectFieldTypeDate         ProjectFieldType = "date"
+	ProjectFieldTypeSingleSelect ProjectFieldType = "single_select"
+	ProjectFieldTypeIteration    ProjectFieldType = "iteration"
+)
+
+// ProjectField represents a typed custom field of a project
+// swagger:model
+type ProjectField struct {
+	ID        int64                 `json:"id"`
+	ProjectID int64                 `json:"project_id"`
+	Name      string                `json:"name"`
+	Type      ProjectFieldType      `json:"type"`
+	Options   []*ProjectFieldOption `json:"options"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateProjectFieldOption represents options for creating a project field
+// swagger:model
+type CreateProjectFieldOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// required: true
+	Type ProjectFieldType `json:"type" binding:"Required"`
+	// Options of a single select field or iterations of an iteration field
+	Options []*ProjectFieldOption `json:"options"`
+}
+
+// EditProjectFieldOption represents options for editing a project field, the type can't be changed
+// swagger:model
+type EditProjectFieldOption struct {
+	Name *string `json:"name,omitempty" binding:"OmitEmpty;MaxSize(255)"`
+	// Replaces the options, the options without an ID are added and the values of the missing options are cleared
+	Options *[]*ProjectFieldOption `json:"options,omitempty"`
+}
+
+// ProjectIssueFieldValue represents the value of a project field for an issue of the project
+// swagger:model
+type ProjectIssueFieldValue struct {
+	FieldID int64 `json:"field_id"`
+	// The value is a number for number fields, a date like 2006-01-02 for date fields
+	// and the option ID for single select and iteration fields
+	Value string `json:"value"`
+	// Name of the chosen option of single select and iteration fields
+	OptionName string `json:"option_name,omitempty"`
+}
+
+// SetProjectIssueFieldValueOption represents options for setting the value of a project field for an issue
+// swagger:model
+type SetProjectIssueFieldValueOption struct {
+	// The value is a number for number fields, a date like 2006-01-02 for date fields
+	// and the option ID for single select and iteration fields. An empty value clears it.
+	Value string `json:"value"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of a protected branch
+type MergeQueueEntry struct {
+	ID int64 `json:"id"`
+	// index of the queued pull request
+	PullRequestIndex int64 `json:"pull_request_index"`
+	// 1-based position in the merge queue, 0 if the entry has failed
+	Position   int    `json:"position"`
+	BaseBranch string `json:"base_branch"`
+	// the state of the entry
+	// enum: waiting,checking,failed
+	Status     string `json:"status"`
+	MergeStyle string `json:"merge_style"`
+	// the user who added the pull request to the merge queue
+	Doer *User `json:"doer"`
+	// the commit the pull request has been speculatively merged onto
+	BaseCommitID string `json:"base_commit_id,omitempty"`
+	// the speculative merge result whose status checks decide whether the pull request lands
+	MergeCommitID string `json:"merge_commit_id,omitempty"`
+	FailReason    string `json:"fail_reason,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
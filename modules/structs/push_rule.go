@@ -0,0 +1,39 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// PushRule represents the rules the commits pushed to a repository or to the repositories of an organization must follow
+type PushRule struct {
+	// A regular expression the message of every commit must match
+	CommitMessagePattern string `json:"commit_message_pattern"`
+	// Whether every commit message must have a Signed-off-by trailer
+	RequireSignedOffBy bool `json:"require_signed_off_by"`
+	// Whether the author email of every commit must be a verified email address of a user
+	RequireVerifiedAuthorEmail bool `json:"require_verified_author_email"`
+	// The maximum size in bytes of a file added or changed by a commit, 0 means no limit
+	MaxBlobSize int64 `json:"max_blob_size"`
+	// Semicolon separated glob patterns of paths which must not be added or changed
+	ForbiddenFilePatterns string `json:"forbidden_file_patterns"`
+	// Semicolon separated file extensions which must not be added or changed
+	ForbiddenFileExtensions string `json:"forbidden_file_extensions"`
+	// Semicolon separated glob patterns of branches which only accept commits signed with a verified key
+	SignedCommitBranchPatterns string `json:"signed_commit_branch_patterns"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// SetPushRuleOption options for setting a push rule, omitted fields are reset to their defaults
+type SetPushRuleOption struct {
+	CommitMessagePattern       string `json:"commit_message_pattern"`
+	RequireSignedOffBy         bool   `json:"require_signed_off_by"`
+	RequireVerifiedAuthorEmail bool   `json:"require_verified_author_email"`
+	MaxBlobSize                int64  `json:"max_blob_size"`
+	ForbiddenFilePatterns      string `json:"forbidden_file_patterns"`
+	ForbiddenFileExtensions    string `json:"forbidden_file_extensions"`
+	SignedCommitBranchPatterns string `json:"signed_commit_branch_patterns"`
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// QuotaLimits represents the storage limits in bytes, -1 means no limit
+type QuotaLimits struct {
+	// The limit of the sum of all kinds of content
+	Total int64 `json:"total"`
+	// The limit of the git repositories
+	Git int64 `json:"git"`
+	// The limit of the LFS objects
+	LFS int64 `json:"lfs"`
+	// The limit of the issue and release attachments
+	Attachments int64 `json:"attachments"`
+	// The limit of the Actions artifacts
+	Artifacts int64 `json:"artifacts"`
+	// The limit of the packages
+	Packages int64 `json:"packages"`
+}
+
+// QuotaUsed represents the used storage in bytes
+type QuotaUsed struct {
+	Total       int64 `json:"total"`
+	Git         int64 `json:"git"`
+	LFS         int64 `json:"lfs"`
+	Attachments int64 `json:"attachments"`
+	Artifacts   int64 `json:"artifacts"`
+	Packages    int64 `json:"packages"`
+}
+
+// QuotaGroup represents a named set of storage limits assigned to users and organizations
+type QuotaGroup struct {
+	ID     int64        `json:"id"`
+	Name   string       `json:"name"`
+	Limits *QuotaLimits `json:"limits"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// QuotaInfo represents the storage usage and the effective limits of a user or an organization
+type QuotaInfo struct {
+	// Whether quotas are enforced on this instance
+	Enabled bool       `json:"enabled"`
+	Used    *QuotaUsed `json:"used"`
+	// The most permissive limits of the groups
+	Limits *QuotaLimits `json:"limits"`
+	// The names of the groups the limits result from, these are the default groups if none is assigned
+	Groups []string `json:"groups"`
+}
+
+// CreateQuotaGroupOption options for creating a quota group
+type CreateQuotaGroupOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// Limits which are omitted default to no limit
+	Limits *QuotaLimitsOption `json:"limits"`
+}
+
+// EditQuotaGroupOption options for editing a quota group, omitted fields are left unchanged
+type EditQuotaGroupOption struct {
+	Name *string `json:"name" binding:"OmitEmpty;MaxSize(255)"`
+	// Limits which are omitted are left unchanged
+	Limits *QuotaLimitsOption `json:"limits"`
+}
+
+// QuotaLimitsOption represents the limits to set in bytes, -1 means no limit
+type QuotaLimitsOption struct {
+	Total       *int64 `json:"total"`
+	Git         *int64 `json:"git"`
+	LFS         *int64 `json:"lfs"`
+	Attachments *int64 `json:"attachments"`
+	Artifacts   *int64 `json:"artifacts"`
+	Packages    *int64 `json:"packages"`
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// RepoBackups represents the backups of a repository
+type RepoBackups struct {
+	// The ID of the backed up repository, it is kept after the repository is deleted
+	RepoID int64 `json:"repo_id"`
+	// The owner name of the repository when the last backup was created
+	OwnerName string `json:"owner_name"`
+	// The name of the repository when the last backup was created
+	RepoName string `json:"repo_name"`
+	// Whether the backed up repository still exists
+	RepoExists bool `json:"repo_exists"`
+	// The backups ordered from the oldest to the latest
+	Backups []*RepoBackup `json:"backups"`
+}
+
+// RepoBackup represents a single backup of a repository
+type RepoBackup struct {
+	// The ID of the backup
+	ID string `json:"id"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+	// Whether the backup only contains the changes since its parent
+	Incremental bool `json:"incremental"`
+	// The ID of the backup which this incremental backup depends on
+	Parent string `json:"parent,omitempty"`
+	// The size of the backup in bytes
+	Size int64 `json:"size"`
+}
+
+// RestoreRepoBackupOption options for restoring a repository from its backups
+type RestoreRepoBackupOption struct {
+	// The ID of the backup to restore, the latest backup is restored if it is empty
+	BackupID string `json:"backup_id"`
+	// The owner of the restored repository, defaults to the owner when the backup was created
+	Owner string `json:"owner"`
+	// The name of the restored repository, defaults to the name when the backup was created
+	RepoName string `json:"repo_name" binding:"OmitEmpty;AlphaDashDot;MaxSize(100)"`
+}
@@ -69,6 +69,8 @@ type BranchProtection struct {
 	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
 	BlockAdminMergeOverride       bool     `json:"block_admin_merge_override"`
+	EnableMergeQueue              bool     `json:"enable_merge_queue"`
+	MergeQueueMaxGroupSize        int64    `json:"merge_queue_max_group_size"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
@@ -113,6 +115,8 @@ type CreateBranchProtectionOption struct {
 	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
 	BlockAdminMergeOverride       bool     `json:"block_admin_merge_override"`
+	EnableMergeQueue              bool     `json:"enable_merge_queue"`
+	MergeQueueMaxGroupSize        int64    `json:"merge_queue_max_group_size"`
 }
 
 // EditBranchProtectionOption options for editing a branch protection
@@ -150,6 +154,8 @@ type EditBranchProtectionOption struct {
 	ProtectedFilePatterns         *string  `json:"protected_file_patterns"`
 	UnprotectedFilePatterns       *string  `json:"unprotected_file_patterns"`
 	BlockAdminMergeOverride       *bool    `json:"block_admin_merge_override"`
+	EnableMergeQueue              *bool    `json:"enable_merge_queue"`
+	MergeQueueMaxGroupSize        *int64   `json:"merge_queue_max_group_size"`
 }
 
 // UpdateBranchProtectionPriories a list to update the branch protection rule priorities
@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// CodeOwnerRule represents a rule of a CODEOWNERS file
+type CodeOwnerRule struct {
+	// line of the rule in the CODEOWNERS file, starting from 1
+	Line int `json:"line"`
+	// the pattern as written in the CODEOWNERS file
+	Pattern string `json:"pattern"`
+	// whether the pattern is negated with a leading "!"
+	Negative bool `json:"negative"`
+	// the users and the teams of the rule which exist, like "@user" or "@org/team"
+	Owners []string `json:"owners"`
+}
+
+// FileCodeOwners represents the code owners of a file
+type FileCodeOwners struct {
+	Path string `json:"path"`
+	// the rules matching the file, in the order of the CODEOWNERS file
+	Rules []*CodeOwnerRule `json:"rules"`
+	// the owners of all the matching rules
+	Owners []string `json:"owners"`
+}
+
+// CodeOwnersFileOwners represents the code owners of a set of files
+type CodeOwnersFileOwners struct {
+	// path of the CODEOWNERS file, empty if there is none
+	CodeOwnersPath string            `json:"codeowners_path"`
+	Files          []*FileCodeOwners `json:"files"`
+	// false if the matching took too long and the matching rules of some files are missing
+	Complete bool `json:"complete"`
+}
+
+// CodeOwnersError represents a problem found in a CODEOWNERS file
+type CodeOwnersError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// CodeOwnersValidation represents the result of the validation of a CODEOWNERS file
+type CodeOwnersValidation struct {
+	// path of the CODEOWNERS file, empty if there is none
+	Path string `json:"path"`
+	// whether the file has no errors, a missing file is valid
+	Valid      bool               `json:"valid"`
+	RulesCount int                `json:"rules_count"`
+	Errors     []*CodeOwnersError `json:"errors"`
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// SecretScanningAlertState is the triage state of a secret scanning alert
+//
+// swagger:enum SecretScanningAlertState
+type SecretScanningAlertState string
+
+const (
+	SecretScanningAlertStateOpen          SecretScanningAlertState = "open"
+	SecretScanningAlertStateRevoked       SecretScanningAlertState = "revoked"
+	SecretScanningAlertStateFalsePositive SecretScanningAlertState = "false_positive"
+)
+
+// SecretScanningAlert represents a secret found in the files of a repository
+type SecretScanningAlert struct {
+	ID int64 `json:"id"`
+	// The ID of the rule which found the secret, e.g. "aws-access-key-id"
+	RuleID      string `json:"rule_id"`
+	Description string `json:"description"`
+	// The secret with all but its first characters masked
+	RedactedSecret string `json:"redacted_secret"`
+	// The commit which added the secret
+	CommitSHA  string                   `json:"commit_sha"`
+	Path       string                   `json:"path"`
+	Line       int                      `json:"line"`
+	State      SecretScanningAlertState `json:"state"`
+	ResolvedBy *User                    `json:"resolved_by"`
+	// swagger:strfmt date-time
+	ResolvedAt *time.Time `json:"resolved_at"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// EditSecretScanningAlertOption options for triaging a secret scanning alert
+type EditSecretScanningAlertOption struct {
+	// required: true
+	State SecretScanningAlertState `json:"state" binding:"Required;In(open,revoked,false_positive)"`
+}
@@ -114,6 +114,9 @@ func newFuncMapWebPage() template.FuncMap {
 		"DisableWebhooks": func() bool {
 			return setting.DisableWebhooks
 		},
+		"EnableCodeNavigation": func() bool {
+			return setting.CodeNavigation.Enabled
+		},
 		"NotificationSettings": func() map[string]any {
 			return map[string]any{
 				"MinTimeout":  int(setting.UI.Notification.MinTimeout / time.Millisecond),
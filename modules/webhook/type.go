@@ -40,6 +40,7 @@ const (
 	HookEventSchedule    HookEventType = "schedule"
 	HookEventWorkflowRun HookEventType = "workflow_run"
 	HookEventWorkflowJob HookEventType = "workflow_job"
+	HookEventMergeGroup  HookEventType = "merge_group"
 )
 
 func AllEvents() []HookEventType {
@@ -128,6 +129,7 @@ const (
 	MATRIX     HookType = "matrix"
 	WECHATWORK HookType = "wechatwork"
 	PACKAGIST  HookType = "packagist"
+	CUSTOM     HookType = "custom"
 )
 
 // HookStatus is the status of a web hook
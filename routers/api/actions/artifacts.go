@@ -262,6 +262,10 @@ func (ar artifactRoutes) uploadArtifact(ctx *ArtifactContext) {
 	log.Debug("[artifact] upload chunk, name: %s, path: %s, size: %d, retention days: %d",
 		artifactName, artifactPath, fileRealTotalSize, expiredDays)
 
+	if !checkArtifactQuota(ctx, task.OwnerID, max(ctx.Req.ContentLength, 0)) {
+		return
+	}
+
 	// create or get artifact with name and path
 	artifact, err := actions.CreateArtifact(ctx, task, artifactName, artifactPath, expiredDays)
 	if err != nil {
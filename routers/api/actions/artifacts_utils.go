@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"gitea.dev/models/actions"
+	quota_model "gitea.dev/models/quota"
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/util"
 )
@@ -103,3 +104,18 @@ func getUploadFileSize(ctx *ArtifactContext) int64 {
 	}
 	return ctx.Req.ContentLength
 }
+
+// checkArtifactQuota rejects uploads exceeding the artifact quota of the repository owner, size is 0 if it isn't known yet
+func checkArtifactQuota(ctx *ArtifactContext, ownerID, size int64) bool {
+	if err := quota_model.Check(ctx, ownerID, quota_model.SubjectArtifacts, size); err != nil {
+		if quota_model.IsErrQuotaExceeded(err) {
+			log.Warn("Artifact upload rejected: %v", err)
+			ctx.HTTPError(http.StatusRequestEntityTooLarge, "Artifact upload exceeds the storage quota of the repository owner")
+			return false
+		}
+		log.Error("Error checking artifact quota: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error checking artifact quota")
+		return false
+	}
+	return true
+}
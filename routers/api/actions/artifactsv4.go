@@ -325,11 +325,15 @@ func (r *artifactV4Routes) createArtifact(ctx *ArtifactContext) {
 	if ok := r.parseProtobufBody(ctx, &req); !ok {
 		return
 	}
-	_, _, ok := validateRunIDV4(ctx, req.WorkflowRunBackendId)
+	task, _, ok := validateRunIDV4(ctx, req.WorkflowRunBackendId)
 	if !ok {
 		return
 	}
 
+	if !checkArtifactQuota(ctx, task.OwnerID, 0) {
+		return
+	}
+
 	artifactName := req.Name
 
 	retentionDays := setting.Actions.ArtifactRetentionDays
@@ -408,6 +412,9 @@ func (r *artifactV4Routes) uploadArtifact(ctx *ArtifactContext) {
 			ctx.HTTPError(http.StatusNotFound, "Error artifact not found")
 			return
 		}
+		if !checkArtifactQuota(ctx, task.OwnerID, max(ctx.Req.ContentLength, 0)) {
+			return
+		}
 		blockID := ctx.Req.URL.Query().Get("blockid")
 		if blockID == "" {
 			uploadedLength, err := appendUploadChunkV3(r.fs, ctx, artifact, artifact.RunID, artifact.FileSize)
@@ -0,0 +1,325 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+// GitHub Actions Cache API Simple Description
+//
+// The runners use the cache server when ACTIONS_CACHE_URL is set to "<ROOT_URL>api/actions_cache/",
+// every request but the download is authenticated with ACTIONS_RUNTIME_TOKEN.
+//
+// 1. Restore a cache entry
+// GET: /_apis/artifactcache/cache?keys=primary-key,restore-key-1,restore-key-2&version=c0ffee
+// Response: 204 if nothing matched, or
+// {
+//     "cacheKey": "restore-key-1-abc",
+//     "scope": "refs/heads/main",
+//     "creationTime": "2026-01-23T00:13:28Z",
+//     "archiveLocation": "http://localhost:3000/api/actions_cache/_apis/artifactcache/artifacts/4?sig=...&expires=..."
+// }
+// The archive is then downloaded from archiveLocation without authentication.
+//
+// 2. Save a cache entry
+// 2.1. Reserve the entry
+// POST: /_apis/artifactcache/caches
+// Request: {"key": "primary-key", "version": "c0ffee", "cacheSize": 2097}
+// Response: {"cacheId": 4}
+// 2.2. Upload the archive in chunks, possibly in parallel and out of order
+// PATCH: /_apis/artifactcache/caches/4
+// Content-Range: bytes 0-1023/*
+// 2.3. Commit the entry once all the chunks have been uploaded
+// POST: /_apis/artifactcache/caches/4
+// Request: {"size": 2097}
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	actions_model "gitea.dev/models/actions"
+	actions_module "gitea.dev/modules/actions"
+	"gitea.dev/modules/httplib"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/storage"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	actions_service "gitea.dev/services/actions"
+)
+
+const cacheRouteBase = "/_apis/artifactcache"
+
+type cacheRoutes struct {
+	prefix string
+}
+
+func CacheRoutes(prefix string) *web.Router {
+	m := web.NewRouter()
+
+	r := cacheRoutes{prefix: prefix}
+
+	m.Group(cacheRouteBase, func() {
+		m.Get("/cache", r.findCache)
+		m.Post("/caches", r.reserveCache)
+		m.Patch("/caches/{cache_id}", r.uploadCache)
+		m.Post("/caches/{cache_id}", r.commitCache)
+	}, ArtifactContexter())
+	// the client downloads the archive without authentication, the URL is signed instead
+	m.Get(cacheRouteBase+"/artifacts/{cache_id}", ArtifactV4Contexter(), r.downloadCache)
+
+	return m
+}
+
+func (r cacheRoutes) buildSignature(expires string, cacheID int64) []byte {
+	return actions_module.BuildSignature("cache", expires, strconv.FormatInt(cacheID, 10))
+}
+
+func (r cacheRoutes) buildDownloadURL(ctx *ArtifactContext, cacheID int64) string {
+	expires := time.Now().Add(60 * time.Minute).Format(time.RFC3339)
+	return strings.TrimSuffix(httplib.GuessCurrentAppURL(ctx), "/") + strings.TrimSuffix(r.prefix, "/") +
+		cacheRouteBase + "/artifacts/" + strconv.FormatInt(cacheID, 10) +
+		"?sig=" + base64.RawURLEncoding.EncodeToString(r.buildSignature(expires, cacheID)) +
+		"&expires=" + url.QueryEscape(expires)
+}
+
+type findCacheResponse struct {
+	CacheKey        string    `json:"cacheKey"`
+	Scope           string    `json:"scope"`
+	CreationTime    time.Time `json:"creationTime"`
+	ArchiveLocation string    `json:"archiveLocation"`
+}
+
+func (r cacheRoutes) findCache(ctx *ArtifactContext) {
+	var keys []string
+	for key := range strings.SplitSeq(ctx.Req.URL.Query().Get("keys"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	version := ctx.Req.URL.Query().Get("version")
+	if len(keys) == 0 || version == "" {
+		ctx.HTTPError(http.StatusBadRequest, "keys and version are required")
+		return
+	}
+
+	_, restoreScopes, err := actions_service.CacheScopes(ctx, ctx.ActionTask)
+	if err != nil {
+		log.Error("Error getting cache scopes: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting cache scopes")
+		return
+	}
+
+	c, err := actions_model.FindCacheToRestore(ctx, ctx.ActionTask.Job.RepoID, restoreScopes, version, keys)
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.Status(http.StatusNoContent)
+		return
+	} else if err != nil {
+		log.Error("Error finding cache entry: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error finding cache entry")
+		return
+	}
+
+	if err := actions_service.MarkCacheUsed(ctx, c); err != nil {
+		log.Error("Error updating cache entry %d: %v", c.ID, err)
+	}
+
+	ctx.JSON(http.StatusOK, findCacheResponse{
+		CacheKey:        c.CacheKey,
+		Scope:           c.Scope,
+		CreationTime:    c.CreatedUnix.AsTime(),
+		ArchiveLocation: r.buildDownloadURL(ctx, c.ID),
+	})
+}
+
+type reserveCacheRequest struct {
+	Key       string `json:"key"`
+	Version   string `json:"version"`
+	CacheSize int64  `json:"cacheSize"`
+}
+
+type reserveCacheResponse struct {
+	CacheID int64 `json:"cacheId"`
+}
+
+func (r cacheRoutes) reserveCache(ctx *ArtifactContext) {
+	var req reserveCacheRequest
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&req); err != nil {
+		log.Error("Error decode request body: %v", err)
+		ctx.HTTPError(http.StatusBadRequest, "Error decode request body")
+		return
+	}
+	if req.Key == "" || req.Version == "" || req.CacheSize <= 0 {
+		ctx.HTTPError(http.StatusBadRequest, "key, version and cacheSize are required")
+		return
+	}
+	if len(req.Key) > actions_model.MaxCacheKeyLength {
+		ctx.HTTPError(http.StatusBadRequest, fmt.Sprintf("Cache key is longer than %d characters", actions_model.MaxCacheKeyLength))
+		return
+	}
+	if req.CacheSize > setting.Actions.CacheMaxSizePerRepo {
+		ctx.HTTPError(http.StatusBadRequest, fmt.Sprintf("Cache size of %d bytes is over the limit of %d bytes", req.CacheSize, setting.Actions.CacheMaxSizePerRepo))
+		return
+	}
+
+	saveScope, _, err := actions_service.CacheScopes(ctx, ctx.ActionTask)
+	if err != nil {
+		log.Error("Error getting cache scopes: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting cache scopes")
+		return
+	}
+
+	c := &actions_model.ActionCache{
+		RepoID:   ctx.ActionTask.Job.RepoID,
+		Scope:    saveScope,
+		CacheKey: req.Key,
+		Version:  req.Version,
+		Size:     req.CacheSize,
+		TaskID:   ctx.ActionTask.ID,
+	}
+	if err := actions_model.ReserveCache(ctx, c); err != nil {
+		if errors.Is(err, util.ErrAlreadyExist) {
+			ctx.HTTPError(http.StatusConflict, "Cache entry already exists")
+			return
+		}
+		log.Error("Error reserving cache entry: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error reserving cache entry")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, reserveCacheResponse{CacheID: c.ID})
+}
+
+// getOwnCache returns the entry being uploaded by the task, other tasks can't upload to it
+func (r cacheRoutes) getOwnCache(ctx *ArtifactContext) *actions_model.ActionCache {
+	c, err := actions_model.GetCacheByRepoAndID(ctx, ctx.ActionTask.Job.RepoID, ctx.PathParamInt64("cache_id"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.HTTPError(http.StatusNotFound, "Cache entry not found")
+		return nil
+	} else if err != nil {
+		log.Error("Error getting cache entry: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting cache entry")
+		return nil
+	}
+	if c.TaskID != ctx.ActionTask.ID {
+		ctx.HTTPError(http.StatusForbidden, "Cache entry is reserved by another job")
+		return nil
+	}
+	return c
+}
+
+// parseContentRange parses the "bytes start-end/*" Content-Range header sent with every chunk
+func parseContentRange(s string) (start, end int64, err error) {
+	s, ok := strings.CutPrefix(s, "bytes ")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", s)
+	}
+	s, _, _ = strings.Cut(s, "/")
+	startStr, endStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", s)
+	}
+	if start, err = strconv.ParseInt(startStr, 10, 64); err != nil {
+		return 0, 0, err
+	}
+	if end, err = strconv.ParseInt(endStr, 10, 64); err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+func (r cacheRoutes) uploadCache(ctx *ArtifactContext) {
+	c := r.getOwnCache(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	start, end, err := parseContentRange(ctx.Req.Header.Get("Content-Range"))
+	if err != nil {
+		ctx.HTTPError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := actions_service.SaveCacheChunk(c, start, end, ctx.Req.Body); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Error saving chunk of cache entry %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error saving chunk")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+type commitCacheRequest struct {
+	Size int64 `json:"size"`
+}
+
+func (r cacheRoutes) commitCache(ctx *ArtifactContext) {
+	c := r.getOwnCache(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var req commitCacheRequest
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&req); err != nil {
+		log.Error("Error decode request body: %v", err)
+		ctx.HTTPError(http.StatusBadRequest, "Error decode request body")
+		return
+	}
+
+	if err := actions_service.CommitCache(ctx, c, req.Size); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Error committing cache entry %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error committing cache entry")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (r cacheRoutes) downloadCache(ctx *ArtifactContext) {
+	cacheID := ctx.PathParamInt64("cache_id")
+	expires := ctx.Req.URL.Query().Get("expires")
+	sig, err := base64.RawURLEncoding.DecodeString(ctx.Req.URL.Query().Get("sig"))
+	if err != nil || !hmac.Equal(sig, r.buildSignature(expires, cacheID)) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error unauthorized")
+		return
+	}
+	if t, err := time.Parse(time.RFC3339, expires); err != nil || t.Before(time.Now()) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error link expired")
+		return
+	}
+
+	// the signature has been issued for an entry of the repository of the task, so it can be looked up by its ID alone
+	c, err := actions_model.GetCacheByID(ctx, cacheID)
+	if errors.Is(err, util.ErrNotExist) || err == nil && c.Status != actions_model.CacheStatusComplete {
+		ctx.HTTPError(http.StatusNotFound, "Cache entry not found")
+		return
+	} else if err != nil {
+		log.Error("Error getting cache entry: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting cache entry")
+		return
+	}
+
+	f, err := storage.ActionsCache.Open(c.StoragePath)
+	if err != nil {
+		log.Error("Error opening cache entry %d: %v", c.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error opening cache entry")
+		return
+	}
+	defer f.Close()
+	httplib.ServeUserContentByFile(ctx.Req, ctx.Resp, f, httplib.ServeHeaderOptions{
+		Filename:    fmt.Sprintf("cache-%d", c.ID),
+		ContentType: "application/octet-stream",
+	})
+}
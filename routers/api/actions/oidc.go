@@ -0,0 +1,109 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.dev/models/actions"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/web"
+	actions_service "gitea.dev/services/actions"
+)
+
+// OIDCRoutes serves the OIDC provider of Actions jobs: relying parties such as cloud providers
+// fetch the discovery document and the keys, while running jobs request their ID tokens.
+func OIDCRoutes() *web.Router {
+	m := web.NewRouter()
+	m.Get("/.well-known/openid-configuration", oidcWellKnown)
+	m.Get("/keys", oidcKeys)
+	m.Get("/token", ArtifactContexter(), oidcToken)
+	return m
+}
+
+func writeOIDCJSON(resp http.ResponseWriter, v any) {
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(v); err != nil {
+		log.Error("Failed to encode representation as json. Error: %v", err)
+	}
+}
+
+func oidcWellKnown(resp http.ResponseWriter, req *http.Request) {
+	key, err := actions_service.IDTokenSigningKey()
+	if err != nil {
+		http.NotFound(resp, req)
+		return
+	}
+
+	issuer := actions_service.IDTokenIssuer()
+	writeOIDCJSON(resp, map[string]any{
+		"issuer":                                issuer,
+		"jwks_uri":                              issuer + "/keys",
+		"subject_types_supported":               []string{"public"},
+		"response_types_supported":              []string{"id_token"},
+		"scopes_supported":                      []string{"openid"},
+		"id_token_signing_alg_values_supported": []string{key.SigningMethod().Alg()},
+		"claims_supported": []string{
+			"aud", "exp", "iat", "iss", "jti", "nbf", "sub",
+			"ref", "ref_type", "ref_protected", "sha",
+			"repository", "repository_id", "repository_owner", "repository_owner_id",
+			"actor", "actor_id", "workflow", "job", "event_name",
+			"run_id", "run_number", "run_attempt",
+		},
+	})
+}
+
+func oidcKeys(resp http.ResponseWriter, req *http.Request) {
+	key, err := actions_service.IDTokenSigningKey()
+	if err != nil {
+		http.NotFound(resp, req)
+		return
+	}
+
+	jwk, err := key.ToJWK()
+	if err != nil {
+		log.Error("Error converting signing key to JWK: %v", err)
+		http.Error(resp, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	jwk["use"] = "sig"
+
+	writeOIDCJSON(resp, map[string][]map[string]string{
+		"keys": {jwk},
+	})
+}
+
+type oidcTokenResponse struct {
+	Value string `json:"value"`
+}
+
+// oidcToken issues an ID token to the running task authenticated by its runtime token,
+// the audience can be chosen by the job with the "audience" query parameter.
+func oidcToken(ctx *ArtifactContext) {
+	allowed, err := actions.CanTaskRequestIDToken(ctx, ctx.ActionTask)
+	if err != nil {
+		log.Error("Error checking the id-token permission of task %d: %v", ctx.ActionTask.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error checking the id-token permission")
+		return
+	}
+	if !allowed {
+		ctx.HTTPError(http.StatusForbidden, `The job has not been granted the "id-token: write" permission`)
+		return
+	}
+
+	token, err := actions_service.CreateIDToken(ctx, ctx.ActionTask, ctx.Req.URL.Query().Get("audience"))
+	if err != nil {
+		if errors.Is(err, actions_service.ErrIDTokenSigningKeyUnavailable) {
+			ctx.HTTPError(http.StatusNotFound, err.Error())
+			return
+		}
+		log.Error("Error creating the ID token of task %d: %v", ctx.ActionTask.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error creating the ID token")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, oidcTokenResponse{Value: token})
+}
@@ -0,0 +1,676 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	packages_model "gitea.dev/models/packages"
+	packages_module "gitea.dev/modules/packages"
+	ansible_module "gitea.dev/modules/packages/ansible"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	"gitea.dev/routers/api/packages/helper"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+var errKindMismatch = util.NewAlreadyExistErrorf("a package of another kind with the same name exists")
+
+// https://github.com/ansible/ansible/blob/devel/lib/ansible/galaxy/api.py
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.JSON(status, map[string]any{
+		"errors": []map[string]string{
+			{
+				"status": strconv.Itoa(status),
+				"code":   strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
+				"title":  http.StatusText(status),
+				"detail": message,
+			},
+		},
+	})
+}
+
+// basePath returns the path of the registry, the client resolves links relative to the server
+func basePath(ctx *context.Context) string {
+	return setting.AppSubURL + "/api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/ansible"
+}
+
+func baseURL(ctx *context.Context) string {
+	return setting.AppURL + "api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/ansible"
+}
+
+func collectionPath(ctx *context.Context, namespace, name string) string {
+	return fmt.Sprintf("%s/v3/collections/%s/%s/", basePath(ctx), url.PathEscape(namespace), url.PathEscape(name))
+}
+
+func downloadURL(ctx *context.Context, namespace, name, version string) string {
+	return baseURL(ctx) + "/download/" + url.PathEscape(ansible_module.Filename(namespace, name, version))
+}
+
+// APIRoot lists the available API versions
+func APIRoot(ctx *context.Context) {
+	ctx.JSON(http.StatusOK, map[string]any{
+		"description":     "Gitea Ansible Galaxy API",
+		"current_version": "v3",
+		"available_versions": map[string]string{
+			"v1": "v1/",
+			"v3": "v3/",
+		},
+	})
+}
+
+// getPackage returns the package of the collection or role, it responds with an error if it doesn't exist
+func getPackage(ctx *context.Context, namespace, name, kind string) *packages_model.Package {
+	p, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, ansible_module.PackageName(namespace, name))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return nil
+	}
+
+	if k, err := packageKind(ctx, p); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil
+	} else if k != kind {
+		apiError(ctx, http.StatusNotFound, nil)
+		return nil
+	}
+	return p
+}
+
+func packageKind(ctx *context.Context, p *packages_model.Package) (string, error) {
+	pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypePackage, p.ID, ansible_module.PropertyKind)
+	if err != nil || len(pps) == 0 {
+		return "", err
+	}
+	return pps[0].Value, nil
+}
+
+// sortedVersions returns the versions of the package from the newest to the oldest
+func sortedVersions(ctx *context.Context, p *packages_model.Package) ([]*packages_model.PackageVersion, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, p.OwnerID, p.Type, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	semvers := make(map[int64]*version.Version, len(pvs))
+	for _, pv := range pvs {
+		v, err := version.NewSemver(pv.Version)
+		if err != nil {
+			return nil, err
+		}
+		semvers[pv.ID] = v
+	}
+	slices.SortFunc(pvs, func(a, b *packages_model.PackageVersion) int {
+		return semvers[b.ID].Compare(semvers[a.ID])
+	})
+	return pvs, nil
+}
+
+type collectionVersionRef struct {
+	Version string `json:"version"`
+	Href    string `json:"href"`
+}
+
+type collectionInfo struct {
+	Href           string                `json:"href"`
+	Namespace      string                `json:"namespace"`
+	Name           string                `json:"name"`
+	Deprecated     bool                  `json:"deprecated"`
+	VersionsURL    string                `json:"versions_url"`
+	HighestVersion *collectionVersionRef `json:"highest_version"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// CollectionInfo returns the information about a collection
+func CollectionInfo(ctx *context.Context) {
+	namespace, name := ctx.PathParam("namespace"), ctx.PathParam("name")
+
+	p := getPackage(ctx, namespace, name, ansible_module.KindCollection)
+	if ctx.Written() {
+		return
+	}
+
+	pvs, err := sortedVersions(ctx, p)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	info := &collectionInfo{
+		Href:        collectionPath(ctx, namespace, name),
+		Namespace:   namespace,
+		Name:        name,
+		VersionsURL: collectionPath(ctx, namespace, name) + "versions/",
+		HighestVersion: &collectionVersionRef{
+			Version: pvs[0].Version,
+			Href:    collectionPath(ctx, namespace, name) + "versions/" + url.PathEscape(pvs[0].Version) + "/",
+		},
+	}
+	createdAt, updatedAt := pvs[0].CreatedUnix, pvs[0].CreatedUnix
+	for _, pv := range pvs {
+		createdAt = min(createdAt, pv.CreatedUnix)
+		updatedAt = max(updatedAt, pv.CreatedUnix)
+	}
+	info.CreatedAt = createdAt.AsLocalTime()
+	info.UpdatedAt = updatedAt.AsLocalTime()
+
+	ctx.JSON(http.StatusOK, info)
+}
+
+type paginationLinks struct {
+	First    *string `json:"first"`
+	Previous *string `json:"previous"`
+	Next     *string `json:"next"`
+	Last     *string `json:"last"`
+}
+
+type collectionVersionList struct {
+	Meta struct {
+		Count int `json:"count"`
+	} `json:"meta"`
+	Links paginationLinks         `json:"links"`
+	Data  []*collectionVersionRef `json:"data"`
+}
+
+// EnumerateCollectionVersions lists the versions of a collection, the client follows the pagination links
+func EnumerateCollectionVersions(ctx *context.Context) {
+	namespace, name := ctx.PathParam("namespace"), ctx.PathParam("name")
+
+	p := getPackage(ctx, namespace, name, ansible_module.KindCollection)
+	if ctx.Written() {
+		return
+	}
+
+	pvs, err := sortedVersions(ctx, p)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	limit := ctx.FormInt("limit")
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+	offset := max(ctx.FormInt("offset"), 0)
+
+	pageLink := func(offset int) *string {
+		link := fmt.Sprintf("%sversions/?limit=%d&offset=%d", collectionPath(ctx, namespace, name), limit, offset)
+		return &link
+	}
+
+	list := &collectionVersionList{
+		Data: []*collectionVersionRef{},
+	}
+	list.Meta.Count = len(pvs)
+	list.Links.First = pageLink(0)
+	list.Links.Last = pageLink(max(len(pvs)-1, 0) / limit * limit)
+	if offset > 0 {
+		list.Links.Previous = pageLink(max(offset-limit, 0))
+	}
+	if offset+limit < len(pvs) {
+		list.Links.Next = pageLink(offset + limit)
+	}
+	for _, pv := range pvs[min(offset, len(pvs)):min(offset+limit, len(pvs))] {
+		list.Data = append(list.Data, &collectionVersionRef{
+			Version: pv.Version,
+			Href:    collectionPath(ctx, namespace, name) + "versions/" + url.PathEscape(pv.Version) + "/",
+		})
+	}
+
+	ctx.JSON(http.StatusOK, list)
+}
+
+type collectionVersionInfo struct {
+	Version     string `json:"version"`
+	Href        string `json:"href"`
+	DownloadURL string `json:"download_url"`
+	Namespace   struct {
+		Name string `json:"name"`
+	} `json:"namespace"`
+	Collection struct {
+		Name string `json:"name"`
+		Href string `json:"href"`
+	} `json:"collection"`
+	Artifact struct {
+		Filename string `json:"filename"`
+		SHA256   string `json:"sha256"`
+		Size     int64  `json:"size"`
+	} `json:"artifact"`
+	Metadata struct {
+		Authors       []string          `json:"authors"`
+		Dependencies  map[string]string `json:"dependencies"`
+		Description   string            `json:"description"`
+		Documentation string            `json:"documentation"`
+		Homepage      string            `json:"homepage"`
+		Issues        string            `json:"issues"`
+		License       []string          `json:"license"`
+		Repository    string            `json:"repository"`
+		Tags          []string          `json:"tags"`
+	} `json:"metadata"`
+	Signatures []any     `json:"signatures"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CollectionVersionInfo returns the metadata and the download location of a collection version
+func CollectionVersionInfo(ctx *context.Context) {
+	namespace, name := ctx.PathParam("namespace"), ctx.PathParam("name")
+
+	p := getPackage(ctx, namespace, name, ansible_module.KindCollection)
+	if ctx.Written() {
+		return
+	}
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, p.Name, ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pd.Files) == 0 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	metadata := packages_model.DescriptorMetadata[*ansible_module.Metadata](pd)
+
+	info := &collectionVersionInfo{
+		Version:     pv.Version,
+		Href:        collectionPath(ctx, namespace, name) + "versions/" + url.PathEscape(pv.Version) + "/",
+		DownloadURL: downloadURL(ctx, namespace, name, pv.Version),
+		Signatures:  []any{},
+		CreatedAt:   pv.CreatedUnix.AsLocalTime(),
+		UpdatedAt:   pv.CreatedUnix.AsLocalTime(),
+	}
+	info.Namespace.Name = namespace
+	info.Collection.Name = name
+	info.Collection.Href = collectionPath(ctx, namespace, name)
+	info.Artifact.Filename = pd.Files[0].File.Name
+	info.Artifact.SHA256 = pd.Files[0].Blob.HashSHA256
+	info.Artifact.Size = pd.Files[0].Blob.Size
+	info.Metadata.Authors = metadata.Authors
+	info.Metadata.Dependencies = metadata.Dependencies
+	if info.Metadata.Dependencies == nil {
+		info.Metadata.Dependencies = map[string]string{}
+	}
+	info.Metadata.Description = metadata.Description
+	info.Metadata.Documentation = metadata.DocumentationURL
+	info.Metadata.Homepage = metadata.ProjectURL
+	info.Metadata.Issues = metadata.IssuesURL
+	info.Metadata.License = metadata.Licenses
+	info.Metadata.Repository = metadata.RepositoryURL
+	info.Metadata.Tags = metadata.Tags
+
+	ctx.JSON(http.StatusOK, info)
+}
+
+// DownloadPackageFile serves the archive of a collection or role
+func DownloadPackageFile(ctx *context.Context) {
+	// namespaces and names can't contain a dash, so the first two separate them from the version
+	parts := strings.SplitN(strings.TrimSuffix(ctx.PathParam("filename"), ".tar.gz"), "-", 3)
+	if len(parts) != 3 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+	namespace, name, packageVersion := parts[0], parts[1], parts[2]
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeAnsible,
+			Name:        ansible_module.PackageName(namespace, name),
+			Version:     packageVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ansible_module.Filename(namespace, name, packageVersion),
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadCollection publishes a collection archive, like ansible-galaxy collection publish
+func UploadCollection(ctx *context.Context) {
+	file, _, err := ctx.Req.FormFile("file")
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	defer file.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(file)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	if checksum := ctx.Req.FormValue("sha256"); checksum != "" {
+		_, _, hashSHA256, _ := buf.Sums()
+		if !strings.EqualFold(checksum, hex.EncodeToString(hashSHA256)) {
+			apiError(ctx, http.StatusBadRequest, "hash mismatch")
+			return
+		}
+	}
+
+	cp, err := ansible_module.ParseCollection(buf)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	pv := createPackage(ctx, cp, buf)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, map[string]string{
+		"task": fmt.Sprintf("%s/v3/imports/collections/%d/", baseURL(ctx), pv.ID),
+	})
+}
+
+// UploadRole publishes a role archive, the namespace, name and version of the role are set by the path
+func UploadRole(ctx *context.Context) {
+	defer ctx.Req.Body.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(ctx.Req.Body)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	rp, err := ansible_module.ParseRole(buf, ctx.PathParam("namespace"), ctx.PathParam("name"), ctx.PathParam("version"))
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	createPackage(ctx, rp, buf)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func createPackage(ctx *context.Context, ap *ansible_module.Package, buf *packages_module.HashedBuffer) *packages_model.PackageVersion {
+	packageName := ansible_module.PackageName(ap.Namespace, ap.Name)
+
+	// collections and roles share the names, a package can only contain one kind
+	p, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, packageName)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil
+	}
+	if p != nil {
+		if kind, err := packageKind(ctx, p); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return nil
+		} else if kind != ap.Metadata.Kind {
+			apiError(ctx, http.StatusConflict, errKindMismatch)
+			return nil
+		}
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil
+	}
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeAnsible,
+				Name:        packageName,
+				Version:     ap.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         ap.Metadata,
+			PackageProperties: map[string]string{
+				ansible_module.PropertyKind: ap.Metadata.Kind,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: ansible_module.Filename(ap.Namespace, ap.Name, ap.Version),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return nil
+	}
+	return pv
+}
+
+// ImportTaskStatus returns the status of a collection import. Collections are imported on upload,
+// so the task is always completed.
+func ImportTaskStatus(ctx *context.Context) {
+	pv, err := packages_model.GetVersionByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if p.OwnerID != ctx.Package.Owner.ID || p.Type != packages_model.TypeAnsible {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"id":          strconv.FormatInt(pv.ID, 10),
+		"state":       "completed",
+		"created_at":  pv.CreatedUnix.AsLocalTime(),
+		"finished_at": pv.CreatedUnix.AsLocalTime(),
+		"messages":    []any{},
+		"error":       nil,
+	})
+}
+
+type roleInfo struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace"`
+	Description   string `json:"description"`
+	GithubUser    string `json:"github_user"`
+	GithubRepo    string `json:"github_repo"`
+	SummaryFields struct {
+		Namespace struct {
+			Name string `json:"name"`
+		} `json:"namespace"`
+	} `json:"summary_fields"`
+}
+
+// SearchRoles finds a role by its namespace and name, like ansible-galaxy role install does
+func SearchRoles(ctx *context.Context) {
+	namespace, name := ctx.FormTrim("owner__username"), ctx.FormTrim("name")
+
+	results := []*roleInfo{}
+	if namespace != "" && name != "" {
+		p, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, ansible_module.PackageName(namespace, name))
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if p != nil {
+			kind, err := packageKind(ctx, p)
+			if err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+			if kind == ansible_module.KindRole {
+				info := packageToRoleInfo(ctx, p)
+				if ctx.Written() {
+					return
+				}
+				results = append(results, info)
+			}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"count":     len(results),
+		"next":      nil,
+		"next_link": nil,
+		"previous":  nil,
+		"results":   results,
+	})
+}
+
+func packageToRoleInfo(ctx *context.Context, p *packages_model.Package) *roleInfo {
+	namespace, name := ansible_module.SplitPackageName(p.Name)
+
+	info := &roleInfo{
+		ID:         p.ID,
+		Name:       name,
+		Namespace:  namespace,
+		GithubUser: namespace,
+		GithubRepo: name,
+	}
+	info.SummaryFields.Namespace.Name = namespace
+
+	pvs, err := sortedVersions(ctx, p)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil
+	}
+	if len(pvs) > 0 {
+		pd, err := packages_model.GetPackageDescriptor(ctx, pvs[0])
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return nil
+		}
+		info.Description = packages_model.DescriptorMetadata[*ansible_module.Metadata](pd).Description
+	}
+	return info
+}
+
+type roleVersion struct {
+	ID       int64     `json:"id"`
+	Name     string    `json:"name"`
+	Source   string    `json:"source"`
+	Created  time.Time `json:"created"`
+	Modified time.Time `json:"modified"`
+}
+
+// EnumerateRoleVersions lists the versions of a role, the client downloads the archive from the source url
+func EnumerateRoleVersions(ctx *context.Context) {
+	p, err := packages_model.GetPackageByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if p.OwnerID != ctx.Package.Owner.ID || p.Type != packages_model.TypeAnsible {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+	if kind, err := packageKind(ctx, p); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	} else if kind != ansible_module.KindRole {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	namespace, name := ansible_module.SplitPackageName(p.Name)
+
+	pvs, err := sortedVersions(ctx, p)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	results := make([]*roleVersion, 0, len(pvs))
+	for _, pv := range pvs {
+		results = append(results, &roleVersion{
+			ID:       pv.ID,
+			Name:     pv.Version,
+			Source:   downloadURL(ctx, namespace, name, pv.Version),
+			Created:  pv.CreatedUnix.AsLocalTime(),
+			Modified: pv.CreatedUnix.AsLocalTime(),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, map[string]any{
+		"count":     len(results),
+		"next":      nil,
+		"next_link": nil,
+		"previous":  nil,
+		"results":   results,
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/web"
 	"gitea.dev/routers/api/packages/alpine"
+	"gitea.dev/routers/api/packages/ansible"
 	"gitea.dev/routers/api/packages/arch"
 	"gitea.dev/routers/api/packages/cargo"
 	"gitea.dev/routers/api/packages/chef"
@@ -24,7 +25,9 @@ import (
 	"gitea.dev/routers/api/packages/generic"
 	"gitea.dev/routers/api/packages/goproxy"
 	"gitea.dev/routers/api/packages/helm"
+	"gitea.dev/routers/api/packages/hex"
 	"gitea.dev/routers/api/packages/maven"
+	"gitea.dev/routers/api/packages/nix"
 	"gitea.dev/routers/api/packages/npm"
 	"gitea.dev/routers/api/packages/nuget"
 	"gitea.dev/routers/api/packages/pub"
@@ -114,6 +117,28 @@ func verifyAuth(r *web.Router, authMethods []auth.Method, opts verifyAuthOptions
 	})
 }
 
+// verifyGroupAuth signs in the user with an authentication method only used by the routes of a group,
+// for clients whose credentials can't be told apart from the ones of the other package types.
+// The package is assigned again because its access mode depends on the signed in user.
+func verifyGroupAuth(authMethod auth.Method) func(ctx *context.Context) {
+	return func(ctx *context.Context) {
+		if ctx.Doer != nil {
+			return
+		}
+		doer, err := authMethod.Verify(ctx.Req, ctx.Resp, ctx, ctx.Session)
+		if err != nil {
+			log.Error("Failed to verify user: %v", err)
+			ctx.HTTPError(http.StatusUnauthorized, "Failed to authenticate user")
+			return
+		} else if doer == nil {
+			return
+		}
+		ctx.Doer, ctx.IsSigned = doer, true
+		ctx.Data["AuthedMethod"] = authMethod.Name()
+		context.PackageAssignment()(ctx)
+	}
+}
+
 // CommonRoutes provide endpoints for most package managers (except containers - see below)
 // These are mounted on `/api/packages` (not `/api/v1/packages`)
 func CommonRoutes() *web.Router {
@@ -143,6 +168,24 @@ func CommonRoutes() *web.Router {
 				})
 			})
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/ansible", func() {
+			r.Get("", ansible.APIRoot)
+			r.Get("/download/{filename}", ansible.DownloadPackageFile)
+			r.Group("/v1/roles", func() {
+				r.Get("", ansible.SearchRoles)
+				r.Get("/{id}/versions", ansible.EnumerateRoleVersions)
+			})
+			r.Put("/roles/{namespace}/{name}/{version}", reqPackageAccess(perm.AccessModeWrite), ansible.UploadRole)
+			r.Group("/v3", func() {
+				r.Post("/artifacts/collections", reqPackageAccess(perm.AccessModeWrite), ansible.UploadCollection)
+				r.Get("/imports/collections/{id}", ansible.ImportTaskStatus)
+				r.Group("/collections/{namespace}/{name}", func() {
+					r.Get("", ansible.CollectionInfo)
+					r.Get("/versions", ansible.EnumerateCollectionVersions)
+					r.Get("/versions/{version}", ansible.CollectionVersionInfo)
+				})
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/arch", func() {
 			r.Methods("HEAD,GET", "/repository.key", arch.GetRepositoryKey)
 			r.Methods("PUT", "" /* no repository */, reqPackageAccess(perm.AccessModeWrite), arch.UploadPackageFile)
@@ -361,11 +404,40 @@ func CommonRoutes() *web.Router {
 			r.Post("/api/charts", reqPackageAccess(perm.AccessModeWrite), helm.UploadPackage)
 			r.Post("/api/prov", reqPackageAccess(perm.AccessModeWrite), helm.UploadProvenanceFile)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/hex", func() {
+			r.Get("/public_key", hex.GetPublicKey)
+			r.Get("/names", hex.EnumeratePackageNames)
+			r.Get("/versions", hex.EnumeratePackageVersions)
+			r.Get("/packages/{name}", hex.PackageReleases)
+			r.Get("/tarballs/{filename}", hex.DownloadPackageFile)
+			r.Group("/api", func() {
+				r.Post("/publish", reqPackageAccess(perm.AccessModeWrite), hex.UploadPackage)
+				r.Group("/packages/{name}", func() {
+					r.Get("", hex.PackageInfo)
+					r.Group("/releases/{version}", func() {
+						r.Get("", hex.ReleaseInfo)
+						r.Group("/retire", func() {
+							r.Post("", hex.RetirePackage)
+							r.Delete("", hex.UnretirePackage)
+						}, reqPackageAccess(perm.AccessModeWrite))
+					})
+				})
+			})
+		}, verifyGroupAuth(&hex.Auth{}), reqPackageAccess(perm.AccessModeRead))
 		r.Group("/maven", func() {
 			r.Put("/*", reqPackageAccess(perm.AccessModeWrite), maven.UploadPackageFile)
 			r.Get("/*", maven.DownloadPackageFile)
 			r.Head("/*", maven.ProvidePackageFileHeader)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/nix", func() {
+			r.Methods("HEAD,GET", "/nix-cache-info", nix.CacheInfo)
+			r.Methods("HEAD,GET", "/{hash}.narinfo", nix.GetNarInfo)
+			r.Put("/{hash}.narinfo", reqPackageAccess(perm.AccessModeWrite), nix.UploadNarInfo)
+			r.Group("/nar/{filename}", func() {
+				r.Methods("HEAD,GET", "", nix.DownloadNar)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), nix.UploadNar)
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/nuget", func() {
 			r.Group("", func() { // Needs to be unauthenticated for the NuGet client.
 				r.Get("/", nuget.ServiceIndexV2)
@@ -516,6 +516,9 @@ func getBlobFromContext(ctx *context.Context) (*packages_model.PackageFileDescri
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#checking-if-content-exists-in-the-registry
 func HeadBlob(ctx *context.Context) {
 	blob, err := getBlobFromContext(ctx)
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		blob, err = pullBlobFromUpstream(ctx)
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errBlobUnknown)
@@ -535,6 +538,9 @@ func HeadBlob(ctx *context.Context) {
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pulling-blobs
 func GetBlob(ctx *context.Context) {
 	blob, err := getBlobFromContext(ctx)
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		blob, err = pullBlobFromUpstream(ctx)
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errBlobUnknown)
@@ -650,6 +656,9 @@ func getManifestFromContext(ctx *context.Context) (*packages_model.PackageFileDe
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#checking-if-content-exists-in-the-registry
 func HeadManifest(ctx *context.Context) {
 	manifest, err := getManifestFromContext(ctx)
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		manifest, err = pullManifestFromUpstream(ctx)
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errManifestUnknown)
@@ -670,6 +679,9 @@ func HeadManifest(ctx *context.Context) {
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pulling-manifests
 func GetManifest(ctx *context.Context) {
 	manifest, err := getManifestFromContext(ctx)
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		manifest, err = pullManifestFromUpstream(ctx)
+	}
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
 			apiErrorDefined(ctx, errManifestUnknown)
@@ -309,6 +309,12 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 		}
 	}
 
+	for name, value := range mci.Properties {
+		if err = packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, name, value); err != nil {
+			return nil, fmt.Errorf("InsertOrUpdateProperty(%s): %w", name, err)
+		}
+	}
+
 	if err = packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestReference); err != nil {
 		return nil, fmt.Errorf("DeletePropertiesByName(ManifestReference): %w", err)
 	}
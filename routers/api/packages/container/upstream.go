@@ -0,0 +1,214 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"bytes"
+	std_ctx "context"
+	"errors"
+	"fmt"
+	"io"
+
+	packages_model "gitea.dev/models/packages"
+	container_model "gitea.dev/models/packages/container"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/json"
+	packages_module "gitea.dev/modules/packages"
+	container_module "gitea.dev/modules/packages/container"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+	"gitea.dev/services/packages/upstream"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// pullManifestFromUpstream fetches the requested manifest from the upstream registry of the owner.
+// ErrContainerBlobNotExist is returned if the owner has no upstream registry or the upstream registry doesn't know the manifest.
+func pullManifestFromUpstream(ctx *context.Context) (*packages_model.PackageFileDescriptor, error) {
+	opts, err := getBlobSearchOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, creator := ctx.Package.Owner, upstream.Creator(ctx.Doer)
+	reference := ctx.PathParam("reference")
+	err = pullFromUpstream(ctx, opts, func(ctx std_ctx.Context, c *upstream.Client) error {
+		return pullManifest(ctx, c, owner, creator, opts.Image, reference)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getManifestFromContext(ctx)
+}
+
+// pullBlobFromUpstream fetches the requested blob from the upstream registry of the owner.
+// ErrContainerBlobNotExist is returned if the owner has no upstream registry or the upstream registry doesn't know the blob.
+func pullBlobFromUpstream(ctx *context.Context) (*packages_model.PackageFileDescriptor, error) {
+	d := digest.Digest(ctx.PathParam("digest"))
+	if d.Validate() != nil {
+		return nil, container_model.ErrContainerBlobNotExist
+	}
+
+	owner, creator := ctx.Package.Owner, upstream.Creator(ctx.Doer)
+	opts := &container_model.BlobSearchOptions{
+		OwnerID: owner.ID,
+		Image:   ctx.PathParam("image"),
+		Digest:  string(d),
+	}
+	err := pullFromUpstream(ctx, opts, func(ctx std_ctx.Context, c *upstream.Client) error {
+		return pullBlob(ctx, c, owner, creator, opts.Image, d)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return getBlobFromContext(ctx)
+}
+
+// pullFromUpstream runs the pull for the image exclusively, the pull is skipped if another request pulled the content meanwhile
+func pullFromUpstream(ctx *context.Context, opts *container_model.BlobSearchOptions, pull func(std_ctx.Context, *upstream.Client) error) error {
+	c, err := upstream.NewClient(ctx, opts.OwnerID, packages_model.TypeContainer)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return container_model.ErrContainerBlobNotExist
+		}
+		return err
+	}
+
+	err = globallock.LockAndDo(ctx, containerGlobalLockKey(opts.OwnerID, opts.Image, "upstream"), func(lockCtx std_ctx.Context) error {
+		if _, err := container_model.GetContainerBlob(lockCtx, opts); err == nil {
+			return nil
+		}
+		return pull(lockCtx, c)
+	})
+	if errors.Is(err, util.ErrNotExist) {
+		return container_model.ErrContainerBlobNotExist
+	}
+	return err
+}
+
+// pullManifest fetches a manifest and the referenced content from the upstream registry.
+// All manifests of an index are pulled because an index can only be stored if its manifests exist.
+func pullManifest(ctx std_ctx.Context, c *upstream.Client, owner, creator *user_model.User, image, reference string) error {
+	data, mediaType, err := c.ContainerManifest(ctx, image, reference)
+	if err != nil {
+		return err
+	}
+	if len(data) > maxManifestSize {
+		return errManifestInvalid.WithMessage("Manifest exceeds maximum size")
+	}
+
+	isTagged := true
+	if d := digest.Digest(reference); d.Validate() == nil {
+		if d.Algorithm().FromBytes(data) != d {
+			return errManifestInvalid.WithMessage("Manifest of the upstream registry doesn't match the digest")
+		}
+		isTagged = false
+	}
+
+	var index oci.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return err
+	}
+	if !container_module.IsMediaTypeValid(mediaType) {
+		mediaType = index.MediaType
+	}
+
+	if container_module.IsMediaTypeImageIndex(mediaType) {
+		for _, m := range index.Manifests {
+			if _, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+				OwnerID:    owner.ID,
+				Image:      image,
+				Digest:     string(m.Digest),
+				IsManifest: true,
+			}); err == nil {
+				continue
+			} else if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+				return err
+			}
+			if !container_module.IsMediaTypeImageManifest(m.MediaType) {
+				return errManifestInvalid
+			}
+			if err := pullManifest(ctx, c, owner, creator, image, string(m.Digest)); err != nil {
+				return err
+			}
+		}
+	} else if container_module.IsMediaTypeImageManifest(mediaType) {
+		var manifest oci.Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return err
+		}
+		for _, desc := range append([]oci.Descriptor{manifest.Config}, manifest.Layers...) {
+			if _, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+				OwnerID: owner.ID,
+				Image:   image,
+				Digest:  string(desc.Digest),
+			}); err == nil {
+				continue
+			} else if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+				return err
+			}
+			if err := pullBlob(ctx, c, owner, creator, image, desc.Digest); err != nil {
+				return err
+			}
+		}
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReaderWithSize(bytes.NewReader(data), len(data))
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	_, err = processManifest(ctx, &manifestCreationInfo{
+		MediaType: mediaType,
+		Owner:     owner,
+		Creator:   creator,
+		Image:     image,
+		Reference: reference,
+		IsTagged:  isTagged,
+		Properties: map[string]string{
+			upstream.PropertyUpstream: c.URL(),
+		},
+	}, buf)
+	return err
+}
+
+// pullBlob fetches a blob from the upstream registry and stores it like an uploaded blob
+func pullBlob(ctx std_ctx.Context, c *upstream.Client, owner, creator *user_model.User, image string, d digest.Digest) error {
+	if d.Algorithm() != digest.SHA256 {
+		return fmt.Errorf("unsupported digest algorithm %s", d.Algorithm())
+	}
+
+	resp, err := c.ContainerBlob(ctx, image, string(d))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(resp.Body)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	if digestFromHashSummer(buf) != string(d) {
+		return errDigestInvalid.WithMessage("Blob of the upstream registry doesn't match the digest")
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	_, err = saveAsPackageBlob(ctx, buf, &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Owner:       owner,
+			PackageType: packages_model.TypeContainer,
+			Name:        image,
+		},
+		Creator: creator,
+	})
+	return err
+}
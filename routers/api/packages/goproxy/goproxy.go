@@ -12,6 +12,8 @@ import (
 	"time"
 
 	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/log"
 	"gitea.dev/modules/optional"
 	packages_module "gitea.dev/modules/packages"
 	goproxy_module "gitea.dev/modules/packages/goproxy"
@@ -19,6 +21,7 @@ import (
 	"gitea.dev/routers/api/packages/helper"
 	"gitea.dev/services/context"
 	packages_service "gitea.dev/services/packages"
+	"gitea.dev/services/packages/upstream"
 )
 
 func apiError(ctx *context.Context, status int, obj any) {
@@ -32,8 +35,14 @@ func EnumeratePackageVersions(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
+
+	upstreamVersions, err := upstream.GoModuleVersions(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"))
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		log.Warn("Failed to list versions of %s from the upstream registry: %v", ctx.PathParam("name"), err)
+	}
+
+	if len(pvs) == 0 && len(upstreamVersions) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
 		return
 	}
 
@@ -43,9 +52,17 @@ func EnumeratePackageVersions(ctx *context.Context) {
 
 	ctx.Resp.Header().Set("Content-Type", "text/plain;charset=utf-8")
 
+	versions := make(container.Set[string], len(pvs))
 	for _, pv := range pvs {
+		versions.Add(pv.Version)
 		fmt.Fprintln(ctx.Resp, pv.Version)
 	}
+	// versions which are not cached yet can be pulled from the upstream registry
+	for _, version := range upstreamVersions {
+		if versions.Add(version) {
+			fmt.Fprintln(ctx.Resp, version)
+		}
+	}
 }
 
 func PackageVersionMetadata(ctx *context.Context) {
@@ -122,6 +139,14 @@ func resolvePackage(ctx *context.Context, ownerID int64, name, version string) (
 	var pv *packages_model.PackageVersion
 
 	if version == "latest" {
+		// the upstream registry knows newer versions than the cached ones
+		latest, err := upstream.GoModuleLatestVersion(ctx, ownerID, name)
+		if err == nil {
+			return resolvePackage(ctx, ownerID, name, latest)
+		} else if !errors.Is(err, util.ErrNotExist) {
+			log.Warn("Failed to get the latest version of %s from the upstream registry: %v", name, err)
+		}
+
 		pvs, _, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
 			OwnerID: ownerID,
 			Type:    packages_model.TypeGo,
@@ -144,6 +169,12 @@ func resolvePackage(ctx *context.Context, ownerID int64, name, version string) (
 	} else {
 		var err error
 		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ownerID, packages_model.TypeGo, name, version)
+		if errors.Is(err, util.ErrNotExist) {
+			pv, err = upstream.PullGoModule(ctx, ctx.Package.Owner, ctx.Doer, name, version)
+			if errors.Is(err, util.ErrNotExist) {
+				return nil, packages_model.ErrPackageNotExist
+			}
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"net/http"
+	"strings"
+
+	user_model "gitea.dev/models/user"
+	"gitea.dev/services/auth"
+)
+
+var _ auth.Method = &Auth{}
+
+type Auth struct {
+	basicAuth auth.Basic
+}
+
+func (a *Auth) Name() string {
+	return "hex"
+}
+
+// Verify extracts the user from the API key which the Hex client sends as the whole authorization header, without a scheme.
+// It must only be used for the routes of the Hex registry.
+func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) (*user_model.User, error) {
+	token := req.Header.Get("Authorization")
+	if token == "" || strings.Contains(token, " ") {
+		return nil, nil //nolint:nilnil // the auth method is not applicable
+	}
+	return a.basicAuth.VerifyAuthToken(req, w, store, sess, token)
+}
@@ -0,0 +1,419 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/json"
+	packages_module "gitea.dev/modules/packages"
+	hex_module "gitea.dev/modules/packages/hex"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	"gitea.dev/routers/api/packages/helper"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+	hex_service "gitea.dev/services/packages/hex"
+)
+
+// https://github.com/hexpm/specifications/blob/main/apiary.apib#errors
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.JSON(status, map[string]any{
+		"status":  status,
+		"message": message,
+	})
+}
+
+func baseURL(ctx *context.Context) string {
+	return setting.AppURL + "api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/hex"
+}
+
+// serveResource serves a signed registry resource, it is already gzipped
+func serveResource(ctx *context.Context, data []byte) {
+	ctx.Resp.Header().Set("Content-Type", "application/octet-stream")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(data)
+}
+
+// GetPublicKey serves the public key used to verify the registry resources
+func GetPublicKey(ctx *context.Context) {
+	key, err := hex_service.GetOrCreateSigningKey(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.PlainText(http.StatusOK, key.PublicKey)
+}
+
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#names
+func EnumeratePackageNames(ctx *context.Context) {
+	data, err := hex_service.BuildNames(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	serveResource(ctx, data)
+}
+
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#versions
+func EnumeratePackageVersions(ctx *context.Context) {
+	data, err := hex_service.BuildVersions(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	serveResource(ctx, data)
+}
+
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#package
+func PackageReleases(ctx *context.Context) {
+	p, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, ctx.PathParam("name"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	data, err := hex_service.BuildPackage(ctx, ctx.Package.Owner, p)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if data == nil {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	serveResource(ctx, data)
+}
+
+func tarballFilename(name, version string) string {
+	return strings.ToLower(fmt.Sprintf("%s-%s.tar", name, version))
+}
+
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#package-tarball
+func DownloadPackageFile(ctx *context.Context) {
+	// package names can't contain a dash, so the first one separates the name from the version
+	name, version, ok := strings.Cut(strings.TrimSuffix(ctx.PathParam("filename"), ".tar"), "-")
+	if !ok {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        name,
+			Version:     version,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: tarballFilename(name, version),
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+type releaseInfo struct {
+	Version      string                      `json:"version"`
+	Checksum     string                      `json:"checksum,omitempty"`
+	URL          string                      `json:"url"`
+	HTMLURL      string                      `json:"html_url"`
+	PackageURL   string                      `json:"package_url,omitempty"`
+	Meta         *releaseMeta                `json:"meta,omitempty"`
+	Requirements map[string]*releaseRequired `json:"requirements,omitempty"`
+	Retirement   *hex_module.Retirement      `json:"retirement"`
+	InsertedAt   time.Time                   `json:"inserted_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+}
+
+type releaseMeta struct {
+	App        string   `json:"app"`
+	BuildTools []string `json:"build_tools"`
+	Elixir     string   `json:"elixir,omitempty"`
+}
+
+type releaseRequired struct {
+	App         string `json:"app"`
+	Optional    bool   `json:"optional"`
+	Requirement string `json:"requirement"`
+}
+
+func releaseURL(ctx *context.Context, name, version string) string {
+	return fmt.Sprintf("%s/api/packages/%s/releases/%s", baseURL(ctx), url.PathEscape(name), url.PathEscape(version))
+}
+
+func packageDescriptorToRelease(ctx *context.Context, pd *packages_model.PackageDescriptor) *releaseInfo {
+	metadata := packages_model.DescriptorMetadata[*hex_module.Metadata](pd)
+
+	requirements := make(map[string]*releaseRequired, len(metadata.Requirements))
+	for _, req := range metadata.Requirements {
+		requirements[req.Name] = &releaseRequired{
+			App:         req.App,
+			Optional:    req.Optional,
+			Requirement: req.Requirement,
+		}
+	}
+
+	var checksum string
+	if len(pd.Files) > 0 {
+		checksum = pd.Files[0].Blob.HashSHA256
+	}
+
+	return &releaseInfo{
+		Version:    pd.Version.Version,
+		Checksum:   checksum,
+		URL:        releaseURL(ctx, pd.Package.Name, pd.Version.Version),
+		HTMLURL:    pd.VersionHTMLURL(ctx),
+		PackageURL: fmt.Sprintf("%s/api/packages/%s", baseURL(ctx), url.PathEscape(pd.Package.Name)),
+		Meta: &releaseMeta{
+			App:        metadata.App,
+			BuildTools: metadata.BuildTools,
+			Elixir:     metadata.ElixirRequirement,
+		},
+		Requirements: requirements,
+		Retirement:   hex_service.GetRetirement(pd),
+		InsertedAt:   pd.Version.CreatedUnix.AsLocalTime(),
+		UpdatedAt:    pd.Version.CreatedUnix.AsLocalTime(),
+	}
+}
+
+type packageInfo struct {
+	Name        string                            `json:"name"`
+	Repository  string                            `json:"repository"`
+	URL         string                            `json:"url"`
+	HTMLURL     string                            `json:"html_url"`
+	Meta        *packageMeta                      `json:"meta"`
+	Releases    []*packageRelease                 `json:"releases"`
+	Retirements map[string]*hex_module.Retirement `json:"retirements"`
+	InsertedAt  time.Time                         `json:"inserted_at"`
+	UpdatedAt   time.Time                         `json:"updated_at"`
+}
+
+type packageMeta struct {
+	Description string            `json:"description"`
+	Licenses    []string          `json:"licenses"`
+	Links       map[string]string `json:"links"`
+}
+
+type packageRelease struct {
+	Version    string    `json:"version"`
+	URL        string    `json:"url"`
+	InsertedAt time.Time `json:"inserted_at"`
+}
+
+// https://github.com/hexpm/specifications/blob/main/apiary.apib#packages
+func PackageInfo(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, ctx.PathParam("name"))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// the releases are listed from the newest to the oldest
+	slices.SortFunc(pds, func(a, b *packages_model.PackageDescriptor) int {
+		return b.SemVer.Compare(a.SemVer)
+	})
+	latest := pds[0]
+	metadata := packages_model.DescriptorMetadata[*hex_module.Metadata](latest)
+
+	insertedAt, updatedAt := latest.Version.CreatedUnix, latest.Version.CreatedUnix
+	info := &packageInfo{
+		Name:       latest.Package.Name,
+		Repository: ctx.Package.Owner.Name,
+		URL:        fmt.Sprintf("%s/api/packages/%s", baseURL(ctx), url.PathEscape(latest.Package.Name)),
+		HTMLURL:    latest.PackageHTMLURL(ctx),
+		Meta: &packageMeta{
+			Description: metadata.Description,
+			Licenses:    metadata.Licenses,
+			Links:       metadata.Links,
+		},
+		Releases:    make([]*packageRelease, 0, len(pds)),
+		Retirements: make(map[string]*hex_module.Retirement),
+	}
+	for _, pd := range pds {
+		info.Releases = append(info.Releases, &packageRelease{
+			Version:    pd.Version.Version,
+			URL:        releaseURL(ctx, pd.Package.Name, pd.Version.Version),
+			InsertedAt: pd.Version.CreatedUnix.AsLocalTime(),
+		})
+		if r := hex_service.GetRetirement(pd); r != nil {
+			info.Retirements[pd.Version.Version] = r
+		}
+		insertedAt = min(insertedAt, pd.Version.CreatedUnix)
+		updatedAt = max(updatedAt, pd.Version.CreatedUnix)
+	}
+	info.InsertedAt = insertedAt.AsLocalTime()
+	info.UpdatedAt = updatedAt.AsLocalTime()
+
+	ctx.JSON(http.StatusOK, info)
+}
+
+func getPackageDescriptor(ctx *context.Context) *packages_model.PackageDescriptor {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, ctx.PathParam("name"), ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return nil
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return nil
+	}
+	return pd
+}
+
+// https://github.com/hexpm/specifications/blob/main/apiary.apib#package-releases
+func ReleaseInfo(ctx *context.Context) {
+	pd := getPackageDescriptor(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, packageDescriptorToRelease(ctx, pd))
+}
+
+// https://github.com/hexpm/specifications/blob/main/apiary.apib#package-releases
+func UploadPackage(ctx *context.Context) {
+	defer ctx.Req.Body.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(ctx.Req.Body)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	hp, err := hex_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusUnprocessableEntity, err)
+		} else {
+			apiError(ctx, http.StatusBadRequest, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeHex,
+				Name:        hp.Name,
+				Version:     hp.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         hp.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: tarballFilename(hp.Name, hp.Version),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, packageDescriptorToRelease(ctx, pd))
+}
+
+// https://github.com/hexpm/specifications/blob/main/apiary.apib#package-release-retirement
+func RetirePackage(ctx *context.Context) {
+	var r hex_module.Retirement
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&r); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if !hex_module.IsValidRetirementReason(r.Reason) {
+		apiError(ctx, http.StatusUnprocessableEntity, fmt.Sprintf("retirement reason %q is invalid", r.Reason))
+		return
+	}
+
+	setRetirement(ctx, &r)
+}
+
+// https://github.com/hexpm/specifications/blob/main/apiary.apib#package-release-retirement
+func UnretirePackage(ctx *context.Context) {
+	setRetirement(ctx, nil)
+}
+
+func setRetirement(ctx *context.Context, r *hex_module.Retirement) {
+	pd := getPackageDescriptor(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := hex_service.SetRetirement(ctx, pd.Version, r); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
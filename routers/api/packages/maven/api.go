@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/container"
 )
 
 // MetadataResponse https://maven.apache.org/ref/3.2.5/maven-repository-metadata/repository-metadata.html
@@ -45,3 +46,30 @@ func createMetadataResponse(pds []*packages_model.PackageDescriptor, groupID, ar
 	}
 	return resp
 }
+
+// mergeUpstreamVersions lists the versions of the upstream registry, versions only known to this registry are appended.
+// Latest and release are derived from the merged list because clients treat the last version as the newest one.
+func mergeUpstreamVersions(resp *MetadataResponse, upstreamVersions []string) {
+	versions := make([]string, 0, len(upstreamVersions)+len(resp.Version))
+	known := make(container.Set[string], len(upstreamVersions))
+	for _, version := range upstreamVersions {
+		if known.Add(version) {
+			versions = append(versions, version)
+		}
+	}
+	for _, version := range resp.Version {
+		if known.Add(version) {
+			versions = append(versions, version)
+		}
+	}
+
+	resp.Version = versions
+	resp.Latest = versions[len(versions)-1]
+	resp.Release = ""
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !strings.HasSuffix(versions[i], "-SNAPSHOT") {
+			resp.Release = versions[i]
+			break
+		}
+	}
+}
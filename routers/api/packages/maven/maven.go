@@ -22,12 +22,14 @@ import (
 	packages_model "gitea.dev/models/packages"
 	"gitea.dev/modules/globallock"
 	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
 	packages_module "gitea.dev/modules/packages"
 	maven_module "gitea.dev/modules/packages/maven"
 	"gitea.dev/modules/util"
 	"gitea.dev/routers/api/packages/helper"
 	"gitea.dev/services/context"
 	packages_service "gitea.dev/services/packages"
+	"gitea.dev/services/packages/upstream"
 )
 
 const (
@@ -92,7 +94,12 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	}
 	pvs = append(pvsLegacy, pvs...)
 
-	if len(pvs) == 0 {
+	upstreamVersions, err := upstream.MavenVersions(ctx, ctx.Package.Owner.ID, params.GroupID, params.ArtifactID)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		log.Warn("Failed to get the versions of %s from the upstream registry: %v", params.toInternalPackageName(), err)
+	}
+
+	if len(pvs) == 0 && len(upstreamVersions) == 0 {
 		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
 		return
 	}
@@ -108,18 +115,29 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 		return pds[i].Version.CreatedUnix < pds[j].Version.CreatedUnix
 	})
 
-	xmlMetadata, err := xml.Marshal(createMetadataResponse(pds, params.GroupID, params.ArtifactID))
+	resp := &MetadataResponse{
+		GroupID:    params.GroupID,
+		ArtifactID: params.ArtifactID,
+	}
+	if len(pds) > 0 {
+		resp = createMetadataResponse(pds, params.GroupID, params.ArtifactID)
+
+		latest := pds[len(pds)-1]
+		// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
+		lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
+		ctx.Resp.Header().Set("Last-Modified", lastModified)
+	}
+	if len(upstreamVersions) > 0 {
+		mergeUpstreamVersions(resp, upstreamVersions)
+	}
+
+	xmlMetadata, err := xml.Marshal(resp)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 	xmlMetadataWithHeader := append([]byte(xml.Header), xmlMetadata...)
 
-	latest := pds[len(pds)-1]
-	// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
-	lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
-	ctx.Resp.Header().Set("Last-Modified", lastModified)
-
 	ext := strings.ToLower(path.Ext(params.Filename))
 	if isChecksumExtension(ext) {
 		var hash []byte
@@ -147,20 +165,18 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	_, _ = ctx.Resp.Write(xmlMetadataWithHeader)
 }
 
-func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
+func getPackageFile(ctx *context.Context, params parameters, filename string) (*packages_model.PackageFile, error) {
 	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
 	if errors.Is(err, util.ErrNotExist) {
 		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
 	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
-		}
-		return
+		return nil, err
 	}
+	return packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+}
 
+func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
 	filename := params.Filename
 
 	ext := strings.ToLower(path.Ext(filename))
@@ -168,9 +184,14 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 		filename = filename[:len(filename)-len(ext)]
 	}
 
-	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+	pf, err := getPackageFile(ctx, params, filename)
+	if errors.Is(err, util.ErrNotExist) {
+		if _, err = upstream.PullMavenFile(ctx, ctx.Package.Owner, ctx.Doer, params.GroupID, params.ArtifactID, params.Version, filename); err == nil {
+			pf, err = getPackageFile(ctx, params, filename)
+		}
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -0,0 +1,166 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"errors"
+	"net/http"
+
+	packages_model "gitea.dev/models/packages"
+	packages_module "gitea.dev/modules/packages"
+	nix_module "gitea.dev/modules/packages/nix"
+	"gitea.dev/modules/util"
+	"gitea.dev/routers/api/packages/helper"
+	"gitea.dev/services/context"
+	packages_service "gitea.dev/services/packages"
+	nix_service "gitea.dev/services/packages/nix"
+)
+
+// cachePriority is the priority of the binary cache, lower values are preferred by Nix. cache.nixos.org uses 40.
+const cachePriority = 50
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.PlainText(status, message)
+}
+
+// CacheInfo returns the nix-cache-info file
+// https://nix.dev/manual/nix/latest/protocols/binary-cache
+func CacheInfo(ctx *context.Context) {
+	ctx.PlainText(http.StatusOK, nix_module.CacheInfo(cachePriority))
+}
+
+// GetNarInfo returns the narinfo of a store path
+func GetNarInfo(ctx *context.Context) {
+	hash := ctx.PathParam("hash")
+	if !nix_module.IsValidStoreHash(hash) {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	pv, err := nix_service.GetStorePath(ctx, ctx.Package.Owner.ID, hash)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", nix_module.NarInfoContentType)
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if ctx.Req.Method == http.MethodHead {
+		return
+	}
+	_, _ = ctx.Resp.Write([]byte(pd.Metadata.(*nix_module.Metadata).String()))
+}
+
+// UploadNarInfo creates a store path from the uploaded narinfo. The NAR must have been uploaded before.
+func UploadNarInfo(ctx *context.Context) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	m, err := nix_module.ParseNarInfo(upload)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if m.StoreHash() != ctx.PathParam("hash") {
+		apiError(ctx, http.StatusBadRequest, "the store path doesn't match the narinfo file name")
+		return
+	}
+
+	if _, err := nix_service.AddStorePath(ctx, ctx.Doer, ctx.Package.Owner, m); err != nil {
+		switch {
+		case errors.Is(err, packages_model.ErrDuplicatePackageVersion):
+			apiError(ctx, http.StatusConflict, err)
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			apiError(ctx, http.StatusForbidden, err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			apiError(ctx, http.StatusBadRequest, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadNar serves a compressed NAR
+func DownloadNar(ctx *context.Context) {
+	pf, err := nix_service.GetNarFile(ctx, ctx.Package.Owner.ID, ctx.PathParam("filename"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	s, u, _, err := packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadNar stores a compressed NAR which gets referenced by a narinfo uploaded afterwards
+func UploadNar(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	if _, err := nix_module.ParseNarFilename(filename); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	if err := nix_service.UploadNar(ctx, ctx.Doer, ctx.Package.Owner, filename, buf); err != nil {
+		switch {
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			apiError(ctx, http.StatusForbidden, err)
+		case errors.Is(err, util.ErrInvalidArgument):
+			apiError(ctx, http.StatusBadRequest, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 	"sort"
+	"strings"
 	"time"
 
 	packages_model "gitea.dev/models/packages"
@@ -99,11 +100,56 @@ func createPackageMetadataVersion(registryURL string, pd *packages_model.Package
 		Dist: npm_module.PackageDistribution{
 			Shasum:    pd.Files[0].Blob.HashSHA1,
 			Integrity: "sha512-" + base64.StdEncoding.EncodeToString(hashBytes),
-			Tarball:   fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(pd.Package.Name), url.PathEscape(pd.Version.Version), url.PathEscape(pd.Files[0].File.LowerName)),
+			Tarball:   packageTarballURL(registryURL, pd.Package.Name, pd.Version.Version, pd.Files[0].File.LowerName),
 		},
 	}
 }
 
+func packageTarballURL(registryURL, name, version, filename string) string {
+	return fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(name), url.PathEscape(version), url.PathEscape(filename))
+}
+
+// mergeUpstreamPackageMetadata serves the packument of the upstream registry with the tarballs pointing to this registry,
+// the tarballs get pulled on the first download. Versions and tags of this registry take precedence.
+func mergeUpstreamPackageMetadata(registryURL string, metadata *npm_module.PackageMetadata, pds []*packages_model.PackageDescriptor) *npm_module.PackageMetadata {
+	unscopedName := metadata.Name
+	if _, name, ok := strings.Cut(metadata.Name, "/"); ok {
+		unscopedName = name
+	}
+
+	for version, pmv := range metadata.Versions {
+		if pmv == nil {
+			delete(metadata.Versions, version)
+			continue
+		}
+		pmv.Dist.Tarball = packageTarballURL(registryURL, metadata.Name, version, strings.ToLower(fmt.Sprintf("%s-%s.tgz", unscopedName, version)))
+	}
+
+	if metadata.Versions == nil {
+		metadata.Versions = make(map[string]*npm_module.PackageMetadataVersion)
+	}
+	if metadata.DistTags == nil {
+		metadata.DistTags = make(map[string]string)
+	}
+	if metadata.Time == nil {
+		metadata.Time = make(map[string]time.Time)
+	}
+	for _, pd := range pds {
+		metadata.Versions[pd.Version.Version] = createPackageMetadataVersion(registryURL, pd)
+		if _, ok := metadata.Time[pd.Version.Version]; !ok {
+			metadata.Time[pd.Version.Version] = pd.Version.CreatedUnix.AsTimeInLocation(time.UTC)
+		}
+
+		for _, pvp := range pd.VersionProperties {
+			if pvp.Name == npm_module.TagProperty {
+				metadata.DistTags[pvp.Value] = pd.Version.Version
+			}
+		}
+	}
+
+	return metadata
+}
+
 func createPackageSearchResponse(pds []*packages_model.PackageDescriptor, total int64) *npm_module.PackageSearch {
 	objects := make([]*npm_module.PackageSearchObject, 0, len(pds))
 	for _, pd := range pds {
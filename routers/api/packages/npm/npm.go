@@ -18,6 +18,7 @@ import (
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/models/unit"
 	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
 	"gitea.dev/modules/optional"
 	packages_module "gitea.dev/modules/packages"
 	npm_module "gitea.dev/modules/packages/npm"
@@ -26,6 +27,7 @@ import (
 	"gitea.dev/routers/api/packages/helper"
 	"gitea.dev/services/context"
 	packages_service "gitea.dev/services/packages"
+	"gitea.dev/services/packages/upstream"
 
 	"github.com/hashicorp/go-version"
 )
@@ -60,8 +62,14 @@ func PackageMetadata(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
+
+	upstreamMetadata, err := upstream.NpmPackageMetadata(ctx, ctx.Package.Owner.ID, packageName)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		log.Warn("Failed to get the metadata of %s from the upstream registry: %v", packageName, err)
+	}
+
+	if len(pvs) == 0 && upstreamMetadata == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
 		return
 	}
 
@@ -71,8 +79,15 @@ func PackageMetadata(ctx *context.Context) {
 		return
 	}
 
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/npm"
+
+	if upstreamMetadata != nil {
+		ctx.JSON(http.StatusOK, mergeUpstreamPackageMetadata(registryURL, upstreamMetadata, pds))
+		return
+	}
+
 	resp := createPackageMetadataResponse(
-		setting.AppURL+"api/packages/"+ctx.Package.Owner.Name+"/npm",
+		registryURL,
 		pds,
 	)
 
@@ -85,21 +100,24 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypeNpm,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	pi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypeNpm,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+	pfi := &packages_service.PackageFileInfo{
+		Filename: filename,
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pi, pfi, ctx.Req.Method)
+	if errors.Is(err, packages_model.ErrPackageNotExist) {
+		if _, err = upstream.PullNpmPackage(ctx, ctx.Package.Owner, ctx.Doer, packageName, packageVersion); err == nil {
+			s, u, pf, err = packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pi, pfi, ctx.Req.Method)
+		}
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 			return
 		}
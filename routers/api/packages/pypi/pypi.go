@@ -9,18 +9,23 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"unicode"
 
 	packages_model "gitea.dev/models/packages"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/log"
 	packages_module "gitea.dev/modules/packages"
 	pypi_module "gitea.dev/modules/packages/pypi"
 	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
 	"gitea.dev/modules/validation"
 	"gitea.dev/routers/api/packages/helper"
 	"gitea.dev/services/context"
 	packages_service "gitea.dev/services/packages"
+	"gitea.dev/services/packages/upstream"
 )
 
 // https://peps.python.org/pep-0426/#name
@@ -53,10 +58,6 @@ func PackageMetadata(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
-		return
-	}
 
 	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
 	if err != nil {
@@ -64,14 +65,40 @@ func PackageMetadata(ctx *context.Context) {
 		return
 	}
 
+	upstreamFiles, err := upstream.PyPIFiles(ctx, ctx.Package.Owner.ID, packageName)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		log.Warn("Failed to get the files of %s from the upstream registry: %v", packageName, err)
+	}
+
+	// files of this registry take precedence over the upstream ones
+	localFiles := make(container.Set[string])
+	for _, pd := range pds {
+		for _, pf := range pd.Files {
+			localFiles.Add(pf.File.Name)
+		}
+	}
+	upstreamFiles = slices.DeleteFunc(upstreamFiles, func(f *upstream.PyPIFile) bool {
+		return localFiles.Contains(f.Filename) || !isValidNameAndVersion(packageName, f.Version)
+	})
+
+	if len(pds) == 0 && len(upstreamFiles) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
 	// sort package descriptors by version to mimic PyPI format
 	sort.Slice(pds, func(i, j int) bool {
 		return strings.Compare(pds[i].Version.Version, pds[j].Version.Version) < 0
 	})
 
 	ctx.Data["RegistryURL"] = setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
-	ctx.Data["PackageDescriptor"] = pds[0]
+	ctx.Data["PackageName"] = packageName
+	if len(pds) > 0 {
+		ctx.Data["PackageName"] = pds[0].Package.Name
+	}
+	ctx.Data["PackageLowerName"] = strings.ToLower(packageName)
 	ctx.Data["PackageDescriptors"] = pds
+	ctx.Data["UpstreamFiles"] = upstreamFiles
 	ctx.HTML(http.StatusOK, "api/packages/pypi/simple")
 }
 
@@ -81,21 +108,24 @@ func DownloadPackageFile(ctx *context.Context) {
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypePyPI,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	pi := &packages_service.PackageInfo{
+		Owner:       ctx.Package.Owner,
+		PackageType: packages_model.TypePyPI,
+		Name:        packageName,
+		Version:     packageVersion,
+	}
+	pfi := &packages_service.PackageFileInfo{
+		Filename: filename,
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pi, pfi, ctx.Req.Method)
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if _, err = upstream.PullPyPIFile(ctx, ctx.Package.Owner, ctx.Doer, packageName, packageVersion, filename); err == nil {
+			s, u, pf, err = packages_service.OpenFileForDownloadByPackageNameAndVersion(ctx, pi, pfi, ctx.Req.Method)
+		}
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 			return
 		}
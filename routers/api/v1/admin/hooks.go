@@ -202,3 +202,83 @@ func DeleteHook(ctx *context.APIContext) {
 	}
 	ctx.Status(http.StatusNoContent)
 }
+
+// ListHookDeadLetters lists the dead letters of a system hook
+func ListHookDeadLetters(ctx *context.APIContext) {
+	// swagger:operation GET /admin/hooks/{id}/dead_letters admin adminListHookDeadLetters
+	// ---
+	// summary: List the deliveries of a hook which failed and won't be retried
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	hook, err := webhook.GetSystemOrDefaultWebhook(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	utils.ListHookDeadLetters(ctx, hook)
+}
+
+// ReplayHookDeadLetters redelivers the dead letters of a system hook
+func ReplayHookDeadLetters(ctx *context.APIContext) {
+	// swagger:operation POST /admin/hooks/{id}/dead_letters/replay admin adminReplayHookDeadLetters
+	// ---
+	// summary: Redeliver the dead letters of a hook
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReplayHookDeliveriesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	hook, err := webhook.GetSystemOrDefaultWebhook(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	utils.ReplayHookDeadLetters(ctx, hook, web.GetForm[*api.ReplayHookDeliveriesOption](ctx))
+}
@@ -0,0 +1,397 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	quota_model "gitea.dev/models/quota"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// applyQuotaLimitsOption sets the limits given by the option, a limit must be -1 or positive
+func applyQuotaLimitsOption(limits *quota_model.Limits, opt *api.QuotaLimitsOption) error {
+	if opt == nil {
+		return nil
+	}
+	for _, l := range []struct {
+		value  *int64
+		target *int64
+	}{
+		{opt.Total, &limits.Total},
+		{opt.Git, &limits.Git},
+		{opt.LFS, &limits.LFS},
+		{opt.Attachments, &limits.Attachments},
+		{opt.Artifacts, &limits.Artifacts},
+		{opt.Packages, &limits.Packages},
+	} {
+		if l.value == nil {
+			continue
+		}
+		if *l.value < -1 {
+			return util.NewInvalidArgumentErrorf("invalid limit %d, use -1 for no limit", *l.value)
+		}
+		*l.target = *l.value
+	}
+	return nil
+}
+
+func getQuotaGroupByContext(ctx *context.APIContext) *quota_model.Group {
+	g, err := quota_model.GetGroupByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return g
+}
+
+func getQuotaOwnerByContext(ctx *context.APIContext) *user_model.User {
+	owner, err := user_model.GetUserByName(ctx, ctx.PathParam("username"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil
+	}
+	return owner
+}
+
+// ListQuotaGroups lists all quota groups
+func ListQuotaGroups(ctx *context.APIContext) {
+	// swagger:operation GET /admin/quota/groups admin adminListQuotaGroups
+	// ---
+	// summary: List the quota groups
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaGroupList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	groups, err := quota_model.GetGroups(ctx)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	res := make([]*api.QuotaGroup, 0, len(groups))
+	for _, g := range groups {
+		res = append(res, convert.ToQuotaGroup(g))
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateQuotaGroup creates a quota group
+func CreateQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation POST /admin/quota/groups admin adminCreateQuotaGroup
+	// ---
+	// summary: Create a quota group
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateQuotaGroupOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/QuotaGroup"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.CreateQuotaGroupOption](ctx)
+
+	limits := quota_model.Unlimited()
+	if err := applyQuotaLimitsOption(&limits, form.Limits); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+
+	g := &quota_model.Group{Name: form.Name}
+	g.SetLimits(limits)
+	if err := quota_model.CreateGroup(ctx, g); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToQuotaGroup(g))
+}
+
+// GetQuotaGroup gets a quota group
+func GetQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation GET /admin/quota/groups/{id} admin adminGetQuotaGroup
+	// ---
+	// summary: Get a quota group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the quota group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaGroup"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getQuotaGroupByContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToQuotaGroup(g))
+}
+
+// EditQuotaGroup edits the name and the limits of a quota group
+func EditQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation PATCH /admin/quota/groups/{id} admin adminEditQuotaGroup
+	// ---
+	// summary: Edit a quota group
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the quota group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/EditQuotaGroupOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaGroup"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.EditQuotaGroupOption](ctx)
+
+	g := getQuotaGroupByContext(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Name != nil && *form.Name != "" {
+		g.Name = *form.Name
+	}
+	limits := g.Limits()
+	if err := applyQuotaLimitsOption(&limits, form.Limits); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	g.SetLimits(limits)
+
+	if err := quota_model.UpdateGroup(ctx, g); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToQuotaGroup(g))
+}
+
+// DeleteQuotaGroup deletes a quota group
+func DeleteQuotaGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/quota/groups/{id} admin adminDeleteQuotaGroup
+	// ---
+	// summary: Delete a quota group, the users and organizations of the group get the default groups unless they are in other groups
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the quota group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getQuotaGroupByContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.DeleteGroup(ctx, g.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListQuotaGroupOwners lists the users and organizations a quota group is assigned to
+func ListQuotaGroupOwners(ctx *context.APIContext) {
+	// swagger:operation GET /admin/quota/groups/{id}/owners admin adminListQuotaGroupOwners
+	// ---
+	// summary: List the users and organizations a quota group is assigned to
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the quota group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getQuotaGroupByContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	ownerIDs, err := quota_model.GetGroupOwnerIDs(ctx, g.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	owners, err := user_model.GetUsersByIDs(ctx, ownerIDs)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToUsers(ctx, ctx.Doer, owners))
+}
+
+// AddQuotaGroupOwner assigns a quota group to a user or an organization
+func AddQuotaGroupOwner(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/quota/groups/{id}/owners/{username} admin adminAddQuotaGroupOwner
+	// ---
+	// summary: Assign a quota group to a user or an organization
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the quota group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: name of the user or the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getQuotaGroupByContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	owner := getQuotaOwnerByContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.AddGroupOwner(ctx, g.ID, owner.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveQuotaGroupOwner removes a quota group from a user or an organization
+func RemoveQuotaGroupOwner(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/quota/groups/{id}/owners/{username} admin adminRemoveQuotaGroupOwner
+	// ---
+	// summary: Remove a quota group from a user or an organization
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the quota group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: name of the user or the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getQuotaGroupByContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	owner := getQuotaOwnerByContext(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := quota_model.RemoveGroupOwner(ctx, g.ID, owner.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetUserQuota returns the storage usage and the limits of a user or an organization
+func GetUserQuota(ctx *context.APIContext) {
+	// swagger:operation GET /admin/users/{username}/quota admin adminGetUserQuota
+	// ---
+	// summary: Get the storage usage and the quota limits of a user or an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: name of the user or the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/QuotaInfo"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetQuota(ctx, ctx.ContextUser.ID)
+}
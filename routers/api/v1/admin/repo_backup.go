@@ -0,0 +1,192 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.dev/models/perm"
+	access_model "gitea.dev/models/perm/access"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	backup_service "gitea.dev/services/repository/backup"
+)
+
+func toRepoBackups(ctx *context.APIContext, m *backup_service.Manifest) (*api.RepoBackups, error) {
+	exist := true
+	if _, err := repo_model.GetRepositoryByID(ctx, m.RepoID); repo_model.IsErrRepoNotExist(err) {
+		exist = false
+	} else if err != nil {
+		return nil, err
+	}
+	res := &api.RepoBackups{
+		RepoID:     m.RepoID,
+		OwnerName:  m.OwnerName,
+		RepoName:   m.RepoName,
+		RepoExists: exist,
+		Backups:    make([]*api.RepoBackup, 0, len(m.Backups)),
+	}
+	for _, b := range m.Backups {
+		res.Backups = append(res.Backups, &api.RepoBackup{
+			ID:          b.ID,
+			Created:     b.Created,
+			Incremental: b.IsIncremental(),
+			Parent:      b.Parent,
+			Size:        b.Size,
+		})
+	}
+	return res, nil
+}
+
+// ListRepoBackups lists the repositories which have backups
+func ListRepoBackups(ctx *context.APIContext) {
+	// swagger:operation GET /admin/backups/repos admin adminListRepoBackups
+	// ---
+	// summary: List the backups of all repositories, including the deleted ones
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoBackupsList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	manifests, count, err := backup_service.ListManifests(utils.GetListOptions(ctx))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.RepoBackups, 0, len(manifests))
+	for _, m := range manifests {
+		backups, err := toRepoBackups(ctx, m)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		res = append(res, backups)
+	}
+
+	ctx.SetTotalCountHeader(int64(count))
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetRepoBackups returns the backups of a repository
+func GetRepoBackups(ctx *context.APIContext) {
+	// swagger:operation GET /admin/backups/repos/{id} admin adminGetRepoBackups
+	// ---
+	// summary: Get the backups of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the backed up repository
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoBackups"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	m, err := backup_service.GetManifest(ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	res, err := toRepoBackups(ctx, m)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RestoreRepoBackup restores a repository from its backups
+func RestoreRepoBackup(ctx *context.APIContext) {
+	// swagger:operation POST /admin/backups/repos/{id}/restore admin adminRestoreRepoBackup
+	// ---
+	// summary: Restore a repository from its backups as a new repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the backed up repository
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/RestoreRepoBackupOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Repository"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.RestoreRepoBackupOption](ctx)
+	if form.Owner != "" {
+		if _, err := user_model.GetUserByName(ctx, form.Owner); err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+	}
+
+	repo, err := backup_service.RestoreRepository(ctx, ctx.PathParamInt64("id"), backup_service.RestoreOptions{
+		BackupID:  form.BackupID,
+		OwnerName: form.Owner,
+		RepoName:  form.RepoName,
+	})
+	if err != nil {
+		switch {
+		case repo_model.IsErrRepoAlreadyExist(err):
+			ctx.APIError(http.StatusConflict, "The repository with the same name already exists.")
+		case errors.Is(err, util.ErrNotExist):
+			ctx.APIErrorNotFound(err.Error())
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}))
+}
@@ -641,6 +641,26 @@ func reqGitHook() func(ctx *context.APIContext) {
 	}
 }
 
+// reqSecretScanningEnabled requires secret scanning to be enabled by admin.
+func reqSecretScanningEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.SecretScanning.Enabled {
+			ctx.APIError(http.StatusForbidden, "secret scanning disabled by administrator")
+			return
+		}
+	}
+}
+
+// reqCodeNavigationEnabled requires code navigation to be enabled by admin.
+func reqCodeNavigationEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.CodeNavigation.Enabled {
+			ctx.APIError(http.StatusForbidden, "code navigation disabled by administrator")
+			return
+		}
+	}
+}
+
 // reqWebhooksEnabled requires webhooks to be enabled by admin.
 func reqWebhooksEnabled() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -828,6 +848,9 @@ func addProjectRoutes(m *web.Router, writeChecks ...any) {
 	m.Group("/{id}", func() {
 		m.Get("", shared.GetProject)
 		m.Get("/columns", shared.ListProjectColumns)
+		m.Get("/automations", shared.ListProjectAutomations)
+		m.Get("/fields", shared.ListProjectFields)
+		m.Get("/issues/{issue_id}/fields", shared.ListProjectIssueFieldValues)
 		m.Group("/columns/{column_id}", func() {
 			m.Get("", shared.GetProjectColumn)
 			m.Get("/issues", shared.ListProjectColumnIssues)
@@ -848,6 +871,17 @@ func addProjectRoutes(m *web.Router, writeChecks ...any) {
 				m.Delete("/issues/{issue_id}", shared.RemoveIssueFromProjectColumn)
 			})
 			m.Post("/issues/{issue_id}/move", bind(api.MoveProjectIssueOption{}), shared.MoveProjectIssue)
+			m.Post("/automations", bind(api.CreateProjectAutomationOption{}), shared.CreateProjectAutomation)
+			m.Group("/automations/{automation_id}", func() {
+				m.Patch("", bind(api.EditProjectAutomationOption{}), shared.EditProjectAutomation)
+				m.Delete("", shared.DeleteProjectAutomation)
+			})
+			m.Post("/fields", bind(api.CreateProjectFieldOption{}), shared.CreateProjectField)
+			m.Group("/fields/{field_id}", func() {
+				m.Patch("", bind(api.EditProjectFieldOption{}), shared.EditProjectField)
+				m.Delete("", shared.DeleteProjectField)
+			})
+			m.Put("/issues/{issue_id}/fields/{field_id}", bind(api.SetProjectIssueFieldValueOption{}), shared.SetProjectIssueFieldValue)
 		})
 	}, writeChecks...)
 }
@@ -1176,6 +1210,7 @@ func Routes() *web.Router {
 				m.Get("", user.GetUserSettings)
 				m.Patch("", bind(api.UserSettingsOptions{}), user.UpdateUserSettings)
 			}, rejectPublicOnly())
+			m.Get("/quota", rejectPublicOnly(), user.GetQuota)
 			m.Group("/projects", func() {
 				addProjectRoutes(m, reqToken())
 			}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryIssue))
@@ -1273,9 +1308,13 @@ func Routes() *web.Router {
 			m.Group("/hooks", func() {
 				m.Combo("").Get(user.ListHooks).
 					Post(bind(api.CreateHookOption{}), user.CreateHook)
-				m.Combo("/{id}").Get(user.GetHook).
-					Patch(bind(api.EditHookOption{}), user.EditHook).
-					Delete(user.DeleteHook)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(user.GetHook).
+						Patch(bind(api.EditHookOption{}), user.EditHook).
+						Delete(user.DeleteHook)
+					m.Get("/dead_letters", user.ListHookDeadLetters)
+					m.Post("/dead_letters/replay", bind(api.ReplayHookDeliveriesOption{}), user.ReplayHookDeadLetters)
+				})
 			}, reqWebhooksEnabled(), rejectPublicOnly())
 
 			m.Group("/avatar", func() {
@@ -1358,6 +1397,8 @@ func Routes() *web.Router {
 							Patch(bind(api.EditHookOption{}), repo.EditHook).
 							Delete(repo.DeleteHook)
 						m.Post("/tests", context.ReferencesGitRepo(), context.RepoRefForAPI, repo.TestHook)
+						m.Get("/dead_letters", repo.ListHookDeadLetters)
+						m.Post("/dead_letters/replay", bind(api.ReplayHookDeliveriesOption{}), repo.ReplayHookDeadLetters)
 					})
 				}, reqToken(), reqAdmin(), reqWebhooksEnabled())
 				m.Group("/collaborators", func() {
@@ -1417,6 +1458,22 @@ func Routes() *web.Router {
 							Delete(repo.DeleteTagProtection)
 					})
 				}, reqToken(), reqAdmin())
+				m.Combo("/push_rule", reqToken(), reqAdmin()).Get(repo.GetPushRule).
+					Put(bind(api.SetPushRuleOption{}), mustNotBeArchived, repo.SetPushRule).
+					Delete(repo.DeletePushRule)
+				m.Group("/secret_scanning", func() {
+					m.Get("/alerts", repo.ListSecretScanningAlerts)
+					m.Combo("/alerts/{id}").Get(repo.GetSecretScanningAlert).
+						Patch(bind(api.EditSecretScanningAlertOption{}), repo.EditSecretScanningAlert)
+					m.Post("/scan", repo.ScanSecrets)
+				}, reqToken(), reqAdmin(), reqSecretScanningEnabled())
+				m.Group("/code_navigation", func() {
+					m.Get("/indexes", repo.ListCodeNavigationIndexes)
+					m.Combo("/indexes/{sha}", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived).
+						Put(repo.UploadCodeNavigationIndex).
+						Delete(repo.DeleteCodeNavigationIndex)
+					m.Get("/lookup", repo.LookupCodeNavigation)
+				}, reqRepoReader(unit.TypeCode), reqCodeNavigationEnabled(), context.ReferencesGitRepo())
 				m.Group("/actions", func() {
 					m.Get("/tasks", repo.ListActionTasks)
 					m.Group("/runs", func() {
@@ -1509,10 +1566,15 @@ func Routes() *web.Router {
 				}, reqAdmin(), reqToken())
 
 				m.Get("/editorconfig/{filename}", context.ReferencesGitRepo(), context.RepoRefForAPI, reqRepoReader(unit.TypeCode), repo.GetEditorconfig)
+				m.Group("/codeowners", func() {
+					m.Get("", repo.GetCodeOwners)
+					m.Get("/validate", repo.ValidateCodeOwners)
+				}, context.ReferencesGitRepo(), context.RepoRefForAPI, reqRepoReader(unit.TypeCode))
 				m.Group("/pulls", func() {
 					m.Combo("").Get(repo.ListPullRequests).
 						Post(reqToken(), mustNotBeArchived, bind(api.CreatePullRequestOption{}), repo.CreatePullRequest)
 					m.Get("/pinned", repo.ListPinnedPullRequests)
+					m.Get("/merge_queue", repo.ListMergeQueue)
 					m.Post("/comments/{id}/resolve", reqToken(), mustNotBeArchived, repo.ResolvePullReviewComment)
 					m.Post("/comments/{id}/unresolve", reqToken(), mustNotBeArchived, repo.UnresolvePullReviewComment)
 					m.Group("/{index}", func() {
@@ -1522,9 +1584,12 @@ func Routes() *web.Router {
 						m.Post("/update", reqToken(), repo.UpdatePullRequest)
 						m.Get("/commits", repo.GetPullRequestCommits)
 						m.Get("/files", repo.GetPullRequestFiles)
+						m.Get("/codeowners", repo.GetPullRequestCodeOwners)
 						m.Combo("/merge").Get(repo.IsPullRequestMerged).
 							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), repo.MergePullRequest).
 							Delete(reqToken(), mustNotBeArchived, repo.CancelScheduledAutoMerge)
+						m.Combo("/merge_queue").Get(repo.GetPullRequestMergeQueueEntry).
+							Delete(reqToken(), mustNotBeArchived, repo.RemovePullRequestFromMergeQueue)
 						m.Group("/reviews", func() {
 							m.Combo("").
 								Get(repo.ListPullReviews).
@@ -1672,6 +1737,7 @@ func Routes() *web.Router {
 						m.Combo("").Get(repo.GetIssue).
 							Patch(reqToken(), bind(api.EditIssueOption{}), repo.EditIssue).
 							Delete(reqToken(), reqAdmin(), context.ReferencesGitRepo(), repo.DeleteIssue)
+						m.Get("/form_values", repo.GetIssueFormValues)
 						m.Combo("/assignees").
 							Post(reqToken(), mustNotBeArchived, bind(api.IssueAssigneesOption{}), repo.AddIssueAssignees).
 							Delete(reqToken(), mustNotBeArchived, bind(api.IssueAssigneesOption{}), repo.DeleteIssueAssignees)
@@ -1798,6 +1864,7 @@ func Routes() *web.Router {
 				Patch(reqToken(), reqOrgOwnership(), bind(api.EditOrgOption{}), org.Edit).
 				Delete(reqToken(), reqOrgOwnership(), org.Delete)
 			m.Post("/rename", reqToken(), reqOrgOwnership(), bind(api.RenameOrgOption{}), org.Rename)
+			m.Get("/quota", reqToken(), reqOrgMembership(), org.GetQuota)
 			m.Combo("/repos").Get(user.ListOrgRepos).
 				Post(reqToken(), bind(api.CreateRepoOption{}), repo.CreateOrgRepo).
 				Delete(reqToken(), reqOrgOwnership(), tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository), org.DeleteOrgRepos)
@@ -1836,16 +1903,28 @@ func Routes() *web.Router {
 			m.Group("/hooks", func() {
 				m.Combo("").Get(org.ListHooks).
 					Post(bind(api.CreateHookOption{}), org.CreateHook)
-				m.Combo("/{id}").Get(org.GetHook).
-					Patch(bind(api.EditHookOption{}), org.EditHook).
-					Delete(org.DeleteHook)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(org.GetHook).
+						Patch(bind(api.EditHookOption{}), org.EditHook).
+						Delete(org.DeleteHook)
+					m.Get("/dead_letters", org.ListHookDeadLetters)
+					m.Post("/dead_letters/replay", bind(api.ReplayHookDeliveriesOption{}), org.ReplayHookDeadLetters)
+				})
 			}, reqToken(), reqOrgOwnership(), reqWebhooksEnabled())
 			m.Group("/avatar", func() {
 				m.Post("", bind(api.UpdateUserAvatarOption{}), org.UpdateAvatar)
 				m.Delete("", org.DeleteAvatar)
 			}, reqToken(), reqOrgOwnership())
+			m.Combo("/push_rule", reqToken(), reqOrgOwnership()).Get(org.GetPushRule).
+				Put(bind(api.SetPushRuleOption{}), org.SetPushRule).
+				Delete(org.DeletePushRule)
 			m.Get("/activities/feeds", org.ListOrgActivityFeeds)
 
+			m.Group("/audit_log", func() {
+				m.Get("", org.ListAuditEvents)
+				m.Get("/export", org.ExportAuditEvents)
+			}, reqToken(), reqOrgOwnership())
+
 			m.Group("/blocks", func() {
 				m.Get("", org.ListBlocks)
 				m.Group("/{username}", func() {
@@ -1887,6 +1966,26 @@ func Routes() *web.Router {
 				m.Get("", admin.ListCronTasks)
 				m.Post("/{task}", admin.PostCronTask)
 			})
+			m.Group("/backups/repos", func() {
+				m.Get("", admin.ListRepoBackups)
+				m.Group("/{id}", func() {
+					m.Get("", admin.GetRepoBackups)
+					m.Post("/restore", bind(api.RestoreRepoBackupOption{}), admin.RestoreRepoBackup)
+				})
+			})
+			m.Group("/quota/groups", func() {
+				m.Combo("").Get(admin.ListQuotaGroups).
+					Post(bind(api.CreateQuotaGroupOption{}), admin.CreateQuotaGroup)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(admin.GetQuotaGroup).
+						Patch(bind(api.EditQuotaGroupOption{}), admin.EditQuotaGroup).
+						Delete(admin.DeleteQuotaGroup)
+					m.Get("/owners", admin.ListQuotaGroupOwners)
+					m.Combo("/owners/{username}").
+						Put(admin.AddQuotaGroupOwner).
+						Delete(admin.RemoveQuotaGroupOwner)
+				})
+			})
 			m.Get("/orgs", admin.GetAllOrgs)
 			m.Group("/users", func() {
 				m.Get("", admin.SearchUsers)
@@ -1905,6 +2004,7 @@ func Routes() *web.Router {
 					m.Get("/badges", admin.ListUserBadges)
 					m.Post("/badges", bind(api.UserBadgeOption{}), admin.AddUserBadges)
 					m.Delete("/badges", bind(api.UserBadgeOption{}), admin.DeleteUserBadges)
+					m.Get("/quota", admin.GetUserQuota)
 				}, context.UserAssignmentAPI())
 			})
 			m.Group("/emails", func() {
@@ -1916,12 +2016,20 @@ func Routes() *web.Router {
 				m.Post("/{username}/{reponame}", admin.AdoptRepository)
 				m.Delete("/{username}/{reponame}", admin.DeleteUnadoptedRepository)
 			})
+			m.Group("/audit_log", func() {
+				m.Get("", admin.ListAuditEvents)
+				m.Get("/export", admin.ExportAuditEvents)
+			})
 			m.Group("/hooks", func() {
 				m.Combo("").Get(admin.ListHooks).
 					Post(bind(api.CreateHookOption{}), admin.CreateHook)
-				m.Combo("/{id}").Get(admin.GetHook).
-					Patch(bind(api.EditHookOption{}), admin.EditHook).
-					Delete(admin.DeleteHook)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(admin.GetHook).
+						Patch(bind(api.EditHookOption{}), admin.EditHook).
+						Delete(admin.DeleteHook)
+					m.Get("/dead_letters", admin.ListHookDeadLetters)
+					m.Post("/dead_letters/replay", bind(api.ReplayHookDeliveriesOption{}), admin.ReplayHookDeadLetters)
+				})
 			})
 			m.Group("/actions", func() {
 				m.Group("/runners", func() {
@@ -0,0 +1,125 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+)
+
+// ListAuditEvents lists the audit log of an organization
+func ListAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/audit_log organization orgListAuditEvents
+	// ---
+	// summary: List the audit log of an organization and its repositories, most recent events first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: action
+	//   in: query
+	//   description: only show events of this action, e.g. "protected_branch.update"
+	//   type: string
+	// - name: target_type
+	//   in: query
+	//   description: only show events of this kind of target, e.g. "repository"
+	//   type: string
+	// - name: actor_id
+	//   in: query
+	//   description: only show events made by the user with this ID
+	//   type: integer
+	//   format: int64
+	// - name: repo_id
+	//   in: query
+	//   description: only show events on the repository with this ID
+	//   type: integer
+	//   format: int64
+	// - name: since
+	//   in: query
+	//   description: only show events recorded at or after the given time (RFC 3339 format)
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only show events recorded before the given time (RFC 3339 format)
+	//   type: string
+	//   format: date-time
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.ListAuditEvents(ctx, ctx.Org.Organization.ID)
+}
+
+// ExportAuditEvents exports the audit log of an organization
+func ExportAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/audit_log/export organization orgExportAuditEvents
+	// ---
+	// summary: Export the audit log of an organization and its repositories as JSON lines, oldest events first
+	// produces:
+	// - application/x-ndjson
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: action
+	//   in: query
+	//   description: only show events of this action, e.g. "protected_branch.update"
+	//   type: string
+	// - name: target_type
+	//   in: query
+	//   description: only show events of this kind of target, e.g. "repository"
+	//   type: string
+	// - name: actor_id
+	//   in: query
+	//   description: only show events made by the user with this ID
+	//   type: integer
+	//   format: int64
+	// - name: repo_id
+	//   in: query
+	//   description: only show events on the repository with this ID
+	//   type: integer
+	//   format: int64
+	// - name: since
+	//   in: query
+	//   description: only show events recorded at or after the given time (RFC 3339 format)
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only show events recorded before the given time (RFC 3339 format)
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     description: one AuditEvent JSON object per line
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.ExportAuditEvents(ctx, ctx.Org.Organization.ID)
+}
@@ -187,3 +187,83 @@ func DeleteHook(ctx *context.APIContext) {
 		ctx.PathParamInt64("id"),
 	)
 }
+
+// ListHookDeadLetters lists the dead letters of an organization's hook
+func ListHookDeadLetters(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/hooks/{id}/dead_letters organization orgListHookDeadLetters
+	// ---
+	// summary: List the deliveries of a hook which failed and won't be retried
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	hook, err := utils.GetOwnerHook(ctx, ctx.ContextUser.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		return
+	}
+	utils.ListHookDeadLetters(ctx, hook)
+}
+
+// ReplayHookDeadLetters redelivers the dead letters of an organization's hook
+func ReplayHookDeadLetters(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/hooks/{id}/dead_letters/replay organization orgReplayHookDeadLetters
+	// ---
+	// summary: Redeliver the dead letters of a hook
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the hook
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReplayHookDeliveriesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	hook, err := utils.GetOwnerHook(ctx, ctx.ContextUser.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		return
+	}
+	utils.ReplayHookDeadLetters(ctx, hook, web.GetForm[*api.ReplayHookDeliveriesOption](ctx))
+}
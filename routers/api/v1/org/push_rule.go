@@ -0,0 +1,90 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+)
+
+// GetPushRule returns the push rule of an organization
+func GetPushRule(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/push_rule organization orgGetPushRule
+	// ---
+	// summary: Get the push rule of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetPushRule(ctx, ctx.Org.Organization.AsUser(), nil)
+}
+
+// SetPushRule creates or replaces the push rule of an organization
+func SetPushRule(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/push_rule organization orgSetPushRule
+	// ---
+	// summary: Create or replace the push rule of an organization, it applies to all repositories of the organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SetPushRuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.SetPushRule(ctx, ctx.Org.Organization.AsUser(), nil)
+}
+
+// DeletePushRule deletes the push rule of an organization
+func DeletePushRule(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/push_rule organization orgDeletePushRule
+	// ---
+	// summary: Delete the push rule of an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeletePushRule(ctx, ctx.Org.Organization.AsUser(), nil)
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	activities_model "gitea.dev/models/activities"
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/organization"
 	"gitea.dev/models/perm"
 	access_model "gitea.dev/models/perm/access"
@@ -20,6 +21,7 @@ import (
 	"gitea.dev/modules/web"
 	"gitea.dev/routers/api/v1/user"
 	"gitea.dev/routers/api/v1/utils"
+	audit_service "gitea.dev/services/audit"
 	"gitea.dev/services/context"
 	"gitea.dev/services/convert"
 	feed_service "gitea.dev/services/feed"
@@ -248,6 +250,7 @@ func CreateTeam(ctx *context.APIContext) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamCreate, audit_service.TeamTarget(team), nil, audit_service.TeamState(ctx, team))
 
 	apiTeam, err := convert.ToTeam(ctx, team, true)
 	if err != nil {
@@ -288,6 +291,7 @@ func EditTeam(ctx *context.APIContext) {
 		ctx.APIErrorInternal(err)
 		return
 	}
+	teamBefore := audit_service.TeamState(ctx, team)
 
 	if form.CanCreateOrgRepo != nil {
 		team.CanCreateOrgRepo = team.IsOwnerTeam() || *form.CanCreateOrgRepo
@@ -335,6 +339,7 @@ func EditTeam(ctx *context.APIContext) {
 		ctx.APIErrorInternal(err)
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamUpdate, audit_service.TeamTarget(team), teamBefore, audit_service.TeamState(ctx, team))
 
 	apiTeam, err := convert.ToTeam(ctx, team)
 	if err != nil {
@@ -362,10 +367,12 @@ func DeleteTeam(ctx *context.APIContext) {
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
+	teamBefore := audit_service.TeamState(ctx, ctx.Org.Team)
 	if err := org_service.DeleteTeam(ctx, ctx.Org.Team); err != nil {
 		ctx.APIErrorInternal(err)
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamDelete, audit_service.TeamTarget(ctx.Org.Team), teamBefore, nil)
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -489,7 +496,7 @@ func AddTeamMember(ctx *context.APIContext) {
 	if ctx.Written() {
 		return
 	}
-	if err := org_service.AddTeamMember(ctx, ctx.Org.Team, u); err != nil {
+	if err := org_service.AddTeamMember(ctx, ctx.Doer, ctx.Org.Team, u); err != nil {
 		if errors.Is(err, user_model.ErrBlockedUser) {
 			ctx.APIError(http.StatusForbidden, err.Error())
 		} else {
@@ -530,7 +537,7 @@ func RemoveTeamMember(ctx *context.APIContext) {
 		return
 	}
 
-	if err := org_service.RemoveTeamMember(ctx, ctx.Org.Team, u); err != nil {
+	if err := org_service.RemoveTeamMember(ctx, ctx.Doer, ctx.Org.Team, u); err != nil {
 		ctx.APIErrorInternal(err)
 		return
 	}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net/http"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	git_model "gitea.dev/models/git"
 	"gitea.dev/models/organization"
@@ -20,6 +21,7 @@ import (
 	"gitea.dev/modules/util"
 	"gitea.dev/modules/web"
 	"gitea.dev/routers/api/v1/utils"
+	audit_service "gitea.dev/services/audit"
 	"gitea.dev/services/context"
 	"gitea.dev/services/convert"
 	pull_service "gitea.dev/services/pull"
@@ -805,6 +807,8 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		UnprotectedFilePatterns:       form.UnprotectedFilePatterns,
 		BlockOnOutdatedBranch:         form.BlockOnOutdatedBranch,
 		BlockAdminMergeOverride:       form.BlockAdminMergeOverride,
+		EnableMergeQueue:              form.EnableMergeQueue,
+		MergeQueueMaxGroupSize:        max(form.MergeQueueMaxGroupSize, 0),
 	}
 
 	if err := pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
@@ -834,7 +838,9 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, convert.ToBranchProtection(ctx, bp, repo))
+	apiBp := convert.ToBranchProtection(ctx, bp, repo)
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionProtectedBranchCreate, audit_service.ProtectedBranchTarget(repo, bp), nil, apiBp)
+	ctx.JSON(http.StatusCreated, apiBp)
 }
 
 // EditBranchProtection edits a branch protection for a repo
@@ -887,6 +893,7 @@ func EditBranchProtection(ctx *context.APIContext) {
 		ctx.APIErrorNotFound()
 		return
 	}
+	protectBranchBefore := convert.ToBranchProtection(ctx, protectBranch, repo)
 
 	if form.EnablePush != nil {
 		if !*form.EnablePush {
@@ -998,6 +1005,14 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.BlockAdminMergeOverride = *form.BlockAdminMergeOverride
 	}
 
+	if form.EnableMergeQueue != nil {
+		protectBranch.EnableMergeQueue = *form.EnableMergeQueue
+	}
+
+	if form.MergeQueueMaxGroupSize != nil && *form.MergeQueueMaxGroupSize >= 0 {
+		protectBranch.MergeQueueMaxGroupSize = *form.MergeQueueMaxGroupSize
+	}
+
 	var whitelistUsers, forcePushAllowlistUsers, mergeWhitelistUsers, approvalsWhitelistUsers, bypassAllowlistUsers []int64
 	if form.PushWhitelistUsernames != nil {
 		whitelistUsers, err = user_model.GetUserIDsByNames(ctx, form.PushWhitelistUsernames, false)
@@ -1207,7 +1222,9 @@ func EditBranchProtection(ctx *context.APIContext) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, convert.ToBranchProtection(ctx, bp, repo))
+	apiBp := convert.ToBranchProtection(ctx, bp, repo)
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionProtectedBranchUpdate, audit_service.ProtectedBranchTarget(repo, bp), protectBranchBefore, apiBp)
+	ctx.JSON(http.StatusOK, apiBp)
 }
 
 // DeleteBranchProtection deletes a branch protection for a repo
@@ -1251,10 +1268,12 @@ func DeleteBranchProtection(ctx *context.APIContext) {
 		return
 	}
 
+	bpBefore := convert.ToBranchProtection(ctx, bp, repo)
 	if err := git_model.DeleteProtectedBranch(ctx, ctx.Repo.Repository, bp.ID); err != nil {
 		ctx.APIErrorInternal(err)
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionProtectedBranchDelete, audit_service.ProtectedBranchTarget(repo, bp), bpBefore, nil)
 
 	ctx.Status(http.StatusNoContent)
 }
@@ -0,0 +1,257 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	codenav_model "gitea.dev/models/codenav"
+	"gitea.dev/models/db"
+	"gitea.dev/modules/git"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/routers/api/v1/utils"
+	codenav_service "gitea.dev/services/codenav"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// ListCodeNavigationIndexes lists the code navigation indexes of a repository
+func ListCodeNavigationIndexes(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/code_navigation/indexes repository repoListCodeNavigationIndexes
+	// ---
+	// summary: List the code navigation indexes of a repository, most recent first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeNavigationIndexList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	opts := codenav_model.FindIndexesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+		IsReady:     true,
+	}
+	indexes, total, err := db.FindAndCount[codenav_model.Index](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiIndexes := make([]*api.CodeNavigationIndex, 0, len(indexes))
+	for _, idx := range indexes {
+		apiIndexes = append(apiIndexes, convert.ToCodeNavigationIndex(idx))
+	}
+
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiIndexes)
+}
+
+// UploadCodeNavigationIndex stores a SCIP index for a commit of a repository
+func UploadCodeNavigationIndex(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/code_navigation/indexes/{sha} repository repoUploadCodeNavigationIndex
+	// ---
+	// summary: Upload a SCIP index for a commit, replacing the index previously uploaded for it
+	// consumes:
+	// - application/octet-stream
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: the commit the index has been generated from
+	//   type: string
+	//   required: true
+	// - name: root
+	//   in: query
+	//   description: the directory of the repository the paths of the index are relative to, the root of the repository by default
+	//   type: string
+	// - name: body
+	//   in: body
+	//   description: the index, in the SCIP protobuf format
+	//   required: true
+	//   schema:
+	//     type: string
+	//     format: binary
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CodeNavigationIndex"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+
+	commit := getCodeNavigationCommit(ctx, ctx.PathParam("sha"))
+	if ctx.Written() {
+		return
+	}
+	idx, err := codenav_service.UploadIndex(ctx, ctx.Doer, ctx.Repo.Repository, commit, ctx.FormString("root"), ctx.Req.Body)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToCodeNavigationIndex(idx))
+}
+
+// DeleteCodeNavigationIndex deletes the code navigation index of a commit of a repository
+func DeleteCodeNavigationIndex(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/code_navigation/indexes/{sha} repository repoDeleteCodeNavigationIndex
+	// ---
+	// summary: Delete the code navigation index of a commit
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: the commit of the index
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	commit := getCodeNavigationCommit(ctx, ctx.PathParam("sha"))
+	if ctx.Written() {
+		return
+	}
+	if err := codenav_service.DeleteIndex(ctx, ctx.Repo.Repository, commit.ID.String()); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// LookupCodeNavigation returns the definitions and references of the symbol at a position in a file
+func LookupCodeNavigation(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/code_navigation/lookup repository repoLookupCodeNavigation
+	// ---
+	// summary: Get the definitions and references of the symbol at a position in a file
+	// description: The index of the commit is used if it covers the file, otherwise the files of the commit are searched for the word.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: the name of the commit/branch/tag
+	//   type: string
+	//   required: true
+	// - name: path
+	//   in: query
+	//   description: the path of the file
+	//   type: string
+	//   required: true
+	// - name: line
+	//   in: query
+	//   description: the line of the position (1-based)
+	//   type: integer
+	//   required: true
+	// - name: character
+	//   in: query
+	//   description: the offset of the position in the line, in UTF-16 code units (0-based)
+	//   type: integer
+	// - name: word
+	//   in: query
+	//   description: the identifier at the position, searched for when no index covers the position
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeNavigation"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	commit := getCodeNavigationCommit(ctx, ctx.FormString("ref"))
+	if ctx.Written() {
+		return
+	}
+	nav, err := codenav_service.Navigate(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, commit, codenav_service.NavigateOptions{
+		Path:      ctx.FormString("path"),
+		Line:      ctx.FormInt("line"),
+		Character: ctx.FormInt("character"),
+		Word:      ctx.FormString("word"),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToCodeNavigation(ctx, ctx.Repo.Repository, commit.ID.String(), nav))
+}
+
+func getCodeNavigationCommit(ctx *context.APIContext, ref string) *git.Commit {
+	if ref == "" {
+		ctx.APIErrorNotFound("commit not found")
+		return nil
+	}
+	commit, err := ctx.Repo.GitRepo.GetCommit(ctx, ref)
+	if git.IsErrNotExist(err) {
+		ctx.APIErrorNotFound("commit not found")
+		return nil
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	return commit
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+
+	issues_model "gitea.dev/models/issues"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	issue_service "gitea.dev/services/issue"
+)
+
+// maxCodeOwnersPaths is the maximum number of paths whose code owners can be requested at once
+const maxCodeOwnersPaths = 100
+
+func toCodeOwnersFileOwners(codeOwners *issue_service.CodeOwners, files []string) *api.CodeOwnersFileOwners {
+	result := &api.CodeOwnersFileOwners{
+		Files:    make([]*api.FileCodeOwners, 0, len(files)),
+		Complete: true,
+	}
+	var matches map[string][]*issues_model.CodeOwnerRule
+	if codeOwners != nil {
+		result.CodeOwnersPath = codeOwners.Path
+		matches, result.Complete = codeOwners.MatchFiles(files)
+	}
+	for _, file := range files {
+		result.Files = append(result.Files, convert.ToFileCodeOwners(file, matches[file]))
+	}
+	return result
+}
+
+// GetCodeOwners gets the code owners of files at a ref
+func GetCodeOwners(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/codeowners repository repoGetCodeOwners
+	// ---
+	// summary: Get the CODEOWNERS rules matching files of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: path
+	//   in: query
+	//   description: paths of the files, at most 100
+	//   type: array
+	//   items:
+	//     type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: "The name of the commit/branch/tag. Default to the repository’s default branch."
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeOwnersFileOwners"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	var files []string
+	for _, file := range ctx.FormStrings("path") {
+		if file = strings.TrimPrefix(path.Clean("/"+file), "/"); file != "" {
+			files = append(files, file)
+		}
+	}
+	if len(files) == 0 || len(files) > maxCodeOwnersPaths {
+		ctx.APIError(http.StatusUnprocessableEntity, "between 1 and 100 paths are required")
+		return
+	}
+
+	codeOwners, err := issue_service.GetCodeOwners(ctx, ctx.Repo.GitRepo, ctx.Repo.Commit)
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toCodeOwnersFileOwners(codeOwners, files))
+}
+
+// ValidateCodeOwners reports the problems of the CODEOWNERS file at a ref
+func ValidateCodeOwners(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/codeowners/validate repository repoValidateCodeOwners
+	// ---
+	// summary: Validate the CODEOWNERS file of a repository
+	// description: Reports the syntax errors and the unknown users and teams of the CODEOWNERS file, their rules are ignored.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: "The name of the commit/branch/tag. Default to the repository’s default branch."
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeOwnersValidation"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	codeOwners, err := issue_service.GetCodeOwners(ctx, ctx.Repo.GitRepo, ctx.Repo.Commit)
+	// a file which can't be read is reported as invalid, its rules are ignored like the ones with errors
+	var errTooLarge issue_service.ErrCodeOwnersTooLarge
+	if errors.As(err, &errTooLarge) {
+		ctx.JSON(http.StatusOK, &api.CodeOwnersValidation{
+			Path:   errTooLarge.Path,
+			Errors: []*api.CodeOwnersError{{Message: err.Error()}},
+		})
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	result := &api.CodeOwnersValidation{
+		Valid:  true,
+		Errors: make([]*api.CodeOwnersError, 0),
+	}
+	if codeOwners != nil {
+		result.Path = codeOwners.Path
+		result.RulesCount = len(codeOwners.Rules)
+		for _, w := range codeOwners.Warnings {
+			result.Errors = append(result.Errors, &api.CodeOwnersError{Line: w.Line, Message: w.Message})
+		}
+		result.Valid = len(result.Errors) == 0
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetPullRequestCodeOwners gets the code owners of the files changed by a pull request
+func GetPullRequestCodeOwners(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/codeowners repository repoGetPullRequestCodeOwners
+	// ---
+	// summary: Get the CODEOWNERS rules matching the files changed by a pull request
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeOwnersFileOwners"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	codeOwners, err := issue_service.GetPullRequestCodeOwners(ctx, ctx.Repo.GitRepo, pr)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	files, err := issue_service.GetPullRequestChangedFiles(ctx, ctx.Repo.GitRepo, pr)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toCodeOwnersFileOwners(codeOwners, files))
+}
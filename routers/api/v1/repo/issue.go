@@ -19,7 +19,9 @@ import (
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/models/unit"
 	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/git"
 	issue_indexer "gitea.dev/modules/indexer/issues"
+	issue_template "gitea.dev/modules/issue/template"
 	"gitea.dev/modules/optional"
 	"gitea.dev/modules/setting"
 	api "gitea.dev/modules/structs"
@@ -648,6 +650,14 @@ func CreateIssue(ctx *context.APIContext) {
 		DeadlineUnix: deadlineUnix,
 	}
 
+	var attachmentUUIDs []string
+	if form.Template != "" {
+		attachmentUUIDs = prepareIssueForm(ctx, issue, form)
+		if ctx.Written() {
+			return
+		}
+	}
+
 	assigneeIDs := make([]int64, 0)
 	var err error
 	if ctx.Repo.Permission.CanWrite(unit.TypeIssues) {
@@ -685,7 +695,7 @@ func CreateIssue(ctx *context.APIContext) {
 		form.Labels = make([]int64, 0)
 	}
 
-	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, nil, assigneeIDs, form.Projects); err != nil {
+	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, attachmentUUIDs, assigneeIDs, form.Projects); err != nil {
 		if errors.Is(err, user_model.ErrBlockedUser) {
 			ctx.APIError(http.StatusForbidden, err.Error())
 		} else if errors.Is(err, util.ErrPermissionDenied) || errors.Is(err, util.ErrNotExist) {
@@ -716,6 +726,102 @@ func CreateIssue(ctx *context.APIContext) {
 	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, issue))
 }
 
+// prepareIssueForm fills in the new issue from the values of the issue form template given by the form,
+// and returns the UUIDs of the attachments uploaded for its file fields. Errors are written to ctx.
+func prepareIssueForm(ctx *context.APIContext, issue *issues_model.Issue, form *api.CreateIssueOption) []string {
+	if ctx.Repo.GitRepo == nil {
+		var err error
+		ctx.Repo.GitRepo, err = git.RepositoryFromRequestContextOrOpen(ctx, ctx.Repo.Repository)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return nil
+		}
+	}
+
+	template, err := issue_template.UnmarshalFromRepo(ctx, ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, form.Template)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("invalid issue template %q: %v", form.Template, err))
+		return nil
+	}
+	if template.Type() != api.IssueTemplateTypeYaml {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("issue template %q is not an issue form", form.Template))
+		return nil
+	}
+	values, err := issue_template.ValuesFromMap(template, form.FormValues)
+	if err == nil {
+		var uuids []string
+		if uuids, err = issue_service.PrepareIssueForm(ctx, ctx.Repo.Repository, ctx.Doer, issue, template, values); err == nil {
+			return uuids
+		}
+	}
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+	} else {
+		ctx.APIErrorInternal(err)
+	}
+	return nil
+}
+
+// GetIssueFormValues returns the values submitted through the issue form an issue has been created with
+func GetIssueFormValues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/form_values issue issueGetFormValues
+	// ---
+	// summary: Get the values submitted through the issue form an issue has been created with
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFormValues"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	if !ctx.Repo.Permission.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	data, err := issues_model.GetIssueFormData(ctx, issue.ID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.IssueFormValues{
+		Template: data.TemplateFile,
+		Values:   data.Values,
+		Created:  data.CreatedUnix.AsTime(),
+	})
+}
+
 // EditIssue modify an issue of a repository
 func EditIssue(ctx *context.APIContext) {
 	// swagger:operation PATCH /repos/{owner}/{repo}/issues/{index} issue issueEditIssue
@@ -911,6 +911,8 @@ func MergePullRequest(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/empty"
+	//   "201":
+	//     description: the pull request was scheduled to auto merge or added to the merge queue
 	//   "403":
 	//     "$ref": "#/responses/forbidden"
 	//   "404":
@@ -977,6 +979,8 @@ func MergePullRequest(ctx *context.APIContext) {
 			ctx.APIError(http.StatusMethodNotAllowed, err.Error())
 		} else if errors.Is(err, pull_service.ErrHeadCommitsNotAllVerified) {
 			ctx.APIError(http.StatusMethodNotAllowed, err.Error())
+		} else if errors.Is(err, pull_service.ErrStackParentNotMerged) {
+			ctx.APIError(http.StatusMethodNotAllowed, "The PR is stacked on a PR which has not been merged yet")
 		} else {
 			ctx.APIErrorInternal(err)
 		}
@@ -1041,6 +1045,26 @@ func MergePullRequest(ctx *context.APIContext) {
 		}
 	}
 
+	if !form.ForceMerge {
+		mergeQueueRequired, err := automerge.IsMergeQueueRequired(ctx, pr)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if mergeQueueRequired {
+			if err := automerge.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, deleteBranchAfterMerge); err != nil {
+				if errors.Is(err, util.ErrAlreadyExist) {
+					ctx.APIError(http.StatusConflict, err.Error())
+					return
+				}
+				ctx.APIErrorInternal(err)
+				return
+			}
+			ctx.Status(http.StatusCreated)
+			return
+		}
+	}
+
 	if err := pull_service.Merge(ctx, pr, ctx.Doer, repo_model.MergeStyle(form.Do), form.HeadCommitID, message, false); err != nil {
 		if pull_service.IsErrInvalidMergeStyle(err) {
 			ctx.APIError(http.StatusMethodNotAllowed, fmt.Sprintf("%s is not allowed an allowed merge style for this repository", repo_model.MergeStyle(form.Do)))
@@ -0,0 +1,218 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	pull_model "gitea.dev/models/pull"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/services/automerge"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	pull_service "gitea.dev/services/pull"
+)
+
+// ListMergeQueue lists the pull requests in the merge queue of a branch
+func ListMergeQueue(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/merge_queue repository repoListMergeQueue
+	// ---
+	// summary: List the pull requests in the merge queue of a branch
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: branch
+	//   in: query
+	//   description: base branch of the merge queue, defaults to the default branch of the repository
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MergeQueueEntryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	branch := ctx.FormString("branch")
+	if branch == "" {
+		branch = ctx.Repo.Repository.DefaultBranch
+	}
+
+	entries, err := pull_model.GetMergeQueueEntries(ctx, ctx.Repo.Repository.ID, branch)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiEntries := make([]*api.MergeQueueEntry, 0, len(entries))
+	for i, entry := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiEntry, err := convert.ToMergeQueueEntry(ctx, entry, pr, i+1, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiEntries = append(apiEntries, apiEntry)
+	}
+
+	ctx.SetTotalCountHeader(int64(len(apiEntries)))
+	ctx.JSON(http.StatusOK, apiEntries)
+}
+
+// GetPullRequestMergeQueueEntry gets the merge queue entry of a pull request
+func GetPullRequestMergeQueueEntry(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/merge_queue repository repoGetPullRequestMergeQueueEntry
+	// ---
+	// summary: Get the merge queue entry of a pull request
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MergeQueueEntry"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, entry := getPullRequestMergeQueueEntry(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	position := 0
+	if entry.Status != pull_model.MergeQueueEntryStatusFailed {
+		entries, err := pull_model.GetMergeQueueEntries(ctx, entry.RepoID, entry.BaseBranch)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		for i, e := range entries {
+			if e.ID == entry.ID {
+				position = i + 1
+				break
+			}
+		}
+	}
+
+	apiEntry, err := convert.ToMergeQueueEntry(ctx, entry, pr, position, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiEntry)
+}
+
+// RemovePullRequestFromMergeQueue removes a pull request from the merge queue
+func RemovePullRequestFromMergeQueue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/pulls/{index}/merge_queue repository repoRemovePullRequestFromMergeQueue
+	// ---
+	// summary: Remove a pull request from the merge queue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	pr, entry := getPullRequestMergeQueueEntry(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if ctx.Doer.ID != entry.DoerID {
+		allowed, err := pull_service.IsUserAllowedToMerge(ctx, pr, ctx.Repo.Permission, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if !allowed {
+			ctx.APIError(http.StatusForbidden, "user has no permission to remove the pull request from the merge queue")
+			return
+		}
+	}
+
+	if err := automerge.RemoveFromMergeQueue(ctx, pr); err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.APIErrorNotFound()
+			return
+		}
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func getPullRequestMergeQueueEntry(ctx *context.APIContext) (*issues_model.PullRequest, *pull_model.MergeQueueEntry) {
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil, nil
+	}
+
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		if db.IsErrNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil, nil
+	}
+	return pr, entry
+}
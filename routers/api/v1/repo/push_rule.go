@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"gitea.dev/routers/api/v1/shared"
+	"gitea.dev/services/context"
+)
+
+// GetPushRule returns the push rule of a repository
+func GetPushRule(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/push_rule repository repoGetPushRule
+	// ---
+	// summary: Get the push rule of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetPushRule(ctx, ctx.Repo.Owner, ctx.Repo.Repository)
+}
+
+// SetPushRule creates or replaces the push rule of a repository
+func SetPushRule(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/push_rule repository repoSetPushRule
+	// ---
+	// summary: Create or replace the push rule of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SetPushRuleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PushRule"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	shared.SetPushRule(ctx, ctx.Repo.Owner, ctx.Repo.Repository)
+}
+
+// DeletePushRule deletes the push rule of a repository
+func DeletePushRule(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/push_rule repository repoDeletePushRule
+	// ---
+	// summary: Delete the push rule of a repository
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeletePushRule(ctx, ctx.Repo.Owner, ctx.Repo.Repository)
+}
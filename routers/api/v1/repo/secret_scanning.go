@@ -0,0 +1,230 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	"gitea.dev/models/db"
+	secretscan_model "gitea.dev/models/secretscan"
+	"gitea.dev/modules/optional"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/web"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	secretscan_service "gitea.dev/services/secretscan"
+)
+
+// ListSecretScanningAlerts lists the secret scanning alerts of a repository
+func ListSecretScanningAlerts(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/secret_scanning/alerts repository repoListSecretScanningAlerts
+	// ---
+	// summary: List the secret scanning alerts of a repository, most recent first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: only show alerts in this state
+	//   type: string
+	//   enum: [open, revoked, false_positive]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningAlertList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	opts := secretscan_model.FindAlertsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+	}
+	if state := ctx.FormString("state"); state != "" {
+		status, ok := secretscan_model.ParseAlertStatus(state)
+		if !ok {
+			ctx.APIError(http.StatusBadRequest, "invalid state: "+state)
+			return
+		}
+		opts.Status = optional.Some(status)
+	}
+
+	alerts, total, err := db.FindAndCount[secretscan_model.Alert](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiAlerts := make([]*api.SecretScanningAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		apiAlert, err := convert.ToSecretScanningAlert(ctx, alert, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiAlerts = append(apiAlerts, apiAlert)
+	}
+
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiAlerts)
+}
+
+// GetSecretScanningAlert returns a secret scanning alert of a repository
+func GetSecretScanningAlert(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/secret_scanning/alerts/{id} repository repoGetSecretScanningAlert
+	// ---
+	// summary: Get a secret scanning alert of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the alert
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningAlert"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	alert, err := secretscan_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	apiAlert, err := convert.ToSecretScanningAlert(ctx, alert, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiAlert)
+}
+
+// EditSecretScanningAlert changes the state of a secret scanning alert of a repository
+func EditSecretScanningAlert(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/secret_scanning/alerts/{id} repository repoEditSecretScanningAlert
+	// ---
+	// summary: Triage a secret scanning alert of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the alert
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/EditSecretScanningAlertOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretScanningAlert"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm[*api.EditSecretScanningAlertOption](ctx)
+
+	alert, err := secretscan_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	status, _ := secretscan_model.ParseAlertStatus(string(form.State))
+	if err := secretscan_model.UpdateAlertStatus(ctx, alert, status, ctx.Doer.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	apiAlert, err := convert.ToSecretScanningAlert(ctx, alert, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiAlert)
+}
+
+// ScanSecrets queues a secret scan of the full history of a repository
+func ScanSecrets(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/secret_scanning/scan repository repoScanSecrets
+	// ---
+	// summary: Scan the full history of a repository for secrets in the background
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := secretscan_service.QueueHistoryScan(ctx.Repo.Repository); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusAccepted)
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	audit_model "gitea.dev/models/audit"
+	"gitea.dev/models/db"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/routers/api/v1/utils"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// getAuditEventsOptions parses the audit log filters of the request, ownerID 0 means the whole instance
+func getAuditEventsOptions(ctx *context.APIContext, ownerID int64) (audit_model.FindEventsOptions, bool) {
+	before, since, err := context.GetQueryBeforeSince(ctx.Base)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return audit_model.FindEventsOptions{}, false
+	}
+	return audit_model.FindEventsOptions{
+		OwnerID:    ownerID,
+		RepoID:     ctx.FormInt64("repo_id"),
+		ActorID:    ctx.FormInt64("actor_id"),
+		Action:     audit_model.Action(ctx.FormTrim("action")),
+		TargetType: audit_model.TargetType(ctx.FormTrim("target_type")),
+		Since:      since,
+		Before:     before,
+	}, true
+}
+
+// ListAuditEvents lists the audit log events of an owner, the most recent first
+func ListAuditEvents(ctx *context.APIContext, ownerID int64) {
+	opts, ok := getAuditEventsOptions(ctx, ownerID)
+	if !ok {
+		return
+	}
+	opts.ListOptions = utils.GetListOptions(ctx)
+
+	events, total, err := db.FindAndCount[audit_model.Event](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiEvents := make([]*api.AuditEvent, 0, len(events))
+	for _, e := range events {
+		apiEvents = append(apiEvents, convert.ToAuditEvent(e))
+	}
+
+	ctx.SetLinkHeader(total, opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiEvents)
+}
+
+// ExportAuditEvents writes all the audit log events of an owner as JSON lines, the oldest first
+func ExportAuditEvents(ctx *context.APIContext, ownerID int64) {
+	opts, ok := getAuditEventsOptions(ctx, ownerID)
+	if !ok {
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/x-ndjson")
+	ctx.Resp.Header().Set("Content-Disposition", `attachment; filename="audit-log.jsonl"`)
+	ctx.Resp.WriteHeader(http.StatusOK)
+
+	// the encoder terminates every value with a newline
+	enc := json.NewEncoder(ctx.Resp)
+	if err := audit_model.IterateEvents(ctx, opts, func(e *audit_model.Event) error {
+		return enc.Encode(convert.ToAuditEvent(e))
+	}); err != nil {
+		// the status has already been sent, so the export can only be cut short
+		log.Error("ExportAuditEvents: %v", err)
+	}
+}
@@ -109,9 +109,24 @@ func (s projectScope) findColumnIssue(ctx *context.APIContext) (*project_model.C
 	return column, s.findIssue(ctx)
 }
 
-// findIssue resolves an issue addressable within this scope. Owner-level boards span
-// repositories, so the issue is looked up globally and gated on the doer's read access.
+// findIssue resolves an issue addressable within this scope for a change, rejecting the
+// issues of archived repositories.
 func (s projectScope) findIssue(ctx *context.APIContext) *issues_model.Issue {
+	issue := s.findReadableIssue(ctx)
+	if ctx.Written() {
+		return nil
+	}
+	// the repo route group has mustNotBeArchived, owner boards have to check per issue
+	if s.Repo == nil && issue.Repo.IsArchived {
+		ctx.APIError(http.StatusLocked, "repo is archived")
+		return nil
+	}
+	return issue
+}
+
+// findReadableIssue resolves an issue addressable within this scope. Owner-level boards span
+// repositories, so the issue is looked up globally and gated on the doer's read access.
+func (s projectScope) findReadableIssue(ctx *context.APIContext) *issues_model.Issue {
 	issueID := ctx.PathParamInt64("issue_id")
 	var issue *issues_model.Issue
 	var err error
@@ -139,11 +154,6 @@ func (s projectScope) findIssue(ctx *context.APIContext) *issues_model.Issue {
 			ctx.APIErrorNotFound()
 			return nil
 		}
-		// the repo route group has mustNotBeArchived, owner boards have to check per issue
-		if issue.Repo.IsArchived {
-			ctx.APIError(http.StatusLocked, "repo is archived")
-			return nil
-		}
 	}
 	return issue
 }
@@ -0,0 +1,498 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	project_model "gitea.dev/models/project"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/web"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	project_service "gitea.dev/services/projects"
+)
+
+// automationIn resolves the "automation_id" path param inside an already-resolved project.
+func automationIn(ctx *context.APIContext, project *project_model.Project) *project_model.Automation {
+	automation, err := project_model.GetAutomationByIDAndProjectID(ctx, ctx.PathParamInt64("automation_id"), project.ID)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return automation
+}
+
+func parseAutomationEvent(ctx *context.APIContext, name string) project_model.AutomationEvent {
+	event := project_model.ParseAutomationEvent(name)
+	if event == 0 {
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid event "+name)
+	}
+	return event
+}
+
+func ListProjectAutomations(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/automations repository repoListProjectAutomations
+	// ---
+	// summary: List a project's automations
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /orgs/{org}/projects/{id}/automations organization orgListProjectAutomations
+	// ---
+	// summary: List a project's automations
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /user/projects/{id}/automations user userCurrentListProjectAutomations
+	// ---
+	// summary: List a project's automations
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	automations, err := project_model.GetAutomations(ctx, project.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToProjectAutomationList(automations))
+}
+
+func CreateProjectAutomation(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects/{id}/automations repository repoCreateProjectAutomation
+	// ---
+	// summary: Create an automation in a project
+	// description: The first matching automation of a project wins when several ones match the same event.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectAutomationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectAutomation"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation POST /orgs/{org}/projects/{id}/automations organization orgCreateProjectAutomation
+	// ---
+	// summary: Create an automation in a project
+	// description: The first matching automation of a project wins when several ones match the same event.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectAutomationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectAutomation"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation POST /user/projects/{id}/automations user userCurrentCreateProjectAutomation
+	// ---
+	// summary: Create an automation in a project
+	// description: The first matching automation of a project wins when several ones match the same event.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectAutomationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectAutomation"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.CreateProjectAutomationOption](ctx)
+	event := parseAutomationEvent(ctx, string(form.Event))
+	if ctx.Written() {
+		return
+	}
+	automation := &project_model.Automation{
+		Event:     event,
+		LabelID:   form.LabelID,
+		ColumnID:  form.ColumnID,
+		CreatorID: ctx.Doer.ID,
+	}
+	if err := project_service.CreateAutomation(ctx, project, automation); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToProjectAutomation(automation))
+}
+
+func EditProjectAutomation(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/projects/{id}/automations/{automation_id} repository repoEditProjectAutomation
+	// ---
+	// summary: Edit a project automation
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: automation_id
+	//   in: path
+	//   description: id of the automation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectAutomationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomation"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation PATCH /orgs/{org}/projects/{id}/automations/{automation_id} organization orgEditProjectAutomation
+	// ---
+	// summary: Edit a project automation
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: automation_id
+	//   in: path
+	//   description: id of the automation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectAutomationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomation"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation PATCH /user/projects/{id}/automations/{automation_id} user userCurrentEditProjectAutomation
+	// ---
+	// summary: Edit a project automation
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: automation_id
+	//   in: path
+	//   description: id of the automation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectAutomationOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectAutomation"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	automation := automationIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.EditProjectAutomationOption](ctx)
+	if form.Event != nil {
+		automation.Event = parseAutomationEvent(ctx, string(*form.Event))
+		if ctx.Written() {
+			return
+		}
+	}
+	if form.LabelID != nil {
+		automation.LabelID = *form.LabelID
+	}
+	if form.ColumnID != nil {
+		automation.ColumnID = *form.ColumnID
+	}
+	if err := project_service.UpdateAutomation(ctx, project, automation); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToProjectAutomation(automation))
+}
+
+func DeleteProjectAutomation(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/projects/{id}/automations/{automation_id} repository repoDeleteProjectAutomation
+	// ---
+	// summary: Delete a project automation
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: automation_id
+	//   in: path
+	//   description: id of the automation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation DELETE /orgs/{org}/projects/{id}/automations/{automation_id} organization orgDeleteProjectAutomation
+	// ---
+	// summary: Delete a project automation
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: automation_id
+	//   in: path
+	//   description: id of the automation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation DELETE /user/projects/{id}/automations/{automation_id} user userCurrentDeleteProjectAutomation
+	// ---
+	// summary: Delete a project automation
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: automation_id
+	//   in: path
+	//   description: id of the automation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	automation := automationIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	if err := project_model.DeleteAutomationByID(ctx, automation.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,779 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	project_model "gitea.dev/models/project"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/web"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+	project_service "gitea.dev/services/projects"
+)
+
+// fieldIn resolves the "field_id" path param inside an already-resolved project.
+func fieldIn(ctx *context.APIContext, project *project_model.Project) *project_model.Field {
+	field, err := project_model.GetFieldByIDAndProjectID(ctx, ctx.PathParamInt64("field_id"), project.ID)
+	if err != nil {
+		ctx.APIErrorAuto(err)
+		return nil
+	}
+	return field
+}
+
+func toFieldOptions(options []*api.ProjectFieldOption) []*project_model.FieldOption {
+	result := make([]*project_model.FieldOption, 0, len(options))
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		result = append(result, &project_model.FieldOption{
+			ID:        option.ID,
+			Name:      option.Name,
+			StartDate: option.StartDate,
+			EndDate:   option.EndDate,
+		})
+	}
+	return result
+}
+
+func ListProjectFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/fields repository repoListProjectFields
+	// ---
+	// summary: List a project's custom fields
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /orgs/{org}/projects/{id}/fields organization orgListProjectFields
+	// ---
+	// summary: List a project's custom fields
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /user/projects/{id}/fields user userCurrentListProjectFields
+	// ---
+	// summary: List a project's custom fields
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	fields, err := project_model.GetFields(ctx, project.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToProjectFieldList(fields))
+}
+
+func CreateProjectField(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/projects/{id}/fields repository repoCreateProjectField
+	// ---
+	// summary: Create a custom field in a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation POST /orgs/{org}/projects/{id}/fields organization orgCreateProjectField
+	// ---
+	// summary: Create a custom field in a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation POST /user/projects/{id}/fields user userCurrentCreateProjectField
+	// ---
+	// summary: Create a custom field in a project
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateProjectFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.CreateProjectFieldOption](ctx)
+	fieldType := project_model.ParseFieldType(string(form.Type))
+	if fieldType == 0 {
+		ctx.APIError(http.StatusUnprocessableEntity, "invalid field type "+string(form.Type))
+		return
+	}
+	field := &project_model.Field{
+		Name:    form.Name,
+		Type:    fieldType,
+		Options: toFieldOptions(form.Options),
+	}
+	if err := project_service.CreateField(ctx, ctx.Doer, project, field); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToProjectField(field))
+}
+
+func EditProjectField(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/projects/{id}/fields/{field_id} repository repoEditProjectField
+	// ---
+	// summary: Edit a project's custom field
+	// description: Removing an option clears the values which use it.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation PATCH /orgs/{org}/projects/{id}/fields/{field_id} organization orgEditProjectField
+	// ---
+	// summary: Edit a project's custom field
+	// description: Removing an option clears the values which use it.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation PATCH /user/projects/{id}/fields/{field_id} user userCurrentEditProjectField
+	// ---
+	// summary: Edit a project's custom field
+	// description: Removing an option clears the values which use it.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditProjectFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectField"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.EditProjectFieldOption](ctx)
+	if form.Name != nil {
+		field.Name = *form.Name
+	}
+	if form.Options != nil {
+		field.Options = toFieldOptions(*form.Options)
+	}
+	if err := project_service.UpdateField(ctx, field); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToProjectField(field))
+}
+
+func DeleteProjectField(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/projects/{id}/fields/{field_id} repository repoDeleteProjectField
+	// ---
+	// summary: Delete a project's custom field and its values
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation DELETE /orgs/{org}/projects/{id}/fields/{field_id} organization orgDeleteProjectField
+	// ---
+	// summary: Delete a project's custom field and its values
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation DELETE /user/projects/{id}/fields/{field_id} user userCurrentDeleteProjectField
+	// ---
+	// summary: Delete a project's custom field and its values
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	project := projectScopeFromContext(ctx).findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_service.DeleteField(ctx, field); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func ListProjectIssueFieldValues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/projects/{id}/issues/{issue_id}/fields repository repoListProjectIssueFieldValues
+	// ---
+	// summary: List the custom field values of an issue in a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectIssueFieldValueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /orgs/{org}/projects/{id}/issues/{issue_id}/fields organization orgListProjectIssueFieldValues
+	// ---
+	// summary: List the custom field values of an issue in a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectIssueFieldValueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// swagger:operation GET /user/projects/{id}/issues/{issue_id}/fields user userCurrentListProjectIssueFieldValues
+	// ---
+	// summary: List the custom field values of an issue in a project
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ProjectIssueFieldValueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	scope := projectScopeFromContext(ctx)
+	project := scope.findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	issue := scope.findReadableIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	inProject, err := project_model.IsIssueInProject(ctx, issue.ID, project.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	} else if !inProject {
+		ctx.APIErrorNotFound("issue is not in the project")
+		return
+	}
+
+	fields, err := project_model.GetFields(ctx, project.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	values, err := project_model.GetProjectIssueFieldValues(ctx, project.ID, issue.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToProjectIssueFieldValues(fields, values))
+}
+
+func SetProjectIssueFieldValue(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/projects/{id}/issues/{issue_id}/fields/{field_id} repository repoSetProjectIssueFieldValue
+	// ---
+	// summary: Set the value of a custom field for an issue in a project
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetProjectIssueFieldValueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation PUT /orgs/{org}/projects/{id}/issues/{issue_id}/fields/{field_id} organization orgSetProjectIssueFieldValue
+	// ---
+	// summary: Set the value of a custom field for an issue in a project
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetProjectIssueFieldValueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	// swagger:operation PUT /user/projects/{id}/issues/{issue_id}/fields/{field_id} user userCurrentSetProjectIssueFieldValue
+	// ---
+	// summary: Set the value of a custom field for an issue in a project
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the project
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: issue_id
+	//   in: path
+	//   description: global id of the issue, not the repository-local index
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: field_id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetProjectIssueFieldValueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	scope := projectScopeFromContext(ctx)
+	project := scope.findOpenProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	field := fieldIn(ctx, project)
+	if ctx.Written() {
+		return
+	}
+	issue := scope.findIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm[*api.SetProjectIssueFieldValueOption](ctx)
+	if err := project_service.SetIssueFieldValue(ctx, field, issue, form.Value); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	audit_model "gitea.dev/models/audit"
+	git_model "gitea.dev/models/git"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/web"
+	audit_service "gitea.dev/services/audit"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+func getPushRule(ctx *context.APIContext, owner *user_model.User, repo *repo_model.Repository) (*git_model.PushRule, error) {
+	if repo != nil {
+		return git_model.GetPushRuleByRepoID(ctx, repo.ID)
+	}
+	return git_model.GetPushRuleByOwnerID(ctx, owner.ID)
+}
+
+// GetPushRule responds with the push rule of the repository, or of the owner if repo is nil
+func GetPushRule(ctx *context.APIContext, owner *user_model.User, repo *repo_model.Repository) {
+	rule, err := getPushRule(ctx, owner, repo)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if rule == nil {
+		ctx.APIErrorNotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToPushRule(rule))
+}
+
+// SetPushRule creates or replaces the push rule of the repository, or of the owner if repo is nil
+func SetPushRule(ctx *context.APIContext, owner *user_model.User, repo *repo_model.Repository) {
+	form := web.GetForm[*api.SetPushRuleOption](ctx)
+
+	existing, err := getPushRule(ctx, owner, repo)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	rule := &git_model.PushRule{
+		CommitMessagePattern:       form.CommitMessagePattern,
+		RequireSignedOffBy:         form.RequireSignedOffBy,
+		RequireVerifiedAuthorEmail: form.RequireVerifiedAuthorEmail,
+		MaxBlobSize:                form.MaxBlobSize,
+		ForbiddenFilePatterns:      form.ForbiddenFilePatterns,
+		ForbiddenFileExtensions:    form.ForbiddenFileExtensions,
+		SignedCommitBranchPatterns: form.SignedCommitBranchPatterns,
+	}
+	if repo != nil {
+		rule.RepoID = repo.ID
+	} else {
+		rule.OwnerID = owner.ID
+	}
+	if err := git_model.SavePushRule(ctx, rule); err != nil {
+		ctx.APIErrorAuto(err)
+		return
+	}
+
+	var before *api.PushRule
+	if existing != nil {
+		before = convert.ToPushRule(existing)
+	}
+	after := convert.ToPushRule(rule)
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionPushRuleUpdate, audit_service.PushRuleTarget(owner, repo, rule), before, after)
+
+	ctx.JSON(http.StatusOK, after)
+}
+
+// DeletePushRule deletes the push rule of the repository, or of the owner if repo is nil
+func DeletePushRule(ctx *context.APIContext, owner *user_model.User, repo *repo_model.Repository) {
+	rule, err := getPushRule(ctx, owner, repo)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if rule == nil {
+		ctx.APIErrorNotFound()
+		return
+	}
+	if err := git_model.DeletePushRule(ctx, rule.OwnerID, rule.RepoID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionPushRuleDelete, audit_service.PushRuleTarget(owner, repo, rule), convert.ToPushRule(rule), nil)
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	quota_model "gitea.dev/models/quota"
+	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
+)
+
+// GetQuota responds with the storage usage and the limits of the owner
+func GetQuota(ctx *context.APIContext, ownerID int64) {
+	limits, groups, err := quota_model.GetOwnerLimits(ctx, ownerID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	usage, err := quota_model.GetUsage(ctx, ownerID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToQuotaInfo(usage, limits, groups))
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package swagger
+
+import (
+	api "gitea.dev/modules/structs"
+)
+
+// AuditEventList
+// swagger:response AuditEventList
+type swaggerResponseAuditEventList struct {
+	// in:body
+	Body []api.AuditEvent `json:"body"`
+}
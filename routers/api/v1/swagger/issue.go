@@ -118,3 +118,10 @@ type swaggerReactionList struct {
 	// in:body
 	Body []api.Reaction `json:"body"`
 }
+
+// IssueFormValues
+// swagger:response IssueFormValues
+type swaggerIssueFormValues struct {
+	// in:body
+	Body api.IssueFormValues `json:"body"`
+}
@@ -28,6 +28,9 @@ type swaggerParameterBodies struct {
 	// in:body
 	EditHookOption api.EditHookOption
 
+	// in:body
+	ReplayHookDeliveriesOption api.ReplayHookDeliveriesOption
+
 	// in:body
 	EditGitHookOption api.EditGitHookOption
 
@@ -99,6 +102,8 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreateForkOption api.CreateForkOption
 	// in:body
+	RestoreRepoBackupOption api.RestoreRepoBackupOption
+	// in:body
 	GenerateRepoOption api.GenerateRepoOption
 
 	// in:body
@@ -253,6 +258,29 @@ type swaggerParameterBodies struct {
 	// in:body
 	MoveProjectIssueOption api.MoveProjectIssueOption
 
+	// in:body
+	CreateProjectAutomationOption api.CreateProjectAutomationOption
+	// in:body
+	EditProjectAutomationOption api.EditProjectAutomationOption
+
+	// in:body
+	CreateProjectFieldOption api.CreateProjectFieldOption
+	// in:body
+	EditProjectFieldOption api.EditProjectFieldOption
+	// in:body
+	SetProjectIssueFieldValueOption api.SetProjectIssueFieldValueOption
+
 	// in:body
 	MergeUpstreamRequest api.MergeUpstreamRequest
+
+	// in:body
+	CreateQuotaGroupOption api.CreateQuotaGroupOption
+	// in:body
+	EditQuotaGroupOption api.EditQuotaGroupOption
+
+	// in:body
+	SetPushRuleOption api.SetPushRuleOption
+
+	// in:body
+	EditSecretScanningAlertOption api.EditSecretScanningAlertOption
 }
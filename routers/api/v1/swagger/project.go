@@ -34,3 +34,38 @@ type swaggerResponseProjectColumnList struct {
 	// in:body
 	Body []api.ProjectColumn `json:"body"`
 }
+
+// ProjectAutomation
+// swagger:response ProjectAutomation
+type swaggerResponseProjectAutomation struct {
+	// in:body
+	Body api.ProjectAutomation `json:"body"`
+}
+
+// ProjectAutomationList
+// swagger:response ProjectAutomationList
+type swaggerResponseProjectAutomationList struct {
+	// in:body
+	Body []api.ProjectAutomation `json:"body"`
+}
+
+// ProjectField
+// swagger:response ProjectField
+type swaggerResponseProjectField struct {
+	// in:body
+	Body api.ProjectField `json:"body"`
+}
+
+// ProjectFieldList
+// swagger:response ProjectFieldList
+type swaggerResponseProjectFieldList struct {
+	// in:body
+	Body []api.ProjectField `json:"body"`
+}
+
+// ProjectIssueFieldValueList
+// swagger:response ProjectIssueFieldValueList
+type swaggerResponseProjectIssueFieldValueList struct {
+	// in:body
+	Body []api.ProjectIssueFieldValue `json:"body"`
+}
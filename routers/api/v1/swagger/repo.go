@@ -77,6 +77,48 @@ type swaggerResponseTagProtectionList struct {
 	Body []api.TagProtection `json:"body"`
 }
 
+// PushRule
+// swagger:response PushRule
+type swaggerResponsePushRule struct {
+	// in:body
+	Body api.PushRule `json:"body"`
+}
+
+// SecretScanningAlert
+// swagger:response SecretScanningAlert
+type swaggerResponseSecretScanningAlert struct {
+	// in:body
+	Body api.SecretScanningAlert `json:"body"`
+}
+
+// SecretScanningAlertList
+// swagger:response SecretScanningAlertList
+type swaggerResponseSecretScanningAlertList struct {
+	// in:body
+	Body []api.SecretScanningAlert `json:"body"`
+}
+
+// CodeNavigationIndex
+// swagger:response CodeNavigationIndex
+type swaggerResponseCodeNavigationIndex struct {
+	// in:body
+	Body api.CodeNavigationIndex `json:"body"`
+}
+
+// CodeNavigationIndexList
+// swagger:response CodeNavigationIndexList
+type swaggerResponseCodeNavigationIndexList struct {
+	// in:body
+	Body []api.CodeNavigationIndex `json:"body"`
+}
+
+// CodeNavigation
+// swagger:response CodeNavigation
+type swaggerResponseCodeNavigation struct {
+	// in:body
+	Body api.CodeNavigation `json:"body"`
+}
+
 // TagProtection
 // swagger:response TagProtection
 type swaggerResponseTagProtection struct {
@@ -105,6 +147,13 @@ type swaggerResponseHookList struct {
 	Body []api.Hook `json:"body"`
 }
 
+// HookDeliveryList
+// swagger:response HookDeliveryList
+type swaggerResponseHookDeliveryList struct {
+	// in:body
+	Body []api.HookDelivery `json:"body"`
+}
+
 // GitHook
 // swagger:response GitHook
 type swaggerResponseGitHook struct {
@@ -161,6 +210,34 @@ type swaggerResponsePullReviewList struct {
 	Body []api.PullReview `json:"body"`
 }
 
+// MergeQueueEntry
+// swagger:response MergeQueueEntry
+type swaggerResponseMergeQueueEntry struct {
+	// in:body
+	Body api.MergeQueueEntry `json:"body"`
+}
+
+// MergeQueueEntryList
+// swagger:response MergeQueueEntryList
+type swaggerResponseMergeQueueEntryList struct {
+	// in:body
+	Body []api.MergeQueueEntry `json:"body"`
+}
+
+// CodeOwnersFileOwners
+// swagger:response CodeOwnersFileOwners
+type swaggerResponseCodeOwnersFileOwners struct {
+	// in:body
+	Body api.CodeOwnersFileOwners `json:"body"`
+}
+
+// CodeOwnersValidation
+// swagger:response CodeOwnersValidation
+type swaggerResponseCodeOwnersValidation struct {
+	// in:body
+	Body api.CodeOwnersValidation `json:"body"`
+}
+
 // PullComment
 // swagger:response PullReviewComment
 type swaggerPullReviewComment struct {
@@ -509,3 +586,17 @@ type swaggerMergeUpstreamResponse struct {
 	// in:body
 	Body api.MergeUpstreamResponse `json:"body"`
 }
+
+// RepoBackupsList
+// swagger:response RepoBackupsList
+type swaggerResponseRepoBackupsList struct {
+	// in:body
+	Body []api.RepoBackups `json:"body"`
+}
+
+// RepoBackups
+// swagger:response RepoBackups
+type swaggerResponseRepoBackups struct {
+	// in:body
+	Body api.RepoBackups `json:"body"`
+}
@@ -12,6 +12,7 @@ import (
 	"gitea.dev/modules/auth/httpauth"
 	api "gitea.dev/modules/structs"
 	"gitea.dev/modules/util"
+	auth_service "gitea.dev/services/auth"
 	"gitea.dev/services/context"
 )
 
@@ -68,7 +69,7 @@ func DeleteCurrentToken(ctx *context.APIContext) {
 	}
 
 	// Delete the token
-	err = auth_model.DeleteAccessTokenByID(ctx, accessToken.ID, accessToken.UID)
+	err = auth_service.DeleteAccessTokenByID(ctx, ctx.Doer, accessToken.ID, accessToken.UID)
 	if err != nil && !errors.Is(err, util.ErrNotExist) {
 		ctx.APIErrorAuto(err)
 		return
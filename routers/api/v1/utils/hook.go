@@ -259,6 +259,9 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 		}
 		w.Meta = string(meta)
 	}
+	if w.Type == webhook_module.CUSTOM && !setCustomHookMeta(ctx, w, form.Config) {
+		return nil, false
+	}
 
 	if err := w.UpdateEvent(); err != nil {
 		ctx.APIErrorInternal(err)
@@ -270,6 +273,38 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 	return w, true
 }
 
+// setCustomHookMeta applies the config options of a custom webhook to its metadata.
+// If an error occurs, write to `ctx` accordingly. Return whether successful
+func setCustomHookMeta(ctx *context.APIContext, w *webhook.Webhook, config map[string]string) bool {
+	meta := &webhook_service.CustomMeta{}
+	if w.Meta != "" {
+		meta = webhook_service.GetCustomHook(w)
+	}
+	if err := meta.ApplyConfig(config); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return false
+	}
+	if w.Secret == "" {
+		secret, err := webhook_service.GenerateCustomSecret(meta.SignatureScheme)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return false
+		}
+		w.Secret = secret
+	}
+	if err := meta.Validate(w.Secret); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err.Error())
+		return false
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	w.Meta = string(data)
+	return true
+}
+
 // EditSystemHook edit system webhook `w` according to `form`. Writes to `ctx` accordingly
 func EditSystemHook(ctx *context.APIContext, form *api.EditHookOption, hookID int64) {
 	hook, err := webhook.GetSystemOrDefaultWebhook(ctx, hookID)
@@ -369,6 +404,12 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 				w.Meta = string(meta)
 			}
 		}
+		if secret, ok := form.Config["secret"]; ok && w.Type == webhook_module.CUSTOM {
+			w.Secret = secret
+		}
+		if w.Type == webhook_module.CUSTOM && !setCustomHookMeta(ctx, w, form.Config) {
+			return false
+		}
 	}
 
 	// Update events
@@ -416,3 +457,41 @@ func DeleteOwnerHook(ctx *context.APIContext, owner *user_model.User, hookID int
 	}
 	ctx.Status(http.StatusNoContent)
 }
+
+// ListHookDeadLetters lists the dead letters of the webhook
+func ListHookDeadLetters(ctx *context.APIContext, w *webhook.Webhook) {
+	listOptions := GetListOptions(ctx)
+	tasks, count, err := webhook.FindDeadLetterHookTasks(ctx, w.ID, listOptions)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	deliveries := make([]*api.HookDelivery, len(tasks))
+	for i, t := range tasks {
+		deliveries[i] = webhook_service.ToHookDelivery(t)
+	}
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// ReplayHookDeadLetters redelivers the dead letters of the webhook selected by the form
+func ReplayHookDeadLetters(ctx *context.APIContext, w *webhook.Webhook, form *api.ReplayHookDeliveriesOption) {
+	if !w.IsActive {
+		ctx.APIError(http.StatusUnprocessableEntity, "the webhook is not active")
+		return
+	}
+
+	tasks, err := webhook_service.ReplayDeadLetters(ctx, w, form.IDs)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	deliveries := make([]*api.HookDelivery, len(tasks))
+	for i, t := range tasks {
+		deliveries[i] = webhook_service.ToHookDelivery(t)
+	}
+	ctx.JSON(http.StatusOK, deliveries)
+}
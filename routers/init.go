@@ -51,6 +51,7 @@ import (
 	release_service "gitea.dev/services/release"
 	repo_service "gitea.dev/services/repository"
 	"gitea.dev/services/repository/archiver"
+	secretscan_service "gitea.dev/services/secretscan"
 	"gitea.dev/services/task"
 	"gitea.dev/services/uinotification"
 	"gitea.dev/services/webhook"
@@ -153,6 +154,7 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(webhook.Init)
 	mustInit(pull_service.Init)
 	mustInit(automerge.Init)
+	mustInit(secretscan_service.Init)
 	mustInit(task.Init)
 	mustInit(repo_migrations.Init)
 	mustInit(websocket_service.Init)
@@ -197,6 +199,7 @@ func NormalRoutes() *web.Router {
 	if setting.Actions.Enabled {
 		prefix := "/api/actions"
 		r.Mount(prefix, actions_router.Routes(prefix))
+		r.Mount("/"+actions_service.IDTokenIssuerPath, actions_router.OIDCRoutes())
 
 		// TODO: Pipeline api used for runner internal communication with gitea server. but only artifact is used for now.
 		// In Github, it uses ACTIONS_RUNTIME_URL=https://pipelines.actions.githubusercontent.com/fLgcSHkPGySXeIFrg8W8OBSfeg3b5Fls1A1CwX566g8PayEGlg/
@@ -205,6 +208,8 @@ func NormalRoutes() *web.Router {
 		r.Mount(prefix, actions_router.ArtifactsRoutes(prefix))
 		prefix = actions_router.ArtifactV4RouteBase
 		r.Mount(prefix, actions_router.ArtifactsV4Routes(prefix))
+		prefix = "/api/actions_cache"
+		r.Mount(prefix, actions_router.CacheRoutes(prefix))
 	}
 
 	r.NotFound(func(w http.ResponseWriter, req *http.Request) {
@@ -6,20 +6,24 @@ package private
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	asymkey_model "gitea.dev/models/asymkey"
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
 	perm_model "gitea.dev/models/perm"
 	access_model "gitea.dev/models/perm/access"
+	quota_model "gitea.dev/models/quota"
 	"gitea.dev/models/unit"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/git"
 	"gitea.dev/modules/git/gitcmd"
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/private"
+	"gitea.dev/modules/setting"
 	"gitea.dev/modules/util"
 	"gitea.dev/modules/web"
 	"gitea.dev/services/agit"
@@ -40,6 +44,9 @@ type preReceiveContext struct {
 	protectedTags    []*git_model.ProtectedTag
 	gotProtectedTags bool
 
+	pushRules    []*git_model.PushRule
+	gotPushRules bool
+
 	env []string
 
 	opts *private.HookOptions
@@ -119,6 +126,10 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		return // if error occurs, loadPusherAndPermission had written the error response
 	}
 
+	if !ourCtx.assertQuota() {
+		return
+	}
+
 	// Iterate across the provided old commit IDs
 	for i := range opts.OldCommitIDs {
 		oldCommitID := opts.OldCommitIDs[i]
@@ -132,12 +143,22 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 			preReceiveTag(ourCtx, refFullName)
 		case git.DefaultFeatures().SupportProcReceive && refFullName.IsFor():
 			preReceiveFor(ourCtx, refFullName)
+		case pull_service.IsMergeQueueRef(refFullName.String()):
+			preReceiveMergeQueue(ourCtx, refFullName)
 		default:
 			ourCtx.assertCanWriteRef(refFullName)
 		}
 		if ctx.Written() {
 			return
 		}
+
+		if !ourCtx.assertPushRules(newCommitID, refFullName) {
+			return
+		}
+
+		if !ourCtx.assertNoSecrets(newCommitID, refFullName) {
+			return
+		}
 	}
 
 	ctx.PlainText(http.StatusOK, "ok")
@@ -426,6 +447,14 @@ func preReceiveTag(ctx *preReceiveContext, refFullName git.RefName) {
 	}
 }
 
+// preReceiveMergeQueue rejects the pushes to the refs of the merge queues, Gitea writes them without running the hooks
+func preReceiveMergeQueue(ctx *preReceiveContext, refFullName git.RefName) {
+	log.Warn("Forbidden: %s is a ref of a merge queue in %-v and can't be pushed", refFullName, ctx.Repo.Repository)
+	ctx.JSON(http.StatusForbidden, private.Response{
+		UserMsg: fmt.Sprintf("%s is managed by the merge queue and can't be pushed", refFullName),
+	})
+}
+
 func preReceiveFor(ctx *preReceiveContext, refFullName git.RefName) {
 	if !ctx.AssertCreatePullRequest() {
 		return
@@ -532,3 +561,69 @@ func (ctx *preReceiveContext) loadPusherAndPermission() bool {
 	}
 	return true
 }
+
+// assertQuota rejects pushes adding content while the owner of the repository exceeds the git quota.
+// The size of the pushed objects is taken from the quarantine directory git receives them in.
+func (ctx *preReceiveContext) assertQuota() bool {
+	if !setting.Quota.Enabled {
+		return true
+	}
+
+	emptyObjectID := ctx.Repo.GetObjectFormat().EmptyObjectID().String()
+	onlyDeletions := true
+	for _, newCommitID := range ctx.opts.NewCommitIDs {
+		if newCommitID != emptyObjectID {
+			onlyDeletions = false
+			break
+		}
+	}
+	// deleting refs must stay possible to get below the quota again
+	if onlyDeletions {
+		return true
+	}
+
+	size, err := quarantineSize(ctx.opts.GitQuarantinePath)
+	if err != nil {
+		log.Error("Unable to get the size of the quarantine directory %s: %v", ctx.opts.GitQuarantinePath, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to get the size of the quarantine directory: %v", err),
+		})
+		return false
+	}
+
+	if err := quota_model.Check(ctx, ctx.Repo.Repository.OwnerID, quota_model.SubjectGit, size); err != nil {
+		if quota_model.IsErrQuotaExceeded(err) {
+			log.Warn("Forbidden: push to %-v exceeds the quota of the owner: %v", ctx.Repo.Repository, err)
+			ctx.JSON(http.StatusRequestEntityTooLarge, private.Response{
+				UserMsg: "The push exceeds the storage quota of the repository owner",
+			})
+			return false
+		}
+		log.Error("Unable to check the quota of %-v: %v", ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to check the quota: %v", err),
+		})
+		return false
+	}
+	return true
+}
+
+// quarantineSize returns the size of the objects git received for the push, it is 0 for pushes without quarantine
+func quarantineSize(quarantinePath string) (int64, error) {
+	if quarantinePath == "" {
+		return 0, nil
+	}
+	var size int64
+	err := filepath.WalkDir(quarantinePath, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
@@ -4,6 +4,8 @@
 package private
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	issues_model "gitea.dev/models/issues"
@@ -67,3 +69,18 @@ func TestPreReceiveCanWriteCodePerBranch(t *testing.T) {
 	// yields an empty branch name, so this guards the per-ref evaluation, not the IsBranch check.)
 	assert.False(t, ctx.canWriteCodeRef(git.RefNameFromTag("granted-branch")))
 }
+
+func TestQuarantineSize(t *testing.T) {
+	size, err := quarantineSize("")
+	assert.NoError(t, err)
+	assert.Zero(t, size)
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "pack"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pack", "pack-1.pack"), make([]byte, 100), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pack", "pack-1.idx"), make([]byte, 20), 0o644))
+
+	size, err = quarantineSize(dir)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 120, size)
+}
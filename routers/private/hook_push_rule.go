@@ -0,0 +1,256 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	git_model "gitea.dev/models/git"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/private"
+)
+
+// This file contains the push rule checks for the commits passed across in hooks
+
+// maxReportedPushRuleViolations limits the number of violations which are returned to the git client
+const maxReportedPushRuleViolations = 20
+
+type pushRuleViolation struct {
+	CommitID string
+	Reason   string
+}
+
+type pushedCommit struct {
+	ID          string
+	AuthorEmail string
+	Message     string
+	Files       []pushedFile
+}
+
+type pushedFile struct {
+	Path   string
+	BlobID string
+}
+
+// assertPushRules checks the commits which are new to the repository against the push rules of the repository and its owner.
+// It responds with 403 Forbidden listing the offending commits and returns false if a rule is violated.
+func (ctx *preReceiveContext) assertPushRules(newCommitID string, refFullName git.RefName) bool {
+	// the wiki and the merges of pull requests done by Gitea itself are not subject to push rules
+	if ctx.opts.IsWiki || ctx.opts.PullRequestID != 0 {
+		return true
+	}
+	if newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return true
+	}
+
+	if !ctx.gotPushRules {
+		var err error
+		ctx.pushRules, err = git_model.GetPushRulesForRepo(ctx, ctx.Repo.Repository)
+		if err != nil {
+			log.Error("Unable to get push rules for %-v Error: %v", ctx.Repo.Repository, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return false
+		}
+		ctx.gotPushRules = true
+	}
+	if len(ctx.pushRules) == 0 {
+		return true
+	}
+
+	violations, err := checkPushRules(ctx, ctx.Repo.GitRepo, ctx.pushRules, refFullName, newCommitID, ctx.env)
+	if err != nil {
+		log.Error("Unable to check push rules for %s in %-v: %v", refFullName, ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to check push rules for %s: %v", refFullName, err),
+		})
+		return false
+	}
+	if len(violations) == 0 {
+		return true
+	}
+
+	log.Warn("Forbidden: push of %s to %s in %-v violates %d push rule(s)", newCommitID, refFullName, ctx.Repo.Repository, len(violations))
+	ctx.JSON(http.StatusForbidden, private.Response{
+		UserMsg: formatPushRuleViolations(refFullName, violations),
+	})
+	return false
+}
+
+func formatPushRuleViolations(refFullName git.RefName, violations []pushRuleViolation) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "push to %s rejected by push rules:", refFullName.ShortName())
+	for i, v := range violations {
+		if i == maxReportedPushRuleViolations {
+			fmt.Fprintf(&sb, "\n... and %d more", len(violations)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "\n%s: %s", v.CommitID, v.Reason)
+	}
+	return sb.String()
+}
+
+// checkPushRules returns the violations of the rules by the commits reachable from newCommitID which are not yet referenced in the repository
+func checkPushRules(ctx context.Context, repo *git.Repository, rules []*git_model.PushRule, refFullName git.RefName, newCommitID string, env []string) ([]pushRuleViolation, error) {
+	checkFiles, checkSignature := false, false
+	for _, rule := range rules {
+		checkFiles = checkFiles || rule.ChecksFiles()
+		checkSignature = checkSignature || (refFullName.IsBranch() && rule.RequireSignedCommits(refFullName.BranchName()))
+	}
+
+	// "newCommitID --not --all" only lists the commits received by this push, see verifyCommits
+	cmd := gitcmd.NewCommand("log", "-z", "--format=%H%n%ae%n%B")
+	if checkFiles {
+		cmd.AddArguments("--raw", "--no-abbrev", "--no-renames")
+	}
+	stdout, _, runErr := cmd.AddDynamicArguments(newCommitID).AddArguments("--not", "--all").
+		WithEnv(env).WithRepo(repo).RunStdString(ctx)
+	if runErr != nil {
+		return nil, runErr
+	}
+	commits := parsePushedCommits(stdout)
+
+	var blobSizes map[string]int64
+	if checkFiles {
+		var err error
+		if blobSizes, err = getPushedBlobSizes(ctx, repo, commits, env); err != nil {
+			return nil, err
+		}
+	}
+
+	verifiedEmails := make(map[string]bool)
+	isVerifiedEmail := func(email string) (bool, error) {
+		if verified, ok := verifiedEmails[email]; ok {
+			return verified, nil
+		}
+		ea, err := user_model.GetEmailAddressByEmail(ctx, email)
+		if err != nil && !user_model.IsErrEmailAddressNotExist(err) {
+			return false, err
+		}
+		verifiedEmails[email] = ea != nil && ea.IsActivated
+		return verifiedEmails[email], nil
+	}
+
+	var violations []pushRuleViolation
+	for _, c := range commits {
+		reasons := make([]string, 0, 2)
+		addReason := func(reason string) {
+			if reason != "" && !slices.Contains(reasons, reason) {
+				reasons = append(reasons, reason)
+			}
+		}
+
+		for _, rule := range rules {
+			addReason(rule.CheckCommitMessage(c.Message))
+			if rule.RequireVerifiedAuthorEmail {
+				verified, err := isVerifiedEmail(c.AuthorEmail)
+				if err != nil {
+					return nil, err
+				}
+				if !verified {
+					addReason(fmt.Sprintf("author email %s is not a verified email address", c.AuthorEmail))
+				}
+			}
+			for _, f := range c.Files {
+				addReason(rule.CheckFile(f.Path, blobSizes[f.BlobID]))
+			}
+		}
+
+		if checkSignature {
+			if err := readAndVerifyCommit(ctx, c.ID, repo, env); err != nil {
+				if !isErrUnverifiedCommit(err) {
+					return nil, err
+				}
+				addReason("commit is not signed with a verified key")
+			}
+		}
+
+		for _, reason := range reasons {
+			violations = append(violations, pushRuleViolation{CommitID: c.ID, Reason: reason})
+		}
+	}
+	return violations, nil
+}
+
+// parsePushedCommits parses the output of "git log -z --format=%H%n%ae%n%B [--raw --no-abbrev --no-renames]".
+// Deleted files and submodules are omitted from the files of a commit.
+func parsePushedCommits(stdout string) []*pushedCommit {
+	var commits []*pushedCommit
+	var current *pushedCommit
+
+	tokens := strings.Split(stdout, "\x00")
+	for i := 0; i < len(tokens); i++ {
+		token := strings.TrimLeft(tokens[i], "\n")
+		if token == "" {
+			continue
+		}
+		if token[0] == ':' && current != nil {
+			// ":<old mode> <new mode> <old id> <new id> <status>" is followed by the path
+			fields := strings.Fields(token)
+			i++
+			if len(fields) != 5 || i >= len(tokens) {
+				continue
+			}
+			if fields[4] == "D" || fields[1] == "160000" {
+				continue
+			}
+			current.Files = append(current.Files, pushedFile{Path: tokens[i], BlobID: fields[3]})
+			continue
+		}
+
+		id, rest, _ := strings.Cut(token, "\n")
+		email, message, _ := strings.Cut(rest, "\n")
+		current = &pushedCommit{ID: id, AuthorEmail: email, Message: message}
+		commits = append(commits, current)
+	}
+	return commits
+}
+
+// getPushedBlobSizes returns the sizes of the blobs of the files added or changed by the commits
+func getPushedBlobSizes(ctx context.Context, repo *git.Repository, commits []*pushedCommit, env []string) (map[string]int64, error) {
+	sizes := make(map[string]int64)
+
+	var stdin strings.Builder
+	for _, c := range commits {
+		for _, f := range c.Files {
+			if _, ok := sizes[f.BlobID]; !ok {
+				sizes[f.BlobID] = 0
+				stdin.WriteString(f.BlobID)
+				stdin.WriteByte('\n')
+			}
+		}
+	}
+	if len(sizes) == 0 {
+		return sizes, nil
+	}
+
+	stdout, _, runErr := gitcmd.NewCommand("cat-file", "--batch-check").
+		WithStdinBytes([]byte(stdin.String())).
+		WithEnv(env).WithRepo(repo).RunStdString(ctx)
+	if runErr != nil {
+		return nil, runErr
+	}
+	for line := range strings.SplitSeq(stdout, "\n") {
+		// "<id> <type> <size>" or "<id> missing"
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected cat-file output %q: %w", line, err)
+		}
+		sizes[fields[0]] = size
+	}
+	return sizes, nil
+}
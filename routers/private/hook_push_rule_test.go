@@ -0,0 +1,122 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"strings"
+	"testing"
+
+	git_model "gitea.dev/models/git"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPushRules(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	// "main" holds the existing commit, the commits of "incoming" are unreferenced like the objects of a push in the quarantine
+	repoDir := t.TempDir()
+	require.NoError(t, gitcmd.NewCommand("init", "--bare").AddDynamicArguments(repoDir).Run(t.Context()))
+	_, _, runErr := gitcmd.NewCommand("fast-import").WithDir(repoDir).WithStdinBytes([]byte(strings.TrimSpace(`
+commit refs/heads/main
+author Unknown <unknown@example.com> 1714310400 +0000
+committer Unknown <unknown@example.com> 1714310400 +0000
+data <<EOT
+old commit
+EOT
+M 100644 inline big.bin
+data <<EOT
+01234567890123456789
+EOT
+
+commit refs/heads/incoming
+author User Two <user2@example.com> 1714310401 +0000
+committer User Two <user2@example.com> 1714310401 +0000
+data <<EOT
+feat: add readme
+
+Signed-off-by: User Two <user2@example.com>
+EOT
+from refs/heads/main
+M 100644 inline README.md
+data <<EOT
+hello
+EOT
+
+commit refs/heads/incoming
+author Unknown <unknown@example.com> 1714310402 +0000
+committer Unknown <unknown@example.com> 1714310402 +0000
+data <<EOT
+add a tool
+EOT
+M 100644 inline bin/tool.exe
+data <<EOT
+01234567890123456789
+EOT
+D big.bin
+`))).RunStdString(t.Context())
+	require.NoError(t, runErr)
+
+	gitRepo, err := git.OpenRepositoryLocal(t.Context(), repoDir)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	commitIDs, _, runErr := gitcmd.NewCommand("rev-list", "refs/heads/incoming", "^refs/heads/main").WithDir(repoDir).RunStdString(t.Context())
+	require.NoError(t, runErr)
+	newCommitID, firstCommitID, _ := strings.Cut(strings.TrimSpace(commitIDs), "\n")
+	_, _, runErr = gitcmd.NewCommand("update-ref", "-d", "refs/heads/incoming").WithDir(repoDir).RunStdString(t.Context())
+	require.NoError(t, runErr)
+
+	refName := git.RefNameFromBranch("main")
+
+	violations, err := checkPushRules(t.Context(), gitRepo, []*git_model.PushRule{{}}, refName, newCommitID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = checkPushRules(t.Context(), gitRepo, []*git_model.PushRule{
+		{CommitMessagePattern: `^feat: `, RequireSignedOffBy: true},
+		{RequireVerifiedAuthorEmail: true, MaxBlobSize: 10, ForbiddenFileExtensions: "exe"},
+	}, refName, newCommitID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []pushRuleViolation{
+		{CommitID: newCommitID, Reason: "commit message does not match \"^feat: \""},
+		{CommitID: newCommitID, Reason: "author email unknown@example.com is not a verified email address"},
+		{CommitID: newCommitID, Reason: "file bin/tool.exe has the forbidden extension .exe"},
+	}, violations)
+
+	violations, err = checkPushRules(t.Context(), gitRepo, []*git_model.PushRule{
+		{MaxBlobSize: 10},
+		{SignedCommitBranchPatterns: "main"},
+	}, refName, newCommitID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []pushRuleViolation{
+		{CommitID: newCommitID, Reason: "file bin/tool.exe is larger than 10 bytes"},
+		{CommitID: newCommitID, Reason: "commit is not signed with a verified key"},
+		{CommitID: firstCommitID, Reason: "commit is not signed with a verified key"},
+	}, violations)
+
+	// the signed commit rule only applies to the matching branches
+	violations, err = checkPushRules(t.Context(), gitRepo, []*git_model.PushRule{
+		{SignedCommitBranchPatterns: "release/*"},
+	}, refName, newCommitID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestFormatPushRuleViolations(t *testing.T) {
+	violations := make([]pushRuleViolation, 0, maxReportedPushRuleViolations+2)
+	for range maxReportedPushRuleViolations + 2 {
+		violations = append(violations, pushRuleViolation{CommitID: "1234", Reason: "reason"})
+	}
+	msg := formatPushRuleViolations(git.RefNameFromBranch("main"), violations)
+	lines := strings.Split(msg, "\n")
+	assert.Len(t, lines, maxReportedPushRuleViolations+2)
+	assert.Equal(t, "push to main rejected by push rules:", lines[0])
+	assert.Equal(t, "1234: reason", lines[1])
+	assert.Equal(t, "... and 2 more", lines[len(lines)-1])
+}
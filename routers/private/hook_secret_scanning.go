@@ -0,0 +1,78 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/private"
+	"gitea.dev/modules/setting"
+	secretscan_service "gitea.dev/services/secretscan"
+)
+
+// maxReportedLeaks limits the number of secrets which are returned to the git client
+const maxReportedLeaks = 20
+
+// assertNoSecrets scans the files added by the commits which are new to the repository for secrets.
+// Depending on the push mode, it either raises alerts for the secrets or responds with 403 Forbidden listing them and returns false.
+func (ctx *preReceiveContext) assertNoSecrets(newCommitID string, refFullName git.RefName) bool {
+	if !setting.SecretScanning.Enabled {
+		return true
+	}
+	// the wiki and the merges of pull requests done by Gitea itself are not scanned, the merged commits have already been pushed
+	if ctx.opts.IsWiki || ctx.opts.PullRequestID != 0 {
+		return true
+	}
+	if newCommitID == ctx.Repo.GetObjectFormat().EmptyObjectID().String() {
+		return true
+	}
+
+	leaks, err := secretscan_service.ScanPush(ctx, ctx.Repo.Repository, newCommitID, ctx.env)
+	if err != nil {
+		log.Error("Unable to scan %s in %-v for secrets: %v", refFullName, ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to scan %s for secrets: %v", refFullName, err),
+		})
+		return false
+	}
+	if len(leaks) == 0 {
+		return true
+	}
+
+	if setting.SecretScanning.PushMode == setting.SecretScanningPushModeBlock {
+		log.Warn("Forbidden: push of %s to %s in %-v contains %d secret(s)", newCommitID, refFullName, ctx.Repo.Repository, len(leaks))
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: formatLeaks(refFullName, leaks),
+		})
+		return false
+	}
+
+	alerts, err := secretscan_service.RecordLeaks(ctx, ctx.Repo.Repository, leaks)
+	if err != nil {
+		log.Error("Unable to record the secrets pushed to %s in %-v: %v", refFullName, ctx.Repo.Repository, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to record the secrets pushed to %s: %v", refFullName, err),
+		})
+		return false
+	}
+	log.Warn("Push of %s to %s in %-v raised %d secret scanning alert(s)", newCommitID, refFullName, ctx.Repo.Repository, len(alerts))
+	return true
+}
+
+func formatLeaks(refFullName git.RefName, leaks []*secretscan_service.Leak) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "push to %s rejected, secrets found:", refFullName.ShortName())
+	for i, leak := range leaks {
+		if i == maxReportedLeaks {
+			fmt.Fprintf(&sb, "\n... and %d more", len(leaks)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "\n%s %s:%d: %s (%s)", leak.CommitID, leak.Path, leak.Line, leak.Rule.Description, leak.Redacted())
+	}
+	return sb.String()
+}
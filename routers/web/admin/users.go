@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/auth"
 	"gitea.dev/models/db"
 	org_model "gitea.dev/models/organization"
@@ -26,6 +27,7 @@ import (
 	"gitea.dev/modules/web"
 	"gitea.dev/routers/web/explore"
 	user_setting "gitea.dev/routers/web/user/setting"
+	audit_service "gitea.dev/services/audit"
 	auth_service "gitea.dev/services/auth"
 	"gitea.dev/services/context"
 	"gitea.dev/services/forms"
@@ -454,10 +456,14 @@ func EditUserPost(ctx *context.Context) {
 	log.Trace("Account profile updated by admin (%s): %s", ctx.Doer.Name, u.Name)
 
 	if form.Reset2FA {
-		if _, _, err := auth.DisableTwoFactor(ctx, u.ID); err != nil {
+		totp, webAuthn, err := auth.DisableTwoFactor(ctx, u.ID)
+		if err != nil {
 			ctx.ServerError("auth.DisableTwoFactor", err)
 			return
 		}
+		if totp > 0 || webAuthn > 0 {
+			audit_service.Record(ctx, ctx.Doer, audit_model.ActionTwoFactorDisable, audit_service.UserTarget(u), nil, nil)
+		}
 	}
 
 	ctx.Flash.Success(ctx.Tr("admin.users.update_profile_success"))
@@ -475,6 +481,7 @@ func ImpersonateUser(ctx *context.Context) {
 		ctx.ServerError("unable to impersonate user", err)
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionUserImpersonate, audit_service.UserTarget(u), nil, nil)
 	ctx.JSONRedirect(setting.AppSubURL + "/user/settings")
 }
 
@@ -18,6 +18,7 @@ import (
 	"gitea.dev/modules/templates"
 	"gitea.dev/modules/web"
 	"gitea.dev/routers/web/shared/issue"
+	shared_project "gitea.dev/routers/web/shared/project"
 	shared_user "gitea.dev/routers/web/shared/user"
 	"gitea.dev/services/context"
 	"gitea.dev/services/forms"
@@ -328,11 +329,22 @@ func ViewProject(ctx *context.Context) {
 		milestoneIDs = []int64{db.NoConditionID}
 	}
 
+	view := shared_project.PrepareView(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	fieldFilters := shared_project.PrepareFieldFilters(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
 	opts := issues_model.IssuesOptions{
-		LabelIDs:     preparedLabelFilter.SelectedLabelIDs,
-		AssigneeID:   assigneeID,
-		MilestoneIDs: milestoneIDs,
-		Owner:        project.Owner,
+		LabelIDs:           preparedLabelFilter.SelectedLabelIDs,
+		AssigneeID:         assigneeID,
+		MilestoneIDs:       milestoneIDs,
+		ProjectFieldValues: fieldFilters,
+		Owner:              project.Owner,
 	}
 	if ctx.Doer != nil {
 		opts.Doer = ctx.Doer
@@ -349,6 +361,12 @@ func ViewProject(ctx *context.Context) {
 		column.NumIssues = int64(len(issuesMap[column.ID]))
 	}
 
+	fieldValues := shared_project.PrepareFieldValues(ctx, project, issuesMap)
+	if ctx.Written() {
+		return
+	}
+	shared_project.PrepareViewLayout(ctx, view, columns, issuesMap, fieldValues)
+
 	if project.CardType != project_model.CardTypeTextOnly {
 		issuesAttachmentMap := make(map[int64][]*repo_model.Attachment)
 		for _, issuesList := range issuesMap {
@@ -463,6 +481,13 @@ func ViewProject(ctx *context.Context) {
 	ctx.Data["Project"] = project
 	ctx.Data["IssuesMap"] = issuesMap
 	ctx.Data["Columns"] = columns
+
+	if canWriteProjects(ctx) {
+		shared_project.PrepareAutomations(ctx, project, columns)
+		if ctx.Written() {
+			return
+		}
+	}
 	ctx.Data["Title"] = fmt.Sprintf("%s - %s", project.Title, ctx.ContextUser.DisplayName())
 
 	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	org_model "gitea.dev/models/organization"
 	"gitea.dev/models/perm"
@@ -26,6 +27,7 @@ import (
 	"gitea.dev/modules/util"
 	"gitea.dev/modules/web"
 	shared_user "gitea.dev/routers/web/shared/user"
+	audit_service "gitea.dev/services/audit"
 	"gitea.dev/services/context"
 	"gitea.dev/services/convert"
 	"gitea.dev/services/forms"
@@ -120,9 +122,9 @@ func TeamsAction(ctx *context.Context) {
 			ctx.HTTPError(http.StatusNotFound)
 			return
 		}
-		err = org_service.AddTeamMember(ctx, ctx.Org.Team, ctx.Doer)
+		err = org_service.AddTeamMember(ctx, ctx.Doer, ctx.Org.Team, ctx.Doer)
 	case "leave":
-		err = org_service.RemoveTeamMember(ctx, ctx.Org.Team, ctx.Doer)
+		err = org_service.RemoveTeamMember(ctx, ctx.Doer, ctx.Org.Team, ctx.Doer)
 		if err != nil {
 			if org_model.IsErrLastOrgOwner(err) {
 				ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
@@ -149,7 +151,7 @@ func TeamsAction(ctx *context.Context) {
 			return
 		}
 
-		err = org_service.RemoveTeamMember(ctx, ctx.Org.Team, user)
+		err = org_service.RemoveTeamMember(ctx, ctx.Doer, ctx.Org.Team, user)
 		if err != nil {
 			if org_model.IsErrLastOrgOwner(err) {
 				ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
@@ -204,7 +206,7 @@ func TeamsAction(ctx *context.Context) {
 		if ctx.Org.Team.IsMember(ctx, u.ID) {
 			ctx.Flash.Error(ctx.Tr("org.teams.add_duplicate_users"))
 		} else {
-			err = org_service.AddTeamMember(ctx, ctx.Org.Team, u)
+			err = org_service.AddTeamMember(ctx, ctx.Doer, ctx.Org.Team, u)
 		}
 
 		page = "team"
@@ -419,6 +421,7 @@ func NewTeamPost(ctx *context.Context) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamCreate, audit_service.TeamTarget(t), nil, audit_service.TeamState(ctx, t))
 	log.Trace("Team created: %s/%s", ctx.Org.Organization.Name, t.Name)
 	ctx.Redirect(ctx.Org.OrgLink + "/teams/" + url.PathEscape(t.LowerName))
 }
@@ -557,6 +560,7 @@ func EditTeamPost(ctx *context.Context) {
 	ctx.Data["Team"] = t
 	ctx.Data["Units"] = unit_model.Units
 
+	teamBefore := audit_service.TeamState(ctx, t)
 	if !t.IsOwnerTeam() {
 		t.Name = form.TeamName
 		if t.AccessMode != teamPermission {
@@ -608,14 +612,17 @@ func EditTeamPost(ctx *context.Context) {
 		}
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamUpdate, audit_service.TeamTarget(t), teamBefore, audit_service.TeamState(ctx, t))
 	ctx.Redirect(ctx.Org.OrgLink + "/teams/" + url.PathEscape(t.LowerName))
 }
 
 // DeleteTeam response for the delete team request
 func DeleteTeam(ctx *context.Context) {
+	teamBefore := audit_service.TeamState(ctx, ctx.Org.Team)
 	if err := org_service.DeleteTeam(ctx, ctx.Org.Team); err != nil {
 		ctx.Flash.Error("DeleteTeam: " + err.Error())
 	} else {
+		audit_service.Record(ctx, ctx.Doer, audit_model.ActionTeamDelete, audit_service.TeamTarget(ctx.Org.Team), teamBefore, nil)
 		ctx.Flash.Success(ctx.Tr("org.teams.delete_team_success"))
 	}
 
@@ -657,7 +664,7 @@ func TeamInvitePost(ctx *context.Context) {
 		return
 	}
 
-	if err := org_service.AddTeamMember(ctx, team, ctx.Doer); err != nil {
+	if err := org_service.AddTeamMember(ctx, ctx.Doer, team, ctx.Doer); err != nil {
 		ctx.ServerError("AddTeamMember", err)
 		return
 	}
@@ -0,0 +1,63 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/markup"
+	"gitea.dev/modules/markup/markdown"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/templates"
+	codenav_service "gitea.dev/services/codenav"
+	"gitea.dev/services/context"
+)
+
+const tplCodeNavigation templates.TplName = "repo/code_navigation"
+
+// CodeNavigation renders the popup with the definitions and references of the symbol at a position in a file
+func CodeNavigation(ctx *context.Context) {
+	if !setting.CodeNavigation.Enabled {
+		ctx.NotFound(nil)
+		return
+	}
+	ref := ctx.FormString("ref")
+	if ref == "" {
+		ctx.NotFound(nil)
+		return
+	}
+	commit, err := ctx.Repo.GitRepo.GetCommit(ctx, ref)
+	if err != nil {
+		ctx.NotFoundOrServerError("GetCommit", git.IsErrNotExist, err)
+		return
+	}
+
+	nav, err := codenav_service.Navigate(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, commit, codenav_service.NavigateOptions{
+		Path:      ctx.FormString("path"),
+		Line:      ctx.FormInt("line"),
+		Character: ctx.FormInt("character"),
+		Word:      ctx.FormString("word"),
+	})
+	if err != nil {
+		ctx.ServerError("Navigate", err)
+		return
+	}
+
+	if len(nav.Documentation) > 0 {
+		rctx := markup.NewRenderContext(ctx).WithMetas(markup.ComposeSimpleDocumentMetas())
+		var documentation template.HTML
+		if documentation, err = markdown.RenderString(rctx, strings.Join(nav.Documentation, "\n\n")); err != nil {
+			ctx.ServerError("RenderString", err)
+			return
+		}
+		ctx.Data["Documentation"] = documentation
+	}
+	ctx.Data["Navigation"] = nav
+	ctx.Data["Word"] = ctx.FormString("word")
+	ctx.Data["CommitID"] = commit.ID.String()
+	ctx.HTML(http.StatusOK, tplCodeNavigation)
+}
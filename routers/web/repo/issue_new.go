@@ -356,13 +356,6 @@ func NewIssuePost(ctx *context.Context) {
 		return
 	}
 
-	content := form.Content
-	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
-		if template, err := issue_template.UnmarshalFromRepo(ctx, ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
-			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
-		}
-	}
-
 	issue := &issues_model.Issue{
 		RepoID:      repo.ID,
 		Repo:        repo,
@@ -370,9 +363,23 @@ func NewIssuePost(ctx *context.Context) {
 		PosterID:    ctx.Doer.ID,
 		Poster:      ctx.Doer,
 		MilestoneID: milestoneID,
-		Content:     content,
+		Content:     form.Content,
 		Ref:         form.Ref,
 	}
+	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
+		if template, err := issue_template.UnmarshalFromRepo(ctx, ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil && template.Type() == api.IssueTemplateTypeYaml {
+			uuids, err := issue_service.PrepareIssueForm(ctx, repo, ctx.Doer, issue, template, ctx.Req.Form)
+			if err != nil {
+				if errors.Is(err, util.ErrInvalidArgument) {
+					ctx.JSONError(ctx.Tr("repo.issues.new.invalid_form_value", err.Error()))
+				} else {
+					ctx.ServerError("PrepareIssueForm", err)
+				}
+				return
+			}
+			attachments = append(attachments, uuids...)
+		}
+	}
 
 	if err := issue_service.NewIssue(ctx, repo, issue, labelIDs, attachments, assigneeIDs, projectIDs); err != nil {
 		if repo_model.IsErrUserDoesNotHaveAccessToRepo(err) {
@@ -378,6 +378,7 @@ func ViewIssue(ctx *context.Context) {
 	}
 	if issue.IsPull {
 		prepareFuncs = append(prepareFuncs,
+			prepareIssueViewSidebarPullStack,
 			prViewInfo.prepareViewInfo,
 			prViewInfo.prepareMergeBox,
 		)
@@ -486,6 +487,30 @@ func prepareIssueViewSidebarDependency(ctx *context.Context, issue *issues_model
 	ctx.Data["BlockingDependencies"], ctx.Data["BlockingDependenciesNotPermitted"] = checkBlockedByIssues(ctx, blocking)
 }
 
+func prepareIssueViewSidebarPullStack(ctx *context.Context, issue *issues_model.Issue) {
+	if issue.PullRequest.HasMerged || issue.IsClosed {
+		return
+	}
+	stack, err := issues_model.GetPullRequestStack(ctx, issue.PullRequest)
+	if err != nil {
+		ctx.ServerError("GetPullRequestStack", err)
+		return
+	}
+	if len(stack) < 2 {
+		return
+	}
+	if err := stack.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	for _, pr := range stack {
+		if pr.Issue.Repo == nil {
+			pr.Issue.Repo = issue.Repo // the whole stack lives in the base repository
+		}
+	}
+	ctx.Data["PullStack"] = stack
+}
+
 func (prInfo *pullRequestViewInfo) prepareMergeBoxCommitSigning(ctx *context.Context) {
 	pull := prInfo.issue.PullRequest
 	data := prInfo.MergeBoxData
@@ -969,9 +994,19 @@ func (prInfo *pullRequestViewInfo) prepareMergeBox(ctx *context.Context, issue *
 		data.canBypassProtectionAsAdmin = isRepoAdmin && !prInfo.ProtectedBranchRule.BlockAdminMergeOverride
 	}
 
+	if !pull.HasMerged && !issue.IsClosed {
+		var err error
+		data.stackParent, err = issues_model.GetStackParentPullRequest(ctx, pull)
+		if err != nil {
+			ctx.ServerError("GetStackParentPullRequest", err)
+			return
+		}
+	}
+
 	// CanMergeNow means: if the doer has write permission, whether the PR can be merged now
 	data.canMergeNow = (!data.hasOverridableBlockers || data.canBypassProtection) && // status checks are satisfied
-		(!data.requireSigned || data.willSign) // signing requirement is satisfied
+		(!data.requireSigned || data.willSign) && // signing requirement is satisfied
+		data.stackParent == nil // a stacked PR can't be merged before its parent, even by admins
 
 	prInfo.prepareMergeBoxFormProps(ctx)
 	prInfo.prepareMergeBoxInfoItems(ctx)
@@ -23,6 +23,7 @@ import (
 	"gitea.dev/modules/util"
 	"gitea.dev/modules/web"
 	"gitea.dev/routers/web/shared/issue"
+	shared_project "gitea.dev/routers/web/shared/project"
 	shared_user "gitea.dev/routers/web/shared/user"
 	"gitea.dev/services/context"
 	"gitea.dev/services/forms"
@@ -312,11 +313,22 @@ func ViewProject(ctx *context.Context) {
 		milestoneIDs = []int64{db.NoConditionID}
 	}
 
+	view := shared_project.PrepareView(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
+	fieldFilters := shared_project.PrepareFieldFilters(ctx, project)
+	if ctx.Written() {
+		return
+	}
+
 	issuesMap, err := project_service.LoadIssuesFromProject(ctx, project, &issues_model.IssuesOptions{
-		RepoIDs:      []int64{ctx.Repo.Repository.ID},
-		LabelIDs:     preparedLabelFilter.SelectedLabelIDs,
-		AssigneeID:   assigneeID,
-		MilestoneIDs: milestoneIDs,
+		RepoIDs:            []int64{ctx.Repo.Repository.ID},
+		LabelIDs:           preparedLabelFilter.SelectedLabelIDs,
+		AssigneeID:         assigneeID,
+		MilestoneIDs:       milestoneIDs,
+		ProjectFieldValues: fieldFilters,
 	})
 	if err != nil {
 		ctx.ServerError("LoadIssuesOfColumns", err)
@@ -326,6 +338,12 @@ func ViewProject(ctx *context.Context) {
 		column.NumIssues = int64(len(issuesMap[column.ID]))
 	}
 
+	fieldValues := shared_project.PrepareFieldValues(ctx, project, issuesMap)
+	if ctx.Written() {
+		return
+	}
+	shared_project.PrepareViewLayout(ctx, view, columns, issuesMap, fieldValues)
+
 	if project.CardType != project_model.CardTypeTextOnly {
 		issuesAttachmentMap := make(map[int64][]*repo_model.Attachment)
 		for _, issuesList := range issuesMap {
@@ -427,6 +445,13 @@ func ViewProject(ctx *context.Context) {
 	ctx.Data["IssuesMap"] = issuesMap
 	ctx.Data["Columns"] = columns
 
+	if ctx.Repo.Permission.CanWrite(unit.TypeProjects) {
+		shared_project.PrepareAutomations(ctx, project, columns)
+		if ctx.Written() {
+			return
+		}
+	}
+
 	ctx.HTML(http.StatusOK, tplProjectsView)
 }
 
@@ -34,6 +34,7 @@ import (
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/optional"
 	"gitea.dev/modules/setting"
+	api "gitea.dev/modules/structs"
 	"gitea.dev/modules/templates"
 	"gitea.dev/modules/translation"
 	"gitea.dev/modules/util"
@@ -48,6 +49,7 @@ import (
 	"gitea.dev/services/forms"
 	git_service "gitea.dev/services/git"
 	"gitea.dev/services/gitdiff"
+	issue_service "gitea.dev/services/issue"
 	"gitea.dev/services/notifications"
 	notify_service "gitea.dev/services/notify"
 	pull_service "gitea.dev/services/pull"
@@ -286,6 +288,7 @@ type pullMergeBoxData struct {
 	UpdateStyleOptions  []*pullUpdateAction
 
 	MergeFormProps        map[string]any
+	InMergeQueue          bool // the pull request is waiting in the merge queue and can be removed from it
 	ShowPullCommands      bool
 	ShowMergeInstructions bool
 	AutodetectManualMerge bool
@@ -302,6 +305,7 @@ type pullMergeBoxData struct {
 	isBlockedByChangedProtectedFiles  bool
 	requireSigned, willSign           bool
 	signingKeyMergeDisplay            string
+	stackParent                       *issues_model.PullRequest // the unmerged PR this one is stacked on
 
 	infoCommitBlockers     pullMergeBoxInfoItemCollection
 	infoProtectionBlockers pullMergeBoxInfoItemCollection
@@ -847,6 +851,20 @@ func viewPullFiles(ctx *context.Context, beforeCommitID, afterCommitID string) {
 		}
 	}
 
+	// the owners of the files only help the reviewers, the page is still usable without them
+	if codeOwners, err := issue_service.GetPullRequestCodeOwners(ctx, gitRepo, pull); err != nil {
+		log.Error("GetPullRequestCodeOwners(%d): %v", pull.ID, err)
+	} else if codeOwners != nil {
+		fileNames := make([]string, 0, len(diff.Files))
+		for _, file := range diff.Files {
+			fileNames = append(fileNames, file.Name)
+		}
+		matches, _ := codeOwners.MatchFiles(fileNames)
+		for _, file := range diff.Files {
+			file.CodeOwnerRules = matches[file.Name]
+		}
+	}
+
 	if !fileOnly {
 		// note: use mergeBase is set to false because we already have the merge base from the pull request info
 		diffTree, err := gitdiff.GetDiffTree(ctx, gitRepo, false, beforeCommitID, afterCommitID)
@@ -1090,6 +1108,8 @@ func MergePullRequest(ctx *context.Context) {
 			ctx.JSONError(ctx.Tr("repo.pulls.require_signed_head_commits_unverified"))
 		case errors.Is(err, pull_service.ErrDependenciesLeft):
 			ctx.JSONError(ctx.Tr("repo.issues.dependency.pr_close_blocked"))
+		case errors.Is(err, pull_service.ErrStackParentNotMerged):
+			ctx.JSONError(ctx.Tr("repo.pulls.no_merge_stack_parent"))
 		default:
 			ctx.ServerError("WebCheck", err)
 		}
@@ -1151,6 +1171,27 @@ func MergePullRequest(ctx *context.Context) {
 		}
 	}
 
+	if !form.ForceMerge {
+		mergeQueueRequired, err := automerge.IsMergeQueueRequired(ctx, pr)
+		if err != nil {
+			ctx.ServerError("IsMergeQueueRequired", err)
+			return
+		}
+		if mergeQueueRequired {
+			if err := automerge.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, deleteBranchAfterMerge); err != nil {
+				if errors.Is(err, util.ErrAlreadyExist) {
+					ctx.JSONError(ctx.Tr("repo.pulls.merge_queue_already_queued"))
+					return
+				}
+				ctx.ServerError("AddToMergeQueue", err)
+				return
+			}
+			ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue_added"))
+			ctx.JSONRedirect(issue.Link())
+			return
+		}
+	}
+
 	if err := pull_service.Merge(ctx, pr, ctx.Doer, repo_model.MergeStyle(form.Do), form.HeadCommitID, message, false); err != nil {
 		if pull_service.IsErrInvalidMergeStyle(err) {
 			ctx.JSONError(ctx.Tr("repo.pulls.invalid_merge_option"))
@@ -1291,6 +1332,43 @@ func CancelAutoMergePullRequest(ctx *context.Context) {
 	ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
 }
 
+// CancelMergeQueuePullRequest removes a pull request from the merge queue
+func CancelMergeQueuePullRequest(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, issue.PullRequest.ID)
+	if db.IsErrNotExist(err) {
+		ctx.Flash.Error(ctx.Tr("repo.pulls.merge_queue_not_queued"))
+		ctx.JSONRedirect(issue.Link())
+		return
+	} else if err != nil {
+		ctx.ServerError("GetMergeQueueEntryByPullID", err)
+		return
+	}
+
+	if ctx.Doer.ID != entry.DoerID {
+		allowed, err := pull_service.IsUserAllowedToMerge(ctx, issue.PullRequest, ctx.Repo.Permission, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("IsUserAllowedToMerge", err)
+			return
+		}
+		if !allowed {
+			ctx.HTTPError(http.StatusForbidden, "user has no permission to remove the pull request from the merge queue")
+			return
+		}
+	}
+
+	if err := automerge.RemoveFromMergeQueue(ctx, issue.PullRequest); err != nil && !db.IsErrNotExist(err) {
+		ctx.ServerError("RemoveFromMergeQueue", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue_removed"))
+	ctx.JSONRedirect(issue.Link())
+}
+
 func stopTimerIfAvailable(ctx *context.Context, user *user_model.User, issue *issues_model.Issue) error {
 	stopped, err := issues_model.FinishIssueStopwatch(ctx, user, issue)
 	if err != nil || !stopped {
@@ -1369,13 +1447,6 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 		return
 	}
 
-	content := form.Content
-	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
-		if template, err := issue_template.UnmarshalFromRepo(ctx, ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
-			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
-		}
-	}
-
 	pullIssue := &issues_model.Issue{
 		RepoID:      repo.ID,
 		Repo:        repo,
@@ -1384,8 +1455,23 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 		Poster:      ctx.Doer,
 		MilestoneID: milestoneID,
 		IsPull:      true,
-		Content:     content,
+		Content:     form.Content,
 	}
+	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
+		if template, err := issue_template.UnmarshalFromRepo(ctx, ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil && template.Type() == api.IssueTemplateTypeYaml {
+			uuids, err := issue_service.PrepareIssueForm(ctx, repo, ctx.Doer, pullIssue, template, ctx.Req.Form)
+			if err != nil {
+				if errors.Is(err, util.ErrInvalidArgument) {
+					ctx.JSONError(ctx.Tr("repo.issues.new.invalid_form_value", err.Error()))
+				} else {
+					ctx.ServerError("PrepareIssueForm", err)
+				}
+				return
+			}
+			attachments = append(attachments, uuids...)
+		}
+	}
+
 	pullRequest := &issues_model.PullRequest{
 		HeadRepoID:          ci.HeadRepo.ID,
 		BaseRepoID:          repo.ID,
@@ -4,8 +4,12 @@
 package repo
 
 import (
+	"fmt"
 	"html/template"
+	"slices"
 
+	"gitea.dev/models/db"
+	pull_model "gitea.dev/models/pull"
 	"gitea.dev/modules/htmlutil"
 	"gitea.dev/modules/svg"
 	"gitea.dev/modules/util"
@@ -157,6 +161,14 @@ func (prInfo *pullRequestViewInfo) prepareMergeBoxInfoItems(ctx *context.Context
 		}
 	}
 
+	if data.stackParent != nil {
+		parentLink := fmt.Sprintf("%s/pulls/%d", prInfo.issue.Repo.Link(), data.stackParent.Index)
+		prInfo.MergeBoxData.infoProtectionBlockers.AddInfoItem(
+			svg.RenderHTML("octicon-git-pull-request"),
+			ctx.Locale.Tr("repo.pulls.stack_parent_not_merged", htmlutil.HTMLFormat(`<a href="%s">#%d</a>`, parentLink, data.stackParent.Index)),
+		)
+	}
+
 	if !data.hasPermToMerge {
 		prInfo.MergeBoxData.infoProtectionBlockers.AddInfoItem(
 			svg.RenderHTML("octicon-info"),
@@ -182,6 +194,11 @@ func (prInfo *pullRequestViewInfo) prepareMergeBoxInfoItems(ctx *context.Context
 		}
 	}
 
+	prInfo.prepareMergeQueueInfoItems(ctx)
+	if ctx.Written() {
+		return
+	}
+
 	if len(data.infoCommitBlockers.items) > 0 {
 		data.InfoSections = append(data.InfoSections, &pullInfoSection{data.infoCommitBlockers.items})
 	} else {
@@ -189,3 +206,37 @@ func (prInfo *pullRequestViewInfo) prepareMergeBoxInfoItems(ctx *context.Context
 	}
 	data.InfoSections = append(data.InfoSections, &pullInfoSection{data.infoMergePrompts.items})
 }
+
+func (prInfo *pullRequestViewInfo) prepareMergeQueueInfoItems(ctx *context.Context) {
+	pull := prInfo.issue.PullRequest
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pull.ID)
+	if db.IsErrNotExist(err) {
+		return
+	} else if err != nil {
+		ctx.ServerError("GetMergeQueueEntryByPullID", err)
+		return
+	}
+
+	switch entry.Status {
+	case pull_model.MergeQueueEntryStatusFailed:
+		prInfo.MergeBoxData.infoMergePrompts.AddErrorItem(ctx.Locale.Tr("repo.pulls.merge_queue_failed", entry.FailReason))
+		return
+	case pull_model.MergeQueueEntryStatusChecking:
+		prInfo.MergeBoxData.infoMergePrompts.AddInfoItem(
+			svg.RenderHTML("gitea-running", 16, "rotate-clockwise"),
+			ctx.Locale.Tr("repo.pulls.merge_queue_checking"),
+		)
+	default:
+		entries, err := pull_model.GetMergeQueueEntries(ctx, entry.RepoID, entry.BaseBranch)
+		if err != nil {
+			ctx.ServerError("GetMergeQueueEntries", err)
+			return
+		}
+		position := slices.IndexFunc(entries, func(e *pull_model.MergeQueueEntry) bool { return e.ID == entry.ID }) + 1
+		prInfo.MergeBoxData.infoMergePrompts.AddInfoItem(
+			svg.RenderHTML("octicon-clock"),
+			ctx.Locale.Tr("repo.pulls.merge_queue_position", position),
+		)
+	}
+	prInfo.MergeBoxData.InMergeQueue = ctx.Doer != nil && (prInfo.MergeBoxData.hasPermToMerge || ctx.Doer.ID == entry.DoerID)
+}
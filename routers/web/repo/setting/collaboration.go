@@ -98,7 +98,7 @@ func CollaborationPost(ctx *context.Context) {
 		}
 	}
 
-	if err = repo_service.AddOrUpdateCollaborator(ctx, ctx.Repo.Repository, u, perm.AccessModeWrite); err != nil {
+	if err = repo_service.AddOrUpdateCollaborator(ctx, ctx.Doer, ctx.Repo.Repository, u, perm.AccessModeWrite); err != nil {
 		if errors.Is(err, user_model.ErrBlockedUser) {
 			ctx.Flash.Error(ctx.Tr("repo.settings.add_collaborator.blocked_user"))
 			ctx.Redirect(ctx.Repo.RepoLink + "/settings/collaboration")
@@ -125,7 +125,7 @@ func ChangeCollaborationAccessMode(ctx *context.Context) {
 		return
 	}
 	mode := perm.AccessMode(ctx.FormInt("mode"))
-	if err := repo_service.AddOrUpdateCollaborator(ctx, ctx.Repo.Repository, u, mode); err != nil {
+	if err := repo_service.AddOrUpdateCollaborator(ctx, ctx.Doer, ctx.Repo.Repository, u, mode); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		log.Error("AddOrUpdateCollaborator: %v", err)
 		return
@@ -143,7 +143,7 @@ func DeleteCollaboration(ctx *context.Context) {
 			return
 		}
 	} else {
-		if err := repo_service.DeleteCollaboration(ctx, ctx.Repo.Repository, collaborator); err != nil {
+		if err := repo_service.DeleteCollaboration(ctx, ctx.Doer, ctx.Repo.Repository, collaborator); err != nil {
 			ctx.Flash.Error("DeleteCollaboration: " + err.Error())
 		} else {
 			ctx.Flash.Success(ctx.Tr("repo.settings.remove_collaborator_success"))
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	audit_model "gitea.dev/models/audit"
 	git_model "gitea.dev/models/git"
 	"gitea.dev/models/organization"
 	"gitea.dev/models/perm"
@@ -21,10 +22,13 @@ import (
 	"gitea.dev/modules/base"
 	"gitea.dev/modules/glob"
 	"gitea.dev/modules/json"
+	api "gitea.dev/modules/structs"
 	"gitea.dev/modules/templates"
 	"gitea.dev/modules/web"
 	"gitea.dev/routers/web/repo"
+	audit_service "gitea.dev/services/audit"
 	"gitea.dev/services/context"
+	"gitea.dev/services/convert"
 	"gitea.dev/services/forms"
 	pull_service "gitea.dev/services/pull"
 	"gitea.dev/services/repository"
@@ -149,12 +153,15 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 			return
 		}
 	}
+	var protectBranchBefore *api.BranchProtection
 	if protectBranch == nil {
 		// No options found, create defaults.
 		protectBranch = &git_model.ProtectedBranch{
 			RepoID:   ctx.Repo.Repository.ID,
 			RuleName: f.RuleName,
 		}
+	} else {
+		protectBranchBefore = convert.ToBranchProtection(ctx, protectBranch, ctx.Repo.Repository)
 	}
 
 	var whitelistUsers, whitelistTeams, forcePushAllowlistUsers, forcePushAllowlistTeams, mergeWhitelistUsers, mergeWhitelistTeams, approvalsWhitelistUsers, approvalsWhitelistTeams, bypassAllowlistUsers, bypassAllowlistTeams []int64
@@ -274,6 +281,8 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 	protectBranch.UnprotectedFilePatterns = f.UnprotectedFilePatterns
 	protectBranch.BlockOnOutdatedBranch = f.BlockOnOutdatedBranch
 	protectBranch.BlockAdminMergeOverride = f.BlockAdminMergeOverride
+	protectBranch.EnableMergeQueue = f.EnableMergeQueue
+	protectBranch.MergeQueueMaxGroupSize = max(f.MergeQueueMaxGroupSize, 0)
 
 	if err = pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
 		UserIDs:          whitelistUsers,
@@ -291,6 +300,13 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		return
 	}
 
+	protectBranchAfter := convert.ToBranchProtection(ctx, protectBranch, ctx.Repo.Repository)
+	if protectBranchBefore == nil {
+		audit_service.Record(ctx, ctx.Doer, audit_model.ActionProtectedBranchCreate, audit_service.ProtectedBranchTarget(ctx.Repo.Repository, protectBranch), nil, protectBranchAfter)
+	} else {
+		audit_service.Record(ctx, ctx.Doer, audit_model.ActionProtectedBranchUpdate, audit_service.ProtectedBranchTarget(ctx.Repo.Repository, protectBranch), protectBranchBefore, protectBranchAfter)
+	}
+
 	ctx.Flash.Success(ctx.Tr("repo.settings.update_protect_branch_success", protectBranch.RuleName))
 	ctx.Redirect(fmt.Sprintf("%s/settings/branches?rule_name=%s", ctx.Repo.RepoLink, protectBranch.RuleName))
 }
@@ -317,11 +333,13 @@ func DeleteProtectedBranchRulePost(ctx *context.Context) {
 		return
 	}
 
+	ruleBefore := convert.ToBranchProtection(ctx, rule, ctx.Repo.Repository)
 	if err := git_model.DeleteProtectedBranch(ctx, ctx.Repo.Repository, ruleID); err != nil {
 		ctx.Flash.Error(ctx.Tr("repo.settings.remove_protected_branch_failed", rule.RuleName))
 		ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/branches")
 		return
 	}
+	audit_service.Record(ctx, ctx.Doer, audit_model.ActionProtectedBranchDelete, audit_service.ProtectedBranchTarget(ctx.Repo.Repository, rule), ruleBefore, nil)
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.remove_protected_branch_success", rule.RuleName))
 	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/branches")
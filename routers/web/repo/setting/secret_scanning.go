@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+
+	"gitea.dev/models/db"
+	secretscan_model "gitea.dev/models/secretscan"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/templates"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/context"
+	secretscan_service "gitea.dev/services/secretscan"
+)
+
+const tplSecretScanning templates.TplName = "repo/settings/secret_scanning"
+
+// SecretScanning render the secret scanning alerts of a repository
+func SecretScanning(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.secret_scanning")
+	ctx.Data["PageIsSettingsSecretScanning"] = true
+
+	status, ok := secretscan_model.ParseAlertStatus(ctx.FormString("state"))
+	if !ok {
+		status = secretscan_model.AlertStatusOpen
+	}
+	ctx.Data["State"] = status.String()
+
+	page := max(ctx.FormInt("page"), 1)
+	alerts, total, err := db.FindAndCount[secretscan_model.Alert](ctx, secretscan_model.FindAlertsOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.ExplorePagingNum},
+		RepoID:      ctx.Repo.Repository.ID,
+		Status:      optional.Some(status),
+	})
+	if err != nil {
+		ctx.ServerError("FindAlerts", err)
+		return
+	}
+	for _, alert := range alerts {
+		if err := alert.LoadResolver(ctx); err != nil {
+			ctx.ServerError("LoadResolver", err)
+			return
+		}
+	}
+	ctx.Data["Alerts"] = alerts
+
+	numOpen, err := secretscan_model.CountOpenAlerts(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("CountOpenAlerts", err)
+		return
+	}
+	ctx.Data["NumOpenAlerts"] = numOpen
+
+	pager := context.NewPagination(total, setting.UI.ExplorePagingNum, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplSecretScanning)
+}
+
+// SecretScanningAlertStatePost response for triaging a secret scanning alert
+func SecretScanningAlertStatePost(ctx *context.Context) {
+	alert, err := secretscan_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.NotFound(nil)
+		return
+	} else if err != nil {
+		ctx.ServerError("GetAlertByID", err)
+		return
+	}
+	status, ok := secretscan_model.ParseAlertStatus(ctx.FormString("state"))
+	if !ok {
+		ctx.HTTPError(http.StatusBadRequest)
+		return
+	}
+	if err := secretscan_model.UpdateAlertStatus(ctx, alert, status, ctx.Doer.ID); err != nil {
+		ctx.ServerError("UpdateAlertStatus", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.secret_scanning.update_success"))
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/secret_scanning")
+}
+
+// SecretScanningScanPost response for queueing a secret scan of the full history of a repository
+func SecretScanningScanPost(ctx *context.Context) {
+	if err := secretscan_service.QueueHistoryScan(ctx.Repo.Repository); err != nil {
+		ctx.ServerError("QueueHistoryScan", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.secret_scanning.scan_queued"))
+	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/secret_scanning")
+}
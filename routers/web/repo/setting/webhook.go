@@ -592,6 +592,31 @@ func packagistHookParams(ctx *context.Context) webhookParams {
 	}
 }
 
+// CustomHooksNewPost response for creating custom webhook
+func CustomHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, customHookParams(ctx))
+}
+
+// CustomHooksEditPost response for editing custom webhook
+func CustomHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, customHookParams(ctx))
+}
+
+func customHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm[*forms.NewCustomHookForm](ctx)
+
+	// the form has been validated, so the metadata can only be missing if the validation failed
+	meta, _ := form.Meta()
+	return webhookParams{
+		Type:        webhook_module.CUSTOM,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeJSON,
+		HTTPMethod:  form.HTTPMethod,
+		WebhookForm: form.WebhookForm,
+		Meta:        meta,
+	}
+}
+
 func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 	orCtx, err := getOwnerRepoCtx(ctx)
 	if err != nil {
@@ -630,6 +655,9 @@ func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 		ctx.Data["MatrixHook"] = webhook_service.GetMatrixHook(w)
 	case webhook_module.PACKAGIST:
 		ctx.Data["PackagistHook"] = webhook_service.GetPackagistHook(w)
+	case webhook_module.CUSTOM:
+		ctx.Data["CustomHook"] = webhook_service.GetCustomHook(w)
+		ctx.Data["CustomHookPublicKey"] = webhook_service.CustomPublicKey(w)
 	}
 
 	ctx.Data["History"], err = w.History(ctx, 1)
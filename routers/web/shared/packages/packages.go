@@ -6,6 +6,7 @@ package packages
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	packages_model "gitea.dev/models/packages"
@@ -28,6 +29,14 @@ func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
 	}
 
 	ctx.Data["CleanupRules"] = pcrs
+
+	pus, err := packages_model.GetUpstreamsByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetUpstreamsByOwner", err)
+		return
+	}
+
+	ctx.Data["Upstreams"] = pus
 }
 
 func SetRuleAddContext(ctx *context.Context) {
@@ -231,6 +240,143 @@ func getCleanupRuleByContext(ctx *context.Context, owner *user_model.User) *pack
 	return nil
 }
 
+func SetUpstreamAddContext(ctx *context.Context) {
+	setUpstreamEditContext(ctx, nil)
+}
+
+func SetUpstreamEditContext(ctx *context.Context, owner *user_model.User) {
+	pu := getUpstreamByContext(ctx, owner)
+	if pu == nil {
+		return
+	}
+
+	setUpstreamEditContext(ctx, pu)
+}
+
+func setUpstreamEditContext(ctx *context.Context, pu *packages_model.PackageUpstream) {
+	ctx.Data["IsEditUpstream"] = pu != nil
+
+	if pu == nil {
+		pu = &packages_model.PackageUpstream{}
+	}
+	ctx.Data["Upstream"] = pu
+	ctx.Data["AvailableTypes"] = packages_model.UpstreamTypeList
+}
+
+func PerformUpstreamAddPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	performUpstreamEditPost(ctx, owner, nil, redirectURL, template)
+}
+
+func PerformUpstreamEditPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	pu := getUpstreamByContext(ctx, owner)
+	if pu == nil {
+		return
+	}
+
+	form := web.GetForm[*forms.PackageUpstreamForm](ctx)
+
+	if form.Action == "remove" {
+		if err := packages_model.DeleteUpstreamByID(ctx, pu.ID); err != nil {
+			ctx.ServerError("DeleteUpstreamByID", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.upstreams.success.delete"))
+		ctx.Redirect(redirectURL)
+	} else {
+		performUpstreamEditPost(ctx, owner, pu, redirectURL, template)
+	}
+}
+
+func performUpstreamEditPost(ctx *context.Context, owner *user_model.User, pu *packages_model.PackageUpstream, redirectURL string, template templates.TplName) {
+	isEditUpstream := pu != nil
+
+	if pu == nil {
+		pu = &packages_model.PackageUpstream{}
+	}
+
+	form := web.GetForm[*forms.PackageUpstreamForm](ctx)
+
+	pu.Enabled = form.Enabled
+	pu.OwnerID = owner.ID
+	pu.URL = strings.TrimSuffix(form.URL, "/")
+	if pu.Username != form.Username {
+		// the stored password belongs to the previous user name
+		pu.PasswordEncrypted = ""
+	}
+	pu.Username = form.Username
+
+	ctx.Data["IsEditUpstream"] = isEditUpstream
+	ctx.Data["Upstream"] = pu
+	ctx.Data["AvailableTypes"] = packages_model.UpstreamTypeList
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+
+	// an empty password keeps the stored one, it is never sent to the browser
+	if form.Password != "" {
+		if err := pu.SetPassword(form.Password); err != nil {
+			ctx.ServerError("SetPassword", err)
+			return
+		}
+	}
+
+	if isEditUpstream {
+		if err := packages_model.UpdateUpstream(ctx, pu); err != nil {
+			ctx.ServerError("UpdateUpstream", err)
+			return
+		}
+	} else {
+		pu.Type = packages_model.Type(form.Type)
+
+		if has, err := packages_model.HasOwnerUpstreamForPackageType(ctx, owner.ID, pu.Type); err != nil {
+			ctx.ServerError("HasOwnerUpstreamForPackageType", err)
+			return
+		} else if has {
+			ctx.Data["Err_Type"] = true
+			ctx.Flash.Error(ctx.Tr("packages.owner.settings.upstreams.type.already_exists"), true)
+			ctx.HTML(http.StatusOK, template)
+			return
+		}
+
+		var err error
+		if pu, err = packages_model.InsertUpstream(ctx, pu); err != nil {
+			ctx.ServerError("InsertUpstream", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.upstreams.success.update"))
+	ctx.Redirect(fmt.Sprintf("%s/upstreams/%d", redirectURL, pu.ID))
+}
+
+func getUpstreamByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageUpstream {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.PathParamInt64("id")
+	}
+
+	pu, err := packages_model.GetUpstreamByID(ctx, id)
+	if err != nil {
+		if err == packages_model.ErrPackageUpstreamNotExist {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetUpstreamByID", err)
+		}
+		return nil
+	}
+
+	if pu != nil && pu.OwnerID == owner.ID {
+		return pu
+	}
+
+	ctx.NotFound(fmt.Errorf("PackageUpstream[%v] not associated to owner %v", id, owner))
+
+	return nil
+}
+
 func InitializeCargoIndex(ctx *context.Context, owner *user_model.User) {
 	err := cargo_service.InitializeIndexRepository(ctx, owner, owner)
 	if err != nil {
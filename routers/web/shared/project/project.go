@@ -5,10 +5,13 @@ package project
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 
 	issues_model "gitea.dev/models/issues"
 	project_model "gitea.dev/models/project"
 	"gitea.dev/modules/json"
+	"gitea.dev/modules/util"
 	"gitea.dev/modules/web"
 	"gitea.dev/services/context"
 	"gitea.dev/services/forms"
@@ -203,3 +206,160 @@ func MoveIssues(ctx *context.Context) {
 
 	ctx.JSONOK()
 }
+
+// automationView is an automation with the label and the column it refers to
+type automationView struct {
+	*project_model.Automation
+	Label  *issues_model.Label
+	Column *project_model.Column
+}
+
+// PrepareFieldFilters loads the custom fields of the project and returns the field filters of the request,
+// every field is filtered by the "field_<id>" query parameter. Invalid filter values are ignored.
+func PrepareFieldFilters(ctx *context.Context, project *project_model.Project) map[int64]string {
+	fields, err := project_model.GetFields(ctx, project.ID)
+	if err != nil {
+		ctx.ServerError("GetFields", err)
+		return nil
+	}
+
+	filters := make(map[int64]string)
+	query := url.Values{}
+	for _, field := range fields {
+		key := fmt.Sprintf("field_%d", field.ID)
+		value := ctx.FormTrim(key)
+		if value == "" {
+			continue
+		}
+		if value, err = field.NormalizeValue(value); err == nil {
+			filters[field.ID] = value
+			query.Set(key, value)
+		}
+	}
+	ctx.Data["ProjectFields"] = fields
+	ctx.Data["ProjectFieldFilters"] = filters
+	ctx.Data["ProjectFieldQuery"] = query.Encode()
+	return filters
+}
+
+type fieldValueView struct {
+	Field *project_model.Field
+	Value string
+}
+
+// PrepareFieldValues loads the custom field values of the issues shown on the board and returns them by issue
+// and field ID, it has to be called after PrepareFieldFilters
+func PrepareFieldValues(ctx *context.Context, project *project_model.Project, issuesMap map[int64]issues_model.IssueList) map[int64]map[int64]string {
+	fields, _ := ctx.Data["ProjectFields"].([]*project_model.Field)
+	if len(fields) == 0 {
+		return nil
+	}
+	var issueIDs []int64
+	for _, issues := range issuesMap {
+		for _, issue := range issues {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	valuesMap, err := project_model.GetFieldValuesMap(ctx, project.ID, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetFieldValuesMap", err)
+		return nil
+	}
+
+	views := make(map[int64][]*fieldValueView, len(valuesMap))
+	for issueID, values := range valuesMap {
+		for _, field := range fields {
+			if value, ok := values[field.ID]; ok {
+				views[issueID] = append(views[issueID], &fieldValueView{Field: field, Value: field.DisplayValue(value)})
+			}
+		}
+	}
+	ctx.Data["ProjectFieldValues"] = views
+	return valuesMap
+}
+
+// PrepareAutomations loads the automations of the project for the board's automation modal
+func PrepareAutomations(ctx *context.Context, project *project_model.Project, columns project_model.ColumnList) {
+	automations, err := project_model.GetAutomations(ctx, project.ID)
+	if err != nil {
+		ctx.ServerError("GetAutomations", err)
+		return
+	}
+	labelIDs := make([]int64, 0, len(automations))
+	for _, a := range automations {
+		if a.LabelID != 0 {
+			labelIDs = append(labelIDs, a.LabelID)
+		}
+	}
+	labels, err := issues_model.GetLabelsByIDs(ctx, labelIDs)
+	if err != nil {
+		ctx.ServerError("GetLabelsByIDs", err)
+		return
+	}
+
+	views := make([]*automationView, 0, len(automations))
+	for _, a := range automations {
+		view := &automationView{Automation: a}
+		for _, label := range labels {
+			if label.ID == a.LabelID {
+				view.Label = label
+			}
+		}
+		for _, column := range columns {
+			if column.ID == a.ColumnID {
+				view.Column = column
+			}
+		}
+		views = append(views, view)
+	}
+	ctx.Data["Automations"] = views
+	ctx.Data["AutomationEvents"] = project_model.AutomationEvents()
+}
+
+func AddAutomationPost(ctx *context.Context) {
+	form := web.GetForm[*forms.ProjectAutomationForm](ctx)
+	project := findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	event := project_model.ParseAutomationEvent(form.Event)
+	if event == 0 {
+		ctx.JSONError(ctx.Tr("projects.automation.invalid"))
+		return
+	}
+	err := project_service.CreateAutomation(ctx, project, &project_model.Automation{
+		Event:     event,
+		LabelID:   form.LabelID,
+		ColumnID:  form.ColumnID,
+		CreatorID: ctx.Doer.ID,
+	})
+	if errors.Is(err, util.ErrUnprocessableContent) {
+		ctx.JSONError(ctx.Tr("projects.automation.invalid"))
+		return
+	} else if err != nil {
+		ctx.ServerError("CreateAutomation", err)
+		return
+	}
+
+	ctx.JSONRedirect(project.Link(ctx))
+}
+
+func DeleteAutomation(ctx *context.Context) {
+	project := findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	automation, err := project_model.GetAutomationByIDAndProjectID(ctx, ctx.PathParamInt64("automationID"), project.ID)
+	if err != nil {
+		ctx.NotFoundOrServerError("GetAutomationByIDAndProjectID", project_model.IsErrProjectAutomationNotExist, err)
+		return
+	}
+
+	if err := project_model.DeleteAutomationByID(ctx, automation.ID); err != nil {
+		ctx.ServerError("DeleteAutomationByID", err)
+		return
+	}
+
+	ctx.JSONOK()
+}
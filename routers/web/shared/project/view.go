@@ -0,0 +1,193 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+	"gitea.dev/modules/util"
+	"gitea.dev/modules/web"
+	"gitea.dev/services/context"
+	"gitea.dev/services/forms"
+	project_service "gitea.dev/services/projects"
+)
+
+// viewFilterKeys are the query parameters of the board filters which are saved with a view,
+// besides the "field_<id>" filters of the custom fields
+var viewFilterKeys = []string{"labels", "assignee", "milestone", "archived_labels"}
+
+// requestViewFilter returns the board filters of the request as the query string saved with a view
+func requestViewFilter(ctx *context.Context) string {
+	query := url.Values{}
+	for key, values := range ctx.Req.URL.Query() {
+		if !slices.Contains(viewFilterKeys, key) && project_model.ParseViewFieldKey(key) == 0 {
+			continue
+		}
+		for _, value := range values {
+			if value != "" {
+				query.Add(key, value)
+			}
+		}
+	}
+	return query.Encode()
+}
+
+// PrepareView loads the saved views of the project and returns the one chosen by the "view" query parameter,
+// nil is the board of the project
+func PrepareView(ctx *context.Context, project *project_model.Project) *project_model.View {
+	views, err := project_model.GetViews(ctx, project.ID)
+	if err != nil {
+		ctx.ServerError("GetViews", err)
+		return nil
+	}
+
+	var view *project_model.View
+	layout := project_model.ViewLayoutBoard
+	if viewID := ctx.FormInt64("view"); viewID != 0 {
+		for _, v := range views {
+			if v.ID == viewID {
+				view = v
+			}
+		}
+		if view == nil {
+			ctx.NotFound(nil)
+			return nil
+		}
+		layout = view.Layout
+		ctx.Data["ProjectViewID"] = viewID
+	}
+	ctx.Data["ProjectViews"] = views
+	ctx.Data["ProjectView"] = view
+	ctx.Data["ProjectViewLayout"] = layout.String()
+	ctx.Data["ProjectViewFilter"] = requestViewFilter(ctx)
+	ctx.Data["ProjectViewLayouts"] = project_model.ViewLayouts()
+	ctx.Data["ProjectViewGroupKeys"] = project_model.ViewGroupKeys()
+	ctx.Data["ProjectViewSortKeys"] = project_model.ViewSortKeys()
+	return view
+}
+
+// PrepareViewLayout prepares the table or the roadmap of the view, it has to be called after PrepareFieldFilters.
+// The "sort" and "sort_desc" query parameters sort a table without changing the saved view.
+func PrepareViewLayout(ctx *context.Context, view *project_model.View, columns project_model.ColumnList, issuesMap map[int64]issues_model.IssueList, values map[int64]map[int64]string) {
+	if view == nil || view.Layout == project_model.ViewLayoutBoard {
+		return
+	}
+	fields, _ := ctx.Data["ProjectFields"].([]*project_model.Field)
+	data := &project_service.ViewData{Fields: fields, Columns: columns, IssuesMap: issuesMap, Values: values}
+
+	if view.Layout == project_model.ViewLayoutRoadmap {
+		ctx.Data["ProjectRoadmap"] = data.RoadmapOf(view)
+		return
+	}
+
+	sorted := *view
+	if sortBy := ctx.FormString("sort"); sortBy != "" {
+		sorted.SortBy = sortBy
+		sorted.SortDesc = ctx.FormBool("sort_desc")
+	}
+	ctx.Data["ProjectViewSortBy"] = sorted.SortBy
+	ctx.Data["ProjectViewSortDesc"] = sorted.SortDesc
+	ctx.Data["ProjectViewGroups"] = data.TableGroups(&sorted)
+
+	issueColumns := make(map[int64]*project_model.Column)
+	for _, column := range columns {
+		for _, issue := range issuesMap[column.ID] {
+			issueColumns[issue.ID] = column
+		}
+	}
+	ctx.Data["ProjectIssueColumns"] = issueColumns
+
+	displayValues := make(map[int64]map[int64]string, len(values))
+	for issueID, issueValues := range values {
+		displayValues[issueID] = make(map[int64]string, len(issueValues))
+		for _, field := range fields {
+			if value, ok := issueValues[field.ID]; ok {
+				displayValues[issueID][field.ID] = field.DisplayValue(value)
+			}
+		}
+	}
+	ctx.Data["ProjectFieldDisplayValues"] = displayValues
+}
+
+func findView(ctx *context.Context) (*project_model.Project, *project_model.View) {
+	project := findProject(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+	view, err := project_model.GetViewByIDAndProjectID(ctx, ctx.PathParamInt64("viewID"), project.ID)
+	if err != nil {
+		ctx.NotFoundOrServerError("GetViewByIDAndProjectID", project_model.IsErrProjectViewNotExist, err)
+		return nil, nil
+	}
+	return project, view
+}
+
+func applyViewForm(view *project_model.View, form *forms.ProjectViewForm) {
+	view.Name = form.Name
+	view.Layout = project_model.ParseViewLayout(form.Layout)
+	view.Filter = form.Filter
+	view.GroupBy = form.GroupBy
+	view.SortBy = form.SortBy
+	view.SortDesc = form.SortDesc
+	view.DateFieldID = form.DateFieldID
+}
+
+func AddViewPost(ctx *context.Context) {
+	form := web.GetForm[*forms.ProjectViewForm](ctx)
+	project := findProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	view := &project_model.View{ProjectID: project.ID, CreatorID: ctx.Doer.ID}
+	applyViewForm(view, form)
+	err := project_model.NewView(ctx, view)
+	if errors.Is(err, util.ErrUnprocessableContent) {
+		ctx.JSONError(ctx.Tr("projects.view.invalid"))
+		return
+	} else if err != nil {
+		ctx.ServerError("NewView", err)
+		return
+	}
+
+	ctx.JSONRedirect(project.Link(ctx) + "?" + view.Query())
+}
+
+func EditViewPost(ctx *context.Context) {
+	form := web.GetForm[*forms.ProjectViewForm](ctx)
+	project, view := findView(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	applyViewForm(view, form)
+	err := project_model.UpdateView(ctx, view)
+	if errors.Is(err, util.ErrUnprocessableContent) {
+		ctx.JSONError(ctx.Tr("projects.view.invalid"))
+		return
+	} else if err != nil {
+		ctx.ServerError("UpdateView", err)
+		return
+	}
+
+	ctx.JSONRedirect(project.Link(ctx) + "?" + view.Query())
+}
+
+func DeleteView(ctx *context.Context) {
+	project, view := findView(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := project_model.DeleteViewByID(ctx, view.ID); err != nil {
+		ctx.ServerError("DeleteViewByID", err)
+		return
+	}
+
+	ctx.JSONRedirect(project.Link(ctx))
+}
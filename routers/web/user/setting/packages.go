@@ -17,9 +17,10 @@ import (
 )
 
 const (
-	tplSettingsPackages            templates.TplName = "user/settings/packages"
-	tplSettingsPackagesRuleEdit    templates.TplName = "user/settings/packages_cleanup_rules_edit"
-	tplSettingsPackagesRulePreview templates.TplName = "user/settings/packages_cleanup_rules_preview"
+	tplSettingsPackages             templates.TplName = "user/settings/packages"
+	tplSettingsPackagesRuleEdit     templates.TplName = "user/settings/packages_cleanup_rules_edit"
+	tplSettingsPackagesRulePreview  templates.TplName = "user/settings/packages_cleanup_rules_preview"
+	tplSettingsPackagesUpstreamEdit templates.TplName = "user/settings/packages_upstreams_edit"
 )
 
 func Packages(ctx *context.Context) {
@@ -82,6 +83,48 @@ func PackagesRulePreview(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplSettingsPackagesRulePreview)
 }
 
+func PackagesUpstreamAdd(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+
+	shared.SetUpstreamAddContext(ctx)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesUpstreamEdit)
+}
+
+func PackagesUpstreamEdit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+
+	shared.SetUpstreamEditContext(ctx, ctx.Doer)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesUpstreamEdit)
+}
+
+func PackagesUpstreamAddPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+
+	shared.PerformUpstreamAddPost(
+		ctx,
+		ctx.Doer,
+		setting.AppSubURL+"/user/settings/packages",
+		tplSettingsPackagesUpstreamEdit,
+	)
+}
+
+func PackagesUpstreamEditPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+
+	shared.PerformUpstreamEditPost(
+		ctx,
+		ctx.Doer,
+		setting.AppSubURL+"/user/settings/packages",
+		tplSettingsPackagesUpstreamEdit,
+	)
+}
+
 func InitializeCargoIndex(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("packages.title")
 	ctx.Data["PageIsSettingsPackages"] = true
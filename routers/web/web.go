@@ -330,6 +330,11 @@ func addProjectBoardRoutes(m *web.Router) {
 	// TODO: improper name. Others are "delete project", "edit project", but this one is "move columns"
 	m.Post("/move", project.MoveColumns)
 	m.Post("/columns/new", web.Bind(forms.EditProjectColumnForm{}), project.AddColumnToProjectPost)
+	m.Post("/automations/new", web.Bind(forms.ProjectAutomationForm{}), project.AddAutomationPost)
+	m.Post("/automations/{automationID}/delete", project.DeleteAutomation)
+	m.Post("/views/new", web.Bind(forms.ProjectViewForm{}), project.AddViewPost)
+	m.Post("/views/{viewID}/edit", web.Bind(forms.ProjectViewForm{}), project.EditViewPost)
+	m.Post("/views/{viewID}/delete", project.DeleteView)
 	m.Group("/{columnID}", func() {
 		m.Put("", web.Bind(forms.EditProjectColumnForm{}), project.EditProjectColumn)
 		m.Delete("", project.DeleteProjectColumn)
@@ -385,6 +390,13 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 		}
 	}
 
+	secretScanningEnabled := func(ctx *context.Context) {
+		if !setting.SecretScanning.Enabled {
+			ctx.HTTPError(http.StatusForbidden)
+			return
+		}
+	}
+
 	starsEnabled := func(ctx *context.Context) {
 		if setting.Repository.DisableStars {
 			ctx.HTTPError(http.StatusForbidden)
@@ -469,6 +481,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 		m.Post("/feishu/new", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksNewPost)
 		m.Post("/wechatwork/new", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksNewPost)
 		m.Post("/packagist/new", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksNewPost)
+		m.Post("/custom/new", web.Bind(forms.NewCustomHookForm{}), repo_setting.CustomHooksNewPost)
 	}
 
 	addWebhookEditRoutes := func() {
@@ -483,6 +496,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 		m.Post("/feishu/{id}", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksEditPost)
 		m.Post("/wechatwork/{id}", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksEditPost)
 		m.Post("/packagist/{id}", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksEditPost)
+		m.Post("/custom/{id}", web.Bind(forms.NewCustomHookForm{}), repo_setting.CustomHooksEditPost)
 	}
 
 	addSettingsVariablesRoutes := func() {
@@ -708,6 +722,16 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 					m.Get("/preview", user_setting.PackagesRulePreview)
 				})
 			})
+			m.Group("/upstreams", func() {
+				m.Group("/add", func() {
+					m.Get("", user_setting.PackagesUpstreamAdd)
+					m.Post("", web.Bind(forms.PackageUpstreamForm{}), user_setting.PackagesUpstreamAddPost)
+				})
+				m.Group("/{id}", func() {
+					m.Get("", user_setting.PackagesUpstreamEdit)
+					m.Post("", web.Bind(forms.PackageUpstreamForm{}), user_setting.PackagesUpstreamEditPost)
+				})
+			})
 			m.Group("/cargo", func() {
 				m.Post("/initialize", user_setting.InitializeCargoIndex)
 				m.Post("/rebuild", user_setting.RebuildCargoIndex)
@@ -1067,6 +1091,16 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 							m.Get("/preview", org.PackagesRulePreview)
 						})
 					})
+					m.Group("/upstreams", func() {
+						m.Group("/add", func() {
+							m.Get("", org.PackagesUpstreamAdd)
+							m.Post("", web.Bind(forms.PackageUpstreamForm{}), org.PackagesUpstreamAddPost)
+						})
+						m.Group("/{id}", func() {
+							m.Get("", org.PackagesUpstreamEdit)
+							m.Post("", web.Bind(forms.PackageUpstreamForm{}), org.PackagesUpstreamEditPost)
+						})
+					})
 					m.Group("/cargo", func() {
 						m.Post("/initialize", org.InitializeCargoIndex)
 						m.Post("/rebuild", org.RebuildCargoIndex)
@@ -1229,6 +1263,12 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 			m.Post("/delete", repo_setting.DeleteDeployKey)
 		})
 
+		m.Group("/secret_scanning", func() {
+			m.Get("", repo_setting.SecretScanning)
+			m.Post("/scan", repo.MustBeNotEmpty, repo_setting.SecretScanningScanPost)
+			m.Post("/{id}/state", repo_setting.SecretScanningAlertStatePost)
+		}, secretScanningEnabled)
+
 		m.Group("/lfs", func() {
 			m.Get("/", repo_setting.LFSFiles)
 			m.Get("/show/{oid}", repo_setting.LFSFileGet)
@@ -1266,7 +1306,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 		})
 	},
 		reqSignIn, context.RepoAssignment, reqRepoAdmin,
-		ctxDataSet(reqctx.ContextData{"PageIsRepoSettings": true, "LFSStartServer": setting.LFS.StartServer}),
+		ctxDataSet(reqctx.ContextData{"PageIsRepoSettings": true, "LFSStartServer": setting.LFS.StartServer, "SecretScanningEnabled": setting.SecretScanning.Enabled}),
 	)
 	// end "/{username}/{reponame}/settings"
 
@@ -1636,6 +1676,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 			})
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
+			m.Post("/cancel_merge_queue", context.RepoMustNotBeArchived(), repo.CancelMergeQueuePullRequest)
 			m.Post("/update", repo.UpdatePullRequest)
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), repo.CleanUpPullRequest)
@@ -1708,6 +1749,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 
 		m.Group("", func() {
 			m.Get("/graph", repo.Graph)
+			m.Get("/code-nav", repo.CodeNavigation)
 			m.Get("/commit/{sha:([a-f0-9]{7,64})$}", repo.SetEditorconfigIfExists, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.Diff)
 			m.Get("/commit/{sha:([a-f0-9]{7,64})$}/load-branches-and-tags", repo.LoadBranchesAndTags)
 
@@ -0,0 +1,288 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"time"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/db"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/storage"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/modules/util"
+)
+
+// abandonedCacheReservationTimeout is how long an entry may stay reserved before its upload is considered abandoned
+const abandonedCacheReservationTimeout = 24 * time.Hour
+
+// CacheScopes returns the scope the job of the task saves its cache entries to, and the scopes it can restore entries from in priority order.
+// Like GitHub, a job can restore the entries of its own ref, then the ones of the base branch for a pull request, then the ones of the default branch.
+func CacheScopes(ctx context.Context, task *actions_model.ActionTask) (saveScope string, restoreScopes []string, err error) {
+	if err := task.LoadJob(ctx); err != nil {
+		return "", nil, err
+	}
+	if err := task.Job.LoadRun(ctx); err != nil {
+		return "", nil, err
+	}
+	run := task.Job.Run
+	if err := run.LoadRepo(ctx); err != nil {
+		return "", nil, err
+	}
+
+	saveScope = run.Ref
+	restoreScopes = []string{saveScope}
+	addScope := func(scope string) {
+		if !slices.Contains(restoreScopes, scope) {
+			restoreScopes = append(restoreScopes, scope)
+		}
+	}
+	if payload, err := run.GetPullRequestEventPayload(); err == nil && payload.PullRequest != nil && payload.PullRequest.Base != nil {
+		addScope(git.BranchPrefix + payload.PullRequest.Base.Ref)
+	}
+	addScope(git.BranchPrefix + run.Repo.DefaultBranch)
+	return saveScope, restoreScopes, nil
+}
+
+func cacheStoragePath(c *actions_model.ActionCache) string {
+	return fmt.Sprintf("%d/%d", c.RepoID, c.ID)
+}
+
+func cacheChunksDir(c *actions_model.ActionCache) string {
+	return fmt.Sprintf("tmp/%d", c.ID)
+}
+
+// SaveCacheChunk stores a chunk of the content of a reserved entry, chunks can be uploaded in any order
+func SaveCacheChunk(c *actions_model.ActionCache, start, end int64, r io.Reader) error {
+	if c.Status != actions_model.CacheStatusReserved {
+		return util.NewInvalidArgumentErrorf("cache entry %d is not being uploaded", c.ID)
+	}
+	if start < 0 || end < start || end >= c.Size {
+		return util.NewInvalidArgumentErrorf("chunk %d-%d is out of the size of cache entry %d", start, end, c.ID)
+	}
+	size := end - start + 1
+	p := fmt.Sprintf("%s/%d-%d", cacheChunksDir(c), start, end)
+	lr := &io.LimitedReader{R: r, N: size}
+	written, err := storage.ActionsCache.Save(p, lr, size)
+	if err == nil && written == size {
+		// the body must not be longer than the range either
+		if n, _ := io.ReadFull(r, make([]byte, 1)); n == 0 {
+			return nil
+		}
+		err = util.NewInvalidArgumentErrorf("chunk %d-%d of cache entry %d is longer than its range", start, end, c.ID)
+	} else if lr.N > 0 {
+		// the storage may fail on the unexpected end of the body
+		err = util.NewInvalidArgumentErrorf("chunk %d-%d of cache entry %d is shorter than its range", start, end, c.ID)
+	} else if err == nil {
+		err = fmt.Errorf("chunk %d-%d of cache entry %d: %d bytes stored", start, end, c.ID, written)
+	}
+	if delErr := storage.ActionsCache.Delete(p); delErr != nil {
+		log.Error("Unable to delete the rejected chunk %q of cache entry %d: %v", p, c.ID, delErr)
+	}
+	return err
+}
+
+type cacheChunk struct {
+	path       string
+	start, end int64
+	size       int64 // the size of the stored chunk, which can only differ from its range if the storage has been tampered with
+}
+
+func listCacheChunks(c *actions_model.ActionCache) ([]*cacheChunk, error) {
+	var chunks []*cacheChunk
+	err := storage.ActionsCache.IterateObjects(cacheChunksDir(c), func(fullPath string, obj storage.Object) error {
+		fi, err := obj.Stat()
+		_ = obj.Close()
+		if err != nil {
+			return err
+		}
+		chunk := &cacheChunk{path: fullPath, size: fi.Size()}
+		if _, err := fmt.Sscanf(path.Base(fullPath), "%d-%d", &chunk.start, &chunk.end); err != nil {
+			return fmt.Errorf("invalid chunk %q: %w", fullPath, err)
+		}
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(chunks, func(a, b *cacheChunk) int {
+		return cmp.Compare(a.start, b.start)
+	})
+	return chunks, nil
+}
+
+// CommitCache merges the uploaded chunks of a reserved entry into its content and makes it available for restoring.
+// size is the total size reported by the client, it has to match the reserved size and the uploaded content.
+func CommitCache(ctx context.Context, c *actions_model.ActionCache, size int64) error {
+	if c.Status != actions_model.CacheStatusReserved {
+		return util.NewInvalidArgumentErrorf("cache entry %d is not being uploaded", c.ID)
+	}
+	if size != c.Size {
+		return util.NewInvalidArgumentErrorf("cache entry %d has been reserved for %d bytes but its size is %d", c.ID, c.Size, size)
+	}
+
+	chunks, err := listCacheChunks(c)
+	if err != nil {
+		return err
+	}
+	var next int64
+	for _, chunk := range chunks {
+		if chunk.start != next {
+			return util.NewInvalidArgumentErrorf("cache entry %d has a missing or overlapping chunk at %d", c.ID, next)
+		}
+		if chunk.size != chunk.end-chunk.start+1 {
+			return util.NewInvalidArgumentErrorf("chunk %d-%d of cache entry %d has %d bytes", chunk.start, chunk.end, c.ID, chunk.size)
+		}
+		next = chunk.end + 1
+	}
+	if next != size {
+		return util.NewInvalidArgumentErrorf("cache entry %d has %d bytes uploaded but its size is %d", c.ID, next, size)
+	}
+
+	c.StoragePath = cacheStoragePath(c)
+	if err := storage.SaveFrom(storage.ActionsCache, c.StoragePath, func(w io.Writer) error {
+		for _, chunk := range chunks {
+			if err := copyCacheChunk(w, chunk); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	deleteCacheChunks(c)
+
+	c.Size = size
+	c.Status = actions_model.CacheStatusComplete
+	c.LastUsedUnix = timeutil.TimeStampNow()
+	if err := actions_model.UpdateCache(ctx, c, "size", "storage_path", "status", "last_used_unix"); err != nil {
+		return err
+	}
+
+	// keep the repository within its quota right away instead of waiting for the cron task
+	return evictCachesOverSize(ctx, c.RepoID)
+}
+
+func copyCacheChunk(w io.Writer, chunk *cacheChunk) error {
+	f, err := storage.ActionsCache.Open(chunk.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func deleteCacheChunks(c *actions_model.ActionCache) {
+	chunks, err := listCacheChunks(c)
+	if err != nil {
+		log.Error("Unable to list the chunks of cache entry %d: %v", c.ID, err)
+		return
+	}
+	for _, chunk := range chunks {
+		if err := storage.ActionsCache.Delete(chunk.path); err != nil {
+			log.Error("Unable to delete chunk %q of cache entry %d: %v", chunk.path, c.ID, err)
+		}
+	}
+}
+
+// DeleteCache deletes an entry and its content
+func DeleteCache(ctx context.Context, c *actions_model.ActionCache) error {
+	if err := actions_model.DeleteCacheByID(ctx, c.ID); err != nil {
+		return err
+	}
+	DeleteCacheContent(c)
+	return nil
+}
+
+// DeleteCacheContent deletes the content of an entry from the storage, or the uploaded chunks of an entry which is not complete
+func DeleteCacheContent(c *actions_model.ActionCache) {
+	if c.Status != actions_model.CacheStatusComplete {
+		deleteCacheChunks(c)
+	} else if err := storage.ActionsCache.Delete(c.StoragePath); err != nil {
+		log.Error("Unable to delete the content %q of cache entry %d: %v", c.StoragePath, c.ID, err)
+	}
+}
+
+// MarkCacheUsed records that an entry has been restored, so that it is not evicted as unused
+func MarkCacheUsed(ctx context.Context, c *actions_model.ActionCache) error {
+	c.LastUsedUnix = timeutil.TimeStampNow()
+	return actions_model.UpdateCache(ctx, c, "last_used_unix")
+}
+
+// CleanupCaches evicts the entries of the cache server which are abandoned, unused or over the size quota of their repository
+func CleanupCaches(ctx context.Context) error {
+	abandoned, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		Status:        actions_model.CacheStatusReserved,
+		CreatedBefore: timeutil.TimeStamp(time.Now().Add(-abandonedCacheReservationTimeout).Unix()),
+	})
+	if err != nil {
+		return fmt.Errorf("find abandoned cache entries: %w", err)
+	}
+	unused, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		Status:         actions_model.CacheStatusComplete,
+		LastUsedBefore: timeutil.TimeStamp(time.Now().AddDate(0, 0, -int(setting.Actions.CacheRetentionDays)).Unix()),
+	})
+	if err != nil {
+		return fmt.Errorf("find unused cache entries: %w", err)
+	}
+	log.Info("Found %d abandoned and %d unused cache entries", len(abandoned), len(unused))
+	for _, c := range append(abandoned, unused...) {
+		if err := DeleteCache(ctx, c); err != nil {
+			log.Error("Unable to delete cache entry %d: %v", c.ID, err)
+		}
+	}
+
+	repos, err := actions_model.FindCacheReposOverSize(ctx, setting.Actions.CacheMaxSizePerRepo)
+	if err != nil {
+		return fmt.Errorf("find repositories over the cache size quota: %w", err)
+	}
+	for _, repo := range repos {
+		if err := evictCachesOverSize(ctx, repo.RepoID); err != nil {
+			return fmt.Errorf("evict cache entries of repository %d: %w", repo.RepoID, err)
+		}
+	}
+	return nil
+}
+
+// evictCachesOverSize deletes the least recently used complete entries of a repository until it fits in its size quota
+func evictCachesOverSize(ctx context.Context, repoID int64) error {
+	total, err := actions_model.GetCacheRepoSize(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if total <= setting.Actions.CacheMaxSizePerRepo {
+		return nil
+	}
+
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		RepoID: repoID,
+		Status: actions_model.CacheStatusComplete,
+	})
+	if err != nil {
+		return err
+	}
+	for _, c := range caches {
+		if total <= setting.Actions.CacheMaxSizePerRepo {
+			break
+		}
+		if err := DeleteCache(ctx, c); err != nil {
+			return err
+		}
+		total -= c.Size
+	}
+	if total > setting.Actions.CacheMaxSizePerRepo {
+		log.Warn("The cache entries being uploaded for repository %d exceed its size quota", repoID)
+	}
+	return nil
+}
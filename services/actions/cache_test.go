@@ -0,0 +1,52 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/storage"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheUpload(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	c := &actions_model.ActionCache{RepoID: 4, Scope: "refs/heads/master", CacheKey: "key", Version: "v1", Size: 10}
+	require.NoError(t, actions_model.ReserveCache(t.Context(), c))
+	defer DeleteCacheContent(c)
+
+	// the body has to match the range
+	err := SaveCacheChunk(c, 0, 4, strings.NewReader("0123"))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = SaveCacheChunk(c, 0, 4, strings.NewReader("012345"))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	chunks, err := listCacheChunks(c)
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+
+	require.NoError(t, SaveCacheChunk(c, 5, 9, strings.NewReader("56789")))
+	// a chunk is missing
+	assert.ErrorIs(t, CommitCache(t.Context(), c, 10), util.ErrInvalidArgument)
+
+	require.NoError(t, SaveCacheChunk(c, 0, 4, strings.NewReader("01234")))
+	// the size doesn't match the reserved size
+	assert.ErrorIs(t, CommitCache(t.Context(), c, 9), util.ErrInvalidArgument)
+
+	require.NoError(t, CommitCache(t.Context(), c, 10))
+	assert.Equal(t, actions_model.CacheStatusComplete, c.Status)
+	f, err := storage.ActionsCache.Open(c.StoragePath)
+	require.NoError(t, err)
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(content))
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	actions_model "gitea.dev/models/actions"
+	actions_module "gitea.dev/modules/actions"
+	"gitea.dev/modules/setting"
+	"gitea.dev/services/oauth2_provider"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IDTokenIssuerPath is the path, relative to AppURL, of the issuer of the ID tokens of Actions jobs.
+// The discovery document and the JWKS are served below it.
+const IDTokenIssuerPath = "api/actions/oidc"
+
+const idTokenExpiration = 5 * time.Minute
+
+// ErrIDTokenSigningKeyUnavailable is returned if ID tokens can't be signed with a key that relying parties can verify
+var ErrIDTokenSigningKeyUnavailable = errors.New("the OAuth2 provider has no asymmetric signing key to sign ID tokens")
+
+// IDTokenClaims are the claims of an ID token identifying an Actions job, they follow the claims of GitHub's ID tokens
+// so that the trust policies written for them can be reused.
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Ref               string `json:"ref"`
+	RefType           string `json:"ref_type"`
+	RefProtected      string `json:"ref_protected"`
+	SHA               string `json:"sha"`
+	Repository        string `json:"repository"`
+	RepositoryID      string `json:"repository_id"`
+	RepositoryOwner   string `json:"repository_owner"`
+	RepositoryOwnerID string `json:"repository_owner_id"`
+	Actor             string `json:"actor"`
+	ActorID           string `json:"actor_id"`
+	Workflow          string `json:"workflow"`
+	Job               string `json:"job"`
+	EventName         string `json:"event_name"`
+	RunID             string `json:"run_id"`
+	RunNumber         string `json:"run_number"`
+	RunAttempt        string `json:"run_attempt"`
+}
+
+// IDTokenIssuer returns the issuer of the ID tokens of Actions jobs
+func IDTokenIssuer() string {
+	return setting.AppURL + IDTokenIssuerPath
+}
+
+// IDTokenSigningKey returns the key used to sign ID tokens. Only asymmetric keys can be used,
+// a symmetric key could not be published for relying parties without allowing them to forge tokens.
+func IDTokenSigningKey() (oauth2_provider.JWTSigningKey, error) {
+	key := oauth2_provider.DefaultSigningKey
+	if !setting.OAuth2.Enabled || key == nil || key.IsSymmetric() {
+		return nil, ErrIDTokenSigningKeyUnavailable
+	}
+	return key, nil
+}
+
+// CreateIDToken creates a signed ID token for the job of a running task. If audience is empty,
+// the URL of the owner of the repository is used, as GitHub does.
+// The caller must have checked that the job is allowed to request an ID token, see actions_model.CanTaskRequestIDToken.
+func CreateIDToken(ctx context.Context, task *actions_model.ActionTask, audience string) (string, error) {
+	key, err := IDTokenSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	if err := task.LoadAttributes(ctx); err != nil {
+		return "", err
+	}
+	job := task.Job
+	run := job.Run
+	if err := run.Repo.LoadOwner(ctx); err != nil {
+		return "", err
+	}
+
+	// the values are taken from the gitea context so that they match what the job has been run with
+	gitCtx := GenerateGiteaContext(ctx, run, nil, job)
+	ref, _ := gitCtx["ref"].(string)
+	refProtected, _ := gitCtx["ref_protected"].(bool)
+
+	if audience == "" {
+		audience = run.Repo.Owner.HTMLURL(ctx)
+	}
+
+	subject := "repo:" + run.Repo.FullName() + ":ref:" + ref
+	if run.TriggerEvent == actions_module.GithubEventPullRequest {
+		subject = "repo:" + run.Repo.FullName() + ":pull_request"
+	}
+
+	now := time.Now()
+	claims := IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    IDTokenIssuer(),
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{audience},
+			ID:        fmt.Sprintf("%d-%d", task.ID, now.UnixNano()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(idTokenExpiration)),
+		},
+		Ref:               ref,
+		RefType:           fmt.Sprint(gitCtx["ref_type"]),
+		RefProtected:      strconv.FormatBool(refProtected),
+		SHA:               fmt.Sprint(gitCtx["sha"]),
+		Repository:        run.Repo.FullName(),
+		RepositoryID:      strconv.FormatInt(run.Repo.ID, 10),
+		RepositoryOwner:   run.Repo.OwnerName,
+		RepositoryOwnerID: strconv.FormatInt(run.Repo.OwnerID, 10),
+		Actor:             run.TriggerUser.Name,
+		ActorID:           strconv.FormatInt(run.TriggerUser.ID, 10),
+		Workflow:          run.WorkflowID,
+		Job:               job.JobID,
+		EventName:         run.TriggerEvent,
+		RunID:             strconv.FormatInt(run.ID, 10),
+		RunNumber:         strconv.FormatInt(run.Index, 10),
+		RunAttempt:        strconv.FormatInt(job.Attempt, 10),
+	}
+
+	token := jwt.NewWithClaims(key.SigningMethod(), claims)
+	key.PreProcessToken(token)
+	return token.SignedString(key.SignKey())
+}
+
+// IDTokenRequestURL returns the URL a job requests its ID tokens from, authenticated with its runtime token.
+// It already has a query string because the actions toolkit appends "&audience=..." to it.
+func IDTokenRequestURL() string {
+	return IDTokenIssuer() + "/token?api-version=2.0"
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	actions_model "gitea.dev/models/actions"
+	"gitea.dev/models/perm"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+	"gitea.dev/services/oauth2_provider"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateIDToken(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.OAuth2.Enabled, true)()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := oauth2_provider.CreateJWTSigningKey("EdDSA", privateKey)
+	require.NoError(t, err)
+	defer test.MockVariableValue(&oauth2_provider.DefaultSigningKey, key)()
+
+	parseIDToken := func(signed string, verifyKey any, audience string) (*IDTokenClaims, error) {
+		claims := &IDTokenClaims{}
+		_, err := jwt.ParseWithClaims(signed, claims, func(*jwt.Token) (any, error) {
+			return verifyKey, nil
+		}, jwt.WithValidMethods([]string{key.SigningMethod().Alg()}), jwt.WithIssuer(IDTokenIssuer()), jwt.WithAudience(audience), jwt.WithExpirationRequired())
+		return claims, err
+	}
+
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+
+	t.Run("Claims", func(t *testing.T) {
+		signed, err := CreateIDToken(t.Context(), task, "https://sts.example.com")
+		require.NoError(t, err)
+		claims, err := parseIDToken(signed, key.VerifyKey(), "https://sts.example.com")
+		require.NoError(t, err)
+
+		assert.Equal(t, "repo:user5/repo4:ref:refs/heads/master", claims.Subject)
+		assert.Equal(t, "refs/heads/master", claims.Ref)
+		assert.Equal(t, "branch", claims.RefType)
+		assert.Equal(t, "c2d72f548424103f01ee1dc02889c1e2bff816b0", claims.SHA)
+		assert.Equal(t, "user5/repo4", claims.Repository)
+		assert.Equal(t, "4", claims.RepositoryID)
+		assert.Equal(t, "user5", claims.RepositoryOwner)
+		assert.Equal(t, "user1", claims.Actor)
+		assert.Equal(t, "artifact.yaml", claims.Workflow)
+		assert.Equal(t, "job_2", claims.Job)
+		assert.Equal(t, "push", claims.EventName)
+		assert.Equal(t, "791", claims.RunID)
+	})
+
+	t.Run("DefaultAudience", func(t *testing.T) {
+		signed, err := CreateIDToken(t.Context(), task, "")
+		require.NoError(t, err)
+		_, err = parseIDToken(signed, key.VerifyKey(), setting.AppURL+"user5")
+		require.NoError(t, err)
+	})
+
+	t.Run("OtherKey", func(t *testing.T) {
+		signed, err := CreateIDToken(t.Context(), task, "https://sts.example.com")
+		require.NoError(t, err)
+		otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		_, err = parseIDToken(signed, otherPublicKey, "https://sts.example.com")
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("SymmetricKey", func(t *testing.T) {
+		hmacKey, err := oauth2_provider.CreateJWTSigningKey("HS256", []byte("secret"))
+		require.NoError(t, err)
+		defer test.MockVariableValue(&oauth2_provider.DefaultSigningKey, hmacKey)()
+		_, err = CreateIDToken(t.Context(), task, "")
+		assert.ErrorIs(t, err, ErrIDTokenSigningKeyUnavailable)
+	})
+}
+
+func TestCanTaskRequestIDToken(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+	require.NoError(t, task.LoadJob(t.Context()))
+
+	// the job doesn't declare "id-token: write"
+	allowed, err := actions_model.CanTaskRequestIDToken(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	declared := repo_model.MakeActionsTokenPermissions(perm.AccessModeRead)
+	declared.IDToken = true
+	task.Job.TokenPermissions = &declared
+	allowed, err = actions_model.CanTaskRequestIDToken(t.Context(), task)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// the maximum permissions of the owner don't allow ID tokens
+	maxPerms := repo_model.MakeActionsTokenPermissions(perm.AccessModeWrite)
+	require.NoError(t, actions_model.SetOwnerActionsConfig(t.Context(), task.Job.Repo.OwnerID, actions_model.OwnerActionsConfig{MaxTokenPermissions: &maxPerms}))
+	allowed, err = actions_model.CanTaskRequestIDToken(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	task.IsForkPullRequest = true
+	require.NoError(t, actions_model.SetOwnerActionsConfig(t.Context(), task.Job.Repo.OwnerID, actions_model.OwnerActionsConfig{}))
+	allowed, err = actions_model.CanTaskRequestIDToken(t.Context(), task)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
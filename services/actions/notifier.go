@@ -13,6 +13,7 @@ import (
 	packages_model "gitea.dev/models/packages"
 	perm_model "gitea.dev/models/perm"
 	access_model "gitea.dev/models/perm/access"
+	pull_model "gitea.dev/models/pull"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/git"
@@ -686,6 +687,31 @@ func (n *actionsNotifier) AutoMergePullRequest(ctx context.Context, doer *user_m
 	n.MergePullRequest(ctx, doer, pr)
 }
 
+func (n *actionsNotifier) MergeGroupChecksRequested(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, entry *pull_model.MergeQueueEntry) {
+	ctx = withMethod(ctx, "MergeGroupChecksRequested")
+
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		log.Error("pr.LoadBaseRepo: %v", err)
+		return
+	}
+
+	newNotifyInput(pr.BaseRepo, doer, webhook_module.HookEventMergeGroup).
+		WithRef(entry.GetRefName()).
+		WithPayload(&api.MergeGroupPayload{
+			Action: api.HookMergeGroupChecksRequested,
+			MergeGroup: &api.MergeGroup{
+				HeadSHA:     entry.MergeCommitID,
+				HeadRef:     entry.GetRefName(),
+				BaseSHA:     entry.BaseCommitID,
+				BaseRef:     git.RefNameFromBranch(entry.BaseBranch).String(),
+				PullRequest: convert.ToAPIPullRequest(ctx, pr, nil),
+			},
+			Repo:   convert.ToRepo(ctx, pr.BaseRepo, access_model.Permission{AccessMode: perm_model.AccessModeNone}),
+			Sender: convert.ToUser(ctx, doer, nil),
+		}).
+		Notify(ctx)
+}
+
 func (n *actionsNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, before, after string) {
 	ctx = withMethod(ctx, "PullRequestSynchronized")
 
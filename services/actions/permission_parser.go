@@ -63,7 +63,9 @@ func parseRawPermissionsExplicit(rawPerms *yaml.Node) *repo_model.ActionsTokenPe
 		case "read-all":
 			return new(repo_model.MakeActionsTokenPermissions(perm.AccessModeRead))
 		case "write-all":
-			return new(repo_model.MakeActionsTokenPermissions(perm.AccessModeWrite))
+			result := repo_model.MakeActionsTokenPermissions(perm.AccessModeWrite)
+			result.IDToken = true
+			return &result
 		default:
 			// Explicit but unrecognized scalar: return all-none permissions.
 			return new(repo_model.MakeActionsTokenPermissions(perm.AccessModeNone))
@@ -117,10 +119,13 @@ func parseRawPermissionsExplicit(rawPerms *yaml.Node) *repo_model.ActionsTokenPe
 				result.UnitAccessModes[unit.TypeReleases] = mode
 			case "projects":
 				result.UnitAccessModes[unit.TypeProjects] = mode
+			case "id-token":
+				// "read" is not a valid level for id-token, only "write" allows requesting an ID token
+				result.IDToken = mode == perm.AccessModeWrite
 			// Scopes github supports but gitea does not, see url for details
 			// https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-syntax
 			case "artifact-metadata", "attestations", "checks", "deployments",
-				"id-token", "models", "discussions", "pages", "security-events", "statuses":
+				"models", "discussions", "pages", "security-events", "statuses":
 				// not supported
 			default:
 				setting.PanicInDevOrTesting("Unrecognized permission scope: %s", scope)
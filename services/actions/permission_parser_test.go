@@ -78,6 +78,28 @@ func TestParseRawPermissions_WriteAll(t *testing.T) {
 	assert.Equal(t, perm.AccessModeWrite, result.UnitAccessModes[unit.TypeActions])
 	assert.Equal(t, perm.AccessModeWrite, result.UnitAccessModes[unit.TypeWiki])
 	assert.Equal(t, perm.AccessModeWrite, result.UnitAccessModes[unit.TypeProjects])
+	assert.True(t, result.IDToken)
+}
+
+func TestParseRawPermissions_IDToken(t *testing.T) {
+	cases := []struct {
+		yaml    string
+		idToken bool
+	}{
+		{yaml: "id-token: write", idToken: true},
+		{yaml: "id-token: read", idToken: false},
+		{yaml: "id-token: none", idToken: false},
+		{yaml: "contents: write", idToken: false},
+		{yaml: "read-all", idToken: false},
+	}
+	for _, c := range cases {
+		var rawPerms yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(c.yaml), &rawPerms))
+
+		result := parseRawPermissionsExplicit(&rawPerms)
+		require.NotNil(t, result)
+		assert.Equal(t, c.idToken, result.IDToken, c.yaml)
+	}
 }
 
 func TestParseRawPermissions_IndividualScopes(t *testing.T) {
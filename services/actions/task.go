@@ -176,6 +176,17 @@ func generateTaskContext(ctx context.Context, t *actions_model.ActionTask) (*str
 	gitCtx["token"] = t.Token
 	gitCtx["gitea_runtime_token"] = giteaRuntimeToken
 
+	// Like GitHub, only the jobs granted "id-token: write" get the values to request an ID token,
+	// the request itself is authenticated with the runtime token.
+	canRequestIDToken, err := actions_model.CanTaskRequestIDToken(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	if canRequestIDToken {
+		gitCtx["actions_id_token_request_url"] = IDTokenRequestURL()
+		gitCtx["actions_id_token_request_token"] = giteaRuntimeToken
+	}
+
 	return structpb.NewStruct(gitCtx)
 }
 
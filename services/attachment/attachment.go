@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"gitea.dev/models/db"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/storage"
@@ -84,6 +85,17 @@ func uploadAttachment(ctx context.Context, file *UploaderFile, allowedTypes stri
 		return nil, util.ErrorWrap(util.ErrContentTooLarge, "attachment exceeds limit %d", maxFileSize)
 	}
 
+	if attach.RepoID != 0 {
+		repo, err := repo_model.GetRepositoryByID(ctx, attach.RepoID)
+		if err != nil {
+			return nil, err
+		}
+		// the size of streamed uploads is unknown, they are only rejected if the quota is already used up
+		if err := quota_model.Check(ctx, repo.OwnerID, quota_model.SubjectAttachments, max(file.size, 0)); err != nil {
+			return nil, err
+		}
+	}
+
 	attach, err := NewAttachment(ctx, attach, io.MultiReader(bytes.NewReader(buf), src), file.size)
 	var maxBytesError *http.MaxBytesError
 	if errors.As(err, &maxBytesError) {
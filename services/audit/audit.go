@@ -0,0 +1,223 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	audit_model "gitea.dev/models/audit"
+	auth_model "gitea.dev/models/auth"
+	git_model "gitea.dev/models/git"
+	"gitea.dev/models/organization"
+	"gitea.dev/models/perm"
+	repo_model "gitea.dev/models/repo"
+	secret_model "gitea.dev/models/secret"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/httplib"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+)
+
+// Target describes the object an audit event is recorded for
+type Target struct {
+	OwnerID int64 // if zero, the owner of the repository is used
+	RepoID  int64
+	Type    audit_model.TargetType
+	ID      int64
+	Name    string
+}
+
+func RepositoryTarget(repo *repo_model.Repository) Target {
+	return Target{OwnerID: repo.OwnerID, RepoID: repo.ID, Type: audit_model.TargetTypeRepository, ID: repo.ID, Name: repo.FullName()}
+}
+
+func ProtectedBranchTarget(repo *repo_model.Repository, rule *git_model.ProtectedBranch) Target {
+	return Target{OwnerID: repo.OwnerID, RepoID: repo.ID, Type: audit_model.TargetTypeProtectedBranch, ID: rule.ID, Name: rule.RuleName}
+}
+
+// CollaboratorTarget describes the collaboration of a user on a repository
+func CollaboratorTarget(repo *repo_model.Repository, u *user_model.User) Target {
+	return Target{OwnerID: repo.OwnerID, RepoID: repo.ID, Type: audit_model.TargetTypeUser, ID: u.ID, Name: u.Name}
+}
+
+func UserTarget(u *user_model.User) Target {
+	return Target{OwnerID: u.ID, Type: audit_model.TargetTypeUser, ID: u.ID, Name: u.Name}
+}
+
+func TeamTarget(team *organization.Team) Target {
+	return Target{OwnerID: team.OrgID, Type: audit_model.TargetTypeTeam, ID: team.ID, Name: team.Name}
+}
+
+// TeamMemberTarget describes the membership of a user in a team
+func TeamMemberTarget(team *organization.Team, u *user_model.User) Target {
+	return Target{OwnerID: team.OrgID, Type: audit_model.TargetTypeUser, ID: u.ID, Name: u.Name}
+}
+
+func AccessTokenTarget(token *auth_model.AccessToken) Target {
+	return Target{OwnerID: token.UID, Type: audit_model.TargetTypeAccessToken, ID: token.ID, Name: token.Name}
+}
+
+// SecretTarget describes a secret, the owner of a repository level secret is resolved from its repository when recording
+func SecretTarget(s *secret_model.Secret) Target {
+	return Target{OwnerID: s.OwnerID, RepoID: s.RepoID, Type: audit_model.TargetTypeSecret, ID: s.ID, Name: s.Name}
+}
+
+// PushRuleTarget describes the push rule of a repository, or of the owner if repo is nil
+func PushRuleTarget(owner *user_model.User, repo *repo_model.Repository, rule *git_model.PushRule) Target {
+	if repo != nil {
+		return Target{OwnerID: repo.OwnerID, RepoID: repo.ID, Type: audit_model.TargetTypePushRule, ID: rule.ID, Name: repo.FullName()}
+	}
+	return Target{OwnerID: owner.ID, Type: audit_model.TargetTypePushRule, ID: rule.ID, Name: owner.Name}
+}
+
+// Record appends an event to the audit log. before and after are the states of the target around the change,
+// they are stored as JSON and may be nil. The IP address is taken from the current request if there is one.
+// Errors are logged instead of returned because the audited change has already been made at this point.
+func Record(ctx context.Context, doer *user_model.User, action audit_model.Action, target Target, before, after any) {
+	e := &audit_model.Event{
+		Action:     action,
+		IPAddress:  requestIPAddress(ctx),
+		OwnerID:    target.OwnerID,
+		RepoID:     target.RepoID,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		TargetName: target.Name,
+	}
+	if doer != nil {
+		e.ActorID = doer.ID
+		e.ActorName = doer.Name
+	}
+	if e.OwnerID == 0 && e.RepoID > 0 {
+		repo, err := repo_model.GetRepositoryByID(ctx, e.RepoID)
+		if err != nil {
+			log.Error("audit: GetRepositoryByID[%d]: %v", e.RepoID, err)
+		} else {
+			e.OwnerID = repo.OwnerID
+		}
+	}
+
+	var err error
+	if e.Before, err = marshalState(before); err != nil {
+		log.Error("audit: unable to marshal the state before %s on %s %d: %v", action, target.Type, target.ID, err)
+	}
+	if e.After, err = marshalState(after); err != nil {
+		log.Error("audit: unable to marshal the state after %s on %s %d: %v", action, target.Type, target.ID, err)
+	}
+
+	if err := audit_model.InsertEvent(ctx, e); err != nil {
+		log.Error("audit: unable to record %s on %s %d by %s: %v", action, target.Type, target.ID, e.ActorName, err)
+	}
+}
+
+func marshalState(state any) (string, error) {
+	if state == nil {
+		return "", nil
+	}
+	bs, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func requestIPAddress(ctx context.Context) string {
+	req, ok := ctx.Value(httplib.RequestContextKey).(*http.Request)
+	if !ok {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		return host
+	}
+	return req.RemoteAddr
+}
+
+type collaboratorState struct {
+	Permission string `json:"permission"`
+}
+
+// RecordCollaboratorChange records the change of the collaboration access mode of u on repo,
+// perm.AccessModeNone meaning that u is not a collaborator.
+func RecordCollaboratorChange(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, u *user_model.User, before, after perm.AccessMode) {
+	target := CollaboratorTarget(repo, u)
+	switch {
+	case before == after:
+	case before == perm.AccessModeNone:
+		Record(ctx, doer, audit_model.ActionCollaboratorAdd, target, nil, &collaboratorState{Permission: after.ToString()})
+	case after == perm.AccessModeNone:
+		Record(ctx, doer, audit_model.ActionCollaboratorRemove, target, &collaboratorState{Permission: before.ToString()}, nil)
+	default:
+		Record(ctx, doer, audit_model.ActionCollaboratorUpdate, target, &collaboratorState{Permission: before.ToString()}, &collaboratorState{Permission: after.ToString()})
+	}
+}
+
+type teamState struct {
+	Name                    string            `json:"name"`
+	Description             string            `json:"description"`
+	Permission              string            `json:"permission"`
+	IncludesAllRepositories bool              `json:"includes_all_repositories"`
+	CanCreateOrgRepo        bool              `json:"can_create_org_repo"`
+	Visibility              string            `json:"visibility"`
+	Units                   map[string]string `json:"units"`
+}
+
+// TeamState returns the state of a team to be recorded in the audit log, loading the team units if necessary.
+// It must be called before the team is modified in place to get the state before a change.
+func TeamState(ctx context.Context, team *organization.Team) any {
+	if err := team.LoadUnits(ctx); err != nil {
+		log.Error("audit: LoadUnits for team %d: %v", team.ID, err)
+	}
+	return &teamState{
+		Name:                    team.Name,
+		Description:             team.Description,
+		Permission:              team.AccessMode.ToString(),
+		IncludesAllRepositories: team.IncludesAllRepositories,
+		CanCreateOrgRepo:        team.CanCreateOrgRepo,
+		Visibility:              team.Visibility.String(),
+		Units:                   team.GetUnitsMap(),
+	}
+}
+
+type teamMemberState struct {
+	Team       string `json:"team"`
+	Permission string `json:"permission"`
+}
+
+// TeamMemberState returns the state of a membership in the team to be recorded in the audit log
+func TeamMemberState(team *organization.Team) any {
+	return &teamMemberState{Team: team.Name, Permission: team.AccessMode.ToString()}
+}
+
+type accessTokenState struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// AccessTokenState returns the state of an access token to be recorded in the audit log, the token itself is never included
+func AccessTokenState(token *auth_model.AccessToken) any {
+	return &accessTokenState{Name: token.Name, Scopes: token.Scope.StringSlice()}
+}
+
+type secretState struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SecretState returns the state of a secret to be recorded in the audit log, the secret value is never included
+func SecretState(s *secret_model.Secret) any {
+	return &secretState{Name: s.Name, Description: s.Description}
+}
+
+type repositoryOwnerState struct {
+	Owner string `json:"owner"`
+}
+
+// RecordRepositoryTransfer records that repo has been transferred away from its previous owner.
+// The event belongs to the previous owner, which is the one losing control over the repository.
+func RecordRepositoryTransfer(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, oldOwnerID int64, oldOwnerName string) {
+	target := RepositoryTarget(repo)
+	target.OwnerID = oldOwnerID
+	Record(ctx, doer, audit_model.ActionRepositoryTransfer, target, &repositoryOwnerState{Owner: oldOwnerName}, &repositoryOwnerState{Owner: repo.OwnerName})
+}
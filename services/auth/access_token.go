@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+
+	audit_model "gitea.dev/models/audit"
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/db"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/util"
+	audit_service "gitea.dev/services/audit"
+
+	"xorm.io/builder"
+)
+
+// DeleteAccessTokenByID deletes the access token of the user and records the deletion in the audit log
+func DeleteAccessTokenByID(ctx context.Context, doer *user_model.User, id, userID int64) error {
+	token, exist, err := db.Get[auth_model.AccessToken](ctx, builder.Eq{"id": id, "uid": userID})
+	if err != nil {
+		return err
+	} else if !exist {
+		return util.NewNotExistErrorf("access token not found")
+	}
+
+	if err := auth_model.DeleteAccessTokenByID(ctx, token.ID, token.UID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, doer, audit_model.ActionAccessTokenDelete, audit_service.AccessTokenTarget(token), audit_service.AccessTokenState(token), nil)
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"testing"
+
+	audit_model "gitea.dev/models/audit"
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAccessTokenByID(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	token := unittest.AssertExistsAndLoadBean(t, &auth_model.AccessToken{ID: 1})
+
+	// the token of another user is not deleted
+	err := DeleteAccessTokenByID(t.Context(), doer, token.ID, 2)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	unittest.AssertExistsAndLoadBean(t, &auth_model.AccessToken{ID: token.ID})
+	unittest.AssertNotExistsBean(t, &audit_model.Event{Action: audit_model.ActionAccessTokenDelete})
+
+	require.NoError(t, DeleteAccessTokenByID(t.Context(), doer, token.ID, token.UID))
+	unittest.AssertNotExistsBean(t, &auth_model.AccessToken{ID: token.ID})
+
+	event := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionAccessTokenDelete})
+	assert.Equal(t, doer.ID, event.ActorID)
+	assert.Equal(t, token.UID, event.OwnerID)
+	assert.Equal(t, audit_model.TargetTypeAccessToken, event.TargetType)
+	assert.Equal(t, token.ID, event.TargetID)
+	assert.Equal(t, "Token A", event.TargetName)
+	assert.JSONEq(t, `{"name":"Token A","scopes":null}`, event.Before)
+	assert.Empty(t, event.After)
+
+	err = DeleteAccessTokenByID(t.Context(), doer, token.ID, token.UID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
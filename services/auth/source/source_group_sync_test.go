@@ -65,7 +65,7 @@ func TestSyncGroupsToTeams(t *testing.T) {
 		assert.ElementsMatch(t, []string{"Owners", "teamCreateRepo"}, getUserTeamNames(t))
 		// 2. there are other owners, so the user2 is removed from the "owners" team
 		teamOwners, _ := organization.GetTeam(t.Context(), org3.ID, "owners")
-		_ = org_service.AddTeamMember(t.Context(), teamOwners, user4)
+		_ = org_service.AddTeamMember(t.Context(), nil, teamOwners, user4)
 		testSyncUserWithoutGroupMapping(t)
 		assert.ElementsMatch(t, []string{"teamCreateRepo"}, getUserTeamNames(t))
 	})
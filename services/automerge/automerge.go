@@ -37,6 +37,12 @@ func Init() error {
 		return errors.New("unable to create pr_auto_merge queue")
 	}
 	go graceful.GetManager().RunWithCancel(automergequeue.AutoMergeQueue)
+
+	automergequeue.MergeQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "pr_merge_queue", mergeQueueHandler)
+	if automergequeue.MergeQueue == nil {
+		return errors.New("unable to create pr_merge_queue queue")
+	}
+	go graceful.GetManager().RunWithCancel(automergequeue.MergeQueue)
 	return nil
 }
 
@@ -255,10 +261,27 @@ func handlePullRequestAutoMerge(pullID int64, sha string) {
 			log.Info("%-v was scheduled to automerge by an unauthorized user", pr)
 			return
 		}
+		if errors.Is(err, pull_service.ErrStackParentNotMerged) {
+			log.Trace("%-v is waiting for the pull request it is stacked on to merge", pr)
+			return
+		}
 		log.Error("%-v CheckPullMergeable: %v", pr, err)
 		return
 	}
 
+	if mergeQueueRequired, err := IsMergeQueueRequired(ctx, pr); err != nil {
+		log.Error("IsMergeQueueRequired %-v: %v", pr, err)
+		return
+	} else if mergeQueueRequired {
+		if err := pull_model.DeleteScheduledAutoMerge(ctx, pr.ID); err != nil && !db.IsErrNotExist(err) {
+			log.Error("DeleteScheduledAutoMerge %-v: %v", pr, err)
+		}
+		if err := AddToMergeQueue(ctx, doer, pr, scheduledPRM.MergeStyle, scheduledPRM.Message, scheduledPRM.DeleteBranchAfterMerge); err != nil {
+			log.Error("AddToMergeQueue %-v: %v", pr, err)
+		}
+		return
+	}
+
 	if err := pull_service.Merge(ctx, pr, doer, scheduledPRM.MergeStyle, "", scheduledPRM.Message, true); err != nil {
 		log.Error("pull_service.Merge: %v", err)
 		// FIXME: if merge failed, we should display some error message to the pull request page.
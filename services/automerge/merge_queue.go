@@ -0,0 +1,399 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
+	issues_model "gitea.dev/models/issues"
+	pull_model "gitea.dev/models/pull"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/graceful"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/process"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/automergequeue"
+	notify_service "gitea.dev/services/notify"
+	pull_service "gitea.dev/services/pull"
+	repo_service "gitea.dev/services/repository"
+)
+
+// IsMergeQueueRequired returns true if pull requests targeting the base branch of pr must go through a merge queue
+func IsMergeQueueRequired(ctx context.Context, pr *issues_model.PullRequest) (bool, error) {
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return false, err
+	}
+	return pb != nil && pb.EnableMergeQueue, nil
+}
+
+// AddToMergeQueue appends a pull request to the merge queue of its base branch.
+// The caller should check the pull request is ready to be merged by the doer.
+func AddToMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, style repo_model.MergeStyle, message string, deleteBranchAfterMerge bool) error {
+	if err := pull_model.AddToMergeQueue(ctx, &pull_model.MergeQueueEntry{
+		RepoID:                 pr.BaseRepoID,
+		BaseBranch:             pr.BaseBranch,
+		PullID:                 pr.ID,
+		DoerID:                 doer.ID,
+		MergeStyle:             style,
+		Message:                message,
+		DeleteBranchAfterMerge: deleteBranchAfterMerge,
+	}); err != nil {
+		return err
+	}
+	log.Trace("Pull request [%d] added to the merge queue of %s with style [%s]", pr.ID, pr.BaseBranch, style)
+	automergequeue.AddBranchToMergeQueue(pr.BaseRepoID, pr.BaseBranch)
+	return nil
+}
+
+// RemoveFromMergeQueue removes a pull request from the merge queue of its base branch.
+// The merge groups containing it are invalidated and rebuilt without it.
+func RemoveFromMergeQueue(ctx context.Context, pr *issues_model.PullRequest) error {
+	entry, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		return err
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+
+	releaser, err := globallock.Lock(ctx, getMergeQueueLockKey(entry.RepoID, entry.BaseBranch))
+	if err != nil {
+		return fmt.Errorf("globallock.Lock: %w", err)
+	}
+	defer releaser()
+
+	if entry.Status == pull_model.MergeQueueEntryStatusChecking {
+		entries, err := pull_model.GetMergeQueueEntries(ctx, entry.RepoID, entry.BaseBranch)
+		if err != nil {
+			return err
+		}
+		// all merge groups after this entry contain its changes
+		for _, e := range entries {
+			if e.ID > entry.ID && e.Status == pull_model.MergeQueueEntryStatusChecking {
+				if err := resetMergeQueueEntry(ctx, pr.BaseRepo, e); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := pull_model.RemoveFromMergeQueue(ctx, pr.ID); err != nil {
+		return err
+	}
+	pull_service.RemoveMergeQueueRef(ctx, pr.BaseRepo, entry)
+	automergequeue.AddBranchToMergeQueue(entry.RepoID, entry.BaseBranch)
+	return nil
+}
+
+// StartMergeQueueByCommitID requests the merge queues with a merge group at the commit to be processed
+func StartMergeQueueByCommitID(ctx context.Context, repo *repo_model.Repository, commitID string) error {
+	entries, err := db.Find[pull_model.MergeQueueEntry](ctx, pull_model.FindMergeQueueEntriesOptions{
+		RepoID:        repo.ID,
+		MergeCommitID: commitID,
+	})
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		automergequeue.AddBranchToMergeQueue(entry.RepoID, entry.BaseBranch)
+	}
+	return nil
+}
+
+func getMergeQueueLockKey(repoID int64, branch string) string {
+	return fmt.Sprintf("merge_queue_%d_%s", repoID, branch)
+}
+
+func mergeQueueHandler(items ...string) []string {
+	for _, s := range items {
+		repoIDStr, branch, ok := strings.Cut(s, "_")
+		repoID, err := strconv.ParseInt(repoIDStr, 10, 64)
+		if !ok || err != nil {
+			log.Error("could not parse data from pr_merge_queue queue (%v)", s)
+			continue
+		}
+		handleMergeQueue(repoID, branch)
+	}
+	return nil
+}
+
+// handleMergeQueue lands the merge groups whose checks passed, drops the entries whose checks failed,
+// and creates new merge groups for the waiting entries
+func handleMergeQueue(repoID int64, branch string) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(),
+		fmt.Sprintf("Handle merge queue of branch %s in repo %d", branch, repoID))
+	defer finished()
+
+	releaser, err := globallock.Lock(ctx, getMergeQueueLockKey(repoID, branch))
+	if err != nil {
+		log.Error("globallock.Lock: %v", err)
+		return
+	}
+	defer releaser()
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		log.Error("GetRepositoryByID[%d]: %v", repoID, err)
+		return
+	}
+
+	entries, err := pull_model.GetMergeQueueEntries(ctx, repoID, branch)
+	if err != nil {
+		log.Error("GetMergeQueueEntries[%d, %s]: %v", repoID, branch, err)
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repoID, branch)
+	if err != nil {
+		log.Error("GetFirstMatchProtectedBranchRule[%d, %s]: %v", repoID, branch, err)
+		return
+	}
+	if pb == nil || !pb.EnableMergeQueue {
+		for _, entry := range entries {
+			failMergeQueueEntry(ctx, repo, entry, "the merge queue has been disabled for the base branch")
+		}
+		return
+	}
+
+	gitRepo, err := git.OpenRepository(ctx, repo)
+	if err != nil {
+		log.Error("OpenRepository: %v", err)
+		return
+	}
+	defer gitRepo.Close()
+
+	if entries, err = landMergeGroups(ctx, repo, gitRepo, pb, entries); err != nil {
+		log.Error("landMergeGroups[%-v, %s]: %v", repo, branch, err)
+		return
+	}
+	if created, err := createMergeGroups(ctx, repo, gitRepo, pb, entries); err != nil {
+		log.Error("createMergeGroups[%-v, %s]: %v", repo, branch, err)
+	} else if created {
+		// the new merge groups may not require any status check, so check them again
+		automergequeue.AddBranchToMergeQueue(repoID, branch)
+	}
+}
+
+// landMergeGroups fast-forwards the base branch to the last passed merge group and returns the remaining entries
+func landMergeGroups(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, pb *git_model.ProtectedBranch, entries []*pull_model.MergeQueueEntry) ([]*pull_model.MergeQueueEntry, error) {
+	numChecking := 0
+	for numChecking < len(entries) && entries[numChecking].Status == pull_model.MergeQueueEntryStatusChecking {
+		numChecking++
+	}
+	if numChecking == 0 {
+		return entries, nil
+	}
+	checking := entries[:numChecking]
+
+	baseCommitID, err := gitRepo.GetBranchCommitID(ctx, checking[0].BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+	if checking[0].BaseCommitID != baseCommitID {
+		// the base branch has been changed outside the merge queue, all merge groups are outdated
+		for _, entry := range checking {
+			if err := resetMergeQueueEntry(ctx, repo, entry); err != nil {
+				return nil, err
+			}
+		}
+		return entries, nil
+	}
+
+	// A passed merge group contains all entries before it, so the entries before a failed group
+	// can still land if a later group (which contains them) has passed.
+	lastSuccess, firstFailure := -1, -1
+	for i, entry := range checking {
+		state, err := pull_service.GetMergeGroupCommitStatusState(ctx, repo, pb, entry.MergeCommitID)
+		if err != nil {
+			return nil, err
+		}
+		if state.IsSuccess() {
+			lastSuccess = i
+		} else if state.IsFailure() || state.IsError() {
+			firstFailure = i
+			break
+		}
+	}
+
+	if lastSuccess >= 0 {
+		if err := landMergeGroup(ctx, repo, checking[:lastSuccess+1]); err != nil {
+			log.Warn("Unable to land merge group of %-v at %s: %v", repo, checking[lastSuccess].MergeCommitID, err)
+			// the next run compares the base branch again and rebuilds the groups if needed
+			return entries[lastSuccess+1:], nil
+		}
+	}
+
+	if firstFailure >= 0 {
+		failMergeQueueEntry(ctx, repo, checking[firstFailure], "the required status checks of its merge group failed")
+		for _, entry := range checking[firstFailure+1:] {
+			if err := resetMergeQueueEntry(ctx, repo, entry); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return pull_model.GetMergeQueueEntries(ctx, repo.ID, checking[0].BaseBranch)
+}
+
+// landMergeGroup fast-forwards the base branch to the merge group of the last entry and marks all entries as merged
+func landMergeGroup(ctx context.Context, repo *repo_model.Repository, entries []*pull_model.MergeQueueEntry) error {
+	last := entries[len(entries)-1]
+	if err := last.LoadDoer(ctx); err != nil {
+		return err
+	}
+	lastPR, err := issues_model.GetPullRequestByID(ctx, last.PullID)
+	if err != nil {
+		return err
+	}
+	if err := pull_service.FastForwardMergeQueue(ctx, repo, last.Doer, lastPR, last); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			log.Error("GetPullRequestByID[%d]: %v", entry.PullID, err)
+			continue
+		}
+		if err := entry.LoadDoer(ctx); err != nil {
+			log.Error("LoadDoer[%d]: %v", entry.DoerID, err)
+			continue
+		}
+		if err := pr.LoadBaseRepo(ctx); err != nil {
+			log.Error("LoadBaseRepo: %v", err)
+			continue
+		}
+		if err := pull_service.SetMergedByMergeQueue(ctx, pr, entry.Doer, entry); err != nil {
+			log.Error("SetMergedByMergeQueue %-v: %v", pr, err)
+		}
+		if err := pull_model.RemoveFromMergeQueue(ctx, pr.ID); err != nil {
+			log.Error("RemoveFromMergeQueue %-v: %v", pr, err)
+		}
+		pull_service.RemoveMergeQueueRef(ctx, repo, entry)
+
+		deleteBranchAfterMerge, err := pull_service.ShouldDeleteBranchAfterMerge(ctx, &entry.DeleteBranchAfterMerge, repo, pr)
+		if err != nil {
+			log.Error("ShouldDeleteBranchAfterMerge: %v", err)
+		} else if deleteBranchAfterMerge {
+			if err = repo_service.DeleteBranchAfterMerge(ctx, entry.Doer, pr.ID, nil); err != nil {
+				log.Error("DeleteBranchAfterMerge: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// createMergeGroups speculatively merges the waiting entries on top of the last merge group until the group size limit is reached.
+// It returns true if any new merge group has been created.
+func createMergeGroups(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, pb *git_model.ProtectedBranch, entries []*pull_model.MergeQueueEntry) (created bool, _ error) {
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	branch := entries[0].BaseBranch
+	baseRef := git.BranchPrefix + branch
+	baseCommitID, err := gitRepo.GetBranchCommitID(ctx, branch)
+	if err != nil {
+		return false, err
+	}
+
+	numChecking := 0
+	for _, entry := range entries {
+		if entry.Status != pull_model.MergeQueueEntryStatusChecking {
+			break
+		}
+		numChecking++
+		baseRef, baseCommitID = entry.GetRefName(), entry.MergeCommitID
+	}
+
+	for _, entry := range entries[numChecking:] {
+		if numChecking >= pb.GetMergeQueueMaxGroupSize() {
+			break
+		}
+
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil {
+			if issues_model.IsErrPullRequestNotExist(err) {
+				_ = pull_model.RemoveFromMergeQueue(ctx, entry.PullID)
+				continue
+			}
+			return created, err
+		}
+		if err := pr.LoadIssue(ctx); err != nil {
+			return created, err
+		}
+		if pr.HasMerged || pr.Issue.IsClosed {
+			if err := pull_model.RemoveFromMergeQueue(ctx, pr.ID); err != nil {
+				return created, err
+			}
+			continue
+		}
+		if err := entry.LoadDoer(ctx); err != nil {
+			return created, err
+		}
+
+		mergeCommitID, err := pull_service.SpeculativeMerge(ctx, pr, entry.Doer, entry.MergeStyle, entry.Message, baseRef, entry.GetRefName())
+		if err != nil {
+			log.Debug("SpeculativeMerge %-v onto %s: %v", pr, baseRef, err)
+			failMergeQueueEntry(ctx, repo, entry, mergeQueueFailReason(err))
+			continue
+		}
+
+		entry.Status = pull_model.MergeQueueEntryStatusChecking
+		entry.BaseCommitID = baseCommitID
+		entry.MergeCommitID = mergeCommitID
+		if err := pull_model.UpdateMergeQueueEntryCols(ctx, entry, "status", "base_commit_id", "merge_commit_id"); err != nil {
+			return created, err
+		}
+		notify_service.MergeGroupChecksRequested(ctx, entry.Doer, pr, entry)
+
+		baseRef, baseCommitID = entry.GetRefName(), mergeCommitID
+		numChecking++
+		created = true
+	}
+	return created, nil
+}
+
+func mergeQueueFailReason(err error) string {
+	switch {
+	case pull_service.IsErrMergeConflicts(err), pull_service.IsErrRebaseConflicts(err):
+		return "the pull request conflicts with the merge group ahead of it"
+	case pull_service.IsErrMergeUnrelatedHistories(err):
+		return "the pull request has an unrelated history"
+	case pull_service.IsErrMergeDivergingFastForwardOnly(err):
+		return "the pull request cannot be fast-forwarded onto the merge group ahead of it"
+	case pull_service.IsErrInvalidMergeStyle(err):
+		return "the merge style is not allowed"
+	}
+	return "unable to merge the pull request: " + util.SanitizeErrorCredentialURLs(err).Error()
+}
+
+func failMergeQueueEntry(ctx context.Context, repo *repo_model.Repository, entry *pull_model.MergeQueueEntry, reason string) {
+	pull_service.RemoveMergeQueueRef(ctx, repo, entry)
+	entry.Status = pull_model.MergeQueueEntryStatusFailed
+	entry.FailReason = reason
+	if err := pull_model.UpdateMergeQueueEntryCols(ctx, entry, "status", "fail_reason"); err != nil {
+		log.Error("UpdateMergeQueueEntryCols[%d]: %v", entry.ID, err)
+	}
+}
+
+func resetMergeQueueEntry(ctx context.Context, repo *repo_model.Repository, entry *pull_model.MergeQueueEntry) error {
+	pull_service.RemoveMergeQueueRef(ctx, repo, entry)
+	entry.Status = pull_model.MergeQueueEntryStatusWaiting
+	entry.BaseCommitID = ""
+	entry.MergeCommitID = ""
+	return pull_model.UpdateMergeQueueEntryCols(ctx, entry, "status", "base_commit_id", "merge_commit_id")
+}
@@ -6,8 +6,10 @@ package automerge
 import (
 	"context"
 
+	"gitea.dev/models/db"
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
+	pull_model "gitea.dev/models/pull"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/log"
@@ -55,4 +57,33 @@ func (n *automergeNotifier) CreateCommitStatus(ctx context.Context, repo *repo_m
 			log.Error("MergeScheduledPullRequest[repo_id: %d, user_id: %d, sha: %s]: %w", repo.ID, sender.ID, commit.Sha1, err)
 		}
 	}
+	// both passed and failed checks of a merge group move its merge queue forward
+	if !status.State.IsPending() {
+		if err := StartMergeQueueByCommitID(ctx, repo, commit.Sha1); err != nil {
+			log.Error("StartMergeQueueByCommitID[repo_id: %d, sha: %s]: %v", repo.ID, commit.Sha1, err)
+		}
+	}
+}
+
+func (n *automergeNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
+	if !opts.RefFullName.IsBranch() {
+		return
+	}
+	// merge groups based on the old head of the branch need to be rebuilt
+	exist, err := db.Exist[pull_model.MergeQueueEntry](ctx, pull_model.FindMergeQueueEntriesOptions{
+		RepoID:     repo.ID,
+		BaseBranch: opts.RefFullName.BranchName(),
+	}.ToConds())
+	if err != nil {
+		log.Error("Exist merge queue entries[repo_id: %d, branch: %s]: %v", repo.ID, opts.RefFullName.BranchName(), err)
+	} else if exist {
+		automergequeue.AddBranchToMergeQueue(repo.ID, opts.RefFullName.BranchName())
+	}
+}
+
+func (n *automergeNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, before, after string) {
+	// a queued pull request must be added to the merge queue again after its head changed
+	if err := RemoveFromMergeQueue(ctx, pr); err != nil && !db.IsErrNotExist(err) {
+		log.Error("RemoveFromMergeQueue %-v: %v", pr, err)
+	}
 }
@@ -16,6 +16,9 @@ import (
 
 var AutoMergeQueue *queue.WorkerPoolQueue[string]
 
+// MergeQueue processes the merge queues of protected branches, the items are "<repoID>_<branch>"
+var MergeQueue *queue.WorkerPoolQueue[string]
+
 var AddToQueue = func(pr *issues_model.PullRequest, sha string) {
 	log.Trace("Adding pullID: %d to the pull requests patch checking queue with sha %s", pr.ID, sha)
 	if err := AutoMergeQueue.Push(fmt.Sprintf("%d_%s", pr.ID, sha)); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
@@ -23,6 +26,14 @@ var AddToQueue = func(pr *issues_model.PullRequest, sha string) {
 	}
 }
 
+// AddBranchToMergeQueue requests the merge queue of a branch to be processed
+var AddBranchToMergeQueue = func(repoID int64, branch string) {
+	log.Trace("Adding branch %s of repo %d to the merge queue processing queue", branch, repoID)
+	if err := MergeQueue.Push(fmt.Sprintf("%d_%s", repoID, branch)); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		log.Error("Error adding branch %s of repo %d to the merge queue processing queue: %v", branch, repoID, err)
+	}
+}
+
 // StartPRCheckAndAutoMerge start an automerge check and auto merge task for a pull request
 func StartPRCheckAndAutoMerge(ctx context.Context, pull *issues_model.PullRequest) {
 	if pull == nil || pull.HasMerged || !pull.IsStatusMergeable() {
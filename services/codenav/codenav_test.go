@@ -0,0 +1,168 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"bytes"
+	"testing"
+
+	codenav_model "gitea.dev/models/codenav"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/scip"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const testSource = `package main
+
+// Hello says hello
+func Hello() {}
+
+func main() {
+	s := "😀"; Hello()
+}
+
+var HelloWorld = 1
+`
+
+func appendField(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func encodeOccurrence(symbol string, roles scip.SymbolRole, rng ...int32) []byte {
+	var packed []byte
+	for _, v := range rng {
+		packed = protowire.AppendVarint(packed, uint64(v))
+	}
+	b := appendField(appendField(nil, 1, packed), 2, []byte(symbol))
+	b = protowire.AppendTag(b, 3, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(roles))
+}
+
+// testIndex returns a SCIP index of testSource in main.go
+func testIndex() []byte {
+	const hello = "scip-go gomod example v1 `example`/Hello()."
+	doc := appendField(nil, 1, []byte("main.go"))
+	doc = appendField(doc, 2, encodeOccurrence(hello, scip.SymbolRoleDefinition, 3, 5, 10))
+	doc = appendField(doc, 2, encodeOccurrence(hello, 0, 6, 14, 19)) // the emoji takes 4 bytes
+	doc = appendField(doc, 2, encodeOccurrence("local 0", scip.SymbolRoleDefinition, 6, 1, 2))
+	info := appendField(appendField(nil, 1, []byte(hello)), 3, []byte("Hello says hello"))
+	doc = appendField(doc, 3, appendField(info, 6, []byte("Hello")))
+
+	escaping := appendField(nil, 1, []byte("../escape.go"))
+
+	var index []byte
+	index = appendField(index, 1, appendField(nil, 2, appendField(nil, 1, []byte("scip-go"))))
+	index = appendField(index, 2, doc)
+	index = appendField(index, 2, escaping)
+	return index
+}
+
+func TestCodeNavigation(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repoDir := t.TempDir()
+	require.NoError(t, gitcmd.NewCommand("init", "--bare").AddDynamicArguments(repoDir).Run(t.Context()))
+	_, _, runErr := gitcmd.NewCommand("fast-import").WithDir(repoDir).WithStdinBytes([]byte(`commit refs/heads/main
+committer User Two <user2@example.com> 1714310400 +0000
+data 4
+main
+M 100644 inline main.go
+data <<EOT
+` + testSource + `EOT
+`)).RunStdString(t.Context())
+	require.NoError(t, runErr)
+
+	gitRepo, err := git.OpenRepositoryLocal(t.Context(), repoDir)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+	commit, err := gitRepo.GetBranchCommit(t.Context(), "main")
+	require.NoError(t, err)
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.CodeNavigation.MaxIndexSize, 16)()
+		_, err := UploadIndex(t.Context(), doer, repo, commit, "", bytes.NewReader(testIndex()))
+		assert.ErrorIs(t, err, util.ErrContentTooLarge)
+		unittest.AssertNotExistsBean(t, &codenav_model.Index{RepoID: repo.ID})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		index := testIndex()
+		_, err := UploadIndex(t.Context(), doer, repo, commit, "", bytes.NewReader(index[:len(index)-2]))
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		unittest.AssertNotExistsBean(t, &codenav_model.Index{RepoID: repo.ID})
+	})
+
+	idx, err := UploadIndex(t.Context(), doer, repo, commit, "", bytes.NewReader(testIndex()))
+	require.NoError(t, err)
+	assert.True(t, idx.IsReady)
+	assert.Equal(t, "scip-go", idx.ToolName)
+	assert.Equal(t, 1, idx.NumDocuments) // the document outside of the repository is left out
+
+	t.Run("Precise", func(t *testing.T) {
+		// the position is in UTF-16 code units, the emoji takes 2 of them
+		nav, err := Navigate(t.Context(), repo, gitRepo, commit, NavigateOptions{Path: "main.go", Line: 7, Character: 13, Word: "Hello"})
+		require.NoError(t, err)
+		assert.True(t, nav.Precise)
+		assert.Equal(t, "Hello", nav.DisplayName)
+		assert.Equal(t, []string{"Hello says hello"}, nav.Documentation)
+		assert.Equal(t, []*Location{{Path: "main.go", Line: 4}}, nav.Definitions)
+		assert.Equal(t, []*Location{{Path: "main.go", Line: 7}}, nav.References)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		// the document isn't covered by the index
+		nav, err := Navigate(t.Context(), repo, gitRepo, commit, NavigateOptions{Path: "other.go", Line: 1, Word: "Hello"})
+		require.NoError(t, err)
+		assert.False(t, nav.Precise)
+		assert.Empty(t, nav.Definitions)
+		assert.Equal(t, []*Location{{Path: "main.go", Line: 3}, {Path: "main.go", Line: 4}, {Path: "main.go", Line: 7}}, nav.References)
+
+		// no occurrence at the position and no identifier to search for
+		nav, err = Navigate(t.Context(), repo, gitRepo, commit, NavigateOptions{Path: "main.go", Line: 7, Character: 9, Word: `"`})
+		require.NoError(t, err)
+		assert.False(t, nav.Precise)
+		assert.Empty(t, nav.References)
+	})
+
+	// a new upload for the same commit replaces the index
+	newIdx, err := UploadIndex(t.Context(), doer, repo, commit, "", bytes.NewReader(testIndex()))
+	require.NoError(t, err)
+	unittest.AssertNotExistsBean(t, &codenav_model.Index{ID: idx.ID})
+	unittest.AssertNotExistsBean(t, &codenav_model.Occurrence{IndexID: idx.ID})
+
+	require.NoError(t, DeleteIndex(t.Context(), repo, commit.ID.String()))
+	unittest.AssertNotExistsBean(t, &codenav_model.Index{ID: newIdx.ID})
+}
+
+func TestConvertCharacter(t *testing.T) {
+	line := `s := "😀"; Hello()`
+	assert.EqualValues(t, 13, convertCharacter(line, 11, scip.PositionEncodingUTF8))
+	assert.EqualValues(t, 13, convertCharacter(line, 11, scip.PositionEncodingUnspecified))
+	assert.EqualValues(t, 10, convertCharacter(line, 11, scip.PositionEncodingUTF32))
+	assert.EqualValues(t, len(line), convertCharacter(line, 18, scip.PositionEncodingUTF8))
+	assert.EqualValues(t, -1, convertCharacter(line, 19, scip.PositionEncodingUTF8))
+}
+
+func TestContainsWord(t *testing.T) {
+	assert.True(t, containsWord("Hello()", "Hello"))
+	assert.True(t, containsWord("HelloWorld(); Hello()", "Hello"))
+	assert.False(t, containsWord("var HelloWorld = 1", "Hello"))
+	assert.False(t, containsWord("sayHello()", "Hello"))
+	assert.False(t, containsWord("_Hello", "Hello"))
+	assert.False(t, containsWord("", "Hello"))
+}
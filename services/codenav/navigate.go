@@ -0,0 +1,246 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	codenav_model "gitea.dev/models/codenav"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/scip"
+	"gitea.dev/modules/setting"
+)
+
+// NavigateOptions is the position in a file of a commit to navigate from
+type NavigateOptions struct {
+	Path      string
+	Line      int    // one-based
+	Character int    // zero-based offset in UTF-16 code units, like the offsets of JavaScript strings
+	Word      string // the identifier at the position, searched for when no index covers it
+}
+
+// Location is a line of a file
+type Location struct {
+	Path string
+	Line int // one-based
+}
+
+// Navigation holds the definitions and references of the symbol at a position.
+// Precise results come from an uploaded index, otherwise they are the result of a text search
+// for the identifier at the position, which can't tell definitions from references.
+type Navigation struct {
+	Precise       bool
+	Symbol        string
+	DisplayName   string
+	Documentation []string // markdown
+	Definitions   []*Location
+	References    []*Location
+}
+
+// Navigate returns the definitions and references of the symbol at the position
+func Navigate(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, commit *git.Commit, opts NavigateOptions) (*Navigation, error) {
+	nav, err := navigatePrecise(ctx, repo, gitRepo, commit, opts)
+	if err != nil || nav != nil {
+		return nav, err
+	}
+	return navigateSearch(ctx, gitRepo, commit, opts)
+}
+
+// navigatePrecise returns nil if no ready index covers the position
+func navigatePrecise(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, commit *git.Commit, opts NavigateOptions) (*Navigation, error) {
+	if opts.Line < 1 || opts.Character < 0 {
+		return nil, nil
+	}
+	idx, err := codenav_model.GetIndexByCommit(ctx, repo.ID, commit.ID.String())
+	if errors.Is(err, codenav_model.ErrIndexNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !idx.IsReady {
+		return nil, nil
+	}
+	doc, err := codenav_model.GetDocumentByPath(ctx, idx.ID, opts.Path)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	line := int32(opts.Line - 1)
+	character, err := documentCharacter(ctx, gitRepo, commit, doc, opts)
+	if err != nil || character < 0 {
+		return nil, err
+	}
+	occurrences, err := codenav_model.GetOccurrencesAtLine(ctx, doc.ID, line)
+	if err != nil {
+		return nil, err
+	}
+	var occurrence *codenav_model.Occurrence
+	for _, o := range occurrences {
+		if o.Contains(line, character) && (occurrence == nil || isNarrower(o, occurrence)) {
+			occurrence = o
+		}
+	}
+	if occurrence == nil {
+		return nil, nil
+	}
+
+	nav := &Navigation{Precise: true}
+	symbol, err := codenav_model.GetSymbol(ctx, idx.ID, occurrence.SymbolHash)
+	if err != nil {
+		return nil, err
+	}
+	if symbol != nil {
+		nav.Symbol = symbol.Symbol
+		nav.DisplayName = symbol.DisplayName
+		nav.Documentation = symbol.Documentation
+	}
+
+	occurrences, err = codenav_model.FindSymbolOccurrences(ctx, idx.ID, occurrence.SymbolHash, setting.CodeNavigation.MaxReferences)
+	if err != nil {
+		return nil, err
+	}
+	docIDs := make([]int64, 0, len(occurrences))
+	for _, o := range occurrences {
+		docIDs = append(docIDs, o.DocumentID)
+	}
+	docs, err := codenav_model.GetDocumentsByIDs(ctx, docIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range occurrences {
+		d, ok := docs[o.DocumentID]
+		if !ok {
+			continue
+		}
+		loc := &Location{Path: d.Path, Line: int(o.StartLine) + 1}
+		if o.IsDefinition {
+			nav.Definitions = append(nav.Definitions, loc)
+		} else {
+			nav.References = append(nav.References, loc)
+		}
+	}
+	return nav, nil
+}
+
+// isNarrower reports whether a spans less than b, so the innermost of nested occurrences is used
+func isNarrower(a, b *codenav_model.Occurrence) bool {
+	if a.EndLine-a.StartLine != b.EndLine-b.StartLine {
+		return a.EndLine-a.StartLine < b.EndLine-b.StartLine
+	}
+	return a.EndCharacter-a.StartCharacter < b.EndCharacter-b.StartCharacter
+}
+
+// documentCharacter converts the character of the position to the encoding of the document,
+// it returns -1 if the file doesn't have such a position
+func documentCharacter(ctx context.Context, gitRepo *git.Repository, commit *git.Commit, doc *codenav_model.Document, opts NavigateOptions) (int32, error) {
+	if scip.PositionEncoding(doc.PositionEncoding) == scip.PositionEncodingUTF16 {
+		return int32(opts.Character), nil
+	}
+
+	blob, err := commit.GetBlobByPath(ctx, gitRepo, opts.Path)
+	if git.IsErrNotExist(err) {
+		return -1, nil
+	} else if err != nil {
+		return 0, err
+	}
+	content, err := blob.GetBlobContent(ctx, setting.UI.MaxDisplayFileSize)
+	if err != nil {
+		return 0, err
+	}
+	for range opts.Line - 1 {
+		var ok bool
+		if _, content, ok = strings.Cut(content, "\n"); !ok {
+			return -1, nil
+		}
+	}
+	line, _, _ := strings.Cut(content, "\n")
+	return convertCharacter(line, opts.Character, scip.PositionEncoding(doc.PositionEncoding)), nil
+}
+
+// convertCharacter converts a UTF-16 offset in the line to an offset in the encoding,
+// unspecified encodings are handled as UTF-8 which is what most indexers use
+func convertCharacter(line string, utf16Offset int, encoding scip.PositionEncoding) int32 {
+	var units, runes int
+	for i, r := range line {
+		if units >= utf16Offset {
+			if encoding == scip.PositionEncodingUTF32 {
+				return int32(runes)
+			}
+			return int32(i)
+		}
+		units++
+		if r >= 0x10000 {
+			units++
+		}
+		runes++
+	}
+	if units < utf16Offset {
+		return -1
+	}
+	if encoding == scip.PositionEncodingUTF32 {
+		return int32(runes)
+	}
+	return int32(len(line))
+}
+
+var identifierPattern = regexp.MustCompile(`^[\p{L}_$][\p{L}\p{N}_$]*$`)
+
+// maxSearchResultFiles is the number of files whose matches are returned by the search fallback
+const maxSearchResultFiles = 50
+
+// navigateSearch looks for the whole-word occurrences of the identifier in the files of the commit
+func navigateSearch(ctx context.Context, gitRepo *git.Repository, commit *git.Commit, opts NavigateOptions) (*Navigation, error) {
+	nav := &Navigation{}
+	if len(opts.Word) > 255 || !identifierPattern.MatchString(opts.Word) {
+		return nav, nil
+	}
+
+	results, err := git.GrepSearch(ctx, gitRepo, opts.Word, git.GrepOptions{
+		RefName:        commit.ID.String(),
+		MaxResultLimit: maxSearchResultFiles,
+		GrepMode:       git.GrepModeExact,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		for i, lineNum := range res.LineNumbers {
+			if !containsWord(res.LineCodes[i], opts.Word) {
+				continue
+			}
+			if len(nav.References) >= setting.CodeNavigation.MaxReferences {
+				return nav, nil
+			}
+			nav.References = append(nav.References, &Location{Path: res.Filename, Line: lineNum})
+		}
+	}
+	return nav, nil
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// containsWord reports whether the word occurs in the line, not as a part of a longer identifier
+func containsWord(line, word string) bool {
+	for offset := 0; ; {
+		i := strings.Index(line[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(word)
+		before, _ := utf8.DecodeLastRuneInString(line[:start])
+		after, _ := utf8.DecodeRuneInString(line[end:])
+		if (start == 0 || !isIdentifierRune(before)) && (end == len(line) || !isIdentifierRune(after)) {
+			return true
+		}
+		offset = end
+	}
+}
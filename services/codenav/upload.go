@@ -0,0 +1,209 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package codenav
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	codenav_model "gitea.dev/models/codenav"
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/scip"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+)
+
+const insertBatchSize = 1000
+
+// UploadIndex stores the SCIP index read from r for the commit, replacing the index previously uploaded for it.
+// root is the directory of the repository the paths of the index are relative to.
+func UploadIndex(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, commit *git.Commit, root string, r io.Reader) (*codenav_model.Index, error) {
+	root, err := cleanIndexRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	commitID := commit.ID.String()
+	if old, err := codenav_model.GetIndexByCommit(ctx, repo.ID, commitID); err == nil {
+		if err := codenav_model.DeleteIndex(ctx, old); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, codenav_model.ErrIndexNotExist) {
+		return nil, err
+	}
+
+	idx := &codenav_model.Index{
+		RepoID:     repo.ID,
+		CommitID:   commitID,
+		Root:       root,
+		UploaderID: doer.ID,
+	}
+	if err := db.Insert(ctx, idx); err != nil {
+		return nil, err
+	}
+
+	if err := storeIndex(ctx, idx, r); err != nil {
+		if delErr := codenav_model.DeleteIndex(ctx, idx); delErr != nil {
+			log.Error("Unable to delete the incomplete code navigation index %d: %v", idx.ID, delErr)
+		}
+		return nil, err
+	}
+
+	if err := codenav_model.PruneIndexes(ctx, repo.ID, setting.CodeNavigation.MaxIndexesPerRepo); err != nil {
+		log.Error("Unable to prune the code navigation indexes of %-v: %v", repo, err)
+	}
+	return idx, nil
+}
+
+func cleanIndexRoot(root string) (string, error) {
+	root = util.PathJoinRelX(root)
+	if len(root) > codenav_model.MaxPathLength {
+		return "", util.NewInvalidArgumentErrorf("index root is too long")
+	}
+	return root, nil
+}
+
+func storeIndex(ctx context.Context, idx *codenav_model.Index, r io.Reader) error {
+	lr := &io.LimitedReader{R: r, N: setting.CodeNavigation.MaxIndexSize + 1}
+	w := &indexWriter{ctx: ctx, idx: idx, paths: make(container.Set[string]), symbols: make(container.Set[string])}
+	err := scip.Decode(lr, int(setting.CodeNavigation.MaxDocumentSize), w)
+	if lr.N <= 0 {
+		return util.ErrorWrap(util.ErrContentTooLarge, "index exceeds limit %d", setting.CodeNavigation.MaxIndexSize)
+	}
+	if err == nil {
+		err = w.flush()
+	}
+	if w.err != nil {
+		return w.err
+	} else if errors.Is(err, scip.ErrMessageTooLarge) {
+		return util.ErrorWrap(util.ErrContentTooLarge, "document of index exceeds limit %d", setting.CodeNavigation.MaxDocumentSize)
+	} else if err != nil {
+		return util.NewInvalidArgumentErrorf("invalid SCIP index: %v", err)
+	}
+	return codenav_model.MarkIndexReady(ctx, idx)
+}
+
+// indexWriter stores the decoded parts of an index, errors of the database are kept apart
+// from the decoding errors to tell invalid indexes from failed uploads
+type indexWriter struct {
+	ctx         context.Context
+	idx         *codenav_model.Index
+	paths       container.Set[string]
+	symbols     container.Set[string]
+	occurrences []*codenav_model.Occurrence
+	infos       []*codenav_model.Symbol
+	err         error
+}
+
+func (w *indexWriter) Metadata(m *scip.Metadata) error {
+	w.idx.ToolName = util.TruncateRunes(m.ToolName, 255)
+	w.idx.ToolVersion = util.TruncateRunes(m.ToolVersion, 255)
+	_, w.err = db.GetEngine(w.ctx).ID(w.idx.ID).Cols("tool_name", "tool_version").Update(w.idx)
+	return w.err
+}
+
+func (w *indexWriter) Document(d *scip.Document) error {
+	p := path.Join(w.idx.Root, d.RelativePath)
+	if p == "." || path.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") || len(p) > codenav_model.MaxPathLength || !w.paths.Add(p) {
+		return nil
+	}
+
+	doc := &codenav_model.Document{
+		IndexID:          w.idx.ID,
+		Path:             p,
+		Language:         util.TruncateRunes(d.Language, 255),
+		PositionEncoding: int(d.PositionEncoding),
+	}
+	if w.err = db.Insert(w.ctx, doc); w.err != nil {
+		return w.err
+	}
+	w.idx.NumDocuments++
+
+	for _, o := range d.Occurrences {
+		w.occurrences = append(w.occurrences, &codenav_model.Occurrence{
+			IndexID:        w.idx.ID,
+			SymbolHash:     codenav_model.HashSymbol(p, o.Symbol, scip.IsLocalSymbol(o.Symbol)),
+			DocumentID:     doc.ID,
+			StartLine:      o.Range.StartLine,
+			StartCharacter: o.Range.StartCharacter,
+			EndLine:        o.Range.EndLine,
+			EndCharacter:   o.Range.EndCharacter,
+			IsDefinition:   o.IsDefinition(),
+		})
+		if len(w.occurrences) >= insertBatchSize {
+			if err := w.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	for _, s := range d.Symbols {
+		if err := w.addSymbol(p, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *indexWriter) ExternalSymbol(s *scip.SymbolInformation) error {
+	return w.addSymbol("", s)
+}
+
+// addSymbol keeps the documentation of a symbol, symbols without any are left out
+func (w *indexWriter) addSymbol(docPath string, s *scip.SymbolInformation) error {
+	if s.Symbol == "" || (s.DisplayName == "" && len(s.Documentation) == 0) {
+		return nil
+	}
+	isLocal := scip.IsLocalSymbol(s.Symbol)
+	if isLocal && docPath == "" {
+		return nil
+	}
+	hash := codenav_model.HashSymbol(docPath, s.Symbol, isLocal)
+	if !w.symbols.Add(hash) {
+		return nil
+	}
+	w.infos = append(w.infos, &codenav_model.Symbol{
+		IndexID:       w.idx.ID,
+		Hash:          hash,
+		Symbol:        s.Symbol,
+		DisplayName:   s.DisplayName,
+		Documentation: s.Documentation,
+	})
+	if len(w.infos) >= insertBatchSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *indexWriter) flush() error {
+	if len(w.occurrences) > 0 {
+		if w.err = db.Insert(w.ctx, w.occurrences); w.err != nil {
+			return w.err
+		}
+		w.occurrences = w.occurrences[:0]
+	}
+	if len(w.infos) > 0 {
+		if w.err = db.Insert(w.ctx, w.infos); w.err != nil {
+			return w.err
+		}
+		w.infos = w.infos[:0]
+	}
+	return nil
+}
+
+// DeleteIndex deletes the index uploaded for the commit of the repository
+func DeleteIndex(ctx context.Context, repo *repo_model.Repository, commitID string) error {
+	idx, err := codenav_model.GetIndexByCommit(ctx, repo.ID, commitID)
+	if err != nil {
+		return err
+	}
+	return codenav_model.DeleteIndex(ctx, idx)
+}
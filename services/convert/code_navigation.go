@@ -0,0 +1,51 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+	"fmt"
+
+	codenav_model "gitea.dev/models/codenav"
+	repo_model "gitea.dev/models/repo"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	codenav_service "gitea.dev/services/codenav"
+)
+
+// ToCodeNavigationIndex converts a codenav_model.Index to api.CodeNavigationIndex
+func ToCodeNavigationIndex(idx *codenav_model.Index) *api.CodeNavigationIndex {
+	return &api.CodeNavigationIndex{
+		ID:          idx.ID,
+		CommitSHA:   idx.CommitID,
+		Root:        idx.Root,
+		ToolName:    idx.ToolName,
+		ToolVersion: idx.ToolVersion,
+		Documents:   idx.NumDocuments,
+		Created:     idx.CreatedUnix.AsTime(),
+	}
+}
+
+// ToCodeNavigation converts a codenav_service.Navigation in a commit of the repository to api.CodeNavigation
+func ToCodeNavigation(ctx context.Context, repo *repo_model.Repository, commitID string, nav *codenav_service.Navigation) *api.CodeNavigation {
+	toLocations := func(locations []*codenav_service.Location) []*api.CodeNavigationLocation {
+		apiLocations := make([]*api.CodeNavigationLocation, 0, len(locations))
+		for _, loc := range locations {
+			apiLocations = append(apiLocations, &api.CodeNavigationLocation{
+				Path:    loc.Path,
+				Line:    loc.Line,
+				HTMLURL: fmt.Sprintf("%s/src/commit/%s/%s#L%d", repo.HTMLURL(ctx), commitID, util.PathEscapeSegments(loc.Path), loc.Line),
+			})
+		}
+		return apiLocations
+	}
+	return &api.CodeNavigation{
+		Precise:       nav.Precise,
+		Symbol:        nav.Symbol,
+		DisplayName:   nav.DisplayName,
+		Documentation: nav.Documentation,
+		Definitions:   toLocations(nav.Definitions),
+		References:    toLocations(nav.References),
+	}
+}
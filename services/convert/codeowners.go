@@ -0,0 +1,39 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"slices"
+
+	issues_model "gitea.dev/models/issues"
+	api "gitea.dev/modules/structs"
+)
+
+// ToCodeOwnerRule converts a rule of a CODEOWNERS file to api format
+func ToCodeOwnerRule(rule *issues_model.CodeOwnerRule) *api.CodeOwnerRule {
+	return &api.CodeOwnerRule{
+		Line:     rule.Line,
+		Pattern:  rule.Pattern,
+		Negative: rule.Negative,
+		Owners:   slices.Clone(rule.Owners),
+	}
+}
+
+// ToFileCodeOwners converts the CODEOWNERS rules matching a file to api format
+func ToFileCodeOwners(path string, rules []*issues_model.CodeOwnerRule) *api.FileCodeOwners {
+	result := &api.FileCodeOwners{
+		Path:   path,
+		Rules:  make([]*api.CodeOwnerRule, 0, len(rules)),
+		Owners: make([]string, 0),
+	}
+	for _, rule := range rules {
+		result.Rules = append(result.Rules, ToCodeOwnerRule(rule))
+		for _, owner := range rule.Owners {
+			if !slices.Contains(result.Owners, owner) {
+				result.Owners = append(result.Owners, owner)
+			}
+		}
+	}
+	return result
+}
@@ -203,6 +203,8 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
 		BlockAdminMergeOverride:       bp.BlockAdminMergeOverride,
+		EnableMergeQueue:              bp.EnableMergeQueue,
+		MergeQueueMaxGroupSize:        bp.MergeQueueMaxGroupSize,
 		Created:                       bp.CreatedUnix.AsTime(),
 		Updated:                       bp.UpdatedUnix.AsTime(),
 	}
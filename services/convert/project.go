@@ -195,3 +195,74 @@ func ToProjectColumnList(ctx context.Context, columns []*project_model.Column, d
 	}
 	return result
 }
+
+// ToProjectAutomation converts a project automation to API format
+func ToProjectAutomation(a *project_model.Automation) *api.ProjectAutomation {
+	return &api.ProjectAutomation{
+		ID:        a.ID,
+		ProjectID: a.ProjectID,
+		Event:     api.ProjectAutomationEvent(a.Event.String()),
+		LabelID:   a.LabelID,
+		ColumnID:  a.ColumnID,
+		CreatedAt: a.CreatedUnix.AsTime(),
+		UpdatedAt: a.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToProjectAutomationList converts a list of project automations to API format
+func ToProjectAutomationList(automations []*project_model.Automation) []*api.ProjectAutomation {
+	result := make([]*api.ProjectAutomation, len(automations))
+	for i, a := range automations {
+		result[i] = ToProjectAutomation(a)
+	}
+	return result
+}
+
+// ToProjectField converts a project field to API format
+func ToProjectField(f *project_model.Field) *api.ProjectField {
+	options := make([]*api.ProjectFieldOption, 0, len(f.Options))
+	for _, option := range f.Options {
+		options = append(options, &api.ProjectFieldOption{
+			ID:        option.ID,
+			Name:      option.Name,
+			StartDate: option.StartDate,
+			EndDate:   option.EndDate,
+		})
+	}
+	return &api.ProjectField{
+		ID:        f.ID,
+		ProjectID: f.ProjectID,
+		Name:      f.Name,
+		Type:      api.ProjectFieldType(f.Type.String()),
+		Options:   options,
+		CreatedAt: f.CreatedUnix.AsTime(),
+		UpdatedAt: f.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToProjectFieldList converts a list of project fields to API format
+func ToProjectFieldList(fields []*project_model.Field) []*api.ProjectField {
+	result := make([]*api.ProjectField, len(fields))
+	for i, f := range fields {
+		result[i] = ToProjectField(f)
+	}
+	return result
+}
+
+// ToProjectIssueFieldValues converts the field values of an issue to API format, the fields are used to
+// name the chosen options
+func ToProjectIssueFieldValues(fields []*project_model.Field, values []*project_model.FieldValue) []*api.ProjectIssueFieldValue {
+	fieldMap := make(map[int64]*project_model.Field, len(fields))
+	for _, f := range fields {
+		fieldMap[f.ID] = f
+	}
+	result := make([]*api.ProjectIssueFieldValue, 0, len(values))
+	for _, v := range values {
+		apiValue := &api.ProjectIssueFieldValue{FieldID: v.FieldID, Value: v.Value}
+		if f := fieldMap[v.FieldID]; f != nil && f.Type.HasOptions() {
+			apiValue.OptionName = f.DisplayValue(v.Value)
+		}
+		result = append(result, apiValue)
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	issues_model "gitea.dev/models/issues"
+	pull_model "gitea.dev/models/pull"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+)
+
+// ToMergeQueueEntry converts a merge queue entry of the given pull request to api format
+func ToMergeQueueEntry(ctx context.Context, entry *pull_model.MergeQueueEntry, pr *issues_model.PullRequest, position int, doer *user_model.User) (*api.MergeQueueEntry, error) {
+	if err := entry.LoadDoer(ctx); err != nil {
+		return nil, err
+	}
+	return &api.MergeQueueEntry{
+		ID:               entry.ID,
+		PullRequestIndex: pr.Index,
+		Position:         position,
+		BaseBranch:       entry.BaseBranch,
+		Status:           entry.Status.String(),
+		MergeStyle:       string(entry.MergeStyle),
+		Doer:             ToUser(ctx, entry.Doer, doer),
+		BaseCommitID:     entry.BaseCommitID,
+		MergeCommitID:    entry.MergeCommitID,
+		FailReason:       entry.FailReason,
+		Created:          entry.CreatedUnix.AsTime(),
+		Updated:          entry.UpdatedUnix.AsTime(),
+	}, nil
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	git_model "gitea.dev/models/git"
+	api "gitea.dev/modules/structs"
+)
+
+// ToPushRule converts a git_model.PushRule to api.PushRule
+func ToPushRule(rule *git_model.PushRule) *api.PushRule {
+	return &api.PushRule{
+		CommitMessagePattern:       rule.CommitMessagePattern,
+		RequireSignedOffBy:         rule.RequireSignedOffBy,
+		RequireVerifiedAuthorEmail: rule.RequireVerifiedAuthorEmail,
+		MaxBlobSize:                rule.MaxBlobSize,
+		ForbiddenFilePatterns:      rule.ForbiddenFilePatterns,
+		ForbiddenFileExtensions:    rule.ForbiddenFileExtensions,
+		SignedCommitBranchPatterns: rule.SignedCommitBranchPatterns,
+		Created:                    rule.CreatedUnix.AsTime(),
+		Updated:                    rule.UpdatedUnix.AsTime(),
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	quota_model "gitea.dev/models/quota"
+	"gitea.dev/modules/setting"
+	api "gitea.dev/modules/structs"
+)
+
+func toQuotaLimits(l quota_model.Limits) *api.QuotaLimits {
+	return &api.QuotaLimits{
+		Total:       l.Total,
+		Git:         l.Git,
+		LFS:         l.LFS,
+		Attachments: l.Attachments,
+		Artifacts:   l.Artifacts,
+		Packages:    l.Packages,
+	}
+}
+
+// ToQuotaGroup converts a quota_model.Group to api.QuotaGroup
+func ToQuotaGroup(g *quota_model.Group) *api.QuotaGroup {
+	return &api.QuotaGroup{
+		ID:      g.ID,
+		Name:    g.Name,
+		Limits:  toQuotaLimits(g.Limits()),
+		Created: g.CreatedUnix.AsTime(),
+		Updated: g.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToQuotaInfo converts the usage and the limits of an owner to api.QuotaInfo
+func ToQuotaInfo(usage *quota_model.Usage, limits quota_model.Limits, groups []*quota_model.Group) *api.QuotaInfo {
+	groupNames := make([]string, 0, len(groups))
+	for _, g := range groups {
+		groupNames = append(groupNames, g.Name)
+	}
+	return &api.QuotaInfo{
+		Enabled: setting.Quota.Enabled,
+		Used: &api.QuotaUsed{
+			Total:       usage.Total(),
+			Git:         usage.Git,
+			LFS:         usage.LFS,
+			Attachments: usage.Attachments,
+			Artifacts:   usage.Artifacts,
+			Packages:    usage.Packages,
+		},
+		Limits: toQuotaLimits(limits),
+		Groups: groupNames,
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	secretscan_model "gitea.dev/models/secretscan"
+	user_model "gitea.dev/models/user"
+	api "gitea.dev/modules/structs"
+)
+
+// ToSecretScanningAlert converts a secretscan_model.Alert to api.SecretScanningAlert
+func ToSecretScanningAlert(ctx context.Context, alert *secretscan_model.Alert, doer *user_model.User) (*api.SecretScanningAlert, error) {
+	apiAlert := &api.SecretScanningAlert{
+		ID:             alert.ID,
+		RuleID:         alert.RuleID,
+		Description:    alert.Description,
+		RedactedSecret: alert.Redacted,
+		CommitSHA:      alert.CommitID,
+		Path:           alert.Path,
+		Line:           alert.Line,
+		State:          api.SecretScanningAlertState(alert.Status.String()),
+		Created:        alert.CreatedUnix.AsTime(),
+		Updated:        alert.UpdatedUnix.AsTime(),
+	}
+	if alert.ResolverID != 0 {
+		if err := alert.LoadResolver(ctx); err != nil {
+			return nil, err
+		}
+		apiAlert.ResolvedBy = ToUser(ctx, alert.Resolver, doer)
+		resolvedAt := alert.ResolvedUnix.AsTime()
+		apiAlert.ResolvedAt = &resolvedAt
+	}
+	return apiAlert, nil
+}
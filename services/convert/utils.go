@@ -40,6 +40,10 @@ func ToGitServiceType(value string) structs.GitServiceType {
 		return structs.CodebaseService
 	case "codecommit":
 		return structs.CodeCommitService
+	case "bitbucket":
+		return structs.BitbucketService
+	case "azuredevops":
+		return structs.AzureDevOpsService
 	default:
 		return structs.PlainGitService
 	}
@@ -20,6 +20,7 @@ func initActionsTasks() {
 	registerCancelAbandonedJobs()
 	registerScheduleTasks()
 	registerActionsCleanup()
+	registerActionsCacheCleanup()
 }
 
 func registerStopZombieTasks() {
@@ -74,3 +75,13 @@ func registerActionsCleanup() {
 		return actions_service.Cleanup(ctx)
 	})
 }
+
+func registerActionsCacheCleanup() {
+	RegisterTaskFatal("cleanup_actions_cache", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ *BaseConfig) error {
+		return actions_service.CleanupCaches(ctx)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	packages_cleanup_service "gitea.dev/services/packages/cleanup"
 	repo_service "gitea.dev/services/repository"
 	archiver_service "gitea.dev/services/repository/archiver"
+	webhook_service "gitea.dev/services/webhook"
 )
 
 func registerUpdateMirrorTask() {
@@ -136,6 +137,29 @@ func registerCleanupHookTaskTable() {
 	})
 }
 
+func registerCleanupDeadLetterHookTasks() {
+	RegisterTaskFatal("cleanup_hook_task_dead_letters", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		OlderThan: 30 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config *OlderThanConfig) error {
+		return webhook.CleanupDeadLetterHookTasks(ctx, config.OlderThan)
+	})
+}
+
+func registerDeliverHookTaskRetries() {
+	RegisterTaskFatal("deliver_hook_task_retries", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ *BaseConfig) error {
+		return webhook_service.EnqueueDueHookTaskRetries(ctx)
+	})
+}
+
 func registerCleanupPackages() {
 	RegisterTaskFatal("cleanup_packages", &OlderThanConfig{
 		BaseConfig: BaseConfig{
@@ -172,6 +196,8 @@ func initBasicTasks() {
 		registerUpdateMigrationPosterID()
 	}
 	registerCleanupHookTaskTable()
+	registerCleanupDeadLetterHookTasks()
+	registerDeliverHookTaskRetries()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
 	}
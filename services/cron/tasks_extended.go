@@ -17,6 +17,7 @@ import (
 	asymkey_service "gitea.dev/services/asymkey"
 	repo_service "gitea.dev/services/repository"
 	archiver_service "gitea.dev/services/repository/archiver"
+	backup_service "gitea.dev/services/repository/backup"
 	user_service "gitea.dev/services/user"
 )
 
@@ -218,6 +219,32 @@ func registerRebuildIssueIndexer() {
 	})
 }
 
+// RepoBackupsConfig represents the schedule and the retention of the repository backups
+type RepoBackupsConfig struct {
+	BaseConfig
+	KeepBackups        int
+	MaxAge             time.Duration
+	FullBackupInterval int
+}
+
+func registerRepoBackups() {
+	RegisterTaskFatal("repo_backups", &RepoBackupsConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		KeepBackups:        14,
+		FullBackupInterval: 7,
+	}, func(ctx context.Context, _ *user_model.User, config *RepoBackupsConfig) error {
+		return backup_service.BackupRepositories(ctx, backup_service.Options{
+			KeepBackups:        config.KeepBackups,
+			MaxAge:             config.MaxAge,
+			FullBackupInterval: config.FullBackupInterval,
+		})
+	})
+}
+
 func initExtendedTasks() {
 	registerDeleteInactiveUsers()
 	registerDeleteRepositoryArchives()
@@ -233,4 +260,5 @@ func initExtendedTasks() {
 	registerDeleteOldSystemNotices()
 	registerGCLFS()
 	registerRebuildIssueIndexer()
+	registerRepoBackups()
 }
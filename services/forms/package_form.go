@@ -15,7 +15,7 @@ import (
 type PackageCleanupRuleForm struct {
 	ID            int64
 	Enabled       bool
-	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,terraform,vagrant)"`
+	Type          string `binding:"Required;In(alpine,ansible,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,hex,maven,nix,npm,nuget,pub,pypi,rpm,rubygems,swift,terraform,vagrant)"`
 	KeepCount     int    `binding:"In(0,1,5,10,25,50,100)"`
 	KeepPattern   string `binding:"RegexPattern"`
 	RemoveDays    int    `binding:"In(0,7,14,30,60,90,180)"`
@@ -28,3 +28,18 @@ func (f *PackageCleanupRuleForm) Validate(req *http.Request, errs binding.Errors
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(ctx, errs, f)
 }
+
+type PackageUpstreamForm struct {
+	ID       int64
+	Enabled  bool
+	Type     string `binding:"Required;In(container,go,maven,npm,pypi)"`
+	URL      string `binding:"Required;ValidUrl"`
+	Username string `binding:"MaxSize(255)"`
+	Password string
+	Action   string `binding:"Required;In(save,remove)"`
+}
+
+func (f *PackageUpstreamForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(ctx, errs, f)
+}
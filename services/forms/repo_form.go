@@ -200,6 +200,8 @@ type ProtectBranchForm struct {
 	ProtectedFilePatterns         string
 	UnprotectedFilePatterns       string
 	BlockAdminMergeOverride       bool
+	EnableMergeQueue              bool
+	MergeQueueMaxGroupSize        int64
 }
 
 // Validate validates the fields
@@ -407,6 +409,46 @@ func (f *NewPackagistHookForm) Validate(req *http.Request, errs binding.Errors)
 	return middleware.Validate(ctx, errs, f)
 }
 
+// NewCustomHookForm form for creating custom hook
+type NewCustomHookForm struct {
+	PayloadURL      string `binding:"Required;ValidUrl"`
+	HTTPMethod      string `binding:"Required;In(POST,PUT,PATCH)"`
+	PayloadTemplate string
+	ContentType     string
+	Headers         string
+	SignatureScheme string
+	WebhookForm
+}
+
+// Meta returns the metadata of the webhook, an Ed25519 or Standard Webhooks signature gets a new secret if none is set
+func (f *NewCustomHookForm) Meta() (*webhook.CustomMeta, error) {
+	headers, err := webhook.ParseCustomHeaders(f.Headers)
+	if err != nil {
+		return nil, err
+	}
+	if f.Secret == "" {
+		if f.Secret, err = webhook.GenerateCustomSecret(f.SignatureScheme); err != nil {
+			return nil, err
+		}
+	}
+	meta := &webhook.CustomMeta{
+		PayloadTemplate: f.PayloadTemplate,
+		ContentType:     strings.TrimSpace(f.ContentType),
+		Headers:         headers,
+		SignatureScheme: f.SignatureScheme,
+	}
+	return meta, meta.Validate(f.Secret)
+}
+
+// Validate validates the fields
+func (f *NewCustomHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	if _, err := f.Meta(); err != nil {
+		errs = middleware.AddValidationError(errs, "PayloadTemplate", ctx.Locale.TrString("repo.settings.custom_hook.invalid", err.Error()))
+	}
+	return middleware.Validate(ctx, errs, f)
+}
+
 // CreateIssueForm form for creating issue
 type CreateIssueForm struct {
 	Title               string `binding:"Required;MaxSize(255)"`
@@ -475,6 +517,24 @@ type EditProjectColumnForm struct {
 	Color   string `binding:"MaxSize(7)"`
 }
 
+// ProjectAutomationForm is a form for adding a project automation
+type ProjectAutomationForm struct {
+	Event    string `binding:"Required"`
+	LabelID  int64
+	ColumnID int64 `binding:"Required"`
+}
+
+// ProjectViewForm is a form for saving a project view
+type ProjectViewForm struct {
+	Name        string `binding:"Required;MaxSize(255)"`
+	Layout      string `binding:"Required"`
+	Filter      string
+	GroupBy     string
+	SortBy      string
+	SortDesc    bool
+	DateFieldID int64
+}
+
 // CreateMilestoneForm form for creating milestone
 type CreateMilestoneForm struct {
 	Title    string `binding:"Required;MaxSize(50)"`
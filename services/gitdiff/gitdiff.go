@@ -466,7 +466,8 @@ type DiffFile struct {
 	SubmoduleDiffInfo *SubmoduleDiffInfo // IsSubmodule==true, then there must be a SubmoduleDiffInfo
 
 	// will be filled by route handler
-	IsProtected bool
+	IsProtected    bool
+	CodeOwnerRules []*issues_model.CodeOwnerRule // the CODEOWNERS rules matching the file
 
 	// will be filled by SyncUserSpecificDiff
 	IsViewed                  bool // User specific
@@ -0,0 +1,117 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"context"
+	"net/url"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/container"
+	issue_template "gitea.dev/modules/issue/template"
+	"gitea.dev/modules/setting"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+)
+
+// PrepareIssueForm checks the values submitted through the issue form template against the validations of its fields,
+// then renders the content of the new issue from them and keeps them as the form data of the issue.
+// It returns the UUIDs of the attachments uploaded through the file fields of the form.
+func PrepareIssueForm(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, issue *issues_model.Issue, template *api.IssueTemplate, values url.Values) ([]string, error) {
+	formValues, err := issue_template.ParseValues(template, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var uuids []string
+	for _, field := range template.Fields {
+		var names []string
+		switch v := formValues[field.ID].(type) {
+		case string:
+			names = []string{v}
+		case []string:
+			names = v
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		switch field.Type {
+		case api.IssueFormFieldTypeUser:
+			for _, name := range names {
+				if _, err := user_model.GetUserByName(ctx, name); err != nil {
+					if user_model.IsErrUserNotExist(err) {
+						return nil, util.NewInvalidArgumentErrorf("user %q does not exist", name)
+					}
+					return nil, err
+				}
+			}
+		case api.IssueFormFieldTypeLabel:
+			labelNames, err := repoLabelNames(ctx, repo)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				if !labelNames.Contains(name) {
+					return nil, util.NewInvalidArgumentErrorf("label %q does not exist", name)
+				}
+			}
+		case api.IssueFormFieldTypeFile:
+			if !setting.Attachment.Enabled {
+				return nil, util.NewInvalidArgumentErrorf("attachments are disabled")
+			}
+			attachments, err := repo_model.GetAttachmentsByUUIDs(ctx, names)
+			if err != nil {
+				return nil, err
+			}
+			uploaded := make(container.Set[string], len(attachments))
+			for _, attachment := range attachments {
+				// only the files uploaded by the doer which are not bound to another issue or release can be used
+				if attachment.RepoID == repo.ID && attachment.UploaderID == doer.ID && attachment.IssueID == 0 && attachment.ReleaseID == 0 {
+					uploaded.Add(attachment.UUID)
+				}
+			}
+			for _, uuid := range names {
+				if !uploaded.Contains(uuid) {
+					return nil, util.NewInvalidArgumentErrorf("attachment %q does not exist", uuid)
+				}
+			}
+			uuids = append(uuids, names...)
+		}
+	}
+
+	issue.Content = issue_template.RenderToMarkdown(template, values)
+	issue.FormData = &issues_model.IssueFormData{
+		TemplateFile: template.FileName,
+		Values:       formValues,
+	}
+	return uuids, nil
+}
+
+// repoLabelNames returns the names of the labels which can be used by the issues of the repository
+func repoLabelNames(ctx context.Context, repo *repo_model.Repository) (container.Set[string], error) {
+	labels, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	if repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, repo.OwnerID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, orgLabels...)
+	}
+
+	names := make(container.Set[string], len(labels))
+	for _, label := range labels {
+		names.Add(label.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"net/url"
+	"testing"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	issue_template "gitea.dev/modules/issue/template"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareIssueForm(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	template, err := issue_template.Unmarshal(".gitea/ISSUE_TEMPLATE/bug.yaml", []byte(`
+name: Bug
+about: Report a bug
+body:
+  - type: user
+    id: owner
+    attributes:
+      label: Owner
+  - type: label
+    id: area
+    attributes:
+      label: Area
+  - type: file
+    id: logs
+    attributes:
+      label: Logs
+`))
+	require.NoError(t, err)
+
+	attachment := &repo_model.Attachment{UUID: "c0ffee00-0000-4000-8000-000000000001", RepoID: repo.ID, UploaderID: doer.ID, Name: "logs.txt"}
+	require.NoError(t, db.Insert(t.Context(), attachment))
+
+	issue := &issues_model.Issue{RepoID: repo.ID, Repo: repo, Title: "form", PosterID: doer.ID, Poster: doer}
+	uuids, err := PrepareIssueForm(t.Context(), repo, doer, issue, template, url.Values{
+		"form-field-owner": {"user4"},
+		"form-field-area":  {"label1"},
+		"form-field-logs":  {attachment.UUID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{attachment.UUID}, uuids)
+	assert.Contains(t, issue.Content, "@user4")
+	require.NoError(t, NewIssue(t.Context(), repo, issue, nil, uuids, nil, nil))
+
+	data, err := issues_model.GetIssueFormData(t.Context(), issue.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ".gitea/ISSUE_TEMPLATE/bug.yaml", data.TemplateFile)
+	assert.Equal(t, map[string]any{"owner": "user4", "area": "label1", "logs": []any{attachment.UUID}}, data.Values)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: attachment.ID, IssueID: issue.ID})
+
+	for _, values := range []url.Values{
+		{"form-field-owner": {"no-such-user"}},
+		{"form-field-area": {"no-such-label"}},
+		{"form-field-logs": {attachment.UUID}}, // already bound to the issue
+	} {
+		_, err := PrepareIssueForm(t.Context(), repo, doer, &issues_model.Issue{}, template, values)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, values)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/optional"
 	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
 )
 
 type ReviewRequestNotifier struct {
@@ -36,8 +37,93 @@ func IsCodeOwnerFile(f string) bool {
 	return slices.Contains(codeOwnerFiles, f)
 }
 
-// Get all code owner rules for a given pr + repo combination.
-func getCodeOwnerRules(ctx context.Context, repo *git.Repository, pr *issues_model.PullRequest) ([]*issues_model.CodeOwnerRule, error) {
+// CodeOwners is the parsed CODEOWNERS file of a commit
+type CodeOwners struct {
+	Path     string
+	Rules    []*issues_model.CodeOwnerRule
+	Warnings []*issues_model.CodeOwnerWarning
+}
+
+// ErrCodeOwnersTooLarge represents a CODEOWNERS file which is too large to be read
+type ErrCodeOwnersTooLarge struct {
+	Path string
+}
+
+func (err ErrCodeOwnersTooLarge) Error() string {
+	return fmt.Sprintf("CODEOWNERS file %q exceeds the maximum readable size of %d bytes", err.Path, setting.UI.MaxDisplayFileSize)
+}
+
+func (err ErrCodeOwnersTooLarge) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// GetCodeOwners reads and parses the CODEOWNERS file of a commit, it returns nil if the commit has none
+func GetCodeOwners(ctx context.Context, repo *git.Repository, commit *git.Commit) (*CodeOwners, error) {
+	for _, file := range codeOwnerFiles {
+		blob, err := commit.GetBlobByPath(ctx, repo, file)
+		if err != nil {
+			continue // no CODEOWNERS at this path, try the next candidate
+		}
+		// A truncated CODEOWNERS would silently drop rules, so fail closed rather
+		// than evaluate an incomplete file (the gate must not under-enforce).
+		if blob.Size(ctx) > setting.UI.MaxDisplayFileSize {
+			return nil, ErrCodeOwnersTooLarge{Path: file}
+		}
+		// The file exists but is unreadable: propagate instead of swallowing, so
+		// callers can fail closed instead of treating it as "no code owners".
+		data, err := blob.GetBlobContent(ctx, setting.UI.MaxDisplayFileSize)
+		if err != nil {
+			return nil, err
+		}
+		rules, warnings := issues_model.ParseCodeOwners(ctx, data)
+		return &CodeOwners{Path: file, Rules: rules, Warnings: warnings}, nil
+	}
+	return nil, nil
+}
+
+// MatchFiles returns the rules which apply to each of the files. The returned complete flag
+// is false when matching was cut short by the match budget, the result is partial then.
+func (c *CodeOwners) MatchFiles(files []string) (matches map[string][]*issues_model.CodeOwnerRule, complete bool) {
+	matches = make(map[string][]*issues_model.CodeOwnerRule, len(files))
+	// Bound the total time spent matching rules×files. The per-rule MatchTimeout
+	// only caps a single match; without an aggregate budget a crafted CODEOWNERS
+	// plus a PR touching many files could still exhaust CPU inside this loop.
+	matchDeadline := time.Now().Add(codeOwnerMatchBudget)
+	for _, f := range files {
+		for _, rule := range c.Rules {
+			if time.Now().After(matchDeadline) {
+				return matches, false
+			}
+			if rule.MatchFile(f) {
+				matches[f] = append(matches[f], rule)
+			}
+		}
+	}
+	return matches, true
+}
+
+// MatchingRules returns the rules which apply to any of the files, in the order of the file. Unlike MatchFiles
+// a rule isn't matched against the remaining files once it applies to one, this is used to enforce the code owner reviews.
+// The returned complete flag is false when matching was cut short by the match budget, the result is partial then.
+func (c *CodeOwners) MatchingRules(files []string) (rules []*issues_model.CodeOwnerRule, complete bool) {
+	matchDeadline := time.Now().Add(codeOwnerMatchBudget)
+	for _, rule := range c.Rules {
+		for _, f := range files {
+			if time.Now().After(matchDeadline) {
+				return rules, false
+			}
+			if rule.MatchFile(f) {
+				rules = append(rules, rule)
+				break
+			}
+		}
+	}
+	return rules, true
+}
+
+// GetPullRequestCodeOwners returns the CODEOWNERS file of the base branch of a pull request, which decides
+// who owns the files changed by the pull request. It returns nil if there are no code owners to review it.
+func GetPullRequestCodeOwners(ctx context.Context, repo *git.Repository, pr *issues_model.PullRequest) (*CodeOwners, error) {
 	if err := pr.LoadBaseRepo(ctx); err != nil {
 		return nil, err
 	}
@@ -57,89 +143,68 @@ func getCodeOwnerRules(ctx context.Context, repo *git.Repository, pr *issues_mod
 		return nil, err
 	}
 
-	var data string
-
-	for _, file := range codeOwnerFiles {
-		blob, err := commit.GetBlobByPath(ctx, repo, file)
-		if err != nil {
-			continue // no CODEOWNERS at this path, try the next candidate
-		}
-		// A truncated CODEOWNERS would silently drop rules, so fail closed rather
-		// than evaluate an incomplete file (the gate must not under-enforce).
-		if blob.Size(ctx) > setting.UI.MaxDisplayFileSize {
-			return nil, fmt.Errorf("CODEOWNERS file %q exceeds the maximum readable size", file)
-		}
-		// The file exists but is unreadable: propagate instead of swallowing, so
-		// callers can fail closed instead of treating it as "no code owners".
-		data, err = blob.GetBlobContent(ctx, setting.UI.MaxDisplayFileSize)
-		if err != nil {
-			return nil, err
-		}
-		break
+	codeOwners, err := GetCodeOwners(ctx, repo, commit)
+	if err != nil {
+		return nil, err
 	}
 
 	// no code owner file = no one to approve
-	if data == "" {
+	if codeOwners == nil {
 		return nil, nil
 	}
 
-	rules, warnings := issues_model.GetCodeOwnersFromContent(ctx, data)
-	for _, w := range warnings {
-		log.Warn("CODEOWNERS parsing for PR %s#%d: %s", pr.BaseRepo.FullName(), pr.ID, w)
+	// the warnings are reported by the validation API, this is called for every view of the files of the pull request
+	for _, w := range codeOwners.Warnings {
+		log.Debug("CODEOWNERS parsing for PR %s#%d: %s", pr.BaseRepo.FullName(), pr.ID, w)
 	}
-	if len(rules) == 0 {
+	if len(codeOwners.Rules) == 0 {
 		return nil, nil
 	}
 
-	return rules, nil
+	return codeOwners, nil
+}
+
+// GetPullRequestChangedFiles returns the files changed by a pull request since its merge base
+func GetPullRequestChangedFiles(ctx context.Context, repo *git.Repository, pr *issues_model.PullRequest) ([]string, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	// the base branch contains the head of a merged pull request, its merge base was recorded when it was merged
+	mergeBase := pr.MergeBase
+	if !pr.HasMerged {
+		var err error
+		mergeBase, err = git.MergeBase(ctx, pr.BaseRepo, git.BranchPrefix+pr.BaseBranch, pr.GetGitHeadRefName())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// https://github.com/go-gitea/gitea/issues/29763, we need to get the files changed
+	// between the merge base and the head commit but not the base branch and the head commit
+	return repo.GetFilesChangedBetween(ctx, mergeBase, pr.GetGitHeadRefName())
 }
 
 // Get the matching code owner rules for a given pr + repo combination. The returned
 // complete flag is false when rule matching was cut short by the match budget, so
 // the returned slice is only a partial set of the matching rules.
 func getMatchingCodeOwnerRules(ctx context.Context, repo *git.Repository, pr *issues_model.PullRequest) (matchingRules []*issues_model.CodeOwnerRule, complete bool, err error) {
-	rules, err := getCodeOwnerRules(ctx, repo, pr)
+	codeOwners, err := GetPullRequestCodeOwners(ctx, repo, pr)
 	if err != nil {
 		return nil, false, err
 	}
-	if len(rules) == 0 {
+	if codeOwners == nil {
 		return nil, true, nil
 	}
 
-	// get the mergebase
-	mergeBase, err := git.MergeBase(ctx, pr.BaseRepo, git.BranchPrefix+pr.BaseBranch, pr.GetGitHeadRefName())
-	if err != nil {
-		return nil, false, err
-	}
-
-	// https://github.com/go-gitea/gitea/issues/29763, we need to get the files changed
-	// between the merge base and the head commit but not the base branch and the head commit
-	changedFiles, err := repo.GetFilesChangedBetween(ctx, mergeBase, pr.GetGitHeadRefName())
+	changedFiles, err := GetPullRequestChangedFiles(ctx, repo, pr)
 	if err != nil {
 		return nil, false, err
 	}
 
-	matchingRules = make([]*issues_model.CodeOwnerRule, 0)
-	complete = true
-
-	// Bound the total time spent matching rules×files. The per-rule MatchTimeout
-	// only caps a single match; without an aggregate budget a crafted CODEOWNERS
-	// plus a PR touching many files could still exhaust CPU inside this loop.
-	matchDeadline := time.Now().Add(codeOwnerMatchBudget)
-ruleLoop:
-	for _, rule := range rules {
-		for _, f := range changedFiles {
-			if time.Now().After(matchDeadline) {
-				log.Warn("CODEOWNERS matching for PR %s#%d exceeded its time budget; some rules were not evaluated", pr.BaseRepo.FullName(), pr.ID)
-				complete = false
-				break ruleLoop
-			}
-			matched, _ := rule.Rule.MatchString(f) // err only happens when timeouts, any error can be considered as not matched
-			if matched != rule.Negative {
-				matchingRules = append(matchingRules, rule)
-				break
-			}
-		}
+	matchingRules, complete = codeOwners.MatchingRules(changedFiles)
+	if !complete {
+		log.Warn("CODEOWNERS matching for PR %s#%d exceeded its time budget; some rules were not evaluated", pr.BaseRepo.FullName(), pr.ID)
 	}
 
 	return matchingRules, complete, nil
@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issue
+
+import (
+	"testing"
+
+	issues_model "gitea.dev/models/issues"
+
+	"github.com/dlclark/regexp2/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOwnersMatching(t *testing.T) {
+	newRule := func(pattern, expr string) *issues_model.CodeOwnerRule {
+		return &issues_model.CodeOwnerRule{Pattern: pattern, Rule: regexp2.MustCompile(expr, regexp2.None)}
+	}
+	goRule := newRule("*.go", `^.*\.go$`)
+	docsRule := newRule("docs/", `^docs/.*$`)
+	webRule := newRule("web_src/", `^web_src/.*$`)
+	codeOwners := &CodeOwners{Rules: []*issues_model.CodeOwnerRule{goRule, docsRule, webRule}}
+	files := []string{"main.go", "docs/index.md", "docs/gen.go"}
+
+	matches, complete := codeOwners.MatchFiles(files)
+	assert.True(t, complete)
+	assert.Equal(t, map[string][]*issues_model.CodeOwnerRule{
+		"main.go":       {goRule},
+		"docs/index.md": {docsRule},
+		"docs/gen.go":   {goRule, docsRule},
+	}, matches)
+
+	rules, complete := codeOwners.MatchingRules(files)
+	assert.True(t, complete)
+	assert.Equal(t, []*issues_model.CodeOwnerRule{goRule, docsRule}, rules)
+}
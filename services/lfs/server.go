@@ -23,6 +23,7 @@ import (
 	git_model "gitea.dev/models/git"
 	perm_model "gitea.dev/models/perm"
 	access_model "gitea.dev/models/perm/access"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	"gitea.dev/models/unit"
 	user_model "gitea.dev/models/user"
@@ -221,6 +222,18 @@ func BatchHandler(ctx *context.Context) {
 	contentStore := lfs_module.NewContentStore()
 
 	var responseObjects []*lfs_module.ObjectResponse
+	var uploadSize int64
+	var quotaChecker *quota_model.Checker
+	if isUpload {
+		// the usage is computed once, the objects of the batch are uploaded together so their sizes are added up
+		var err error
+		quotaChecker, err = quota_model.NewChecker(ctx, repository.OwnerID, quota_model.SubjectLFS)
+		if err != nil {
+			log.Error("Unable to check the LFS quota of %s/%s. Error: %v", rc.User, rc.Repo, err)
+			writeStatus(ctx, http.StatusInternalServerError)
+			return
+		}
+	}
 
 	for _, p := range br.Objects {
 		if !p.IsValid() {
@@ -272,6 +285,15 @@ func BatchHandler(ctx *context.Context) {
 					Code:    http.StatusUnprocessableEntity,
 					Message: fmt.Sprintf("Size must be less than or equal to %d", setting.LFS.MaxFileSize),
 				}
+			} else if !exists {
+				if quotaChecker.Check(uploadSize+p.Size) != nil {
+					err = &lfs_module.ObjectError{
+						Code:    http.StatusRequestEntityTooLarge,
+						Message: "The upload exceeds the storage quota of the repository owner",
+					}
+				} else {
+					uploadSize += p.Size
+				}
 			}
 
 			responseObject = buildObjectResponse(rc, p, false, !exists, err)
@@ -320,6 +342,16 @@ func UploadHandler(ctx *context.Context) {
 		return
 	}
 
+	if err := quota_model.Check(ctx, repository.OwnerID, quota_model.SubjectLFS, p.Size); err != nil {
+		if quota_model.IsErrQuotaExceeded(err) {
+			writeStatusMessage(ctx, http.StatusRequestEntityTooLarge, "The upload exceeds the storage quota of the repository owner")
+		} else {
+			log.Error("Unable to check the LFS quota of %s/%s. Error: %v", rc.User, rc.Repo, err)
+			writeStatus(ctx, http.StatusInternalServerError)
+		}
+		return
+	}
+
 	uploadOrVerify := func() error {
 		contentStore := lfs_module.NewContentStore()
 		stat, err := contentStore.Stat(p)
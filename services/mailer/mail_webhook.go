@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+
+	user_model "gitea.dev/models/user"
+	webhook_model "gitea.dev/models/webhook"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/templates"
+	"gitea.dev/modules/translation"
+	sender_service "gitea.dev/services/mailer/sender"
+)
+
+const mailWebhookDisabled templates.TplName = "webhook/disabled"
+
+// SendWebhookDisabledMail notifies the admins of a webhook that it has been disabled after too many failed deliveries
+func SendWebhookDisabledMail(w *webhook_model.Webhook, target, link string, recipients []*user_model.User) {
+	if setting.MailService == nil {
+		return
+	}
+
+	langMap := make(map[string][]*user_model.User)
+	for _, u := range recipients {
+		if u.IsActive && !u.ProhibitLogin {
+			langMap[u.Language] = append(langMap[u.Language], u)
+		}
+	}
+
+	for lang, tos := range langMap {
+		locale := translation.NewLocale(lang)
+		subject := locale.TrString("mail.webhook.disabled.subject", target)
+		data := map[string]any{
+			"locale":       locale,
+			"Subject":      subject,
+			"URL":          w.URL,
+			"Target":       target,
+			"FailureCount": w.FailureCount,
+			"Link":         link,
+			"Language":     locale.Language(),
+		}
+
+		var content bytes.Buffer
+		if err := LoadedTemplates().BodyTemplates.ExecuteTemplate(&content, string(mailWebhookDisabled), data); err != nil {
+			log.Error("Template: %v", err)
+			return
+		}
+
+		for _, to := range tos {
+			msg := sender_service.NewMessage(to.EmailTo(), subject, content.String())
+			msg.Info = fmt.Sprintf("UID: %d, webhook %d disabled", to.ID, w.ID)
+			SendAsync(msg)
+		}
+	}
+}
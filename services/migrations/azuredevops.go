@@ -0,0 +1,770 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+	"gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+)
+
+var (
+	_ base.Downloader        = &AzureDevOpsDownloader{}
+	_ base.DownloaderFactory = &AzureDevOpsDownloaderFactory{}
+)
+
+func init() {
+	RegisterDownloaderFactory(&AzureDevOpsDownloaderFactory{})
+}
+
+// AzureDevOpsDownloaderFactory defines an Azure DevOps downloader factory
+type AzureDevOpsDownloaderFactory struct{}
+
+// New returns a Downloader related to this factory according MigrateOptions
+func (f *AzureDevOpsDownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	u, err := url.Parse(opts.CloneAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// the clone URLs look like "https://dev.azure.com/<org>/<project>/_git/<repo>",
+	// "https://<org>.visualstudio.com/<project>/_git/<repo>" or "https://host/<collection>/<project>/_git/<repo>"
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	i := slices.Index(fields, "_git")
+	if i < 0 || i+1 >= len(fields) {
+		return nil, fmt.Errorf("invalid path: %s", u.Path)
+	}
+	repoName := strings.TrimSuffix(fields[i+1], ".git")
+
+	orgFields := 0
+	if strings.EqualFold(u.Host, "dev.azure.com") {
+		orgFields = 1
+	}
+	var project string
+	if i <= orgFields {
+		// the project is omitted from the URL when it has the same name as the repository
+		project, fields = repoName, fields[:i]
+	} else {
+		project, fields = fields[i-1], fields[:i-1]
+	}
+	baseURL := u.Scheme + "://" + u.Host + strings.TrimSuffix("/"+strings.Join(fields, "/"), "/")
+
+	log.Trace("Create Azure DevOps downloader. BaseURL: %s Project: %s RepoName: %s", baseURL, project, repoName)
+	return NewAzureDevOpsDownloader(ctx, baseURL, opts.AuthUsername, opts.AuthPassword, opts.AuthToken, project, repoName), nil
+}
+
+// GitServiceType returns the type of git service
+func (f *AzureDevOpsDownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.AzureDevOpsService
+}
+
+const azureDevOpsAPIVersion = "7.1"
+
+type azureDevOpsUser struct {
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+// Email returns the email address of the user, the unique names of the users of an Active Directory are not email addresses
+func (u *azureDevOpsUser) Email() string {
+	if u == nil || !strings.Contains(u.UniqueName, "@") {
+		return ""
+	}
+	return u.UniqueName
+}
+
+func (u *azureDevOpsUser) Name() string {
+	if u == nil {
+		return ""
+	}
+	return u.DisplayName
+}
+
+type azureDevOpsIssueContext struct {
+	IsPullRequest bool
+}
+
+// AzureDevOpsDownloader implements a Downloader interface to get repository information
+// from Azure DevOps Services or Azure DevOps Server. The work items of Azure Boards are
+// migrated as issues, they belong to the project so all of them are migrated with each of its repositories.
+type AzureDevOpsDownloader struct {
+	base.NullDownloader
+	client        *http.Client
+	baseURL       string
+	project       string
+	repoName      string
+	workItemIDs   []int64
+	maxIssueIndex int64
+	reviewers     map[int64][]*azureDevOpsReviewer
+}
+
+// NewAzureDevOpsDownloader creates an Azure DevOps downloader, baseURL is the URL of the organization or of the collection
+func NewAzureDevOpsDownloader(ctx context.Context, baseURL, username, password, token, project, repoName string) *AzureDevOpsDownloader {
+	httpTransport := NewMigrationHTTPTransport()
+	return &AzureDevOpsDownloader{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		project:  project,
+		repoName: repoName,
+		client: &http.Client{
+			Transport: roundTripperFunc(
+				func(req *http.Request) (*http.Response, error) {
+					// personal access tokens are sent as the password of any user
+					if token != "" {
+						req.SetBasicAuth("", token)
+					} else if username != "" && password != "" {
+						req.SetBasicAuth(username, password)
+					}
+					return httpTransport.RoundTrip(req.WithContext(ctx))
+				}),
+		},
+		reviewers: make(map[int64][]*azureDevOpsReviewer),
+	}
+}
+
+// String implements Stringer
+func (d *AzureDevOpsDownloader) String() string {
+	return fmt.Sprintf("migration from azure devops %s %s/%s", d.baseURL, d.project, d.repoName)
+}
+
+func (d *AzureDevOpsDownloader) LogString() string {
+	if d == nil {
+		return "<AzureDevOpsDownloader nil>"
+	}
+	return fmt.Sprintf("<AzureDevOpsDownloader %s %s/%s>", d.baseURL, d.project, d.repoName)
+}
+
+func (d *AzureDevOpsDownloader) callAPI(ctx context.Context, method, endpoint string, parameter url.Values, body, result any) error {
+	u, err := url.Parse(d.baseURL + "/" + url.PathEscape(d.project) + "/_apis" + endpoint)
+	if err != nil {
+		return err
+	}
+	if parameter == nil {
+		parameter = url.Values{}
+	}
+	if !parameter.Has("api-version") {
+		parameter.Set("api-version", azureDevOpsAPIVersion)
+	}
+	u.RawQuery = parameter.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%s: %w", u.Path, util.ErrNotExist)
+	case resp.StatusCode != http.StatusOK:
+		// the API redirects to a sign in page instead of failing when the credentials are invalid
+		return fmt.Errorf("unexpected status %q for %s", resp.Status, u.Path)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (d *AzureDevOpsDownloader) repoEndpoint(format string, args ...any) string {
+	return "/git/repositories/" + url.PathEscape(d.repoName) + fmt.Sprintf(format, args...)
+}
+
+// GetRepoInfo returns repository information
+func (d *AzureDevOpsDownloader) GetRepoInfo(ctx context.Context) (*base.Repository, error) {
+	var repo struct {
+		Name          string `json:"name"`
+		DefaultBranch string `json:"defaultBranch"`
+		RemoteURL     string `json:"remoteUrl"`
+		WebURL        string `json:"webUrl"`
+		Project       struct {
+			Description string `json:"description"`
+			Visibility  string `json:"visibility"`
+		} `json:"project"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint(""), nil, nil, &repo); err != nil {
+		return nil, err
+	}
+
+	cloneURL, err := url.Parse(repo.RemoteURL)
+	if err != nil {
+		return nil, err
+	}
+	cloneURL.User = nil
+
+	return &base.Repository{
+		Name:          repo.Name,
+		Owner:         d.project,
+		IsPrivate:     repo.Project.Visibility != "public",
+		Description:   repo.Project.Description,
+		CloneURL:      cloneURL.String(),
+		OriginalURL:   repo.WebURL,
+		DefaultBranch: strings.TrimPrefix(repo.DefaultBranch, "refs/heads/"),
+	}, nil
+}
+
+// GetTopics return repository topics, Azure DevOps has none
+func (d *AzureDevOpsDownloader) GetTopics(_ context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+type azureDevOpsIteration struct {
+	Name       string `json:"name"`
+	Attributes *struct {
+		StartDate  *time.Time `json:"startDate"`
+		FinishDate *time.Time `json:"finishDate"`
+	} `json:"attributes"`
+	Children []*azureDevOpsIteration `json:"children"`
+}
+
+// GetMilestones returns the iterations of the project as milestones, they are named after their
+// iteration path without the name of the project, like "Release 1\Sprint 1"
+func (d *AzureDevOpsDownloader) GetMilestones(ctx context.Context) ([]*base.Milestone, error) {
+	var root azureDevOpsIteration
+	if err := d.callAPI(ctx, http.MethodGet, "/wit/classificationnodes/Iterations", url.Values{
+		"$depth": {"10"},
+	}, nil, &root); err != nil {
+		return nil, err
+	}
+
+	var milestones []*base.Milestone
+	var walk func(parent string, iterations []*azureDevOpsIteration)
+	walk = func(parent string, iterations []*azureDevOpsIteration) {
+		for _, iteration := range iterations {
+			milestone := &base.Milestone{
+				Title: parent + iteration.Name,
+				State: "open",
+			}
+			if iteration.Attributes != nil && iteration.Attributes.FinishDate != nil {
+				milestone.Deadline = iteration.Attributes.FinishDate
+				if iteration.Attributes.FinishDate.Before(time.Now()) {
+					milestone.State = "closed"
+					milestone.Closed = iteration.Attributes.FinishDate
+				}
+			}
+			if iteration.Attributes != nil && iteration.Attributes.StartDate != nil {
+				milestone.Created = *iteration.Attributes.StartDate
+			}
+			milestones = append(milestones, milestone)
+			walk(milestone.Title+`\`, iteration.Children)
+		}
+	}
+	walk("", root.Children)
+	return milestones, nil
+}
+
+// GetLabels returns labels, the tags of the work items are migrated as labels and their types as scoped labels
+func (d *AzureDevOpsDownloader) GetLabels(ctx context.Context) ([]*base.Label, error) {
+	var types struct {
+		Value []struct {
+			Name       string `json:"name"`
+			Color      string `json:"color"`
+			IsDisabled bool   `json:"isDisabled"`
+		} `json:"value"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, "/wit/workitemtypes", nil, nil, &types); err != nil {
+		return nil, err
+	}
+	var tags struct {
+		Value []struct {
+			Name string `json:"name"`
+		} `json:"value"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, "/wit/tags", url.Values{
+		"api-version": {azureDevOpsAPIVersion + "-preview.1"},
+	}, nil, &tags); err != nil {
+		return nil, err
+	}
+
+	labels := make([]*base.Label, 0, len(types.Value)+len(tags.Value))
+	for _, tp := range types.Value {
+		if tp.IsDisabled {
+			continue
+		}
+		color := strings.ToLower(tp.Color)
+		if len(color) == 8 { // ARGB
+			color = color[2:]
+		}
+		labels = append(labels, &base.Label{Name: "type/" + tp.Name, Color: color, Exclusive: true})
+	}
+	for _, tag := range tags.Value {
+		labels = append(labels, &base.Label{Name: tag.Name, Color: "cccccc"})
+	}
+	return labels, nil
+}
+
+// listWorkItemIDs returns the ids of all the work items of the project, a WIQL query
+// returns at most 20000 items so only the first ones of bigger projects are migrated
+func (d *AzureDevOpsDownloader) listWorkItemIDs(ctx context.Context) ([]int64, error) {
+	var result struct {
+		WorkItems []struct {
+			ID int64 `json:"id"`
+		} `json:"workItems"`
+	}
+	if err := d.callAPI(ctx, http.MethodPost, "/wit/wiql", nil, map[string]string{
+		"query": "SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project ORDER BY [System.Id]",
+	}, &result); err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(result.WorkItems))
+	for _, item := range result.WorkItems {
+		ids = append(ids, item.ID)
+	}
+	if len(ids) == 20000 {
+		log.Warn("Only the first 20000 work items of %s are migrated", d)
+	}
+	return ids, nil
+}
+
+var azureDevOpsClosedStates = []string{"Closed", "Done", "Removed", "Resolved"}
+
+// GetIssues returns the work items according page and perPage
+func (d *AzureDevOpsDownloader) GetIssues(ctx context.Context, page, perPage int) ([]*base.Issue, bool, error) {
+	if d.workItemIDs == nil {
+		ids, err := d.listWorkItemIDs(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		d.workItemIDs = ids
+		if len(ids) > 0 {
+			d.maxIssueIndex = slices.Max(ids)
+		}
+	}
+	// the ids of at most 200 work items can be requested at once
+	perPage = min(perPage, 200)
+	start := (page - 1) * perPage
+	if start >= len(d.workItemIDs) {
+		return nil, true, nil
+	}
+	ids := d.workItemIDs[start:min(start+perPage, len(d.workItemIDs))]
+
+	idStrings := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrings = append(idStrings, strconv.FormatInt(id, 10))
+	}
+	var result struct {
+		Value []struct {
+			ID     int64 `json:"id"`
+			Fields struct {
+				Title         string           `json:"System.Title"`
+				Description   string           `json:"System.Description"`
+				ReproSteps    string           `json:"Microsoft.VSTS.TCM.ReproSteps"`
+				State         string           `json:"System.State"`
+				WorkItemType  string           `json:"System.WorkItemType"`
+				Tags          string           `json:"System.Tags"`
+				IterationPath string           `json:"System.IterationPath"`
+				CreatedBy     *azureDevOpsUser `json:"System.CreatedBy"`
+				AssignedTo    *azureDevOpsUser `json:"System.AssignedTo"`
+				CreatedDate   time.Time        `json:"System.CreatedDate"`
+				ChangedDate   time.Time        `json:"System.ChangedDate"`
+				ClosedDate    *time.Time       `json:"Microsoft.VSTS.Common.ClosedDate"`
+			} `json:"fields"`
+			Relations []struct {
+				Rel string `json:"rel"`
+				URL string `json:"url"`
+			} `json:"relations"`
+		} `json:"value"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, "/wit/workitems", url.Values{
+		"ids":     {strings.Join(idStrings, ",")},
+		"$expand": {"relations"},
+	}, nil, &result); err != nil {
+		return nil, false, err
+	}
+
+	issues := make([]*base.Issue, 0, len(result.Value))
+	for _, item := range result.Value {
+		fields := &item.Fields
+
+		labels := []*base.Label{{Name: "type/" + fields.WorkItemType}}
+		for tag := range strings.SplitSeq(fields.Tags, ";") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				labels = append(labels, &base.Label{Name: tag})
+			}
+		}
+
+		// the iteration path of the work items which are not planned is the name of the project
+		milestone, _ := strings.CutPrefix(fields.IterationPath, d.project+`\`)
+		if milestone == fields.IterationPath {
+			milestone = ""
+		}
+
+		content := fields.Description
+		if content == "" {
+			content = fields.ReproSteps
+		}
+
+		state := "open"
+		var closed *time.Time
+		if slices.Contains(azureDevOpsClosedStates, fields.State) {
+			state = "closed"
+			closed = fields.ClosedDate
+			if closed == nil {
+				closed = &fields.ChangedDate
+			}
+		}
+
+		var assignees []string
+		if fields.AssignedTo != nil {
+			assignees = []string{fields.AssignedTo.Name()}
+		}
+
+		// a parent work item can only be closed once its children are closed
+		var dependencies []int64
+		for _, relation := range item.Relations {
+			if relation.Rel != "System.LinkTypes.Hierarchy-Forward" {
+				continue
+			}
+			if child, err := strconv.ParseInt(path.Base(relation.URL), 10, 64); err == nil {
+				dependencies = append(dependencies, child)
+			}
+		}
+
+		issues = append(issues, &base.Issue{
+			Number:       item.ID,
+			PosterName:   fields.CreatedBy.Name(),
+			PosterEmail:  fields.CreatedBy.Email(),
+			Title:        fields.Title,
+			Content:      content,
+			Milestone:    milestone,
+			State:        state,
+			Created:      fields.CreatedDate,
+			Updated:      fields.ChangedDate,
+			Closed:       closed,
+			Labels:       labels,
+			Assignees:    assignees,
+			Dependencies: dependencies,
+			ForeignIndex: item.ID,
+			Context:      azureDevOpsIssueContext{IsPullRequest: false},
+		})
+	}
+
+	return issues, start+perPage >= len(d.workItemIDs), nil
+}
+
+type azureDevOpsThread struct {
+	ID            int64 `json:"id"`
+	IsDeleted     bool  `json:"isDeleted"`
+	ThreadContext *struct {
+		FilePath      string `json:"filePath"`
+		LeftFileStart *struct {
+			Line int `json:"line"`
+		} `json:"leftFileStart"`
+		RightFileStart *struct {
+			Line int `json:"line"`
+		} `json:"rightFileStart"`
+	} `json:"threadContext"`
+	Comments []*struct {
+		ID              int64            `json:"id"`
+		ParentCommentID int64            `json:"parentCommentId"`
+		Author          *azureDevOpsUser `json:"author"`
+		Content         string           `json:"content"`
+		PublishedDate   time.Time        `json:"publishedDate"`
+		LastUpdatedDate time.Time        `json:"lastUpdatedDate"`
+		CommentType     string           `json:"commentType"` // Possible values: text, codeChange, system
+		IsDeleted       bool             `json:"isDeleted"`
+	} `json:"comments"`
+}
+
+func (d *AzureDevOpsDownloader) listThreads(ctx context.Context, index int64) ([]*azureDevOpsThread, error) {
+	var result struct {
+		Value []*azureDevOpsThread `json:"value"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint("/pullRequests/%d/threads", index), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// GetComments returns the comments of a work item, or the comments of a pull request which are not on its files
+func (d *AzureDevOpsDownloader) GetComments(ctx context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	context, ok := commentable.GetContext().(azureDevOpsIssueContext)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected context: %+v", commentable.GetContext())
+	}
+
+	var comments []*base.Comment
+	if context.IsPullRequest {
+		threads, err := d.listThreads(ctx, commentable.GetForeignIndex())
+		if err != nil {
+			return nil, false, err
+		}
+		for _, thread := range threads {
+			if thread.IsDeleted || thread.ThreadContext != nil {
+				continue
+			}
+			for _, comment := range thread.Comments {
+				// the votes and the updates of the pull request are recorded as system comments
+				if comment.IsDeleted || comment.CommentType != "text" {
+					continue
+				}
+				comments = append(comments, &base.Comment{
+					IssueIndex:  commentable.GetLocalIndex(),
+					PosterName:  comment.Author.Name(),
+					PosterEmail: comment.Author.Email(),
+					Content:     comment.Content,
+					Created:     comment.PublishedDate,
+					Updated:     comment.LastUpdatedDate,
+				})
+			}
+		}
+		return comments, true, nil
+	}
+
+	for token := ""; ; {
+		parameter := url.Values{"api-version": {azureDevOpsAPIVersion + "-preview.4"}}
+		if token != "" {
+			parameter.Set("continuationToken", token)
+		}
+		var result struct {
+			Comments []struct {
+				ID           int64            `json:"id"`
+				Text         string           `json:"text"`
+				CreatedBy    *azureDevOpsUser `json:"createdBy"`
+				CreatedDate  time.Time        `json:"createdDate"`
+				ModifiedDate time.Time        `json:"modifiedDate"`
+			} `json:"comments"`
+			ContinuationToken string `json:"continuationToken"`
+		}
+		if err := d.callAPI(ctx, http.MethodGet, fmt.Sprintf("/wit/workItems/%d/comments", commentable.GetForeignIndex()), parameter, nil, &result); err != nil {
+			return nil, false, err
+		}
+		for _, comment := range result.Comments {
+			comments = append(comments, &base.Comment{
+				IssueIndex:  commentable.GetLocalIndex(),
+				Index:       comment.ID,
+				PosterName:  comment.CreatedBy.Name(),
+				PosterEmail: comment.CreatedBy.Email(),
+				Content:     comment.Text,
+				Created:     comment.CreatedDate,
+				Updated:     comment.ModifiedDate,
+			})
+		}
+		if result.ContinuationToken == "" || len(result.Comments) == 0 {
+			return comments, true, nil
+		}
+		token = result.ContinuationToken
+	}
+}
+
+type azureDevOpsReviewer struct {
+	azureDevOpsUser
+	Vote int `json:"vote"` // Possible values: 10 approved, 5 approved with suggestions, 0 no vote, -5 waiting for author, -10 rejected
+}
+
+// GetPullRequests returns pull requests according page and perPage
+func (d *AzureDevOpsDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	type commit struct {
+		CommitID string `json:"commitId"`
+	}
+	var result struct {
+		Value []struct {
+			PullRequestID         int64                  `json:"pullRequestId"`
+			Status                string                 `json:"status"` // Possible values: active, abandoned, completed
+			CreatedBy             *azureDevOpsUser       `json:"createdBy"`
+			CreationDate          time.Time              `json:"creationDate"`
+			ClosedDate            *time.Time             `json:"closedDate"`
+			Title                 string                 `json:"title"`
+			Description           string                 `json:"description"`
+			SourceRefName         string                 `json:"sourceRefName"`
+			TargetRefName         string                 `json:"targetRefName"`
+			IsDraft               bool                   `json:"isDraft"`
+			LastMergeSourceCommit *commit                `json:"lastMergeSourceCommit"`
+			LastMergeCommit       *commit                `json:"lastMergeCommit"`
+			Reviewers             []*azureDevOpsReviewer `json:"reviewers"`
+			ForkSource            *struct {
+				Repository struct {
+					Name      string `json:"name"`
+					RemoteURL string `json:"remoteUrl"`
+					Project   struct {
+						Name string `json:"name"`
+					} `json:"project"`
+				} `json:"repository"`
+			} `json:"forkSource"`
+		} `json:"value"`
+	}
+	if err := d.callAPI(ctx, http.MethodGet, d.repoEndpoint("/pullrequests"), url.Values{
+		"searchCriteria.status": {"all"},
+		"$skip":                 {strconv.Itoa((page - 1) * perPage)},
+		"$top":                  {strconv.Itoa(perPage)},
+	}, nil, &result); err != nil {
+		return nil, false, err
+	}
+
+	pullRequests := make([]*base.PullRequest, 0, len(result.Value))
+	for _, pr := range result.Value {
+		state := "open"
+		merged := false
+		var closed, mergedTime *time.Time
+		var mergeCommitSHA string
+		if pr.Status != "active" {
+			state = "closed"
+			closed = pr.ClosedDate
+			if pr.Status == "completed" {
+				merged = true
+				mergedTime = pr.ClosedDate
+				if pr.LastMergeCommit != nil {
+					mergeCommitSHA = pr.LastMergeCommit.CommitID
+				}
+			}
+		}
+
+		head := base.PullRequestBranch{
+			Ref:       strings.TrimPrefix(pr.SourceRefName, "refs/heads/"),
+			OwnerName: d.project,
+			RepoName:  d.repoName,
+		}
+		if pr.LastMergeSourceCommit != nil {
+			head.SHA = pr.LastMergeSourceCommit.CommitID
+		}
+		if pr.ForkSource != nil {
+			head.OwnerName = pr.ForkSource.Repository.Project.Name
+			head.RepoName = pr.ForkSource.Repository.Name
+			if u, err := url.Parse(pr.ForkSource.Repository.RemoteURL); err == nil {
+				u.User = nil
+				head.CloneURL = u.String()
+			}
+		}
+
+		d.reviewers[pr.PullRequestID] = pr.Reviewers
+
+		// the ids of pull requests overlap the ones of work items
+		pullRequests = append(pullRequests, &base.PullRequest{
+			Number:         pr.PullRequestID + d.maxIssueIndex,
+			Title:          pr.Title,
+			PosterName:     pr.CreatedBy.Name(),
+			PosterEmail:    pr.CreatedBy.Email(),
+			Content:        pr.Description,
+			State:          state,
+			Created:        pr.CreationDate,
+			Updated:        pr.CreationDate,
+			Closed:         closed,
+			Merged:         merged,
+			MergedTime:     mergedTime,
+			MergeCommitSHA: mergeCommitSHA,
+			Head:           head,
+			Base: base.PullRequestBranch{
+				Ref:       strings.TrimPrefix(pr.TargetRefName, "refs/heads/"),
+				OwnerName: d.project,
+				RepoName:  d.repoName,
+			},
+			IsDraft:      pr.IsDraft,
+			ForeignIndex: pr.PullRequestID,
+			Context:      azureDevOpsIssueContext{IsPullRequest: true},
+		})
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequests[len(pullRequests)-1], d.baseURL, d)
+	}
+
+	return pullRequests, len(result.Value) < perPage, nil
+}
+
+// azureDevOpsCommentID returns an ID unique in a pull request for a comment,
+// the IDs of the comments of Azure DevOps are only unique in their thread
+func azureDevOpsCommentID(threadID, commentID int64) int64 {
+	return threadID<<32 | commentID
+}
+
+// GetReviews returns pull requests review, the votes of the reviewers become reviews
+// and every comment on the files becomes a review holding it
+func (d *AzureDevOpsDownloader) GetReviews(ctx context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	var reviews []*base.Review
+	for _, reviewer := range d.reviewers[reviewable.GetForeignIndex()] {
+		var state string
+		switch {
+		case reviewer.Vote > 0:
+			state = base.ReviewStateApproved
+		case reviewer.Vote < 0:
+			state = base.ReviewStateChangesRequested
+		default:
+			continue
+		}
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerName: reviewer.Name(),
+			State:        state,
+		})
+	}
+
+	threads, err := d.listThreads(ctx, reviewable.GetForeignIndex())
+	if err != nil {
+		return nil, err
+	}
+	for _, thread := range threads {
+		threadContext := thread.ThreadContext
+		if thread.IsDeleted || threadContext == nil {
+			continue
+		}
+		var line int
+		switch {
+		case threadContext.RightFileStart != nil:
+			line = threadContext.RightFileStart.Line
+		case threadContext.LeftFileStart != nil:
+			line = -threadContext.LeftFileStart.Line
+		default:
+			// the comments on a whole file can't be migrated
+			continue
+		}
+		var firstID int64
+		for _, comment := range thread.Comments {
+			if comment.IsDeleted || comment.CommentType != "text" {
+				continue
+			}
+			id := azureDevOpsCommentID(thread.ID, comment.ID)
+			var inReplyTo int64
+			if comment.ParentCommentID != 0 {
+				inReplyTo = azureDevOpsCommentID(thread.ID, comment.ParentCommentID)
+			} else if firstID != 0 {
+				// a thread is a single conversation, even if a comment doesn't reply to another one
+				inReplyTo = firstID
+			} else {
+				firstID = id
+			}
+			reviews = append(reviews, &base.Review{
+				IssueIndex:   reviewable.GetLocalIndex(),
+				ReviewerName: comment.Author.Name(),
+				CreatedAt:    comment.PublishedDate,
+				State:        base.ReviewStateCommented,
+				Comments: []*base.ReviewComment{{
+					ID:        id,
+					InReplyTo: inReplyTo,
+					Content:   comment.Content,
+					TreePath:  strings.TrimPrefix(threadContext.FilePath, "/"),
+					Line:      line,
+					CreatedAt: comment.PublishedDate,
+					UpdatedAt: comment.LastUpdatedDate,
+				}},
+			})
+		}
+	}
+	return reviews, nil
+}
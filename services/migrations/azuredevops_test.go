@@ -0,0 +1,257 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"gitea.dev/models/unittest"
+	base "gitea.dev/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzureDevOpsDownloaderFactory(t *testing.T) {
+	for _, c := range []struct {
+		cloneAddr string
+		expected  string
+	}{
+		{"https://gitea-test@dev.azure.com/gitea-test/TestProject/_git/test_repo", "<AzureDevOpsDownloader https://dev.azure.com/gitea-test TestProject/test_repo>"},
+		{"https://dev.azure.com/gitea-test/_git/test_repo", "<AzureDevOpsDownloader https://dev.azure.com/gitea-test test_repo/test_repo>"},
+		{"https://gitea-test.visualstudio.com/TestProject/_git/test_repo", "<AzureDevOpsDownloader https://gitea-test.visualstudio.com TestProject/test_repo>"},
+		{"https://git.example.com/tfs/DefaultCollection/TestProject/_git/test_repo", "<AzureDevOpsDownloader https://git.example.com/tfs/DefaultCollection TestProject/test_repo>"},
+	} {
+		downloader, err := (&AzureDevOpsDownloaderFactory{}).New(t.Context(), base.MigrateOptions{CloneAddr: c.cloneAddr})
+		require.NoError(t, err, c.cloneAddr)
+		assert.Equal(t, c.expected, downloader.(interface{ LogString() string }).LogString())
+	}
+
+	_, err := (&AzureDevOpsDownloaderFactory{}).New(t.Context(), base.MigrateOptions{CloneAddr: "https://dev.azure.com/gitea-test/TestProject"})
+	assert.Error(t, err)
+}
+
+func TestAzureDevOpsDownloadRepo(t *testing.T) {
+	token := os.Getenv("AZURE_DEVOPS_READ_TOKEN")
+	liveMode := token != ""
+
+	_, callerFile, _, _ := runtime.Caller(0)
+	fixtureDir := filepath.Join(filepath.Dir(callerFile), "_mock_data/TestAzureDevOpsDownloadRepo")
+	mockServer := unittest.NewMockWebServer(t, "https://dev.azure.com", fixtureDir, liveMode)
+
+	ctx := t.Context()
+	downloader := NewAzureDevOpsDownloader(ctx, mockServer.URL+"/gitea-test", "", "", token, "TestProject", "test_repo")
+
+	repo, err := downloader.GetRepoInfo(ctx)
+	require.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "test_repo",
+		Owner:         "TestProject",
+		Description:   "Test project for testing migration from Azure DevOps to gitea",
+		CloneURL:      "https://dev.azure.com/gitea-test/TestProject/_git/test_repo",
+		OriginalURL:   mockServer.URL + "/gitea-test/TestProject/_git/test_repo",
+		DefaultBranch: "main",
+	}, repo)
+
+	milestones, err := downloader.GetMilestones(ctx)
+	require.NoError(t, err)
+	release1Start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	release1End := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	sprint1End := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)
+	assertMilestonesEqual(t, []*base.Milestone{
+		{Title: "Release 1", State: "closed", Created: release1Start, Deadline: &release1End, Closed: &release1End},
+		{Title: `Release 1\Sprint 1`, State: "closed", Created: release1Start, Deadline: &sprint1End, Closed: &sprint1End},
+		{Title: "Release 2", State: "open"},
+	}, milestones)
+
+	labels, err := downloader.GetLabels(ctx)
+	require.NoError(t, err)
+	assert.Len(t, labels, 5)
+	assertLabelEqual(t, &base.Label{Name: "type/Bug", Color: "cc293d", Exclusive: true}, labels[0])
+	assertLabelEqual(t, &base.Label{Name: "ui", Color: "cccccc"}, labels[4])
+
+	issues, isEnd, err := downloader.GetIssues(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.False(t, isEnd)
+	closed := time.Date(2024, 5, 4, 12, 30, 0, 0, time.UTC)
+	assertIssuesEqual(t, []*base.Issue{
+		{
+			Number:      1,
+			Title:       "Dark mode",
+			Content:     "<div>Support a dark theme.</div>",
+			PosterName:  "Alice",
+			PosterEmail: "alice@example.com",
+			Milestone:   "Release 1",
+			State:       "open",
+			Created:     time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 5, 2, 11, 0, 0, 0, time.UTC),
+			Labels: []*base.Label{
+				{Name: "type/Epic"},
+				{Name: "ui"},
+			},
+		},
+		{
+			Number:      2,
+			Title:       "Crash on start",
+			Content:     "It crashes when the configuration is empty.",
+			PosterName:  "Alice",
+			PosterEmail: "alice@example.com",
+			State:       "closed",
+			Created:     time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 5, 4, 13, 0, 0, 0, time.UTC),
+			Closed:      &closed,
+			Labels: []*base.Label{
+				{Name: "type/Bug"},
+				{Name: "backend"},
+				{Name: "ui"},
+			},
+			Assignees: []string{"Bob"},
+		},
+	}, issues)
+	assert.Equal(t, []int64{2}, issues[0].Dependencies)
+	assert.Empty(t, issues[1].Dependencies)
+
+	comments, _, err := downloader.GetComments(ctx, issues[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex: 1,
+			Index:      101,
+			PosterName: "Bob",
+			Content:    "<div>Should it follow the system theme?</div>",
+			Created:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Updated:    time.Date(2024, 5, 1, 12, 5, 0, 0, time.UTC),
+		},
+	}, comments)
+
+	prs, isEnd, err := downloader.GetPullRequests(ctx, 1, 50)
+	require.NoError(t, err)
+	assert.True(t, isEnd)
+	merged := time.Date(2024, 5, 6, 15, 0, 0, 0, time.UTC)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			Number:         4,
+			Title:          "Fix crash on start",
+			Content:        "Fixes #2",
+			PosterName:     "Bob",
+			State:          "closed",
+			Created:        time.Date(2024, 5, 5, 9, 0, 0, 0, time.UTC),
+			Updated:        time.Date(2024, 5, 5, 9, 0, 0, 0, time.UTC),
+			Closed:         &merged,
+			Merged:         true,
+			MergedTime:     &merged,
+			MergeCommitSHA: "8d3e9f1c2b4a5d6e7f8091a2b3c4d5e6f7081920",
+			Head: base.PullRequestBranch{
+				Ref:       "fix-crash",
+				SHA:       "1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e",
+				OwnerName: "TestProject",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				OwnerName: "TestProject",
+				RepoName:  "test_repo",
+			},
+		},
+		{
+			Number:      5,
+			Title:       "Add dark mode",
+			PosterName:  "Alice",
+			PosterEmail: "alice@example.com",
+			State:       "open",
+			Created:     time.Date(2024, 5, 7, 9, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 5, 7, 9, 0, 0, 0, time.UTC),
+			Head: base.PullRequestBranch{
+				CloneURL:  mockServer.URL + "/gitea-test/TestProject/_git/test_repo_fork",
+				Ref:       "dark-mode",
+				SHA:       "c0ffee1234567890abcdef1234567890abcdef12",
+				OwnerName: "TestProject",
+				RepoName:  "test_repo_fork",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				OwnerName: "TestProject",
+				RepoName:  "test_repo",
+			},
+		},
+	}, prs)
+	assert.True(t, prs[0].EnsuredSafe)
+	assert.True(t, prs[1].IsDraft)
+	assert.True(t, prs[1].IsForkPullRequest())
+
+	comments, _, err = downloader.GetComments(ctx, prs[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex:  4,
+			PosterName:  "Alice",
+			PosterEmail: "alice@example.com",
+			Content:     "Thanks, looks good overall.",
+			Created:     time.Date(2024, 5, 5, 10, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 5, 5, 10, 0, 0, 0, time.UTC),
+		},
+	}, comments)
+
+	reviews, err := downloader.GetReviews(ctx, prs[0])
+	require.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			IssueIndex:   4,
+			ReviewerName: "Alice",
+			State:        base.ReviewStateApproved,
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "Alice",
+			CreatedAt:    time.Date(2024, 5, 5, 10, 10, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        2<<32 | 1,
+				Content:   "Please check for nil here.",
+				TreePath:  "main.go",
+				Line:      12,
+				CreatedAt: time.Date(2024, 5, 5, 10, 10, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 5, 5, 10, 10, 0, 0, time.UTC),
+			}},
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "Bob",
+			CreatedAt:    time.Date(2024, 5, 5, 11, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        2<<32 | 2,
+				InReplyTo: 2<<32 | 1,
+				Content:   "Done.",
+				TreePath:  "main.go",
+				Line:      12,
+				CreatedAt: time.Date(2024, 5, 5, 11, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 5, 5, 11, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			IssueIndex:   4,
+			ReviewerName: "Alice",
+			CreatedAt:    time.Date(2024, 5, 5, 10, 20, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        3<<32 | 1,
+				Content:   "This line was useless.",
+				TreePath:  "util.go",
+				Line:      -7,
+				CreatedAt: time.Date(2024, 5, 5, 10, 20, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 5, 5, 10, 20, 0, 0, time.UTC),
+			}},
+		},
+	}, reviews)
+
+	reviews, err = downloader.GetReviews(ctx, prs[1])
+	require.NoError(t, err)
+	require.NotEmpty(t, reviews)
+	assert.Equal(t, base.ReviewStateChangesRequested, reviews[0].State)
+}
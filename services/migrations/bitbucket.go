@@ -0,0 +1,777 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+	"gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+)
+
+var (
+	_ base.Downloader        = &BitbucketCloudDownloader{}
+	_ base.Downloader        = &BitbucketServerDownloader{}
+	_ base.DownloaderFactory = &BitbucketDownloaderFactory{}
+)
+
+func init() {
+	RegisterDownloaderFactory(&BitbucketDownloaderFactory{})
+}
+
+// BitbucketCloudAPIURL is the base URL of the API of bitbucket.org
+const BitbucketCloudAPIURL = "https://api.bitbucket.org/2.0"
+
+// BitbucketDownloaderFactory defines a Bitbucket downloader factory, it handles both bitbucket.org and Bitbucket Server / Data Center
+type BitbucketDownloaderFactory struct{}
+
+// New returns a Downloader related to this factory according MigrateOptions
+func (f *BitbucketDownloaderFactory) New(ctx context.Context, opts base.MigrateOptions) (base.Downloader, error) {
+	u, err := url.Parse(opts.CloneAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if strings.EqualFold(u.Host, "bitbucket.org") {
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid path: %s", u.Path)
+		}
+		workspace := fields[0]
+		repoName := strings.TrimSuffix(fields[1], ".git")
+
+		log.Trace("Create Bitbucket Cloud downloader. Workspace: %s RepoName: %s", workspace, repoName)
+		return NewBitbucketCloudDownloader(ctx, BitbucketCloudAPIURL, opts.AuthUsername, opts.AuthPassword, opts.AuthToken, workspace, repoName), nil
+	}
+
+	// Bitbucket Server clone URLs look like "https://host/<context>/scm/<project>/<repo>.git"
+	// and its web URLs like "https://host/<context>/projects/<project>/repos/<repo>/browse"
+	for i := range fields {
+		var projectKey, repoSlug string
+		if fields[i] == "scm" && i+2 < len(fields) {
+			projectKey, repoSlug = fields[i+1], fields[i+2]
+		} else if fields[i] == "projects" && i+3 < len(fields) && fields[i+2] == "repos" {
+			projectKey, repoSlug = fields[i+1], fields[i+3]
+		} else {
+			continue
+		}
+		baseURL := u.Scheme + "://" + u.Host + strings.TrimSuffix("/"+strings.Join(fields[:i], "/"), "/")
+		repoSlug = strings.TrimSuffix(repoSlug, ".git")
+
+		log.Trace("Create Bitbucket Server downloader. BaseURL: %s Project: %s RepoName: %s", baseURL, projectKey, repoSlug)
+		return NewBitbucketServerDownloader(ctx, baseURL, opts.AuthUsername, opts.AuthPassword, opts.AuthToken, projectKey, repoSlug), nil
+	}
+	return nil, fmt.Errorf("invalid path: %s", u.Path)
+}
+
+// GitServiceType returns the type of git service
+func (f *BitbucketDownloaderFactory) GitServiceType() structs.GitServiceType {
+	return structs.BitbucketService
+}
+
+// bitbucketClient calls the REST API of bitbucket.org or of a Bitbucket Server instance
+type bitbucketClient struct {
+	client  *http.Client
+	baseURL string
+}
+
+func newBitbucketClient(ctx context.Context, baseURL, username, password, token string) *bitbucketClient {
+	apiURL, _ := url.Parse(baseURL)
+	httpTransport := NewMigrationHTTPTransport()
+	return &bitbucketClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client: &http.Client{
+			Transport: roundTripperFunc(
+				func(req *http.Request) (*http.Response, error) {
+					// downloads are redirected to pre-signed URLs of another host which must not get the credentials
+					if apiURL != nil && req.URL.Host == apiURL.Host {
+						if token != "" {
+							req.Header.Set("Authorization", "Bearer "+token)
+						} else if username != "" && password != "" {
+							req.SetBasicAuth(username, password)
+						}
+					}
+					return httpTransport.RoundTrip(req.WithContext(ctx))
+				}),
+		},
+	}
+}
+
+func (c *bitbucketClient) callAPI(ctx context.Context, endpoint string, parameter url.Values, result any) error {
+	u, err := url.Parse(c.baseURL + endpoint)
+	if err != nil {
+		return err
+	}
+	if parameter != nil {
+		u.RawQuery = parameter.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%s: %w", u.Path, util.ErrNotExist)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status %q for %s", resp.Status, u.Path)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// formatBitbucketCloneURL adds the credentials to a clone URL, Bitbucket expects access tokens to be sent with the "x-token-auth" user
+func formatBitbucketCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	if opts.AuthToken == "" && opts.AuthUsername == "" {
+		return remoteAddr, nil
+	}
+	u, err := url.Parse(remoteAddr)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case opts.AuthToken != "" && opts.AuthUsername != "":
+		u.User = url.UserPassword(opts.AuthUsername, opts.AuthToken)
+	case opts.AuthToken != "":
+		u.User = url.UserPassword("x-token-auth", opts.AuthToken)
+	default:
+		u.User = url.UserPassword(opts.AuthUsername, opts.AuthPassword)
+	}
+	return u.String(), nil
+}
+
+type bitbucketIssueContext struct {
+	IsPullRequest bool
+}
+
+type bitbucketCloudUser struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+// Name returns the name of the user, deleted users don't have one
+func (u *bitbucketCloudUser) Name() string {
+	if u == nil {
+		return "Ghost"
+	}
+	if u.Nickname != "" {
+		return u.Nickname
+	}
+	return u.DisplayName
+}
+
+type bitbucketCloudContent struct {
+	Raw string `json:"raw"`
+}
+
+type bitbucketCloudPage[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+type bitbucketCloudComment struct {
+	ID        int64                 `json:"id"`
+	Content   bitbucketCloudContent `json:"content"`
+	User      *bitbucketCloudUser   `json:"user"`
+	CreatedOn time.Time             `json:"created_on"`
+	UpdatedOn time.Time             `json:"updated_on"`
+	Deleted   bool                  `json:"deleted"`
+	Parent    *struct {
+		ID int64 `json:"id"`
+	} `json:"parent"`
+	Inline *struct {
+		Path string `json:"path"`
+		From int    `json:"from"`
+		To   int    `json:"to"`
+	} `json:"inline"`
+}
+
+// BitbucketCloudDownloader implements a Downloader interface to get repository information
+// from bitbucket.org
+type BitbucketCloudDownloader struct {
+	base.NullDownloader
+	*bitbucketClient
+	workspace     string
+	repoName      string
+	defaultBranch string
+	hasIssues     bool
+	maxIssueIndex int64
+	maxPerPage    int
+}
+
+// NewBitbucketCloudDownloader creates a Bitbucket Cloud downloader, baseURL is the URL of its API
+func NewBitbucketCloudDownloader(ctx context.Context, baseURL, username, password, token, workspace, repoName string) *BitbucketCloudDownloader {
+	return &BitbucketCloudDownloader{
+		bitbucketClient: newBitbucketClient(ctx, baseURL, username, password, token),
+		workspace:       workspace,
+		repoName:        repoName,
+		maxPerPage:      50,
+	}
+}
+
+// String implements Stringer
+func (d *BitbucketCloudDownloader) String() string {
+	return fmt.Sprintf("migration from bitbucket cloud %s %s/%s", d.baseURL, d.workspace, d.repoName)
+}
+
+func (d *BitbucketCloudDownloader) LogString() string {
+	if d == nil {
+		return "<BitbucketCloudDownloader nil>"
+	}
+	return fmt.Sprintf("<BitbucketCloudDownloader %s %s/%s>", d.baseURL, d.workspace, d.repoName)
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *BitbucketCloudDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	return formatBitbucketCloneURL(opts, remoteAddr)
+}
+
+func (d *BitbucketCloudDownloader) repoEndpoint(format string, args ...any) string {
+	return fmt.Sprintf("/repositories/%s/%s", url.PathEscape(d.workspace), url.PathEscape(d.repoName)) + fmt.Sprintf(format, args...)
+}
+
+// listBitbucketCloudPages returns the values of all the pages of a list endpoint
+func listBitbucketCloudPages[T any](ctx context.Context, d *BitbucketCloudDownloader, endpoint string) ([]T, error) {
+	var values []T
+	for page := 1; ; page++ {
+		var result bitbucketCloudPage[T]
+		if err := d.callAPI(ctx, endpoint, url.Values{
+			"page":    {strconv.Itoa(page)},
+			"pagelen": {strconv.Itoa(d.maxPerPage)},
+		}, &result); err != nil {
+			return nil, err
+		}
+		values = append(values, result.Values...)
+		if result.Next == "" || len(result.Values) == 0 {
+			return values, nil
+		}
+	}
+}
+
+// GetRepoInfo returns repository information
+func (d *BitbucketCloudDownloader) GetRepoInfo(ctx context.Context) (*base.Repository, error) {
+	var repo struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		IsPrivate   bool   `json:"is_private"`
+		Website     string `json:"website"`
+		HasIssues   bool   `json:"has_issues"`
+		MainBranch  *struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+		Links struct {
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+			Clone []struct {
+				Name string `json:"name"`
+				Href string `json:"href"`
+			} `json:"clone"`
+		} `json:"links"`
+	}
+	if err := d.callAPI(ctx, d.repoEndpoint(""), nil, &repo); err != nil {
+		return nil, err
+	}
+
+	var cloneURL string
+	for _, link := range repo.Links.Clone {
+		if link.Name == "https" {
+			u, err := url.Parse(link.Href)
+			if err != nil {
+				return nil, err
+			}
+			u.User = nil
+			cloneURL = u.String()
+		}
+	}
+	if cloneURL == "" {
+		return nil, fmt.Errorf("no https clone URL for %s/%s", d.workspace, d.repoName)
+	}
+
+	d.hasIssues = repo.HasIssues
+	if repo.MainBranch != nil {
+		d.defaultBranch = repo.MainBranch.Name
+	}
+
+	return &base.Repository{
+		Name:          repo.Name,
+		Owner:         d.workspace,
+		IsPrivate:     repo.IsPrivate,
+		Description:   repo.Description,
+		Website:       repo.Website,
+		CloneURL:      cloneURL,
+		OriginalURL:   repo.Links.HTML.Href,
+		DefaultBranch: d.defaultBranch,
+	}, nil
+}
+
+// GetTopics return repository topics, Bitbucket has none
+func (d *BitbucketCloudDownloader) GetTopics(_ context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+// GetMilestones returns the milestones of the issue tracker
+func (d *BitbucketCloudDownloader) GetMilestones(ctx context.Context) ([]*base.Milestone, error) {
+	if !d.hasIssues {
+		return nil, nil
+	}
+	rawMilestones, err := listBitbucketCloudPages[struct {
+		Name string `json:"name"`
+	}](ctx, d, d.repoEndpoint("/milestones"))
+	if err != nil {
+		return nil, err
+	}
+
+	milestones := make([]*base.Milestone, 0, len(rawMilestones))
+	for _, milestone := range rawMilestones {
+		milestones = append(milestones, &base.Milestone{
+			Title: milestone.Name,
+			State: "open",
+		})
+	}
+	return milestones, nil
+}
+
+var (
+	bitbucketCloudIssueKinds      = []string{"bug", "enhancement", "proposal", "task"}
+	bitbucketCloudIssuePriorities = []string{"trivial", "minor", "major", "critical", "blocker"}
+)
+
+// GetLabels returns labels, the kinds, priorities and components of the issues are migrated as scoped labels
+func (d *BitbucketCloudDownloader) GetLabels(ctx context.Context) ([]*base.Label, error) {
+	if !d.hasIssues {
+		return nil, nil
+	}
+	components, err := listBitbucketCloudPages[struct {
+		Name string `json:"name"`
+	}](ctx, d, d.repoEndpoint("/components"))
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]*base.Label, 0, len(bitbucketCloudIssueKinds)+len(bitbucketCloudIssuePriorities)+len(components))
+	for _, kind := range bitbucketCloudIssueKinds {
+		labels = append(labels, &base.Label{Name: "kind/" + kind, Color: "1d76db", Exclusive: true})
+	}
+	for _, priority := range bitbucketCloudIssuePriorities {
+		labels = append(labels, &base.Label{Name: "priority/" + priority, Color: "e99695", Exclusive: true})
+	}
+	for _, component := range components {
+		labels = append(labels, &base.Label{Name: "component/" + component.Name, Color: "c5def5", Exclusive: true})
+	}
+	return labels, nil
+}
+
+// GetIssues returns issues according start and limit
+func (d *BitbucketCloudDownloader) GetIssues(ctx context.Context, page, perPage int) ([]*base.Issue, bool, error) {
+	if !d.hasIssues {
+		return nil, true, nil
+	}
+	if perPage > d.maxPerPage {
+		perPage = d.maxPerPage
+	}
+
+	type namedItem struct {
+		Name string `json:"name"`
+	}
+	var result bitbucketCloudPage[struct {
+		ID        int64                 `json:"id"`
+		Title     string                `json:"title"`
+		Content   bitbucketCloudContent `json:"content"`
+		Reporter  *bitbucketCloudUser   `json:"reporter"`
+		Assignee  *bitbucketCloudUser   `json:"assignee"`
+		State     string                `json:"state"`
+		Kind      string                `json:"kind"`
+		Priority  string                `json:"priority"`
+		Milestone *namedItem            `json:"milestone"`
+		Component *namedItem            `json:"component"`
+		CreatedOn time.Time             `json:"created_on"`
+		UpdatedOn time.Time             `json:"updated_on"`
+	}]
+	if err := d.callAPI(ctx, d.repoEndpoint("/issues"), url.Values{
+		"sort":    {"id"},
+		"page":    {strconv.Itoa(page)},
+		"pagelen": {strconv.Itoa(perPage)},
+	}, &result); err != nil {
+		return nil, false, err
+	}
+
+	issues := make([]*base.Issue, 0, len(result.Values))
+	for _, issue := range result.Values {
+		labels := make([]*base.Label, 0, 3)
+		if issue.Kind != "" {
+			labels = append(labels, &base.Label{Name: "kind/" + issue.Kind})
+		}
+		if issue.Priority != "" {
+			labels = append(labels, &base.Label{Name: "priority/" + issue.Priority})
+		}
+		if issue.Component != nil {
+			labels = append(labels, &base.Label{Name: "component/" + issue.Component.Name})
+		}
+
+		var milestone string
+		if issue.Milestone != nil {
+			milestone = issue.Milestone.Name
+		}
+
+		var assignees []string
+		if issue.Assignee != nil {
+			assignees = []string{issue.Assignee.Name()}
+		}
+
+		// new, open and on hold issues are still to be handled, the other states are resolutions
+		state := "closed"
+		var closed *time.Time
+		switch issue.State {
+		case "new", "open", "on hold":
+			state = "open"
+		default:
+			closed = &issue.UpdatedOn
+		}
+
+		issues = append(issues, &base.Issue{
+			Number:       issue.ID,
+			PosterName:   issue.Reporter.Name(),
+			Title:        issue.Title,
+			Content:      issue.Content.Raw,
+			Milestone:    milestone,
+			State:        state,
+			Created:      issue.CreatedOn,
+			Updated:      issue.UpdatedOn,
+			Closed:       closed,
+			Labels:       labels,
+			Assignees:    assignees,
+			ForeignIndex: issue.ID,
+			Context:      bitbucketIssueContext{IsPullRequest: false},
+		})
+
+		if d.maxIssueIndex < issue.ID {
+			d.maxIssueIndex = issue.ID
+		}
+	}
+
+	return issues, result.Next == "", nil
+}
+
+func (d *BitbucketCloudDownloader) listPullRequestComments(ctx context.Context, index int64) ([]*bitbucketCloudComment, error) {
+	return listBitbucketCloudPages[*bitbucketCloudComment](ctx, d, d.repoEndpoint("/pullrequests/%d/comments", index))
+}
+
+// GetComments returns comments, the inline comments of pull requests are returned as reviews
+func (d *BitbucketCloudDownloader) GetComments(ctx context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	context, ok := commentable.GetContext().(bitbucketIssueContext)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected context: %+v", commentable.GetContext())
+	}
+
+	var rawComments []*bitbucketCloudComment
+	var err error
+	if context.IsPullRequest {
+		rawComments, err = d.listPullRequestComments(ctx, commentable.GetForeignIndex())
+	} else {
+		rawComments, err = listBitbucketCloudPages[*bitbucketCloudComment](ctx, d, d.repoEndpoint("/issues/%d/comments", commentable.GetForeignIndex()))
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments := make([]*base.Comment, 0, len(rawComments))
+	for _, comment := range rawComments {
+		// the comments of issues without content only record changes of their attributes
+		if comment.Deleted || comment.Inline != nil || comment.Content.Raw == "" {
+			continue
+		}
+		comments = append(comments, &base.Comment{
+			IssueIndex: commentable.GetLocalIndex(),
+			Index:      comment.ID,
+			PosterName: comment.User.Name(),
+			Content:    comment.Content.Raw,
+			Created:    comment.CreatedOn,
+			Updated:    comment.UpdatedOn,
+		})
+	}
+	return comments, true, nil
+}
+
+type bitbucketCloudBranch struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit *struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+	Repository *struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// resolveCommit returns the full hash of a commit, the pull requests only contain abbreviated hashes
+func (d *BitbucketCloudDownloader) resolveCommit(ctx context.Context, repoFullName, hash string) string {
+	var commit struct {
+		Hash string `json:"hash"`
+	}
+	owner, name, _ := strings.Cut(repoFullName, "/")
+	endpoint := fmt.Sprintf("/repositories/%s/%s/commit/%s", url.PathEscape(owner), url.PathEscape(name), url.PathEscape(hash))
+	if err := d.callAPI(ctx, endpoint, nil, &commit); err != nil {
+		log.Warn("Unable to resolve commit %s of %s: %v", hash, repoFullName, err)
+		return ""
+	}
+	return commit.Hash
+}
+
+func (d *BitbucketCloudDownloader) convertBranch(ctx context.Context, branch *bitbucketCloudBranch) base.PullRequestBranch {
+	ret := base.PullRequestBranch{
+		Ref:       branch.Branch.Name,
+		OwnerName: d.workspace,
+		RepoName:  d.repoName,
+	}
+	repoFullName := d.workspace + "/" + d.repoName
+	if branch.Repository != nil {
+		repoFullName = branch.Repository.FullName
+		ret.OwnerName, ret.RepoName, _ = strings.Cut(branch.Repository.FullName, "/")
+	}
+	if branch.Commit != nil {
+		ret.SHA = d.resolveCommit(ctx, repoFullName, branch.Commit.Hash)
+	}
+	return ret
+}
+
+// GetPullRequests returns pull requests according page and perPage
+func (d *BitbucketCloudDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	if perPage > d.maxPerPage {
+		perPage = d.maxPerPage
+	}
+
+	var result bitbucketCloudPage[struct {
+		ID          int64                `json:"id"`
+		Title       string               `json:"title"`
+		Description string               `json:"description"`
+		State       string               `json:"state"` // Possible values: OPEN, MERGED, DECLINED, SUPERSEDED
+		Draft       bool                 `json:"draft"`
+		Author      *bitbucketCloudUser  `json:"author"`
+		Source      bitbucketCloudBranch `json:"source"`
+		Destination bitbucketCloudBranch `json:"destination"`
+		MergeCommit *struct {
+			Hash string `json:"hash"`
+		} `json:"merge_commit"`
+		CreatedOn time.Time `json:"created_on"`
+		UpdatedOn time.Time `json:"updated_on"`
+	}]
+	if err := d.callAPI(ctx, d.repoEndpoint("/pullrequests"), url.Values{
+		"state":   {"OPEN", "MERGED", "DECLINED", "SUPERSEDED"},
+		"sort":    {"id"},
+		"page":    {strconv.Itoa(page)},
+		"pagelen": {strconv.Itoa(perPage)},
+	}, &result); err != nil {
+		return nil, false, err
+	}
+
+	pullRequests := make([]*base.PullRequest, 0, len(result.Values))
+	for _, pr := range result.Values {
+		state := "open"
+		merged := false
+		var closed, mergedTime *time.Time
+		var mergeCommitSHA string
+		if pr.State != "OPEN" {
+			state = "closed"
+			closed = &pr.UpdatedOn
+			if pr.State == "MERGED" {
+				merged = true
+				mergedTime = &pr.UpdatedOn
+				if pr.MergeCommit != nil {
+					mergeCommitSHA = d.resolveCommit(ctx, d.workspace+"/"+d.repoName, pr.MergeCommit.Hash)
+				}
+			}
+		}
+
+		// the numbers of pull requests overlap the ones of issues on Bitbucket
+		pullRequests = append(pullRequests, &base.PullRequest{
+			Number:         pr.ID + d.maxIssueIndex,
+			Title:          pr.Title,
+			PosterName:     pr.Author.Name(),
+			Content:        pr.Description,
+			State:          state,
+			Created:        pr.CreatedOn,
+			Updated:        pr.UpdatedOn,
+			Closed:         closed,
+			PatchURL:       d.baseURL + d.repoEndpoint("/pullrequests/%d/patch", pr.ID),
+			Merged:         merged,
+			MergedTime:     mergedTime,
+			MergeCommitSHA: mergeCommitSHA,
+			Head:           d.convertBranch(ctx, &pr.Source),
+			Base:           d.convertBranch(ctx, &pr.Destination),
+			IsDraft:        pr.Draft,
+			ForeignIndex:   pr.ID,
+			Context:        bitbucketIssueContext{IsPullRequest: true},
+		})
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequests[len(pullRequests)-1], d.baseURL, d)
+	}
+
+	return pullRequests, result.Next == "", nil
+}
+
+// GetReviews returns pull requests review, approvals and change requests become reviews
+// and every inline comment becomes a review holding it, replies are put on the line of their thread
+func (d *BitbucketCloudDownloader) GetReviews(ctx context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	var pr struct {
+		Participants []struct {
+			User           *bitbucketCloudUser `json:"user"`
+			State          string              `json:"state"` // Possible values: approved, changes_requested, null
+			ParticipatedOn time.Time           `json:"participated_on"`
+		} `json:"participants"`
+	}
+	if err := d.callAPI(ctx, d.repoEndpoint("/pullrequests/%d", reviewable.GetForeignIndex()), nil, &pr); err != nil {
+		return nil, err
+	}
+
+	reviews := make([]*base.Review, 0, len(pr.Participants))
+	for _, participant := range pr.Participants {
+		var state string
+		switch participant.State {
+		case "approved":
+			state = base.ReviewStateApproved
+		case "changes_requested":
+			state = base.ReviewStateChangesRequested
+		default:
+			continue
+		}
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerName: participant.User.Name(),
+			CreatedAt:    participant.ParticipatedOn,
+			State:        state,
+		})
+	}
+
+	rawComments, err := d.listPullRequestComments(ctx, reviewable.GetForeignIndex())
+	if err != nil {
+		return nil, err
+	}
+	threads := make(map[int64]*bitbucketCloudComment, len(rawComments))
+	for _, comment := range rawComments {
+		if comment.Inline == nil {
+			continue
+		}
+		threads[comment.ID] = comment
+		if comment.Parent != nil {
+			if parent, ok := threads[comment.Parent.ID]; ok {
+				comment.Inline = parent.Inline
+			}
+		}
+		if comment.Deleted {
+			continue
+		}
+
+		line := comment.Inline.To
+		if line == 0 {
+			line = -comment.Inline.From
+		}
+		var inReplyTo int64
+		if comment.Parent != nil {
+			inReplyTo = comment.Parent.ID
+		}
+		reviews = append(reviews, &base.Review{
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerName: comment.User.Name(),
+			CreatedAt:    comment.CreatedOn,
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        comment.ID,
+				InReplyTo: inReplyTo,
+				Content:   comment.Content.Raw,
+				TreePath:  comment.Inline.Path,
+				Line:      line,
+				CreatedAt: comment.CreatedOn,
+				UpdatedAt: comment.UpdatedOn,
+			}},
+		})
+	}
+	return reviews, nil
+}
+
+// GetReleases returns releases. Bitbucket has no releases, the files of the "Downloads" section
+// are migrated as the assets of a draft release.
+func (d *BitbucketCloudDownloader) GetReleases(ctx context.Context) ([]*base.Release, error) {
+	downloads, err := listBitbucketCloudPages[struct {
+		Name      string              `json:"name"`
+		Size      int                 `json:"size"`
+		Downloads int                 `json:"downloads"`
+		User      *bitbucketCloudUser `json:"user"`
+		CreatedOn time.Time           `json:"created_on"`
+		Links     struct {
+			Self struct {
+				Href string `json:"href"`
+			} `json:"self"`
+		} `json:"links"`
+	}](ctx, d, d.repoEndpoint("/downloads"))
+	if err != nil {
+		return nil, err
+	}
+	if len(downloads) == 0 {
+		return nil, nil
+	}
+
+	release := &base.Release{
+		TagName:         "downloads",
+		TargetCommitish: d.defaultBranch,
+		Name:            "Downloads",
+		Body:            "Files of the Downloads section of the Bitbucket repository.",
+		Draft:           true,
+	}
+	for i, download := range downloads {
+		downloadURL := download.Links.Self.Href
+		// SECURITY: the download URL must point to the API
+		if !hasBaseURL(downloadURL, d.baseURL) {
+			WarnAndNotice("Download %s in %s has invalid URL: %s", download.Name, d, downloadURL)
+			continue
+		}
+		if release.Created.IsZero() || download.CreatedOn.Before(release.Created) {
+			release.Created = download.CreatedOn
+			release.PublisherName = download.User.Name()
+		}
+		release.Assets = append(release.Assets, &base.ReleaseAsset{
+			ID:            int64(i + 1),
+			Name:          download.Name,
+			Size:          &download.Size,
+			DownloadCount: &download.Downloads,
+			Created:       download.CreatedOn,
+			Updated:       download.CreatedOn,
+			DownloadFunc: func() (io.ReadCloser, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+				if err != nil {
+					return nil, err
+				}
+				resp, err := d.client.Do(req)
+				if err != nil {
+					return nil, err
+				}
+				if resp.StatusCode != http.StatusOK {
+					resp.Body.Close()
+					return nil, fmt.Errorf("unexpected status %q for %s", resp.Status, downloadURL)
+				}
+				return resp.Body, nil
+			},
+		})
+	}
+	return []*base.Release{release}, nil
+}
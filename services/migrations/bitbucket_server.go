@@ -0,0 +1,403 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+)
+
+type bitbucketServerUser struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+type bitbucketServerRepository struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+	Project     struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Links struct {
+		Clone []struct {
+			Name string `json:"name"`
+			Href string `json:"href"`
+		} `json:"clone"`
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+func (r *bitbucketServerRepository) cloneURL() string {
+	for _, link := range r.Links.Clone {
+		if link.Name == "http" {
+			u, err := url.Parse(link.Href)
+			if err != nil {
+				return ""
+			}
+			u.User = nil
+			return u.String()
+		}
+	}
+	return ""
+}
+
+type bitbucketServerRef struct {
+	DisplayID    string                     `json:"displayId"`
+	LatestCommit string                     `json:"latestCommit"`
+	Repository   *bitbucketServerRepository `json:"repository"`
+}
+
+type bitbucketServerComment struct {
+	ID          int64                     `json:"id"`
+	Text        string                    `json:"text"`
+	Author      bitbucketServerUser       `json:"author"`
+	CreatedDate int64                     `json:"createdDate"`
+	UpdatedDate int64                     `json:"updatedDate"`
+	Comments    []*bitbucketServerComment `json:"comments"`
+}
+
+type bitbucketServerActivity struct {
+	Action        string                  `json:"action"` // Possible values: COMMENTED, APPROVED, UNAPPROVED, REVIEWED, OPENED, MERGED, ...
+	CommentAction string                  `json:"commentAction"`
+	CreatedDate   int64                   `json:"createdDate"`
+	User          bitbucketServerUser     `json:"user"`
+	Comment       *bitbucketServerComment `json:"comment"`
+	CommentAnchor *struct {
+		Path     string `json:"path"`
+		Line     int    `json:"line"`
+		FileType string `json:"fileType"` // Possible values: FROM, TO
+		ToHash   string `json:"toHash"`
+	} `json:"commentAnchor"`
+}
+
+// flattenBitbucketServerComments returns the comments of a thread in order, replies are nested on Bitbucket Server
+func flattenBitbucketServerComments(comment *bitbucketServerComment, parentID int64, yield func(comment *bitbucketServerComment, parentID int64)) {
+	yield(comment, parentID)
+	for _, reply := range comment.Comments {
+		flattenBitbucketServerComments(reply, comment.ID, yield)
+	}
+}
+
+func bitbucketServerTime(millis int64) time.Time {
+	return time.UnixMilli(millis)
+}
+
+// BitbucketServerDownloader implements a Downloader interface to get repository information
+// from Bitbucket Server and Bitbucket Data Center. Their issues are managed in Jira, so only
+// the pull requests and their reviews can be migrated.
+type BitbucketServerDownloader struct {
+	base.NullDownloader
+	*bitbucketClient
+	webURL     string
+	projectKey string
+	repoSlug   string
+}
+
+// NewBitbucketServerDownloader creates a Bitbucket Server downloader, baseURL is the URL of the instance
+func NewBitbucketServerDownloader(ctx context.Context, baseURL, username, password, token, projectKey, repoSlug string) *BitbucketServerDownloader {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return &BitbucketServerDownloader{
+		bitbucketClient: newBitbucketClient(ctx, baseURL+"/rest/api/1.0", username, password, token),
+		webURL:          baseURL,
+		projectKey:      projectKey,
+		repoSlug:        repoSlug,
+	}
+}
+
+// String implements Stringer
+func (d *BitbucketServerDownloader) String() string {
+	return fmt.Sprintf("migration from bitbucket server %s %s/%s", d.webURL, d.projectKey, d.repoSlug)
+}
+
+func (d *BitbucketServerDownloader) LogString() string {
+	if d == nil {
+		return "<BitbucketServerDownloader nil>"
+	}
+	return fmt.Sprintf("<BitbucketServerDownloader %s %s/%s>", d.webURL, d.projectKey, d.repoSlug)
+}
+
+// FormatCloneURL add authentication into remote URLs
+func (d *BitbucketServerDownloader) FormatCloneURL(opts base.MigrateOptions, remoteAddr string) (string, error) {
+	return formatBitbucketCloneURL(opts, remoteAddr)
+}
+
+func (d *BitbucketServerDownloader) repoEndpoint(format string, args ...any) string {
+	return fmt.Sprintf("/projects/%s/repos/%s", url.PathEscape(d.projectKey), url.PathEscape(d.repoSlug)) + fmt.Sprintf(format, args...)
+}
+
+// GetRepoInfo returns repository information
+func (d *BitbucketServerDownloader) GetRepoInfo(ctx context.Context) (*base.Repository, error) {
+	var repo bitbucketServerRepository
+	if err := d.callAPI(ctx, d.repoEndpoint(""), nil, &repo); err != nil {
+		return nil, err
+	}
+	cloneURL := repo.cloneURL()
+	if cloneURL == "" {
+		return nil, fmt.Errorf("no http clone URL for %s/%s", d.projectKey, d.repoSlug)
+	}
+	var originalURL string
+	if len(repo.Links.Self) > 0 {
+		originalURL = repo.Links.Self[0].Href
+	}
+
+	// empty repositories have no default branch
+	var defaultBranch struct {
+		DisplayID string `json:"displayId"`
+	}
+	if err := d.callAPI(ctx, d.repoEndpoint("/branches/default"), nil, &defaultBranch); err != nil {
+		log.Warn("Unable to get the default branch of %s/%s: %v", d.projectKey, d.repoSlug, err)
+	}
+
+	return &base.Repository{
+		Name:          repo.Name,
+		Owner:         repo.Project.Key,
+		IsPrivate:     !repo.Public,
+		Description:   repo.Description,
+		CloneURL:      cloneURL,
+		OriginalURL:   originalURL,
+		DefaultBranch: defaultBranch.DisplayID,
+	}, nil
+}
+
+// GetTopics return repository topics, Bitbucket has none
+func (d *BitbucketServerDownloader) GetTopics(_ context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+func (d *BitbucketServerDownloader) convertRef(ref *bitbucketServerRef) base.PullRequestBranch {
+	ret := base.PullRequestBranch{
+		Ref:       ref.DisplayID,
+		SHA:       ref.LatestCommit,
+		OwnerName: d.projectKey,
+		RepoName:  d.repoSlug,
+	}
+	if ref.Repository != nil {
+		ret.OwnerName = ref.Repository.Project.Key
+		ret.RepoName = ref.Repository.Slug
+		ret.CloneURL = ref.Repository.cloneURL()
+	}
+	return ret
+}
+
+// GetPullRequests returns pull requests according page and perPage
+func (d *BitbucketServerDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	var result struct {
+		Values []struct {
+			ID          int64              `json:"id"`
+			Title       string             `json:"title"`
+			Description string             `json:"description"`
+			State       string             `json:"state"` // Possible values: OPEN, MERGED, DECLINED
+			Draft       bool               `json:"draft"`
+			CreatedDate int64              `json:"createdDate"`
+			UpdatedDate int64              `json:"updatedDate"`
+			ClosedDate  int64              `json:"closedDate"`
+			FromRef     bitbucketServerRef `json:"fromRef"`
+			ToRef       bitbucketServerRef `json:"toRef"`
+			Author      struct {
+				User bitbucketServerUser `json:"user"`
+			} `json:"author"`
+			Properties struct {
+				MergeCommit *struct {
+					ID string `json:"id"`
+				} `json:"mergeCommit"`
+			} `json:"properties"`
+		} `json:"values"`
+		IsLastPage bool `json:"isLastPage"`
+	}
+	if err := d.callAPI(ctx, d.repoEndpoint("/pull-requests"), url.Values{
+		"state": {"ALL"},
+		"order": {"OLDEST"},
+		"start": {strconv.Itoa((page - 1) * perPage)},
+		"limit": {strconv.Itoa(perPage)},
+	}, &result); err != nil {
+		return nil, false, err
+	}
+
+	pullRequests := make([]*base.PullRequest, 0, len(result.Values))
+	for _, pr := range result.Values {
+		state := "open"
+		merged := false
+		var closed, mergedTime *time.Time
+		var mergeCommitSHA string
+		if pr.State != "OPEN" {
+			state = "closed"
+			closedDate := bitbucketServerTime(pr.ClosedDate)
+			if pr.ClosedDate == 0 {
+				closedDate = bitbucketServerTime(pr.UpdatedDate)
+			}
+			closed = &closedDate
+			if pr.State == "MERGED" {
+				merged = true
+				mergedTime = &closedDate
+				if pr.Properties.MergeCommit != nil {
+					mergeCommitSHA = pr.Properties.MergeCommit.ID
+				}
+			}
+		}
+
+		pullRequests = append(pullRequests, &base.PullRequest{
+			Number:         pr.ID,
+			Title:          pr.Title,
+			PosterID:       pr.Author.User.ID,
+			PosterName:     pr.Author.User.Name,
+			PosterEmail:    pr.Author.User.EmailAddress,
+			Content:        pr.Description,
+			State:          state,
+			Created:        bitbucketServerTime(pr.CreatedDate),
+			Updated:        bitbucketServerTime(pr.UpdatedDate),
+			Closed:         closed,
+			Merged:         merged,
+			MergedTime:     mergedTime,
+			MergeCommitSHA: mergeCommitSHA,
+			Head:           d.convertRef(&pr.FromRef),
+			Base:           d.convertRef(&pr.ToRef),
+			IsDraft:        pr.Draft,
+			ForeignIndex:   pr.ID,
+			Context:        bitbucketIssueContext{IsPullRequest: true},
+		})
+
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(pullRequests[len(pullRequests)-1], d.webURL, d)
+	}
+
+	return pullRequests, result.IsLastPage, nil
+}
+
+func (d *BitbucketServerDownloader) listActivities(ctx context.Context, index int64) ([]*bitbucketServerActivity, error) {
+	var activities []*bitbucketServerActivity
+	for start := 0; ; {
+		var result struct {
+			Values        []*bitbucketServerActivity `json:"values"`
+			IsLastPage    bool                       `json:"isLastPage"`
+			NextPageStart int                        `json:"nextPageStart"`
+		}
+		if err := d.callAPI(ctx, d.repoEndpoint("/pull-requests/%d/activities", index), url.Values{
+			"start": {strconv.Itoa(start)},
+			"limit": {"100"},
+		}, &result); err != nil {
+			return nil, err
+		}
+		activities = append(activities, result.Values...)
+		if result.IsLastPage || len(result.Values) == 0 {
+			break
+		}
+		start = result.NextPageStart
+	}
+	// the activities are returned from the most recent one
+	for i, j := 0, len(activities)-1; i < j; i, j = i+1, j-1 {
+		activities[i], activities[j] = activities[j], activities[i]
+	}
+	return activities, nil
+}
+
+// GetComments returns the comments of a pull request which are not anchored to a line of its diff
+func (d *BitbucketServerDownloader) GetComments(ctx context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	activities, err := d.listActivities(ctx, commentable.GetForeignIndex())
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments := make([]*base.Comment, 0, len(activities))
+	for _, activity := range activities {
+		if activity.Action != "COMMENTED" || activity.CommentAction != "ADDED" || activity.Comment == nil {
+			continue
+		}
+		if activity.CommentAnchor != nil && activity.CommentAnchor.Line != 0 {
+			continue
+		}
+		flattenBitbucketServerComments(activity.Comment, 0, func(comment *bitbucketServerComment, _ int64) {
+			comments = append(comments, &base.Comment{
+				IssueIndex:  commentable.GetLocalIndex(),
+				Index:       comment.ID,
+				PosterID:    comment.Author.ID,
+				PosterName:  comment.Author.Name,
+				PosterEmail: comment.Author.EmailAddress,
+				Content:     comment.Text,
+				Created:     bitbucketServerTime(comment.CreatedDate),
+				Updated:     bitbucketServerTime(comment.UpdatedDate),
+			})
+		})
+	}
+	return comments, true, nil
+}
+
+// GetReviews returns pull requests review, approvals and "needs work" statuses become reviews
+// and every comment anchored to a line becomes a review holding it
+func (d *BitbucketServerDownloader) GetReviews(ctx context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	activities, err := d.listActivities(ctx, reviewable.GetForeignIndex())
+	if err != nil {
+		return nil, err
+	}
+
+	// only the last status set by a reviewer is kept
+	statuses := make(map[int64]*base.Review)
+	var reviews []*base.Review
+	for _, activity := range activities {
+		switch activity.Action {
+		case "UNAPPROVED":
+			delete(statuses, activity.User.ID)
+		case "APPROVED", "REVIEWED":
+			state := base.ReviewStateApproved
+			if activity.Action == "REVIEWED" {
+				state = base.ReviewStateChangesRequested
+			}
+			statuses[activity.User.ID] = &base.Review{
+				IssueIndex:   reviewable.GetLocalIndex(),
+				ReviewerID:   activity.User.ID,
+				ReviewerName: activity.User.Name,
+				CreatedAt:    bitbucketServerTime(activity.CreatedDate),
+				State:        state,
+			}
+		case "COMMENTED":
+			anchor := activity.CommentAnchor
+			if activity.CommentAction != "ADDED" || activity.Comment == nil || anchor == nil || anchor.Line == 0 {
+				continue
+			}
+			line := anchor.Line
+			if anchor.FileType == "FROM" {
+				line = -line
+			}
+			flattenBitbucketServerComments(activity.Comment, 0, func(comment *bitbucketServerComment, parentID int64) {
+				reviews = append(reviews, &base.Review{
+					IssueIndex:   reviewable.GetLocalIndex(),
+					ReviewerID:   comment.Author.ID,
+					ReviewerName: comment.Author.Name,
+					CommitID:     anchor.ToHash,
+					CreatedAt:    bitbucketServerTime(comment.CreatedDate),
+					State:        base.ReviewStateCommented,
+					Comments: []*base.ReviewComment{{
+						ID:        comment.ID,
+						InReplyTo: parentID,
+						Content:   comment.Text,
+						TreePath:  anchor.Path,
+						Line:      line,
+						CommitID:  anchor.ToHash,
+						PosterID:  comment.Author.ID,
+						CreatedAt: bitbucketServerTime(comment.CreatedDate),
+						UpdatedAt: bitbucketServerTime(comment.UpdatedDate),
+					}},
+				})
+			})
+		}
+	}
+	for _, activity := range activities {
+		if review, ok := statuses[activity.User.ID]; ok {
+			reviews = append(reviews, review)
+			delete(statuses, activity.User.ID)
+		}
+	}
+	return reviews, nil
+}
@@ -0,0 +1,437 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"gitea.dev/models/unittest"
+	base "gitea.dev/modules/migration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketDownloaderFactory(t *testing.T) {
+	for _, c := range []struct {
+		cloneAddr string
+		expected  string
+	}{
+		{"https://bitbucket.org/gitea-test/test_repo.git", "<BitbucketCloudDownloader https://api.bitbucket.org/2.0 gitea-test/test_repo>"},
+		{"https://bitbucket.org/gitea-test/test_repo/src/main/", "<BitbucketCloudDownloader https://api.bitbucket.org/2.0 gitea-test/test_repo>"},
+		{"https://git.example.com/scm/gt/test_repo.git", "<BitbucketServerDownloader https://git.example.com gt/test_repo>"},
+		{"https://example.com/bitbucket/projects/GT/repos/test_repo/browse", "<BitbucketServerDownloader https://example.com/bitbucket GT/test_repo>"},
+	} {
+		downloader, err := (&BitbucketDownloaderFactory{}).New(t.Context(), base.MigrateOptions{CloneAddr: c.cloneAddr})
+		require.NoError(t, err, c.cloneAddr)
+		assert.Equal(t, c.expected, downloader.(interface{ LogString() string }).LogString())
+	}
+
+	_, err := (&BitbucketDownloaderFactory{}).New(t.Context(), base.MigrateOptions{CloneAddr: "https://git.example.com/gt/test_repo.git"})
+	assert.Error(t, err)
+}
+
+func TestBitbucketDownloadRepo(t *testing.T) {
+	token := os.Getenv("BITBUCKET_READ_TOKEN")
+	liveMode := token != ""
+
+	_, callerFile, _, _ := runtime.Caller(0)
+	fixtureDir := filepath.Join(filepath.Dir(callerFile), "_mock_data/TestBitbucketDownloadRepo")
+	mockServer := unittest.NewMockWebServer(t, "https://api.bitbucket.org", fixtureDir, liveMode)
+
+	ctx := t.Context()
+	downloader := NewBitbucketCloudDownloader(ctx, mockServer.URL+"/2.0", "", "", token, "gitea-test", "test_repo")
+
+	repo, err := downloader.GetRepoInfo(ctx)
+	require.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "test_repo",
+		Owner:         "gitea-test",
+		Description:   "Test repository for testing migration from Bitbucket to gitea",
+		Website:       "https://gitea.io",
+		CloneURL:      "https://bitbucket.org/gitea-test/test_repo.git",
+		OriginalURL:   "https://bitbucket.org/gitea-test/test_repo",
+		DefaultBranch: "main",
+	}, repo)
+
+	milestones, err := downloader.GetMilestones(ctx)
+	require.NoError(t, err)
+	assertMilestonesEqual(t, []*base.Milestone{
+		{Title: "1.0.0", State: "open"},
+		{Title: "1.1.0", State: "open"},
+	}, milestones)
+
+	labels, err := downloader.GetLabels(ctx)
+	require.NoError(t, err)
+	assert.Len(t, labels, 10)
+	assertLabelEqual(t, &base.Label{Name: "component/backend", Color: "c5def5", Exclusive: true}, labels[9])
+
+	issues, isEnd, err := downloader.GetIssues(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.False(t, isEnd)
+	closed := time.Date(2024, 5, 4, 12, 30, 0, 0, time.UTC)
+	assertIssuesEqual(t, []*base.Issue{
+		{
+			Number:     1,
+			Title:      "Crash on start",
+			Content:    "It crashes when the configuration is empty.",
+			PosterName: "alice",
+			Milestone:  "1.0.0",
+			State:      "open",
+			Created:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			Updated:    time.Date(2024, 5, 2, 11, 0, 0, 0, time.UTC),
+			Labels: []*base.Label{
+				{Name: "kind/bug"},
+				{Name: "priority/major"},
+				{Name: "component/backend"},
+			},
+			Assignees: []string{"bob"},
+		},
+		{
+			Number:     2,
+			Title:      "Support dark mode",
+			PosterName: "bob",
+			State:      "closed",
+			Created:    time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC),
+			Updated:    closed,
+			Closed:     &closed,
+			Labels: []*base.Label{
+				{Name: "kind/enhancement"},
+				{Name: "priority/minor"},
+			},
+		},
+	}, issues)
+
+	comments, _, err := downloader.GetComments(ctx, issues[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex: 1,
+			Index:      101,
+			PosterName: "bob",
+			Content:    "I can reproduce it.",
+			Created:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Updated:    time.Date(2024, 5, 1, 12, 5, 0, 0, time.UTC),
+		},
+	}, comments)
+
+	prs, isEnd, err := downloader.GetPullRequests(ctx, 1, 50)
+	require.NoError(t, err)
+	assert.True(t, isEnd)
+	merged := time.Date(2024, 5, 6, 15, 0, 0, 0, time.UTC)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			Number:         3,
+			Title:          "Fix crash on start",
+			Content:        "Fixes #1",
+			PosterName:     "bob",
+			State:          "closed",
+			Created:        time.Date(2024, 5, 5, 9, 0, 0, 0, time.UTC),
+			Updated:        merged,
+			Closed:         &merged,
+			PatchURL:       mockServer.URL + "/2.0/repositories/gitea-test/test_repo/pullrequests/1/patch",
+			Merged:         true,
+			MergedTime:     &merged,
+			MergeCommitSHA: "8d3e9f1c2b4a5d6e7f8091a2b3c4d5e6f7081920",
+			Head: base.PullRequestBranch{
+				Ref:       "fix-crash",
+				SHA:       "1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e",
+				OwnerName: "gitea-test",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4",
+				OwnerName: "gitea-test",
+				RepoName:  "test_repo",
+			},
+		},
+		{
+			Number:     4,
+			Title:      "Add dark mode",
+			PosterName: "alice",
+			State:      "open",
+			Created:    time.Date(2024, 5, 7, 9, 0, 0, 0, time.UTC),
+			Updated:    time.Date(2024, 5, 7, 9, 30, 0, 0, time.UTC),
+			PatchURL:   mockServer.URL + "/2.0/repositories/gitea-test/test_repo/pullrequests/2/patch",
+			Head: base.PullRequestBranch{
+				Ref:       "dark-mode",
+				SHA:       "c0ffee1234567890abcdef1234567890abcdef12",
+				OwnerName: "alice",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				Ref:       "main",
+				SHA:       "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4",
+				OwnerName: "gitea-test",
+				RepoName:  "test_repo",
+			},
+		},
+	}, prs)
+	assert.True(t, prs[0].EnsuredSafe)
+	assert.True(t, prs[1].IsDraft)
+
+	comments, _, err = downloader.GetComments(ctx, prs[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex: 3,
+			Index:      201,
+			PosterName: "alice",
+			Content:    "Thanks, looks good overall.",
+			Created:    time.Date(2024, 5, 5, 10, 0, 0, 0, time.UTC),
+			Updated:    time.Date(2024, 5, 5, 10, 0, 0, 0, time.UTC),
+		},
+	}, comments)
+
+	reviews, err := downloader.GetReviews(ctx, prs[0])
+	require.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			IssueIndex:   3,
+			ReviewerName: "alice",
+			CreatedAt:    time.Date(2024, 5, 6, 14, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateApproved,
+		},
+		{
+			IssueIndex:   3,
+			ReviewerName: "alice",
+			CreatedAt:    time.Date(2024, 5, 5, 10, 10, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        202,
+				Content:   "Please check for nil here.",
+				TreePath:  "main.go",
+				Line:      12,
+				CreatedAt: time.Date(2024, 5, 5, 10, 10, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 5, 5, 10, 10, 0, 0, time.UTC),
+			}},
+		},
+		{
+			IssueIndex:   3,
+			ReviewerName: "bob",
+			CreatedAt:    time.Date(2024, 5, 5, 11, 0, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        203,
+				InReplyTo: 202,
+				Content:   "Done.",
+				TreePath:  "main.go",
+				Line:      12,
+				CreatedAt: time.Date(2024, 5, 5, 11, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 5, 5, 11, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			IssueIndex:   3,
+			ReviewerName: "alice",
+			CreatedAt:    time.Date(2024, 5, 5, 10, 20, 0, 0, time.UTC),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        204,
+				Content:   "This line was useless.",
+				TreePath:  "util.go",
+				Line:      -7,
+				CreatedAt: time.Date(2024, 5, 5, 10, 20, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 5, 5, 10, 20, 0, 0, time.UTC),
+			}},
+		},
+	}, reviews)
+
+	releases, err := downloader.GetReleases(ctx)
+	require.NoError(t, err)
+	created := time.Date(2024, 5, 8, 8, 0, 0, 0, time.UTC)
+	size, downloadCount := 13, 4
+	assertReleasesEqual(t, []*base.Release{
+		{
+			TagName:         "downloads",
+			TargetCommitish: "main",
+			Name:            "Downloads",
+			Body:            "Files of the Downloads section of the Bitbucket repository.",
+			Draft:           true,
+			PublisherName:   "alice",
+			Created:         created,
+			Assets: []*base.ReleaseAsset{
+				{
+					ID:            1,
+					Name:          "test_repo-1.0.0.tar.gz",
+					Size:          &size,
+					DownloadCount: &downloadCount,
+					Created:       created,
+					Updated:       created,
+				},
+			},
+		},
+	}, releases)
+
+	rc, err := releases[0].Assets[0].DownloadFunc()
+	require.NoError(t, err)
+	defer rc.Close()
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "fake tarball\n", string(content))
+}
+
+func TestBitbucketServerDownloadRepo(t *testing.T) {
+	liveURL := os.Getenv("BITBUCKET_SERVER_URL")
+	token := os.Getenv("BITBUCKET_SERVER_TOKEN")
+	liveMode := liveURL != ""
+	if !liveMode {
+		liveURL = "https://bitbucket.example.com"
+	}
+
+	_, callerFile, _, _ := runtime.Caller(0)
+	fixtureDir := filepath.Join(filepath.Dir(callerFile), "_mock_data/TestBitbucketServerDownloadRepo")
+	mockServer := unittest.NewMockWebServer(t, liveURL, fixtureDir, liveMode)
+
+	ctx := t.Context()
+	downloader := NewBitbucketServerDownloader(ctx, mockServer.URL, "", "", token, "GT", "test_repo")
+
+	repo, err := downloader.GetRepoInfo(ctx)
+	require.NoError(t, err)
+	assertRepositoryEqual(t, &base.Repository{
+		Name:          "test_repo",
+		Owner:         "GT",
+		IsPrivate:     true,
+		Description:   "Test repository for testing migration from Bitbucket Server to gitea",
+		CloneURL:      mockServer.URL + "/scm/gt/test_repo.git",
+		OriginalURL:   mockServer.URL + "/projects/GT/repos/test_repo/browse",
+		DefaultBranch: "master",
+	}, repo)
+
+	_, err = downloader.GetMilestones(ctx)
+	assert.True(t, base.IsErrNotSupported(err))
+	_, _, err = downloader.GetIssues(ctx, 1, 2)
+	assert.True(t, base.IsErrNotSupported(err))
+
+	prs, isEnd, err := downloader.GetPullRequests(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, isEnd)
+	merged := time.UnixMilli(1715090000000)
+	assertPullRequestsEqual(t, []*base.PullRequest{
+		{
+			Number:         1,
+			Title:          "Add README",
+			Content:        "Documents the project",
+			PosterID:       3,
+			PosterName:     "bob",
+			PosterEmail:    "bob@example.com",
+			State:          "closed",
+			Created:        time.UnixMilli(1715000000000),
+			Updated:        time.UnixMilli(1715100000000),
+			Closed:         &merged,
+			Merged:         true,
+			MergedTime:     &merged,
+			MergeCommitSHA: "5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e5e",
+			Head: base.PullRequestBranch{
+				CloneURL:  mockServer.URL + "/scm/gt/test_repo.git",
+				Ref:       "readme",
+				SHA:       "2f4e6a8c0b1d3f5a7c9e1b3d5f7a9c1e3b5d7f9a",
+				OwnerName: "GT",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				CloneURL:  mockServer.URL + "/scm/gt/test_repo.git",
+				Ref:       "master",
+				SHA:       "9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b",
+				OwnerName: "GT",
+				RepoName:  "test_repo",
+			},
+		},
+		{
+			Number:      2,
+			Title:       "Fix typo",
+			PosterID:    2,
+			PosterName:  "alice",
+			PosterEmail: "alice@example.com",
+			State:       "open",
+			Created:     time.UnixMilli(1715200000000),
+			Updated:     time.UnixMilli(1715200000000),
+			Head: base.PullRequestBranch{
+				CloneURL:  mockServer.URL + "/scm/~alice/test_repo.git",
+				Ref:       "typo",
+				SHA:       "7b7b7b7b7b7b7b7b7b7b7b7b7b7b7b7b7b7b7b7b",
+				OwnerName: "~ALICE",
+				RepoName:  "test_repo",
+			},
+			Base: base.PullRequestBranch{
+				CloneURL:  mockServer.URL + "/scm/gt/test_repo.git",
+				Ref:       "master",
+				SHA:       "9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b",
+				OwnerName: "GT",
+				RepoName:  "test_repo",
+			},
+		},
+	}, prs)
+	assert.True(t, prs[1].IsForkPullRequest())
+
+	comments, _, err := downloader.GetComments(ctx, prs[0])
+	require.NoError(t, err)
+	assertCommentsEqual(t, []*base.Comment{
+		{
+			IssueIndex:  1,
+			Index:       31,
+			PosterID:    2,
+			PosterName:  "alice",
+			PosterEmail: "alice@example.com",
+			Content:     "Nice, thanks!",
+			Created:     time.UnixMilli(1715050000000),
+			Updated:     time.UnixMilli(1715055000000),
+		},
+	}, comments)
+
+	reviews, err := downloader.GetReviews(ctx, prs[0])
+	require.NoError(t, err)
+	assertReviewsEqual(t, []*base.Review{
+		{
+			IssueIndex:   1,
+			ReviewerID:   2,
+			ReviewerName: "alice",
+			CommitID:     "2f4e6a8c0b1d3f5a7c9e1b3d5f7a9c1e3b5d7f9a",
+			CreatedAt:    time.UnixMilli(1715060000000),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        32,
+				Content:   `Typo: "projcet"`,
+				TreePath:  "README.md",
+				Line:      3,
+				CommitID:  "2f4e6a8c0b1d3f5a7c9e1b3d5f7a9c1e3b5d7f9a",
+				PosterID:  2,
+				CreatedAt: time.UnixMilli(1715060000000),
+				UpdatedAt: time.UnixMilli(1715060000000),
+			}},
+		},
+		{
+			IssueIndex:   1,
+			ReviewerID:   3,
+			ReviewerName: "bob",
+			CommitID:     "2f4e6a8c0b1d3f5a7c9e1b3d5f7a9c1e3b5d7f9a",
+			CreatedAt:    time.UnixMilli(1715065000000),
+			State:        base.ReviewStateCommented,
+			Comments: []*base.ReviewComment{{
+				ID:        33,
+				InReplyTo: 32,
+				Content:   "Fixed",
+				TreePath:  "README.md",
+				Line:      3,
+				CommitID:  "2f4e6a8c0b1d3f5a7c9e1b3d5f7a9c1e3b5d7f9a",
+				PosterID:  3,
+				CreatedAt: time.UnixMilli(1715065000000),
+				UpdatedAt: time.UnixMilli(1715065000000),
+			}},
+		},
+		{
+			IssueIndex:   1,
+			ReviewerID:   2,
+			ReviewerName: "alice",
+			CreatedAt:    time.UnixMilli(1715080000000),
+			State:        base.ReviewStateApproved,
+		},
+	}, reviews)
+}
@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/git"
 	"gitea.dev/modules/git/gitcmd"
@@ -47,6 +48,8 @@ type RepositoryDumper struct {
 
 	gitRepo     *git.Repository
 	prHeadCache map[string]string
+	// skipGit is set when the git data is saved by the caller, then only the metadata is dumped
+	skipGit bool
 }
 
 // NewRepositoryDumper creates a gitea Uploader
@@ -112,22 +115,23 @@ func (g *RepositoryDumper) CreateRepo(ctx context.Context, repo *base.Repository
 	defer f.Close()
 
 	bs, err := yaml.Marshal(map[string]any{
-		"name":         repo.Name,
-		"owner":        repo.Owner,
-		"description":  repo.Description,
-		"website":      repo.Website,
-		"clone_addr":   opts.CloneAddr,
-		"original_url": repo.OriginalURL,
-		"is_private":   opts.Private,
-		"service_type": opts.GitServiceType,
-		"wiki":         opts.Wiki,
-		"issues":       opts.Issues,
-		"milestones":   opts.Milestones,
-		"labels":       opts.Labels,
-		"releases":     opts.Releases,
-		"comments":     opts.Comments,
-		"pulls":        opts.PullRequests,
-		"assets":       opts.ReleaseAssets,
+		"name":           repo.Name,
+		"owner":          repo.Owner,
+		"description":    repo.Description,
+		"website":        repo.Website,
+		"clone_addr":     opts.CloneAddr,
+		"original_url":   repo.OriginalURL,
+		"default_branch": repo.DefaultBranch,
+		"is_private":     opts.Private,
+		"service_type":   opts.GitServiceType,
+		"wiki":           opts.Wiki,
+		"issues":         opts.Issues,
+		"milestones":     opts.Milestones,
+		"labels":         opts.Labels,
+		"releases":       opts.Releases,
+		"comments":       opts.Comments,
+		"pulls":          opts.PullRequests,
+		"assets":         opts.ReleaseAssets,
 	})
 	if err != nil {
 		return err
@@ -137,6 +141,10 @@ func (g *RepositoryDumper) CreateRepo(ctx context.Context, repo *base.Repository
 		return err
 	}
 
+	if g.skipGit {
+		return nil
+	}
+
 	repoAbsPath, err := filepath.Abs(g.gitPath())
 	if err != nil {
 		return err
@@ -683,6 +691,41 @@ func DumpRepository(ctx context.Context, baseDir, ownerName string, opts base.Mi
 	return nil
 }
 
+// DumpRepositoryMetadata dumps the metadata of a repository of this instance to a local directory in the same
+// format as DumpRepository, the git data is not dumped and has to be saved by the caller
+func DumpRepositoryMetadata(ctx context.Context, repo *repo_model.Repository, baseDir string) error {
+	doer, err := user_model.GetAdminUser(ctx)
+	if err != nil {
+		return err
+	}
+	downloader, err := NewGiteaLocalDownloader(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	opts := base.MigrateOptions{
+		RepoName:       repo.Name,
+		Private:        repo.IsPrivate,
+		GitServiceType: structs.GiteaService,
+	}
+	if err := updateOptionsUnits(&opts, nil); err != nil {
+		return err
+	}
+	uploader, err := NewRepositoryDumper(ctx, baseDir, repo.OwnerName, repo.Name, opts)
+	if err != nil {
+		return err
+	}
+	uploader.skipGit = true
+
+	if err := migrateRepository(ctx, doer, downloader, uploader, opts, nil); err != nil {
+		if err1 := uploader.Rollback(); err1 != nil {
+			log.Error("rollback failed: %v", err1)
+		}
+		return err
+	}
+	return nil
+}
+
 func updateOptionsUnits(opts *base.MigrateOptions, units []string) error {
 	if len(units) == 0 {
 		opts.Wiki = true
@@ -738,9 +781,11 @@ func RestoreRepository(ctx context.Context, baseDir, ownerName, repoName string,
 		return err
 	}
 	tp, _ := strconv.Atoi(opts["service_type"])
+	isPrivate, _ := strconv.ParseBool(opts["is_private"])
 
 	migrateOpts := base.MigrateOptions{
 		GitServiceType: structs.GitServiceType(tp),
+		Private:        isPrivate,
 	}
 	if err := updateOptionsUnits(&migrateOpts, units); err != nil {
 		return err
@@ -0,0 +1,506 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/git/gitrepo"
+	"gitea.dev/modules/log"
+	base "gitea.dev/modules/migration"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/storage"
+)
+
+var _ base.Downloader = &GiteaLocalDownloader{}
+
+// GiteaLocalDownloader implements a Downloader which reads a repository of this instance from the database,
+// it is used to dump the metadata of a local repository, e.g. for the repository backups
+type GiteaLocalDownloader struct {
+	base.NullDownloader
+	repo *repo_model.Repository
+}
+
+// NewGiteaLocalDownloader creates a downloader of a repository of this instance
+func NewGiteaLocalDownloader(ctx context.Context, repo *repo_model.Repository) (*GiteaLocalDownloader, error) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	return &GiteaLocalDownloader{repo: repo}, nil
+}
+
+// String implements Stringer
+func (g *GiteaLocalDownloader) String() string {
+	return "local repository " + g.repo.FullName()
+}
+
+func (g *GiteaLocalDownloader) LogString() string {
+	if g == nil {
+		return "<GiteaLocalDownloader nil>"
+	}
+	return fmt.Sprintf("<GiteaLocalDownloader %s>", g.repo.FullName())
+}
+
+// convertLocalPoster returns the id and the name of the poster which the uploader should remap
+func convertLocalPoster(poster *user_model.User, posterID int64, originalAuthor string, originalAuthorID int64) (int64, string, string) {
+	if originalAuthor != "" {
+		return originalAuthorID, originalAuthor, ""
+	}
+	if poster == nil {
+		return posterID, "", ""
+	}
+	return poster.ID, poster.Name, poster.Email
+}
+
+// GetRepoInfo returns repository information
+func (g *GiteaLocalDownloader) GetRepoInfo(_ context.Context) (*base.Repository, error) {
+	return &base.Repository{
+		Name:          g.repo.Name,
+		Owner:         g.repo.OwnerName,
+		IsPrivate:     g.repo.IsPrivate,
+		Description:   g.repo.Description,
+		Website:       g.repo.Website,
+		CloneURL:      gitrepo.RepoLocalPath(g.repo),
+		OriginalURL:   g.repo.HTMLURL(),
+		DefaultBranch: g.repo.DefaultBranch,
+	}, nil
+}
+
+// GetTopics returns the topics of the repository
+func (g *GiteaLocalDownloader) GetTopics(ctx context.Context) ([]string, error) {
+	topics, err := db.Find[repo_model.Topic](ctx, &repo_model.FindTopicOptions{RepoID: g.repo.ID})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		names = append(names, topic.Name)
+	}
+	return names, nil
+}
+
+// GetMilestones returns milestones
+func (g *GiteaLocalDownloader) GetMilestones(ctx context.Context) ([]*base.Milestone, error) {
+	milestones, err := db.Find[issues_model.Milestone](ctx, issues_model.FindMilestoneOptions{RepoID: g.repo.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*base.Milestone, 0, len(milestones))
+	for _, m := range milestones {
+		state := "open"
+		var closed *time.Time
+		if m.IsClosed {
+			state = "closed"
+			closed = m.ClosedDateUnix.AsTimePtr()
+		}
+		var deadline *time.Time
+		if m.DeadlineUnix > 0 {
+			deadline = m.DeadlineUnix.AsTimePtr()
+		}
+		result = append(result, &base.Milestone{
+			Title:       m.Name,
+			Description: m.Content,
+			Deadline:    deadline,
+			Created:     m.CreatedUnix.AsTime(),
+			Updated:     m.UpdatedUnix.AsTimePtr(),
+			Closed:      closed,
+			State:       state,
+		})
+	}
+	return result, nil
+}
+
+// GetLabels returns labels
+func (g *GiteaLocalDownloader) GetLabels(ctx context.Context) ([]*base.Label, error) {
+	labels, err := issues_model.GetLabelsByRepoID(ctx, g.repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*base.Label, 0, len(labels))
+	for _, label := range labels {
+		result = append(result, convertLocalLabel(label))
+	}
+	return result, nil
+}
+
+func convertLocalLabel(label *issues_model.Label) *base.Label {
+	return &base.Label{
+		Name:        label.Name,
+		Color:       label.Color,
+		Description: label.Description,
+		Exclusive:   label.Exclusive,
+	}
+}
+
+// GetReleases returns releases, the assets are read from the attachment storage
+func (g *GiteaLocalDownloader) GetReleases(ctx context.Context) ([]*base.Release, error) {
+	releases, err := db.Find[repo_model.Release](ctx, repo_model.FindReleasesOptions{
+		RepoID:        g.repo.ID,
+		IncludeDrafts: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := repo_model.GetReleaseAttachments(ctx, releases...); err != nil {
+		return nil, err
+	}
+
+	result := make([]*base.Release, 0, len(releases))
+	for _, rel := range releases {
+		if err := rel.LoadAttributes(ctx); err != nil {
+			return nil, err
+		}
+		publisherID, publisherName, publisherEmail := convertLocalPoster(rel.Publisher, rel.PublisherID, rel.OriginalAuthor, rel.OriginalAuthorID)
+		r := &base.Release{
+			TagName:         rel.TagName,
+			TargetCommitish: rel.Target,
+			Name:            rel.Title,
+			Body:            rel.Note,
+			Draft:           rel.IsDraft,
+			Prerelease:      rel.IsPrerelease,
+			PublisherID:     publisherID,
+			PublisherName:   publisherName,
+			PublisherEmail:  publisherEmail,
+			Created:         rel.CreatedUnix.AsTime(),
+			Published:       rel.CreatedUnix.AsTime(),
+		}
+		for _, attach := range rel.Attachments {
+			size := int(attach.Size)
+			downloadCount := int(attach.DownloadCount)
+			relativePath := attach.RelativePath()
+			r.Assets = append(r.Assets, &base.ReleaseAsset{
+				ID:            attach.ID,
+				Name:          attach.Name,
+				Size:          &size,
+				DownloadCount: &downloadCount,
+				Created:       attach.CreatedUnix.AsTime(),
+				Updated:       attach.CreatedUnix.AsTime(),
+				DownloadFunc: func() (io.ReadCloser, error) {
+					return storage.Attachments.Open(relativePath)
+				},
+			})
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+func (g *GiteaLocalDownloader) getReactions(ctx context.Context, issueID, commentID int64) ([]*base.Reaction, error) {
+	reactions, _, err := issues_model.FindReactions(ctx, issues_model.FindReactionsOptions{
+		IssueID:   issueID,
+		CommentID: commentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := reactions.LoadUsers(ctx, g.repo); err != nil {
+		return nil, err
+	}
+
+	result := make([]*base.Reaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		userID, userName, _ := convertLocalPoster(reaction.User, reaction.UserID, reaction.OriginalAuthor, reaction.OriginalAuthorID)
+		result = append(result, &base.Reaction{
+			UserID:   userID,
+			UserName: userName,
+			Content:  reaction.Type,
+		})
+	}
+	return result, nil
+}
+
+func (g *GiteaLocalDownloader) findIssues(ctx context.Context, page, perPage int, isPull bool) (issues_model.IssueList, bool, error) {
+	issues, err := issues_model.Issues(ctx, &issues_model.IssuesOptions{
+		Paginator: &db.ListOptions{Page: page, PageSize: perPage},
+		RepoIDs:   []int64{g.repo.ID},
+		IsPull:    optional.Some(isPull),
+		SortType:  "oldest",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return issues, len(issues) < perPage, nil
+}
+
+func (g *GiteaLocalDownloader) convertIssueCommon(ctx context.Context, issue *issues_model.Issue) (labels []*base.Label, milestone string, assignees []string, reactions []*base.Reaction) {
+	for _, label := range issue.Labels {
+		labels = append(labels, convertLocalLabel(label))
+	}
+	if issue.Milestone != nil {
+		milestone = issue.Milestone.Name
+	}
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.Name)
+	}
+	reactions, err := g.getReactions(ctx, issue.ID, -1)
+	if err != nil {
+		WarnAndNotice("Unable to load reactions during dumping issue #%d in %s. Error: %v", issue.Index, g, err)
+	}
+	return labels, milestone, assignees, reactions
+}
+
+// GetIssues returns issues according start and limit
+func (g *GiteaLocalDownloader) GetIssues(ctx context.Context, page, perPage int) ([]*base.Issue, bool, error) {
+	issues, isEnd, err := g.findIssues(ctx, page, perPage, false)
+	if err != nil {
+		return nil, false, err
+	}
+
+	result := make([]*base.Issue, 0, len(issues))
+	for _, issue := range issues {
+		labels, milestone, assignees, reactions := g.convertIssueCommon(ctx, issue)
+		posterID, posterName, posterEmail := convertLocalPoster(issue.Poster, issue.PosterID, issue.OriginalAuthor, issue.OriginalAuthorID)
+		state := "open"
+		var closed *time.Time
+		if issue.IsClosed {
+			state = "closed"
+			closed = issue.ClosedUnix.AsTimePtr()
+		}
+		result = append(result, &base.Issue{
+			Number:       issue.Index,
+			PosterID:     posterID,
+			PosterName:   posterName,
+			PosterEmail:  posterEmail,
+			Title:        issue.Title,
+			Content:      issue.Content,
+			Ref:          issue.Ref,
+			Milestone:    milestone,
+			State:        state,
+			IsLocked:     issue.IsLocked,
+			Created:      issue.CreatedUnix.AsTime(),
+			Updated:      issue.UpdatedUnix.AsTime(),
+			Closed:       closed,
+			Labels:       labels,
+			Reactions:    reactions,
+			Assignees:    assignees,
+			ForeignIndex: issue.Index,
+			Context:      issue.ID,
+		})
+	}
+	return result, isEnd, nil
+}
+
+// GetComments returns the plain comments and the events which could be migrated of an issue or a pull request
+func (g *GiteaLocalDownloader) GetComments(ctx context.Context, commentable base.Commentable) ([]*base.Comment, bool, error) {
+	issueID, ok := commentable.GetContext().(int64)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected context: %+v", commentable.GetContext())
+	}
+	comments, err := issues_model.FindComments(ctx, &issues_model.FindCommentsOptions{IssueID: issueID})
+	if err != nil {
+		return nil, false, err
+	}
+	if err := comments.LoadPosters(ctx); err != nil {
+		return nil, false, err
+	}
+
+	result := make([]*base.Comment, 0, len(comments))
+	for _, comment := range comments {
+		var meta map[string]any
+		switch comment.Type {
+		case issues_model.CommentTypeComment, issues_model.CommentTypeClose, issues_model.CommentTypeReopen, issues_model.CommentTypeMergePull:
+		case issues_model.CommentTypeChangeTitle:
+			meta = map[string]any{"OldTitle": comment.OldTitle, "NewTitle": comment.NewTitle}
+		case issues_model.CommentTypeChangeTargetBranch:
+			meta = map[string]any{"OldRef": comment.OldRef, "NewRef": comment.NewRef}
+		default:
+			// the other events reference the database records which can't be restored from a dump
+			continue
+		}
+
+		reactions, err := g.getReactions(ctx, issueID, comment.ID)
+		if err != nil {
+			WarnAndNotice("Unable to load reactions during dumping comment %d of #%d in %s. Error: %v", comment.ID, commentable.GetLocalIndex(), g, err)
+		}
+		posterID, posterName, posterEmail := convertLocalPoster(comment.Poster, comment.PosterID, comment.OriginalAuthor, comment.OriginalAuthorID)
+		result = append(result, &base.Comment{
+			IssueIndex:  commentable.GetLocalIndex(),
+			Index:       comment.ID,
+			CommentType: comment.Type.String(),
+			PosterID:    posterID,
+			PosterName:  posterName,
+			PosterEmail: posterEmail,
+			Created:     comment.CreatedUnix.AsTime(),
+			Updated:     comment.UpdatedUnix.AsTime(),
+			Content:     comment.Content,
+			Reactions:   reactions,
+			Meta:        meta,
+		})
+	}
+	return result, true, nil
+}
+
+// GetPullRequests returns pull requests according page and perPage
+func (g *GiteaLocalDownloader) GetPullRequests(ctx context.Context, page, perPage int) ([]*base.PullRequest, bool, error) {
+	issues, isEnd, err := g.findIssues(ctx, page, perPage, true)
+	if err != nil {
+		return nil, false, err
+	}
+
+	result := make([]*base.PullRequest, 0, len(issues))
+	for _, issue := range issues {
+		pr := issue.PullRequest
+		if pr == nil {
+			log.Warn("Pull request #%d in %s has no pull request record, skipped", issue.Index, g)
+			continue
+		}
+		labels, milestone, assignees, reactions := g.convertIssueCommon(ctx, issue)
+		posterID, posterName, posterEmail := convertLocalPoster(issue.Poster, issue.PosterID, issue.OriginalAuthor, issue.OriginalAuthorID)
+		state := "open"
+		var closed, mergedTime *time.Time
+		if issue.IsClosed {
+			state = "closed"
+			closed = issue.ClosedUnix.AsTimePtr()
+		}
+		if pr.HasMerged {
+			mergedTime = pr.MergedUnix.AsTimePtr()
+		}
+
+		// the head of every pull request is kept in the base repository, so even the pull requests
+		// from forks are dumped as if they were created from the base repository itself
+		headSHA, _, err := gitcmd.NewCommand("rev-parse", "--verify", "--end-of-options").
+			AddDynamicArguments(pr.GetGitHeadRefName() + "^{commit}").
+			WithRepo(g.repo).
+			RunStdString(ctx)
+		if err != nil {
+			log.Warn("Unable to get the head of pull request #%d in %s: %v", issue.Index, g, err)
+		}
+
+		basePR := &base.PullRequest{
+			Number:         issue.Index,
+			Title:          issue.Title,
+			PosterName:     posterName,
+			PosterID:       posterID,
+			PosterEmail:    posterEmail,
+			Content:        issue.Content,
+			Milestone:      milestone,
+			State:          state,
+			Created:        issue.CreatedUnix.AsTime(),
+			Updated:        issue.UpdatedUnix.AsTime(),
+			Closed:         closed,
+			Labels:         labels,
+			Merged:         pr.HasMerged,
+			MergedTime:     mergedTime,
+			MergeCommitSHA: pr.MergedCommitID,
+			Head: base.PullRequestBranch{
+				Ref:       pr.HeadBranch,
+				SHA:       strings.TrimSpace(headSHA),
+				RepoName:  g.repo.Name,
+				OwnerName: g.repo.OwnerName,
+			},
+			Base: base.PullRequestBranch{
+				Ref:       pr.BaseBranch,
+				SHA:       pr.MergeBase,
+				RepoName:  g.repo.Name,
+				OwnerName: g.repo.OwnerName,
+			},
+			Assignees:    assignees,
+			IsLocked:     issue.IsLocked,
+			Reactions:    reactions,
+			ForeignIndex: issue.Index,
+			Context:      issue.ID,
+		}
+		// SECURITY: Ensure that the PR is safe
+		_ = CheckAndEnsureSafePR(basePR, g.repo.HTMLURL(), g)
+		result = append(result, basePR)
+	}
+	return result, isEnd, nil
+}
+
+func convertLocalReviewState(tp issues_model.ReviewType) string {
+	switch tp {
+	case issues_model.ReviewTypeApprove:
+		return base.ReviewStateApproved
+	case issues_model.ReviewTypeReject:
+		return base.ReviewStateChangesRequested
+	case issues_model.ReviewTypeComment:
+		return base.ReviewStateCommented
+	case issues_model.ReviewTypeRequest:
+		return base.ReviewStateRequestReview
+	default:
+		return base.ReviewStatePending
+	}
+}
+
+// GetReviews returns the submitted reviews of a pull request with their code comments
+func (g *GiteaLocalDownloader) GetReviews(ctx context.Context, reviewable base.Reviewable) ([]*base.Review, error) {
+	commentable, ok := reviewable.(base.Commentable)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reviewable: %+v", reviewable)
+	}
+	issueID, ok := commentable.GetContext().(int64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected context: %+v", commentable.GetContext())
+	}
+	reviews, err := issues_model.FindReviews(ctx, issues_model.FindReviewOptions{IssueID: issueID})
+	if err != nil {
+		return nil, err
+	}
+	if err := reviews.LoadReviewers(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make([]*base.Review, 0, len(reviews))
+	for _, review := range reviews {
+		// pending reviews are the private drafts of their reviewers
+		if review.Type == issues_model.ReviewTypePending || review.ReviewerTeamID > 0 {
+			continue
+		}
+		codeComments, err := issues_model.FindComments(ctx, &issues_model.FindCommentsOptions{
+			IssueID:  issueID,
+			ReviewID: review.ID,
+			Type:     issues_model.CommentTypeCode,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := codeComments.LoadPosters(ctx); err != nil {
+			return nil, err
+		}
+
+		reviewerID, reviewerName, _ := convertLocalPoster(review.Reviewer, review.ReviewerID, review.OriginalAuthor, review.OriginalAuthorID)
+		r := &base.Review{
+			ID:           review.ID,
+			IssueIndex:   reviewable.GetLocalIndex(),
+			ReviewerID:   reviewerID,
+			ReviewerName: reviewerName,
+			Official:     review.Official,
+			CommitID:     review.CommitID,
+			Content:      review.Content,
+			CreatedAt:    review.CreatedUnix.AsTime(),
+			State:        convertLocalReviewState(review.Type),
+		}
+		for _, comment := range codeComments {
+			reactions, err := g.getReactions(ctx, issueID, comment.ID)
+			if err != nil {
+				WarnAndNotice("Unable to load reactions during dumping review comment %d of #%d in %s. Error: %v", comment.ID, reviewable.GetLocalIndex(), g, err)
+			}
+			posterID, _, _ := convertLocalPoster(comment.Poster, comment.PosterID, comment.OriginalAuthor, comment.OriginalAuthorID)
+			r.Comments = append(r.Comments, &base.ReviewComment{
+				ID:        comment.ID,
+				Content:   comment.Content,
+				TreePath:  comment.TreePath,
+				Line:      int(comment.Line),
+				CommitID:  comment.CommitSHA,
+				PosterID:  posterID,
+				Reactions: reactions,
+				CreatedAt: comment.CreatedUnix.AsTime(),
+				UpdatedAt: comment.UpdatedUnix.AsTime(),
+			})
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
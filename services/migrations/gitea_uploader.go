@@ -53,6 +53,8 @@ type GiteaLocalUploader struct {
 	userMap        map[int64]int64 // external user id mapping to user id
 	prCache        map[int64]*issues_model.PullRequest
 	gitServiceType structs.GitServiceType
+	// the dependencies of the issues by issue number, they are created once all the issues exist
+	issueDependencies map[int64][]int64
 }
 
 // NewGiteaLocalUploader creates a gitea Uploader via gitea API v1
@@ -67,6 +69,8 @@ func NewGiteaLocalUploader(_ context.Context, doer *user_model.User, repoOwner,
 		prHeadCache: make(map[string]string),
 		userMap:     make(map[int64]int64),
 		prCache:     make(map[int64]*issues_model.PullRequest),
+
+		issueDependencies: make(map[int64][]int64),
 	}
 }
 
@@ -445,6 +449,9 @@ func (g *GiteaLocalUploader) CreateIssues(ctx context.Context, issues ...*base.I
 			}
 			is.Reactions = append(is.Reactions, &res)
 		}
+		if len(issue.Dependencies) > 0 {
+			g.issueDependencies[issue.Number] = issue.Dependencies
+		}
 		iss = append(iss, &is)
 	}
 
@@ -960,6 +967,10 @@ func (g *GiteaLocalUploader) Finish(ctx context.Context) error {
 		return ErrRepoNotCreated
 	}
 
+	if err := g.createIssueDependencies(ctx); err != nil {
+		return err
+	}
+
 	// update issue_index
 	if err := issues_model.RecalculateIssueIndexForRepo(ctx, g.repo.ID); err != nil {
 		return err
@@ -973,6 +984,31 @@ func (g *GiteaLocalUploader) Finish(ctx context.Context) error {
 	return repo_model.UpdateRepositoryColsWithAutoTime(ctx, g.repo, "status")
 }
 
+// createIssueDependencies creates the dependencies between the migrated issues,
+// the ones on issues which have not been migrated are ignored
+func (g *GiteaLocalUploader) createIssueDependencies(ctx context.Context) error {
+	deps := make([]*issues_model.IssueDependency, 0, len(g.issueDependencies))
+	for number, depNumbers := range g.issueDependencies {
+		issue, ok := g.issues[number]
+		if !ok {
+			continue
+		}
+		for _, depNumber := range depNumbers {
+			dep, ok := g.issues[depNumber]
+			if !ok {
+				log.Warn("Dependency of issue #%d on #%d in %s/%s ignored: the issue has not been migrated", number, depNumber, g.repoOwner, g.repoName)
+				continue
+			}
+			deps = append(deps, &issues_model.IssueDependency{
+				UserID:       g.doer.ID,
+				IssueID:      issue.ID,
+				DependencyID: dep.ID,
+			})
+		}
+	}
+	return issues_model.InsertIssueDependencies(ctx, deps...)
+}
+
 func (g *GiteaLocalUploader) remapUser(ctx context.Context, source user_model.ExternalUserMigrated, target user_model.ExternalUserRemappable) error {
 	var userID int64
 	var err error
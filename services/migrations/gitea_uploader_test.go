@@ -222,3 +222,23 @@ func TestGiteaUploadRemapExternalUser(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, linkedUser.ID, target.GetUserID())
 }
+
+func TestGiteaUploadIssueDependencies(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Index: 1})
+	issue2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Index: 2})
+
+	ctx := t.Context()
+	uploader := NewGiteaLocalUploader(ctx, doer, repo.OwnerName, repo.Name)
+	uploader.repo = repo
+	uploader.issues[1] = issue1
+	uploader.issues[2] = issue2
+	// #1 is a parent of #2 and of an issue which has not been migrated
+	uploader.issueDependencies[1] = []int64{2, 42}
+
+	require.NoError(t, uploader.createIssueDependencies(ctx))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueDependency{IssueID: issue1.ID, DependencyID: issue2.ID})
+	unittest.AssertCount(t, &issues_model.IssueDependency{IssueID: issue1.ID}, 1)
+}
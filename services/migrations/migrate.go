@@ -196,8 +196,10 @@ func migrateRepository(ctx context.Context, doer *user_model.User, downloader ba
 		return err
 	}
 
-	// SECURITY: If the downloader is not a RepositoryRestorer then we need to recheck the CloneURL
-	if _, ok := downloader.(*RepositoryRestorer); !ok {
+	// SECURITY: If the downloader is not a RepositoryRestorer or a GiteaLocalDownloader then we need to recheck the CloneURL
+	_, isRestorer := downloader.(*RepositoryRestorer)
+	_, isLocal := downloader.(*GiteaLocalDownloader)
+	if !isRestorer && !isLocal {
 		// Now the clone URL can be rewritten by the downloader so we must recheck
 		if err := IsMigrateURLAllowed(repo.CloneURL, doer); err != nil {
 			return err
@@ -10,6 +10,7 @@ import (
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
 	packages_model "gitea.dev/models/packages"
+	pull_model "gitea.dev/models/pull"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/git"
@@ -105,6 +106,13 @@ func AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues
 	}
 }
 
+// MergeGroupChecksRequested notifies that a merge queue created a new merge group to be checked
+func MergeGroupChecksRequested(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, entry *pull_model.MergeQueueEntry) {
+	for _, notifier := range notifiers {
+		notifier.MergeGroupChecksRequested(ctx, doer, pr, entry)
+	}
+}
+
 // NewPullRequest notifies new pull request to notifiers
 func NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
 	if err := pr.LoadIssue(ctx); err != nil {
@@ -10,6 +10,7 @@ import (
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
 	packages_model "gitea.dev/models/packages"
+	pull_model "gitea.dev/models/pull"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/git"
@@ -62,6 +63,10 @@ func (*NullNotifier) MergePullRequest(ctx context.Context, doer *user_model.User
 func (*NullNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
 }
 
+// MergeGroupChecksRequested places a place holder function
+func (*NullNotifier) MergeGroupChecksRequested(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, entry *pull_model.MergeQueueEntry) {
+}
+
 // PullRequestSynchronized places a place holder function
 func (*NullNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, before, after string) {
 }
@@ -10,9 +10,11 @@ import (
 	actions_model "gitea.dev/models/actions"
 	activities_model "gitea.dev/models/activities"
 	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
 	org_model "gitea.dev/models/organization"
 	packages_model "gitea.dev/models/packages"
 	access_model "gitea.dev/models/perm/access"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	secret_model "gitea.dev/models/secret"
 	user_model "gitea.dev/models/user"
@@ -41,6 +43,9 @@ func deleteOrganization(ctx context.Context, org *org_model.Organization) error
 		&actions_model.ActionRunner{OwnerID: org.ID},
 		&actions_model.ActionRunnerToken{OwnerID: org.ID},
 		&actions_model.ActionScopedWorkflowSource{OwnerID: org.ID},
+		&packages_model.PackageUpstream{OwnerID: org.ID},
+		&git_model.PushRule{OwnerID: org.ID},
+		&quota_model.GroupOwner{OwnerID: org.ID},
 	); err != nil {
 		return fmt.Errorf("DeleteBeans: %w", err)
 	}
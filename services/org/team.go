@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
@@ -20,6 +21,7 @@ import (
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/setting"
 	"gitea.dev/modules/util"
+	audit_service "gitea.dev/services/audit"
 	repo_service "gitea.dev/services/repository"
 
 	"xorm.io/builder"
@@ -210,7 +212,7 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 
 // AddTeamMember adds new membership of given team to given organization,
 // the user will have membership to given organization automatically when needed.
-func AddTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
+func AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, user *user_model.User) error {
 	if user_model.IsUserBlockedBy(ctx, user, team.OrgID) {
 		return user_model.ErrBlockedUser
 	}
@@ -244,6 +246,7 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 		}
 
 		team.NumMembers++
+		audit_service.Record(ctx, doer, audit_model.ActionTeamMemberAdd, audit_service.TeamMemberTarget(team, user), nil, audit_service.TeamMemberState(team))
 		return nil
 	})
 	if err != nil {
@@ -346,8 +349,16 @@ func removeInvalidOrgUser(ctx context.Context, orgID int64, user *user_model.Use
 }
 
 // RemoveTeamMember removes member from given team of given organization.
-func RemoveTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
+func RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, user *user_model.User) error {
 	return db.WithTx(ctx, func(ctx context.Context) error {
-		return removeTeamMember(ctx, team, user)
+		isMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, user.ID)
+		if err != nil || !isMember {
+			return err
+		}
+		if err := removeTeamMember(ctx, team, user); err != nil {
+			return err
+		}
+		audit_service.Record(ctx, doer, audit_model.ActionTeamMemberRemove, audit_service.TeamMemberTarget(team, user), audit_service.TeamMemberState(team), nil)
+		return nil
 	})
 }
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	audit_model "gitea.dev/models/audit"
 	issues_model "gitea.dev/models/issues"
 	"gitea.dev/models/organization"
 	"gitea.dev/models/perm"
@@ -25,7 +26,7 @@ func TestTeam_AddMember(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
 	test := func(team *organization.Team, user *user_model.User) {
-		assert.NoError(t, AddTeamMember(t.Context(), team, user))
+		assert.NoError(t, AddTeamMember(t.Context(), nil, team, user))
 		unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{UID: user.ID, TeamID: team.ID})
 		unittest.CheckConsistencyFor(t, &organization.Team{ID: team.ID}, &user_model.User{ID: team.OrgID})
 	}
@@ -44,7 +45,7 @@ func TestTeam_RemoveMember(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
 	testSuccess := func(team *organization.Team, user *user_model.User) {
-		assert.NoError(t, RemoveTeamMember(t.Context(), team, user))
+		assert.NoError(t, RemoveTeamMember(t.Context(), nil, team, user))
 		unittest.AssertNotExistsBean(t, &organization.TeamUser{UID: user.ID, TeamID: team.ID})
 		unittest.CheckConsistencyFor(t, &organization.Team{ID: team.ID})
 	}
@@ -59,7 +60,7 @@ func TestTeam_RemoveMember(t *testing.T) {
 	testSuccess(team2, user2)
 	testSuccess(team3, user2)
 
-	err := RemoveTeamMember(t.Context(), team1, user2)
+	err := RemoveTeamMember(t.Context(), nil, team1, user2)
 	assert.True(t, organization.IsErrLastOrgOwner(err))
 }
 
@@ -78,7 +79,7 @@ func TestRemoveTeamMemberRemovesSubscriptionsAndStopwatches(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, ok)
 
-	assert.NoError(t, RemoveTeamMember(ctx, team, user))
+	assert.NoError(t, RemoveTeamMember(ctx, nil, team, user))
 
 	watch, err := repo_model.GetWatch(ctx, user.ID, repo.ID)
 	assert.NoError(t, err)
@@ -158,7 +159,7 @@ func TestAddTeamMember(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
 	test := func(team *organization.Team, user *user_model.User) {
-		assert.NoError(t, AddTeamMember(t.Context(), team, user))
+		assert.NoError(t, AddTeamMember(t.Context(), nil, team, user))
 		unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{UID: user.ID, TeamID: team.ID})
 		unittest.CheckConsistencyFor(t, &organization.Team{ID: team.ID}, &user_model.User{ID: team.OrgID})
 	}
@@ -177,7 +178,7 @@ func TestRemoveTeamMember(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
 	testSuccess := func(team *organization.Team, user *user_model.User) {
-		assert.NoError(t, RemoveTeamMember(t.Context(), team, user))
+		assert.NoError(t, RemoveTeamMember(t.Context(), nil, team, user))
 		unittest.AssertNotExistsBean(t, &organization.TeamUser{UID: user.ID, TeamID: team.ID})
 		unittest.CheckConsistencyFor(t, &organization.Team{ID: team.ID})
 	}
@@ -192,10 +193,42 @@ func TestRemoveTeamMember(t *testing.T) {
 	testSuccess(team2, user2)
 	testSuccess(team3, user2)
 
-	err := RemoveTeamMember(t.Context(), team1, user2)
+	err := RemoveTeamMember(t.Context(), nil, team1, user2)
 	assert.True(t, organization.IsErrLastOrgOwner(err))
 }
 
+func TestTeamMemberAuditEvents(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	assert.NoError(t, AddTeamMember(t.Context(), doer, team, user))
+	assert.NoError(t, AddTeamMember(t.Context(), doer, team, user))
+	unittest.AssertCount(t, &audit_model.Event{Action: audit_model.ActionTeamMemberAdd}, 1)
+	added := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionTeamMemberAdd})
+	assert.Equal(t, doer.ID, added.ActorID)
+	assert.Equal(t, team.OrgID, added.OwnerID)
+	assert.Equal(t, audit_model.TargetTypeUser, added.TargetType)
+	assert.Equal(t, user.ID, added.TargetID)
+	assert.Empty(t, added.Before)
+	assert.JSONEq(t, `{"team":"Owners","permission":"owner"}`, added.After)
+
+	assert.NoError(t, RemoveTeamMember(t.Context(), doer, team, user))
+	assert.NoError(t, RemoveTeamMember(t.Context(), doer, team, user))
+	unittest.AssertCount(t, &audit_model.Event{Action: audit_model.ActionTeamMemberRemove}, 1)
+	removed := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionTeamMemberRemove})
+	assert.Equal(t, user.ID, removed.TargetID)
+	assert.JSONEq(t, `{"team":"Owners","permission":"owner"}`, removed.Before)
+	assert.Empty(t, removed.After)
+
+	// a failed removal is not recorded
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	assert.True(t, organization.IsErrLastOrgOwner(RemoveTeamMember(t.Context(), doer, team, user2)))
+	unittest.AssertCount(t, &audit_model.Event{Action: audit_model.ActionTeamMemberRemove}, 1)
+}
+
 func TestIncludesAllRepositoriesTeams(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 
@@ -20,6 +20,7 @@ import (
 	cargo_service "gitea.dev/services/packages/cargo"
 	container_service "gitea.dev/services/packages/container"
 	debian_service "gitea.dev/services/packages/debian"
+	nix_service "gitea.dev/services/packages/nix"
 	rpm_service "gitea.dev/services/packages/rpm"
 )
 
@@ -58,6 +59,14 @@ func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.Packa
 				continue
 			}
 		}
+		if pcr.Type == packages_model.TypeNix {
+			if skip, err := nix_service.ShouldBeSkipped(ctx, pcr, p, pv); err != nil {
+				return false, fmt.Errorf("CleanupRule [%d]: nix.ShouldBeSkipped failed: %w", pcr.ID, err)
+			} else if skip {
+				log.Debug("Rule[%d]: keep '%s/%s' (nix reference)", pcr.ID, p.Name, pv.Version)
+				continue
+			}
+		}
 		toMatch := pv.LowerVersion
 		if pcr.MatchFullName {
 			toMatch = p.LowerName + "/" + pv.LowerVersion
@@ -171,6 +180,10 @@ func CleanupExpiredData(ctx context.Context, olderThan time.Duration) error {
 			return err
 		}
 
+		if err := nix_service.Cleanup(ctx, olderThan); err != nil {
+			return err
+		}
+
 		ps, err := packages_model.FindUnreferencedPackages(ctx)
 		if err != nil {
 			return err
@@ -0,0 +1,217 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/json"
+	hex_module "gitea.dev/modules/packages/hex"
+	"gitea.dev/modules/system"
+	"gitea.dev/modules/util"
+)
+
+// SigningKey is the RSA key pair of the instance used to sign the registry resources of all owners,
+// so the clients only have to trust a single public key
+type SigningKey struct {
+	PrivateKey string
+	PublicKey  string
+}
+
+// Name returns the name of the app state item
+func (*SigningKey) Name() string {
+	return "hex-signing-key"
+}
+
+var signingKeyMutex sync.Mutex
+
+// GetOrCreateSigningKey gets or creates the key pair used to sign the registry resources
+func GetOrCreateSigningKey(ctx context.Context) (*SigningKey, error) {
+	signingKeyMutex.Lock()
+	defer signingKeyMutex.Unlock()
+
+	key := &SigningKey{}
+	if err := system.AppState.Get(ctx, key); err != nil {
+		return nil, err
+	}
+	if key.PrivateKey != "" && key.PublicKey != "" {
+		return key, nil
+	}
+
+	priv, pub, err := util.GenerateKeyPair(4096)
+	if err != nil {
+		return nil, err
+	}
+	key = &SigningKey{PrivateKey: priv, PublicKey: pub}
+	if err := system.AppState.Set(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func signResource(ctx context.Context, payload []byte) ([]byte, error) {
+	key, err := GetOrCreateSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, errors.New("failed to decode private key pem")
+	}
+	priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return hex_module.SignResource(payload, priv)
+}
+
+// ownerPackageDescriptors returns the descriptors of all versions of the packages of the owner,
+// grouped by package and sorted by version
+func ownerPackageDescriptors(ctx context.Context, ownerID int64) ([][]*packages_model.PackageDescriptor, error) {
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, ownerID, packages_model.TypeHex)
+	if err != nil {
+		return nil, err
+	}
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+	return groupDescriptors(pds), nil
+}
+
+func groupDescriptors(pds []*packages_model.PackageDescriptor) [][]*packages_model.PackageDescriptor {
+	slices.SortFunc(pds, func(a, b *packages_model.PackageDescriptor) int {
+		if c := strings.Compare(a.Package.LowerName, b.Package.LowerName); c != 0 {
+			return c
+		}
+		return a.SemVer.Compare(b.SemVer)
+	})
+
+	var groups [][]*packages_model.PackageDescriptor
+	for i, pd := range pds {
+		if i == 0 || pds[i-1].Package.ID != pd.Package.ID {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], pd)
+	}
+	return groups
+}
+
+// GetRetirement returns the retirement status of the package version, or nil if it isn't retired
+func GetRetirement(pd *packages_model.PackageDescriptor) *hex_module.Retirement {
+	value := pd.VersionProperties.GetByName(hex_module.PropertyRetirement)
+	if value == "" {
+		return nil
+	}
+	r := &hex_module.Retirement{}
+	if err := json.Unmarshal([]byte(value), r); err != nil {
+		return nil
+	}
+	return r
+}
+
+// BuildNames builds the signed names resource of the owner
+func BuildNames(ctx context.Context, owner *user_model.User) ([]byte, error) {
+	groups, err := ownerPackageDescriptors(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]*hex_module.NamesPackage, 0, len(groups))
+	for _, pds := range groups {
+		updated := pds[0].Version.CreatedUnix
+		for _, pd := range pds {
+			updated = max(updated, pd.Version.CreatedUnix)
+		}
+		packages = append(packages, &hex_module.NamesPackage{
+			Name:      pds[0].Package.Name,
+			UpdatedAt: updated.AsTime(),
+		})
+	}
+	return signResource(ctx, hex_module.EncodeNames(owner.Name, packages))
+}
+
+// BuildVersions builds the signed versions resource of the owner
+func BuildVersions(ctx context.Context, owner *user_model.User) ([]byte, error) {
+	groups, err := ownerPackageDescriptors(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]*hex_module.VersionsPackage, 0, len(groups))
+	for _, pds := range groups {
+		p := &hex_module.VersionsPackage{Name: pds[0].Package.Name}
+		for i, pd := range pds {
+			p.Versions = append(p.Versions, pd.Version.Version)
+			if GetRetirement(pd) != nil {
+				p.Retired = append(p.Retired, int32(i))
+			}
+		}
+		packages = append(packages, p)
+	}
+	return signResource(ctx, hex_module.EncodeVersions(owner.Name, packages))
+}
+
+// BuildPackage builds the signed resource of the package listing its releases.
+// It returns nil if the package has no versions.
+func BuildPackage(ctx context.Context, owner *user_model.User, p *packages_model.Package) ([]byte, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, owner.ID, packages_model.TypeHex, p.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, nil
+	}
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+	groups := groupDescriptors(pds)
+
+	releases := make([]*hex_module.Release, 0, len(pds))
+	for _, pd := range groups[0] {
+		metadata := packages_model.DescriptorMetadata[*hex_module.Metadata](pd)
+		innerChecksum, err := hex.DecodeString(metadata.InnerChecksum)
+		if err != nil {
+			return nil, err
+		}
+		release := &hex_module.Release{
+			Version:       pd.Version.Version,
+			InnerChecksum: innerChecksum,
+			Dependencies:  metadata.Requirements,
+			Retirement:    GetRetirement(pd),
+		}
+		if len(pd.Files) > 0 {
+			if release.OuterChecksum, err = hex.DecodeString(pd.Files[0].Blob.HashSHA256); err != nil {
+				return nil, err
+			}
+		}
+		releases = append(releases, release)
+	}
+	return signResource(ctx, hex_module.EncodePackage(owner.Name, p.Name, releases))
+}
+
+// SetRetirement retires the package version, or unretires it if the retirement is nil
+func SetRetirement(ctx context.Context, pv *packages_model.PackageVersion, r *hex_module.Retirement) error {
+	if r == nil {
+		return packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, hex_module.PropertyRetirement)
+	}
+	if !hex_module.IsValidRetirementReason(r.Reason) {
+		return util.NewInvalidArgumentErrorf("retirement reason %q is invalid", r.Reason)
+	}
+	value, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, hex_module.PropertyRetirement, string(value))
+}
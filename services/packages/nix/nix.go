@@ -0,0 +1,285 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"gitea.dev/models/db"
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/optional"
+	packages_module "gitea.dev/modules/packages"
+	nix_module "gitea.dev/modules/packages/nix"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	packages_service "gitea.dev/services/packages"
+)
+
+const (
+	// NARs are uploaded before the narinfo which references them, they are kept in an internal package until then.
+	// The name can't be the name of a store path.
+	uploadPackageName = ".uploads"
+	uploadVersion     = "_upload"
+)
+
+var (
+	ErrHashMismatch = util.NewInvalidArgumentErrorf("the hash of the NAR doesn't match its file name")
+	ErrMissingNar   = util.NewInvalidArgumentErrorf("the NAR referenced by the narinfo has not been uploaded")
+	ErrSizeMismatch = util.NewInvalidArgumentErrorf("the size of the NAR doesn't match the narinfo")
+)
+
+// TrustedPublicKeys returns the configured keys which must sign the uploaded narinfo files
+func TrustedPublicKeys() []*nix_module.PublicKey {
+	keys := make([]*nix_module.PublicKey, 0, len(setting.Packages.NixTrustedPublicKeys))
+	for _, s := range setting.Packages.NixTrustedPublicKeys {
+		key, err := nix_module.ParsePublicKey(s)
+		if err != nil {
+			log.Error("Invalid Nix trusted public key: %v", err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// UploadNar stores a compressed NAR until a narinfo references it
+func UploadNar(ctx context.Context, doer, owner *user_model.User, filename string, buf *packages_module.HashedBuffer) error {
+	hash, err := nix_module.ParseNarFilename(filename)
+	if err != nil {
+		return err
+	}
+	if _, _, hashSHA256, _ := buf.Sums(); hex.EncodeToString(hashSHA256) != hex.EncodeToString(hash) {
+		return ErrHashMismatch
+	}
+
+	if err := packages_service.CheckSizeQuotaExceeded(ctx, doer, owner, packages_model.TypeNix, buf.Size()); err != nil {
+		return err
+	}
+
+	pv, err := packages_service.GetOrCreateInternalPackageVersion(ctx, owner.ID, packages_model.TypeNix, uploadPackageName, uploadVersion)
+	if err != nil {
+		return err
+	}
+
+	_, err = packages_service.AddFileToPackageVersionInternal(ctx, pv, &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Creator:           doer,
+		Data:              buf,
+		OverwriteExisting: true,
+	})
+	return err
+}
+
+// GetNarFile returns the file of a compressed NAR, which belongs to a store path or is not yet referenced by one
+func GetNarFile(ctx context.Context, ownerID int64, filename string) (*packages_model.PackageFile, error) {
+	hash, err := nix_module.ParseNarFilename(filename)
+	if err != nil {
+		return nil, packages_model.ErrPackageFileNotExist
+	}
+
+	opts := &packages_model.PackageFileSearchOptions{
+		OwnerID:       ownerID,
+		PackageType:   packages_model.TypeNix,
+		HashAlgorithm: "sha256",
+		Hash:          hex.EncodeToString(hash),
+		Paginator:     db.NewAbsoluteListOptions(0, 1),
+	}
+	pfs, _, err := packages_model.SearchFiles(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(pfs) > 0 {
+		return pfs[0], nil
+	}
+
+	pv, err := packages_model.GetInternalVersionByNameAndVersion(ctx, ownerID, packages_model.TypeNix, uploadPackageName, uploadVersion)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			return nil, packages_model.ErrPackageFileNotExist
+		}
+		return nil, err
+	}
+	opts.VersionID = pv.ID
+	pfs, _, err = packages_model.SearchFiles(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(pfs) == 0 {
+		return nil, packages_model.ErrPackageFileNotExist
+	}
+	return pfs[0], nil
+}
+
+// GetStorePath returns the package version of a store path by the hash part of the path
+func GetStorePath(ctx context.Context, ownerID int64, hash string) (*packages_model.PackageVersion, error) {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID: ownerID,
+		Type:    packages_model.TypeNix,
+		Version: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      hash,
+		},
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+	return pvs[0], nil
+}
+
+// AddStorePath creates the package version of a store path from its narinfo and links the uploaded NAR to it.
+// The package is named by the name of the store path and versioned by its hash.
+func AddStorePath(ctx context.Context, doer, owner *user_model.User, m *nix_module.Metadata) (*packages_model.PackageVersion, error) {
+	if keys := TrustedPublicKeys(); len(keys) > 0 {
+		if err := m.VerifySignatures(keys); err != nil {
+			return nil, err
+		}
+	}
+
+	pf, err := GetNarFile(ctx, owner.ID, m.NarFilename())
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			return nil, ErrMissingNar
+		}
+		return nil, err
+	}
+	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+	if err != nil {
+		return nil, err
+	}
+	if pb.Size != m.FileSize {
+		return nil, ErrSizeMismatch
+	}
+
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(s)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	// the references are stored with the store path to keep the closures complete when the cleanup rules run
+	pv, err := db.WithTx2(ctx, func(ctx context.Context) (*packages_model.PackageVersion, error) {
+		pv, _, err := packages_service.CreatePackageAndAddFile(
+			ctx,
+			&packages_service.PackageCreationInfo{
+				PackageInfo: packages_service.PackageInfo{
+					Owner:       owner,
+					PackageType: packages_model.TypeNix,
+					Name:        m.StoreName(),
+					Version:     m.StoreHash(),
+				},
+				Creator:  doer,
+				Metadata: m,
+			},
+			&packages_service.PackageFileCreationInfo{
+				PackageFileInfo: packages_service.PackageFileInfo{
+					Filename: m.NarFilename(),
+				},
+				Creator: doer,
+				Data:    buf,
+				IsLead:  true,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		storePath := m.StoreHash() + "-" + m.StoreName()
+		for _, ref := range m.References {
+			if ref == storePath {
+				continue
+			}
+			if _, err := packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, nix_module.PropertyReference, ref); err != nil {
+				return nil, err
+			}
+		}
+		return pv, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the upload isn't needed anymore, the blob is referenced by the store path now
+	if upload, err := packages_model.GetVersionByID(ctx, pf.VersionID); err == nil && upload.IsInternal {
+		if err := packages_service.DeletePackageFile(ctx, pf); err != nil {
+			log.Error("DeletePackageFile failed: %v", err)
+		}
+	}
+
+	return pv, nil
+}
+
+// ShouldBeSkipped returns whether the store path is referenced by another store path.
+// Removing it would break the closure of the referencing store path.
+func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package, pv *packages_model.PackageVersion) (bool, error) {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID:    pcr.OwnerID,
+		Type:       packages_model.TypeNix,
+		IsInternal: optional.Some(false),
+		Properties: map[string]string{
+			nix_module.PropertyReference: pv.Version + "-" + p.Name,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, other := range pvs {
+		if other.ID != pv.ID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Cleanup removes expired NAR uploads which are not referenced by a narinfo
+func Cleanup(ctx context.Context, olderThan time.Duration) error {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		Type: packages_model.TypeNix,
+		Name: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      uploadPackageName,
+		},
+		Version: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      uploadVersion,
+		},
+		IsInternal: optional.Some(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pv := range pvs {
+		pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
+			VersionID: pv.ID,
+			OlderThan: olderThan,
+		})
+		if err != nil {
+			return err
+		}
+		for _, pf := range pfs {
+			if err := packages_service.DeletePackageFile(ctx, pf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 
 	"gitea.dev/models/db"
 	packages_model "gitea.dev/models/packages"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/globallock"
@@ -366,6 +367,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 	switch packageType {
 	case packages_model.TypeAlpine:
 		typeSpecificSize = setting.Packages.LimitSizeAlpine
+	case packages_model.TypeAnsible:
+		typeSpecificSize = setting.Packages.LimitSizeAnsible
 	case packages_model.TypeArch:
 		typeSpecificSize = setting.Packages.LimitSizeArch
 	case packages_model.TypeCargo:
@@ -390,8 +393,12 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
 		typeSpecificSize = setting.Packages.LimitSizeHelm
+	case packages_model.TypeHex:
+		typeSpecificSize = setting.Packages.LimitSizeHex
 	case packages_model.TypeMaven:
 		typeSpecificSize = setting.Packages.LimitSizeMaven
+	case packages_model.TypeNix:
+		typeSpecificSize = setting.Packages.LimitSizeNix
 	case packages_model.TypeNpm:
 		typeSpecificSize = setting.Packages.LimitSizeNpm
 	case packages_model.TypeNuGet:
@@ -428,6 +435,14 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		}
 	}
 
+	if err := quota_model.Check(ctx, owner.ID, quota_model.SubjectPackages, uploadSize); err != nil {
+		if quota_model.IsErrQuotaExceeded(err) {
+			log.Debug("Package upload rejected: %v", err)
+			return ErrQuotaTotalSize
+		}
+		return err
+	}
+
 	return nil
 }
 
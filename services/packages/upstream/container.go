@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package upstream
+
+import (
+	"context"
+	"net/http"
+
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// containerManifestMediaTypes are the accepted manifest types, the upstream registry may convert manifests otherwise
+var containerManifestMediaTypes = []string{
+	oci.MediaTypeImageManifest,
+	oci.MediaTypeImageIndex,
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}
+
+// ContainerManifest fetches a manifest by tag or digest from the upstream registry.
+// The URL of a container upstream is the base of the registry API including an optional namespace,
+// for example https://registry-1.docker.io/v2/library
+func (c *Client) ContainerManifest(ctx context.Context, image, reference string) ([]byte, string, error) {
+	data, header, err := c.GetBytes(ctx, image+"/manifests/"+reference, containerManifestMediaTypes...)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, header.Get("Content-Type"), nil
+}
+
+// ContainerBlob fetches a blob by digest from the upstream registry, the caller must close the body
+func (c *Client) ContainerBlob(ctx context.Context, image, digest string) (*http.Response, error) {
+	return c.Get(ctx, image+"/blobs/"+digest)
+}
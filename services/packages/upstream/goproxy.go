@@ -0,0 +1,146 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/json"
+	packages_module "gitea.dev/modules/packages"
+	goproxy_module "gitea.dev/modules/packages/goproxy"
+	"gitea.dev/modules/util"
+	packages_service "gitea.dev/services/packages"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// GoModuleVersions returns the versions of a Go module known to the upstream proxy.
+// The name is the escaped module path of the GOPROXY protocol.
+func GoModuleVersions(ctx context.Context, ownerID int64, name string) ([]string, error) {
+	c, err := NewClient(ctx, ownerID, packages_model.TypeGo)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := c.GetBytes(ctx, name+"/@v/list")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, 10)
+	for _, line := range strings.Split(string(data), "\n") {
+		if version := strings.TrimSpace(line); semver.IsValid(version) {
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+// GoModuleLatestVersion returns the latest version of a Go module known to the upstream proxy
+func GoModuleLatestVersion(ctx context.Context, ownerID int64, name string) (string, error) {
+	c, err := NewClient(ctx, ownerID, packages_model.TypeGo)
+	if err != nil {
+		return "", err
+	}
+
+	data, _, err := c.GetBytes(ctx, name+"/@latest")
+	if err != nil {
+		return "", err
+	}
+
+	var info struct {
+		Version string `json:"Version"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return "", err
+	}
+	if !semver.IsValid(info.Version) {
+		return "", goproxy_module.ErrInvalidVersion
+	}
+	return info.Version, nil
+}
+
+// PullGoModule fetches a version of a Go module from the upstream proxy and stores it as cached version.
+// The name is the escaped module path of the GOPROXY protocol.
+func PullGoModule(ctx context.Context, owner, doer *user_model.User, name, version string) (*packages_model.PackageVersion, error) {
+	modulePath, err := module.UnescapePath(name)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if !semver.IsValid(version) {
+		return nil, ErrNotFound
+	}
+
+	c, err := NewClient(ctx, owner.ID, packages_model.TypeGo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pv *packages_model.PackageVersion
+	err = globallock.LockAndDo(ctx, lockKey(owner.ID, packages_model.TypeGo, modulePath, version), func(ctx context.Context) error {
+		// another request may have pulled the version while waiting for the lock
+		pv, err = packages_model.GetVersionByNameAndVersion(ctx, owner.ID, packages_model.TypeGo, modulePath, version)
+		if !errors.Is(err, util.ErrNotExist) {
+			return err
+		}
+
+		resp, err := c.Get(ctx, name+"/@v/"+version+".zip")
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		buf, err := packages_module.CreateHashedBufferFromReader(resp.Body)
+		if err != nil {
+			return err
+		}
+		defer buf.Close()
+
+		pck, err := goproxy_module.ParsePackage(buf, buf.Size())
+		if err != nil {
+			return err
+		}
+		if pck.Name != modulePath || pck.Version != version {
+			return util.NewInvalidArgumentErrorf("upstream module %s@%s doesn't match the requested module", pck.Name, pck.Version)
+		}
+
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		pv, err = createCachedVersion(
+			ctx,
+			c,
+			doer,
+			&packages_service.PackageCreationInfo{
+				PackageInfo: packages_service.PackageInfo{
+					Owner:       owner,
+					PackageType: packages_model.TypeGo,
+					Name:        pck.Name,
+					Version:     pck.Version,
+				},
+				VersionProperties: map[string]string{
+					goproxy_module.PropertyGoMod: pck.GoMod,
+				},
+			},
+			&packages_service.PackageFileCreationInfo{
+				PackageFileInfo: packages_service.PackageFileInfo{
+					Filename: fmt.Sprintf("%v.zip", pck.Version),
+				},
+				Data:   buf,
+				IsLead: true,
+			},
+		)
+		return err
+	})
+	return pv, err
+}
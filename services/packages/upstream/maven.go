@@ -0,0 +1,136 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package upstream
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/json"
+	packages_module "gitea.dev/modules/packages"
+	maven_module "gitea.dev/modules/packages/maven"
+	"gitea.dev/modules/util"
+	packages_service "gitea.dev/services/packages"
+)
+
+func mavenArtifactPath(groupID, artifactID string) string {
+	return strings.ReplaceAll(groupID, ".", "/") + "/" + url.PathEscape(artifactID)
+}
+
+// MavenVersions returns the versions of an artifact listed in the maven-metadata.xml of the upstream registry.
+// The versions are in the order of the upstream registry, the newest version is the last one.
+func MavenVersions(ctx context.Context, ownerID int64, groupID, artifactID string) ([]string, error) {
+	c, err := NewClient(ctx, ownerID, packages_model.TypeMaven)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := c.GetBytes(ctx, mavenArtifactPath(groupID, artifactID)+"/maven-metadata.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata struct {
+		Versions []string `xml:"versioning>versions>version"`
+	}
+	if err := xml.Unmarshal(data, &metadata); err != nil {
+		return nil, err
+	}
+	return metadata.Versions, nil
+}
+
+// PullMavenFile fetches a file of an artifact version from the upstream registry and adds it to the cached version.
+// Snapshots change in place, only release versions are pulled through.
+func PullMavenFile(ctx context.Context, owner, doer *user_model.User, groupID, artifactID, version, filename string) (*packages_model.PackageVersion, error) {
+	if strings.HasSuffix(version, "-SNAPSHOT") {
+		return nil, ErrNotFound
+	}
+
+	c, err := NewClient(ctx, owner.ID, packages_model.TypeMaven)
+	if err != nil {
+		return nil, err
+	}
+
+	packageName := groupID + ":" + artifactID
+
+	var pv *packages_model.PackageVersion
+	err = globallock.LockAndDo(ctx, lockKey(owner.ID, packages_model.TypeMaven, packageName, version), func(ctx context.Context) error {
+		// another request may have pulled the file while waiting for the lock
+		existing, err := packages_model.GetVersionByNameAndVersion(ctx, owner.ID, packages_model.TypeMaven, packageName, version)
+		if err == nil {
+			pv = existing
+			if _, err = packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey); !errors.Is(err, util.ErrNotExist) {
+				return err
+			}
+		} else if !errors.Is(err, util.ErrNotExist) {
+			return err
+		}
+
+		resp, err := c.Get(ctx, mavenArtifactPath(groupID, artifactID)+"/"+url.PathEscape(version)+"/"+url.PathEscape(filename))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		buf, err := packages_module.CreateHashedBufferFromReader(resp.Body)
+		if err != nil {
+			return err
+		}
+		defer buf.Close()
+
+		pvci := &packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       owner,
+				PackageType: packages_model.TypeMaven,
+				Name:        packageName,
+				Version:     version,
+			},
+		}
+		pfci := &packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Data: buf,
+		}
+
+		if strings.ToLower(path.Ext(filename)) == ".pom" {
+			pfci.IsLead = true
+
+			metadata, err := maven_module.ParsePackageMetaData(buf)
+			if err != nil {
+				return err
+			}
+			if metadata != nil {
+				pvci.Metadata = metadata
+
+				// the metadata is only stored when the version gets created, the pom may be pulled after other files
+				if existing != nil {
+					raw, err := json.Marshal(metadata)
+					if err != nil {
+						return err
+					}
+					existing.MetadataJSON = string(raw)
+					if err := packages_model.UpdateVersion(ctx, existing); err != nil {
+						return err
+					}
+				}
+			}
+			if _, err := buf.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+		}
+
+		pv, err = createCachedVersion(ctx, c, doer, pvci, pfci)
+		return err
+	})
+	return pv, err
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package upstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/url"
+
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/json"
+	packages_module "gitea.dev/modules/packages"
+	npm_module "gitea.dev/modules/packages/npm"
+	"gitea.dev/modules/util"
+	packages_service "gitea.dev/services/packages"
+)
+
+// maxNpmTarballSize is the maximum size of a tarball pulled from the upstream registry.
+// The tarball is decoded in memory like an uploaded package.
+const maxNpmTarballSize = 256 * 1024 * 1024
+
+// npmPackumentPath returns the path of the packument, the slash of scoped packages is escaped
+func npmPackumentPath(name string) string {
+	return url.PathEscape(name)
+}
+
+// NpmPackageMetadata returns the packument of a package from the upstream registry.
+// The tarball URLs still point to the upstream registry.
+func NpmPackageMetadata(ctx context.Context, ownerID int64, name string) (*npm_module.PackageMetadata, error) {
+	c, err := NewClient(ctx, ownerID, packages_model.TypeNpm)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := c.GetBytes(ctx, npmPackumentPath(name), "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata npm_module.PackageMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, err
+	}
+	if metadata.Name != name {
+		return nil, ErrNotFound
+	}
+	return &metadata, nil
+}
+
+// PullNpmPackage fetches a version of a package from the upstream registry and stores it as cached version.
+// The tarball is validated like an uploaded package.
+func PullNpmPackage(ctx context.Context, owner, doer *user_model.User, name, version string) (*packages_model.PackageVersion, error) {
+	c, err := NewClient(ctx, owner.ID, packages_model.TypeNpm)
+	if err != nil {
+		return nil, err
+	}
+
+	var pv *packages_model.PackageVersion
+	err = globallock.LockAndDo(ctx, lockKey(owner.ID, packages_model.TypeNpm, name, version), func(ctx context.Context) error {
+		// another request may have pulled the version while waiting for the lock
+		pv, err = packages_model.GetVersionByNameAndVersion(ctx, owner.ID, packages_model.TypeNpm, name, version)
+		if !errors.Is(err, util.ErrNotExist) {
+			return err
+		}
+
+		data, _, err := c.GetBytes(ctx, npmPackumentPath(name), "application/json")
+		if err != nil {
+			return err
+		}
+
+		var packument struct {
+			Name     string                `json:"name"`
+			Versions map[string]json.Value `json:"versions"`
+		}
+		if err := json.Unmarshal(data, &packument); err != nil {
+			return err
+		}
+		rawVersion, ok := packument.Versions[version]
+		if !ok || packument.Name != name {
+			return ErrNotFound
+		}
+
+		var meta map[string]any
+		if err := json.Unmarshal(rawVersion, &meta); err != nil {
+			return err
+		}
+		dist, _ := meta["dist"].(map[string]any)
+		tarballURL, _ := dist["tarball"].(string)
+		if tarballURL == "" {
+			return ErrNotFound
+		}
+		// old packages have no integrity, the shasum is checked instead
+		if integrity, _ := dist["integrity"].(string); integrity == "" {
+			shasum, _ := dist["shasum"].(string)
+			hash, err := hex.DecodeString(shasum)
+			if err != nil || len(hash) == 0 {
+				return npm_module.ErrInvalidIntegrity
+			}
+			dist["integrity"] = "sha1-" + base64.StdEncoding.EncodeToString(hash)
+		}
+
+		resp, err := c.GetURL(ctx, tarballURL)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		tarball, err := io.ReadAll(io.LimitReader(resp.Body, maxNpmTarballSize+1))
+		if err != nil {
+			return err
+		}
+		if len(tarball) > maxNpmTarballSize {
+			return util.NewInvalidArgumentErrorf("tarball of %s@%s exceeds the maximum size", name, version)
+		}
+
+		// the package is parsed like a published one to validate the integrity and to extract the metadata
+		upload, err := json.Marshal(map[string]any{
+			"name":     name,
+			"versions": map[string]any{version: meta},
+			"_attachments": map[string]any{
+				"tarball": map[string]string{
+					"data": base64.StdEncoding.EncodeToString(tarball),
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		npmPackage, err := npm_module.ParsePackage(bytes.NewReader(upload))
+		if err != nil {
+			return err
+		}
+		if npmPackage.Name != name {
+			return util.NewInvalidArgumentErrorf("upstream package %s doesn't match the requested package", npmPackage.Name)
+		}
+
+		buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(npmPackage.Data))
+		if err != nil {
+			return err
+		}
+		defer buf.Close()
+
+		pv, err = createCachedVersion(
+			ctx,
+			c,
+			doer,
+			&packages_service.PackageCreationInfo{
+				PackageInfo: packages_service.PackageInfo{
+					Owner:       owner,
+					PackageType: packages_model.TypeNpm,
+					Name:        npmPackage.Name,
+					Version:     npmPackage.Version,
+				},
+				SemverCompatible: true,
+				Metadata:         npmPackage.Metadata,
+			},
+			&packages_service.PackageFileCreationInfo{
+				PackageFileInfo: packages_service.PackageFileInfo{
+					Filename: npmPackage.Filename,
+				},
+				Data:   buf,
+				IsLead: true,
+			},
+		)
+		return err
+	})
+	return pv, err
+}
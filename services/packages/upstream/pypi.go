@@ -0,0 +1,256 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package upstream
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/json"
+	packages_module "gitea.dev/modules/packages"
+	pypi_module "gitea.dev/modules/packages/pypi"
+	"gitea.dev/modules/util"
+	packages_service "gitea.dev/services/packages"
+)
+
+var (
+	pypiNormalizer     = strings.NewReplacer(".", "-", "_", "-")
+	pypiAnchorMatcher  = regexp.MustCompile(`(?is)<a\s([^>]*)>([^<]*)</a>`)
+	pypiAttrMatcher    = regexp.MustCompile(`(?s)([a-zA-Z-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	pypiFileExtensions = []string{".tar.gz", ".tar.bz2", ".tgz", ".zip", ".whl", ".egg"}
+)
+
+// PyPIFile is a file of a project listed by the simple repository API of the upstream registry
+type PyPIFile struct {
+	Filename       string
+	Version        string
+	URL            string
+	SHA256         string
+	RequiresPython string
+}
+
+// PyPIFiles returns the files of a project listed by the upstream registry.
+// The JSON form of the simple repository API is preferred, the HTML form is parsed otherwise.
+func PyPIFiles(ctx context.Context, ownerID int64, name string) ([]*PyPIFile, error) {
+	c, err := NewClient(ctx, ownerID, packages_model.TypePyPI)
+	if err != nil {
+		return nil, err
+	}
+	return pypiFiles(ctx, c, name)
+}
+
+func pypiFiles(ctx context.Context, c *Client, name string) ([]*PyPIFile, error) {
+	resp, err := c.Get(ctx, "simple/"+url.PathEscape(strings.ToLower(name))+"/", "application/vnd.pypi.simple.v1+json", "text/html;q=0.1")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxMetadataSize {
+		return nil, util.NewInvalidArgumentErrorf("project page of %s exceeds the maximum size", name)
+	}
+
+	// relative links are resolved against the page, the upstream may have redirected the request
+	base := resp.Request.URL
+
+	var files []*PyPIFile
+	if strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		files, err = parsePyPIJSON(data, base)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		files = parsePyPIHTML(data, base)
+	}
+
+	result := make([]*PyPIFile, 0, len(files))
+	for _, f := range files {
+		if f.Version = pypiVersionFromFilename(name, f.Filename); f.Version != "" {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+// https://peps.python.org/pep-0691/
+func parsePyPIJSON(data []byte, base *url.URL) ([]*PyPIFile, error) {
+	var page struct {
+		Files []struct {
+			Filename       string            `json:"filename"`
+			URL            string            `json:"url"`
+			Hashes         map[string]string `json:"hashes"`
+			RequiresPython string            `json:"requires-python"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, err
+	}
+
+	files := make([]*PyPIFile, 0, len(page.Files))
+	for _, f := range page.Files {
+		u, err := base.Parse(f.URL)
+		if err != nil {
+			continue
+		}
+		files = append(files, &PyPIFile{
+			Filename:       f.Filename,
+			URL:            u.String(),
+			SHA256:         strings.ToLower(f.Hashes["sha256"]),
+			RequiresPython: f.RequiresPython,
+		})
+	}
+	return files, nil
+}
+
+// https://peps.python.org/pep-0503/
+func parsePyPIHTML(data []byte, base *url.URL) []*PyPIFile {
+	files := make([]*PyPIFile, 0, 10)
+	for _, anchor := range pypiAnchorMatcher.FindAllSubmatch(data, -1) {
+		f := &PyPIFile{
+			Filename: strings.TrimSpace(html.UnescapeString(string(anchor[2]))),
+		}
+		for _, attr := range pypiAttrMatcher.FindAllSubmatch(anchor[1], -1) {
+			value := html.UnescapeString(string(attr[2]) + string(attr[3]))
+			switch strings.ToLower(string(attr[1])) {
+			case "href":
+				u, err := base.Parse(value)
+				if err != nil {
+					continue
+				}
+				if hash, ok := strings.CutPrefix(u.Fragment, "sha256="); ok {
+					f.SHA256 = strings.ToLower(hash)
+				}
+				u.Fragment = ""
+				f.URL = u.String()
+			case "data-requires-python":
+				f.RequiresPython = value
+			}
+		}
+		if f.URL != "" && f.Filename != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// pypiVersionFromFilename extracts the version from the name of a source distribution or wheel.
+// The project name in file names may use other separators than the normalized name.
+func pypiVersionFromFilename(name, filename string) string {
+	base := ""
+	for _, ext := range pypiFileExtensions {
+		if b, ok := strings.CutSuffix(strings.ToLower(filename), ext); ok {
+			base = filename[:len(b)]
+			break
+		}
+	}
+	if len(base) <= len(name)+1 || base[len(name)] != '-' {
+		return ""
+	}
+	if !strings.EqualFold(pypiNormalizer.Replace(base[:len(name)]), pypiNormalizer.Replace(name)) {
+		return ""
+	}
+	// wheels and eggs continue with build and platform tags after the version
+	version, _, _ := strings.Cut(base[len(name)+1:], "-")
+	// files of other projects sharing the name prefix continue with a name part instead of a version
+	if version == "" || version[0] < '0' || version[0] > '9' {
+		return ""
+	}
+	return version
+}
+
+// PullPyPIFile fetches a file of a project from the upstream registry and stores it as cached version
+func PullPyPIFile(ctx context.Context, owner, doer *user_model.User, name, version, filename string) (*packages_model.PackageVersion, error) {
+	c, err := NewClient(ctx, owner.ID, packages_model.TypePyPI)
+	if err != nil {
+		return nil, err
+	}
+
+	var pv *packages_model.PackageVersion
+	err = globallock.LockAndDo(ctx, lockKey(owner.ID, packages_model.TypePyPI, name, version), func(ctx context.Context) error {
+		// another request may have pulled the file while waiting for the lock
+		pv, err = packages_model.GetVersionByNameAndVersion(ctx, owner.ID, packages_model.TypePyPI, name, version)
+		if err == nil {
+			if _, err = packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey); !errors.Is(err, util.ErrNotExist) {
+				return err
+			}
+		} else if !errors.Is(err, util.ErrNotExist) {
+			return err
+		}
+
+		files, err := pypiFiles(ctx, c, name)
+		if err != nil {
+			return err
+		}
+		var file *PyPIFile
+		for _, f := range files {
+			if f.Filename == filename && f.Version == version {
+				file = f
+				break
+			}
+		}
+		if file == nil {
+			return ErrNotFound
+		}
+
+		resp, err := c.GetURL(ctx, file.URL)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		buf, err := packages_module.CreateHashedBufferFromReader(resp.Body)
+		if err != nil {
+			return err
+		}
+		defer buf.Close()
+
+		if file.SHA256 != "" {
+			if _, _, hashSHA256, _ := buf.Sums(); hex.EncodeToString(hashSHA256) != file.SHA256 {
+				return util.NewInvalidArgumentErrorf("hash of upstream file %s doesn't match", filename)
+			}
+			if _, err := buf.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+		}
+
+		pv, err = createCachedVersion(
+			ctx,
+			c,
+			doer,
+			&packages_service.PackageCreationInfo{
+				PackageInfo: packages_service.PackageInfo{
+					Owner:       owner,
+					PackageType: packages_model.TypePyPI,
+					Name:        name,
+					Version:     version,
+				},
+				Metadata: &pypi_module.Metadata{
+					RequiresPython: file.RequiresPython,
+				},
+			},
+			&packages_service.PackageFileCreationInfo{
+				PackageFileInfo: packages_service.PackageFileInfo{
+					Filename: filename,
+				},
+				Data:   buf,
+				IsLead: true,
+			},
+		)
+		return err
+	})
+	return pv, err
+}
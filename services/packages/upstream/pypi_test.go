@@ -0,0 +1,62 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package upstream
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPyPIVersionFromFilename(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Filename string
+		Version  string
+	}{
+		{"test-package", "test-package-1.0.1.tar.gz", "1.0.1"},
+		{"test-package", "test_package-1.0.1-py3-none-any.whl", "1.0.1"},
+		{"zope-interface", "zope.interface-6.0.zip", "6.0"},
+		{"Test-Package", "test_package-2.0rc1.tar.gz", "2.0rc1"},
+		{"test-package", "test-package-extra-1.0.tar.gz", ""},
+		{"test-package", "test-package-1.0.exe", ""},
+		{"other", "test-package-1.0.tar.gz", ""},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.Version, pypiVersionFromFilename(c.Name, c.Filename), c.Filename)
+	}
+}
+
+func TestParsePyPIHTML(t *testing.T) {
+	base, _ := url.Parse("https://pypi.example.com/simple/test-package/")
+
+	files := parsePyPIHTML([]byte(`<!DOCTYPE html><html><body>
+<a href="../../files/test_package-1.0.tar.gz#sha256=ABCDEF" data-requires-python="&gt;=3.8">test_package-1.0.tar.gz</a><br>
+<a href='https://files.example.com/test_package-1.1-py3-none-any.whl'>test_package-1.1-py3-none-any.whl</a>
+<a name="no-link">ignored</a>
+</body></html>`), base)
+
+	require.Len(t, files, 2)
+	assert.Equal(t, "test_package-1.0.tar.gz", files[0].Filename)
+	assert.Equal(t, "https://pypi.example.com/files/test_package-1.0.tar.gz", files[0].URL)
+	assert.Equal(t, "abcdef", files[0].SHA256)
+	assert.Equal(t, ">=3.8", files[0].RequiresPython)
+	assert.Equal(t, "https://files.example.com/test_package-1.1-py3-none-any.whl", files[1].URL)
+	assert.Empty(t, files[1].SHA256)
+}
+
+func TestParsePyPIJSON(t *testing.T) {
+	base, _ := url.Parse("https://pypi.example.com/simple/test-package/")
+
+	files, err := parsePyPIJSON([]byte(`{"meta":{"api-version":"1.1"},"name":"test-package","files":[
+{"filename":"test_package-1.0.tar.gz","url":"/files/test_package-1.0.tar.gz","hashes":{"sha256":"ABC"},"requires-python":">=3.9"}]}`), base)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "https://pypi.example.com/files/test_package-1.0.tar.gz", files[0].URL)
+	assert.Equal(t, "abc", files[0].SHA256)
+	assert.Equal(t, ">=3.9", files[0].RequiresPython)
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package upstream
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	packages_model "gitea.dev/models/packages"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/hostmatcher"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/proxy"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	packages_service "gitea.dev/services/packages"
+)
+
+// PropertyUpstream is the version property storing the upstream registry a cached version was pulled from
+const PropertyUpstream = "upstream.url"
+
+// maxMetadataSize is the maximum size of metadata documents like npm packuments or PyPI project pages
+const maxMetadataSize = 64 * 1024 * 1024
+
+var ErrNotFound = util.NewNotExistErrorf("package does not exist in the upstream registry")
+
+// Client requests packages from the upstream registry of an owner
+type Client struct {
+	upstream *packages_model.PackageUpstream
+	baseURL  *url.URL
+	password string
+	token    string
+	client   *http.Client
+}
+
+// NewClient returns a client for the enabled upstream registry of the owner.
+// If the owner has no upstream registry for the package type, ErrPackageUpstreamNotExist is returned.
+func NewClient(ctx context.Context, ownerID int64, packageType packages_model.Type) (*Client, error) {
+	pu, err := packages_model.GetEnabledUpstream(ctx, ownerID, packageType)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(strings.TrimSuffix(pu.URL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	password, err := pu.Password()
+	if err != nil {
+		return nil, err
+	}
+
+	allowList := hostmatcher.ParseHostMatchList("packages.UPSTREAM_ALLOWED_HOST_LIST", setting.Packages.UpstreamAllowedHostList)
+	transport := hostmatcher.NewHTTPTransport("package upstream", allowList, nil, proxy.Proxy(), setting.Proxy.ProxyURLFixed, nil)
+	transport.ResponseHeaderTimeout = time.Minute
+
+	return &Client{
+		upstream: pu,
+		baseURL:  baseURL,
+		password: password,
+		client:   &http.Client{Transport: transport},
+	}, nil
+}
+
+// URL returns the URL of the upstream registry
+func (c *Client) URL() string {
+	return c.upstream.URL
+}
+
+// ResolveURL returns the absolute URL of a path relative to the upstream registry
+func (c *Client) ResolveURL(p string) string {
+	return c.baseURL.String() + "/" + strings.TrimPrefix(p, "/")
+}
+
+// Get requests a path relative to the upstream registry
+func (c *Client) Get(ctx context.Context, p string, accept ...string) (*http.Response, error) {
+	return c.GetURL(ctx, c.ResolveURL(p), accept...)
+}
+
+// GetURL requests an absolute URL. The credentials are only sent to the host of the upstream registry.
+// Missing files result in ErrNotFound, the caller must close the body of successful responses.
+func (c *Client) GetURL(ctx context.Context, u string, accept ...string) (*http.Response, error) {
+	resp, err := c.do(ctx, u, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	// container registries require a token which is requested from the realm of the challenge
+	if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		if err := c.requestToken(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(ctx, u, accept); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		resp.Body.Close()
+		return nil, fmt.Errorf("upstream registry %s responded with status %d", c.baseURL.Host, resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func (c *Client) do(ctx context.Context, u string, accept []string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if req.URL.Host == c.baseURL.Host {
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		} else if c.upstream.Username != "" {
+			req.SetBasicAuth(c.upstream.Username, c.password)
+		}
+	}
+	return c.client.Do(req)
+}
+
+// GetBytes requests a path relative to the upstream registry and returns the content of the response
+func (c *Client) GetBytes(ctx context.Context, p string, accept ...string) ([]byte, http.Header, error) {
+	resp, err := c.Get(ctx, p, accept...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) > maxMetadataSize {
+		return nil, nil, fmt.Errorf("response of upstream registry %s exceeds the maximum size", c.baseURL.Host)
+	}
+	return data, resp.Header, nil
+}
+
+// requestToken requests a token for the Bearer challenge of a container registry
+// https://distribution.github.io/distribution/spec/auth/token/
+func (c *Client) requestToken(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("upstream registry %s requires authentication", c.baseURL.Host)
+	}
+
+	values := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if ok {
+			values[strings.ToLower(key)] = strings.Trim(value, `"`)
+		}
+	}
+
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Scheme == "" {
+		return fmt.Errorf("upstream registry %s sent an invalid authentication challenge", c.baseURL.Host)
+	}
+	query := realm.Query()
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	if values["scope"] != "" {
+		query.Set("scope", values["scope"])
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.upstream.Username != "" {
+		req.SetBasicAuth(c.upstream.Username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token request of upstream registry %s failed with status %d", c.baseURL.Host, resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1024*1024)).Decode(&token); err != nil {
+		return err
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	if c.token == "" {
+		return fmt.Errorf("token request of upstream registry %s returned no token", c.baseURL.Host)
+	}
+	return nil
+}
+
+// Creator returns the user recorded as creator of a cached version.
+// Anonymous requests can pull packages through, the ghost user is recorded then.
+func Creator(doer *user_model.User) *user_model.User {
+	if doer == nil {
+		return user_model.NewGhostUser()
+	}
+	return doer
+}
+
+func lockKey(ownerID int64, packageType packages_model.Type, name, version string) string {
+	return fmt.Sprintf("package_upstream_%d_%s_%s_%s", ownerID, packageType, strings.ToLower(name), strings.ToLower(version))
+}
+
+// createCachedVersion stores a file pulled from the upstream registry, the version gets marked as cached
+func createCachedVersion(ctx context.Context, c *Client, doer *user_model.User, pvci *packages_service.PackageCreationInfo, pfci *packages_service.PackageFileCreationInfo) (*packages_model.PackageVersion, error) {
+	if pvci.VersionProperties == nil {
+		pvci.VersionProperties = map[string]string{}
+	}
+	pvci.VersionProperties[PropertyUpstream] = c.URL()
+	pvci.Creator = Creator(doer)
+	pfci.Creator = pvci.Creator
+
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(ctx, pvci, pfci)
+	return pv, err
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/util"
+)
+
+// checkAutomationLabel rejects the labels which can't be set on the issues of the project
+func checkAutomationLabel(ctx context.Context, project *project_model.Project, labelID int64) error {
+	if labelID == 0 {
+		return nil
+	}
+	label, err := issues_model.GetLabelByID(ctx, labelID)
+	if err != nil {
+		if issues_model.IsErrLabelNotExist(err) {
+			return util.ErrorWrap(util.ErrUnprocessableContent, "label %d does not exist", labelID)
+		}
+		return err
+	}
+
+	ownerID := project.OwnerID
+	if project.RepoID != 0 {
+		if err := project.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if label.BelongsToRepo() && label.RepoID == project.RepoID {
+			return nil
+		}
+		ownerID = project.Repo.OwnerID
+	}
+	if label.BelongsToOrg() && label.OrgID == ownerID {
+		return nil
+	}
+	if label.BelongsToRepo() && project.RepoID == 0 {
+		// owner-level projects span all the repositories of the owner
+		repo, err := repo_model.GetRepositoryByID(ctx, label.RepoID)
+		if err != nil {
+			return err
+		}
+		if repo.OwnerID == ownerID {
+			return nil
+		}
+	}
+	return util.ErrorWrap(util.ErrUnprocessableContent, "label %d can't be used by project %d", labelID, project.ID)
+}
+
+// CreateAutomation adds an automation to the project
+func CreateAutomation(ctx context.Context, project *project_model.Project, a *project_model.Automation) error {
+	a.ProjectID = project.ID
+	if err := checkAutomationLabel(ctx, project, a.LabelID); err != nil {
+		return err
+	}
+	return project_model.NewAutomation(ctx, a)
+}
+
+// UpdateAutomation saves the changed event, label and column of an automation of the project
+func UpdateAutomation(ctx context.Context, project *project_model.Project, a *project_model.Automation) error {
+	if err := checkAutomationLabel(ctx, project, a.LabelID); err != nil {
+		return err
+	}
+	return project_model.UpdateAutomation(ctx, a)
+}
+
+// RunAutomations moves the issue to the columns configured by the automations of the event. For the labeled
+// event, labelIDs are the added labels, otherwise the current labels of the issue are matched.
+// Only the first matching automation of every project is applied.
+func RunAutomations(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, event project_model.AutomationEvent, labelIDs []int64) error {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	automations, err := project_model.FindAutomationsForRepo(ctx, event, issue.RepoID, issue.Repo.OwnerID)
+	if err != nil || len(automations) == 0 {
+		return err
+	}
+
+	if event != project_model.AutomationEventIssueLabeled {
+		if err := issue.LoadLabels(ctx); err != nil {
+			return err
+		}
+		labelIDs = make([]int64, 0, len(issue.Labels))
+		for _, label := range issue.Labels {
+			labelIDs = append(labelIDs, label.ID)
+		}
+	}
+	labels := container.SetOf(labelIDs...)
+
+	columnMap, err := issue.ProjectColumnMap(ctx)
+	if err != nil {
+		return err
+	}
+
+	handled := make(container.Set[int64])
+	for _, a := range automations {
+		if handled.Contains(a.ProjectID) || (a.LabelID != 0 && !labels.Contains(a.LabelID)) {
+			continue
+		}
+		columnID, inProject := columnMap[a.ProjectID]
+		if !inProject && !event.CanAddIssue() {
+			continue
+		}
+		handled.Add(a.ProjectID)
+		if columnID == a.ColumnID {
+			continue
+		}
+
+		column, err := project_model.GetColumnByIDAndProjectID(ctx, a.ColumnID, a.ProjectID)
+		if err != nil {
+			return err
+		}
+		if inProject {
+			err = MoveIssueToColumn(ctx, doer, issue, column, optional.None[int64]())
+		} else {
+			err = AddIssueToColumn(ctx, doer, issue, column)
+		}
+		if err != nil {
+			return err
+		}
+		log.Trace("Project automation %d moved issue %d to column %d", a.ID, issue.ID, column.ID)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAutomations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	// issue1 of repo1 is in column 1 of project 1 and has label 1
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	project1 := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 1})
+	project4 := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 4})
+
+	columnOf := func(projectID int64) (int64, bool) {
+		columns, err := issue.ProjectColumnMap(t.Context())
+		require.NoError(t, err)
+		columnID, ok := columns[projectID]
+		return columnID, ok
+	}
+
+	t.Run("MoveIssueInProject", func(t *testing.T) {
+		// the first matching automation wins
+		require.NoError(t, CreateAutomation(t.Context(), project1, &project_model.Automation{Event: project_model.AutomationEventIssueClosed, ColumnID: 3, CreatorID: 2}))
+		require.NoError(t, CreateAutomation(t.Context(), project1, &project_model.Automation{Event: project_model.AutomationEventIssueClosed, ColumnID: 2, CreatorID: 2}))
+
+		require.NoError(t, RunAutomations(t.Context(), doer, issue, project_model.AutomationEventIssueClosed, nil))
+		columnID, _ := columnOf(1)
+		assert.EqualValues(t, 3, columnID)
+	})
+
+	t.Run("OnlyAddingEventsAddIssues", func(t *testing.T) {
+		require.NoError(t, CreateAutomation(t.Context(), project4, &project_model.Automation{Event: project_model.AutomationEventIssueReopened, ColumnID: 4, CreatorID: 2}))
+		require.NoError(t, RunAutomations(t.Context(), doer, issue, project_model.AutomationEventIssueReopened, nil))
+		_, ok := columnOf(4)
+		assert.False(t, ok)
+
+		// the issue doesn't have label 2
+		require.NoError(t, CreateAutomation(t.Context(), project4, &project_model.Automation{Event: project_model.AutomationEventIssueLabeled, LabelID: 2, ColumnID: 6, CreatorID: 2}))
+		require.NoError(t, CreateAutomation(t.Context(), project4, &project_model.Automation{Event: project_model.AutomationEventIssueLabeled, LabelID: 1, ColumnID: 4, CreatorID: 2}))
+		require.NoError(t, RunAutomations(t.Context(), doer, issue, project_model.AutomationEventIssueLabeled, []int64{1}))
+		columnID, ok := columnOf(4)
+		assert.True(t, ok)
+		assert.EqualValues(t, 4, columnID)
+	})
+
+	t.Run("LabelScope", func(t *testing.T) {
+		// label 1 belongs to repo1 of user2
+		err := CreateAutomation(t.Context(), project4, &project_model.Automation{Event: project_model.AutomationEventIssueOpened, LabelID: 1, ColumnID: 4, CreatorID: 2})
+		assert.NoError(t, err)
+		project2 := unittest.AssertExistsAndLoadBean(t, &project_model.Project{ID: 2})
+		err = CreateAutomation(t.Context(), project2, &project_model.Automation{Event: project_model.AutomationEventIssueOpened, LabelID: 1, ColumnID: 5, CreatorID: 2})
+		assert.ErrorIs(t, err, util.ErrUnprocessableContent)
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+	user_model "gitea.dev/models/user"
+	issue_indexer "gitea.dev/modules/indexer/issues"
+)
+
+// CreateField adds a custom field to the project
+func CreateField(ctx context.Context, doer *user_model.User, project *project_model.Project, f *project_model.Field) error {
+	f.ProjectID = project.ID
+	f.CreatorID = doer.ID
+	return project_model.NewField(ctx, f)
+}
+
+// reindexFieldIssues updates the indexed field values of the issues which had a value for the field
+func reindexFieldIssues(ctx context.Context, f *project_model.Field, change func() error) error {
+	issueIDs, err := project_model.GetFieldIssueIDs(ctx, f.ID)
+	if err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	for _, issueID := range issueIDs {
+		issue_indexer.UpdateIssueIndexer(ctx, issueID)
+	}
+	return nil
+}
+
+// UpdateField saves the name and the options of the field, removing an option clears the values using it
+func UpdateField(ctx context.Context, f *project_model.Field) error {
+	return reindexFieldIssues(ctx, f, func() error {
+		return project_model.UpdateField(ctx, f)
+	})
+}
+
+// DeleteField deletes the field and its values
+func DeleteField(ctx context.Context, f *project_model.Field) error {
+	return reindexFieldIssues(ctx, f, func() error {
+		return project_model.DeleteFieldByID(ctx, f.ID)
+	})
+}
+
+// SetIssueFieldValue sets the value of the field for an issue of the project, an empty value clears it
+func SetIssueFieldValue(ctx context.Context, f *project_model.Field, issue *issues_model.Issue, value string) error {
+	if err := project_model.SetIssueFieldValue(ctx, f, issue.ID, value); err != nil {
+		return err
+	}
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"context"
+
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/references"
+	notify_service "gitea.dev/services/notify"
+)
+
+func init() {
+	notify_service.RegisterNotifier(&automationNotifier{})
+}
+
+// automationNotifier runs the project automations when the issues change
+type automationNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &automationNotifier{}
+
+func runAutomations(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, event project_model.AutomationEvent, labelIDs []int64) {
+	if err := RunAutomations(ctx, doer, issue, event, labelIDs); err != nil {
+		log.Error("Failed to run the %s project automations of issue %d: %v", event, issue.ID, err)
+	}
+}
+
+func (*automationNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, _ []*user_model.User) {
+	if err := issue.LoadPoster(ctx); err != nil {
+		log.Error("LoadPoster: %v", err)
+		return
+	}
+	runAutomations(ctx, issue.Poster, issue, project_model.AutomationEventIssueOpened, nil)
+}
+
+func (n *automationNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	n.NewIssue(ctx, pr.Issue, mentions)
+}
+
+func (*automationNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, _ string, issue *issues_model.Issue, _ *issues_model.Comment, isClosed bool) {
+	if isClosed {
+		runAutomations(ctx, doer, issue, project_model.AutomationEventIssueClosed, nil)
+	} else {
+		runAutomations(ctx, doer, issue, project_model.AutomationEventIssueReopened, nil)
+	}
+}
+
+func (*automationNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, addedLabels, removedLabels []*issues_model.Label) {
+	// replacing the labels reports all the new labels as added, the kept ones haven't been added
+	removed := make(container.Set[int64], len(removedLabels))
+	for _, label := range removedLabels {
+		removed.Add(label.ID)
+	}
+	labelIDs := make([]int64, 0, len(addedLabels))
+	for _, label := range addedLabels {
+		if !removed.Contains(label.ID) {
+			labelIDs = append(labelIDs, label.ID)
+		}
+	}
+	if len(labelIDs) > 0 {
+		runAutomations(ctx, doer, issue, project_model.AutomationEventIssueLabeled, labelIDs)
+	}
+}
+
+func (*automationNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		log.Error("LoadIssue: %v", err)
+		return
+	}
+	runAutomations(ctx, doer, pr.Issue, project_model.AutomationEventPullRequestMerged, nil)
+
+	refs, err := pr.ResolveCrossReferences(ctx)
+	if err != nil {
+		log.Error("ResolveCrossReferences: %v", err)
+		return
+	}
+	for _, ref := range refs {
+		if ref.RefAction != references.XRefActionCloses {
+			continue
+		}
+		if err := ref.LoadIssue(ctx); err != nil {
+			log.Error("LoadIssue: %v", err)
+			continue
+		}
+		runAutomations(ctx, doer, ref.Issue, project_model.AutomationEventPullRequestMerged, nil)
+	}
+}
+
+func (n *automationNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	n.MergePullRequest(ctx, doer, pr)
+}
@@ -0,0 +1,346 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+)
+
+// ViewGroup is a group of the issues of a table view
+type ViewGroup struct {
+	Title string
+	// NoValue marks the group of the issues without a value for the grouping key
+	NoValue bool
+	Issues  issues_model.IssueList
+
+	key       string
+	orderNum  float64
+	orderText string
+}
+
+// ViewData is what a table or roadmap view shows besides the issues: their columns and custom field values
+type ViewData struct {
+	Fields    []*project_model.Field
+	Columns   project_model.ColumnList
+	IssuesMap map[int64]issues_model.IssueList
+	// Values maps the issue IDs to their values by field ID, as returned by project_model.GetFieldValuesMap
+	Values map[int64]map[int64]string
+}
+
+// Issues returns the issues in the order of the board: column by column
+func (d *ViewData) Issues() issues_model.IssueList {
+	var issues issues_model.IssueList
+	for _, column := range d.Columns {
+		issues = append(issues, d.IssuesMap[column.ID]...)
+	}
+	return issues
+}
+
+func (d *ViewData) field(key string) *project_model.Field {
+	fieldID := project_model.ParseViewFieldKey(key)
+	for _, f := range d.Fields {
+		if f.ID == fieldID {
+			return f
+		}
+	}
+	return nil
+}
+
+func (d *ViewData) value(issueID int64, f *project_model.Field) string {
+	return d.Values[issueID][f.ID]
+}
+
+// issueSorter compares the issues by one sort key, the issues without a value are never compared
+type issueSorter struct {
+	missing func(*issues_model.Issue) bool
+	compare func(a, b *issues_model.Issue) int
+}
+
+func milestoneDeadline(m *issues_model.Milestone) float64 {
+	if m.DeadlineUnix == 0 {
+		return float64(^uint64(0) >> 1)
+	}
+	return float64(m.DeadlineUnix)
+}
+
+func (d *ViewData) sorter(key string) *issueSorter {
+	switch key {
+	case project_model.ViewKeyTitle:
+		return &issueSorter{
+			missing: func(*issues_model.Issue) bool { return false },
+			compare: func(a, b *issues_model.Issue) int {
+				return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+			},
+		}
+	case project_model.ViewKeyAssignee:
+		return &issueSorter{
+			missing: func(issue *issues_model.Issue) bool { return len(issue.Assignees) == 0 },
+			compare: func(a, b *issues_model.Issue) int {
+				return strings.Compare(strings.ToLower(a.Assignees[0].Name), strings.ToLower(b.Assignees[0].Name))
+			},
+		}
+	case project_model.ViewKeyMilestone:
+		return &issueSorter{
+			missing: func(issue *issues_model.Issue) bool { return issue.Milestone == nil },
+			compare: func(a, b *issues_model.Issue) int {
+				return cmp.Or(
+					cmp.Compare(milestoneDeadline(a.Milestone), milestoneDeadline(b.Milestone)),
+					strings.Compare(strings.ToLower(a.Milestone.Name), strings.ToLower(b.Milestone.Name)),
+				)
+			},
+		}
+	case project_model.ViewKeyCreated:
+		return &issueSorter{
+			missing: func(*issues_model.Issue) bool { return false },
+			compare: func(a, b *issues_model.Issue) int { return cmp.Compare(a.CreatedUnix, b.CreatedUnix) },
+		}
+	case project_model.ViewKeyUpdated:
+		return &issueSorter{
+			missing: func(*issues_model.Issue) bool { return false },
+			compare: func(a, b *issues_model.Issue) int { return cmp.Compare(a.UpdatedUnix, b.UpdatedUnix) },
+		}
+	}
+	if f := d.field(key); f != nil {
+		return &issueSorter{
+			missing: func(issue *issues_model.Issue) bool { return d.value(issue.ID, f) == "" },
+			compare: func(a, b *issues_model.Issue) int { return f.CompareValues(d.value(a.ID, f), d.value(b.ID, f)) },
+		}
+	}
+	return nil
+}
+
+// sortIssues sorts the issues by the key, the issues without a value come last in both directions
+func (d *ViewData) sortIssues(issues issues_model.IssueList, key string, desc bool) {
+	sorter := d.sorter(key)
+	if sorter == nil {
+		return
+	}
+	slices.SortStableFunc(issues, func(a, b *issues_model.Issue) int {
+		aMissing, bMissing := sorter.missing(a), sorter.missing(b)
+		switch {
+		case aMissing && bMissing:
+			return 0
+		case aMissing:
+			return 1
+		case bMissing:
+			return -1
+		}
+		if desc {
+			return sorter.compare(b, a)
+		}
+		return sorter.compare(a, b)
+	})
+}
+
+// issueGroups returns the groups of an issue for the key, an issue is in several groups when it
+// has several assignees or labels. The returned groups don't hold any issue yet.
+func (d *ViewData) issueGroups(issue *issues_model.Issue, key string, issueColumns map[int64]int) []*ViewGroup {
+	switch key {
+	case project_model.ViewKeyColumn:
+		if index, ok := issueColumns[issue.ID]; ok {
+			column := d.Columns[index]
+			return []*ViewGroup{{key: strconv.FormatInt(column.ID, 10), Title: column.Title, orderNum: float64(index)}}
+		}
+	case project_model.ViewKeyRepository:
+		if issue.Repo != nil {
+			return []*ViewGroup{{key: strconv.FormatInt(issue.Repo.ID, 10), Title: issue.Repo.FullName(), orderText: strings.ToLower(issue.Repo.FullName())}}
+		}
+	case project_model.ViewKeyAssignee:
+		groups := make([]*ViewGroup, 0, len(issue.Assignees))
+		for _, assignee := range issue.Assignees {
+			groups = append(groups, &ViewGroup{key: strconv.FormatInt(assignee.ID, 10), Title: assignee.Name, orderText: strings.ToLower(assignee.Name)})
+		}
+		return groups
+	case project_model.ViewKeyMilestone:
+		if issue.Milestone != nil {
+			return []*ViewGroup{{
+				key:       strconv.FormatInt(issue.Milestone.ID, 10),
+				Title:     issue.Milestone.Name,
+				orderNum:  milestoneDeadline(issue.Milestone),
+				orderText: strings.ToLower(issue.Milestone.Name),
+			}}
+		}
+	case project_model.ViewKeyLabel:
+		groups := make([]*ViewGroup, 0, len(issue.Labels))
+		for _, label := range issue.Labels {
+			groups = append(groups, &ViewGroup{key: strconv.FormatInt(label.ID, 10), Title: label.Name, orderText: strings.ToLower(label.Name)})
+		}
+		return groups
+	default:
+		f := d.field(key)
+		value := d.value(issue.ID, f)
+		if value == "" {
+			return nil
+		}
+		group := &ViewGroup{key: value, Title: f.DisplayValue(value)}
+		switch f.Type {
+		case project_model.FieldTypeNumber:
+			group.orderNum, _ = strconv.ParseFloat(value, 64)
+		case project_model.FieldTypeSingleSelect, project_model.FieldTypeIteration:
+			group.orderNum = float64(slices.IndexFunc(f.Options, func(option *project_model.FieldOption) bool {
+				return strconv.FormatInt(option.ID, 10) == value
+			}))
+		default:
+			group.orderText = strings.ToLower(value)
+		}
+		return []*ViewGroup{group}
+	}
+	return nil
+}
+
+// TableGroups returns the issues of a table view sorted and grouped by the settings of the view.
+// A view without grouping has a single group.
+func (d *ViewData) TableGroups(view *project_model.View) []*ViewGroup {
+	issues := d.Issues()
+	d.sortIssues(issues, view.SortBy, view.SortDesc)
+
+	if !slices.Contains(project_model.ViewGroupKeys(), view.GroupBy) && d.field(view.GroupBy) == nil {
+		return []*ViewGroup{{Issues: issues}}
+	}
+
+	issueColumns := make(map[int64]int, len(issues))
+	for i, column := range d.Columns {
+		for _, issue := range d.IssuesMap[column.ID] {
+			issueColumns[issue.ID] = i
+		}
+	}
+	groups := make(map[string]*ViewGroup)
+	noValue := &ViewGroup{NoValue: true}
+	for _, issue := range issues {
+		issueGroups := d.issueGroups(issue, view.GroupBy, issueColumns)
+		if len(issueGroups) == 0 {
+			noValue.Issues = append(noValue.Issues, issue)
+			continue
+		}
+		for _, g := range issueGroups {
+			if group, ok := groups[g.key]; ok {
+				group.Issues = append(group.Issues, issue)
+				continue
+			}
+			g.Issues = issues_model.IssueList{issue}
+			groups[g.key] = g
+		}
+	}
+
+	result := make([]*ViewGroup, 0, len(groups)+1)
+	for _, group := range groups {
+		result = append(result, group)
+	}
+	slices.SortFunc(result, func(a, b *ViewGroup) int {
+		return cmp.Or(cmp.Compare(a.orderNum, b.orderNum), strings.Compare(a.orderText, b.orderText), strings.Compare(a.Title, b.Title))
+	})
+	if len(noValue.Issues) > 0 {
+		result = append(result, noValue)
+	}
+	return result
+}
+
+// RoadmapItem is an issue placed on a roadmap, Offset and Width are percentages of the roadmap's time range
+type RoadmapItem struct {
+	Issue  *issues_model.Issue
+	Start  time.Time
+	End    time.Time
+	Offset float64
+	Width  float64
+}
+
+// RoadmapMonth marks the first day of a month shown on a roadmap
+type RoadmapMonth struct {
+	Start  time.Time
+	Offset float64
+}
+
+// Roadmap is the timeline of a roadmap view, its dates are days in UTC
+type Roadmap struct {
+	Start   time.Time
+	End     time.Time
+	Items   []*RoadmapItem
+	Months  []*RoadmapMonth
+	Undated issues_model.IssueList
+}
+
+// issueDates returns the inclusive date range of an issue on a roadmap: the range of its date or iteration
+// field, or from its creation to the deadline of its milestone
+func (d *ViewData) issueDates(issue *issues_model.Issue, dateField *project_model.Field) (start, end time.Time, ok bool) {
+	var startDate, endDate string
+	if dateField != nil {
+		if startDate, endDate, ok = dateField.DateRange(d.value(issue.ID, dateField)); !ok {
+			return start, end, false
+		}
+	} else {
+		if issue.Milestone == nil || issue.Milestone.DeadlineUnix == 0 {
+			return start, end, false
+		}
+		startDate = issue.CreatedUnix.AsTime().Format(project_model.FieldDateFormat)
+		endDate = issue.Milestone.DeadlineUnix.AsTime().Format(project_model.FieldDateFormat)
+	}
+
+	start, err := time.Parse(project_model.FieldDateFormat, startDate)
+	if err != nil {
+		return start, end, false
+	}
+	end, err = time.Parse(project_model.FieldDateFormat, endDate)
+	if err != nil {
+		return start, end, false
+	}
+	if start.After(end) {
+		// an issue created after the deadline of its milestone is placed on the deadline
+		start = end
+	}
+	return start, end, true
+}
+
+const day = 24 * time.Hour
+
+// RoadmapOf places the issues of a roadmap view on a timeline by the date field of the view, or by the deadline of
+// their milestone if the view has no date field. The issues without dates are listed apart.
+func (d *ViewData) RoadmapOf(view *project_model.View) *Roadmap {
+	var dateField *project_model.Field
+	if view.DateFieldID != 0 {
+		if dateField = d.field(project_model.ViewFieldKey(view.DateFieldID)); dateField == nil {
+			return &Roadmap{Undated: d.Issues()}
+		}
+	}
+
+	roadmap := &Roadmap{}
+	for _, issue := range d.Issues() {
+		start, end, ok := d.issueDates(issue, dateField)
+		if !ok {
+			roadmap.Undated = append(roadmap.Undated, issue)
+			continue
+		}
+		if len(roadmap.Items) == 0 || start.Before(roadmap.Start) {
+			roadmap.Start = start
+		}
+		if len(roadmap.Items) == 0 || end.After(roadmap.End) {
+			roadmap.End = end
+		}
+		roadmap.Items = append(roadmap.Items, &RoadmapItem{Issue: issue, Start: start, End: end})
+	}
+	if len(roadmap.Items) == 0 {
+		return roadmap
+	}
+
+	// the timeline starts on the first day of a month to show a month marker at its beginning
+	roadmap.Start = time.Date(roadmap.Start.Year(), roadmap.Start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	total := float64(roadmap.End.Sub(roadmap.Start) + day)
+	for _, item := range roadmap.Items {
+		item.Offset = float64(item.Start.Sub(roadmap.Start)) / total * 100
+		item.Width = float64(item.End.Sub(item.Start)+day) / total * 100
+	}
+	slices.SortStableFunc(roadmap.Items, func(a, b *RoadmapItem) int {
+		return cmp.Or(a.Start.Compare(b.Start), a.End.Compare(b.End))
+	})
+	for month := roadmap.Start; !month.After(roadmap.End); month = month.AddDate(0, 1, 0) {
+		roadmap.Months = append(roadmap.Months, &RoadmapMonth{Start: month, Offset: float64(month.Sub(roadmap.Start)) / total * 100})
+	}
+	return roadmap
+}
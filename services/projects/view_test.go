@@ -0,0 +1,118 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"testing"
+	"time"
+
+	issues_model "gitea.dev/models/issues"
+	project_model "gitea.dev/models/project"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestViewData() *ViewData {
+	alice := &user_model.User{ID: 1, Name: "alice"}
+	bob := &user_model.User{ID: 2, Name: "bob"}
+	// noon keeps the dates the same in the time zone the tests run in
+	deadline, _ := time.Parse(time.DateTime, "2026-04-30 12:00:00")
+	milestone := &issues_model.Milestone{ID: 1, Name: "v1", DeadlineUnix: timeutil.TimeStamp(deadline.Unix())}
+	created, _ := time.Parse(time.DateTime, "2026-04-01 12:00:00")
+
+	issue1 := &issues_model.Issue{ID: 1, Title: "b", Assignees: []*user_model.User{bob}, CreatedUnix: timeutil.TimeStamp(created.Unix())}
+	issue2 := &issues_model.Issue{ID: 2, Title: "a", Assignees: []*user_model.User{alice, bob}, Milestone: milestone, CreatedUnix: timeutil.TimeStamp(created.Unix())}
+	issue3 := &issues_model.Issue{ID: 3, Title: "c", CreatedUnix: timeutil.TimeStamp(created.Unix())}
+
+	return &ViewData{
+		Fields: []*project_model.Field{
+			{ID: 1, Name: "Estimate", Type: project_model.FieldTypeNumber},
+			{ID: 2, Name: "Sprint", Type: project_model.FieldTypeIteration, Options: []*project_model.FieldOption{
+				{ID: 1, Name: "Sprint 1", StartDate: "2026-03-01", EndDate: "2026-03-14"},
+				{ID: 2, Name: "Sprint 2", StartDate: "2026-03-15", EndDate: "2026-03-28"},
+			}},
+		},
+		Columns:   project_model.ColumnList{{ID: 1, Title: "Todo"}, {ID: 2, Title: "Done"}},
+		IssuesMap: map[int64]issues_model.IssueList{1: {issue1, issue2}, 2: {issue3}},
+		Values: map[int64]map[int64]string{
+			1: {1: "8", 2: "2"},
+			3: {1: "10", 2: "1"},
+		},
+	}
+}
+
+func issueIDs(issues issues_model.IssueList) []int64 {
+	ids := make([]int64, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+func TestViewTableGroups(t *testing.T) {
+	data := newTestViewData()
+
+	groups := data.TableGroups(&project_model.View{})
+	if assert.Len(t, groups, 1) {
+		assert.Equal(t, []int64{1, 2, 3}, issueIDs(groups[0].Issues))
+	}
+
+	// the issues without a value come last in both directions
+	groups = data.TableGroups(&project_model.View{SortBy: project_model.ViewFieldKey(1)})
+	assert.Equal(t, []int64{1, 3, 2}, issueIDs(groups[0].Issues))
+	groups = data.TableGroups(&project_model.View{SortBy: project_model.ViewFieldKey(1), SortDesc: true})
+	assert.Equal(t, []int64{3, 1, 2}, issueIDs(groups[0].Issues))
+
+	// an issue with several assignees is in all their groups
+	groups = data.TableGroups(&project_model.View{GroupBy: project_model.ViewKeyAssignee, SortBy: project_model.ViewKeyTitle})
+	if assert.Len(t, groups, 3) {
+		assert.Equal(t, "alice", groups[0].Title)
+		assert.Equal(t, []int64{2}, issueIDs(groups[0].Issues))
+		assert.Equal(t, "bob", groups[1].Title)
+		assert.Equal(t, []int64{2, 1}, issueIDs(groups[1].Issues))
+		assert.True(t, groups[2].NoValue)
+		assert.Equal(t, []int64{3}, issueIDs(groups[2].Issues))
+	}
+
+	// the options are grouped in the order of the field
+	groups = data.TableGroups(&project_model.View{GroupBy: project_model.ViewFieldKey(2)})
+	if assert.Len(t, groups, 3) {
+		assert.Equal(t, "Sprint 1", groups[0].Title)
+		assert.Equal(t, []int64{3}, issueIDs(groups[0].Issues))
+		assert.Equal(t, "Sprint 2", groups[1].Title)
+		assert.True(t, groups[2].NoValue)
+	}
+
+	groups = data.TableGroups(&project_model.View{GroupBy: project_model.ViewKeyColumn})
+	if assert.Len(t, groups, 2) {
+		assert.Equal(t, "Todo", groups[0].Title)
+		assert.Equal(t, "Done", groups[1].Title)
+	}
+}
+
+func TestViewRoadmap(t *testing.T) {
+	data := newTestViewData()
+
+	roadmap := data.RoadmapOf(&project_model.View{DateFieldID: 2})
+	if assert.Len(t, roadmap.Items, 2) {
+		// sprint 1 of issue 3 comes before sprint 2 of issue 1
+		assert.EqualValues(t, 3, roadmap.Items[0].Issue.ID)
+		assert.EqualValues(t, 1, roadmap.Items[1].Issue.ID)
+		assert.Zero(t, roadmap.Items[0].Offset)
+		assert.InDelta(t, 50, roadmap.Items[1].Offset, 0.01)
+		assert.InDelta(t, 50, roadmap.Items[1].Width, 0.01)
+	}
+	assert.Equal(t, []int64{2}, issueIDs(roadmap.Undated))
+	assert.Len(t, roadmap.Months, 1)
+
+	// without a date field the issues end on the deadline of their milestone
+	roadmap = data.RoadmapOf(&project_model.View{})
+	if assert.Len(t, roadmap.Items, 1) {
+		assert.EqualValues(t, 2, roadmap.Items[0].Issue.ID)
+		assert.Equal(t, "2026-04-30", roadmap.Items[0].End.Format(time.DateOnly))
+	}
+	assert.Equal(t, []int64{1, 3}, issueIDs(roadmap.Undated))
+}
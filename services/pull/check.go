@@ -45,6 +45,7 @@ var (
 	ErrIsChecking                = errors.New("cannot merge while conflict checking is in progress")
 	ErrNotMergeableState         = errors.New("not in mergeable state")
 	ErrDependenciesLeft          = errors.New("is blocked by an open dependency")
+	ErrStackParentNotMerged      = errors.New("is stacked on a pull request which has not been merged yet")
 	ErrHeadCommitsNotAllVerified = errors.New("the branch requires signed commits but not all head commits are verified")
 )
 
@@ -225,6 +226,16 @@ func CheckPullMergeable(stdCtx context.Context, doer *user_model.User, perm *acc
 			return ErrDependenciesLeft
 		}
 
+		// A stacked pull request can only be merged after its parent. Auto Merge may still be scheduled: once the parent
+		// merges, the stacked pull request is retargeted, and the conflict check that follows starts the scheduled merge again.
+		if mergeCheckType != MergeCheckTypeAuto {
+			if parent, err := issues_model.GetStackParentPullRequest(ctx, pr); err != nil {
+				return err
+			} else if parent != nil {
+				return ErrStackParentNotMerged
+			}
+		}
+
 		return nil
 	})
 }
@@ -433,6 +444,8 @@ func manuallyMerged(ctx context.Context, pr *issues_model.PullRequest) bool {
 	notify_service.MergePullRequest(ctx, merger, pr)
 
 	log.Info("manuallyMerged[%-v]: Marked as manually merged into %s/%s by commit id: %s", pr, pr.BaseRepo.Name, pr.BaseBranch, commit.ID.String())
+
+	retargetStackedPulls(ctx, merger, pr)
 	return true
 }
 
@@ -311,6 +311,8 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 
 	// Reset cached commit count
 	git.RemoveCommitsCountCache(pr.Issue.Repo, git.RefNameFromBranch(pr.BaseBranch))
+
+	retargetStackedPulls(ctx, doer, pr)
 	return handleCloseCrossReferences(ctx, pr, doer)
 }
 
@@ -345,6 +347,22 @@ func handleCloseCrossReferences(ctx context.Context, pr *issues_model.PullReques
 	return nil
 }
 
+// doMergeStyle merges "tracking" into "base" of the temporary repository with the given merge style
+func doMergeStyle(mergeCtx *mergeContext, mergeStyle repo_model.MergeStyle, message string) error {
+	switch mergeStyle {
+	case repo_model.MergeStyleMerge:
+		return doMergeStyleMerge(mergeCtx, message)
+	case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
+		return doMergeStyleRebase(mergeCtx, mergeStyle, message)
+	case repo_model.MergeStyleSquash:
+		return doMergeStyleSquash(mergeCtx, message)
+	case repo_model.MergeStyleFastForwardOnly:
+		return doMergeStyleFastForwardOnly(mergeCtx)
+	default:
+		return ErrInvalidMergeStyle{ID: mergeCtx.pr.BaseRepo.ID, Style: mergeStyle}
+	}
+}
+
 // doMergeAndPush performs the merge operation without changing any pull information in database and pushes it up to the base repository
 func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, mergeStyle repo_model.MergeStyle, expectedHeadCommitID, message string, pushTrigger repo_module.PushTrigger) (string, error) { //nolint:unparam // non-error result is never used
 	// Clone base repo.
@@ -355,25 +373,8 @@ func doMergeAndPush(ctx context.Context, pr *issues_model.PullRequest, doer *use
 	defer cancel()
 
 	// Merge commits.
-	switch mergeStyle {
-	case repo_model.MergeStyleMerge:
-		if err := doMergeStyleMerge(mergeCtx, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
-		if err := doMergeStyleRebase(mergeCtx, mergeStyle, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleSquash:
-		if err := doMergeStyleSquash(mergeCtx, message); err != nil {
-			return "", err
-		}
-	case repo_model.MergeStyleFastForwardOnly:
-		if err := doMergeStyleFastForwardOnly(mergeCtx); err != nil {
-			return "", err
-		}
-	default:
-		return "", ErrInvalidMergeStyle{ID: pr.BaseRepo.ID, Style: mergeStyle}
+	if err := doMergeStyle(mergeCtx, mergeStyle, message); err != nil {
+		return "", err
 	}
 
 	// OK we should cache our current head and origin/headbranch
@@ -694,6 +695,7 @@ func MergedManually(ctx context.Context, pr *issues_model.PullRequest, doer *use
 	notify_service.MergePullRequest(ctx, doer, pr)
 	log.Info("manuallyMerged[%d]: Marked as manually merged into %s/%s by commit id: %s", pr.ID, pr.BaseRepo.Name, pr.BaseBranch, commitID)
 
+	retargetStackedPulls(ctx, doer, pr)
 	return handleCloseCrossReferences(ctx, pr, doer)
 }
 
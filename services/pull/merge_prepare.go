@@ -61,8 +61,12 @@ func (err ErrSHADoesNotMatch) Error() string {
 }
 
 func createTemporaryRepoForMerge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID string) (mergeCtx *mergeContext, cancel context.CancelFunc, err error) {
+	return createTemporaryRepoForMergeOnBaseRef(ctx, pr, doer, expectedHeadCommitID, git.BranchPrefix+pr.BaseBranch)
+}
+
+func createTemporaryRepoForMergeOnBaseRef(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, expectedHeadCommitID, baseRef string) (mergeCtx *mergeContext, cancel context.CancelFunc, err error) {
 	// Clone base repo.
-	prCtx, cancel, err := createTemporaryRepoForPR(ctx, pr)
+	prCtx, cancel, err := createTemporaryRepoForPROnBaseRef(ctx, pr, baseRef)
 	if err != nil {
 		log.Error("createTemporaryRepoForPR: %v", err)
 		return nil, cancel, err
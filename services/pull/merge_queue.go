@@ -0,0 +1,136 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gitea.dev/models/db"
+	git_model "gitea.dev/models/git"
+	issues_model "gitea.dev/models/issues"
+	pull_model "gitea.dev/models/pull"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/commitstatus"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/log"
+	repo_module "gitea.dev/modules/repository"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	notify_service "gitea.dev/services/notify"
+)
+
+// SpeculativeMerge merges the pull request onto baseRef of the base repository without touching the base branch,
+// and stores the result in targetRef. It returns the resulting commit ID.
+func SpeculativeMerge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, mergeStyle repo_model.MergeStyle, message, baseRef, targetRef string) (string, error) {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return "", fmt.Errorf("LoadBaseRepo: %w", err)
+	}
+
+	mergeCtx, cancel, err := createTemporaryRepoForMergeOnBaseRef(ctx, pr, doer, "", baseRef)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+
+	if err := doMergeStyle(mergeCtx, mergeStyle, message); err != nil {
+		return "", err
+	}
+
+	mergeHeadSHA, err := git.GetFullCommitID(ctx, mergeCtx.tmpRepo, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to get full commit id for HEAD: %w", err)
+	}
+	mergeBaseSHA, err := git.GetFullCommitID(ctx, mergeCtx.tmpRepo, "original_"+tmpRepoBaseBranch)
+	if err != nil {
+		return "", fmt.Errorf("failed to get full commit id for %s: %w", baseRef, err)
+	}
+	mergeCommitID, err := git.GetFullCommitID(ctx, mergeCtx.tmpRepo, tmpRepoBaseBranch)
+	if err != nil {
+		return "", fmt.Errorf("failed to get full commit id for the new merge: %w", err)
+	}
+
+	if setting.LFS.StartServer {
+		if err := LFSPush(ctx, mergeCtx.tmpBasePath, mergeCtx.tmpRepo, mergeHeadSHA, mergeBaseSHA, pr); err != nil {
+			return "", err
+		}
+	}
+
+	// The merge queue refs are not branches, so push them without running the hooks
+	mergeCtx.env = repo_module.InternalPushingEnvironment(doer, pr.BaseRepo)
+	pushCmd := gitcmd.NewCommand("push", "-f", "origin").AddDynamicArguments(tmpRepoBaseBranch + ":" + targetRef)
+	if err := mergeCtx.PrepareGitCmd(pushCmd).RunWithStderr(ctx); err != nil {
+		return "", fmt.Errorf("git push: %s", err.Stderr())
+	}
+	mergeCtx.outbuf.Reset()
+	return mergeCommitID, nil
+}
+
+// FastForwardMergeQueue moves the base branch to the speculative merge result of the given merge queue entry.
+// The push is done as a merge of lastPR, so the protected branch rules are checked against it by the hooks.
+func FastForwardMergeQueue(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, lastPR *issues_model.PullRequest, entry *pull_model.MergeQueueEntry) error {
+	if err := git.PushManaged(ctx, repo, repo, git.PushOptions{
+		LocalRefName: entry.MergeCommitID,
+		Branch:       git.BranchPrefix + entry.BaseBranch,
+		Env:          repo_module.FullPushingEnvironment(doer, doer, repo, repo.Name, lastPR.ID, lastPR.Index),
+	}); err != nil {
+		return err
+	}
+
+	git.RemoveCommitsCountCache(repo, git.RefNameFromBranch(entry.BaseBranch))
+	return nil
+}
+
+// SetMergedByMergeQueue marks a pull request as merged after the base branch has been fast-forwarded
+// to a merge queue result containing it
+func SetMergedByMergeQueue(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, entry *pull_model.MergeQueueEntry) error {
+	merged, err := SetMerged(ctx, pr, entry.MergeCommitID, timeutil.TimeStampNow(), doer, issues_model.PullRequestStatusMergeable)
+	if err != nil {
+		return err
+	} else if !merged {
+		return nil
+	}
+
+	notify_service.MergePullRequest(ctx, doer, pr)
+	log.Info("mergeQueue[%d]: Marked as merged into %s/%s by commit id: %s", pr.ID, pr.BaseRepo.Name, pr.BaseBranch, entry.MergeCommitID)
+
+	retargetStackedPulls(ctx, doer, pr)
+	return handleCloseCrossReferences(ctx, pr, doer)
+}
+
+// RemoveMergeQueueRef deletes the temporary ref of a merge queue entry if it exists
+func RemoveMergeQueueRef(ctx context.Context, repo *repo_model.Repository, entry *pull_model.MergeQueueEntry) {
+	if !git.IsReferenceExist(ctx, repo, entry.GetRefName()) {
+		return
+	}
+	if err := git.RemoveRef(ctx, repo, entry.GetRefName()); err != nil {
+		log.Error("RemoveRef[%s] in %-v: %v", entry.GetRefName(), repo, err)
+	}
+}
+
+// GetMergeGroupCommitStatusState returns the state of the required status checks of a speculative merge result.
+// If no status check is required by the protected branch the merge group passes immediately.
+func GetMergeGroupCommitStatusState(ctx context.Context, repo *repo_model.Repository, pb *git_model.ProtectedBranch, commitID string) (commitstatus.CommitStatusState, error) {
+	required, err := EffectiveRequiredContexts(ctx, repo, pb)
+	if err != nil {
+		return "", err
+	}
+	if len(required) == 0 {
+		return commitstatus.CommitStatusSuccess, nil
+	}
+
+	statuses, err := git_model.GetLatestCommitStatus(ctx, repo.ID, commitID, db.ListOptionsAll)
+	if err != nil {
+		return "", fmt.Errorf("GetLatestCommitStatus: %w", err)
+	}
+	return MergeRequiredContextsCommitStatus(statuses, required), nil
+}
+
+// IsMergeQueueRef returns true if the ref is a temporary ref of a merge queue
+func IsMergeQueueRef(ref string) bool {
+	return strings.HasPrefix(ref, pull_model.MergeQueueRefPrefix)
+}
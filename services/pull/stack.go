@@ -0,0 +1,75 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/log"
+)
+
+// retargetStackedPulls moves the pull requests stacked on the just merged pr onto pr's base branch,
+// then brings their head branches up to date with it, by rebase or merge depending on the repository's
+// default update style and what the doer is allowed to do. Failures are only logged: the merge itself
+// has already succeeded, and a stacked pull request left behind can still be updated by hand.
+func retargetStackedPulls(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	children, err := issues_model.GetStackChildPullRequests(ctx, pr)
+	if err != nil {
+		log.Error("GetStackChildPullRequests %-v: %v", pr, err)
+		return
+	}
+	if len(children) == 0 {
+		return
+	}
+	if err := children.LoadAttributes(ctx); err != nil {
+		log.Error("LoadAttributes for pulls stacked on %-v: %v", pr, err)
+		return
+	}
+
+	for _, child := range children {
+		if err := child.Issue.LoadRepo(ctx); err != nil {
+			log.Error("LoadRepo for %-v: %v", child, err)
+			continue
+		}
+		if err := ChangeTargetBranch(ctx, child, doer, pr.BaseBranch); err != nil {
+			log.Error("Unable to retarget %-v stacked on %-v to %s: %v", child, pr, pr.BaseBranch, err)
+			continue
+		}
+		if err := updateStackedPull(ctx, doer, child); err != nil {
+			log.Error("Unable to update %-v after retargeting it to %s: %v", child, pr.BaseBranch, err)
+		}
+	}
+}
+
+// updateStackedPull updates the head branch of a retargeted pull request with its new base branch
+func updateStackedPull(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) error {
+	if pr.Flow == issues_model.PullRequestFlowAGit {
+		return nil
+	}
+
+	styles, err := CheckUserAllowedToUpdate(ctx, pr, doer)
+	if err != nil {
+		return err
+	}
+	rebase := styles.RebaseAllowed && (styles.DefaultUpdateStyle == repo_model.UpdateStyleRebase || !styles.MergeAllowed)
+	if !rebase && !styles.MergeAllowed {
+		return nil
+	}
+
+	// a fast-forwarded parent leaves nothing to update
+	diffCount, err := git.GetDivergingCommits(ctx, pr.BaseRepo, pr.BaseBranch, pr.GetGitHeadRefName())
+	if err != nil {
+		return err
+	} else if diffCount.Behind == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Merge branch '%s' into %s", pr.BaseBranch, pr.HeadBranch)
+	return Update(ctx, pr, doer, message, rebase)
+}
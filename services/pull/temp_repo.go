@@ -47,6 +47,12 @@ func (ctx *prTmpRepoContext) PrepareGitCmd(cmd *gitcmd.Command) *gitcmd.Command
 // createTemporaryRepoForPR creates a temporary repo with "base" for pr.BaseBranch and "tracking" for  pr.HeadBranch
 // it also create a second base branch called "original_base"
 func createTemporaryRepoForPR(ctx context.Context, pr *issues_model.PullRequest) (prCtx *prTmpRepoContext, cancel context.CancelFunc, retErr error) {
+	return createTemporaryRepoForPROnBaseRef(ctx, pr, git.BranchPrefix+pr.BaseBranch)
+}
+
+// createTemporaryRepoForPROnBaseRef is like createTemporaryRepoForPR but fetches "base" and "original_base" from
+// the given ref of the base repository instead of pr.BaseBranch, e.g. the speculative merge result of a merge queue
+func createTemporaryRepoForPROnBaseRef(ctx context.Context, pr *issues_model.PullRequest, baseRef string) (prCtx *prTmpRepoContext, cancel context.CancelFunc, retErr error) {
 	defer func() {
 		if retErr != nil && cancel != nil {
 			cancel()
@@ -124,9 +130,9 @@ func createTemporaryRepoForPR(ctx context.Context, pr *issues_model.PullRequest)
 	}
 
 	if err := prCtx.PrepareGitCmd(gitcmd.NewCommand("fetch", "origin").AddArguments(fetchArgs...).
-		AddDashesAndList(git.BranchPrefix+pr.BaseBranch+":"+git.BranchPrefix+tmpRepoBaseBranch, git.BranchPrefix+pr.BaseBranch+":"+git.BranchPrefix+"original_"+tmpRepoBaseBranch)).
+		AddDashesAndList(baseRef+":"+git.BranchPrefix+tmpRepoBaseBranch, baseRef+":"+git.BranchPrefix+"original_"+tmpRepoBaseBranch)).
 		RunWithStderr(ctx); err != nil {
-		return nil, nil, fmt.Errorf("unable to fetch origin base branch [%s:%s -> base, original_base in tmpBasePath]: %w\n%s\n%s", pr.BaseRepo.FullName(), pr.BaseBranch, err, prCtx.outbuf.String(), err.Stderr())
+		return nil, nil, fmt.Errorf("unable to fetch origin base branch [%s:%s -> base, original_base in tmpBasePath]: %w\n%s\n%s", pr.BaseRepo.FullName(), baseRef, err, prCtx.outbuf.String(), err.Stderr())
 	}
 
 	if err := prCtx.PrepareGitCmd(gitcmd.NewCommand("symbolic-ref").AddDynamicArguments("HEAD", git.BranchPrefix+tmpRepoBaseBranch)).
@@ -0,0 +1,344 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gitea.dev/models/db"
+	repo_model "gitea.dev/models/repo"
+	system_model "gitea.dev/models/system"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/git/gitrepo"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/migrations"
+
+	"xorm.io/builder"
+)
+
+// Options are the retention options of the repository backups
+type Options struct {
+	// KeepBackups is the number of the latest backups kept for every repository, 0 means no limit
+	KeepBackups int
+	// MaxAge removes the backups older than it, 0 means no limit
+	MaxAge time.Duration
+	// FullBackupInterval is the number of the backups after which a new full backup is created,
+	// 0 or 1 makes every backup a full one
+	FullBackupInterval int
+}
+
+func getBackupLockKey(repoID int64) string {
+	return fmt.Sprintf("repo_backup_%d", repoID)
+}
+
+// BackupRepositories creates a new backup of every repository and removes the expired backups
+func BackupRepositories(ctx context.Context, opts Options) error {
+	log.Trace("Doing: BackupRepositories")
+
+	var failed int
+	if err := db.Iterate(
+		ctx,
+		builder.Eq{"status": repo_model.RepositoryReady},
+		func(ctx context.Context, repo *repo_model.Repository) error {
+			select {
+			case <-ctx.Done():
+				return db.ErrCancelledf("before backup of %s", repo.FullName())
+			default:
+			}
+			if err := BackupRepository(ctx, repo, opts); err != nil {
+				log.Error("Failed to backup repository %-v: %v", repo, err)
+				if err := system_model.CreateRepositoryNotice("Failed to backup repository %s: %v", repo.FullName(), err); err != nil {
+					log.Error("CreateRepositoryNotice: %v", err)
+				}
+				failed++
+			}
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to backup %d repositories", failed)
+	}
+
+	log.Trace("Finished: BackupRepositories")
+	return nil
+}
+
+// BackupRepository creates a new backup of a repository and removes its expired backups
+func BackupRepository(ctx context.Context, repo *repo_model.Repository, opts Options) error {
+	return globallock.LockAndDo(ctx, getBackupLockKey(repo.ID), func(ctx context.Context) error {
+		return backupRepository(ctx, repo, opts, time.Now())
+	})
+}
+
+func backupRepository(ctx context.Context, repo *repo_model.Repository, opts Options, now time.Time) error {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return err
+	}
+
+	m, err := GetManifest(repo.ID)
+	if errors.Is(err, util.ErrNotExist) {
+		m = &Manifest{RepoID: repo.ID}
+	} else if err != nil {
+		return err
+	}
+	// the repository may have been renamed or transferred since the last backup
+	m.OwnerName, m.RepoName, m.ObjectFormat = repo.OwnerName, repo.Name, repo.ObjectFormatName
+
+	tmpDir, cleanup, err := setting.AppDataTempDir("repo-backup").MkdirTempRandom("backup")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	b := &Backup{ID: now.UTC().Format("20060102T150405.000Z"), Created: now}
+	if latest := m.GetBackup(""); latest != nil {
+		if latest.ID == b.ID {
+			return fmt.Errorf("backup %s already exists", b.ID)
+		}
+		if chain, err := m.chain(latest); err == nil && len(chain) < opts.FullBackupInterval {
+			b.Parent = latest.ID
+		}
+	}
+	parent := m.GetBackup(b.Parent)
+
+	var parentRefs, parentWikiRefs map[string]string
+	if parent != nil {
+		parentRefs, parentWikiRefs = parent.Refs, parent.WikiRefs
+	}
+
+	files := map[string]string{}
+	var incremental bool
+	b.Refs, b.RepoBundle, incremental, err = createBundle(ctx, repo, parentRefs, filepath.Join(tmpDir, repoBundleFileName))
+	if err != nil {
+		return fmt.Errorf("create bundle: %w", err)
+	}
+	if b.RepoBundle {
+		files[repoBundleFileName] = filepath.Join(tmpDir, repoBundleFileName)
+	}
+	if parent != nil && !incremental {
+		// the code doesn't depend on the parent, so the wiki doesn't either and the backup becomes a full one
+		b.Parent, parentWikiRefs = "", nil
+	}
+
+	if exist, err := git.IsRepositoryExist(ctx, repo.WikiStorageRepo()); err != nil {
+		return err
+	} else if exist {
+		b.WikiRefs, b.WikiBundle, _, err = createBundle(ctx, repo.WikiStorageRepo(), parentWikiRefs, filepath.Join(tmpDir, wikiBundleFileName))
+		if err != nil {
+			return fmt.Errorf("create wiki bundle: %w", err)
+		}
+		if b.WikiBundle {
+			files[wikiBundleFileName] = filepath.Join(tmpDir, wikiBundleFileName)
+		}
+	}
+
+	metadataDir := filepath.Join(tmpDir, "metadata")
+	if err := migrations.DumpRepositoryMetadata(ctx, repo, metadataDir); err != nil {
+		return fmt.Errorf("dump metadata: %w", err)
+	}
+	files[metadataFileName] = filepath.Join(tmpDir, metadataFileName)
+	if err := zipDir(filepath.Join(metadataDir, repo.OwnerName, repo.Name), files[metadataFileName]); err != nil {
+		return fmt.Errorf("archive metadata: %w", err)
+	}
+
+	for name, p := range files {
+		size, err := saveLocalFile(repo.ID, b.ID, name, p)
+		if err != nil {
+			if err := deleteBackupFiles(repo.ID, b); err != nil {
+				log.Error("Failed to delete the incomplete backup %s of %-v: %v", b.ID, repo, err)
+			}
+			return err
+		}
+		b.Size += size
+	}
+
+	m.Backups = append(m.Backups, b)
+	removed := m.applyRetention(opts, now)
+	if err := saveManifest(m); err != nil {
+		return err
+	}
+	for _, r := range removed {
+		if err := deleteBackupFiles(repo.ID, r); err != nil {
+			log.Error("Failed to delete the expired backup %s of %-v: %v", r.ID, repo, err)
+		}
+	}
+	return nil
+}
+
+func saveLocalFile(repoID int64, backupID, name, localPath string) (int64, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return fi.Size(), saveBackupFile(repoID, backupID, name, f, fi.Size())
+}
+
+// listRefs returns all the refs of a git repository with their object ids
+func listRefs(ctx context.Context, repo gitrepo.RepositoryFacade) (map[string]string, error) {
+	stdout, _, err := gitcmd.NewCommand("for-each-ref", "--format=%(objectname) %(refname)").WithRepo(repo).RunStdString(ctx)
+	if err != nil {
+		return nil, err
+	}
+	refs := map[string]string{}
+	for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+		objectID, refName, ok := strings.Cut(line, " ")
+		if ok {
+			refs[refName] = objectID
+		}
+	}
+	return refs, nil
+}
+
+func sameRefs(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// createBundle writes a bundle of all the refs of the repository, the objects reachable from the parent refs
+// are excluded. No bundle is needed when the repository is empty or the refs haven't been changed.
+// incremental reports whether the objects of the parent are needed to restore the refs.
+func createBundle(ctx context.Context, repo gitrepo.RepositoryFacade, parentRefs map[string]string, bundlePath string) (refs map[string]string, hasBundle, incremental bool, err error) {
+	refs, err = listRefs(ctx, repo)
+	if err != nil {
+		return nil, false, false, err
+	}
+	if len(refs) == 0 {
+		return refs, false, false, nil
+	}
+	if parentRefs != nil && sameRefs(refs, parentRefs) {
+		return refs, false, true, nil
+	}
+
+	if len(parentRefs) > 0 {
+		cmd := gitcmd.NewCommand("bundle", "create").AddDynamicArguments(bundlePath).AddArguments("--all")
+		excluded := make(map[string]bool, len(parentRefs))
+		for _, objectID := range parentRefs {
+			if !excluded[objectID] {
+				excluded[objectID] = true
+				cmd.AddDynamicArguments("^" + objectID)
+			}
+		}
+		_, _, err := cmd.WithRepo(repo).RunStdString(ctx)
+		if err == nil {
+			return refs, true, true, nil
+		}
+		if gitcmd.IsStderr(err, gitcmd.StderrEmptyBundle) {
+			// only the refs have been changed, all the objects are contained by the previous bundles
+			return refs, false, true, nil
+		}
+		// the objects of the parent might have been removed by a force push and gc,
+		// the full bundle is always correct although it is larger
+		log.Debug("Unable to create incremental bundle of %s, fall back to full bundle: %v", repo.LogString(), err)
+	}
+
+	_, _, err = gitcmd.NewCommand("bundle", "create").AddDynamicArguments(bundlePath).AddArguments("--all").WithRepo(repo).RunStdString(ctx)
+	if err != nil {
+		return nil, false, false, err
+	}
+	return refs, true, false, nil
+}
+
+// zipDir archives all the files in the directory with their relative paths
+func zipDir(dir, zipPath string) error {
+	f, err := os.Create(zipPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		w, err := zw.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(w, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// unzipTo extracts the archive created by zipDir into the directory
+func unzipTo(r io.ReaderAt, size int64, dir string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	for _, zf := range zr.File {
+		// SECURITY: the file names must not escape the target directory
+		if !filepath.IsLocal(zf.Name) || zf.FileInfo().IsDir() {
+			continue
+		}
+		target := filepath.Join(dir, filepath.FromSlash(zf.Name))
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		if err := extractZipFile(zf, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(zf *zip.File, target string) error {
+	src, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	if _, err := io.Copy(dst, src); err != nil {
+		return err
+	}
+	return dst.Close()
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"gitea.dev/models/db"
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/storage"
+	files_service "gitea.dev/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// branchesAndTags returns the refs which are restored as they are, the refs of the pull requests are recreated from the metadata
+func branchesAndTags(refs map[string]string) map[string]string {
+	ret := maps.Clone(refs)
+	maps.DeleteFunc(ret, func(refName, _ string) bool {
+		return !strings.HasPrefix(refName, "refs/heads/") && !strings.HasPrefix(refName, "refs/tags/")
+	})
+	return ret
+}
+
+func TestBackupRestoreRepository(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	require.NoError(t, storage.Clean(storage.RepoBackups))
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	opts := Options{FullBackupInterval: 10}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	require.NoError(t, backupRepository(t.Context(), repo, opts, now))
+	m, err := GetManifest(repo.ID)
+	require.NoError(t, err)
+	require.Len(t, m.Backups, 1)
+	full := m.Backups[0]
+	assert.False(t, full.IsIncremental())
+	assert.True(t, full.RepoBundle)
+
+	_, err = files_service.ChangeRepoFiles(t.Context(), repo, doer, &files_service.ChangeRepoFilesOptions{
+		Files: []*files_service.ChangeRepoFile{
+			{Operation: "create", TreePath: "backup.txt", ContentReader: strings.NewReader("backed up")},
+		},
+		OldBranch: repo.DefaultBranch,
+		NewBranch: repo.DefaultBranch,
+		Message:   "Add backup.txt",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, backupRepository(t.Context(), repo, opts, now.Add(time.Hour)))
+	m, err = GetManifest(repo.ID)
+	require.NoError(t, err)
+	require.Len(t, m.Backups, 2)
+	incremental := m.Backups[1]
+	assert.Equal(t, full.ID, incremental.Parent)
+	assert.True(t, incremental.RepoBundle)
+	refs, err := listRefs(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, refs, incremental.Refs)
+	assert.NotEqual(t, full.Refs["refs/heads/"+repo.DefaultBranch], incremental.Refs["refs/heads/"+repo.DefaultBranch])
+
+	restored, err := RestoreRepository(t.Context(), repo.ID, RestoreOptions{RepoName: "repo1-restored"})
+	require.NoError(t, err)
+	assert.Equal(t, repo.OwnerID, restored.OwnerID)
+	assert.Equal(t, repo.DefaultBranch, restored.DefaultBranch)
+
+	restoredRefs, err := listRefs(t.Context(), restored)
+	require.NoError(t, err)
+	assert.Equal(t, branchesAndTags(incremental.Refs), branchesAndTags(restoredRefs))
+
+	labelNames := func(repoID int64) []string {
+		labels, err := issues_model.GetLabelsByRepoID(t.Context(), repoID, "", db.ListOptions{})
+		require.NoError(t, err)
+		names := make([]string, 0, len(labels))
+		for _, label := range labels {
+			names = append(names, label.Name)
+		}
+		return names
+	}
+	assert.Equal(t, labelNames(repo.ID), labelNames(restored.ID))
+	assert.Equal(t, unittest.GetCount(t, &issues_model.Issue{RepoID: repo.ID}), unittest.GetCount(t, &issues_model.Issue{RepoID: restored.ID}))
+	assert.Equal(t, unittest.GetCount(t, &issues_model.Milestone{RepoID: repo.ID}), unittest.GetCount(t, &issues_model.Milestone{RepoID: restored.ID}))
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Index: 1})
+	restoredIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: restored.ID, Index: 1})
+	assert.Equal(t, issue.Title, restoredIssue.Title)
+	assert.Equal(t, issue.Content, restoredIssue.Content)
+
+	// the repository can't be restored over an existing one
+	_, err = RestoreRepository(t.Context(), repo.ID, RestoreOptions{RepoName: "repo1-restored"})
+	assert.True(t, repo_model.IsErrRepoAlreadyExist(err))
+}
+
+func TestBackupFallbackToFullBundle(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	require.NoError(t, storage.Clean(storage.RepoBackups))
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	opts := Options{FullBackupInterval: 10}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// the objects of the parent don't exist anymore, e.g. after a force push and gc
+	missingRefs := map[string]string{"refs/heads/gone": strings.Repeat("1", 40)}
+	refs, hasBundle, incremental, err := createBundle(t.Context(), repo, missingRefs, t.TempDir()+"/repo.bundle")
+	require.NoError(t, err)
+	assert.NotEmpty(t, refs)
+	assert.True(t, hasBundle)
+	assert.False(t, incremental)
+
+	require.NoError(t, backupRepository(t.Context(), repo, opts, now))
+	m, err := GetManifest(repo.ID)
+	require.NoError(t, err)
+	m.Backups[0].Refs = missingRefs
+	require.NoError(t, saveManifest(m))
+
+	require.NoError(t, backupRepository(t.Context(), repo, opts, now.Add(time.Hour)))
+	m, err = GetManifest(repo.ID)
+	require.NoError(t, err)
+	require.Len(t, m.Backups, 2)
+	assert.False(t, m.Backups[1].IsIncremental())
+	assert.True(t, m.Backups[1].RepoBundle)
+
+	// the refs haven't been changed since the last backup
+	require.NoError(t, backupRepository(t.Context(), repo, opts, now.Add(2*time.Hour)))
+	m, err = GetManifest(repo.ID)
+	require.NoError(t, err)
+	require.Len(t, m.Backups, 3)
+	assert.Equal(t, m.Backups[1].ID, m.Backups[2].Parent)
+	assert.False(t, m.Backups[2].RepoBundle)
+}
+
+func TestListManifests(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	require.NoError(t, storage.Clean(storage.RepoBackups))
+
+	for _, repoID := range []int64{3, 1, 2} {
+		require.NoError(t, saveManifest(&Manifest{RepoID: repoID}))
+	}
+	// the files which are not the manifests of a repository are ignored
+	for _, p := range []string{"1/20260102T030405.000Z/manifest.json", "backups/manifest.json", "4/manifest.json.tmp"} {
+		_, err := storage.RepoBackups.Save(p, strings.NewReader("{}"), 2)
+		require.NoError(t, err)
+	}
+
+	manifests, count, err := ListManifests(db.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, manifests, 3)
+	assert.EqualValues(t, 1, manifests[0].RepoID)
+	assert.EqualValues(t, 3, manifests[2].RepoID)
+
+	manifests, count, err = ListManifests(db.ListOptions{Page: 2, PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, manifests, 1)
+	assert.EqualValues(t, 3, manifests[0].RepoID)
+}
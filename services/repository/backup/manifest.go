@@ -0,0 +1,232 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitea.dev/models/db"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/storage"
+	"gitea.dev/modules/util"
+)
+
+const (
+	manifestFileName   = "manifest.json"
+	repoBundleFileName = "repo.bundle"
+	wikiBundleFileName = "wiki.bundle"
+	metadataFileName   = "metadata.zip"
+)
+
+// Manifest lists the backups of a repository, it is saved beside the backups so that
+// the repository could be restored even after it has been deleted from the database
+type Manifest struct {
+	RepoID       int64     `json:"repo_id"`
+	OwnerName    string    `json:"owner_name"`
+	RepoName     string    `json:"repo_name"`
+	ObjectFormat string    `json:"object_format"`
+	Backups      []*Backup `json:"backups"`
+}
+
+// Backup is a single backup of a repository. The git data of an incremental backup only contains
+// the objects which are not contained by its parent, the metadata is always saved completely.
+type Backup struct {
+	ID         string            `json:"id"`
+	Created    time.Time         `json:"created"`
+	Parent     string            `json:"parent,omitempty"`
+	Refs       map[string]string `json:"refs"`
+	WikiRefs   map[string]string `json:"wiki_refs,omitempty"`
+	RepoBundle bool              `json:"repo_bundle"`
+	WikiBundle bool              `json:"wiki_bundle"`
+	Size       int64             `json:"size"`
+}
+
+// IsIncremental returns whether the backup depends on its parent
+func (b *Backup) IsIncremental() bool {
+	return b.Parent != ""
+}
+
+func manifestPath(repoID int64) string {
+	return path.Join(strconv.FormatInt(repoID, 10), manifestFileName)
+}
+
+func backupFilePath(repoID int64, backupID, name string) string {
+	return path.Join(strconv.FormatInt(repoID, 10), backupID, name)
+}
+
+// GetManifest returns the manifest of the backups of a repository
+func GetManifest(repoID int64) (*Manifest, error) {
+	f, err := storage.RepoBackups.Open(manifestPath(repoID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, util.NewNotExistErrorf("no backup of repository %d", repoID)
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var m Manifest
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return nil, fmt.Errorf("invalid manifest of the backups of repository %d: %w", repoID, err)
+	}
+	return &m, nil
+}
+
+func saveManifest(m *Manifest) error {
+	bs, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = storage.RepoBackups.Save(manifestPath(m.RepoID), strings.NewReader(string(bs)), int64(len(bs)))
+	return err
+}
+
+// listManifestRepoIDs returns the IDs of all repositories which have backups, including the deleted ones
+func listManifestRepoIDs() ([]int64, error) {
+	var repoIDs []int64
+	if err := storage.RepoBackups.IterateObjects("", func(p string, obj storage.Object) error {
+		// only "<repo id>/manifest.json" is a manifest, other files in the storage aren't managed by the backups
+		dir, name, ok := strings.Cut(p, "/")
+		if !ok || name != manifestFileName {
+			return nil
+		}
+		if repoID, err := strconv.ParseInt(dir, 10, 64); err == nil && repoID > 0 {
+			repoIDs = append(repoIDs, repoID)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	slices.Sort(repoIDs)
+	return repoIDs, nil
+}
+
+// ListManifests returns a page of the manifests of all repositories which have backups, including the deleted ones,
+// and the total number of the manifests. Only the manifests of the page are loaded.
+func ListManifests(listOpts db.ListOptions) ([]*Manifest, int, error) {
+	repoIDs, err := listManifestRepoIDs()
+	if err != nil {
+		return nil, 0, err
+	}
+	count := len(repoIDs)
+	repoIDs = util.PaginateSlice(repoIDs, listOpts.Page, listOpts.PageSize)
+
+	manifests := make([]*Manifest, 0, len(repoIDs))
+	for _, repoID := range repoIDs {
+		m, err := GetManifest(repoID)
+		if err != nil {
+			return nil, 0, err
+		}
+		manifests = append(manifests, m)
+	}
+	return manifests, count, nil
+}
+
+// GetBackup returns the backup with the given id, or the latest one if the id is empty
+func (m *Manifest) GetBackup(id string) *Backup {
+	if id == "" {
+		if len(m.Backups) == 0 {
+			return nil
+		}
+		return m.Backups[len(m.Backups)-1]
+	}
+	for _, b := range m.Backups {
+		if b.ID == id {
+			return b
+		}
+	}
+	return nil
+}
+
+// chain returns the backups needed to restore the given backup, from the full backup to the backup itself
+func (m *Manifest) chain(b *Backup) ([]*Backup, error) {
+	var chain []*Backup
+	for cur := b; cur != nil; {
+		chain = append(chain, cur)
+		if !cur.IsIncremental() {
+			break
+		}
+		parent := m.GetBackup(cur.Parent)
+		if parent == nil {
+			return nil, fmt.Errorf("parent %s of backup %s is missing", cur.Parent, cur.ID)
+		}
+		cur = parent
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
+
+// applyRetention removes the backups which are not kept by the options from the manifest and returns them.
+// The latest backup and the parents of every kept backup are always kept.
+func (m *Manifest) applyRetention(opts Options, now time.Time) []*Backup {
+	if len(m.Backups) == 0 {
+		return nil
+	}
+
+	keep := make(map[string]bool, len(m.Backups))
+	for i, b := range m.Backups {
+		fromLatest := len(m.Backups) - i
+		if opts.KeepBackups > 0 && fromLatest > opts.KeepBackups {
+			continue
+		}
+		if opts.MaxAge > 0 && now.Sub(b.Created) > opts.MaxAge {
+			continue
+		}
+		keep[b.ID] = true
+	}
+	keep[m.Backups[len(m.Backups)-1].ID] = true
+
+	for _, b := range m.Backups {
+		if !keep[b.ID] {
+			continue
+		}
+		for parent := b.Parent; parent != "" && !keep[parent]; {
+			keep[parent] = true
+			if p := m.GetBackup(parent); p != nil {
+				parent = p.Parent
+			} else {
+				parent = ""
+			}
+		}
+	}
+
+	var kept, removed []*Backup
+	for _, b := range m.Backups {
+		if keep[b.ID] {
+			kept = append(kept, b)
+		} else {
+			removed = append(removed, b)
+		}
+	}
+	m.Backups = kept
+	return removed
+}
+
+func openBackupFile(repoID int64, backupID, name string) (storage.Object, error) {
+	return storage.RepoBackups.Open(backupFilePath(repoID, backupID, name))
+}
+
+func saveBackupFile(repoID int64, backupID, name string, r io.Reader, size int64) error {
+	_, err := storage.RepoBackups.Save(backupFilePath(repoID, backupID, name), r, size)
+	return err
+}
+
+func deleteBackupFiles(repoID int64, b *Backup) error {
+	for _, name := range []string{repoBundleFileName, wikiBundleFileName, metadataFileName} {
+		if err := storage.RepoBackups.Delete(backupFilePath(repoID, b.ID, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func backupIDs(backups []*Backup) []string {
+	ids := make([]string, 0, len(backups))
+	for _, b := range backups {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+func TestManifestChain(t *testing.T) {
+	m := &Manifest{Backups: []*Backup{
+		{ID: "1"},
+		{ID: "2", Parent: "1"},
+		{ID: "3", Parent: "2"},
+		{ID: "4"},
+		{ID: "5", Parent: "missing"},
+	}}
+
+	chain, err := m.chain(m.GetBackup("3"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, backupIDs(chain))
+
+	chain, err = m.chain(m.GetBackup("4"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4"}, backupIDs(chain))
+
+	_, err = m.chain(m.GetBackup("5"))
+	assert.Error(t, err)
+
+	assert.Equal(t, "5", m.GetBackup("").ID)
+	assert.Nil(t, m.GetBackup("6"))
+}
+
+func TestManifestApplyRetention(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	newManifest := func() *Manifest {
+		return &Manifest{Backups: []*Backup{
+			{ID: "1", Created: now.Add(-5 * 24 * time.Hour)},
+			{ID: "2", Created: now.Add(-4 * 24 * time.Hour), Parent: "1"},
+			{ID: "3", Created: now.Add(-3 * 24 * time.Hour)},
+			{ID: "4", Created: now.Add(-2 * 24 * time.Hour), Parent: "3"},
+			{ID: "5", Created: now.Add(-1 * 24 * time.Hour), Parent: "4"},
+		}}
+	}
+
+	m := newManifest()
+	assert.Empty(t, m.applyRetention(Options{}, now))
+	assert.Len(t, m.Backups, 5)
+
+	// the parents of the kept backups can't be removed
+	m = newManifest()
+	removed := m.applyRetention(Options{KeepBackups: 1}, now)
+	assert.Equal(t, []string{"1", "2"}, backupIDs(removed))
+	assert.Equal(t, []string{"3", "4", "5"}, backupIDs(m.Backups))
+
+	m = newManifest()
+	removed = m.applyRetention(Options{MaxAge: 72 * time.Hour}, now)
+	assert.Equal(t, []string{"1", "2"}, backupIDs(removed))
+
+	// the latest backup is always kept
+	m = newManifest()
+	removed = m.applyRetention(Options{MaxAge: time.Hour}, now)
+	assert.Equal(t, []string{"1", "2"}, backupIDs(removed))
+	assert.Equal(t, []string{"3", "4", "5"}, backupIDs(m.Backups))
+}
+
+func TestZipDir(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "repo.yml"), []byte("name: test"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "issue.yml"), []byte("- title: test"), 0o644))
+
+	zipPath := filepath.Join(t.TempDir(), "metadata.zip")
+	require.NoError(t, zipDir(src, zipPath))
+
+	f, err := os.Open(zipPath)
+	require.NoError(t, err)
+	defer f.Close()
+	fi, err := f.Stat()
+	require.NoError(t, err)
+
+	dst := t.TempDir()
+	require.NoError(t, unzipTo(f, fi.Size(), dst))
+
+	content, err := os.ReadFile(filepath.Join(dst, "repo.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "name: test", string(content))
+	content, err = os.ReadFile(filepath.Join(dst, "sub", "issue.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "- title: test", string(content))
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/git/gitrepo"
+	"gitea.dev/modules/globallock"
+	"gitea.dev/modules/setting"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	"gitea.dev/services/migrations"
+)
+
+// RestoreOptions are the options to restore a repository from a backup
+type RestoreOptions struct {
+	// BackupID is the backup to restore, the latest one is used if it is empty
+	BackupID string
+	// OwnerName and RepoName default to the names of the repository when the backup was created
+	OwnerName string
+	RepoName  string
+}
+
+// RestoreRepository restores a repository from its backups as a new repository, the original one doesn't need to exist
+func RestoreRepository(ctx context.Context, repoID int64, opts RestoreOptions) (*repo_model.Repository, error) {
+	var repo *repo_model.Repository
+	err := globallock.LockAndDo(ctx, getBackupLockKey(repoID), func(ctx context.Context) (err error) {
+		repo, err = restoreRepository(ctx, repoID, opts)
+		return err
+	})
+	return repo, err
+}
+
+func restoreRepository(ctx context.Context, repoID int64, opts RestoreOptions) (*repo_model.Repository, error) {
+	m, err := GetManifest(repoID)
+	if err != nil {
+		return nil, err
+	}
+	b := m.GetBackup(opts.BackupID)
+	if b == nil {
+		return nil, util.NewNotExistErrorf("backup %q of repository %d doesn't exist", opts.BackupID, repoID)
+	}
+	chain, err := m.chain(b)
+	if err != nil {
+		return nil, err
+	}
+	ownerName, repoName := util.IfZero(opts.OwnerName, m.OwnerName), util.IfZero(opts.RepoName, m.RepoName)
+	if _, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName); err == nil {
+		return nil, repo_model.ErrRepoAlreadyExist{Uname: ownerName, Name: repoName}
+	} else if !repo_model.IsErrRepoNotExist(err) {
+		return nil, err
+	}
+
+	baseDir, cleanup, err := setting.AppDataTempDir("repo-backup").MkdirTempRandom("restore")
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	// the restorer clones the code from "git" and finds the wiki beside it
+	if err := restoreGitRepo(ctx, m, chain, filepath.Join(baseDir, "git"), repoBundleFileName, b.Refs); err != nil {
+		return nil, fmt.Errorf("restore git data: %w", err)
+	}
+	if len(b.WikiRefs) > 0 {
+		if err := restoreGitRepo(ctx, m, chain, filepath.Join(baseDir, "git.wiki.git"), wikiBundleFileName, b.WikiRefs); err != nil {
+			return nil, fmt.Errorf("restore wiki: %w", err)
+		}
+	}
+	if err := restoreMetadata(m.RepoID, b, baseDir); err != nil {
+		return nil, fmt.Errorf("restore metadata: %w", err)
+	}
+
+	if err := migrations.RestoreRepository(ctx, baseDir, ownerName, repoName, nil, false); err != nil {
+		return nil, err
+	}
+
+	repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+	if err != nil {
+		return nil, err
+	}
+	// the restored repository is not a migrated one
+	repo.OriginalURL = ""
+	repo.OriginalServiceType = api.NotMigrated
+	if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "original_url", "original_service_type"); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// restoreGitRepo creates a bare repository from the bundles of the backups, then resets the refs to the ones of the last backup
+func restoreGitRepo(ctx context.Context, m *Manifest, chain []*Backup, repoPath, bundleName string, refs map[string]string) error {
+	if err := git.InitRepositoryLocal(ctx, repoPath, true, util.IfZero(m.ObjectFormat, git.Sha1ObjectFormat.Name())); err != nil {
+		return err
+	}
+	gitRepo := gitrepo.RepositoryUnmanaged(repoPath)
+
+	for _, b := range chain {
+		if (bundleName == repoBundleFileName && !b.RepoBundle) || (bundleName == wikiBundleFileName && !b.WikiBundle) {
+			continue
+		}
+		if err := fetchBundle(ctx, gitRepo, m.RepoID, b.ID, bundleName); err != nil {
+			return fmt.Errorf("fetch bundle of backup %s: %w", b.ID, err)
+		}
+	}
+
+	current, err := listRefs(ctx, gitRepo)
+	if err != nil {
+		return err
+	}
+	var stdin strings.Builder
+	for refName := range current {
+		if _, ok := refs[refName]; !ok {
+			fmt.Fprintf(&stdin, "delete %s\n", refName)
+		}
+	}
+	for refName, objectID := range refs {
+		fmt.Fprintf(&stdin, "update %s %s\n", refName, objectID)
+	}
+	if stdin.Len() == 0 {
+		return nil
+	}
+	_, _, err = gitcmd.NewCommand("update-ref", "--stdin").WithStdinBytes([]byte(stdin.String())).WithRepo(gitRepo).RunStdString(ctx)
+	return err
+}
+
+func fetchBundle(ctx context.Context, gitRepo gitrepo.RepositoryFacade, repoID int64, backupID, bundleName string) error {
+	obj, err := openBackupFile(repoID, backupID, bundleName)
+	if err != nil {
+		return err
+	}
+	defer obj.Close()
+
+	f, cleanup, err := setting.AppDataTempDir("repo-backup").CreateTempFileRandom("bundle")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if _, err := io.Copy(f, obj); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	_, _, err = gitcmd.NewCommand("fetch", "--quiet", "--no-tags").
+		AddDynamicArguments(f.Name()).
+		AddArguments("+refs/*:refs/*").
+		WithRepo(gitRepo).
+		RunStdString(ctx)
+	return err
+}
+
+func restoreMetadata(repoID int64, b *Backup, baseDir string) error {
+	obj, err := openBackupFile(repoID, b.ID, metadataFileName)
+	if err != nil {
+		return err
+	}
+	defer obj.Close()
+
+	f, cleanup, err := setting.AppDataTempDir("repo-backup").CreateTempFileRandom("metadata")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	size, err := io.Copy(f, obj)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(baseDir, os.ModePerm); err != nil {
+		return err
+	}
+	return unzipTo(f, size, baseDir)
+}
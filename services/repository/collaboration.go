@@ -14,11 +14,13 @@ import (
 	access_model "gitea.dev/models/perm/access"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
+	audit_service "gitea.dev/services/audit"
 
 	"xorm.io/builder"
 )
 
-func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u *user_model.User, mode perm.AccessMode) error {
+// AddOrUpdateCollaborator adds u as a collaborator of repo with the access mode, or changes the access mode of the collaborator
+func AddOrUpdateCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, u *user_model.User, mode perm.AccessMode) error {
 	// Only allow valid access modes, read, write and admin
 	// Keep in mind: do not allow "owner" here: because "admin" user can update collaborators but not make dangerous operations.
 	// If the "admin" user updates a user to "owner", then it means that the admin user can use owner permission, which is not expected.
@@ -62,23 +64,29 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 			return err
 		}
 
-		return access_model.RecalculateUserAccess(ctx, repo, u.ID)
+		if err := access_model.RecalculateUserAccess(ctx, repo, u.ID); err != nil {
+			return err
+		}
+		modeBefore := perm.AccessModeNone
+		if has {
+			modeBefore = collaboration.Mode
+		}
+		audit_service.RecordCollaboratorChange(ctx, doer, repo, u, modeBefore, mode)
+		return nil
 	})
 }
 
 // DeleteCollaboration removes collaboration relation between the user and repository.
-func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, collaborator *user_model.User) (err error) {
-	collaboration := &repo_model.Collaboration{
-		RepoID: repo.ID,
-		UserID: collaborator.ID,
-	}
-
+func DeleteCollaboration(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) (err error) {
 	return db.WithTx(ctx, func(ctx context.Context) error {
-		if has, err := db.GetEngine(ctx).Delete(collaboration); err != nil {
+		collaboration, err := repo_model.GetCollaboration(ctx, repo.ID, collaborator.ID)
+		if err != nil || collaboration == nil {
+			return err
+		}
+		if _, err := db.DeleteByID[repo_model.Collaboration](ctx, collaboration.ID); err != nil {
 			return err
-		} else if has == 0 {
-			return nil
 		}
+		audit_service.RecordCollaboratorChange(ctx, doer, repo, collaborator, collaboration.Mode, perm.AccessModeNone)
 
 		if err := repo.LoadOwner(ctx); err != nil {
 			return err
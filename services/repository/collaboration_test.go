@@ -6,6 +6,7 @@ package repository
 import (
 	"testing"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	issues_model "gitea.dev/models/issues"
 	"gitea.dev/models/perm"
@@ -25,15 +26,45 @@ func TestRepository_AddCollaborator(t *testing.T) {
 	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
 	testSuccess := func(repo *repo_model.Repository, user *user_model.User) {
 		assert.NoError(t, repo.LoadOwner(t.Context()))
-		assert.NoError(t, AddOrUpdateCollaborator(t.Context(), repo, user, perm.AccessModeWrite))
+		assert.NoError(t, AddOrUpdateCollaborator(t.Context(), nil, repo, user, perm.AccessModeWrite))
 		unittest.CheckConsistencyFor(t, repo, user)
 	}
 	testSuccess(repo1, user4)
 	testSuccess(repo1, user4)
 	testSuccess(repo3, user4)
 
-	assert.Error(t, AddOrUpdateCollaborator(t.Context(), repo1, user4, perm.AccessModeOwner))
-	assert.NoError(t, AddOrUpdateCollaborator(t.Context(), repo1, user4, perm.AccessModeAdmin))
+	assert.Error(t, AddOrUpdateCollaborator(t.Context(), nil, repo1, user4, perm.AccessModeOwner))
+	assert.NoError(t, AddOrUpdateCollaborator(t.Context(), nil, repo1, user4, perm.AccessModeAdmin))
+}
+
+func TestCollaboratorAuditEvents(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	assert.NoError(t, AddOrUpdateCollaborator(t.Context(), doer, repo, user, perm.AccessModeWrite))
+	assert.NoError(t, AddOrUpdateCollaborator(t.Context(), doer, repo, user, perm.AccessModeWrite))
+	unittest.AssertCount(t, &audit_model.Event{Action: audit_model.ActionCollaboratorAdd}, 1)
+	added := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionCollaboratorAdd})
+	assert.Equal(t, doer.ID, added.ActorID)
+	assert.Equal(t, repo.ID, added.RepoID)
+	assert.Equal(t, user.ID, added.TargetID)
+	assert.Empty(t, added.Before)
+	assert.JSONEq(t, `{"permission":"write"}`, added.After)
+
+	assert.NoError(t, AddOrUpdateCollaborator(t.Context(), doer, repo, user, perm.AccessModeAdmin))
+	updated := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionCollaboratorUpdate})
+	assert.JSONEq(t, `{"permission":"write"}`, updated.Before)
+	assert.JSONEq(t, `{"permission":"admin"}`, updated.After)
+
+	assert.NoError(t, DeleteCollaboration(t.Context(), doer, repo, user))
+	assert.NoError(t, DeleteCollaboration(t.Context(), doer, repo, user))
+	unittest.AssertCount(t, &audit_model.Event{Action: audit_model.ActionCollaboratorRemove}, 1)
+	removed := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionCollaboratorRemove})
+	assert.JSONEq(t, `{"permission":"admin"}`, removed.Before)
+	assert.Empty(t, removed.After)
 }
 
 func TestRepository_DeleteCollaboration(t *testing.T) {
@@ -43,10 +74,10 @@ func TestRepository_DeleteCollaboration(t *testing.T) {
 	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 22})
 
 	assert.NoError(t, repo.LoadOwner(t.Context()))
-	assert.NoError(t, DeleteCollaboration(t.Context(), repo, user))
+	assert.NoError(t, DeleteCollaboration(t.Context(), nil, repo, user))
 	unittest.AssertNotExistsBean(t, &repo_model.Collaboration{RepoID: repo.ID, UserID: user.ID})
 
-	assert.NoError(t, DeleteCollaboration(t.Context(), repo, user))
+	assert.NoError(t, DeleteCollaboration(t.Context(), nil, repo, user))
 	unittest.AssertNotExistsBean(t, &repo_model.Collaboration{RepoID: repo.ID, UserID: user.ID})
 
 	unittest.CheckConsistencyFor(t, &repo_model.Repository{ID: repo.ID})
@@ -80,7 +111,7 @@ func TestRepository_DeleteCollaborationRemovesSubscriptionsAndStopwatches(t *tes
 	assert.NoError(t, err)
 	assert.True(t, ok)
 
-	assert.NoError(t, DeleteCollaboration(ctx, repo, user))
+	assert.NoError(t, DeleteCollaboration(ctx, nil, repo, user))
 
 	hasAccess, err = access_model.HasAnyUnitAccess(ctx, user.ID, repo)
 	assert.NoError(t, err)
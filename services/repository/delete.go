@@ -10,6 +10,7 @@ import (
 	actions_model "gitea.dev/models/actions"
 	activities_model "gitea.dev/models/activities"
 	admin_model "gitea.dev/models/admin"
+	codenav_model "gitea.dev/models/codenav"
 	"gitea.dev/models/db"
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
@@ -19,6 +20,7 @@ import (
 	project_model "gitea.dev/models/project"
 	repo_model "gitea.dev/models/repo"
 	secret_model "gitea.dev/models/secret"
+	secretscan_model "gitea.dev/models/secretscan"
 	system_model "gitea.dev/models/system"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/models/webhook"
@@ -82,6 +84,12 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return fmt.Errorf("list actions artifacts of repo %v: %w", repoID, err)
 	}
 
+	// Query the cache entries of this repo, they will be needed after they have been deleted to remove their content in ObjectStorage
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{RepoID: repoID})
+	if err != nil {
+		return fmt.Errorf("list actions cache entries of repo %v: %w", repoID, err)
+	}
+
 	// In case owner is a organization, we have to change repo specific teams
 	// if ignoreOrgTeams is not true
 	var org *user_model.User
@@ -162,6 +170,7 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&activities_model.Notification{RepoID: repoID},
 		&git_model.ProtectedBranch{RepoID: repoID},
 		&git_model.ProtectedTag{RepoID: repoID},
+		&git_model.PushRule{RepoID: repoID},
 		&repo_model.PushMirror{RepoID: repoID},
 		&repo_model.Release{RepoID: repoID},
 		&repo_model.RepoIndexerStatus{RepoID: repoID},
@@ -173,6 +182,7 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&repo_model.Watch{RepoID: repoID},
 		&webhook.Webhook{RepoID: repoID},
 		&secret_model.Secret{RepoID: repoID},
+		&secretscan_model.Alert{RepoID: repoID},
 		&actions_model.ActionVariable{RepoID: repoID},
 		&actions_model.ActionTaskStep{RepoID: repoID},
 		&actions_model.ActionTask{RepoID: repoID},
@@ -183,6 +193,7 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&actions_model.ActionScheduleSpec{RepoID: repoID},
 		&actions_model.ActionSchedule{RepoID: repoID},
 		&actions_model.ActionArtifact{RepoID: repoID},
+		&actions_model.ActionCache{RepoID: repoID},
 		&actions_model.ActionRunJobSummary{RepoID: repoID},
 		&actions_model.ActionRunnerToken{RepoID: repoID},
 		&actions_model.ActionTasksVersion{RepoID: repoID},
@@ -202,6 +213,11 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return err
 	}
 
+	// Delete code navigation indexes
+	if err := codenav_model.DeleteIndexesByRepoID(ctx, repoID); err != nil {
+		return err
+	}
+
 	// Delete Issues and related objects
 	var attachmentPaths []string
 	if attachmentPaths, err = issue_service.DeleteIssuesByRepoID(ctx, repoID); err != nil {
@@ -382,6 +398,11 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		}
 	}
 
+	// delete actions cache entries in ObjectStorage after the repo have already been deleted
+	for _, c := range caches {
+		actions_service.DeleteCacheContent(c)
+	}
+
 	return nil
 }
 
@@ -22,6 +22,7 @@ import (
 	"gitea.dev/modules/globallock"
 	"gitea.dev/modules/log"
 	"gitea.dev/modules/util"
+	audit_service "gitea.dev/services/audit"
 	notify_service "gitea.dev/services/notify"
 )
 
@@ -54,7 +55,7 @@ func AcceptTransferOwnership(ctx context.Context, repo *repo_model.Repository, d
 		return err
 	}
 
-	oldOwnerName := repo.OwnerName
+	oldOwnerID, oldOwnerName := repo.OwnerID, repo.OwnerName
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		if err := repoTransfer.LoadAttributes(ctx); err != nil {
@@ -84,6 +85,7 @@ func AcceptTransferOwnership(ctx context.Context, repo *repo_model.Repository, d
 	}
 	releaser()
 
+	audit_service.RecordRepositoryTransfer(ctx, doer, repo, oldOwnerID, oldOwnerName)
 	notify_service.TransferRepository(ctx, doer, repo, oldOwnerName)
 
 	return nil
@@ -440,7 +442,7 @@ func StartRepositoryTransfer(ctx context.Context, doer, newOwner *user_model.Use
 	}
 
 	var isDirectTransfer bool
-	oldOwnerName := repo.OwnerName
+	oldOwnerID, oldOwnerName := repo.OwnerID, repo.OwnerName
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		// Admin is always allowed to transfer || user transfer repo back to his account,
@@ -472,7 +474,7 @@ func StartRepositoryTransfer(ctx context.Context, doer, newOwner *user_model.Use
 			return err
 		}
 		if !hasAccess {
-			if err := AddOrUpdateCollaborator(ctx, repo, newOwner, perm.AccessModeRead); err != nil {
+			if err := AddOrUpdateCollaborator(ctx, doer, repo, newOwner, perm.AccessModeRead); err != nil {
 				return err
 			}
 		}
@@ -485,6 +487,7 @@ func StartRepositoryTransfer(ctx context.Context, doer, newOwner *user_model.Use
 	}
 
 	if isDirectTransfer {
+		audit_service.RecordRepositoryTransfer(ctx, doer, repo, oldOwnerID, oldOwnerName)
 		notify_service.TransferRepository(ctx, doer, repo, oldOwnerName)
 	} else {
 		// notify users who are able to accept / reject transfer
@@ -6,11 +6,14 @@ package secrets
 import (
 	"context"
 
+	audit_model "gitea.dev/models/audit"
 	"gitea.dev/models/db"
 	secret_model "gitea.dev/models/secret"
+	user_model "gitea.dev/models/user"
+	audit_service "gitea.dev/services/audit"
 )
 
-func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data, description string) (*secret_model.Secret, bool, error) {
+func CreateOrUpdateSecret(ctx context.Context, doer *user_model.User, ownerID, repoID int64, name, data, description string) (*secret_model.Secret, bool, error) {
 	if err := ValidateName(name); err != nil {
 		return nil, false, err
 	}
@@ -29,17 +32,21 @@ func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data
 		if err != nil {
 			return nil, false, err
 		}
+		audit_service.Record(ctx, doer, audit_model.ActionSecretCreate, audit_service.SecretTarget(s), nil, audit_service.SecretState(s))
 		return s, true, nil
 	}
 
+	before := audit_service.SecretState(s[0])
 	if err := secret_model.UpdateSecret(ctx, s[0].ID, data, description); err != nil {
 		return nil, false, err
 	}
+	s[0].Description = description
+	audit_service.Record(ctx, doer, audit_model.ActionSecretUpdate, audit_service.SecretTarget(s[0]), before, audit_service.SecretState(s[0]))
 
 	return s[0], false, nil
 }
 
-func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) error {
+func DeleteSecretByID(ctx context.Context, doer *user_model.User, ownerID, repoID, secretID int64) error {
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
 		OwnerID:  ownerID,
 		RepoID:   repoID,
@@ -52,10 +59,10 @@ func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) erro
 		return secret_model.ErrSecretNotFound{}
 	}
 
-	return deleteSecret(ctx, s[0])
+	return deleteSecret(ctx, doer, s[0])
 }
 
-func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string) error {
+func DeleteSecretByName(ctx context.Context, doer *user_model.User, ownerID, repoID int64, name string) error {
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
 		OwnerID: ownerID,
 		RepoID:  repoID,
@@ -68,12 +75,13 @@ func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string)
 		return secret_model.ErrSecretNotFound{}
 	}
 
-	return deleteSecret(ctx, s[0])
+	return deleteSecret(ctx, doer, s[0])
 }
 
-func deleteSecret(ctx context.Context, s *secret_model.Secret) error {
+func deleteSecret(ctx context.Context, doer *user_model.User, s *secret_model.Secret) error {
 	if _, err := db.DeleteByID[secret_model.Secret](ctx, s.ID); err != nil {
 		return err
 	}
+	audit_service.Record(ctx, doer, audit_model.ActionSecretDelete, audit_service.SecretTarget(s), audit_service.SecretState(s), nil)
 	return nil
 }
@@ -0,0 +1,207 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package secretscan
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	repo_model "gitea.dev/models/repo"
+	secretscan_model "gitea.dev/models/secretscan"
+	"gitea.dev/modules/container"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/git/gitcmd"
+	"gitea.dev/modules/graceful"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/queue"
+	"gitea.dev/modules/secretscan"
+	"gitea.dev/modules/setting"
+)
+
+// Leak is a secret found in a file of a commit
+type Leak struct {
+	*secretscan.Finding
+	CommitID string
+	Path     string
+}
+
+// historyScanQueue holds the IDs of the repositories whose full history should be scanned
+var historyScanQueue *queue.WorkerPoolQueue[int64]
+
+// Init starts the queue of the history scans
+func Init() error {
+	historyScanQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "secret_scanning", historyScanQueueHandler)
+	if historyScanQueue == nil {
+		return errors.New("unable to create secret_scanning queue")
+	}
+	go graceful.GetManager().RunWithCancel(historyScanQueue)
+	return nil
+}
+
+// QueueHistoryScan adds the repository to the queue of the history scans
+func QueueHistoryScan(repo *repo_model.Repository) error {
+	return historyScanQueue.Push(repo.ID)
+}
+
+func historyScanQueueHandler(items ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, repoID := range items {
+		if err := scanRepoHistory(ctx, repoID); err != nil {
+			log.Error("Secret scanning of the history of repository %d failed: %v", repoID, err)
+		}
+	}
+	return nil
+}
+
+func scanRepoHistory(ctx context.Context, repoID int64) error {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if repo_model.IsErrRepoNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if repo.IsEmpty {
+		return nil
+	}
+
+	leaks, err := ScanHistory(ctx, repo)
+	if err != nil {
+		return err
+	}
+	alerts, err := RecordLeaks(ctx, repo, leaks)
+	if err != nil {
+		return err
+	}
+	log.Trace("Secret scanning of the history of %-v raised %d new alert(s)", repo, len(alerts))
+	return nil
+}
+
+// RecordLeaks raises alerts for the leaked secrets which are not yet known in the repository and returns the new alerts
+func RecordLeaks(ctx context.Context, repo *repo_model.Repository, leaks []*Leak) ([]*secretscan_model.Alert, error) {
+	alerts := make([]*secretscan_model.Alert, 0, len(leaks))
+	for _, leak := range leaks {
+		alerts = append(alerts, &secretscan_model.Alert{
+			RepoID:      repo.ID,
+			Fingerprint: leak.Fingerprint(),
+			RuleID:      leak.Rule.ID,
+			Description: leak.Rule.Description,
+			Redacted:    leak.Redacted(),
+			CommitID:    leak.CommitID,
+			Path:        leak.Path,
+			Line:        leak.Line,
+		})
+	}
+	return secretscan_model.InsertAlerts(ctx, alerts)
+}
+
+func newLogCommand() *gitcmd.Command {
+	// "--reverse" lists the oldest commits first, so a secret is reported where it has been added
+	return gitcmd.NewCommand("log", "-z", "--raw", "--no-abbrev", "--no-renames", "--reverse", "--format=%H")
+}
+
+// ScanPush returns the secrets in the files added or changed by the commits reachable from newCommitID which are not yet referenced in the repository.
+// env is the environment of the git hook, it gives access to the quarantined objects of the push.
+func ScanPush(ctx context.Context, repo *repo_model.Repository, newCommitID string, env []string) ([]*Leak, error) {
+	cmd := newLogCommand().AddDynamicArguments(newCommitID).AddArguments("--not", "--all")
+	return scanCommits(ctx, repo, cmd, env)
+}
+
+// ScanHistory returns the secrets in the files of all commits of the repository
+func ScanHistory(ctx context.Context, repo *repo_model.Repository) ([]*Leak, error) {
+	return scanCommits(ctx, repo, newLogCommand().AddArguments("--all"), nil)
+}
+
+// scanCommits scans the blobs listed by the "git log -z --raw --format=%H" command, every blob is scanned only once
+func scanCommits(ctx context.Context, repo git.RepositoryFacade, cmd *gitcmd.Command, env []string) ([]*Leak, error) {
+	rules := secretscan.Rules()
+
+	batch, err := git.NewBatchWithEnv(ctx, repo, env)
+	if err != nil {
+		return nil, err
+	}
+	defer batch.Close()
+
+	stdoutReader, stdoutReaderClose := cmd.MakeStdoutPipe()
+	defer stdoutReaderClose()
+
+	var leaks []*Leak
+	scannedBlobs := make(container.Set[string])
+	err = cmd.WithEnv(env).WithRepo(repo).
+		WithPipelineFunc(func(gitcmd.Context) error {
+			rd := bufio.NewReader(stdoutReader)
+			var commitID string
+			for {
+				token, err := rd.ReadString('\x00')
+				if err == io.EOF {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				token = strings.TrimLeft(strings.TrimSuffix(token, "\x00"), "\n")
+				if token == "" {
+					continue
+				}
+				if token[0] != ':' {
+					commitID = token
+					continue
+				}
+
+				// ":<old mode> <new mode> <old id> <new id> <status>" is followed by the path
+				path, err := rd.ReadString('\x00')
+				if err != nil {
+					return err
+				}
+				path = strings.TrimSuffix(path, "\x00")
+				fields := strings.Fields(token)
+				// only regular files are scanned, not deletions, symlinks or submodules
+				if len(fields) != 5 || fields[4] == "D" || (fields[1] != "100644" && fields[1] != "100755") {
+					continue
+				}
+				if !scannedBlobs.Add(fields[3]) {
+					continue
+				}
+
+				findings, err := scanBlob(batch, fields[3], rules)
+				if err != nil {
+					return err
+				}
+				for _, f := range findings {
+					leaks = append(leaks, &Leak{Finding: f, CommitID: commitID, Path: path})
+				}
+			}
+		}).RunWithStderr(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return leaks, nil
+}
+
+func scanBlob(batch git.CatFileBatch, blobID string, rules []*secretscan.Rule) ([]*secretscan.Finding, error) {
+	info, err := batch.QueryInfo(blobID)
+	if err != nil {
+		return nil, err
+	}
+	if setting.SecretScanning.MaxFileSize > 0 && info.Size > setting.SecretScanning.MaxFileSize {
+		return nil, nil
+	}
+
+	info, batchReader, err := batch.QueryContent(blobID)
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(io.LimitReader(batchReader, info.Size))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = batchReader.Discard(1); err != nil {
+		return nil, err
+	}
+	if secretscan.IsBinary(content) {
+		return nil, nil
+	}
+	return secretscan.Scan(content, rules), nil
+}
@@ -16,8 +16,10 @@ import (
 	git_model "gitea.dev/models/git"
 	issues_model "gitea.dev/models/issues"
 	"gitea.dev/models/organization"
+	packages_model "gitea.dev/models/packages"
 	access_model "gitea.dev/models/perm/access"
 	pull_model "gitea.dev/models/pull"
+	quota_model "gitea.dev/models/quota"
 	repo_model "gitea.dev/models/repo"
 	user_model "gitea.dev/models/user"
 	"gitea.dev/modules/setting"
@@ -89,6 +91,7 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		&user_model.Setting{UserID: u.ID},
 		&user_model.UserBadge{UserID: u.ID},
 		&pull_model.AutoMerge{DoerID: u.ID},
+		&pull_model.MergeQueueEntry{DoerID: u.ID},
 		&pull_model.ReviewState{UserID: u.ID},
 		&user_model.Redirect{RedirectUserID: u.ID},
 		&actions_model.ActionRunner{OwnerID: u.ID},
@@ -100,6 +103,8 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		&auth_model.WebAuthnCredential{UserID: u.ID},
 		&activities_model.Notification{UserID: u.ID},
 		&issues_model.IssueWatch{UserID: u.ID},
+		&packages_model.PackageUpstream{OwnerID: u.ID},
+		&quota_model.GroupOwner{OwnerID: u.ID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
 	}
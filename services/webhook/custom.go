@@ -0,0 +1,529 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	webhook_model "gitea.dev/models/webhook"
+	"gitea.dev/modules/json"
+	"gitea.dev/modules/log"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	webhook_module "gitea.dev/modules/webhook"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// Signature schemes of the custom webhooks
+const (
+	// CustomSignatureNone sends the requests unsigned
+	CustomSignatureNone = ""
+	// CustomSignatureHMACSHA256 signs the body with the secret like the Gitea webhooks
+	CustomSignatureHMACSHA256 = "hmac-sha256"
+	// CustomSignatureEd25519 signs the body with an Ed25519 key, the secret is the base64 encoded seed of the key
+	CustomSignatureEd25519 = "ed25519"
+	// CustomSignatureStandardWebhooks signs the requests as described by https://www.standardwebhooks.com
+	CustomSignatureStandardWebhooks = "standard-webhooks"
+)
+
+const (
+	maxCustomPayloadTemplateSize = 64 * 1024
+	maxCustomRenderedSize        = 4 * 1024 * 1024
+	customTemplateTimeout        = 10 * time.Second
+	maxCustomRangeIterations     = 10000
+	maxCustomHeaders             = 20
+	standardWebhooksSecretPrefix = "whsec_"
+)
+
+// customReservedHeaders can't be set by a custom webhook, they are set by the request itself or by other settings
+var customReservedHeaders = []string{"Authorization", "Content-Length", "Content-Type", "Host", "Transfer-Encoding"}
+
+type (
+	// CustomHeader is a header sent by a custom webhook, the value is a template like the payload
+	CustomHeader struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	// CustomMeta contains the metadata for the webhook
+	CustomMeta struct {
+		// PayloadTemplate renders the body of the requests, the payload is sent as JSON if it is empty
+		PayloadTemplate string         `json:"payload_template"`
+		ContentType     string         `json:"content_type"`
+		Headers         []CustomHeader `json:"headers"`
+		SignatureScheme string         `json:"signature_scheme"`
+	}
+
+	// CustomTemplateData is the data the templates of a custom webhook are executed with
+	CustomTemplateData struct {
+		// Event is the event of the request, for example "issues"
+		Event string
+		// EventType is the detailed event type, for example "issue_assign"
+		EventType string
+		// Delivery is the unique ID of the delivery
+		Delivery string
+		// Payload is the api.*Payload struct of the event
+		Payload any
+	}
+)
+
+// GetCustomHook returns custom metadata
+func GetCustomHook(w *webhook_model.Webhook) *CustomMeta {
+	s := &CustomMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetCustomHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// HeadersText returns the headers as the "Name: value" lines they are edited as
+func (m *CustomMeta) HeadersText() string {
+	lines := make([]string, 0, len(m.Headers))
+	for _, h := range m.Headers {
+		lines = append(lines, h.Name+": "+h.Value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseCustomHeaders parses "Name: value" lines, empty lines are skipped
+func ParseCustomHeaders(text string) ([]CustomHeader, error) {
+	var headers []CustomHeader
+	for line := range strings.SplitSeq(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, util.ErrorWrap(util.ErrInvalidArgument, "header %q has no value", line)
+		}
+		headers = append(headers, CustomHeader{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+	}
+	return headers, nil
+}
+
+// ApplyConfig sets the metadata from the config options of the API, the options which are missing are kept
+func (m *CustomMeta) ApplyConfig(config map[string]string) error {
+	if v, ok := config["payload_template"]; ok {
+		m.PayloadTemplate = v
+	}
+	if v, ok := config["payload_content_type"]; ok {
+		m.ContentType = strings.TrimSpace(v)
+	}
+	if v, ok := config["headers"]; ok {
+		headers, err := ParseCustomHeaders(v)
+		if err != nil {
+			return err
+		}
+		m.Headers = headers
+	}
+	if v, ok := config["signature_scheme"]; ok {
+		m.SignatureScheme = v
+	}
+	return nil
+}
+
+// Config returns the config options of the metadata shown by the API
+func (m *CustomMeta) Config() map[string]string {
+	return map[string]string{
+		"payload_template":     m.PayloadTemplate,
+		"payload_content_type": m.ContentType,
+		"headers":              m.HeadersText(),
+		"signature_scheme":     m.SignatureScheme,
+	}
+}
+
+// customRangeFunc is appended to the pipeline of every range of a template, it isn't meant to be called by the templates
+const customRangeFunc = "customRange"
+
+// newCustomTemplateFuncs returns the functions of a template, they abort its execution once the context is done
+// or the ranges of the template iterated more than maxCustomRangeIterations times
+func newCustomTemplateFuncs(ctx context.Context) template.FuncMap {
+	iterations := 0
+	return template.FuncMap{
+		"toJSON": func(v any) (string, error) {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		customRangeFunc: func(v any) (any, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			val := reflect.Indirect(reflect.ValueOf(v))
+			switch val.Kind() {
+			case reflect.Invalid:
+				return v, nil
+			case reflect.Array, reflect.Slice, reflect.Map:
+				iterations += val.Len()
+				if iterations > maxCustomRangeIterations {
+					return nil, fmt.Errorf("the template iterated more than %d times", maxCustomRangeIterations)
+				}
+				return v, nil
+			}
+			return nil, fmt.Errorf("range can only iterate over lists and maps, not over %s", val.Kind())
+		},
+	}
+}
+
+// guardCustomTemplateNode rejects the calls of other templates and passes the values iterated by the ranges
+// through customRangeFunc, so the execution of a template always ends
+func guardCustomTemplateNode(tree *parse.Tree, node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := guardCustomTemplateNode(tree, child); err != nil {
+				return err
+			}
+		}
+	case *parse.IfNode:
+		return guardCustomTemplateBranch(tree, &n.BranchNode)
+	case *parse.WithNode:
+		return guardCustomTemplateBranch(tree, &n.BranchNode)
+	case *parse.RangeNode:
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pipe.Pos,
+			Args:     []parse.Node{parse.NewIdentifier(customRangeFunc).SetTree(tree).SetPos(n.Pipe.Pos)},
+		})
+		return guardCustomTemplateBranch(tree, &n.BranchNode)
+	case *parse.TemplateNode:
+		return errors.New("a template can't call other templates")
+	}
+	return nil
+}
+
+func guardCustomTemplateBranch(tree *parse.Tree, n *parse.BranchNode) error {
+	if err := guardCustomTemplateNode(tree, n.List); err != nil {
+		return err
+	}
+	return guardCustomTemplateNode(tree, n.ElseList)
+}
+
+func parseCustomTemplate(ctx context.Context, name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(newCustomTemplateFuncs(ctx)).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Name() != name {
+			return nil, errors.New("a template can't define other templates")
+		}
+	}
+	if tmpl.Tree == nil {
+		return tmpl, nil
+	}
+	if err := guardCustomTemplateNode(tmpl.Tree, tmpl.Tree.Root); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// Validate checks the templates, the headers and the signature scheme of the metadata,
+// and the secret the scheme signs the requests with
+func (m *CustomMeta) Validate(secret string) error {
+	if len(m.PayloadTemplate) > maxCustomPayloadTemplateSize {
+		return util.ErrorWrap(util.ErrInvalidArgument, "the payload template is larger than %d bytes", maxCustomPayloadTemplateSize)
+	}
+	if _, err := parseCustomTemplate(context.Background(), "payload", m.PayloadTemplate); err != nil {
+		return util.ErrorWrap(util.ErrInvalidArgument, "invalid payload template: %v", err)
+	}
+	if m.ContentType != "" {
+		if _, _, err := mime.ParseMediaType(m.ContentType); err != nil {
+			return util.ErrorWrap(util.ErrInvalidArgument, "invalid content type %q", m.ContentType)
+		}
+	}
+
+	if len(m.Headers) > maxCustomHeaders {
+		return util.ErrorWrap(util.ErrInvalidArgument, "a webhook can send at most %d headers", maxCustomHeaders)
+	}
+	for _, h := range m.Headers {
+		if !httpguts.ValidHeaderFieldName(h.Name) {
+			return util.ErrorWrap(util.ErrInvalidArgument, "invalid header name %q", h.Name)
+		}
+		for _, reserved := range customReservedHeaders {
+			if strings.EqualFold(h.Name, reserved) {
+				return util.ErrorWrap(util.ErrInvalidArgument, "the header %q can't be set", h.Name)
+			}
+		}
+		if _, err := parseCustomTemplate(context.Background(), h.Name, h.Value); err != nil {
+			return util.ErrorWrap(util.ErrInvalidArgument, "invalid template of header %q: %v", h.Name, err)
+		}
+	}
+
+	switch m.SignatureScheme {
+	case CustomSignatureNone, CustomSignatureHMACSHA256:
+	case CustomSignatureEd25519:
+		if _, err := customEd25519Key(secret); err != nil {
+			return err
+		}
+	case CustomSignatureStandardWebhooks:
+		if _, err := customStandardWebhooksKey(secret); err != nil {
+			return err
+		}
+	default:
+		return util.ErrorWrap(util.ErrInvalidArgument, "unknown signature scheme %q", m.SignatureScheme)
+	}
+	return nil
+}
+
+// GenerateCustomSecret returns a new secret for the signature schemes which need one of a given form,
+// and an empty string for the others
+func GenerateCustomSecret(scheme string) (string, error) {
+	switch scheme {
+	case CustomSignatureEd25519:
+		seed := make([]byte, ed25519.SeedSize)
+		if _, err := rand.Read(seed); err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(seed), nil
+	case CustomSignatureStandardWebhooks:
+		key := make([]byte, 24)
+		if _, err := rand.Read(key); err != nil {
+			return "", err
+		}
+		return standardWebhooksSecretPrefix + base64.StdEncoding.EncodeToString(key), nil
+	}
+	return "", nil
+}
+
+func customEd25519Key(secret string) (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, util.ErrorWrap(util.ErrInvalidArgument, "the Ed25519 secret must be a base64 encoded %d bytes seed", ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func customStandardWebhooksKey(secret string) ([]byte, error) {
+	if encoded, ok := strings.CutPrefix(secret, standardWebhooksSecretPrefix); ok {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, util.ErrorWrap(util.ErrInvalidArgument, "the secret after %q must be base64 encoded", standardWebhooksSecretPrefix)
+		}
+		return key, nil
+	}
+	if secret == "" {
+		return nil, util.ErrorWrap(util.ErrInvalidArgument, "the Standard Webhooks signature needs a secret")
+	}
+	return []byte(secret), nil
+}
+
+// CustomPublicKey returns the base64 encoded public key verifying the Ed25519 signatures of the webhook,
+// or an empty string if the webhook doesn't sign its requests with Ed25519
+func CustomPublicKey(w *webhook_model.Webhook) string {
+	if w.Type != webhook_module.CUSTOM || GetCustomHook(w).SignatureScheme != CustomSignatureEd25519 {
+		return ""
+	}
+	key, err := customEd25519Key(w.Secret)
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+}
+
+// customConvertor passes the payloads to the templates as they are
+type customConvertor struct{}
+
+var _ payloadConvertor[any] = customConvertor{}
+
+// Create implements PayloadConvertor Create method
+func (customConvertor) Create(p *api.CreatePayload) (any, error) { return p, nil }
+
+// Delete implements PayloadConvertor Delete method
+func (customConvertor) Delete(p *api.DeletePayload) (any, error) { return p, nil }
+
+// Fork implements PayloadConvertor Fork method
+func (customConvertor) Fork(p *api.ForkPayload) (any, error) { return p, nil }
+
+// Issue implements PayloadConvertor Issue method
+func (customConvertor) Issue(p *api.IssuePayload) (any, error) { return p, nil }
+
+// IssueComment implements PayloadConvertor IssueComment method
+func (customConvertor) IssueComment(p *api.IssueCommentPayload) (any, error) { return p, nil }
+
+// Push implements PayloadConvertor Push method
+func (customConvertor) Push(p *api.PushPayload) (any, error) { return p, nil }
+
+// PullRequest implements PayloadConvertor PullRequest method
+func (customConvertor) PullRequest(p *api.PullRequestPayload) (any, error) { return p, nil }
+
+// Review implements PayloadConvertor Review method
+func (customConvertor) Review(p *api.PullRequestPayload, _ webhook_module.HookEventType) (any, error) {
+	return p, nil
+}
+
+// Repository implements PayloadConvertor Repository method
+func (customConvertor) Repository(p *api.RepositoryPayload) (any, error) { return p, nil }
+
+// Release implements PayloadConvertor Release method
+func (customConvertor) Release(p *api.ReleasePayload) (any, error) { return p, nil }
+
+// Wiki implements PayloadConvertor Wiki method
+func (customConvertor) Wiki(p *api.WikiPayload) (any, error) { return p, nil }
+
+func (customConvertor) Package(p *api.PackagePayload) (any, error) { return p, nil }
+
+func (customConvertor) Status(p *api.CommitStatusPayload) (any, error) { return p, nil }
+
+func (customConvertor) WorkflowRun(p *api.WorkflowRunPayload) (any, error) { return p, nil }
+
+func (customConvertor) WorkflowJob(p *api.WorkflowJobPayload) (any, error) { return p, nil }
+
+var errCustomTemplateTooLarge = util.ErrorWrap(util.ErrContentTooLarge, "the rendered template is larger than %d bytes", maxCustomRenderedSize)
+
+// customTemplateWriter aborts the execution of a template once its output exceeds maxCustomRenderedSize
+// or the context is done, a template can't be trusted to end in a reasonable time or size
+type customTemplateWriter struct {
+	ctx context.Context
+	buf bytes.Buffer
+}
+
+func (w *customTemplateWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if w.buf.Len()+len(p) > maxCustomRenderedSize {
+		return 0, errCustomTemplateTooLarge
+	}
+	return w.buf.Write(p)
+}
+
+func executeCustomTemplate(ctx context.Context, name, text string, data *CustomTemplateData) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, customTemplateTimeout)
+	defer cancel()
+	tmpl, err := parseCustomTemplate(ctx, name, text)
+	if err != nil {
+		return "", err
+	}
+
+	w := &customTemplateWriter{ctx: ctx}
+	if err := tmpl.Execute(w, data); errors.Is(err, context.DeadlineExceeded) {
+		return "", fmt.Errorf("the template took longer than %s to execute", customTemplateTimeout)
+	} else if err != nil {
+		return "", err
+	}
+	return w.buf.String(), nil
+}
+
+// signCustomRequest adds the signature headers of the scheme of the webhook
+func signCustomRequest(req *http.Request, scheme, secret string, t *webhook_model.HookTask, body []byte, now time.Time) error {
+	switch scheme {
+	case CustomSignatureHMACSHA256:
+		if secret == "" {
+			return nil
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write(body)
+		signature := hex.EncodeToString(mac.Sum(nil))
+		req.Header.Set("X-Gitea-Signature", signature)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+signature)
+	case CustomSignatureEd25519:
+		key, err := customEd25519Key(secret)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Gitea-Signature-Ed25519", base64.StdEncoding.EncodeToString(ed25519.Sign(key, body)))
+	case CustomSignatureStandardWebhooks:
+		key, err := customStandardWebhooksKey(secret)
+		if err != nil {
+			return err
+		}
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		mac := hmac.New(sha256.New, key)
+		_, _ = fmt.Fprintf(mac, "%s.%s.", t.DeliveryUUID, timestamp)
+		_, _ = mac.Write(body)
+		// the header names are lower case in the specification, they are set as they are written there
+		req.Header["webhook-id"] = []string{t.DeliveryUUID}
+		req.Header["webhook-timestamp"] = []string{timestamp}
+		req.Header["webhook-signature"] = []string{"v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))}
+	}
+	return nil
+}
+
+func newCustomRequest(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &CustomMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newCustomRequest meta json: %w", err)
+	}
+
+	payload, err := newPayload[any](customConvertor{}, []byte(t.PayloadContent), t.EventType)
+	if err != nil {
+		return nil, nil, err
+	}
+	data := &CustomTemplateData{
+		Event:     t.EventType.Event(),
+		EventType: string(t.EventType),
+		Delivery:  t.DeliveryUUID,
+		Payload:   payload,
+	}
+
+	var body []byte
+	if meta.PayloadTemplate == "" {
+		body, err = json.MarshalIndent(payload, "", "  ")
+	} else {
+		var rendered string
+		rendered, err = executeCustomTemplate(ctx, "payload", meta.PayloadTemplate, data)
+		body = []byte(rendered)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("newCustomRequest payload: %w", err)
+	}
+
+	method := w.HTTPMethod
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", util.IfZero(meta.ContentType, "application/json"))
+	req.Header.Set("X-Gitea-Delivery", t.DeliveryUUID)
+	req.Header.Set("X-Gitea-Event", data.Event)
+	req.Header.Set("X-Gitea-Event-Type", data.EventType)
+
+	for _, h := range meta.Headers {
+		value, err := executeCustomTemplate(ctx, h.Name, h.Value, data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("newCustomRequest header %s: %w", h.Name, err)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return nil, nil, fmt.Errorf("newCustomRequest header %s: invalid value %q", h.Name, value)
+		}
+		req.Header.Set(h.Name, value)
+	}
+
+	if err := signCustomRequest(req, meta.SignatureScheme, w.Secret, t, body, time.Now()); err != nil {
+		return nil, nil, fmt.Errorf("newCustomRequest signature: %w", err)
+	}
+	return req, body, nil
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.CUSTOM, newCustomRequest)
+}
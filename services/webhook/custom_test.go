@@ -0,0 +1,210 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	webhook_model "gitea.dev/models/webhook"
+	"gitea.dev/modules/json"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/util"
+	webhook_module "gitea.dev/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCustomTestRequest(t *testing.T, meta *CustomMeta, secret string) (body []byte, header func(string) string) {
+	p := issueTestPayload()
+	p.Action = api.HookIssueOpened
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+	metaJSON, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	hook := &webhook_model.Webhook{
+		RepoID:     3,
+		IsActive:   true,
+		Type:       webhook_module.CUSTOM,
+		URL:        "https://example.com/hooks",
+		Meta:       string(metaJSON),
+		HTTPMethod: "PUT",
+		Secret:     secret,
+	}
+	task := &webhook_model.HookTask{
+		HookID:         hook.ID,
+		UUID:           "task-uuid",
+		DeliveryUUID:   "delivery-uuid",
+		EventType:      webhook_module.HookEventIssues,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	req, reqBody, err := newCustomRequest(t.Context(), hook, task)
+	require.NoError(t, err)
+	assert.Equal(t, "PUT", req.Method)
+	assert.Equal(t, "https://example.com/hooks", req.URL.String())
+	sent, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, reqBody, sent)
+	return reqBody, req.Header.Get
+}
+
+func TestCustomPayloadTemplate(t *testing.T) {
+	body, header := newCustomTestRequest(t, &CustomMeta{
+		PayloadTemplate: `{"text": {{toJSON (printf "%s #%d %s" .Payload.Repository.FullName .Payload.Issue.Index .Payload.Action)}}, "event": "{{.EventType}}"}`,
+		ContentType:     "application/vnd.tickets+json",
+		Headers:         []CustomHeader{{Name: "X-Ticket-Event", Value: "{{.Event}}/{{.Delivery}}"}},
+	}, "")
+
+	assert.JSONEq(t, `{"text": "test/repo #2 opened", "event": "issues"}`, string(body))
+	assert.Equal(t, "application/vnd.tickets+json", header("Content-Type"))
+	assert.Equal(t, "issues/delivery-uuid", header("X-Ticket-Event"))
+	assert.Equal(t, "delivery-uuid", header("X-Gitea-Delivery"))
+	assert.Empty(t, header("X-Gitea-Signature"))
+}
+
+func TestCustomPayloadWithoutTemplate(t *testing.T) {
+	body, header := newCustomTestRequest(t, &CustomMeta{}, "")
+
+	var payload api.IssuePayload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "crash", payload.Issue.Title)
+	assert.Equal(t, "application/json", header("Content-Type"))
+}
+
+func TestCustomTemplateLimits(t *testing.T) {
+	data := &CustomTemplateData{Event: "issues", Payload: issueTestPayload()}
+
+	t.Run("TooLarge", func(t *testing.T) {
+		_, err := executeCustomTemplate(t.Context(), "payload", strings.Repeat(`{{printf "%999999s" .Event}}`, 5), data)
+		assert.ErrorIs(t, err, util.ErrContentTooLarge)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := executeCustomTemplate(ctx, "payload", `{{range .Payload.Issue.Assignees}}{{end}}{{toJSON .Payload}}`, data)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		rendered, err := executeCustomTemplate(t.Context(), "payload", `{{range $i, $u := .Payload.Issue.Assignees}}{{$i}}:{{$u.UserName}} {{end}}`, data)
+		require.NoError(t, err)
+		assert.Equal(t, "0:user1 ", rendered)
+
+		// only the lists and maps of the data can be iterated
+		_, err = executeCustomTemplate(t.Context(), "payload", `{{range 1000000000}}{{end}}`, data)
+		assert.ErrorContains(t, err, "range can only iterate over lists and maps")
+		_, err = executeCustomTemplate(t.Context(), "payload", `{{range .Payload.Issue.ID}}{{end}}`, data)
+		assert.ErrorContains(t, err, "range can only iterate over lists and maps")
+
+		nested := `{{range .Payload.Issue.Assignees}}` + strings.Repeat(`{{range $.Payload.Issue.Assignees}}`, 3) + strings.Repeat(`{{end}}`, 4)
+		rendered, err = executeCustomTemplate(t.Context(), "payload", nested, data)
+		require.NoError(t, err)
+		assert.Empty(t, rendered)
+		largeData := &CustomTemplateData{Payload: &api.IssuePayload{Issue: &api.Issue{Assignees: make([]*api.User, 100)}}}
+		_, err = executeCustomTemplate(t.Context(), "payload", nested, largeData)
+		assert.ErrorContains(t, err, "the template iterated more than")
+	})
+}
+
+func TestCustomSignatures(t *testing.T) {
+	t.Run("HMAC-SHA256", func(t *testing.T) {
+		body, header := newCustomTestRequest(t, &CustomMeta{SignatureScheme: CustomSignatureHMACSHA256}, "secret")
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		signature := hex.EncodeToString(mac.Sum(nil))
+		assert.Equal(t, signature, header("X-Gitea-Signature"))
+		assert.Equal(t, "sha256="+signature, header("X-Hub-Signature-256"))
+	})
+
+	t.Run("Ed25519", func(t *testing.T) {
+		secret, err := GenerateCustomSecret(CustomSignatureEd25519)
+		require.NoError(t, err)
+		body, header := newCustomTestRequest(t, &CustomMeta{SignatureScheme: CustomSignatureEd25519}, secret)
+
+		publicKey, err := base64.StdEncoding.DecodeString(CustomPublicKey(&webhook_model.Webhook{
+			Type:   webhook_module.CUSTOM,
+			Meta:   `{"signature_scheme":"ed25519"}`,
+			Secret: secret,
+		}))
+		require.NoError(t, err)
+		signature, err := base64.StdEncoding.DecodeString(header("X-Gitea-Signature-Ed25519"))
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(publicKey, body, signature))
+	})
+
+	t.Run("StandardWebhooks", func(t *testing.T) {
+		// the example secret of the specification
+		secret := "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+		body, header := newCustomTestRequest(t, &CustomMeta{SignatureScheme: CustomSignatureStandardWebhooks}, secret)
+
+		assert.Equal(t, "delivery-uuid", header("webhook-id"))
+		key, err := base64.StdEncoding.DecodeString("MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw")
+		require.NoError(t, err)
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte("delivery-uuid." + header("webhook-timestamp") + "."))
+		mac.Write(body)
+		assert.Equal(t, "v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)), header("webhook-signature"))
+	})
+}
+
+func TestCustomMetaValidate(t *testing.T) {
+	ed25519Secret, err := GenerateCustomSecret(CustomSignatureEd25519)
+	require.NoError(t, err)
+
+	for _, c := range []struct {
+		meta   CustomMeta
+		secret string
+	}{
+		{CustomMeta{}, ""},
+		{CustomMeta{PayloadTemplate: `{{.Payload.Action}}`, ContentType: "text/plain; charset=utf-8"}, ""},
+		{CustomMeta{Headers: []CustomHeader{{Name: "X-Event", Value: "{{.Event}}"}}}, ""},
+		{CustomMeta{SignatureScheme: CustomSignatureEd25519}, ed25519Secret},
+		{CustomMeta{SignatureScheme: CustomSignatureStandardWebhooks}, "plain secret"},
+	} {
+		assert.NoError(t, c.meta.Validate(c.secret), c.meta)
+	}
+
+	for _, c := range []struct {
+		meta   CustomMeta
+		secret string
+	}{
+		{CustomMeta{PayloadTemplate: `{{.Payload`}, ""},
+		{CustomMeta{PayloadTemplate: `{{unknownFunc}}`}, ""},
+		{CustomMeta{PayloadTemplate: `{{define "x"}}{{template "x"}}{{end}}`}, ""},
+		{CustomMeta{PayloadTemplate: `{{template "payload"}}`}, ""},
+		{CustomMeta{PayloadTemplate: `{{block "x" .}}{{.Event}}{{end}}`}, ""},
+		{CustomMeta{Headers: []CustomHeader{{Name: "X-Event", Value: `{{if .Event}}{{range .Payload}}{{template "x"}}{{end}}{{end}}`}}}, ""},
+		{CustomMeta{ContentType: "not a type"}, ""},
+		{CustomMeta{Headers: []CustomHeader{{Name: "X Event", Value: "1"}}}, ""},
+		{CustomMeta{Headers: []CustomHeader{{Name: "content-type", Value: "text/plain"}}}, ""},
+		{CustomMeta{SignatureScheme: "md5"}, "secret"},
+		{CustomMeta{SignatureScheme: CustomSignatureEd25519}, "secret"},
+		{CustomMeta{SignatureScheme: CustomSignatureStandardWebhooks}, ""},
+	} {
+		assert.ErrorIs(t, c.meta.Validate(c.secret), util.ErrInvalidArgument, c.meta)
+	}
+}
+
+func TestParseCustomHeaders(t *testing.T) {
+	headers, err := ParseCustomHeaders("X-Event: {{.Event}}\n\n  X-Source:gitea  \n")
+	require.NoError(t, err)
+	assert.Equal(t, []CustomHeader{{Name: "X-Event", Value: "{{.Event}}"}, {Name: "X-Source", Value: "gitea"}}, headers)
+	assert.Equal(t, "X-Event: {{.Event}}\nX-Source: gitea", (&CustomMeta{Headers: headers}).HeadersText())
+
+	_, err = ParseCustomHeaders("X-Event")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
@@ -127,18 +127,18 @@ func addDefaultHeaders(req *http.Request, secret []byte, w *webhook_model.Webhoo
 		}
 	}
 
-	req.Header.Add("X-Gitea-Delivery", t.UUID)
+	req.Header.Add("X-Gitea-Delivery", t.DeliveryUUID)
 	req.Header.Add("X-Gitea-Event", event)
 	req.Header.Add("X-Gitea-Event-Type", eventType)
 	req.Header.Add("X-Gitea-Signature", signatureSHA256)
 	req.Header.Add("X-Gitea-Hook-Installation-Target-Type", targetType)
-	req.Header.Add("X-Gogs-Delivery", t.UUID)
+	req.Header.Add("X-Gogs-Delivery", t.DeliveryUUID)
 	req.Header.Add("X-Gogs-Event", event)
 	req.Header.Add("X-Gogs-Event-Type", eventType)
 	req.Header.Add("X-Gogs-Signature", signatureSHA256)
 	req.Header.Add("X-Hub-Signature", "sha1="+signatureSHA1)
 	req.Header.Add("X-Hub-Signature-256", "sha256="+signatureSHA256)
-	req.Header["X-GitHub-Delivery"] = []string{t.UUID}
+	req.Header["X-GitHub-Delivery"] = []string{t.DeliveryUUID}
 	req.Header["X-GitHub-Event"] = []string{event}
 	req.Header["X-GitHub-Event-Type"] = []string{eventType}
 	req.Header["X-GitHub-Hook-Installation-Target-Type"] = []string{targetType}
@@ -212,6 +212,9 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		return nil
 	}
 
+	// attempted is set once the request has been sent, retryable if the failure of the attempt may be temporary
+	var attempted, retryable bool
+
 	// All code from this point will update the hook task
 	defer func() {
 		t.Delivered = timeutil.TimeStampNanoNow()
@@ -223,6 +226,10 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 			log.Trace("Hook delivery failed: %s", t.UUID)
 		}
 
+		if attempted {
+			handleDeliveryResult(ctx, w, t, retryable)
+		}
+
 		if err := webhook_model.UpdateHookTask(ctx, t); err != nil {
 			log.Error("UpdateHookTask [%d]: %v", t.ID, err)
 		}
@@ -245,11 +252,15 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 
 	if !w.IsActive {
 		log.Trace("Webhook %s in Webhook Task[%d] is not active", w.URL, t.ID)
+		// keep the event, for example the pending retries of a webhook disabled after too many failures
+		t.IsDeadLetter = true
 		return nil
 	}
 
+	attempted = true
 	resp, err := webhookHTTPClient.Do(req.WithContext(ctx))
 	if err != nil {
+		retryable = isRetryableError(err)
 		t.ResponseInfo.Body = fmt.Sprintf("Delivery: %v", err)
 		return fmt.Errorf("unable to deliver webhook task[%d] in %s due to error in http client: %w", t.ID, w.URL, err)
 	}
@@ -257,6 +268,7 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 
 	// Status code is 20x can be seen as succeed.
 	t.IsSucceed = resp.StatusCode/100 == 2
+	retryable = isRetryableStatus(resp.StatusCode)
 	t.ResponseInfo.Status = resp.StatusCode
 	for k, vals := range resp.Header {
 		t.ResponseInfo.Headers[k] = strings.Join(vals, ",")
@@ -6,6 +6,7 @@ package webhook
 import (
 	"fmt"
 	"html"
+	"maps"
 	"net/url"
 	"strings"
 
@@ -410,6 +411,9 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		config["icon_url"] = s.IconURL
 		config["color"] = s.Color
 	}
+	if w.Type == webhook_module.CUSTOM {
+		maps.Copy(config, GetCustomHook(w).Config())
+	}
 
 	return &api.Hook{
 		ID:     w.ID,
@@ -427,3 +431,19 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		BranchFilter:        w.BranchFilter,
 	}, nil
 }
+
+// ToHookDelivery converts a hook task to an api.HookDelivery
+func ToHookDelivery(t *webhook_model.HookTask) *api.HookDelivery {
+	d := &api.HookDelivery{
+		ID:        t.ID,
+		UUID:      t.UUID,
+		Event:     string(t.EventType),
+		Attempt:   t.Attempt,
+		Succeeded: t.IsSucceed,
+		Delivered: t.Delivered.AsTime(),
+	}
+	if t.ResponseInfo != nil {
+		d.StatusCode = t.ResponseInfo.Status
+	}
+	return d
+}
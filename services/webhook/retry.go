@@ -0,0 +1,212 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"time"
+
+	"gitea.dev/models/db"
+	"gitea.dev/models/organization"
+	"gitea.dev/models/perm"
+	repo_model "gitea.dev/models/repo"
+	user_model "gitea.dev/models/user"
+	webhook_model "gitea.dev/models/webhook"
+	"gitea.dev/modules/log"
+	"gitea.dev/modules/optional"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/timeutil"
+	"gitea.dev/services/mailer"
+)
+
+// isRetryableError returns whether a delivery failing with the error of the http client may succeed later,
+// that is when the receiver timed out or couldn't be reached
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isRetryableStatus returns whether a delivery answered with the status code may succeed later
+func isRetryableStatus(status int) bool {
+	return status >= http.StatusInternalServerError || status == http.StatusTooManyRequests
+}
+
+// retryBackoff returns the delay before retrying the given failed attempt, it doubles with every attempt
+func retryBackoff(attempt int) time.Duration {
+	backoff := setting.Webhook.RetryBackoff
+	for i := 1; i < attempt && backoff < setting.Webhook.RetryMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, setting.Webhook.RetryMaxBackoff)
+}
+
+// handleDeliveryResult schedules the retry of a failed delivery, or turns it into a dead letter once it can't be retried.
+// It counts the failed deliveries of the webhook and disables it when there are too many in a row.
+// It has to be called before the task is updated.
+func handleDeliveryResult(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask, retryable bool) {
+	if t.IsSucceed {
+		if err := webhook_model.ResetWebhookFailureCount(ctx, w.ID); err != nil {
+			log.Error("ResetWebhookFailureCount[%d]: %v", w.ID, err)
+		}
+		return
+	}
+
+	if retryable && t.Attempt <= setting.Webhook.MaxRetries {
+		retryAfter := timeutil.TimeStamp(time.Now().Add(retryBackoff(t.Attempt)).Unix())
+		if _, err := webhook_model.CreateRetryHookTask(ctx, t, retryAfter); err != nil {
+			log.Error("CreateRetryHookTask[%d]: %v", t.ID, err)
+		} else {
+			return
+		}
+	}
+
+	t.IsDeadLetter = true
+	failures, err := webhook_model.IncreaseWebhookFailureCount(ctx, w.ID)
+	if err != nil {
+		log.Error("IncreaseWebhookFailureCount[%d]: %v", w.ID, err)
+		return
+	}
+	if setting.Webhook.DisableAfterFailures == 0 || failures < setting.Webhook.DisableAfterFailures {
+		return
+	}
+
+	disabled, err := webhook_model.DeactivateWebhook(ctx, w.ID)
+	if err != nil {
+		log.Error("DeactivateWebhook[%d]: %v", w.ID, err)
+		return
+	} else if !disabled {
+		return
+	}
+	w.IsActive = false
+	w.FailureCount = failures
+	log.Warn("Webhook[%d] %s has been disabled after %d consecutive failed deliveries", w.ID, w.URL, failures)
+	if err := notifyWebhookDisabled(ctx, w); err != nil {
+		log.Error("notifyWebhookDisabled[%d]: %v", w.ID, err)
+	}
+}
+
+// ownerAdmins returns the user or the owners of the organization
+func ownerAdmins(ctx context.Context, owner *user_model.User) ([]*user_model.User, error) {
+	if !owner.IsOrganization() {
+		return []*user_model.User{owner}, nil
+	}
+	team, err := organization.GetOwnerTeam(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+	return organization.GetTeamMembers(ctx, &organization.SearchMembersOptions{TeamID: team.ID})
+}
+
+// notifyWebhookDisabled mails the users managing a webhook that it has been disabled
+func notifyWebhookDisabled(ctx context.Context, w *webhook_model.Webhook) error {
+	var recipients []*user_model.User
+	var target, link string
+	var err error
+	switch {
+	case w.RepoID != 0:
+		repo, err := repo_model.GetRepositoryByID(ctx, w.RepoID)
+		if err != nil {
+			return err
+		}
+		if err := repo.LoadOwner(ctx); err != nil {
+			return err
+		}
+		target = repo.FullName()
+		link = fmt.Sprintf("%s/settings/hooks/%d", repo.HTMLURL(ctx), w.ID)
+		if recipients, err = ownerAdmins(ctx, repo.Owner); err != nil {
+			return err
+		}
+		collaborators, _, err := repo_model.GetCollaborators(ctx, &repo_model.FindCollaborationOptions{RepoID: repo.ID})
+		if err != nil {
+			return err
+		}
+		for _, c := range collaborators {
+			if c.Collaboration.Mode >= perm.AccessModeAdmin {
+				recipients = append(recipients, c.User)
+			}
+		}
+	case w.OwnerID != 0:
+		owner, err := user_model.GetUserByID(ctx, w.OwnerID)
+		if err != nil {
+			return err
+		}
+		target = owner.Name
+		if owner.IsOrganization() {
+			link = fmt.Sprintf("%sorg/%s/settings/hooks/%d", setting.AppURL, owner.Name, w.ID)
+		} else {
+			link = fmt.Sprintf("%suser/settings/hooks/%d", setting.AppURL, w.ID)
+		}
+		if recipients, err = ownerAdmins(ctx, owner); err != nil {
+			return err
+		}
+	default:
+		target = setting.AppName
+		link = fmt.Sprintf("%s-/admin/hooks/%d", setting.AppURL, w.ID)
+		recipients, _, err = user_model.SearchUsers(ctx, user_model.SearchUserOptions{
+			Types:       []user_model.UserType{user_model.UserTypeIndividual},
+			IsAdmin:     optional.Some(true),
+			ListOptions: db.ListOptionsAll,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	mailer.SendWebhookDisabledMail(w, target, link, recipients)
+	return nil
+}
+
+// EnqueueDueHookTaskRetries enqueues the retries of the failed deliveries which are due
+func EnqueueDueHookTaskRetries(ctx context.Context) error {
+	taskIDs, err := webhook_model.FindDueHookTaskRetryIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, taskID := range taskIDs {
+		if err := enqueueHookTask(taskID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayDeadLetters redelivers the dead letters of the webhook with the given IDs, or all of them if no ID is given.
+// It returns the tasks of the new deliveries.
+func ReplayDeadLetters(ctx context.Context, w *webhook_model.Webhook, ids []int64) ([]*webhook_model.HookTask, error) {
+	deadLetters, err := webhook_model.GetDeadLetterHookTasks(ctx, w.ID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	replayed := make([]*webhook_model.HookTask, 0, len(deadLetters))
+	for _, t := range deadLetters {
+		task, err := db.WithTx2(ctx, func(ctx context.Context) (*webhook_model.HookTask, error) {
+			if err := webhook_model.ClearDeadLetter(ctx, t); err != nil {
+				return nil, err
+			}
+			return webhook_model.ReplayHookTask(ctx, w.ID, t.UUID)
+		})
+		if err != nil {
+			return replayed, err
+		}
+		if err := enqueueHookTask(task.ID); err != nil {
+			return replayed, err
+		}
+		replayed = append(replayed, task)
+	}
+	return replayed, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"gitea.dev/models/db"
+	"gitea.dev/models/unittest"
+	webhook_model "gitea.dev/models/webhook"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+	"gitea.dev/modules/timeutil"
+	webhook_module "gitea.dev/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/builder"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	defer test.MockVariableValue(&setting.Webhook.RetryBackoff, time.Minute)()
+	defer test.MockVariableValue(&setting.Webhook.RetryMaxBackoff, 5*time.Minute)()
+
+	assert.Equal(t, time.Minute, retryBackoff(1))
+	assert.Equal(t, 2*time.Minute, retryBackoff(2))
+	assert.Equal(t, 4*time.Minute, retryBackoff(3))
+	assert.Equal(t, 5*time.Minute, retryBackoff(4))
+	assert.Equal(t, 5*time.Minute, retryBackoff(100))
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, isRetryableError(context.DeadlineExceeded))
+	assert.True(t, isRetryableError(fmt.Errorf("dial: %w", syscall.ECONNREFUSED)))
+	assert.True(t, isRetryableError(io.ErrUnexpectedEOF))
+	assert.False(t, isRetryableError(errors.New("unsupported protocol scheme")))
+
+	assert.True(t, isRetryableStatus(http.StatusBadGateway))
+	assert.True(t, isRetryableStatus(http.StatusTooManyRequests))
+	assert.False(t, isRetryableStatus(http.StatusNotFound))
+	assert.False(t, isRetryableStatus(http.StatusOK))
+}
+
+// retryTestReceiver answers the deliveries with the given status and records their delivery IDs
+type retryTestReceiver struct {
+	mu          sync.Mutex
+	status      int
+	deliveryIDs []string
+}
+
+func (r *retryTestReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveryIDs = append(r.deliveryIDs, req.Header.Get("X-Gitea-Delivery"))
+	w.WriteHeader(r.status)
+}
+
+func (r *retryTestReceiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *retryTestReceiver) popDeliveryIDs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := r.deliveryIDs
+	r.deliveryIDs = nil
+	return ids
+}
+
+func TestDeliverRetries(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Webhook.MaxRetries, 2)()
+	defer test.MockVariableValue(&setting.Webhook.RetryBackoff, time.Minute)()
+	defer test.MockVariableValue(&setting.Webhook.RetryMaxBackoff, time.Hour)()
+	defer test.MockVariableValue(&setting.Webhook.DisableAfterFailures, 2)()
+
+	receiver := &retryTestReceiver{status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	hook := &webhook_model.Webhook{
+		RepoID:      3,
+		URL:         server.URL + "/webhook",
+		ContentType: webhook_model.ContentTypeJSON,
+		IsActive:    true,
+		Type:        webhook_module.GITEA,
+	}
+	require.NoError(t, webhook_model.CreateWebhook(t.Context(), hook))
+
+	newTask := func(t *testing.T) *webhook_model.HookTask {
+		task, err := webhook_model.CreateHookTask(t.Context(), &webhook_model.HookTask{
+			HookID:         hook.ID,
+			EventType:      webhook_module.HookEventPush,
+			PayloadVersion: 2,
+		})
+		require.NoError(t, err)
+		return task
+	}
+	// deliver delivers the task and returns the retry it created, if any
+	deliver := func(t *testing.T, task *webhook_model.HookTask) *webhook_model.HookTask {
+		before := timeutil.TimeStampNow()
+		require.NoError(t, Deliver(t.Context(), task))
+		after := timeutil.TimeStampNow()
+
+		loaded := unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: task.ID})
+		assert.True(t, loaded.IsDelivered)
+		assert.False(t, loaded.IsSucceed)
+		retry, has, err := db.Get[webhook_model.HookTask](t.Context(), builder.Eq{"hook_id": hook.ID, "delivery_uuid": task.DeliveryUUID, "attempt": task.Attempt + 1})
+		require.NoError(t, err)
+		if !has {
+			return nil
+		}
+		assert.False(t, loaded.IsDeadLetter)
+		assert.Equal(t, task.Attempt+1, retry.Attempt)
+		assert.Equal(t, task.DeliveryUUID, retry.DeliveryUUID)
+		assert.NotEqual(t, task.UUID, retry.UUID)
+		backoff := int64(retryBackoff(task.Attempt).Seconds())
+		assert.GreaterOrEqual(t, retry.RetryAfter, before.Add(backoff))
+		assert.LessOrEqual(t, retry.RetryAfter, after.Add(backoff))
+		return retry
+	}
+
+	t.Run("DeadLetterAfterRetries", func(t *testing.T) {
+		task := newTask(t)
+		retry1 := deliver(t, task)
+		require.NotNil(t, retry1)
+		retry2 := deliver(t, retry1)
+		require.NotNil(t, retry2)
+		assert.Nil(t, deliver(t, retry2))
+
+		// the retries are sent with the delivery ID of the event
+		assert.Equal(t, []string{task.UUID, task.UUID, task.UUID}, receiver.popDeliveryIDs())
+		assert.True(t, unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: retry2.ID}).IsDeadLetter)
+		w := unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID})
+		assert.True(t, w.IsActive)
+		assert.Equal(t, 1, w.FailureCount)
+	})
+
+	t.Run("NotRetryable", func(t *testing.T) {
+		receiver.setStatus(http.StatusNotFound)
+		defer receiver.setStatus(http.StatusServiceUnavailable)
+
+		task := newTask(t)
+		assert.Nil(t, deliver(t, task))
+		assert.True(t, unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: task.ID}).IsDeadLetter)
+		receiver.popDeliveryIDs()
+
+		// the webhook is disabled by its second dead letter in a row
+		w := unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID})
+		assert.False(t, w.IsActive)
+		assert.Equal(t, 2, w.FailureCount)
+
+		// the events of a disabled webhook are kept as dead letters without being sent
+		task = newTask(t)
+		require.NoError(t, Deliver(t.Context(), task))
+		assert.True(t, unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: task.ID}).IsDeadLetter)
+		assert.Empty(t, receiver.popDeliveryIDs())
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		receiver.setStatus(http.StatusOK)
+		w := unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID})
+		w.IsActive = true
+		require.NoError(t, webhook_model.UpdateWebhook(t.Context(), w))
+
+		deadLetters, _, err := webhook_model.FindDeadLetterHookTasks(t.Context(), hook.ID, db.ListOptionsAll)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 3)
+
+		replayed, err := ReplayDeadLetters(t.Context(), w, []int64{deadLetters[0].ID})
+		require.NoError(t, err)
+		require.Len(t, replayed, 1)
+		assert.Equal(t, 1, replayed[0].Attempt)
+		assert.False(t, unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: deadLetters[0].ID}).IsDeadLetter)
+
+		// the replayed task is enqueued, it may be delivered by the queue before it is delivered here
+		require.NoError(t, Deliver(t.Context(), replayed[0]))
+		assert.Eventually(t, func() bool {
+			return unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: replayed[0].ID}).IsSucceed
+		}, 5*time.Second, 50*time.Millisecond)
+		assert.Equal(t, []string{replayed[0].DeliveryUUID}, receiver.popDeliveryIDs())
+
+		deadLetters, _, err = webhook_model.FindDeadLetterHookTasks(t.Context(), hook.ID, db.ListOptionsAll)
+		require.NoError(t, err)
+		assert.Len(t, deadLetters, 2)
+		assert.Zero(t, unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID}).FailureCount)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"gitea.dev/modules/queue"
 	"gitea.dev/modules/setting"
 	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/timeutil"
 	"gitea.dev/modules/util"
 	webhook_module "gitea.dev/modules/webhook"
 )
@@ -95,6 +96,12 @@ func handler(items ...int64) []int64 {
 			continue
 		}
 
+		if task.RetryAfter > timeutil.TimeStampNow() {
+			// The retry will be enqueued again once it is due
+			log.Trace("Task[%d] is a retry which is not due yet", task.ID)
+			continue
+		}
+
 		if err := Deliver(ctx, task); err != nil {
 			log.Error("Unable to deliver webhook task[%d]: %v", task.ID, err)
 		}
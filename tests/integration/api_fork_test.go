@@ -57,7 +57,7 @@ func testAPIForkListLimitedAndPrivateRepos(t *testing.T) {
 
 	ownerTeam1, err := org_model.OrgFromUser(limitedOrg).GetOwnerTeam(t.Context())
 	assert.NoError(t, err)
-	assert.NoError(t, org_service.AddTeamMember(t.Context(), ownerTeam1, user1))
+	assert.NoError(t, org_service.AddTeamMember(t.Context(), nil, ownerTeam1, user1))
 	user1Token := getTokenForLoggedInUser(t, user1Sess, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteOrganization)
 	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/forks", &api.CreateForkOption{
 		Organization: &limitedOrg.Name,
@@ -72,7 +72,7 @@ func testAPIForkListLimitedAndPrivateRepos(t *testing.T) {
 
 	ownerTeam2, err := org_model.OrgFromUser(privateOrg).GetOwnerTeam(t.Context())
 	assert.NoError(t, err)
-	assert.NoError(t, org_service.AddTeamMember(t.Context(), ownerTeam2, user4))
+	assert.NoError(t, org_service.AddTeamMember(t.Context(), nil, ownerTeam2, user4))
 	user4Token := getTokenForLoggedInUser(t, user4Sess, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteOrganization)
 	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/forks", &api.CreateForkOption{
 		Organization: &privateOrg.Name,
@@ -99,7 +99,7 @@ func testAPIForkListLimitedAndPrivateRepos(t *testing.T) {
 		assert.Len(t, forks, 2)
 		assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
 
-		assert.NoError(t, org_service.AddTeamMember(t.Context(), ownerTeam2, user1))
+		assert.NoError(t, org_service.AddTeamMember(t.Context(), nil, ownerTeam2, user1))
 
 		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/forks").AddTokenAuth(user1Token)
 		resp = MakeRequest(t, req, http.StatusOK)
@@ -69,7 +69,7 @@ func TestAPICreateIssueDependencyCrossRepoPermission(t *testing.T) {
 	})
 
 	// add user40 as a collaborator to dependency repository with read permission
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), dependencyRepo, user40, perm.AccessModeRead))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, dependencyRepo, user40, perm.AccessModeRead))
 
 	// try again after getting read permission to dependency repository
 	req = NewRequestWithJSON(t, "POST", url, dependencyMeta).
@@ -81,7 +81,7 @@ func TestAPICreateIssueDependencyCrossRepoPermission(t *testing.T) {
 	})
 
 	// add user40 as a collaborator to target repository with write permission
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), targetRepo, user40, perm.AccessModeWrite))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, targetRepo, user40, perm.AccessModeWrite))
 
 	req = NewRequestWithJSON(t, "POST", url, dependencyMeta).
 		AddTokenAuth(writerToken)
@@ -130,7 +130,7 @@ func TestAPIDeleteIssueDependencyCrossRepoPermission(t *testing.T) {
 	})
 
 	// add user40 as a collaborator to dependency repository with read permission
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), dependencyRepo, user40, perm.AccessModeRead))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, dependencyRepo, user40, perm.AccessModeRead))
 
 	// try again after getting read permission to dependency repository
 	req = NewRequestWithJSON(t, "DELETE", url, dependencyMeta).
@@ -142,7 +142,7 @@ func TestAPIDeleteIssueDependencyCrossRepoPermission(t *testing.T) {
 	})
 
 	// add user40 as a collaborator to target repository with write permission
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), targetRepo, user40, perm.AccessModeWrite))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, targetRepo, user40, perm.AccessModeWrite))
 
 	req = NewRequestWithJSON(t, "DELETE", url, dependencyMeta).
 		AddTokenAuth(writerToken)
@@ -169,7 +169,7 @@ func TestWebDeleteIssueDependencyCrossRepoPermission(t *testing.T) {
 	assert.NoError(t, issues_model.CreateIssueDependency(t.Context(), user1, targetIssue, dependencyIssue))
 
 	user40 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 40})
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), targetRepo, user40, perm.AccessModeWrite))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, targetRepo, user40, perm.AccessModeWrite))
 
 	session := loginUser(t, user40.Name)
 	req := NewRequestWithValues(t, "POST", fmt.Sprintf("/user2/repo1/issues/%d/dependency/delete", targetIssue.Index), map[string]string{
@@ -180,7 +180,7 @@ func TestWebDeleteIssueDependencyCrossRepoPermission(t *testing.T) {
 	assert.Equal(t, "Permission denied.", test.ParseJSONError(resp.Body.Bytes()).ErrorMessage)
 	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueDependency{IssueID: targetIssue.ID, DependencyID: dependencyIssue.ID})
 
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), dependencyRepo, user40, perm.AccessModeRead))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, dependencyRepo, user40, perm.AccessModeRead))
 
 	req = NewRequestWithValues(t, "POST", fmt.Sprintf("/user2/repo1/issues/%d/dependency/delete", targetIssue.Index), map[string]string{
 		"removeDependencyID": strconv.FormatInt(dependencyIssue.ID, 10),
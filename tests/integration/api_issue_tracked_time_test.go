@@ -110,10 +110,10 @@ func TestAPIUserTrackedTimesOmitsInaccessiblePrivateIssues(t *testing.T) {
 	privateRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
 	assert.True(t, privateRepo.IsPrivate)
 	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: privateRepo.ID})
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), privateRepo, user, perm.AccessModeRead))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, privateRepo, user, perm.AccessModeRead))
 	_, err := issues_model.AddTime(t.Context(), user, issue, 60, time.Time{})
 	assert.NoError(t, err)
-	assert.NoError(t, repo_service.DeleteCollaboration(t.Context(), privateRepo, user))
+	assert.NoError(t, repo_service.DeleteCollaboration(t.Context(), nil, privateRepo, user))
 
 	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadUser)
 	req := NewRequest(t, "GET", "/api/v1/user/times").AddTokenAuth(token)
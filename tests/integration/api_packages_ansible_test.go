@@ -0,0 +1,261 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/json"
+	ansible_module "gitea.dev/modules/packages/ansible"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAnsible(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	// ansible-galaxy sends the API token with the "Token" scheme
+	token := "Token " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	namespace := "gitea"
+	collectionName := "test_collection"
+	roleName := "test_role"
+	packageDescription := "Test Description"
+
+	buildCollection := func(version string) []byte {
+		return test.WriteTarCompression(gzip.NewWriter, map[string]string{
+			"MANIFEST.json": `{"collection_info":{"namespace":"` + namespace + `","name":"` + collectionName + `","version":"` + version + `","authors":["Gitea"],"description":"` + packageDescription + `","license":["MIT"],"dependencies":{"community.general":">=1.0.0"}},"format":1}`,
+			"README.md":     "# Test",
+		}).Bytes()
+	}
+	content := buildCollection("1.0.0")
+
+	root := fmt.Sprintf("/api/packages/%s/ansible", user.Name)
+
+	uploadCollection := func(t *testing.T, content []byte, checksum string, expectedStatus int) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("sha256", checksum)
+		part, _ := writer.CreateFormFile("file", "collection.tar.gz")
+		part.Write(content)
+		writer.Close()
+
+		req := NewRequestWithBody(t, "POST", root+"/v3/artifacts/collections/", body).
+			SetHeader("Content-Type", writer.FormDataContentType()).
+			SetHeader("Authorization", token)
+		return MakeRequest(t, req, expectedStatus)
+	}
+	checksum := func(content []byte) string {
+		h := sha256.Sum256(content)
+		return hex.EncodeToString(h[:])
+	}
+
+	t.Run("APIRoot", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result struct {
+			AvailableVersions map[string]string `json:"available_versions"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, map[string]string{"v1": "v1/", "v3": "v3/"}, result.AvailableVersions)
+	})
+
+	t.Run("UploadCollection", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "POST", root+"/v3/artifacts/collections/")
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		uploadCollection(t, content, "0000", http.StatusBadRequest)
+		uploadCollection(t, []byte("invalid"), checksum([]byte("invalid")), http.StatusBadRequest)
+
+		resp := uploadCollection(t, content, checksum(content), http.StatusAccepted)
+		var result struct {
+			Task string `json:"task"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeAnsible)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+		assert.Equal(t, fmt.Sprintf("%sapi/packages/%s/ansible/v3/imports/collections/%d/", setting.AppURL, user.Name, pvs[0].ID), result.Task)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &ansible_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, namespace+"."+collectionName, pd.Package.Name)
+		assert.Equal(t, "1.0.0", pd.Version.Version)
+		assert.Equal(t, ansible_module.KindCollection, pd.Metadata.(*ansible_module.Metadata).Kind)
+		assert.Equal(t, packageDescription, pd.Metadata.(*ansible_module.Metadata).Description)
+		assert.Equal(t, "# Test", pd.Metadata.(*ansible_module.Metadata).Readme)
+		require.Len(t, pd.Files, 1)
+		assert.Equal(t, fmt.Sprintf("%s-%s-1.0.0.tar.gz", namespace, collectionName), pd.Files[0].File.Name)
+		assert.True(t, pd.Files[0].File.IsLead)
+
+		req = NewRequest(t, "GET", result.Task).
+			SetHeader("Authorization", token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var task struct {
+			State      string `json:"state"`
+			FinishedAt string `json:"finished_at"`
+		}
+		DecodeJSON(t, resp, &task)
+		assert.Equal(t, "completed", task.State)
+		assert.NotEmpty(t, task.FinishedAt)
+
+		uploadCollection(t, content, checksum(content), http.StatusConflict)
+
+		content2 := buildCollection("1.1.0")
+		uploadCollection(t, content2, checksum(content2), http.StatusAccepted)
+	})
+
+	t.Run("CollectionVersions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/v3/collections/%s/%s/", root, namespace, collectionName))
+		resp := MakeRequest(t, req, http.StatusOK)
+		var info struct {
+			HighestVersion struct {
+				Version string `json:"version"`
+			} `json:"highest_version"`
+		}
+		DecodeJSON(t, resp, &info)
+		assert.Equal(t, "1.1.0", info.HighestVersion.Version)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v3/collections/%s/%s/versions/?limit=1", root, namespace, collectionName))
+		resp = MakeRequest(t, req, http.StatusOK)
+		var list struct {
+			Meta struct {
+				Count int `json:"count"`
+			} `json:"meta"`
+			Links struct {
+				Next *string `json:"next"`
+			} `json:"links"`
+			Data []struct {
+				Version string `json:"version"`
+			} `json:"data"`
+		}
+		DecodeJSON(t, resp, &list)
+		assert.Equal(t, 2, list.Meta.Count)
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, "1.1.0", list.Data[0].Version)
+		require.NotNil(t, list.Links.Next)
+
+		req = NewRequest(t, "GET", *list.Links.Next)
+		resp = MakeRequest(t, req, http.StatusOK)
+		list.Links.Next = nil
+		DecodeJSON(t, resp, &list)
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, "1.0.0", list.Data[0].Version)
+		assert.Nil(t, list.Links.Next)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v3/collections/%s/%s/versions/1.0.0/", root, namespace, collectionName))
+		resp = MakeRequest(t, req, http.StatusOK)
+		var version struct {
+			DownloadURL string `json:"download_url"`
+			Artifact    struct {
+				SHA256 string `json:"sha256"`
+			} `json:"artifact"`
+			Metadata struct {
+				Dependencies map[string]string `json:"dependencies"`
+			} `json:"metadata"`
+		}
+		DecodeJSON(t, resp, &version)
+		assert.Equal(t, checksum(content), version.Artifact.SHA256)
+		assert.Equal(t, map[string]string{"community.general": ">=1.0.0"}, version.Metadata.Dependencies)
+
+		req = NewRequest(t, "GET", version.DownloadURL)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v3/collections/%s/%s/versions/0.0.1/", root, namespace, collectionName))
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v3/collections/%s/unknown/", root, namespace))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Role", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		roleContent := test.WriteTarCompression(gzip.NewWriter, map[string]string{
+			"test_role/meta/main.yml": "galaxy_info:\n  author: Gitea\n  description: " + packageDescription + "\n  license: MIT\ndependencies:\n  - gitea.common\n",
+			"test_role/README.md":     "# Role",
+		}).Bytes()
+
+		uploadURL := fmt.Sprintf("%s/roles/%s/%s/1.0.0", root, namespace, roleName)
+
+		req := NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(roleContent))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(roleContent)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// a role can't be published with the name of a collection
+		req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/roles/%s/%s/2.0.0", root, namespace, collectionName), bytes.NewReader(roleContent)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		p, err := packages.GetPackageByName(t.Context(), user.ID, packages.TypeAnsible, namespace+"."+roleName)
+		require.NoError(t, err)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v1/roles/?owner__username=%s&name=%s", root, namespace, roleName))
+		resp := MakeRequest(t, req, http.StatusOK)
+		var roles struct {
+			Count   int `json:"count"`
+			Results []struct {
+				ID          int64  `json:"id"`
+				Description string `json:"description"`
+			} `json:"results"`
+		}
+		DecodeJSON(t, resp, &roles)
+		assert.Equal(t, 1, roles.Count)
+		assert.Equal(t, p.ID, roles.Results[0].ID)
+		assert.Equal(t, packageDescription, roles.Results[0].Description)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v1/roles/?owner__username=%s&name=%s", root, namespace, collectionName))
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &roles)
+		assert.Equal(t, 0, roles.Count)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/v1/roles/%d/versions/?page_size=50", root, p.ID))
+		resp = MakeRequest(t, req, http.StatusOK)
+		var versions struct {
+			Results []struct {
+				Name   string `json:"name"`
+				Source string `json:"source"`
+			} `json:"results"`
+		}
+		DecodeJSON(t, resp, &versions)
+		require.Len(t, versions.Results, 1)
+		assert.Equal(t, "1.0.0", versions.Results[0].Name)
+
+		req = NewRequest(t, "GET", versions.Results[0].Source)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, roleContent, resp.Body.Bytes())
+	})
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/json"
+	hex_module "gitea.dev/modules/packages/hex"
+	"gitea.dev/modules/test"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestPackageHex(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	// the Hex client sends the API key as the whole authorization header
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "test_package"
+	packageVersion := "1.0.1"
+	packageDescription := "Test Description"
+
+	buildPackage := func(version string) []byte {
+		metadata := `{<<"name">>,<<"` + packageName + `">>}.
+{<<"version">>,<<"` + version + `">>}.
+{<<"description">>,<<"` + packageDescription + `">>}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"requirements">>,[[{<<"name">>,<<"plug">>},{<<"app">>,<<"plug">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.14">>},{<<"repository">>,<<"hexpm">>}]]}.
+`
+		contents := test.WriteTarCompression(gzip.NewWriter, map[string]string{"README.md": "# Test"}).Bytes()
+
+		h := sha256.New()
+		h.Write([]byte("3"))
+		h.Write([]byte(metadata))
+		h.Write(contents)
+
+		return test.WriteTarArchive(map[string]string{
+			"VERSION":         "3",
+			"CHECKSUM":        strings.ToUpper(hex.EncodeToString(h.Sum(nil))),
+			"metadata.config": metadata,
+			"contents.tar.gz": string(contents),
+		}).Bytes()
+	}
+	content := buildPackage(packageVersion)
+
+	root := fmt.Sprintf("/api/packages/%s/hex", user.Name)
+
+	// readResource reads a signed registry resource and verifies its signature
+	readResource := func(t *testing.T, url string) []byte {
+		req := NewRequest(t, "GET", root+"/public_key")
+		resp := MakeRequest(t, req, http.StatusOK)
+		block, _ := pem.Decode(resp.Body.Bytes())
+		require.NotNil(t, block)
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		require.NoError(t, err)
+
+		req = NewRequest(t, "GET", url)
+		resp = MakeRequest(t, req, http.StatusOK)
+		zr, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		signed, err := io.ReadAll(zr)
+		require.NoError(t, err)
+
+		var payload, signature []byte
+		for len(signed) > 0 {
+			num, _, n := protowire.ConsumeTag(signed)
+			require.Positive(t, n)
+			v, m := protowire.ConsumeBytes(signed[n:])
+			require.Positive(t, m)
+			if num == 1 {
+				payload = v
+			} else {
+				signature = v
+			}
+			signed = signed[n+m:]
+		}
+		hash := sha512.Sum512(payload)
+		assert.NoError(t, rsa.VerifyPKCS1v15(publicKey.(*rsa.PublicKey), crypto.SHA512, hash[:], signature))
+		return payload
+	}
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadURL := root + "/api/publish"
+
+		req := NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader([]byte("invalid"))).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content)).
+			SetHeader("Authorization", token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var release struct {
+			Version  string `json:"version"`
+			Checksum string `json:"checksum"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&release))
+		assert.Equal(t, packageVersion, release.Version)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHex)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		assert.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &hex_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+		assert.Equal(t, packageDescription, pd.Metadata.(*hex_module.Metadata).Description)
+		assert.Equal(t, "# Test", pd.Metadata.(*hex_module.Metadata).Readme)
+
+		require.Len(t, pd.Files, 1)
+		assert.Equal(t, fmt.Sprintf("%s-%s.tar", packageName, packageVersion), pd.Files[0].File.Name)
+		assert.True(t, pd.Files[0].File.IsLead)
+		assert.Equal(t, release.Checksum, pd.Files[0].Blob.HashSHA256)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(buildPackage("1.1.0"))).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	t.Run("AuthOnlyForHex", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// the API key without a scheme is not accepted by the other package types
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", user.Name, packageName, packageVersion), strings.NewReader("content")).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusUnauthorized)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-%s.tar", root, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-0.0.1.tar", root, packageName))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Registry", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		names := readResource(t, root+"/names")
+		assert.Contains(t, string(names), packageName)
+		assert.Contains(t, string(names), user.Name)
+
+		versions := readResource(t, root+"/versions")
+		assert.Contains(t, string(versions), packageVersion)
+		assert.Contains(t, string(versions), "1.1.0")
+
+		pkg := readResource(t, root+"/packages/"+packageName)
+		assert.Contains(t, string(pkg), packageVersion)
+		assert.Contains(t, string(pkg), "~> 1.14")
+
+		req := NewRequest(t, "GET", root+"/packages/unknown")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Retire", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		retireURL := fmt.Sprintf("%s/api/packages/%s/releases/%s/retire", root, packageName, packageVersion)
+
+		req := NewRequestWithBody(t, "POST", retireURL, strings.NewReader(`{"reason":"security","message":"CVE"}`))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", retireURL, strings.NewReader(`{"reason":"unknown"}`)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithBody(t, "POST", retireURL, strings.NewReader(`{"reason":"security","message":"CVE"}`)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/api/packages/%s", root, packageName))
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var info struct {
+			Releases []struct {
+				Version string `json:"version"`
+			} `json:"releases"`
+			Retirements map[string]*hex_module.Retirement `json:"retirements"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+		require.Len(t, info.Releases, 2)
+		assert.Equal(t, "1.1.0", info.Releases[0].Version)
+		assert.Equal(t, map[string]*hex_module.Retirement{packageVersion: {Reason: "security", Message: "CVE"}}, info.Retirements)
+		assert.Contains(t, string(readResource(t, root+"/packages/"+packageName)), "CVE")
+
+		req = NewRequest(t, "DELETE", retireURL).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		assert.NotContains(t, string(readResource(t, root+"/packages/"+packageName)), "CVE")
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	nix_module "gitea.dev/modules/packages/nix"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageNix(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	root := fmt.Sprintf("/api/packages/%s/nix", user.Name)
+
+	storeName := "hello-2.12.1"
+	storeHash := "7rjj86a15146cq1d2qy068lq1bjd2nad"
+	glibc := "8vsmbvj6rf3xvj0b2ahx8cpsfq8dbrsd-glibc-2.40-66"
+
+	narFilename := func(content []byte) string {
+		h := sha256.Sum256(content)
+		return nix_module.EncodeBase32(h[:]) + ".nar"
+	}
+	narInfo := func(hash string, content []byte, references ...string) *nix_module.Metadata {
+		h := sha256.Sum256(content)
+		return &nix_module.Metadata{
+			StorePath:   nix_module.StoreDir + "/" + hash + "-" + storeName,
+			URL:         "nar/" + narFilename(content),
+			Compression: "none",
+			FileHash:    nix_module.FormatSHA256(h[:]),
+			FileSize:    int64(len(content)),
+			NarHash:     nix_module.FormatSHA256(h[:]),
+			NarSize:     int64(len(content)),
+			References:  references,
+		}
+	}
+	upload := func(t *testing.T, url string, content []byte, expectedStatus int) {
+		req := NewRequestWithBody(t, "PUT", url, bytes.NewReader(content)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, expectedStatus)
+	}
+
+	content := []byte("nix-archive-1 hello")
+
+	t.Run("CacheInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/nix-cache-info")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "StoreDir: /nix/store\n")
+	})
+
+	t.Run("UploadNar", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", root+"/nar/"+narFilename(content), bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		upload(t, root+"/nar/invalid.nar", content, http.StatusBadRequest)
+		upload(t, root+"/nar/"+narFilename([]byte("other")), content, http.StatusBadRequest)
+		upload(t, root+"/nar/"+narFilename(content), content, http.StatusCreated)
+
+		// the NAR is not visible until a narinfo references it
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeNix)
+		require.NoError(t, err)
+		assert.Empty(t, pvs)
+
+		req = NewRequest(t, "GET", root+"/nar/"+narFilename(content))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+	})
+
+	t.Run("UploadNarInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		m := narInfo(storeHash, content, storeHash+"-"+storeName, glibc)
+
+		upload(t, root+"/"+storeHash+".narinfo", []byte("invalid"), http.StatusBadRequest)
+		upload(t, root+"/"+strings.Repeat("0", 32)+".narinfo", []byte(m.String()), http.StatusBadRequest)
+
+		missing := narInfo(strings.Repeat("1", 32), []byte("missing"))
+		upload(t, root+"/"+missing.StoreHash()+".narinfo", []byte(missing.String()), http.StatusBadRequest)
+
+		upload(t, root+"/"+storeHash+".narinfo", []byte(m.String()), http.StatusCreated)
+		upload(t, root+"/"+storeHash+".narinfo", []byte(m.String()), http.StatusConflict)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeNix)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.Equal(t, storeName, pd.Package.Name)
+		assert.Equal(t, storeHash, pd.Version.Version)
+		assert.IsType(t, &nix_module.Metadata{}, pd.Metadata)
+		require.Len(t, pd.Files, 1)
+		assert.Equal(t, narFilename(content), pd.Files[0].File.Name)
+		assert.True(t, pd.Files[0].File.IsLead)
+		assert.Equal(t, glibc, pd.VersionProperties.GetByName(nix_module.PropertyReference))
+
+		req := NewRequest(t, "GET", root+"/"+storeHash+".narinfo")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, nix_module.NarInfoContentType, resp.Header().Get("Content-Type"))
+		assert.Equal(t, m.String(), resp.Body.String())
+
+		req = NewRequest(t, "HEAD", root+"/"+storeHash+".narinfo")
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", root+"/nar/"+narFilename(content))
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/"+strings.Repeat("0", 32)+".narinfo")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Signatures", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		defer test.MockVariableValue(&setting.Packages.NixTrustedPublicKeys, []string{"gitea-1:" + base64.StdEncoding.EncodeToString(pub)})()
+
+		signedContent := []byte("nix-archive-1 signed")
+		upload(t, root+"/nar/"+narFilename(signedContent), signedContent, http.StatusCreated)
+
+		hash := strings.Repeat("2", 32)
+		m := narInfo(hash, signedContent)
+		upload(t, root+"/"+hash+".narinfo", []byte(m.String()), http.StatusBadRequest)
+
+		m.Signatures = []string{"gitea-1:" + base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(m.Fingerprint())))}
+		upload(t, root+"/"+hash+".narinfo", []byte(m.String()), http.StatusCreated)
+	})
+}
@@ -0,0 +1,329 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitea.dev/models/packages"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/json"
+	container_module "gitea.dev/modules/packages/container"
+	npm_module "gitea.dev/modules/packages/npm"
+	"gitea.dev/modules/setting"
+	"gitea.dev/modules/test"
+	"gitea.dev/services/packages/upstream"
+	"gitea.dev/tests"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageUpstream(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Packages.UpstreamAllowedHostList, "loopback")()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	addUpstream := func(t *testing.T, packageType packages.Type, path string) string {
+		upstreamURL := server.URL + path
+		_, err := packages.InsertUpstream(t.Context(), &packages.PackageUpstream{
+			Enabled: true,
+			OwnerID: user.ID,
+			Type:    packageType,
+			URL:     upstreamURL,
+		})
+		assert.NoError(t, err)
+		return upstreamURL
+	}
+
+	assertCachedVersion := func(t *testing.T, packageType packages.Type, name, version, upstreamURL string) {
+		pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packageType, name, version)
+		assert.NoError(t, err)
+
+		pps, err := packages.GetPropertiesByName(t.Context(), packages.PropertyTypeVersion, pv.ID, upstream.PropertyUpstream)
+		assert.NoError(t, err)
+		assert.Len(t, pps, 1)
+		assert.Equal(t, upstreamURL, pps[0].Value)
+	}
+
+	t.Run("Go", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		packageName := "example.com/upstream/mod"
+		content := test.WriteZipArchive(map[string]string{
+			packageName + "@v1.0.0/go.mod": "module " + packageName,
+		}).Bytes()
+
+		mux.HandleFunc("/go/"+packageName+"/@v/list", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("v1.0.0\nv1.1.0\n"))
+		})
+		mux.HandleFunc("/go/"+packageName+"/@v/v1.0.0.zip", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(content)
+		})
+
+		upstreamURL := addUpstream(t, packages.TypeGo, "/go")
+		root := fmt.Sprintf("/api/packages/%s/go/%s", user.Name, packageName)
+
+		req := NewRequest(t, "GET", root+"/@v/list").
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "v1.0.0\nv1.1.0", strings.TrimSpace(resp.Body.String()))
+
+		req = NewRequest(t, "GET", root+"/@v/v1.0.0.zip").
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		assertCachedVersion(t, packages.TypeGo, packageName, "v1.0.0", upstreamURL)
+
+		req = NewRequest(t, "GET", root+"/@v/v9.9.9.zip").
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Npm", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		packageName := "upstream-package"
+		packageVersion := "1.0.0"
+		tarball := []byte("upstream tarball content")
+
+		hashSHA1 := sha1.Sum(tarball)
+		hashSHA512 := sha512.Sum512(tarball)
+
+		mux.HandleFunc("/npm/"+packageName, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name":      packageName,
+				"dist-tags": map[string]string{"latest": packageVersion},
+				"versions": map[string]any{
+					packageVersion: map[string]any{
+						"name":    packageName,
+						"version": packageVersion,
+						"dist": map[string]string{
+							"integrity": "sha512-" + base64.StdEncoding.EncodeToString(hashSHA512[:]),
+							"shasum":    hex.EncodeToString(hashSHA1[:]),
+							"tarball":   server.URL + "/npm/" + packageName + "/-/" + packageName + "-" + packageVersion + ".tgz",
+						},
+					},
+				},
+			})
+		})
+		mux.HandleFunc("/npm/"+packageName+"/-/"+packageName+"-"+packageVersion+".tgz", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(tarball)
+		})
+
+		upstreamURL := addUpstream(t, packages.TypeNpm, "/npm")
+		root := fmt.Sprintf("/api/packages/%s/npm/%s", user.Name, packageName)
+		tarballURL := fmt.Sprintf("%s/-/%s/%s-%s.tgz", root, packageVersion, packageName, packageVersion)
+
+		req := NewRequest(t, "GET", root).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result npm_module.PackageMetadata
+		DecodeJSON(t, resp, &result)
+
+		assert.Equal(t, packageName, result.Name)
+		assert.Equal(t, packageVersion, result.DistTags["latest"])
+		assert.Contains(t, result.Versions, packageVersion)
+		assert.Equal(t, setting.AppURL+tarballURL[1:], result.Versions[packageVersion].Dist.Tarball)
+
+		req = NewRequest(t, "GET", tarballURL).
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, tarball, resp.Body.Bytes())
+
+		assertCachedVersion(t, packages.TypeNpm, packageName, packageVersion, upstreamURL)
+	})
+
+	t.Run("Maven", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		groupID := "com.example"
+		artifactID := "upstream"
+		packageName := groupID + ":" + artifactID
+		packageVersion := "1.0"
+		jar := []byte("upstream jar content")
+		pom := `<?xml version="1.0"?>
+<project>
+  <groupId>com.example</groupId>
+  <artifactId>upstream</artifactId>
+  <version>1.0</version>
+  <description>Upstream Package</description>
+</project>`
+
+		base := "/maven/com/example/upstream"
+		mux.HandleFunc(base+"/maven-metadata.xml", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`<?xml version="1.0"?>
+<metadata>
+  <groupId>com.example</groupId>
+  <artifactId>upstream</artifactId>
+  <versioning>
+    <versions>
+      <version>1.0</version>
+      <version>1.1</version>
+    </versions>
+  </versioning>
+</metadata>`))
+		})
+		mux.HandleFunc(base+"/1.0/upstream-1.0.jar", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(jar)
+		})
+		mux.HandleFunc(base+"/1.0/upstream-1.0.pom", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(pom))
+		})
+
+		upstreamURL := addUpstream(t, packages.TypeMaven, "/maven")
+		root := fmt.Sprintf("/api/packages/%s/maven/com/example/upstream", user.Name)
+
+		req := NewRequest(t, "GET", root+"/maven-metadata.xml").
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "<version>1.0</version>")
+		assert.Contains(t, resp.Body.String(), "<version>1.1</version>")
+		assert.Contains(t, resp.Body.String(), "<latest>1.1</latest>")
+
+		req = NewRequest(t, "GET", root+"/1.0/upstream-1.0.jar").
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, jar, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/1.0/upstream-1.0.pom").
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, pom, resp.Body.String())
+
+		assertCachedVersion(t, packages.TypeMaven, packageName, packageVersion, upstreamURL)
+
+		pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeMaven, packageName, packageVersion)
+		assert.NoError(t, err)
+		pd, err := packages.GetPackageDescriptor(t.Context(), pv)
+		assert.NoError(t, err)
+		assert.NotNil(t, pd.Metadata)
+
+		hashSHA1 := sha1.Sum(jar)
+
+		req = NewRequest(t, "GET", root+"/1.0/upstream-1.0.jar.sha1").
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, hex.EncodeToString(hashSHA1[:]), resp.Body.String())
+	})
+
+	t.Run("PyPI", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		packageName := "upstream-package"
+		packageVersion := "1.0"
+		filename := "upstream_package-1.0.tar.gz"
+		content := []byte("upstream sdist content")
+
+		hashSHA256 := sha256.Sum256(content)
+
+		mux.HandleFunc("/pypi/simple/"+packageName+"/", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = fmt.Fprintf(w, `<html><body><a href="../../files/%s#sha256=%s" data-requires-python="&gt;=3.8">%s</a></body></html>`, filename, hex.EncodeToString(hashSHA256[:]), filename)
+		})
+		mux.HandleFunc("/pypi/files/"+filename, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(content)
+		})
+
+		upstreamURL := addUpstream(t, packages.TypePyPI, "/pypi")
+		root := fmt.Sprintf("/api/packages/%s/pypi", user.Name)
+		fileURL := fmt.Sprintf("%s/files/%s/%s/%s", root, packageName, packageVersion, filename)
+
+		req := NewRequest(t, "GET", root+"/simple/"+packageName).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), fileURL+"#sha256="+hex.EncodeToString(hashSHA256[:]))
+
+		req = NewRequest(t, "GET", fileURL).
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		assertCachedVersion(t, packages.TypePyPI, packageName, packageVersion, upstreamURL)
+	})
+
+	t.Run("Container", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		image := "upstream-image"
+		config := []byte(`{"architecture":"amd64","os":"linux","config":{}}`)
+		layer := []byte("upstream layer content")
+		configDigest := digest.FromBytes(config)
+		layerDigest := digest.FromBytes(layer)
+
+		manifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","config":{"mediaType":"application/vnd.docker.container.image.v1+json","digest":"%s","size":%d},"layers":[{"mediaType":"application/vnd.docker.image.rootfs.diff.tar.gzip","digest":"%s","size":%d}]}`,
+			container_module.ContentTypeDockerDistributionManifestV2, configDigest, len(config), layerDigest, len(layer))
+		manifestDigest := digest.FromString(manifest)
+
+		upstreamToken := "upstream-token"
+		authorized := func(w http.ResponseWriter, r *http.Request) bool {
+			if r.Header.Get("Authorization") != "Bearer "+upstreamToken {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="upstream"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return false
+			}
+			return true
+		}
+
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": upstreamToken})
+		})
+		mux.HandleFunc("/v2/library/"+image+"/manifests/latest", func(w http.ResponseWriter, r *http.Request) {
+			if authorized(w, r) {
+				w.Header().Set("Content-Type", container_module.ContentTypeDockerDistributionManifestV2)
+				_, _ = w.Write([]byte(manifest))
+			}
+		})
+		for d, blob := range map[digest.Digest][]byte{configDigest: config, layerDigest: layer} {
+			mux.HandleFunc("/v2/library/"+image+"/blobs/"+string(d), func(w http.ResponseWriter, r *http.Request) {
+				if authorized(w, r) {
+					_, _ = w.Write(blob)
+				}
+			})
+		}
+
+		upstreamURL := addUpstream(t, packages.TypeContainer, "/v2/library")
+		root := fmt.Sprintf("/v2/%s/%s", user.Name, image)
+
+		req := NewRequest(t, "GET", setting.AppURL+"v2/token").
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		tokenResponse := DecodeJSON(t, resp, &struct {
+			Token string `json:"token"`
+		}{})
+		token := "Bearer " + tokenResponse.Token
+
+		req = NewRequest(t, "GET", root+"/manifests/latest").
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, string(manifestDigest), resp.Header().Get("Docker-Content-Digest"))
+		assert.Equal(t, manifest, resp.Body.String())
+
+		req = NewRequest(t, "GET", root+"/blobs/"+string(layerDigest)).
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, layer, resp.Body.Bytes())
+
+		assertCachedVersion(t, packages.TypeContainer, image, "latest", upstreamURL)
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	quota_model "gitea.dev/models/quota"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/setting"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/test"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIQuota(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Quota.Enabled, true)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+	userToken := getUserToken(t, user.Name, auth_model.AccessTokenScopeAll)
+
+	getQuota := func(t *testing.T) *api.QuotaInfo {
+		req := NewRequest(t, "GET", "/api/v1/user/quota").AddTokenAuth(userToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		return DecodeJSON(t, resp, &api.QuotaInfo{})
+	}
+
+	var group *api.QuotaGroup
+
+	t.Run("CreateGroup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/admin/quota/groups", &api.CreateQuotaGroupOption{
+			Name: "small",
+			Limits: &api.QuotaLimitsOption{
+				Attachments: new(int64(10)),
+				Packages:    new(int64(10)),
+			},
+		}).AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		group = DecodeJSON(t, resp, &api.QuotaGroup{})
+		assert.Equal(t, "small", group.Name)
+		assert.EqualValues(t, 10, group.Limits.Attachments)
+		assert.EqualValues(t, 10, group.Limits.Packages)
+		assert.EqualValues(t, -1, group.Limits.Total)
+		assert.EqualValues(t, -1, group.Limits.Git)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/admin/quota/groups", &api.CreateQuotaGroupOption{
+			Name: "small",
+		}).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/admin/quota/groups", &api.CreateQuotaGroupOption{
+			Name:   "invalid",
+			Limits: &api.QuotaLimitsOption{Total: new(int64(-5))},
+		}).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/admin/quota/groups", &api.CreateQuotaGroupOption{
+			Name: "small",
+		}).AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("EditGroup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/admin/quota/groups/%d", group.ID), &api.EditQuotaGroupOption{
+			Limits: &api.QuotaLimitsOption{Packages: new(int64(5))},
+		}).AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		group = DecodeJSON(t, resp, &api.QuotaGroup{})
+		assert.Equal(t, "small", group.Name)
+		assert.EqualValues(t, 5, group.Limits.Packages)
+		assert.EqualValues(t, 10, group.Limits.Attachments)
+
+		req = NewRequest(t, "GET", "/api/v1/admin/quota/groups").AddTokenAuth(adminToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		groups := DecodeJSON(t, resp, []*api.QuotaGroup{})
+		require.Len(t, groups, 1)
+		assert.Equal(t, group.ID, groups[0].ID)
+	})
+
+	t.Run("AssignGroup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		info := getQuota(t)
+		assert.True(t, info.Enabled)
+		assert.Empty(t, info.Groups)
+		assert.EqualValues(t, -1, info.Limits.Packages)
+
+		req := NewRequest(t, "PUT", fmt.Sprintf("/api/v1/admin/quota/groups/%d/owners/%s", group.ID, user.Name)).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "PUT", fmt.Sprintf("/api/v1/admin/quota/groups/%d/owners/%s", group.ID, user.Name)).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "PUT", fmt.Sprintf("/api/v1/admin/quota/groups/%d/owners/does-not-exist", group.ID)).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/admin/quota/groups/%d/owners", group.ID)).AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		owners := DecodeJSON(t, resp, []*api.User{})
+		require.Len(t, owners, 1)
+		assert.Equal(t, user.ID, owners[0].ID)
+
+		usage, err := quota_model.GetUsage(t.Context(), user.ID)
+		require.NoError(t, err)
+
+		info = getQuota(t)
+		assert.Equal(t, []string{"small"}, info.Groups)
+		assert.EqualValues(t, 5, info.Limits.Packages)
+		assert.EqualValues(t, 10, info.Limits.Attachments)
+		assert.Equal(t, usage.Total(), info.Used.Total)
+		assert.Equal(t, usage.Git, info.Used.Git)
+		assert.Equal(t, usage.Attachments, info.Used.Attachments)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/admin/users/%s/quota", user.Name)).AddTokenAuth(adminToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, info, DecodeJSON(t, resp, &api.QuotaInfo{}))
+	})
+
+	t.Run("Enforce", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/quota-test/1.0/file.bin", user.Name), bytes.NewReader(make([]byte, 10))).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("attachment", "image.png")
+		require.NoError(t, err)
+		_, err = part.Write(testGeneratePngBytes())
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		req = NewRequestWithBody(t, "POST", fmt.Sprintf("/api/v1/repos/%s/repo1/issues/1/assets", user.Name), body).
+			AddTokenAuth(userToken)
+		req.Header.Add("Content-Type", writer.FormDataContentType())
+		MakeRequest(t, req, http.StatusRequestEntityTooLarge)
+
+		defer test.MockVariableValue(&setting.Quota.Enabled, false)()
+
+		req = NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/quota-test/1.0/file.bin", user.Name), bytes.NewReader(make([]byte, 10))).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	t.Run("DefaultGroups", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/admin/quota/groups/%d/owners/%s", group.ID, user.Name)).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		info := getQuota(t)
+		assert.Empty(t, info.Groups)
+		assert.EqualValues(t, -1, info.Limits.Packages)
+
+		defer test.MockVariableValue(&setting.Quota.DefaultGroups, []string{"small", "unknown"})()
+
+		info = getQuota(t)
+		assert.Equal(t, []string{"small"}, info.Groups)
+		assert.EqualValues(t, 5, info.Limits.Packages)
+	})
+
+	t.Run("DeleteGroup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/admin/quota/groups/%d", group.ID)).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/admin/quota/groups/%d", group.ID)).AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		unittest.AssertNotExistsBean(t, &quota_model.GroupOwner{GroupID: group.ID})
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/modules/setting"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/test"
+	"gitea.dev/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendSCIPField(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+// scipREADMEIndex returns a SCIP index of the README.md of user2/repo1, which is:
+//
+//	# repo1
+//
+//	Description for repo1
+func scipREADMEIndex() []byte {
+	const symbol = "scip-markdown . . . `README.md`/repo1."
+	occurrence := func(isDefinition bool, rng ...int32) []byte {
+		var packed []byte
+		for _, v := range rng {
+			packed = protowire.AppendVarint(packed, uint64(v))
+		}
+		b := appendSCIPField(appendSCIPField(nil, 1, packed), 2, []byte(symbol))
+		if isDefinition {
+			b = protowire.AppendTag(b, 3, protowire.VarintType)
+			b = protowire.AppendVarint(b, 1)
+		}
+		return b
+	}
+
+	doc := appendSCIPField(nil, 1, []byte("README.md"))
+	doc = appendSCIPField(doc, 2, occurrence(true, 0, 2, 7))
+	doc = appendSCIPField(doc, 2, occurrence(false, 2, 16, 21))
+	doc = appendSCIPField(doc, 3, appendSCIPField(appendSCIPField(nil, 1, []byte(symbol)), 3, []byte("The **first** repository")))
+	return appendSCIPField(nil, 2, doc)
+}
+
+func TestAPIRepoCodeNavigation(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.CodeNavigation.Enabled, true)()
+
+	const commitID = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+
+	t.Run("Disabled", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.CodeNavigation.Enabled, false)()
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/code_navigation/indexes").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("UploadRequiresWriteAccess", func(t *testing.T) {
+		req := NewRequestWithBody(t, "PUT", "/api/v1/repos/user2/repo1/code_navigation/indexes/"+commitID, bytes.NewReader(scipREADMEIndex())).
+			AddTokenAuth(getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository))
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("UploadInvalid", func(t *testing.T) {
+		req := NewRequestWithBody(t, "PUT", "/api/v1/repos/user2/repo1/code_navigation/indexes/"+commitID, bytes.NewReader([]byte{0x12, 0x10})).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		req := NewRequestWithBody(t, "PUT", "/api/v1/repos/user2/repo1/code_navigation/indexes/65f1bf27bc", bytes.NewReader(scipREADMEIndex())).
+			AddTokenAuth(token)
+		idx := DecodeJSON(t, MakeRequest(t, req, http.StatusCreated), &api.CodeNavigationIndex{})
+		assert.Equal(t, commitID, idx.CommitSHA)
+		assert.Equal(t, 1, idx.Documents)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/code_navigation/indexes").AddTokenAuth(token)
+		indexes := DecodeJSON(t, MakeRequest(t, req, http.StatusOK), &[]*api.CodeNavigationIndex{})
+		require.Len(t, *indexes, 1)
+		assert.Equal(t, idx.ID, (*indexes)[0].ID)
+	})
+
+	t.Run("LookupPrecise", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/code_navigation/lookup?ref=master&path=README.md&line=3&character=18&word=repo1")
+		nav := DecodeJSON(t, MakeRequest(t, req, http.StatusOK), &api.CodeNavigation{})
+		assert.True(t, nav.Precise)
+		assert.Equal(t, []string{"The **first** repository"}, nav.Documentation)
+		require.Len(t, nav.Definitions, 1)
+		assert.Equal(t, "README.md", nav.Definitions[0].Path)
+		assert.Equal(t, 1, nav.Definitions[0].Line)
+		assert.Equal(t, setting.AppURL+"user2/repo1/src/commit/"+commitID+"/README.md#L1", nav.Definitions[0].HTMLURL)
+		require.Len(t, nav.References, 1)
+		assert.Equal(t, 3, nav.References[0].Line)
+	})
+
+	t.Run("LookupSearch", func(t *testing.T) {
+		// the position is not inside an occurrence of the index
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/code_navigation/lookup?ref=master&path=README.md&line=3&character=2&word=Description")
+		nav := DecodeJSON(t, MakeRequest(t, req, http.StatusOK), &api.CodeNavigation{})
+		assert.False(t, nav.Precise)
+		assert.Empty(t, nav.Definitions)
+		require.Len(t, nav.References, 1)
+		assert.Equal(t, "README.md", nav.References[0].Path)
+		assert.Equal(t, 3, nav.References[0].Line)
+	})
+
+	t.Run("Popup", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/user2/repo1/code-nav?ref="+commitID+"&path=README.md&line=1&character=3&word=repo1")
+		htmlDoc := NewHTMLParser(t, MakeRequest(t, req, http.StatusOK).Body)
+		assert.Contains(t, htmlDoc.Find(".code-navigation-doc").Text(), "first")
+		links := htmlDoc.Find("a.code-navigation-location")
+		require.Equal(t, 2, links.Length())
+		assert.Equal(t, "/user2/repo1/src/commit/"+commitID+"/README.md#L1", links.Eq(0).AttrOr("href", ""))
+		assert.Equal(t, "/user2/repo1/src/commit/"+commitID+"/README.md#L3", links.Eq(1).AttrOr("href", ""))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		req := NewRequest(t, "DELETE", "/api/v1/repos/user2/repo1/code_navigation/indexes/"+commitID).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
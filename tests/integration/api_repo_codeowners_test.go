@@ -0,0 +1,130 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	issues_model "gitea.dev/models/issues"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/git"
+	"gitea.dev/modules/setting"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/modules/test"
+	repo_service "gitea.dev/services/repository"
+	files_service "gitea.dev/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRepoCodeOwners(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+		repo, err := repo_service.CreateRepositoryDirectly(t.Context(), user2, user2, repo_service.CreateRepoOptions{
+			Name:             "test_api_codeowners",
+			Readme:           "Default",
+			AutoInit:         true,
+			ObjectFormatName: git.Sha1ObjectFormat.Name(),
+			DefaultBranch:    "master",
+		}, true)
+		require.NoError(t, err)
+
+		_, err = files_service.ChangeRepoFiles(t.Context(), repo, user2, &files_service.ChangeRepoFilesOptions{
+			OldBranch: repo.DefaultBranch,
+			Files: []*files_service.ChangeRepoFile{
+				{
+					Operation:     "create",
+					TreePath:      ".gitea/CODEOWNERS",
+					ContentReader: strings.NewReader("README.md @user5\ndocs/.* @user8 @user4\nsrc/.* @nobody\n"),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		session := loginUser(t, "user2")
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeReadRepository)
+		link := fmt.Sprintf("/api/v1/repos/%s/%s", user2.Name, repo.Name)
+
+		t.Run("Files", func(t *testing.T) {
+			req := NewRequest(t, "GET", link+"/codeowners?path=README.md&path=docs/index.md&path=main.go").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			result := DecodeJSON(t, resp, &api.CodeOwnersFileOwners{})
+			assert.Equal(t, ".gitea/CODEOWNERS", result.CodeOwnersPath)
+			assert.True(t, result.Complete)
+			require.Len(t, result.Files, 3)
+			assert.Equal(t, []string{"@user5"}, result.Files[0].Owners)
+			require.Len(t, result.Files[1].Rules, 1)
+			assert.Equal(t, 2, result.Files[1].Rules[0].Line)
+			assert.Equal(t, "docs/.*", result.Files[1].Rules[0].Pattern)
+			assert.Equal(t, []string{"@user8", "@user4"}, result.Files[1].Owners)
+			assert.Empty(t, result.Files[2].Rules)
+
+			req = NewRequest(t, "GET", link+"/codeowners").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+		})
+
+		t.Run("Validate", func(t *testing.T) {
+			req := NewRequest(t, "GET", link+"/codeowners/validate").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			result := DecodeJSON(t, resp, &api.CodeOwnersValidation{})
+			assert.Equal(t, ".gitea/CODEOWNERS", result.Path)
+			assert.False(t, result.Valid)
+			assert.Equal(t, 2, result.RulesCount)
+			require.Len(t, result.Errors, 2)
+			assert.Equal(t, 3, result.Errors[0].Line)
+			assert.Equal(t, "incorrect codeowner user: nobody", result.Errors[0].Message)
+		})
+
+		t.Run("TooLarge", func(t *testing.T) {
+			defer test.MockVariableValue(&setting.UI.MaxDisplayFileSize, 10)()
+
+			req := NewRequest(t, "GET", link+"/codeowners/validate").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			result := DecodeJSON(t, resp, &api.CodeOwnersValidation{})
+			assert.Equal(t, ".gitea/CODEOWNERS", result.Path)
+			assert.False(t, result.Valid)
+			require.Len(t, result.Errors, 1)
+			assert.Contains(t, result.Errors[0].Message, "exceeds the maximum readable size")
+
+			req = NewRequest(t, "GET", link+"/codeowners?path=README.md").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+		})
+
+		t.Run("PullRequest", func(t *testing.T) {
+			_, err := files_service.ChangeRepoFiles(t.Context(), repo, user2, &files_service.ChangeRepoFilesOptions{
+				NewBranch: "codeowners-docs",
+				Files: []*files_service.ChangeRepoFile{
+					{
+						Operation:     "create",
+						TreePath:      "docs/index.md",
+						ContentReader: strings.NewReader("# Documentation\n"),
+					},
+				},
+			})
+			require.NoError(t, err)
+			testPullCreate(t, session, user2.Name, repo.Name, false, repo.DefaultBranch, "codeowners-docs", "Add documentation")
+			pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo.ID, HeadBranch: "codeowners-docs"})
+
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/pulls/%d/codeowners", link, pr.Index)).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			result := DecodeJSON(t, resp, &api.CodeOwnersFileOwners{})
+			require.Len(t, result.Files, 1)
+			assert.Equal(t, "docs/index.md", result.Files[0].Path)
+			assert.Equal(t, []string{"@user8", "@user4"}, result.Files[0].Owners)
+
+			// the owners are shown on the files of the diff
+			req = NewRequest(t, "GET", fmt.Sprintf("/%s/%s/pulls/%d/files", user2.Name, repo.Name, pr.Index))
+			resp = session.MakeRequest(t, req, http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "@user8 @user4")
+		})
+	})
+}
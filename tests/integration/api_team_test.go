@@ -315,7 +315,7 @@ func TestAPIAddRemoveTeamRepositoryRequiresOrgOwnerOrSetting(t *testing.T) {
 	assert.False(t, org.RepoAdminChangeTeamAccess)
 	targetRepo := unittest.AssertExistsAndLoadBean(t, &repo.Repository{ID: 32, OwnerID: org.ID})
 	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 28})
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), targetRepo, user, perm.AccessModeAdmin))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, targetRepo, user, perm.AccessModeAdmin))
 
 	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWriteOrganization)
 	url := fmt.Sprintf("/api/v1/teams/%d/repos/%s", team.ID, targetRepo.FullName())
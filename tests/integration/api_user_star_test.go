@@ -107,7 +107,7 @@ func TestAPIStarredReposAccessRevoked(t *testing.T) {
 	collaborator := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
 
 	// grant the collaborator read access and star the private repo as them
-	require.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), repo, collaborator, perm.AccessModeRead))
+	require.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, repo, collaborator, perm.AccessModeRead))
 	require.NoError(t, repo_model.StarRepo(t.Context(), collaborator, repo, true))
 
 	token := getUserToken(t, collaborator.Name, auth_model.AccessTokenScopeReadUser, auth_model.AccessTokenScopeReadRepository)
@@ -120,7 +120,7 @@ func TestAPIStarredReposAccessRevoked(t *testing.T) {
 	assert.Equal(t, repo.FullName(), repos[0].FullName)
 
 	// revoke access
-	require.NoError(t, repo_service.DeleteCollaboration(t.Context(), repo, collaborator))
+	require.NoError(t, repo_service.DeleteCollaboration(t.Context(), nil, repo, collaborator))
 
 	// the star record still exists, but the repo (and its metadata) must no longer be returned
 	assert.True(t, repo_model.IsStaring(t.Context(), collaborator.ID, repo.ID))
@@ -231,9 +231,9 @@ func TestAPIStarredReposOmitsInaccessiblePrivateRepos(t *testing.T) {
 	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 40})
 	privateRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
 	assert.True(t, privateRepo.IsPrivate)
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), privateRepo, user, perm.AccessModeRead))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, privateRepo, user, perm.AccessModeRead))
 	assert.NoError(t, repo_model.StarRepo(t.Context(), user, privateRepo, true))
-	assert.NoError(t, repo_service.DeleteCollaboration(t.Context(), privateRepo, user))
+	assert.NoError(t, repo_service.DeleteCollaboration(t.Context(), nil, privateRepo, user))
 
 	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadUser, auth_model.AccessTokenScopeReadRepository)
 	req := NewRequest(t, "GET", "/api/v1/user/starred").AddTokenAuth(token)
@@ -0,0 +1,92 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "gitea.dev/models/auth"
+	"gitea.dev/modules/git/gitcmd"
+	api "gitea.dev/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitPushRules(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+
+		t.Run("SetPushRule", func(t *testing.T) {
+			req := NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/push_rule", &api.SetPushRuleOption{
+				CommitMessagePattern:    "^fix: ",
+				ForbiddenFileExtensions: "exe",
+			}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			rule := DecodeJSON(t, resp, &api.PushRule{})
+			assert.Equal(t, "^fix: ", rule.CommitMessagePattern)
+			assert.Equal(t, "exe", rule.ForbiddenFileExtensions)
+
+			req = NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/push_rule", &api.SetPushRuleOption{
+				CommitMessagePattern: "(",
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/push_rule").AddTokenAuth(token)
+			resp = MakeRequest(t, req, http.StatusOK)
+			rule = DecodeJSON(t, resp, &api.PushRule{})
+			assert.Equal(t, "^fix: ", rule.CommitMessagePattern)
+
+			// only repository admins can see and change the push rule
+			req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/push_rule").
+				AddTokenAuth(getUserToken(t, "user4", auth_model.AccessTokenScopeReadRepository))
+			MakeRequest(t, req, http.StatusForbidden)
+		})
+
+		dstPath := t.TempDir()
+		u.Path = "user2/repo1.git"
+		u.User = url.UserPassword("user2", userPassword)
+		doGitClone(dstPath, u)(t)
+
+		t.Run("PushRejected", func(t *testing.T) {
+			_, err := generateCommitWithNewData(t.Context(), 10, dstPath, "user2@example.com", "User Two", "push-rule-")
+			require.NoError(t, err)
+			commitID, _, runErr := gitcmd.NewCommand("rev-parse", "HEAD").WithDir(dstPath).RunStdString(t.Context())
+			require.NoError(t, runErr)
+
+			_, stderr, runErr := gitcmd.NewCommand("push", "origin", "master").WithDir(dstPath).RunStdString(t.Context())
+			require.Error(t, runErr)
+			assert.Contains(t, stderr, "push to master rejected by push rules")
+			assert.Contains(t, stderr, strings.TrimSpace(commitID)+`: commit message does not match "^fix: "`)
+		})
+
+		t.Run("DeletePushRule", func(t *testing.T) {
+			req := NewRequest(t, "DELETE", "/api/v1/repos/user2/repo1/push_rule").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNoContent)
+			req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/push_rule").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+
+			_, _, runErr := gitcmd.NewCommand("push", "origin", "master").WithDir(dstPath).RunStdString(t.Context())
+			require.NoError(t, runErr)
+		})
+
+		t.Run("OrgPushRule", func(t *testing.T) {
+			orgToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteOrganization)
+
+			req := NewRequestWithJSON(t, "PUT", "/api/v1/orgs/org3/push_rule", &api.SetPushRuleOption{
+				RequireSignedOffBy: true,
+			}).AddTokenAuth(orgToken)
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.True(t, DecodeJSON(t, resp, &api.PushRule{}).RequireSignedOffBy)
+
+			req = NewRequest(t, "DELETE", "/api/v1/orgs/org3/push_rule").AddTokenAuth(orgToken)
+			MakeRequest(t, req, http.StatusNoContent)
+			req = NewRequest(t, "DELETE", "/api/v1/orgs/org3/push_rule").AddTokenAuth(orgToken)
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+}
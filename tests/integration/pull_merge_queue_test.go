@@ -0,0 +1,177 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	git_model "gitea.dev/models/git"
+	issues_model "gitea.dev/models/issues"
+	pull_model "gitea.dev/models/pull"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/commitstatus"
+	"gitea.dev/modules/git"
+	"gitea.dev/services/automerge"
+	commitstatus_service "gitea.dev/services/repository/commitstatus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullMergeQueue(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		session := loginUser(t, "user2")
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerName: "user2", Name: "repo1"})
+
+		prs := make([]*issues_model.PullRequest, 3)
+		for i := range prs {
+			branch := fmt.Sprintf("queued-%d", i+1)
+			testCreateFileInBranch(t, user2, repo, createFileInBranchOptions{OldBranch: "master", NewBranch: branch}, map[string]string{branch + ".txt": branch})
+			testPullCreate(t, session, "user2", "repo1", true, "master", branch, "Merge queue pull "+branch)
+			prs[i] = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo.ID, HeadBranch: branch})
+		}
+		// a pull request stacked on the last one of the queue
+		testCreateFileInBranch(t, user2, repo, createFileInBranchOptions{OldBranch: "queued-3", NewBranch: "stacked"}, map[string]string{"stacked.txt": "stacked"})
+		testPullCreate(t, session, "user2", "repo1", true, "queued-3", "stacked", "Merge queue stacked pull")
+		stacked := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo.ID, HeadBranch: "stacked"})
+
+		req := NewRequestWithValues(t, "POST", "/user2/repo1/settings/branches/edit", map[string]string{
+			"rule_name":             "master",
+			"enable_push":           "true",
+			"enable_status_check":   "true",
+			"status_check_contexts": "ci",
+			"enable_merge_queue":    "true",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		gitRepo, err := git.OpenRepository(t.Context(), repo)
+		require.NoError(t, err)
+		defer gitRepo.Close()
+		baseCommitID, err := gitRepo.GetBranchCommitID(t.Context(), "master")
+		require.NoError(t, err)
+
+		// waitForEntries waits until the entries of the queue are checked by their merge groups, in the order of the pull requests
+		waitForEntries := func(t *testing.T, prs ...*issues_model.PullRequest) []*pull_model.MergeQueueEntry {
+			var entries []*pull_model.MergeQueueEntry
+			require.Eventually(t, func() bool {
+				var err error
+				entries, err = pull_model.GetMergeQueueEntries(t.Context(), repo.ID, "master")
+				if err != nil || len(entries) != len(prs) {
+					return false
+				}
+				for i, entry := range entries {
+					if entry.PullID != prs[i].ID || entry.Status != pull_model.MergeQueueEntryStatusChecking {
+						return false
+					}
+				}
+				return true
+			}, 30*time.Second, 100*time.Millisecond)
+			return entries
+		}
+		setStatus := func(t *testing.T, commitID string, state commitstatus.CommitStatusState) {
+			require.NoError(t, commitstatus_service.CreateCommitStatus(t.Context(), repo, user2, commitID, &git_model.CommitStatus{
+				State:   state,
+				Context: "ci",
+			}))
+		}
+		assertMergeGroups := func(t *testing.T, entries []*pull_model.MergeQueueEntry) {
+			parentCommitID := baseCommitID
+			for _, entry := range entries {
+				assert.Equal(t, parentCommitID, entry.BaseCommitID)
+				refCommitID, err := gitRepo.GetRefCommitID(t.Context(), entry.GetRefName())
+				require.NoError(t, err)
+				assert.Equal(t, entry.MergeCommitID, refCommitID)
+				parentCommitID = entry.MergeCommitID
+			}
+		}
+
+		for _, pr := range prs {
+			require.NoError(t, automerge.AddToMergeQueue(t.Context(), user2, pr, repo_model.MergeStyleMerge, "", false))
+		}
+
+		var entries []*pull_model.MergeQueueEntry
+		t.Run("SpeculativeMerge", func(t *testing.T) {
+			entries = waitForEntries(t, prs...)
+			assertMergeGroups(t, entries)
+
+			// the last merge group contains all pull requests of the queue, the base branch isn't changed
+			commit, err := gitRepo.GetCommit(t.Context(), entries[2].MergeCommitID)
+			require.NoError(t, err)
+			for i := range prs {
+				_, err := commit.GetTreeEntryByPath(t.Context(), gitRepo, fmt.Sprintf("queued-%d.txt", i+1))
+				assert.NoError(t, err)
+			}
+			commitID, err := gitRepo.GetBranchCommitID(t.Context(), "master")
+			require.NoError(t, err)
+			assert.Equal(t, baseCommitID, commitID)
+		})
+
+		t.Run("PushMergeQueueRef", func(t *testing.T) {
+			dstPath := t.TempDir()
+			u.Path = "user2/repo1.git"
+			u.User = url.UserPassword("user2", userPassword)
+			t.Run("Clone", doGitClone(dstPath, u))
+			t.Run("Push", doGitPushTestRepositoryFail(dstPath, "origin", "+HEAD:"+entries[0].GetRefName()))
+
+			refCommitID, err := gitRepo.GetRefCommitID(t.Context(), entries[0].GetRefName())
+			require.NoError(t, err)
+			assert.Equal(t, entries[0].MergeCommitID, refCommitID)
+		})
+
+		t.Run("FailedCheck", func(t *testing.T) {
+			setStatus(t, entries[0].MergeCommitID, commitstatus.CommitStatusFailure)
+
+			// the failed entry is dropped and the merge groups behind it are rebuilt without it
+			rebuilt := waitForEntries(t, prs[1], prs[2])
+			assertMergeGroups(t, rebuilt)
+			assert.NotEqual(t, entries[1].MergeCommitID, rebuilt[0].MergeCommitID)
+			assert.False(t, git.IsReferenceExist(t.Context(), repo, entries[0].GetRefName()))
+
+			failed, err := pull_model.GetMergeQueueEntryByPullID(t.Context(), prs[0].ID)
+			require.NoError(t, err)
+			assert.Equal(t, pull_model.MergeQueueEntryStatusFailed, failed.Status)
+			assert.NotEmpty(t, failed.FailReason)
+			entries = rebuilt
+		})
+
+		t.Run("Land", func(t *testing.T) {
+			// a passed merge group lands all entries before it
+			setStatus(t, entries[1].MergeCommitID, commitstatus.CommitStatusSuccess)
+			require.Eventually(t, func() bool {
+				pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prs[2].ID})
+				return pr.HasMerged
+			}, 30*time.Second, 100*time.Millisecond)
+
+			commitID, err := gitRepo.GetBranchCommitID(t.Context(), "master")
+			require.NoError(t, err)
+			assert.Equal(t, entries[1].MergeCommitID, commitID)
+
+			pr1 := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prs[1].ID})
+			assert.True(t, pr1.HasMerged)
+			assert.Equal(t, entries[0].MergeCommitID, pr1.MergedCommitID)
+			pr0 := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prs[0].ID})
+			assert.False(t, pr0.HasMerged)
+
+			// the pull request stacked on a landed one is retargeted to the base branch
+			assert.Eventually(t, func() bool {
+				pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: stacked.ID})
+				return pr.BaseBranch == "master"
+			}, 30*time.Second, 100*time.Millisecond)
+
+			remaining, err := pull_model.GetMergeQueueEntries(t.Context(), repo.ID, "master")
+			require.NoError(t, err)
+			assert.Empty(t, remaining)
+			for _, entry := range entries {
+				assert.False(t, git.IsReferenceExist(t.Context(), repo, entry.GetRefName()))
+			}
+		})
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	auth_model "gitea.dev/models/auth"
+	issues_model "gitea.dev/models/issues"
+	repo_model "gitea.dev/models/repo"
+	"gitea.dev/models/unittest"
+	user_model "gitea.dev/models/user"
+	"gitea.dev/modules/git"
+	api "gitea.dev/modules/structs"
+	"gitea.dev/services/forms"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullStack(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		session := loginUser(t, "user2")
+		ctx := NewAPITestContext(t, "user2", "repo1", auth_model.AccessTokenScopeWriteRepository)
+
+		testCreateFileInBranch(t, user2, repo1, createFileInBranchOptions{OldBranch: "master", NewBranch: "stack-a"}, map[string]string{"stack-a.txt": "a"})
+		testCreateFileInBranch(t, user2, repo1, createFileInBranchOptions{OldBranch: "stack-a", NewBranch: "stack-b"}, map[string]string{"stack-b.txt": "b"})
+
+		prA, err := doAPICreatePullRequest(ctx, "user2", "repo1", "master", "stack-a")(t)
+		require.NoError(t, err)
+		prB, err := doAPICreatePullRequest(ctx, "user2", "repo1", "stack-a", "stack-b")(t)
+		require.NoError(t, err)
+
+		t.Run("ShowStack", func(t *testing.T) {
+			req := NewRequest(t, "GET", fmt.Sprintf("/user2/repo1/pulls/%d", prB.Index))
+			htmlDoc := NewHTMLParser(t, session.MakeRequest(t, req, http.StatusOK).Body)
+			items := htmlDoc.Find(".pull-stack .item")
+			require.Equal(t, 2, items.Length())
+			assert.Contains(t, items.Eq(0).Find("a").AttrOr("href", ""), fmt.Sprintf("/pulls/%d", prA.Index))
+			assert.Contains(t, items.Eq(1).Find("a").AttrOr("href", ""), fmt.Sprintf("/pulls/%d", prB.Index))
+		})
+
+		t.Run("MergeBlockedByParent", func(t *testing.T) {
+			var apiErr api.APIError
+			assert.Eventually(t, func() bool {
+				req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/user2/repo1/pulls/%d/merge", prB.Index), &forms.MergePullRequestForm{
+					Do: string(repo_model.MergeStyleMerge),
+				}).AddTokenAuth(ctx.Token)
+				resp := MakeRequest(t, req, http.StatusMethodNotAllowed)
+				apiErr = api.APIError{}
+				DecodeJSON(t, resp, &apiErr)
+				return apiErr.Message != "Please try again later"
+			}, 5*time.Second, 100*time.Millisecond)
+			assert.Equal(t, "The PR is stacked on a PR which has not been merged yet", apiErr.Message)
+		})
+
+		t.Run("RetargetAfterParentMerged", func(t *testing.T) {
+			doAPIMergePullRequest(ctx, "user2", "repo1", prA.Index)(t)
+
+			pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prB.ID})
+			assert.Equal(t, "master", pr.BaseBranch)
+			assert.False(t, pr.HasMerged)
+
+			// the head branch has been updated with the merge commit of its former parent
+			diffCount, err := git.GetDivergingCommits(t.Context(), repo1, "master", "stack-b")
+			require.NoError(t, err)
+			assert.Equal(t, 0, diffCount.Behind)
+
+			doAPIMergePullRequest(ctx, "user2", "repo1", prB.Index)(t)
+		})
+	})
+}
@@ -111,6 +111,7 @@ func PrepareCleanPackageData(t testing.TB) {
 		&packages_model.PackageProperty{},
 		&packages_model.PackageBlobUpload{},
 		&packages_model.PackageCleanupRule{},
+		&packages_model.PackageUpstream{},
 	))
 	assert.NoError(t, storage.Clean(storage.Packages))
 }
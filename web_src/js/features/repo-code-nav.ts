@@ -0,0 +1,110 @@
+import {GET} from '../modules/fetch.ts';
+import {createTippy} from '../modules/tippy.ts';
+import {addDelegatedEventListener, createElementFromHTML} from '../utils/dom.ts';
+import type {Instance} from 'tippy.js';
+
+type CodePosition = {
+  url: string,
+  ref: string,
+  path: string,
+  line: number,
+};
+
+const identifierCharRegex = /[\p{L}\p{N}_$]/u;
+
+let popupInstance: Instance | null = null;
+
+function caretFromPoint(x: number, y: number): {node: Node, offset: number} | null {
+  if (document.caretPositionFromPoint) {
+    const pos = document.caretPositionFromPoint(x, y);
+    return pos ? {node: pos.offsetNode, offset: pos.offset} : null;
+  }
+  // Safari doesn't support caretPositionFromPoint
+  const range = document.caretRangeFromPoint?.(x, y);
+  return range ? {node: range.startContainer, offset: range.startOffset} : null;
+}
+
+// the file view has a single file and commit, a diff has the old and the new version of every file
+function getCodePosition(cell: HTMLElement): CodePosition | null {
+  const fileTable = cell.closest<HTMLElement>('table[data-code-nav-url]');
+  if (fileTable) {
+    return {
+      url: fileTable.getAttribute('data-code-nav-url')!,
+      ref: fileTable.getAttribute('data-code-nav-ref')!,
+      path: fileTable.getAttribute('data-code-nav-path')!,
+      line: parseInt(cell.getAttribute('rel')?.substring(1) ?? ''),
+    };
+  }
+
+  const diffBoxes = cell.closest<HTMLElement>('#diff-file-boxes[data-code-nav-url]');
+  const diffBox = cell.closest<HTMLElement>('.diff-file-box');
+  if (!diffBoxes || !diffBox) return null;
+  const isOld = cell.classList.contains('lines-code-old');
+  const lineNum = cell.closest('tr')?.querySelector(isOld ? '.lines-num-old' : '.lines-num-new')?.getAttribute('data-line-num');
+  const ref = diffBoxes.getAttribute(isOld ? 'data-code-nav-before' : 'data-code-nav-after');
+  const path = diffBox.getAttribute(isOld ? 'data-old-filename' : 'data-new-filename');
+  if (!lineNum || !ref || !path) return null;
+  return {url: diffBoxes.getAttribute('data-code-nav-url')!, ref, path, line: parseInt(lineNum)};
+}
+
+async function showCodeNavPopup(codeInner: HTMLElement, e: MouseEvent) {
+  // don't get in the way of selecting code
+  if (!window.getSelection()?.isCollapsed) return;
+  const caret = caretFromPoint(e.clientX, e.clientY);
+  if (!caret || caret.node.nodeType !== Node.TEXT_NODE || !codeInner.contains(caret.node)) return;
+
+  const text = caret.node.textContent!;
+  let start = caret.offset, end = caret.offset;
+  while (start > 0 && identifierCharRegex.test(text[start - 1])) start--;
+  while (end < text.length && identifierCharRegex.test(text[end])) end++;
+  if (start === end) return;
+
+  const pos = getCodePosition(codeInner.closest<HTMLElement>('.lines-code')!);
+  if (!pos || !pos.line) return;
+
+  const wordRange = document.createRange();
+  wordRange.setStart(caret.node, start);
+  wordRange.setEnd(caret.node, end);
+  // the length of JavaScript strings is counted in UTF-16 code units, like the character offsets of the server
+  const prefixRange = document.createRange();
+  prefixRange.setStart(codeInner, 0);
+  prefixRange.setEnd(caret.node, start);
+
+  const params = new URLSearchParams({
+    ref: pos.ref,
+    path: pos.path,
+    line: String(pos.line),
+    character: String(prefixRange.toString().length),
+    word: wordRange.toString(),
+  });
+  const resp = await GET(`${pos.url}?${params}`);
+  if (!resp.ok) throw new Error(resp.statusText || 'Unknown network error');
+
+  popupInstance?.destroy();
+  popupInstance = createTippy(codeInner, {
+    theme: 'default',
+    content: createElementFromHTML(await resp.text()),
+    trigger: 'manual',
+    placement: 'bottom-start',
+    interactive: true,
+    role: 'dialog',
+    hideOnClick: true,
+    getReferenceClientRect: () => wordRange.getBoundingClientRect(),
+    onHidden: (instance) => {
+      instance.destroy();
+      if (popupInstance === instance) popupInstance = null;
+    },
+  });
+  popupInstance.show();
+}
+
+export function initRepoCodeNavigation() {
+  const selector = '[data-code-nav-url] .lines-code .code-inner';
+  addDelegatedEventListener<HTMLElement, MouseEvent>(document, 'click', selector, async (codeInner, e) => {
+    try {
+      await showCodeNavPopup(codeInner, e);
+    } catch (err) {
+      console.error('Failed to load code navigation:', err);
+    }
+  });
+}
@@ -18,6 +18,7 @@ import {fomanticQuery} from '../modules/fomantic/base.ts';
 import {showFomanticModal} from '../modules/fomantic/modal.ts';
 import {ignoreAreYouSure} from '../vendor/jquery.are-you-sure.ts';
 import {registerGlobalInitFunc} from '../modules/observer.ts';
+import {initDropzone} from './dropzone.ts';
 
 const {appSubUrl} = window.config;
 
@@ -517,4 +518,8 @@ export function initRepoCommentFormAndSidebar() {
     // it's quite unclear about the "comment form" elements, sometimes it's for issue comment, sometimes it's for file editor/uploader message
     initSingleCommentEditor(commentForm);
   }
+
+  for (const el of commentForm.querySelectorAll<HTMLElement>('.issue-form-file-field .dropzone')) {
+    initDropzone(el);
+  }
 }